@@ -17,6 +17,7 @@ package alpha
 import (
 	"context"
 
+	"github.com/GoogleContainerTools/kpt/commands/alpha/composite"
 	"github.com/GoogleContainerTools/kpt/commands/alpha/license"
 	"github.com/GoogleContainerTools/kpt/commands/alpha/live"
 	"github.com/GoogleContainerTools/kpt/commands/alpha/rollouts"
@@ -47,6 +48,7 @@ func GetCommand(ctx context.Context, _, version string) *cobra.Command {
 
 	alpha.AddCommand(
 		wasm.NewCommand(ctx, version),
+		composite.NewCommand(ctx, version),
 		live.GetCommand(ctx, "", version),
 		license.NewCommand(ctx, version),
 		rollouts.NewCommand(ctx, version),
@@ -0,0 +1,37 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"context"
+
+	"github.com/GoogleContainerTools/kpt/commands/alpha/composite/push"
+	"github.com/GoogleContainerTools/kpt/internal/docs/generated/compositedocs"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(ctx context.Context, _ string) *cobra.Command {
+	compositeCmd := &cobra.Command{
+		Use:   "composite",
+		Short: "[Alpha] " + compositedocs.CompositeShort,
+		Long:  "[Alpha] " + compositedocs.CompositeShort + "\n" + compositedocs.CompositeLong,
+	}
+
+	compositeCmd.AddCommand(
+		push.NewCommand(ctx),
+	)
+
+	return compositeCmd
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleContainerTools/kpt/internal/docs/generated/compositedocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/pkg/composite"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	command = "cmdcompositepush"
+)
+
+func newRunner(ctx context.Context) *runner {
+	r := &runner{
+		ctx: ctx,
+	}
+	c := &cobra.Command{
+		Use:     "push LOCAL_PATH IMAGE",
+		Short:   compositedocs.PushShort,
+		Long:    compositedocs.PushShort + "\n" + compositedocs.PushLong,
+		Example: compositedocs.PushExamples,
+		Args:    cobra.ExactArgs(2),
+		RunE:    r.runE,
+	}
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context) *cobra.Command {
+	return newRunner(ctx).Command
+}
+
+type runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+	client  *composite.Client
+}
+
+func (r *runner) runE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".runE"
+
+	if r.client == nil {
+		var err error
+		r.client, err = composite.NewClient(filepath.Join(os.TempDir(), "kpt"))
+		if err != nil {
+			return errors.E(op, err)
+		}
+	}
+
+	digest, err := r.client.Push(r.ctx, args[0], args[1])
+	if err != nil {
+		return errors.E(op, err)
+	}
+	printer.FromContextOrDie(r.ctx).Printf("composite function has been pushed to %s@%s\n", args[1], digest)
+	return nil
+}
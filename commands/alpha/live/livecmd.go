@@ -18,8 +18,8 @@ import (
 	"context"
 	"os"
 
-	"github.com/GoogleContainerTools/kpt/commands/alpha/live/plan"
 	"github.com/GoogleContainerTools/kpt/commands/live/apply"
+	"github.com/GoogleContainerTools/kpt/commands/live/plan"
 	"github.com/GoogleContainerTools/kpt/commands/util"
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
 	"github.com/spf13/cobra"
@@ -39,10 +39,11 @@ func GetCommand(ctx context.Context, _, version string) *cobra.Command {
 		ErrOut: os.Stderr,
 	}
 
-	f := util.NewFactory(liveCmd, version)
+	f, contextFactory := util.NewFactoryWithContexts(liveCmd, version)
 
-	applyCmd := apply.NewCommand(ctx, f, ioStreams, true)
-	liveCmd.AddCommand(applyCmd)
+	applyRunner := apply.NewRunner(ctx, f, ioStreams, true)
+	applyRunner.ContextFactory = contextFactory
+	liveCmd.AddCommand(applyRunner.Command)
 
 	planCmd := plan.NewCommand(ctx, f, ioStreams)
 	liveCmd.AddCommand(planCmd)
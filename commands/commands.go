@@ -19,9 +19,13 @@ import (
 	"strings"
 
 	"github.com/GoogleContainerTools/kpt/commands/alpha"
+	"github.com/GoogleContainerTools/kpt/commands/explainerror"
 	"github.com/GoogleContainerTools/kpt/commands/fn"
 	"github.com/GoogleContainerTools/kpt/commands/live"
 	"github.com/GoogleContainerTools/kpt/commands/pkg"
+	"github.com/GoogleContainerTools/kpt/commands/search"
+	"github.com/GoogleContainerTools/kpt/commands/stats"
+	"github.com/GoogleContainerTools/kpt/commands/ws"
 	"github.com/spf13/cobra"
 )
 
@@ -39,9 +43,13 @@ func GetKptCommands(ctx context.Context, name, version string) []*cobra.Command
 	fnCmd := fn.GetCommand(ctx, name)
 	pkgCmd := pkg.GetCommand(ctx, name)
 	liveCmd := live.GetCommand(ctx, name, version)
+	wsCmd := ws.GetCommand(ctx, name)
 	alphaCmd := alpha.GetCommand(ctx, name, version)
+	explainErrorCmd := explainerror.NewCommand(ctx, name)
+	statsCmd := stats.NewCommand(ctx, name)
+	searchCmd := search.NewCommand(ctx, version)
 
-	c = append(c, pkgCmd, fnCmd, liveCmd, alphaCmd)
+	c = append(c, pkgCmd, fnCmd, liveCmd, wsCmd, alphaCmd, explainErrorCmd, statsCmd, searchCmd)
 
 	// apply cross-cutting issues to commands
 	NormalizeCommand(c...)
@@ -0,0 +1,80 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explainerror
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/errors/resolver"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{ctx: ctx}
+	c := &cobra.Command{
+		Use:   "explain-error [CODE]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Explain an error code",
+		Long: `Explain an error code
+
+Every error of kpt has an error code, like KPT2001, which is printed with the
+error, and is part of the error with --error-format=json. explain-error prints
+what the error means and how to fix it. Without a code, it lists all error
+codes.
+`,
+		Example: `
+  # explain the error code KPT2001
+  $ kpt explain-error KPT2001
+
+  # list all error codes
+  $ kpt explain-error
+`,
+		RunE: r.runE,
+	}
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function for the explain-error command.
+type Runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+}
+
+func (r *Runner) runE(c *cobra.Command, args []string) error {
+	const op errors.Op = "cmdexplainerror.runE"
+	out := c.OutOrStdout()
+	if len(args) == 0 {
+		for _, e := range resolver.Catalog() {
+			fmt.Fprintf(out, "%s\t%s\n", e.Code, e.Title)
+		}
+		return nil
+	}
+	e, found := resolver.Explain(args[0])
+	if !found {
+		return errors.E(op, fmt.Errorf("unknown error code %q", args[0]))
+	}
+	fmt.Fprintf(out, "%s: %s\n\n%s\n", e.Code, e.Title, e.Remediation)
+	return nil
+}
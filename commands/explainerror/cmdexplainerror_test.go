@@ -0,0 +1,69 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explainerror
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmd(t *testing.T) {
+	testCases := map[string]struct {
+		args           []string
+		expectedOutput string
+		expectedErrMsg string
+	}{
+		"explain a code": {
+			args: []string{"KPT1002"},
+			expectedOutput: `KPT1002: Git executable not found
+
+kpt requires git. Install git and make sure it is available in the PATH.
+`,
+		},
+		"list the codes": {
+			expectedOutput: "KPT0001\tUnclassified error\n",
+		},
+		"unknown code": {
+			args:           []string{"KPT9999"},
+			expectedErrMsg: `unknown error code "KPT9999"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			r := NewRunner(context.Background(), "kpt")
+			var out bytes.Buffer
+			r.Command.SetArgs(tc.args)
+			r.Command.SetOut(&out)
+			r.Command.SilenceUsage = true
+			r.Command.SilenceErrors = true
+			err := r.Command.Execute()
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			if len(tc.args) == 0 {
+				assert.True(t, strings.HasPrefix(out.String(), tc.expectedOutput))
+				return
+			}
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
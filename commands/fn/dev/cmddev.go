@@ -0,0 +1,368 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"bytes"
+	"context"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/fndocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/google/shlex"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{ctx: ctx}
+	c := &cobra.Command{
+		Use:               "dev [PKG_PATH] --exec EXEC [flags]",
+		Args:              cobra.MaximumNArgs(1),
+		Short:             docs.DevShort,
+		Long:              docs.DevShort + "\n" + docs.DevLong,
+		Example:           docs.DevExamples,
+		PreRunE:           r.preRunE,
+		RunE:              r.runE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+	}
+	c.Flags().StringVar(&r.exec, "exec", "",
+		"command running the function under development. A single `.go` file is run with `go run`.")
+	c.Flags().StringVar(&r.fnConfigPath, "fn-config", "",
+		"path to the function config file.")
+	c.Flags().StringArrayVar(&r.watchPaths, "watch", []string{},
+		"file or directory of the function source to watch, in addition to the package. Can be repeated.")
+	c.Flags().DurationVar(&r.interval, "interval", 500*time.Millisecond,
+		"how often the watched files are checked for changes.")
+	c.Flags().BoolVar(&r.once, "once", false,
+		"run the function once and exit, instead of watching for changes.")
+	_ = c.MarkFlagRequired("exec")
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+
+	pkgPath      string
+	exec         string
+	fnConfigPath string
+	watchPaths   []string
+	interval     time.Duration
+	once         bool
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmddev.preRunE"
+	r.pkgPath = "."
+	if len(args) > 0 {
+		r.pkgPath = args[0]
+	}
+	var err error
+	if r.pkgPath, err = argutil.ResolveSymlink(r.ctx, r.pkgPath); err != nil {
+		return errors.E(op, err)
+	}
+	if r.pkgPath, err = filepath.Abs(r.pkgPath); err != nil {
+		return errors.E(op, err)
+	}
+	if r.interval <= 0 {
+		return errors.E(op, fmt.Errorf("--interval must be positive"))
+	}
+	r.exec = execCommand(r.exec)
+	// the source of a local function is watched without being listed.
+	if s, err := shlex.Split(r.exec); err == nil {
+		for _, arg := range s {
+			if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+				r.watchPaths = append(r.watchPaths, filepath.Dir(arg))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// execCommand returns the command running exec. A Go source file is run
+// with `go run`.
+func execCommand(exec string) string {
+	if strings.HasSuffix(exec, ".go") && !strings.ContainsAny(exec, " \t") {
+		return "go run " + exec
+	}
+	return exec
+}
+
+func (r *Runner) runE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmddev.runE"
+	pr := printer.FromContextOrDie(r.ctx)
+	if r.once {
+		if err := r.run(); err != nil {
+			return errors.E(op, types.UniquePath(r.pkgPath), err)
+		}
+		return nil
+	}
+
+	paths := append([]string{r.pkgPath}, r.watchPaths...)
+	pr.Printf("Watching %s for changes. Press Ctrl+C to stop.\n", strings.Join(paths, ", "))
+	last, err := snapshot(paths)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.runAndReport()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		current, err := snapshot(paths)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		if changed := changedPath(last, current); changed != "" {
+			pr.Printf("\n[%s] %s changed, running the function again.\n",
+				time.Now().Format(time.TimeOnly), changed)
+			r.runAndReport()
+			// the files changed while the function ran are picked up by the
+			// next check.
+			last = current
+		}
+	}
+}
+
+// runAndReport runs the function and prints the error it failed with, so
+// that watching continues after a failed run.
+func (r *Runner) runAndReport() {
+	if err := r.run(); err != nil && !goerrors.Is(err, errors.ErrAlreadyHandled) {
+		printer.FromContextOrDie(r.ctx).Printf("error: %v\n", err)
+	}
+}
+
+// run runs the function on the resources of the package and prints the
+// diff between the resources and the output of the function. The package
+// is not modified.
+func (r *Runner) run() error {
+	out := printer.FromContextOrDie(r.ctx).OutStream()
+	reader := &kio.LocalPackageReader{
+		PackagePath:        r.pkgPath,
+		PackageFileName:    kptfilev1.KptFileName,
+		IncludeSubpackages: true,
+		MatchFilesGlob:     pkg.MatchAllKRM,
+		PreserveSeqIndent:  true,
+		WrapBareSeqNode:    true,
+	}
+	input, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	before, err := filesContent(input)
+	if err != nil {
+		return err
+	}
+
+	fsys := filesys.FileSystemOrOnDisk{}
+	fn := &kptfilev1.Function{Exec: r.exec}
+	runner, err := fnruntime.NewRunner(r.ctx, fsys, fn, types.UniquePath(r.pkgPath),
+		fnresult.NewResultList(), fnruntime.RunnerOptions{}, nil)
+	if err != nil {
+		return err
+	}
+	if r.fnConfigPath != "" {
+		b, err := os.ReadFile(r.fnConfigPath)
+		if err != nil {
+			return err
+		}
+		fnConfig, err := yaml.Parse(string(b))
+		if err != nil {
+			return fmt.Errorf("invalid function config %q: %w", r.fnConfigPath, err)
+		}
+		runner.SetFnConfig(fnConfig)
+	}
+	output, err := runner.Filter(input)
+	if err != nil {
+		return err
+	}
+	after, err := filesContent(output)
+	if err != nil {
+		return err
+	}
+	return writeDiff(out, before, after)
+}
+
+// filesContent returns the content of the files of nodes, by path. The
+// resources without a path are returned with an empty path.
+func filesContent(nodes []*yaml.RNode) (map[string]string, error) {
+	byPath := map[string][]*yaml.RNode{}
+	for _, n := range nodes {
+		path, _, _ := kioutil.GetFileAnnotations(n)
+		byPath[path] = append(byPath[path], n.Copy())
+	}
+	content := map[string]string{}
+	for path, nodes := range byPath {
+		var b bytes.Buffer
+		w := &kio.ByteWriter{
+			Writer: &b,
+			ClearAnnotations: []string{
+				kioutil.PathAnnotation, kioutil.IndexAnnotation, kioutil.IdAnnotation,
+				kioutil.LegacyPathAnnotation, kioutil.LegacyIndexAnnotation, kioutil.LegacyIdAnnotation,
+				kioutil.SeqIndentAnnotation,
+			},
+		}
+		if err := w.Write(nodes); err != nil {
+			return nil, err
+		}
+		content[path] = b.String()
+	}
+	return content, nil
+}
+
+// writeDiff writes the unified diff between the files before and after
+// to w.
+func writeDiff(w io.Writer, before, after map[string]string) error {
+	paths := map[string]bool{}
+	for p := range before {
+		paths[p] = true
+	}
+	for p := range after {
+		paths[p] = true
+	}
+	var sorted []string
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	changed := 0
+	for _, p := range sorted {
+		if before[p] == after[p] {
+			continue
+		}
+		changed++
+		name := filepath.ToSlash(p)
+		if name == "" {
+			name = "(no path)"
+		}
+		from, to := "a/"+name, "b/"+name
+		if _, found := before[p]; !found {
+			from = "/dev/null"
+		}
+		if _, found := after[p]; !found {
+			to = "/dev/null"
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(before[p]),
+			B:        splitLines(after[p]),
+			FromFile: from,
+			ToFile:   to,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, diff)
+	}
+	if changed == 0 {
+		fmt.Fprintln(w, "The function didn't change any resources.")
+	}
+	return nil
+}
+
+// splitLines splits s into lines that keep their line ending.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// fileStamp identifies the version of a file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshot returns the stamps of the files in paths. Git metadata is
+// skipped.
+func snapshot(paths []string) (map[string]fileStamp, error) {
+	stamps := map[string]fileStamp{}
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if info.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stamps, nil
+}
+
+// changedPath returns a file that was added, changed or removed between
+// the snapshots a and b, or an empty string if there is none.
+func changedPath(a, b map[string]fileStamp) string {
+	var changed []string
+	for path, s := range b {
+		if as, found := a[path]; !found || !as.modTime.Equal(s.modTime) || as.size != s.size {
+			changed = append(changed, path)
+		}
+	}
+	for path := range a {
+		if _, found := b[path]; !found {
+			changed = append(changed, path)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+	sort.Strings(changed)
+	return changed[0]
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd_once(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"fn/fn.sh": "#!/bin/sh\nsed -e 's/count: \"1\"/count: \"2\"/'\n",
+		"pkg/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+`,
+		"pkg/cm.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  count: "1"
+`,
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0700))
+	}
+
+	var out bytes.Buffer
+	r := NewRunner(fake.CtxWithPrinter(&out, &bytes.Buffer{}), "kpt")
+	r.Command.SetArgs([]string{filepath.Join(dir, "pkg"), "--exec", filepath.Join(dir, "fn", "fn.sh"), "--once"})
+	require.NoError(t, r.Command.Execute())
+
+	assert.Equal(t, `--- a/cm.yaml
++++ b/cm.yaml
+@@ -3,4 +3,4 @@
+ metadata:
+   name: cm
+ data:
+-  count: "1"
++  count: "2"
+`, out.String())
+	assert.Equal(t, []string{filepath.Join(dir, "fn")}, r.watchPaths)
+
+	// the package is not modified.
+	b, err := os.ReadFile(filepath.Join(dir, "pkg", "cm.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, files["pkg/cm.yaml"], string(b))
+}
+
+func TestExecCommand(t *testing.T) {
+	assert.Equal(t, "go run ./main.go", execCommand("./main.go"))
+	assert.Equal(t, "go run ./cmd/main.go", execCommand("go run ./cmd/main.go"))
+	assert.Equal(t, "./fn.star", execCommand("./fn.star"))
+}
+
+func TestChangedPath(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.yaml")
+	require.NoError(t, os.WriteFile(a, []byte("a"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0700))
+
+	first, err := snapshot([]string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, "", changedPath(first, first))
+
+	// changes in git metadata are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref"), 0600))
+	second, err := snapshot([]string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, "", changedPath(first, second))
+
+	require.NoError(t, os.Chtimes(a, time.Now(), time.Now().Add(time.Minute)))
+	third, err := snapshot([]string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, a, changedPath(second, third))
+
+	b := filepath.Join(dir, "b.yaml")
+	require.NoError(t, os.WriteFile(b, []byte("b"), 0600))
+	fourth, err := snapshot([]string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, b, changedPath(third, fourth))
+	assert.Equal(t, b, changedPath(fourth, third))
+}
@@ -19,7 +19,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
 
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/fndocs"
@@ -76,8 +75,8 @@ func (r *Runner) runE(c *cobra.Command, _ []string) error {
 		image,
 		"--help",
 	}
-	// If the env var is empty, stringToContainerRuntime defaults it to docker.
-	runtime, err := fnruntime.StringToContainerRuntime(os.Getenv(fnruntime.ContainerRuntimeEnv))
+	// If the env var is empty, the first available runtime is used.
+	runtime, err := fnruntime.ResolveContainerRuntime()
 	if err != nil {
 		return err
 	}
@@ -17,8 +17,11 @@ package fn
 import (
 	"context"
 
+	"github.com/GoogleContainerTools/kpt/commands/fn/dev"
 	"github.com/GoogleContainerTools/kpt/commands/fn/doc"
 	"github.com/GoogleContainerTools/kpt/commands/fn/render"
+	"github.com/GoogleContainerTools/kpt/commands/fn/runtimecheck"
+	"github.com/GoogleContainerTools/kpt/commands/fn/serve"
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/fndocs"
 	"github.com/GoogleContainerTools/kpt/thirdparty/cmdconfig/commands/cmdeval"
 	"github.com/GoogleContainerTools/kpt/thirdparty/cmdconfig/commands/cmdsink"
@@ -50,6 +53,9 @@ func GetCommand(ctx context.Context, name string) *cobra.Command {
 		doc.NewCommand(ctx, name),
 		cmdsource.NewCommand(ctx, name),
 		cmdsink.NewCommand(ctx, name),
+		serve.NewCommand(ctx, name),
+		runtimecheck.NewCommand(ctx, name),
+		dev.NewCommand(ctx, name),
 	)
 	return functions
 }
@@ -21,11 +21,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/fndocs"
 	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
 	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/external"
 	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/render"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
@@ -39,15 +42,24 @@ func NewRunner(ctx context.Context, parent string) *Runner {
 	r.InitDefaults()
 
 	c := &cobra.Command{
-		Use:     "render [PKG_PATH] [flags]",
-		Short:   docs.RenderShort,
-		Long:    docs.RenderShort + "\n" + docs.RenderLong,
-		Example: docs.RenderExamples,
-		RunE:    r.runE,
-		PreRunE: r.preRunE,
+		Use:               "render [PKG_PATH] [flags]",
+		Short:             docs.RenderShort,
+		Long:              docs.RenderShort + "\n" + docs.RenderLong,
+		Example:           docs.RenderExamples,
+		RunE:              r.runE,
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
 	}
 	c.Flags().StringVar(&r.resultsDirPath, "results-dir", "",
 		"path to a directory to save function results")
+	c.Flags().StringVar(&r.statusFilePath, "status-file", "",
+		"path to a file to save the render status, which records the resources changed by every mutator")
+	c.Flags().StringVar(&r.referenceGraphPath, "reference-graph", "",
+		"path to a file to save the reference graph, which records the references between the rendered resources")
+	c.Flags().BoolVar(&r.annotateGenerated, "annotate-generated", false,
+		"mark the resources generated by functions with the `kpt.dev/generated-by` annotation.")
+	c.Flags().BoolVar(&r.verifyIdempotent, "verify-idempotent", false,
+		"render the package a second time and fail if the second render changes any resources.")
 	c.Flags().StringVarP(&r.dest, "output", "o", "",
 		fmt.Sprintf("output resources are written to provided location. Allowed values: %s|%s|<OUT_DIR_PATH>", cmdutil.Stdout, cmdutil.Unwrap))
 
@@ -61,8 +73,35 @@ func NewRunner(ctx context.Context, parent string) *Runner {
 		"allow binary executable to be run during pipeline execution.")
 	c.Flags().BoolVar(&r.RunnerOptions.AllowNetwork, "allow-network", false,
 		"allow functions to access network during pipeline execution.")
+	c.Flags().BoolVar(&r.RunnerOptions.AllowMount, "allow-mount", false,
+		"allow functions to mount the storage declared in their `mounts` field.")
 	c.Flags().BoolVar(&r.RunnerOptions.AllowWasm, "allow-alpha-wasm", r.RunnerOptions.AllowWasm,
 		"allow wasm to be used during pipeline execution.")
+	c.Flags().StringArrayVarP(&r.env, "env", "e", []string{},
+		"environment variable that functions may receive, if they list it in their `env` field. "+
+			"Specified as `KEY=VALUE`, or `KEY` to take the value from the environment.")
+	c.Flags().StringVar(&r.envFile, "env-file", "",
+		"path to a file with environment variables that functions may receive, one `KEY=VALUE` or `KEY` per line.")
+	c.Flags().StringArrayVar(&r.addMutators, "add-mutator", []string{},
+		"mutator appended to the pipeline of the package for this render only. "+
+			"Specified as `image=IMAGE,config=CONFIG_PATH`, or with `exec=EXEC` instead of `image`, and an optional `name=NAME`.")
+	c.Flags().StringArrayVar(&r.addValidators, "add-validator", []string{},
+		"validator appended to the pipeline of the package for this render only. Specified like `--add-mutator`.")
+	c.Flags().StringArrayVar(&r.skipMutators, "skip-mutator", []string{},
+		"name, image, executable or composite of a mutator of the pipeline of the package to skip for this render only.")
+	c.Flags().StringArrayVar(&r.skipValidators, "skip-validator", []string{},
+		"name, image, executable or composite of a validator of the pipeline of the package to skip for this render only.")
+	c.Flags().StringSliceVar(&r.resolveExternal, "resolve-external", []string{},
+		fmt.Sprintf("resolve the ExternalValue resources of the package with the given providers, e.g. `env,vault`. Available providers: %s",
+			strings.Join(external.ProviderNames(), ", ")))
+	c.Flags().StringVar(&r.emitWorkflow, "emit-workflow", "",
+		fmt.Sprintf("print a workflow definition that runs the pipeline instead of rendering the package. Allowed values: %s",
+			strings.Join(render.WorkflowEnginesAsStrings(), "|")))
+	_ = c.RegisterFlagCompletionFunc("emit-workflow", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return render.WorkflowEnginesAsStrings(), cobra.ShellCompDirectiveDefault
+	})
+	c.Flags().StringVar(&r.workflowKptImage, "workflow-kpt-image", render.DefaultWorkflowKptImage,
+		"kpt image used to run the functions in the workflow printed with --emit-workflow.")
 	cmdutil.FixDocs("kpt", parent, c)
 	r.Command = c
 	return r
@@ -74,11 +113,27 @@ func NewCommand(ctx context.Context, parent string) *cobra.Command {
 
 // Runner contains the run function pipeline run command
 type Runner struct {
-	pkgPath        string
-	resultsDirPath string
-	dest           string
-	Command        *cobra.Command
-	ctx            context.Context
+	pkgPath            string
+	resultsDirPath     string
+	statusFilePath     string
+	referenceGraphPath string
+	annotateGenerated  bool
+	verifyIdempotent   bool
+	dest               string
+	emitWorkflow       string
+	env                []string
+	envFile            string
+	addMutators        []string
+	addValidators      []string
+	skipMutators       []string
+	skipValidators     []string
+	pipelineOverride   *render.PipelineOverride
+	resolveExternal    []string
+	externalResolver   *external.Resolver
+	Command            *cobra.Command
+	ctx                context.Context
+
+	workflowKptImage string
 
 	RunnerOptions fnruntime.RunnerOptions
 }
@@ -104,6 +159,34 @@ func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if r.emitWorkflow != "" {
+		if r.dest != "" || r.resultsDirPath != "" || r.statusFilePath != "" || r.referenceGraphPath != "" || r.verifyIdempotent {
+			return fmt.Errorf("--emit-workflow cannot be used with --output, --results-dir, --status-file, --reference-graph or --verify-idempotent")
+		}
+		if len(r.addMutators) != 0 || len(r.addValidators) != 0 || len(r.skipMutators) != 0 || len(r.skipValidators) != 0 {
+			return fmt.Errorf("--emit-workflow cannot be used with --add-mutator, --add-validator, --skip-mutator or --skip-validator")
+		}
+		if len(r.resolveExternal) != 0 {
+			return fmt.Errorf("--emit-workflow cannot be used with --resolve-external")
+		}
+		return nil
+	}
+	if r.pipelineOverride, err = r.parsePipelineOverride(); err != nil {
+		return err
+	}
+	if len(r.resolveExternal) != 0 {
+		// the resolved values are often secrets, which must not end up in
+		// the package files.
+		if r.dest == "" {
+			return fmt.Errorf("--resolve-external requires --output, so that the resolved values are not written to the package")
+		}
+		if r.externalResolver, err = external.NewResolver(r.resolveExternal); err != nil {
+			return err
+		}
+	}
+	if r.RunnerOptions.Env, err = parseEnv(r.env, r.envFile); err != nil {
+		return err
+	}
 	if r.dest != "" && r.dest != cmdutil.Stdout && r.dest != cmdutil.Unwrap {
 		if err := cmdutil.CheckDirectoryNotPresent(r.dest); err != nil {
 			return err
@@ -130,12 +213,37 @@ func (r *Runner) runE(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	if r.emitWorkflow != "" {
+		emitter := render.WorkflowEmitter{
+			PkgPath:    absPkgPath,
+			Engine:     render.WorkflowEngine(r.emitWorkflow),
+			KptImage:   r.workflowKptImage,
+			FileSystem: filesys.FileSystemOrOnDisk{},
+		}
+		return emitter.Emit(printer.FromContextOrDie(r.ctx).OutStream())
+	}
+	if r.statusFilePath != "" {
+		if r.statusFilePath, err = filepath.Abs(r.statusFilePath); err != nil {
+			return err
+		}
+	}
+	if r.referenceGraphPath != "" {
+		if r.referenceGraphPath, err = filepath.Abs(r.referenceGraphPath); err != nil {
+			return err
+		}
+	}
 	executor := render.Renderer{
-		PkgPath:        absPkgPath,
-		ResultsDirPath: r.resultsDirPath,
-		Output:         output,
-		RunnerOptions:  r.RunnerOptions,
-		FileSystem:     filesys.FileSystemOrOnDisk{},
+		PkgPath:            absPkgPath,
+		ResultsDirPath:     r.resultsDirPath,
+		StatusFilePath:     r.statusFilePath,
+		ReferenceGraphPath: r.referenceGraphPath,
+		AnnotateGenerated:  r.annotateGenerated,
+		VerifyIdempotent:   r.verifyIdempotent,
+		Output:             output,
+		RunnerOptions:      r.RunnerOptions,
+		FileSystem:         filesys.FileSystemOrOnDisk{},
+		PipelineOverride:   r.pipelineOverride,
+		ExternalResolver:   r.externalResolver,
 	}
 	if _, err := executor.Execute(r.ctx); err != nil {
 		return err
@@ -143,3 +251,27 @@ func (r *Runner) runE(_ *cobra.Command, _ []string) error {
 
 	return cmdutil.WriteFnOutput(r.dest, outContent.String(), false, printer.FromContextOrDie(r.ctx).OutStream())
 }
+
+// parsePipelineOverride returns the pipeline override given with the
+// --add-mutator, --add-validator, --skip-mutator and --skip-validator flags.
+func (r *Runner) parsePipelineOverride() (*render.PipelineOverride, error) {
+	o := &render.PipelineOverride{
+		SkipMutators:   r.skipMutators,
+		SkipValidators: r.skipValidators,
+	}
+	for _, s := range r.addMutators {
+		fn, err := render.ParseFunction(s)
+		if err != nil {
+			return nil, fmt.Errorf("--add-mutator: %w", err)
+		}
+		o.AddMutators = append(o.AddMutators, fn)
+	}
+	for _, s := range r.addValidators {
+		fn, err := render.ParseFunction(s)
+		if err != nil {
+			return nil, fmt.Errorf("--add-validator: %w", err)
+		}
+		o.AddValidators = append(o.AddValidators, fn)
+	}
+	return o, nil
+}
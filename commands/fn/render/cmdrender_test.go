@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/GoogleContainerTools/kpt/internal/testutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/render"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -45,3 +47,118 @@ func TestCmd_flagAndArgParsing_Symlink(t *testing.T) {
 
 // NoOpRunE is a noop function to replace the run function of a command.  Useful for testing argument parsing.
 var NoOpRunE = func(cmd *cobra.Command, args []string) error { return nil }
+
+func TestCmd_env(t *testing.T) {
+	dir := t.TempDir()
+	defer testutil.Chdir(t, dir)()
+	t.Setenv("KPT_TEST_TOKEN", "from-env")
+	err := os.WriteFile("secrets.env", []byte("# tokens\nAPI_KEY=from-file\n\nPASSWORD=p=w\nKPT_TEST_TOKEN\n"), 0600)
+	assert.NoError(t, err)
+
+	testCases := map[string]struct {
+		args        []string
+		expected    map[string]string
+		expectedErr string
+	}{
+		"no env": {
+			expected: map[string]string{},
+		},
+		"env flags": {
+			args:     []string{"--env", "API_KEY=key", "-e", "EMPTY=", "--env", "KPT_TEST_TOKEN"},
+			expected: map[string]string{"API_KEY": "key", "EMPTY": "", "KPT_TEST_TOKEN": "from-env"},
+		},
+		"env file": {
+			args:     []string{"--env-file", "secrets.env"},
+			expected: map[string]string{"API_KEY": "from-file", "PASSWORD": "p=w", "KPT_TEST_TOKEN": "from-env"},
+		},
+		"env flags take precedence over env file": {
+			args:     []string{"--env-file", "secrets.env", "--env", "API_KEY=from-flag"},
+			expected: map[string]string{"API_KEY": "from-flag", "PASSWORD": "p=w", "KPT_TEST_TOKEN": "from-env"},
+		},
+		"unset env var": {
+			args:        []string{"--env", "KPT_TEST_UNSET"},
+			expectedErr: `invalid --env "KPT_TEST_UNSET": environment variable "KPT_TEST_UNSET" is not set`,
+		},
+		"missing name": {
+			args:        []string{"--env", "=secret"},
+			expectedErr: `invalid --env "=...": missing name of environment variable`,
+		},
+		"missing env file": {
+			args:        []string{"--env-file", "missing.env"},
+			expectedErr: "cannot read env file",
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			r := NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+			r.Command.RunE = NoOpRunE
+			r.Command.SetArgs(tc.args)
+			err := r.Command.Execute()
+			if tc.expectedErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, r.RunnerOptions.Env)
+		})
+	}
+}
+
+func TestCmd_pipelineOverride(t *testing.T) {
+	dir := t.TempDir()
+	defer testutil.Chdir(t, dir)()
+
+	testCases := map[string]struct {
+		args        []string
+		expected    *render.PipelineOverride
+		expectedErr string
+	}{
+		"no override": {
+			expected: &render.PipelineOverride{SkipMutators: []string{}, SkipValidators: []string{}},
+		},
+		"add and skip functions": {
+			args: []string{
+				"--add-mutator", "image=set-labels:v0.1,config=labels.yaml",
+				"--add-validator", "exec=./validate.sh",
+				"--skip-mutator", "setters",
+				"--skip-validator", "kubeval:v0.3",
+			},
+			expected: &render.PipelineOverride{
+				AddMutators:    []kptfilev1.Function{{Image: "set-labels:v0.1", ConfigPath: "labels.yaml"}},
+				AddValidators:  []kptfilev1.Function{{Exec: "./validate.sh"}},
+				SkipMutators:   []string{"setters"},
+				SkipValidators: []string{"kubeval:v0.3"},
+			},
+		},
+		"invalid function": {
+			args:        []string{"--add-mutator", "config=labels.yaml"},
+			expectedErr: "--add-mutator: invalid function",
+		},
+		"resolve external in place": {
+			args:        []string{"--resolve-external", "env"},
+			expectedErr: "--resolve-external requires --output",
+		},
+		"emit workflow": {
+			args:        []string{"--emit-workflow", "tekton", "--skip-validator", "kubeval:v0.3"},
+			expectedErr: "--emit-workflow cannot be used with --add-mutator",
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			r := NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+			r.Command.RunE = NoOpRunE
+			r.Command.SetArgs(tc.args)
+			err := r.Command.Execute()
+			if tc.expectedErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, r.pipelineOverride)
+		})
+	}
+}
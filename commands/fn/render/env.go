@@ -0,0 +1,73 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// parseEnv returns the env vars given with the --env-file and --env flags.
+// Both take `KEY=VALUE` to set a value, or `KEY` to take the value from
+// the environment of kpt. Values given with --env take precedence over
+// the ones in the env file.
+func parseEnv(envs []string, envFile string) (map[string]string, error) {
+	env := map[string]string{}
+	if envFile != "" {
+		b, err := os.ReadFile(envFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read env file: %w", err)
+		}
+		for i, line := range strings.Split(string(b), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if err := parseEnvVar(env, line); err != nil {
+				return nil, fmt.Errorf("invalid env file %q, line %d: %w", envFile, i+1, err)
+			}
+		}
+	}
+	for _, e := range envs {
+		if err := parseEnvVar(env, e); err != nil {
+			return nil, fmt.Errorf("invalid --env %q: %w", redactEnvValue(e), err)
+		}
+	}
+	return env, nil
+}
+
+func parseEnvVar(env map[string]string, e string) error {
+	name, value, hasValue := strings.Cut(e, "=")
+	if name == "" {
+		return fmt.Errorf("missing name of environment variable")
+	}
+	if !hasValue {
+		var found bool
+		if value, found = os.LookupEnv(name); !found {
+			return fmt.Errorf("environment variable %q is not set", name)
+		}
+	}
+	env[name] = value
+	return nil
+}
+
+// redactEnvValue removes the value from e, since it may be a secret.
+func redactEnvValue(e string) string {
+	if name, _, hasValue := strings.Cut(e, "="); hasValue {
+		return name + "=..."
+	}
+	return e
+}
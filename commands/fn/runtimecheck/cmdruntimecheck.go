@@ -0,0 +1,133 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimecheck
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/fndocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx:          ctx,
+		inspect:      fnruntime.InspectContainerRuntime,
+		rootlessArgs: fnruntime.RootlessArgs,
+		cgroupV2:     fnruntime.CgroupV2,
+	}
+	c := &cobra.Command{
+		Use:     "runtime-check",
+		Args:    cobra.NoArgs,
+		Short:   docs.RuntimeCheckShort,
+		Long:    docs.RuntimeCheckShort + "\n" + docs.RuntimeCheckLong,
+		Example: docs.RuntimeCheckExamples,
+		RunE:    r.runE,
+	}
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+
+	// inspect, rootlessArgs and cgroupV2 inspect the host. They are
+	// replaced in tests.
+	inspect      func(fnruntime.ContainerRuntime) fnruntime.RuntimeInfo
+	rootlessArgs func(fnruntime.ContainerRuntime) []string
+	cgroupV2     func() bool
+}
+
+func (r *Runner) runE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdruntimecheck.runE"
+	out := printer.FromContextOrDie(r.ctx).OutStream()
+
+	infos := map[fnruntime.ContainerRuntime]fnruntime.RuntimeInfo{}
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "RUNTIME\tSTATUS\tVERSION\tROOTLESS")
+	for _, rt := range fnruntime.ContainerRuntimes {
+		info := r.inspect(rt)
+		infos[rt] = info
+		status := "available"
+		switch {
+		case goerrors.Is(info.Err, exec.ErrNotFound):
+			status = "not installed"
+		case info.Err != nil:
+			status = "unavailable"
+		}
+		rootless := ""
+		if info.Err == nil {
+			rootless = fmt.Sprintf("%t", info.Rootless)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rt, status, info.Version, rootless)
+	}
+	if err := w.Flush(); err != nil {
+		return errors.E(op, err)
+	}
+
+	// This matches the runtime selection of fnruntime.ResolveContainerRuntime,
+	// without inspecting the runtimes again.
+	selected := fnruntime.Docker
+	source := "default"
+	if v := os.Getenv(fnruntime.ContainerRuntimeEnv); v != "" {
+		rt, err := fnruntime.StringToContainerRuntime(v)
+		if err != nil {
+			return errors.E(op, fmt.Errorf("invalid %s: %w", fnruntime.ContainerRuntimeEnv, err))
+		}
+		selected, source = rt, "set with "+fnruntime.ContainerRuntimeEnv
+	} else {
+		for _, rt := range fnruntime.ContainerRuntimes {
+			if infos[rt].Err == nil {
+				selected, source = rt, "detected"
+				break
+			}
+		}
+	}
+	fmt.Fprintf(out, "\nFunctions run with %s (%s).\n", selected, source)
+
+	info := infos[selected]
+	if info.Err != nil {
+		return errors.E(op, fmt.Errorf("container runtime %s is not available: %w", selected, info.Err))
+	}
+	cgroup := "v1"
+	if r.cgroupV2() {
+		cgroup = "v2"
+	}
+	fmt.Fprintf(out, "cgroup version: %s\n", cgroup)
+	if info.Rootless {
+		if args := r.rootlessArgs(selected); len(args) > 0 {
+			fmt.Fprintf(out, "Rootless adjustments: %s\n", strings.Join(args, " "))
+		}
+	}
+	return nil
+}
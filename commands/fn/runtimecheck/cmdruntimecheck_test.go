@@ -0,0 +1,96 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimecheck
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd(t *testing.T) {
+	infos := map[fnruntime.ContainerRuntime]fnruntime.RuntimeInfo{
+		fnruntime.Docker: {
+			Runtime: fnruntime.Docker,
+			Err:     fmt.Errorf("exit status 1\ndocker must be running to use this command"),
+		},
+		fnruntime.Podman: {
+			Runtime:  fnruntime.Podman,
+			Version:  "4.9.3",
+			Rootless: true,
+		},
+		fnruntime.Nerdctl: {
+			Runtime: fnruntime.Nerdctl,
+			Err:     &exec.Error{Name: "nerdctl", Err: exec.ErrNotFound},
+		},
+	}
+
+	testCases := map[string]struct {
+		env         string
+		expected    string
+		expectedErr string
+	}{
+		"detected": {
+			expected: `RUNTIME   STATUS          VERSION   ROOTLESS
+docker    unavailable               
+podman    available       4.9.3     true
+nerdctl   not installed             
+
+Functions run with podman (detected).
+cgroup version: v1
+Rootless adjustments: --cgroups=disabled
+`,
+		},
+		"set with env": {
+			env:         "docker",
+			expectedErr: "container runtime docker is not available",
+		},
+		"invalid env": {
+			env:         "lxc",
+			expectedErr: `invalid KPT_FN_RUNTIME: unsupported runtime: "lxc"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			t.Setenv(fnruntime.ContainerRuntimeEnv, tc.env)
+			out := &bytes.Buffer{}
+			r := NewRunner(fake.CtxWithPrinter(out, out), "kpt")
+			r.inspect = func(rt fnruntime.ContainerRuntime) fnruntime.RuntimeInfo {
+				return infos[rt]
+			}
+			r.rootlessArgs = func(fnruntime.ContainerRuntime) []string {
+				return []string{"--cgroups=disabled"}
+			}
+			r.cgroupV2 = func() bool { return false }
+			r.Command.SetArgs([]string{})
+
+			err := r.Command.Execute()
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
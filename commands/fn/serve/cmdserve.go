@@ -0,0 +1,127 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/fndocs"
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/fnserver"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewRunner returns a command runner
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx:             ctx,
+		imagePullPolicy: fnruntime.IfNotPresentPull,
+	}
+	c := &cobra.Command{
+		Use:     "serve [flags]",
+		Args:    cobra.NoArgs,
+		Short:   docs.ServeShort,
+		Long:    docs.ServeShort + "\n" + docs.ServeLong,
+		Example: docs.ServeExamples,
+		RunE:    r.runE,
+	}
+	c.Flags().StringVar(&r.address, "address", "localhost:9445",
+		"address to serve the FunctionEvaluator gRPC service on.")
+	c.Flags().StringVar(&r.httpAddress, "http-address", "",
+		"address to serve the HTTP endpoint on. The HTTP endpoint is disabled if empty.")
+	c.Flags().Var(&r.imagePullPolicy, "image-pull-policy",
+		"pull image before running the container "+r.imagePullPolicy.HelpAllowedValues())
+	_ = c.RegisterFlagCompletionFunc("image-pull-policy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return r.imagePullPolicy.AllStrings(), cobra.ShellCompDirectiveDefault
+	})
+	c.Flags().DurationVar(&r.timeout, "timeout", 0,
+		"how long a function may run before it is stopped. Defaults to 5 minutes.")
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function for the serve command
+type Runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+
+	address         string
+	httpAddress     string
+	imagePullPolicy fnruntime.ImagePullPolicy
+	timeout         time.Duration
+}
+
+func (r *Runner) runE(c *cobra.Command, _ []string) error {
+	ctx, stop := signal.NotifyContext(r.ctx, os.Interrupt)
+	defer stop()
+
+	evaluator := &fnserver.ContainerEvaluator{
+		ImagePullPolicy: r.imagePullPolicy,
+		Timeout:         r.timeout,
+	}
+
+	lis, err := net.Listen("tcp", r.address)
+	if err != nil {
+		return err
+	}
+	grpcServer := fnserver.NewGRPCServer(evaluator)
+	errs := make(chan error, 2)
+	go func() {
+		errs <- grpcServer.Serve(lis)
+	}()
+	defer grpcServer.GracefulStop()
+	fmt.Fprintf(c.OutOrStdout(), "Serving FunctionEvaluator gRPC service on %s\n", lis.Addr())
+
+	if r.httpAddress != "" {
+		httpLis, err := net.Listen("tcp", r.httpAddress)
+		if err != nil {
+			return err
+		}
+		httpServer := &http.Server{
+			Handler:           fnserver.NewHTTPHandler(evaluator),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			errs <- httpServer.Serve(httpLis)
+		}()
+		defer func() {
+			_ = httpServer.Shutdown(context.Background())
+		}()
+		fmt.Fprintf(c.OutOrStdout(), "Serving HTTP endpoint on http://%s%s\n", httpLis.Addr(), fnserver.HTTPEvaluatePath)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errs:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd_invalidFlags(t *testing.T) {
+	tests := map[string]struct {
+		args   []string
+		errMsg string
+	}{
+		"invalid image pull policy": {
+			args:   []string{"--image-pull-policy", "sometimes"},
+			errMsg: "must must be one of Always, IfNotPresent, Never",
+		},
+		"invalid address": {
+			args:   []string{"--address", "localhost:-1"},
+			errMsg: "invalid port",
+		},
+		"unexpected args": {
+			args:   []string{"foo"},
+			errMsg: `unknown command "foo"`,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			r := NewRunner(context.Background(), "kpt")
+			r.Command.SetArgs(tc.args)
+			r.Command.SetOut(io.Discard)
+			r.Command.SetErr(io.Discard)
+			err := r.Command.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestCmd_serve(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pr, pw := io.Pipe()
+
+	r := NewRunner(ctx, "kpt")
+	r.Command.SetArgs([]string{"--address", "127.0.0.1:0", "--http-address", "127.0.0.1:0"})
+	r.Command.SetOut(pw)
+	done := make(chan error)
+	go func() {
+		done <- r.Command.Execute()
+		pw.Close()
+	}()
+
+	lines := bufio.NewScanner(pr)
+	require.True(t, lines.Scan())
+	assert.Contains(t, lines.Text(), "Serving FunctionEvaluator gRPC service on 127.0.0.1:")
+	require.True(t, lines.Scan())
+	url := strings.TrimPrefix(lines.Text(), "Serving HTTP endpoint on ")
+	// Keep draining the output so the command doesn't block on writes.
+	go func() {
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(`{"resourceList": "kind: ResourceList"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -17,6 +17,7 @@ package apply
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -24,14 +25,18 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/cmdutil"
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
 	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	utilcmdutil "github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/strings"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/live"
+	kptplanner "github.com/GoogleContainerTools/kpt/pkg/live/planner"
 	"github.com/GoogleContainerTools/kpt/pkg/status"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/validation"
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -55,12 +60,13 @@ func NewRunner(
 		alpha:       alpha,
 	}
 	c := &cobra.Command{
-		Use:     "apply [PKG_PATH | -]",
-		RunE:    r.runE,
-		PreRunE: r.preRunE,
-		Short:   livedocs.ApplyShort,
-		Long:    livedocs.ApplyShort + "\n" + livedocs.ApplyLong,
-		Example: livedocs.ApplyExamples,
+		Use:               "apply [PKG_PATH | -]",
+		RunE:              r.runE,
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: utilcmdutil.CompletePackagePaths,
+		Short:             livedocs.ApplyShort,
+		Long:              livedocs.ApplyShort + "\n" + livedocs.ApplyLong,
+		Example:           livedocs.ApplyExamples,
 	}
 	r.Command = c
 
@@ -81,6 +87,12 @@ func NewRunner(
 	c.Flags().StringVar(&r.inventoryPolicyString, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
 			fmt.Sprintf("%q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt))
+	c.Flags().BoolVar(&r.forceAdopt, "force-adopt", false,
+		"If true, adopt resources owned by other inventories and release conflicting resources from this inventory instead of pruning them.")
+	c.Flags().BoolVar(&r.adopt, "adopt", false,
+		"If true, adopt the resources that exist in the cluster without being managed by an inventory, instead of failing to apply them.")
+	c.Flags().StringArrayVar(&r.adoptMatch, "adopt-match", nil,
+		"Only adopt the unmanaged resources matching this rule, e.g. kind=Deployment,namespace=prod,name=web-*. Can be repeated.")
 	c.Flags().BoolVar(&r.installCRD, "install-resource-group", true,
 		"If true, install the inventory ResourceGroup CRD before applying.")
 	c.Flags().BoolVar(&r.dryRun, "dry-run", false,
@@ -90,6 +102,30 @@ func NewRunner(
 	c.Flags().StringVar(&r.statusPolicyString, "status-policy", "all",
 		"It determines which status information should be saved in the inventory (if compatible). Available options "+
 			fmt.Sprintf("%q and %q.", "all", "none"))
+	c.Flags().StringVar(&r.planPath, "plan", "",
+		"Path of a plan created with 'kpt live plan --plan-file'. The resources and options of the plan are applied.")
+	c.Flags().BoolVar(&r.skipUnchanged, "skip-unchanged", false,
+		"If true, skip the apply and report the resources as unchanged if the package is the one last applied successfully with the inventory.")
+	c.Flags().StringSliceVar(&r.contexts, "contexts", nil,
+		"Kubeconfig contexts of the clusters to apply the package to, e.g. ctx1,ctx2. The package is applied to the clusters one after the other unless --parallel is set.")
+	c.Flags().BoolVar(&r.parallel, "parallel", false,
+		"If true, apply the package to the clusters of --contexts in parallel.")
+	c.Flags().IntVar(&r.maxParallelism, "max-parallelism", 0,
+		"Maximum number of clusters the package is applied to at the same time with --parallel. 0 applies to all of them at once.")
+	c.Flags().StringVar(&r.preflightModeString, "preflight", string(live.PreflightWarn),
+		"Validate the resources against the schema of the cluster before applying. Available options "+
+			fmt.Sprintf("%s.", strings.JoinStringsWithQuotes(live.PreflightModesAsStrings())))
+	_ = c.RegisterFlagCompletionFunc("preflight", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return live.PreflightModesAsStrings(), cobra.ShellCompDirectiveDefault
+	})
+	c.Flags().BoolVar(&r.admissionDryRun, "admission-dry-run", false,
+		"If true, dry-run every resource on the server before applying, and report all the resources that the admission policies and webhooks of the cluster would deny.")
+	c.Flags().StringVar(&r.reportDest, "report", "",
+		"Write an ApplyReport of the apply to the given file, or post it to the given http(s) URL.")
+	c.Flags().StringVar(&r.reportSigningKey, "report-signing-key", "",
+		"Path of an ed25519 private key in PEM format to sign the report with.")
+	c.Flags().DurationVar(&r.reportTimeout, "report-timeout", defaultReportTimeout,
+		"Timeout for posting the report to an http(s) URL. 0 means no timeout.")
 	return r
 }
 
@@ -107,6 +143,10 @@ type Runner struct {
 	ioStreams  genericclioptions.IOStreams
 	factory    util.Factory
 
+	// ContextFactory returns the factory for a kubeconfig context. It is
+	// required to apply to the clusters of --contexts.
+	ContextFactory func(kubeContext string) util.Factory
+
 	installCRD                   bool
 	serverSideOptions            common.ServerSideOptions
 	output                       string
@@ -114,13 +154,41 @@ type Runner struct {
 	prunePropagationPolicyString string
 	pruneTimeout                 time.Duration
 	inventoryPolicyString        string
+	forceAdopt                   bool
+	adopt                        bool
+	adoptMatch                   []string
 	dryRun                       bool
 	printStatusEvents            bool
 	statusPolicyString           string
+	planPath                     string
+	skipUnchanged                bool
+	contexts                     []string
+	parallel                     bool
+	maxParallelism               int
+	preflightModeString          string
+	admissionDryRun              bool
+	reportDest                   string
+	reportSigningKey             string
+	reportTimeout                time.Duration
 
 	inventoryPolicy inventory.Policy
+	adoptRules      []live.AdoptRule
 	prunePropPolicy metav1.DeletionPropagation
 	statusPolicy    inventory.StatusPolicy
+	preflightMode   live.PreflightMode
+
+	reconcilePolicies live.ReconcilePolicies
+
+	// plan is the plan read from planPath. The apply is refused if the
+	// resources in the cluster have changed since the plan was created.
+	plan *kptplanner.PlanFile
+
+	// pkgPath is the path of the applied package. It is empty if the
+	// resources are read from stdin or from a plan.
+	pkgPath string
+
+	// reporter records the apply for the report written with --report.
+	reporter *live.ApplyReporter
 
 	applyRunner func(r *Runner, invInfo inventory.Info, objs []*unstructured.Unstructured,
 		dryRunStrategy common.DryRunStrategy) error
@@ -128,6 +196,27 @@ type Runner struct {
 
 func (r *Runner) preRunE(cmd *cobra.Command, _ []string) error {
 	var err error
+	if r.planPath != "" {
+		// The options of the apply are taken from the plan.
+		for _, f := range []string{"server-side", "force-conflicts", "field-manager", flagutils.InventoryPolicyFlag, "adopt"} {
+			if cmd.Flags().Changed(f) {
+				return fmt.Errorf("--%s can't be used with --plan", f)
+			}
+		}
+	}
+	if r.skipUnchanged && (r.planPath != "" || r.dryRun) {
+		return fmt.Errorf("--skip-unchanged can't be used with --plan or --dry-run")
+	}
+	if err := r.validateContexts(cmd); err != nil {
+		return err
+	}
+	if err := r.parseAdopt(cmd); err != nil {
+		return err
+	}
+	if err := r.validateReport(); err != nil {
+		return err
+	}
+
 	r.prunePropPolicy, err = flagutils.ConvertPropagationPolicy(r.prunePropagationPolicyString)
 	if err != nil {
 		return err
@@ -143,6 +232,14 @@ func (r *Runner) preRunE(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	r.preflightMode, err = live.ParsePreflightMode(r.preflightModeString)
+	if err != nil {
+		return err
+	}
+	if r.admissionDryRun && r.preflightMode == live.PreflightOff {
+		return fmt.Errorf("--admission-dry-run can't be used with --preflight=off")
+	}
+
 	if found := printers.ValidatePrinterType(r.output); !found {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
@@ -154,44 +251,65 @@ func (r *Runner) preRunE(cmd *cobra.Command, _ []string) error {
 	}
 
 	if !r.installCRD {
-		err := cmdutil.VerifyResourceGroupCRD(r.factory)
-		if err != nil {
-			return err
+		for _, f := range r.factories() {
+			err := cmdutil.VerifyResourceGroupCRD(f)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-func (r *Runner) runE(c *cobra.Command, args []string) error {
-	if len(args) == 0 {
-		// default to the current working directory
-		cwd, err := os.Getwd()
+// parseAdopt validates the adopt flags and parses the adopt rules.
+func (r *Runner) parseAdopt(cmd *cobra.Command) error {
+	if len(r.adoptMatch) > 0 && !r.adopt {
+		return fmt.Errorf("--adopt-match can only be used with --adopt")
+	}
+	if r.adopt && cmd.Flags().Changed(flagutils.InventoryPolicyFlag) {
+		return fmt.Errorf("--adopt can't be used with --%s", flagutils.InventoryPolicyFlag)
+	}
+	for _, m := range r.adoptMatch {
+		rule, err := live.ParseAdoptRule(m)
 		if err != nil {
 			return err
 		}
-		args = append(args, cwd)
+		r.adoptRules = append(r.adoptRules, rule)
 	}
-	path := args[0]
+	return nil
+}
+
+func (r *Runner) runE(c *cobra.Command, args []string) error {
+	if len(r.contexts) > 0 {
+		return r.runContexts(c.InOrStdin(), args)
+	}
+	return r.run(c.InOrStdin(), args)
+}
+
+// run applies the package given in args, or the plan, with the factory of
+// the runner.
+func (r *Runner) run(in io.Reader, args []string) error {
+	var objs []*unstructured.Unstructured
+	var inv kptfilev1.Inventory
 	var err error
-	if args[0] != "-" {
-		path, err = argutil.ResolveSymlink(r.ctx, path)
-		if err != nil {
-			return err
+	if r.planPath != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("PKG_PATH can't be used with --plan")
 		}
+		objs, inv, err = r.loadPlan()
+	} else {
+		objs, inv, err = r.loadPackage(in, args)
 	}
-
-	objs, inv, err := live.Load(r.factory, path, c.InOrStdin())
 	if err != nil {
 		return err
 	}
 
-	// objs may contain kind List
-	objs, err = live.Flatten(objs)
+	invInfo, err := live.ToInventoryInfo(inv)
 	if err != nil {
 		return err
 	}
 
-	invInfo, err := live.ToInventoryInfo(inv)
+	r.reconcilePolicies, err = live.NewReconcilePolicies(inv, objs)
 	if err != nil {
 		return err
 	}
@@ -213,11 +331,104 @@ func (r *Runner) runE(c *cobra.Command, args []string) error {
 		}
 	}
 
-	return r.applyRunner(r, invInfo, objs, dryRunStrategy)
+	if r.reportDest == "" {
+		return r.applyRunner(r, invInfo, objs, dryRunStrategy)
+	}
+	r.reporter = &live.ApplyReporter{}
+	r.reporter.Start()
+	err = r.applyRunner(r, invInfo, objs, dryRunStrategy)
+	return r.writeReport(invInfo, objs, err)
+}
+
+// loadPackage loads the resources and inventory of the package given in
+// args. The server-side apply options are completed from the annotations
+// of the package.
+func (r *Runner) loadPackage(in io.Reader, args []string) ([]*unstructured.Unstructured, kptfilev1.Inventory, error) {
+	if len(args) == 0 {
+		// default to the current working directory
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, kptfilev1.Inventory{}, err
+		}
+		args = append(args, cwd)
+	}
+	path := args[0]
+	var err error
+	if args[0] != "-" {
+		path, err = argutil.ResolveSymlink(r.ctx, path)
+		if err != nil {
+			return nil, kptfilev1.Inventory{}, err
+		}
+		r.pkgPath = path
+	}
+
+	objs, inv, err := live.Load(r.factory, path, in)
+	if err != nil {
+		return nil, inv, err
+	}
+
+	// objs may contain kind List
+	objs, err = live.Flatten(objs)
+	if err != nil {
+		return nil, inv, err
+	}
+
+	policy, err := live.ReadServerSidePolicy(inv, objs)
+	if err != nil {
+		return nil, inv, err
+	}
+	r.serverSideOptions = policy.Merge(r.serverSideOptions,
+		r.Command.Flags().Changed("field-manager"), r.Command.Flags().Changed("force-conflicts"))
+	err = live.RemoveIgnoredFields(objs, r.serverSideOptions.ServerSideApply)
+	return objs, inv, err
+}
+
+// loadPlan reads the plan at planPath and returns its resources and
+// inventory. The options of the apply are set from the plan.
+func (r *Runner) loadPlan() ([]*unstructured.Unstructured, kptfilev1.Inventory, error) {
+	plan, err := kptplanner.ReadPlanFile(r.planPath)
+	if err != nil {
+		return nil, kptfilev1.Inventory{}, err
+	}
+	if errs := plan.Errors(); len(errs) > 0 {
+		return nil, kptfilev1.Inventory{}, fmt.Errorf("plan %q can't be applied, %d resource(s) failed to plan", r.planPath, len(errs))
+	}
+	opts := plan.Spec.Options
+	r.serverSideOptions = common.ServerSideOptions{
+		ServerSideApply: opts.ServerSideApply,
+		ForceConflicts:  opts.ForceConflicts,
+		FieldManager:    opts.FieldManager,
+	}
+	if r.serverSideOptions.FieldManager == "" {
+		r.serverSideOptions.FieldManager = common.DefaultFieldManager
+	}
+	if opts.InventoryPolicy != "" {
+		r.inventoryPolicyString = opts.InventoryPolicy
+		r.inventoryPolicy, err = flagutils.ConvertInventoryPolicy(opts.InventoryPolicy)
+		if err != nil {
+			return nil, kptfilev1.Inventory{}, err
+		}
+	}
+	r.plan = plan
+	return plan.Spec.Resources, plan.Spec.Inventory, nil
 }
 
 func runApply(r *Runner, invInfo inventory.Info, objs []*unstructured.Unstructured,
 	dryRunStrategy common.DryRunStrategy) error {
+	if r.plan != nil {
+		fetcher, err := kptplanner.NewResourceFetcher(r.factory)
+		if err != nil {
+			return err
+		}
+		if err := r.plan.CheckStale(r.ctx, fetcher); err != nil {
+			return err
+		}
+	}
+
+	if err := r.preflight(objs); err != nil {
+		return err
+	}
+
 	if r.installCRD {
 		f := r.factory
 		// Install the ResourceGroup CRD if it is not already installed
@@ -244,6 +455,70 @@ func runApply(r *Runner, invInfo inventory.Info, objs []*unstructured.Unstructur
 		return err
 	}
 
+	// Make sure we don't prune resources that are still claimed by other
+	// inventories, e.g. after a package has been split into several packages.
+	getLive, err := live.NewLiveObjectGetter(r.factory)
+	if err != nil {
+		return err
+	}
+	conflicts, err := live.CheckOwnership(r.ctx, invClient, getLive, invInfo, objs)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		if !r.forceAdopt {
+			return &live.OwnershipConflictError{
+				InventoryID: invInfo.ID(),
+				Conflicts:   conflicts,
+			}
+		}
+		if err := live.ReleaseOwnership(invClient, invInfo, conflicts, dryRunStrategy); err != nil {
+			return err
+		}
+		r.inventoryPolicy = inventory.PolicyAdoptAll
+	}
+
+	if r.adopt {
+		if err := r.adoptUnmanaged(getLive, invInfo, objs); err != nil {
+			return err
+		}
+	}
+
+	if r.reporter != nil {
+		if err := r.reporter.RecordExisting(r.ctx, getLive, objs); err != nil {
+			return err
+		}
+		if r.plan != nil {
+			r.reporter.Changes = r.plan.ChangedFields()
+		}
+	}
+
+	hash, err := live.PackageHash(objs, r.serverSideOptions)
+	if err != nil {
+		return err
+	}
+	if r.skipUnchanged {
+		appliedHash, err := live.AppliedHash(r.ctx, r.factory, invInfo)
+		if err != nil {
+			return err
+		}
+		if appliedHash == hash {
+			ch := live.UnchangedApplyEvents(objs)
+			if r.reporter != nil {
+				ch = r.reporter.Run(ch)
+			}
+			return r.printer().Print(ch, dryRunStrategy, r.printStatusEvents)
+		}
+	}
+
+	// Fail before anything is applied if the applied resources can't be
+	// recorded for kpt live rollback.
+	if !dryRunStrategy.ClientOrServerDryRun() {
+		if err := live.CheckSnapshotSize(objs); err != nil {
+			return err
+		}
+	}
+
 	statusWatcher, err := status.NewStatusWatcher(r.factory)
 	if err != nil {
 		return err
@@ -258,34 +533,196 @@ func runApply(r *Runner, invInfo inventory.Info, objs []*unstructured.Unstructur
 		return err
 	}
 
-	ch := applier.Run(r.ctx, invInfo, objs, apply.ApplierOptions{
+	// Per-object reconcile timeouts and failure policies are enforced
+	// on the events of the apply. Without an explicit --reconcile-timeout,
+	// waiting is bounded by the largest per-object timeout so a stuck
+	// object can't block the apply forever.
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+	reconcileTimeout := r.reconcileTimeout
+	var enforcer *live.ReconcilePolicyEnforcer
+	if r.reconcilePolicies.Enabled() && !dryRunStrategy.ClientOrServerDryRun() {
+		if reconcileTimeout == 0 {
+			reconcileTimeout = r.reconcilePolicies.MaxTimeout()
+		}
+		restore, err := live.NewLiveObjectWriter(r.factory)
+		if err != nil {
+			return err
+		}
+		enforcer = &live.ReconcilePolicyEnforcer{
+			Policies: r.reconcilePolicies,
+			GetLive:  getLive,
+			Restore:  restore,
+			Cancel:   cancel,
+		}
+		if err := enforcer.Snapshot(r.ctx); err != nil {
+			return err
+		}
+	}
+
+	ch := applier.Run(ctx, invInfo, objs, apply.ApplierOptions{
 		ServerSideOptions:      r.serverSideOptions,
-		ReconcileTimeout:       r.reconcileTimeout,
+		ReconcileTimeout:       reconcileTimeout,
 		EmitStatusEvents:       true, // We are always waiting for reconcile.
 		DryRunStrategy:         dryRunStrategy,
 		PrunePropagationPolicy: r.prunePropPolicy,
 		PruneTimeout:           r.pruneTimeout,
 		InventoryPolicy:        r.inventoryPolicy,
 	})
+	if enforcer != nil {
+		ch = enforcer.Run(r.ctx, ch)
+	}
+	if r.reporter != nil {
+		ch = r.reporter.Run(ch)
+	}
+
+	// Spans and metrics are recorded for every action group and object of
+	// the apply. They are only exported if configured in the environment.
+	ch, finishTelemetry, err := live.RunTelemetry(r.ctx, "apply", invInfo, ch, r.ioStreams.ErrOut)
+	if err != nil {
+		return err
+	}
+	defer finishTelemetry()
 
 	// Print the preview strategy unless the output format is json.
 	if dryRunStrategy.ClientOrServerDryRun() && r.output != printers.JSONPrinter {
 		if dryRunStrategy.ServerDryRun() {
-			fmt.Println("Dry-run strategy: server")
+			fmt.Fprintln(r.ioStreams.Out, "Dry-run strategy: server")
 		} else {
-			fmt.Println("Dry-run strategy: client")
+			fmt.Fprintln(r.ioStreams.Out, "Dry-run strategy: client")
 		}
 	}
 
 	// The printer will print updates from the channel. It will block
 	// until the channel is closed.
-	var printer cliutilsprinter.Printer
+	err = r.printer().Print(ch, dryRunStrategy, r.printStatusEvents)
+	if enforcer != nil {
+		if policyErr := enforcer.Err(); policyErr != nil {
+			return policyErr
+		}
+	}
+	if err != nil || dryRunStrategy.ClientOrServerDryRun() {
+		return err
+	}
+
+	// Record the applied resources so that kpt live rollback can return to
+	// them later. The apply itself succeeded, so failing to record the
+	// snapshot is only reported.
+	if err := recordSnapshot(r.ctx, r.factory, invInfo, objs); err != nil {
+		fmt.Fprintf(r.ioStreams.ErrOut, "warning: %v\n", err)
+	}
+	// Record the hash of the package for --skip-unchanged.
+	if err := live.RecordAppliedHash(r.ctx, r.factory, invInfo, hash); err != nil {
+		fmt.Fprintf(r.ioStreams.ErrOut, "warning: %v\n", err)
+	}
+	return nil
+}
+
+// adoptUnmanaged adopts the resources of objs that exist in the cluster
+// without being managed by an inventory, if they match the adopt rules.
+// The apply fails if any unmanaged resource doesn't match.
+func (r *Runner) adoptUnmanaged(getLive live.LiveObjectGetter, invInfo inventory.Info,
+	objs []*unstructured.Unstructured) error {
+	unmanaged, err := live.FindUnmanaged(r.ctx, getLive, invInfo, objs)
+	if err != nil {
+		return err
+	}
+	adopted, rest := live.SelectAdopted(unmanaged, r.adoptRules)
+	if len(rest) > 0 {
+		return &live.UnmanagedObjectsError{IDs: rest}
+	}
+	if len(adopted) == 0 {
+		return nil
+	}
+	// Resources owned by other inventories are only adopted with
+	// --force-adopt.
+	if r.inventoryPolicy == inventory.PolicyMustMatch {
+		r.inventoryPolicy = inventory.PolicyAdoptIfNoInventory
+	}
+	if r.reporter != nil {
+		r.reporter.Adopted = adopted
+	}
+	if r.output != printers.JSONPrinter {
+		fmt.Fprintf(r.ioStreams.Out, "adopting %d unmanaged resource(s):\n", len(adopted))
+		for _, id := range adopted {
+			fmt.Fprintf(r.ioStreams.Out, "  %s\n", id)
+		}
+	}
+	return nil
+}
+
+// printer returns the printer for the output format of the apply.
+func (r *Runner) printer() cliutilsprinter.Printer {
 	if r.alpha && r.output == printers.TablePrinter {
-		printer = &alphaprinterstable.Printer{
+		return &alphaprinterstable.Printer{
 			IOStreams: r.ioStreams,
 		}
-	} else {
-		printer = printers.GetPrinter(r.output, r.ioStreams)
 	}
-	return printer.Print(ch, dryRunStrategy, r.printStatusEvents)
+	return printers.GetPrinter(r.output, r.ioStreams)
+}
+
+// preflight validates the resources against the schema of the cluster
+// before anything in the cluster is changed, and with --admission-dry-run
+// against its admission control. Problems are reported as warnings in warn
+// mode, and fail the apply in strict mode.
+func (r *Runner) preflight(objs []*unstructured.Unstructured) error {
+	if r.preflightMode == live.PreflightOff {
+		return nil
+	}
+	resources, err := r.factory.OpenAPISchema()
+	if err != nil {
+		return err
+	}
+	mapper, err := r.factory.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+	problems, err := live.Preflight(validation.NewSchemaValidation(resources), mapper, objs)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		if r.preflightMode == live.PreflightStrict {
+			return &live.PreflightError{Problems: problems}
+		}
+		for _, p := range problems {
+			fmt.Fprintf(r.ioStreams.ErrOut, "warning: %s\n", p)
+		}
+	}
+	if r.admissionDryRun {
+		return r.checkAdmission(objs)
+	}
+	return nil
+}
+
+// checkAdmission dry-runs the resources on the server, and reports all the
+// resources that would be denied by the admission control of the cluster.
+func (r *Runner) checkAdmission(objs []*unstructured.Unstructured) error {
+	dryRun, err := live.NewAdmissionDryRunner(r.factory, r.serverSideOptions.FieldManager)
+	if err != nil {
+		return err
+	}
+	violations, err := live.CheckAdmission(r.ctx, dryRun, objs)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	if r.preflightMode == live.PreflightStrict {
+		return &live.AdmissionError{Violations: violations}
+	}
+	for _, v := range violations {
+		fmt.Fprintf(r.ioStreams.ErrOut, "warning: %s\n", v)
+	}
+	return nil
+}
+
+func recordSnapshot(ctx context.Context, factory util.Factory, invInfo inventory.Info,
+	objs []*unstructured.Unstructured) error {
+	store, err := live.NewSnapshotStore(factory)
+	if err != nil {
+		return err
+	}
+	return store.Record(ctx, invInfo, objs)
 }
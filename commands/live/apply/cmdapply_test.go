@@ -15,19 +15,28 @@
 package apply
 
 import (
+	"bytes"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/GoogleContainerTools/kpt/internal/testutil"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	kptplanner "github.com/GoogleContainerTools/kpt/pkg/live/planner"
 	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/printers"
 )
 
 func TestCmd(t *testing.T) {
@@ -35,6 +44,7 @@ func TestCmd(t *testing.T) {
 		args              []string
 		namespace         string
 		inventory         *kptfilev1.Inventory
+		plan              *kptplanner.PlanFile
 		applyCallbackFunc func(*testing.T, *Runner, inventory.Info)
 		expectedErrorMsg  string
 	}{
@@ -68,6 +78,27 @@ func TestCmd(t *testing.T) {
 			},
 			expectedErrorMsg: "unknown output type \"foo\"",
 		},
+		"invalid preflight mode": {
+			args: []string{
+				"--preflight", "lenient",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "unknown preflight mode \"lenient\", must be one of strict, warn, off",
+		},
+		"admission dry-run without preflight": {
+			args: []string{
+				"--admission-dry-run",
+				"--preflight", "off",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "--admission-dry-run can't be used with --preflight=off",
+		},
 		"fetches the correct inventory information from the Kptfile": {
 			args: []string{
 				"--inventory-policy", "adopt",
@@ -129,6 +160,206 @@ func TestCmd(t *testing.T) {
 				assert.True(t, r.installCRD)
 			},
 		},
+		"server-side options are set from the inventory annotations": {
+			args: []string{
+				"--server-side",
+			},
+			inventory: &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+				Annotations: map[string]string{
+					"kpt.dev/field-manager":         "platform-team",
+					"kpt.dev/apply-conflict-policy": "force",
+				},
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, r *Runner, _ inventory.Info) {
+				assert.Equal(t, "platform-team", r.serverSideOptions.FieldManager)
+				assert.True(t, r.serverSideOptions.ForceConflicts)
+			},
+		},
+		"server-side flags override the inventory annotations": {
+			args: []string{
+				"--server-side",
+				"--field-manager", "my-manager",
+				"--force-conflicts=false",
+			},
+			inventory: &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+				Annotations: map[string]string{
+					"kpt.dev/field-manager":         "platform-team",
+					"kpt.dev/apply-conflict-policy": "force",
+				},
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, r *Runner, _ inventory.Info) {
+				assert.Equal(t, "my-manager", r.serverSideOptions.FieldManager)
+				assert.False(t, r.serverSideOptions.ForceConflicts)
+			},
+		},
+		"applies the resources and options of a plan": {
+			args: []string{
+				"--plan", "plan.yaml",
+			},
+			inventory: &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+			},
+			plan:      testPlan(nil),
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, r *Runner, inv inventory.Info) {
+				assert.Equal(t, "plan-ns", inv.Namespace())
+				assert.Equal(t, "plan-inv-id", inv.ID())
+				assert.True(t, r.serverSideOptions.ServerSideApply)
+				assert.Equal(t, "my-manager", r.serverSideOptions.FieldManager)
+				assert.Equal(t, inventory.PolicyAdoptIfNoInventory, r.inventoryPolicy)
+				assert.NotNil(t, r.plan)
+			},
+		},
+		"plan can't be used with a package": {
+			args: []string{
+				"--plan", "plan.yaml", ".",
+			},
+			plan:      testPlan(nil),
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "PKG_PATH can't be used with --plan",
+		},
+		"plan can't be used with server-side options": {
+			args: []string{
+				"--plan", "plan.yaml",
+				"--force-conflicts",
+			},
+			plan:      testPlan(nil),
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "--force-conflicts can't be used with --plan",
+		},
+		"skip-unchanged can't be used with dry-run": {
+			args: []string{
+				"--skip-unchanged", "--dry-run",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "--skip-unchanged can't be used with --plan or --dry-run",
+		},
+		"report-signing-key can't be used without report": {
+			args: []string{
+				"--report-signing-key", "key.pem",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "--report-signing-key can only be used with --report",
+		},
+		"report records the apply": {
+			args: []string{
+				"--report", "report.yaml",
+			},
+			inventory: &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, r *Runner, _ inventory.Info) {
+				assert.NotNil(t, r.reporter)
+				assert.NotEmpty(t, r.pkgPath)
+			},
+		},
+		"report timeout defaults to 30s": {
+			args: []string{
+				"--report", "report.yaml",
+			},
+			inventory: &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, r *Runner, _ inventory.Info) {
+				assert.Equal(t, 30*time.Second, r.reportTimeout)
+			},
+		},
+		"negative report timeout": {
+			args: []string{
+				"--report", "report.yaml", "--report-timeout", "-1s",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "--report-timeout must not be negative",
+		},
+		"adopt-match requires adopt": {
+			args: []string{
+				"--adopt-match", "kind=Deployment",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "--adopt-match can only be used with --adopt",
+		},
+		"adopt can't be used with inventory policy": {
+			args: []string{
+				"--adopt", "--inventory-policy", "adopt",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "--adopt can't be used with --inventory-policy",
+		},
+		"adopt rules are parsed": {
+			args: []string{
+				"--adopt", "--adopt-match", "kind=Deployment,name=web-*", "--adopt-match", "namespace=prod",
+			},
+			inventory: &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, r *Runner, _ inventory.Info) {
+				assert.Equal(t, []live.AdoptRule{{Kind: "Deployment", Name: "web-*"}, {Namespace: "prod"}}, r.adoptRules)
+			},
+		},
+		"preflight warns by default": {
+			inventory: &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+			},
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, r *Runner, _ inventory.Info) {
+				assert.Equal(t, live.PreflightWarn, r.preflightMode)
+			},
+		},
+		"plan with errors is not applied": {
+			args: []string{
+				"--plan", "plan.yaml",
+			},
+			plan: testPlan([]kptplanner.PlanAction{
+				{Action: kptplanner.Error, Kind: "ConfigMap", Name: "cm", Error: "denied"},
+			}),
+			namespace: "testns",
+			applyCallbackFunc: func(t *testing.T, _ *Runner, _ inventory.Info) {
+				t.FailNow()
+			},
+			expectedErrorMsg: "1 resource(s) failed to plan",
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -143,6 +374,17 @@ func TestCmd(t *testing.T) {
 			kf.Inventory = tc.inventory
 			testutil.AddKptfileToWorkspace(t, w, kf)
 
+			if tc.plan != nil {
+				var b bytes.Buffer
+				if !assert.NoError(t, tc.plan.Write(&b)) {
+					t.FailNow()
+				}
+				err := os.WriteFile(filepath.Join(w.WorkspaceDirectory, "plan.yaml"), b.Bytes(), 0600)
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+			}
+
 			revert := testutil.Chdir(t, w.WorkspaceDirectory)
 			defer revert()
 
@@ -167,3 +409,52 @@ func TestCmd(t *testing.T) {
 		})
 	}
 }
+
+func testPlan(actions []kptplanner.PlanAction) *kptplanner.PlanFile {
+	return &kptplanner.PlanFile{
+		APIVersion: kptplanner.PlanAPIVersion,
+		Kind:       kptplanner.PlanKind,
+		Metadata:   kptplanner.PlanMetadata{Name: "plan"},
+		Spec: kptplanner.PlanSpec{
+			Inventory: kptfilev1.Inventory{
+				Namespace:   "plan-ns",
+				Name:        "plan-name",
+				InventoryID: "plan-inv-id",
+			},
+			Options: kptplanner.PlanOptions{
+				ServerSideApply: true,
+				FieldManager:    "my-manager",
+				InventoryPolicy: "adopt",
+			},
+			Actions: actions,
+		},
+	}
+}
+
+// Objects with the default continue policy don't need a reconcile policy
+// enforcer, so the printer alone makes the apply fail when they don't
+// reconcile.
+func TestPrinter_reconcileFailure(t *testing.T) {
+	id := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "nginx",
+	}
+	ch := make(chan event.Event, 4)
+	ch <- event.Event{Type: event.ActionGroupType, ActionGroupEvent: event.ActionGroupEvent{
+		GroupName: "wait-0", Action: event.WaitAction, Status: event.Started,
+	}}
+	ch <- event.Event{Type: event.WaitType, WaitEvent: event.WaitEvent{
+		GroupName: "wait-0", Identifier: id, Status: event.ReconcileFailed,
+	}}
+	ch <- event.Event{Type: event.ActionGroupType, ActionGroupEvent: event.ActionGroupEvent{
+		GroupName: "wait-0", Action: event.WaitAction, Status: event.Finished,
+	}}
+	close(ch)
+
+	r := &Runner{
+		output:    printers.DefaultPrinter(),
+		ioStreams: genericclioptions.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}},
+	}
+	assert.Error(t, r.printer().Print(ch, common.DryRunNone, false))
+}
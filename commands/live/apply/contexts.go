@@ -0,0 +1,192 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/printers"
+)
+
+// validateContexts validates the --contexts, --parallel and
+// --max-parallelism flags.
+func (r *Runner) validateContexts(cmd *cobra.Command) error {
+	if r.maxParallelism < 0 {
+		return fmt.Errorf("--max-parallelism must not be negative")
+	}
+	if r.maxParallelism > 0 && !r.parallel {
+		return fmt.Errorf("--max-parallelism can only be used with --parallel")
+	}
+	if len(r.contexts) == 0 {
+		if r.parallel {
+			return fmt.Errorf("--parallel can only be used with --contexts")
+		}
+		return nil
+	}
+	if r.ContextFactory == nil {
+		return fmt.Errorf("--contexts is not supported by this command")
+	}
+	if f := cmd.Flags().Lookup("context"); f != nil && f.Changed {
+		return fmt.Errorf("--contexts can't be used with --context")
+	}
+	if r.planPath != "" {
+		return fmt.Errorf("--contexts can't be used with --plan, since a plan is created for a single cluster")
+	}
+	if r.parallel && r.output == printers.TablePrinter {
+		return fmt.Errorf("--parallel can't be used with --output=%s", printers.TablePrinter)
+	}
+	seen := map[string]bool{}
+	for _, c := range r.contexts {
+		if c == "" {
+			return fmt.Errorf("--contexts must not contain empty context names")
+		}
+		if seen[c] {
+			return fmt.Errorf("context %q is listed more than once in --contexts", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// factories returns the factories of the clusters the package is applied
+// to.
+func (r *Runner) factories() []util.Factory {
+	if len(r.contexts) == 0 {
+		return []util.Factory{r.factory}
+	}
+	var factories []util.Factory
+	for _, c := range r.contexts {
+		factories = append(factories, r.ContextFactory(c))
+	}
+	return factories
+}
+
+// runContexts applies the package to the cluster of every context in
+// --contexts. The package is loaded separately for every cluster, since
+// the scope of resources depends on the cluster. The same inventory is
+// used for every cluster, since each cluster keeps its own inventory
+// object. Every line of output is prefixed with the context it is for,
+// and a summary of the applies is printed at the end.
+func (r *Runner) runContexts(in io.Reader, args []string) error {
+	var stdin []byte
+	if len(args) > 0 && args[0] == "-" {
+		var err error
+		if stdin, err = io.ReadAll(in); err != nil {
+			return err
+		}
+	}
+
+	factories := r.factories()
+	errs := make([]error, len(r.contexts))
+	var mu sync.Mutex
+	applyContext := func(i int) {
+		out := &prefixWriter{mu: &mu, out: r.ioStreams.Out, prefix: fmt.Sprintf("[%s] ", r.contexts[i])}
+		errOut := &prefixWriter{mu: &mu, out: r.ioStreams.ErrOut, prefix: out.prefix}
+		cr := *r
+		cr.factory = factories[i]
+		cr.ioStreams = genericclioptions.IOStreams{In: r.ioStreams.In, Out: out, ErrOut: errOut}
+		errs[i] = cr.run(bytes.NewReader(stdin), args)
+		out.Flush()
+		errOut.Flush()
+	}
+	if r.parallel {
+		limit := len(r.contexts)
+		if r.maxParallelism > 0 && r.maxParallelism < limit {
+			limit = r.maxParallelism
+		}
+		sem := make(chan struct{}, limit)
+		var wg sync.WaitGroup
+		for i := range r.contexts {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				applyContext(i)
+			}(i)
+		}
+		wg.Wait()
+	} else {
+		for i := range r.contexts {
+			applyContext(i)
+		}
+	}
+
+	var failed int
+	w := tabwriter.NewWriter(r.ioStreams.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nCONTEXT\tRESULT")
+	for i, c := range r.contexts {
+		result := "Succeeded"
+		if errs[i] != nil {
+			failed++
+			result = "Failed: " + strings.SplitN(errs[i].Error(), "\n", 2)[0]
+		}
+		fmt.Fprintf(w, "%s\t%s\n", c, result)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("apply failed for %d of %d contexts", failed, len(r.contexts))
+	}
+	return nil
+}
+
+// prefixWriter writes the lines written to it to out, each with prefix.
+// Writes to out are serialized with mu, so the lines of prefixWriters that
+// share out are not mixed.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush writes the last line if it isn't terminated by a newline.
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		_ = w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	return err
+}
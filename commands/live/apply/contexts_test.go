@@ -0,0 +1,218 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/testutil"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+func TestCmd_contexts(t *testing.T) {
+	testCases := map[string]struct {
+		args             []string
+		failContexts     map[string]bool
+		expectedApplied  []string
+		expectedOutput   string
+		expectedErrorMsg string
+	}{
+		"parallel requires contexts": {
+			args:             []string{"--parallel"},
+			expectedErrorMsg: "--parallel can only be used with --contexts",
+		},
+		"max-parallelism requires parallel": {
+			args:             []string{"--contexts", "a,b", "--max-parallelism", "1"},
+			expectedErrorMsg: "--max-parallelism can only be used with --parallel",
+		},
+		"contexts can't be used with a plan": {
+			args:             []string{"--contexts", "a,b", "--plan", "plan.yaml"},
+			expectedErrorMsg: "--contexts can't be used with --plan",
+		},
+		"parallel can't be used with table output": {
+			args:             []string{"--contexts", "a,b", "--parallel", "--output", "table"},
+			expectedErrorMsg: "--parallel can't be used with --output=table",
+		},
+		"contexts must be unique": {
+			args:             []string{"--contexts", "a,b,a"},
+			expectedErrorMsg: `context "a" is listed more than once in --contexts`,
+		},
+		"applies to every context in order": {
+			args:            []string{"--contexts", "a,b"},
+			expectedApplied: []string{"a", "b"},
+			expectedOutput: `[a] applied my-inv-id
+[b] applied my-inv-id
+
+CONTEXT  RESULT
+a        Succeeded
+b        Succeeded
+`,
+		},
+		"continues after a failed context": {
+			args:            []string{"--contexts", "a,b,c"},
+			failContexts:    map[string]bool{"b": true},
+			expectedApplied: []string{"a", "b", "c"},
+			expectedOutput: `[a] applied my-inv-id
+[b] applied my-inv-id
+[c] applied my-inv-id
+
+CONTEXT  RESULT
+a        Succeeded
+b        Failed: apply failed in b
+c        Succeeded
+`,
+			expectedErrorMsg: "apply failed for 1 of 3 contexts",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("testns")
+			defer tf.Cleanup()
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			w, clean := testutil.SetupWorkspace(t)
+			defer clean()
+			kf := kptfileutil.DefaultKptfile(filepath.Base(w.WorkspaceDirectory))
+			kf.Inventory = &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+			}
+			testutil.AddKptfileToWorkspace(t, w, kf)
+
+			revert := testutil.Chdir(t, w.WorkspaceDirectory)
+			defer revert()
+
+			contextFactories := map[util.Factory]string{}
+			var mu sync.Mutex
+			var applied []string
+			runner := NewRunner(fake.CtxWithDefaultPrinter(), tf, ioStreams, false)
+			runner.ContextFactory = func(kubeContext string) util.Factory {
+				f := cmdtesting.NewTestFactory().WithNamespace("testns")
+				t.Cleanup(f.Cleanup)
+				contextFactories[f] = kubeContext
+				return f
+			}
+			runner.Command.SetArgs(tc.args)
+			runner.applyRunner = func(r *Runner, inv inventory.Info,
+				_ []*unstructured.Unstructured, _ common.DryRunStrategy) error {
+				kubeContext, found := contextFactories[r.factory]
+				require.True(t, found)
+				mu.Lock()
+				applied = append(applied, kubeContext)
+				mu.Unlock()
+				fmt.Fprintf(r.ioStreams.Out, "applied %s\n", inv.ID())
+				if tc.failContexts[kubeContext] {
+					return fmt.Errorf("apply failed in %s", kubeContext)
+				}
+				return nil
+			}
+			err := runner.Command.Execute()
+
+			if tc.expectedErrorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedApplied, applied)
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
+
+func TestCmd_contextsParallel(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("testns")
+	defer tf.Cleanup()
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+	w, clean := testutil.SetupWorkspace(t)
+	defer clean()
+	kf := kptfileutil.DefaultKptfile(filepath.Base(w.WorkspaceDirectory))
+	kf.Inventory = &kptfilev1.Inventory{
+		Namespace:   "my-ns",
+		Name:        "my-name",
+		InventoryID: "my-inv-id",
+	}
+	testutil.AddKptfileToWorkspace(t, w, kf)
+
+	revert := testutil.Chdir(t, w.WorkspaceDirectory)
+	defer revert()
+
+	runner := NewRunner(fake.CtxWithDefaultPrinter(), tf, ioStreams, false)
+	runner.ContextFactory = func(string) util.Factory {
+		f := cmdtesting.NewTestFactory().WithNamespace("testns")
+		t.Cleanup(f.Cleanup)
+		return f
+	}
+	runner.Command.SetArgs([]string{"--contexts", "a,b,c", "--parallel", "--max-parallelism", "2"})
+	var mu sync.Mutex
+	var running, maxRunning int
+	runner.applyRunner = func(r *Runner, _ inventory.Info,
+		_ []*unstructured.Unstructured, _ common.DryRunStrategy) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+		// Write the line in two parts to check that the lines of the
+		// applies are not mixed.
+		fmt.Fprint(r.ioStreams.Out, "first ")
+		fmt.Fprint(r.ioStreams.Out, "line\nlast line without newline")
+		return nil
+	}
+	require.NoError(t, runner.Command.Execute())
+	assert.LessOrEqual(t, maxRunning, 2)
+
+	for _, c := range []string{"a", "b", "c"} {
+		assert.Contains(t, out.String(), fmt.Sprintf("[%s] first line\n[%s] last line without newline\n", c, c))
+		assert.Contains(t, out.String(), fmt.Sprintf("\n%s        Succeeded\n", c))
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &prefixWriter{mu: &sync.Mutex{}, out: &out, prefix: "> "}
+	_, err := w.Write([]byte("a\nb"))
+	require.NoError(t, err)
+	assert.Equal(t, "> a\n", out.String())
+	_, err = w.Write([]byte("c\n\nd"))
+	require.NoError(t, err)
+	w.Flush()
+	assert.Equal(t, "> a\n> bc\n> \n> d\n", out.String())
+}
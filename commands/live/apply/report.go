@@ -0,0 +1,119 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"fmt"
+	"net/http"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+// defaultReportTimeout is the default timeout for posting the report to a
+// webhook, so an unresponsive webhook doesn't block the command forever.
+const defaultReportTimeout = 30 * time.Second
+
+// validateReport validates the --report and --report-signing-key flags.
+func (r *Runner) validateReport() error {
+	if r.reportDest == "" {
+		if r.reportSigningKey != "" {
+			return fmt.Errorf("--report-signing-key can only be used with --report")
+		}
+		return nil
+	}
+	if r.reportTimeout < 0 {
+		return fmt.Errorf("--report-timeout must not be negative")
+	}
+	if len(r.contexts) > 0 {
+		return fmt.Errorf("--report can't be used with --contexts")
+	}
+	if r.reportSigningKey != "" {
+		// The key is read before the apply, so that an invalid key doesn't
+		// leave an apply without a report.
+		if _, err := live.ReadSigningKey(r.reportSigningKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeReport writes the report of the apply of objs, which ended with
+// applyErr, to the destination of --report. It returns applyErr, or the
+// error writing the report if the apply succeeded.
+func (r *Runner) writeReport(invInfo inventory.Info, objs []*unstructured.Unstructured, applyErr error) error {
+	err := r.sendReport(invInfo, objs, applyErr)
+	if err == nil {
+		return applyErr
+	}
+	if applyErr != nil {
+		fmt.Fprintf(r.ioStreams.ErrOut, "error: %v\n", err)
+		return applyErr
+	}
+	return err
+}
+
+func (r *Runner) sendReport(invInfo inventory.Info, objs []*unstructured.Unstructured, applyErr error) error {
+	report, err := r.reporter.Report(invInfo, objs, r.dryRun, applyErr)
+	if err != nil {
+		return err
+	}
+	if u, err := user.Current(); err == nil {
+		report.Spec.User = u.Username
+	}
+	if cfg, err := r.factory.ToRESTConfig(); err == nil {
+		report.Spec.Cluster = cfg.Host
+	}
+	report.Spec.Commit, report.Spec.UncommittedChanges = r.commit()
+
+	if r.reportSigningKey != "" {
+		key, err := live.ReadSigningKey(r.reportSigningKey)
+		if err != nil {
+			return err
+		}
+		if err := report.Sign(key); err != nil {
+			return err
+		}
+	}
+	return report.Send(r.ctx, &http.Client{Timeout: r.reportTimeout}, r.reportDest)
+}
+
+// commit returns the git commit of the applied package, and whether the
+// package has uncommitted changes. The commit is empty if the package
+// isn't in a git repository.
+func (r *Runner) commit() (string, bool) {
+	if r.pkgPath == "" {
+		return "", false
+	}
+	gitRunner, err := gitutil.NewLocalGitRunner(r.pkgPath)
+	if err != nil {
+		return "", false
+	}
+	rr, err := gitRunner.Run(r.ctx, "rev-parse", "HEAD")
+	if err != nil {
+		return "", false
+	}
+	commit := strings.TrimSpace(rr.Stdout)
+	rr, err = gitRunner.Run(r.ctx, "status", "--porcelain", ".")
+	if err != nil {
+		return commit, false
+	}
+	return commit, strings.TrimSpace(rr.Stdout) != ""
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
 	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/strings"
 	"github.com/GoogleContainerTools/kpt/pkg/live"
 	"github.com/GoogleContainerTools/kpt/pkg/status"
@@ -46,12 +47,13 @@ func NewRunner(
 		destroyRunner: runDestroy,
 	}
 	c := &cobra.Command{
-		Use:     "destroy [PKG_PATH | -]",
-		RunE:    r.runE,
-		PreRunE: r.preRunE,
-		Short:   livedocs.DestroyShort,
-		Long:    livedocs.DestroyShort + "\n" + livedocs.DestroyLong,
-		Example: livedocs.DestroyExamples,
+		Use:               "destroy [PKG_PATH | -]",
+		RunE:              r.runE,
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+		Short:             livedocs.DestroyShort,
+		Long:              livedocs.DestroyShort + "\n" + livedocs.DestroyLong,
+		Example:           livedocs.DestroyExamples,
 	}
 	r.Command = c
 
@@ -194,6 +196,14 @@ func runDestroy(r *Runner, inv inventory.Info, dryRunStrategy common.DryRunStrat
 	}
 	ch := destroyer.Run(context.Background(), inv, options)
 
+	// Spans and metrics are recorded for every action group and object of
+	// the destroy. They are only exported if configured in the environment.
+	ch, finishTelemetry, err := live.RunTelemetry(r.ctx, "destroy", inv, ch, r.ioStreams.ErrOut)
+	if err != nil {
+		return err
+	}
+	defer finishTelemetry()
+
 	// Print the preview strategy unless the output format is json.
 	if dryRunStrategy.ClientOrServerDryRun() && r.output != printers.JSONPrinter {
 		if dryRunStrategy.ServerDryRun() {
@@ -205,5 +215,18 @@ func runDestroy(r *Runner, inv inventory.Info, dryRunStrategy common.DryRunStrat
 	// The printer will print updates from the channel. It will block
 	// until the channel is closed.
 	printer := printers.GetPrinter(r.output, r.ioStreams)
-	return printer.Print(ch, dryRunStrategy, r.printStatusEvents)
+	if err := printer.Print(ch, dryRunStrategy, r.printStatusEvents); err != nil {
+		return err
+	}
+	if dryRunStrategy.ClientOrServerDryRun() {
+		return nil
+	}
+
+	// The snapshots recorded by kpt live apply can't be rolled back to once
+	// the inventory is gone.
+	store, err := live.NewSnapshotStore(r.factory)
+	if err != nil {
+		return err
+	}
+	return store.Delete(r.ctx, inv)
 }
@@ -30,12 +30,15 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/pkg"
 	"github.com/GoogleContainerTools/kpt/internal/types"
 	"github.com/GoogleContainerTools/kpt/internal/util/attribution"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	rgfilev1alpha1 "github.com/GoogleContainerTools/kpt/pkg/api/resourcegroup/v1alpha1"
 	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	k8scmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -75,22 +78,30 @@ func (i *InvInKfExistsError) Error() string {
 func NewRunner(ctx context.Context, factory k8scmdutil.Factory,
 	ioStreams genericclioptions.IOStreams) *Runner {
 	r := &Runner{
-		ctx:       ctx,
-		factory:   factory,
-		ioStreams: ioStreams,
+		ctx:             ctx,
+		factory:         factory,
+		ioStreams:       ioStreams,
+		listInventories: live.ListInventories,
 	}
 
 	cmd := &cobra.Command{
-		Use:     "init [PKG_PATH]",
-		PreRunE: r.preRunE,
-		RunE:    r.runE,
-		Short:   livedocs.InitShort,
-		Long:    livedocs.InitShort + "\n" + livedocs.InitLong,
-		Example: livedocs.InitExamples,
+		Use:               "init [PKG_PATH]",
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+		RunE:              r.runE,
+		Short:             livedocs.InitShort,
+		Long:              livedocs.InitShort + "\n" + livedocs.InitLong,
+		Example:           livedocs.InitExamples,
 	}
 	r.Command = cmd
 
 	cmd.Flags().StringVar(&r.Name, "name", "", "Inventory object name")
+	cmd.Flags().StringVar(&r.NameTemplate, "name-template", "",
+		"Go template for the inventory object name, e.g. '{{.Repo}}-{{.Path}}'")
+	cmd.Flags().StringVar(&r.NamespaceTemplate, "namespace-template", "",
+		"Go template for the inventory object namespace, e.g. '{{.Package}}'")
+	cmd.Flags().BoolVar(&r.SkipCollisionCheck, "skip-collision-check", false,
+		"If true, do not check the cluster for inventories that the package would share")
 	cmd.Flags().BoolVar(&r.Force, "force", false, "Set inventory values even if already set in Kptfile or ResourceGroup file")
 	cmd.Flags().BoolVar(&r.Quiet, "quiet", false, "If true, do not print output message for initialization")
 	cmd.Flags().StringVar(&r.InventoryID, "inventory-id", "", "Inventory id for the package")
@@ -114,6 +125,12 @@ type Runner struct {
 	RGFileName  string // resourcegroup object filename
 	InventoryID string // Inventory object unique identifier label
 	Quiet       bool   // Output message during initialization
+
+	NameTemplate       string // Template for the inventory object name
+	NamespaceTemplate  string // Template for the inventory object namespace
+	SkipCollisionCheck bool   // Do not check the cluster for colliding inventories
+
+	listInventories ListInventoriesFunc
 }
 
 func (r *Runner) preRunE(_ *cobra.Command, _ []string) error {
@@ -121,10 +138,13 @@ func (r *Runner) preRunE(_ *cobra.Command, _ []string) error {
 	if dir != "." {
 		return fmt.Errorf("rg-file must be a valid filename")
 	}
+	if r.Name != "" && r.NameTemplate != "" {
+		return fmt.Errorf("--name and --name-template can't be used together")
+	}
 	return nil
 }
 
-func (r *Runner) runE(_ *cobra.Command, args []string) error {
+func (r *Runner) runE(c *cobra.Command, args []string) error {
 	const op errors.Op = "cmdliveinit.runE"
 	if len(args) == 0 {
 		// default to the current working directory
@@ -150,15 +170,24 @@ func (r *Runner) runE(_ *cobra.Command, args []string) error {
 		return errors.E(op, err)
 	}
 
-	err = (&ConfigureInventoryInfo{
-		Pkg:         p,
-		Factory:     r.factory,
-		Quiet:       r.Quiet,
-		Name:        r.Name,
-		InventoryID: r.InventoryID,
-		RGFileName:  r.RGFileName,
-		Force:       r.Force,
-	}).Run(r.ctx)
+	cfg := &ConfigureInventoryInfo{
+		Pkg:               p,
+		Factory:           r.factory,
+		Quiet:             r.Quiet,
+		Name:              r.Name,
+		NameTemplate:      r.NameTemplate,
+		NamespaceTemplate: r.NamespaceTemplate,
+		InventoryID:       r.InventoryID,
+		RGFileName:        r.RGFileName,
+		Force:             r.Force,
+	}
+	if f := c.Flag("context"); f != nil {
+		cfg.KubeContext = f.Value.String()
+	}
+	if !r.SkipCollisionCheck {
+		cfg.ListInventories = r.listInventories
+	}
+	err = cfg.Run(r.ctx)
 	if err != nil {
 		return errors.E(op, p.UniquePath, err)
 	}
@@ -176,6 +205,21 @@ type ConfigureInventoryInfo struct {
 	InventoryID string
 	RGFileName  string
 
+	// NameTemplate and NamespaceTemplate are Go templates for the name
+	// and namespace of the inventory. NameTemplate is only used if Name is
+	// empty.
+	NameTemplate      string
+	NamespaceTemplate string
+	// KubeContext is the kubeconfig context given with --context, if any.
+	KubeContext string
+
+	// ListInventories lists the inventories in the cluster, to check
+	// that the inventory of the package isn't shared with another
+	// package. The check is skipped if it is nil, or if neither the name
+	// nor the inventory id is given, since a generated inventory can't
+	// collide.
+	ListInventories ListInventoriesFunc
+
 	Force bool
 }
 
@@ -183,17 +227,43 @@ type ConfigureInventoryInfo struct {
 func (c *ConfigureInventoryInfo) Run(ctx context.Context) error {
 	const op errors.Op = "cmdliveinit.Run"
 	pr := printer.FromContextOrDie(ctx)
-
-	namespace, err := config.FindNamespace(c.Factory.ToRawKubeConfigLoader(), c.Pkg.UniquePath.String())
-	if err != nil {
-		return errors.E(op, c.Pkg.UniquePath, err)
+	checkCollisions := c.ListInventories != nil &&
+		(c.Name != "" || c.NameTemplate != "" || c.InventoryID != "")
+
+	data := &templateData{
+		ctx:         ctx,
+		pkg:         c.Pkg,
+		factory:     c.Factory,
+		kubeContext: c.KubeContext,
 	}
-	namespace = strings.TrimSpace(namespace)
-	if !c.Quiet {
-		pr.Printf("initializing %q data (namespace: %s)...", c.RGFileName, namespace)
+	var namespace string
+	if c.NamespaceTemplate != "" {
+		t, err := parseNameTemplate("namespace-template", c.NamespaceTemplate)
+		if err != nil {
+			return errors.E(op, c.Pkg.UniquePath, errors.InvalidParam, err)
+		}
+		if namespace, err = executeNameTemplate(t, data, maxNamespaceLength); err != nil {
+			return errors.E(op, c.Pkg.UniquePath, err)
+		}
+	} else {
+		ns, err := config.FindNamespace(c.Factory.ToRawKubeConfigLoader(), c.Pkg.UniquePath.String())
+		if err != nil {
+			return errors.E(op, c.Pkg.UniquePath, err)
+		}
+		namespace = strings.TrimSpace(ns)
 	}
 
 	// Autogenerate the name if it is not provided through the flag.
+	if c.Name == "" && c.NameTemplate != "" {
+		t, err := parseNameTemplate("name-template", c.NameTemplate)
+		if err != nil {
+			return errors.E(op, c.Pkg.UniquePath, errors.InvalidParam, err)
+		}
+		data.namespace = namespace
+		if c.Name, err = executeNameTemplate(t, data, maxNameLength); err != nil {
+			return errors.E(op, c.Pkg.UniquePath, err)
+		}
+	}
 	if c.Name == "" {
 		randomSuffix := common.RandomStr()
 		c.Name = fmt.Sprintf("%s-%s", defaultInventoryName, randomSuffix)
@@ -201,18 +271,43 @@ func (c *ConfigureInventoryInfo) Run(ctx context.Context) error {
 
 	// Autogenerate the inventory ID if not provided through the flag.
 	if c.InventoryID == "" {
-		c.InventoryID, err = generateID(namespace, c.Name, time.Now())
+		id, err := generateID(namespace, c.Name, time.Now())
 		if err != nil {
 			return errors.E(op, c.Pkg.UniquePath, err)
 		}
+		c.InventoryID = id
 	}
-
-	// Finally, create a ResourceGroup containing the inventory information.
-	err = createRGFile(c.Pkg, &kptfilev1.Inventory{
+	inv := &kptfilev1.Inventory{
 		Namespace:   namespace,
 		Name:        c.Name,
 		InventoryID: c.InventoryID,
-	}, c.RGFileName, c.Force)
+	}
+
+	// Check that no other package uses the same inventory before writing
+	// it, since the packages would prune each other's resources.
+	if checkCollisions {
+		listCtx, cancel := context.WithTimeout(ctx, collisionCheckTimeout)
+		inventories, err := c.ListInventories(listCtx, c.Factory, "")
+		cancel()
+		switch {
+		case err == nil:
+			if collisions := findCollisions(*inv, inventories); len(collisions) > 0 {
+				return errors.E(op, c.Pkg.UniquePath, &InvCollisionError{Inventory: *inv, Collisions: collisions})
+			}
+		case meta.IsNoMatchError(err):
+			// The ResourceGroup CRD isn't installed, so there are no
+			// inventories.
+		default:
+			fmt.Fprintf(pr.ErrStream(), "warning: unable to check the cluster for inventory collisions: %v\n", err)
+		}
+	}
+
+	if !c.Quiet {
+		pr.Printf("initializing %q data (namespace: %s)...", c.RGFileName, namespace)
+	}
+
+	// Finally, create a ResourceGroup containing the inventory information.
+	err := createRGFile(c.Pkg, inv, c.RGFileName, c.Force)
 	if !c.Quiet {
 		if err == nil {
 			pr.Printf("success\n")
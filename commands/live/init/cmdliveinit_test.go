@@ -15,9 +15,13 @@
 package init
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,10 +29,12 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/testutil"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	rgfilev1alpha1 "github.com/GoogleContainerTools/kpt/pkg/api/resourcegroup/v1alpha1"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
 	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	k8scmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 )
 
@@ -351,3 +357,132 @@ func assertGenInvID(t *testing.T, name, namespace, actual string) bool {
 	}
 	return true
 }
+
+func TestCmd_templatesAndCollisions(t *testing.T) {
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"remote", "add", "origin", "git@github.com:example/platform.git"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput()
+		if !assert.NoError(t, err, string(out)) {
+			t.FailNow()
+		}
+	}
+	pkgDir := filepath.Join(repo, "apps", "Web_Frontend")
+	if !assert.NoError(t, os.MkdirAll(pkgDir, 0700)) ||
+		!assert.NoError(t, os.WriteFile(filepath.Join(pkgDir, kptfilev1.KptFileName), []byte(kptFile), 0600)) {
+		t.FailNow()
+	}
+
+	testCases := map[string]struct {
+		args        []string
+		inventories []live.InventorySummary
+		listErr     error
+
+		expectedName      string
+		expectedNamespace string
+		expectedErrorMsg  string
+	}{
+		"templates are rendered into valid names": {
+			args:              []string{"--name-template", "{{.Repo}}-{{.Path}}", "--namespace-template", "team-{{.Package}}"},
+			expectedName:      "platform-apps-web-frontend",
+			expectedNamespace: "team-web-frontend",
+		},
+		"name template can use the namespace": {
+			args:              []string{"--name-template", "{{.Namespace}}.{{.Package}}"},
+			expectedName:      "testns-web-frontend",
+			expectedNamespace: "testns",
+		},
+		"name and name template can't be used together": {
+			args:             []string{"--name", "foo", "--name-template", "{{.Package}}"},
+			expectedErrorMsg: "--name and --name-template can't be used together",
+		},
+		"invalid template is an error": {
+			args:             []string{"--name-template", "{{.Unknown}}"},
+			expectedErrorMsg: "unable to execute --name-template",
+		},
+		"the same inventory of the same package is not a collision": {
+			args: []string{"--name", "web", "--inventory-id", "web-id"},
+			inventories: []live.InventorySummary{
+				{Name: "web", Namespace: "testns", ID: "web-id"},
+				{Name: "other", Namespace: "testns", ID: "other-id"},
+			},
+			expectedName:      "web",
+			expectedNamespace: "testns",
+		},
+		"inventory with the same name is a collision": {
+			args: []string{"--name", "web", "--inventory-id", "web-id"},
+			inventories: []live.InventorySummary{
+				{Name: "web", Namespace: "testns", ID: "other-id"},
+			},
+			expectedErrorMsg: `inventory testns/web already exists in the cluster with inventory id "other-id"`,
+		},
+		"inventory with the same id is a collision": {
+			args: []string{"--name", "web", "--inventory-id", "web-id"},
+			inventories: []live.InventorySummary{
+				{Name: "web", Namespace: "prod", ID: "web-id"},
+			},
+			expectedErrorMsg: `inventory id "web-id" is already used by inventory prod/web`,
+		},
+		"collisions are not checked if skipped": {
+			args: []string{"--name", "web", "--inventory-id", "web-id", "--skip-collision-check"},
+			inventories: []live.InventorySummary{
+				{Name: "web", Namespace: "testns", ID: "other-id"},
+			},
+			expectedName:      "web",
+			expectedNamespace: "testns",
+		},
+		"collisions are not checked for a generated inventory": {
+			listErr:           fmt.Errorf("must not be listed"),
+			expectedNamespace: "testns",
+		},
+		"failing to list inventories is not an error": {
+			args:              []string{"--name", "web"},
+			listErr:           fmt.Errorf("connection refused"),
+			expectedName:      "web",
+			expectedNamespace: "testns",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("testns")
+			defer tf.Cleanup()
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			_ = os.Remove(filepath.Join(pkgDir, rgfilev1alpha1.RGFileName))
+
+			runner := NewRunner(fake.CtxWithDefaultPrinter(), tf, ioStreams)
+			listed := false
+			runner.listInventories = func(ctx context.Context, _ k8scmdutil.Factory, _ string) ([]live.InventorySummary, error) {
+				listed = true
+				_, hasDeadline := ctx.Deadline()
+				assert.True(t, hasDeadline, "listing the inventories must be bounded")
+				return tc.inventories, tc.listErr
+			}
+			runner.Command.SilenceUsage = true
+			runner.Command.SetArgs(append([]string{pkgDir}, tc.args...))
+			err := runner.Command.Execute()
+			if tc.expectedErrorMsg != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMsg)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+			rg, err := pkg.ReadRGFile(pkgDir, rgfilev1alpha1.RGFileName)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+			if tc.expectedName == "" {
+				assert.False(t, listed, "inventories must not be listed for a generated inventory")
+				assert.True(t, strings.HasPrefix(rg.Name, defaultInventoryName+"-"))
+			} else {
+				assert.Equal(t, tc.expectedName, rg.Name)
+			}
+			assert.Equal(t, tc.expectedNamespace, rg.Namespace)
+		})
+	}
+}
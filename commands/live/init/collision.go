@@ -0,0 +1,73 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package init
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	k8scmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// collisionCheckTimeout bounds the listing of the inventories in the cluster
+// for the collision check, so an unreachable cluster doesn't block the init.
+const collisionCheckTimeout = 10 * time.Second
+
+// ListInventoriesFunc returns the inventories in the cluster, in namespace
+// or in all namespaces if namespace is empty.
+type ListInventoriesFunc func(ctx context.Context, factory k8scmdutil.Factory, namespace string) ([]live.InventorySummary, error)
+
+// InvCollisionError is returned when the inventory of a package would be
+// shared with another package, because an inventory with the same name or
+// inventory id already exists in the cluster.
+type InvCollisionError struct {
+	Inventory  kptfilev1.Inventory
+	Collisions []live.InventorySummary
+}
+
+func (e *InvCollisionError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "inventory %s/%s would be shared with another package:", e.Inventory.Namespace, e.Inventory.Name)
+	for _, c := range e.Collisions {
+		if c.Name == e.Inventory.Name && c.Namespace == e.Inventory.Namespace {
+			fmt.Fprintf(&b, "\n  inventory %s/%s already exists in the cluster with inventory id %q",
+				c.Namespace, c.Name, c.ID)
+		} else {
+			fmt.Fprintf(&b, "\n  inventory id %q is already used by inventory %s/%s", c.ID, c.Namespace, c.Name)
+		}
+	}
+	b.WriteString("\nUse another name or inventory id, or --inventory-id with the id of the existing inventory " +
+		"if the package is the one it was created for.")
+	return b.String()
+}
+
+// findCollisions returns the inventories in the cluster that have the name
+// and namespace of inv but another inventory id, or the inventory id of inv
+// but another name or namespace.
+func findCollisions(inv kptfilev1.Inventory, inventories []live.InventorySummary) []live.InventorySummary {
+	var collisions []live.InventorySummary
+	for _, s := range inventories {
+		sameName := s.Name == inv.Name && s.Namespace == inv.Namespace
+		sameID := s.ID == inv.InventoryID
+		if sameName != sameID {
+			collisions = append(collisions, s)
+		}
+	}
+	return collisions
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package init
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	k8scmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+const (
+	// maxNamespaceLength is the maximum length of a namespace, which must
+	// be a DNS label.
+	maxNamespaceLength = 63
+	// maxNameLength is the maximum length of the name of a ResourceGroup,
+	// which must be a DNS subdomain.
+	maxNameLength = 253
+)
+
+// parseNameTemplate parses an inventory name or namespace template.
+func parseNameTemplate(flag, text string) (*template.Template, error) {
+	t, err := template.New(flag).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", flag, err)
+	}
+	return t, nil
+}
+
+// templateData is the data the inventory name and namespace templates are
+// executed with. The values are computed when the template uses them, so
+// that a template that doesn't use the git repository of the package works
+// outside of git.
+type templateData struct {
+	ctx         context.Context
+	pkg         *pkg.Pkg
+	factory     k8scmdutil.Factory
+	kubeContext string
+	namespace   string
+
+	gitRoot string
+}
+
+// Package returns the name of the directory of the package.
+func (d *templateData) Package() string {
+	return filepath.Base(d.pkg.UniquePath.String())
+}
+
+// Namespace returns the namespace of the inventory. It is only set for the
+// name template.
+func (d *templateData) Namespace() (string, error) {
+	if d.namespace == "" {
+		return "", fmt.Errorf("the namespace can only be used in --name-template")
+	}
+	return d.namespace, nil
+}
+
+// Repo returns the name of the git repository of the package: the last
+// element of the URL of its origin remote, or the name of the directory of
+// the repository if it has no origin.
+func (d *templateData) Repo() (string, error) {
+	root, err := d.root()
+	if err != nil {
+		return "", err
+	}
+	git, err := gitutil.NewLocalGitRunner(root)
+	if err != nil {
+		return "", err
+	}
+	rr, err := git.Run(d.ctx, "remote", "get-url", "origin")
+	if err != nil {
+		return filepath.Base(root), nil
+	}
+	url := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(rr.Stdout), "/"), ".git")
+	// scp-like URLs, e.g. git@github.com:org/repo, have no slash before
+	// the path.
+	if i := strings.LastIndexAny(url, "/:"); i >= 0 {
+		url = url[i+1:]
+	}
+	return url, nil
+}
+
+// Path returns the path of the package relative to the root of its git
+// repository.
+func (d *templateData) Path() (string, error) {
+	root, err := d.root()
+	if err != nil {
+		return "", err
+	}
+	dir, err := filepath.EvalSymlinks(d.pkg.UniquePath.String())
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// Cluster returns the name of the cluster of the kubeconfig context.
+func (d *templateData) Cluster() (string, error) {
+	raw, err := d.factory.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return "", err
+	}
+	kubeContext := d.kubeContext
+	if kubeContext == "" {
+		kubeContext = raw.CurrentContext
+	}
+	c, found := raw.Contexts[kubeContext]
+	if !found || c.Cluster == "" {
+		return "", fmt.Errorf("no cluster found for kubeconfig context %q", kubeContext)
+	}
+	return c.Cluster, nil
+}
+
+func (d *templateData) root() (string, error) {
+	if d.gitRoot != "" {
+		return d.gitRoot, nil
+	}
+	git, err := gitutil.NewLocalGitRunner(d.pkg.UniquePath.String())
+	if err != nil {
+		return "", err
+	}
+	rr, err := git.Run(d.ctx, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("package %s is not in a git repository", d.pkg.UniquePath)
+	}
+	root, err := filepath.EvalSymlinks(strings.TrimSpace(rr.Stdout))
+	if err != nil {
+		return "", err
+	}
+	d.gitRoot = root
+	return root, nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// executeNameTemplate executes t and turns the result into a valid name of
+// at most maxLength characters: it is lowercased, runs of characters that
+// are not alphanumeric are replaced with a dash, e.g. the slashes of a
+// path, and leading and trailing dashes are trimmed.
+func executeNameTemplate(t *template.Template, data *templateData, maxLength int) (string, error) {
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("unable to execute --%s: %w", t.Name(), err)
+	}
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(b.String()), "-")
+	name = strings.Trim(name, "-")
+	if len(name) > maxLength {
+		name = strings.TrimRight(name[:maxLength], "-")
+	}
+	if name == "" {
+		return "", fmt.Errorf("--%s %q results in an empty name", t.Name(), t.Root.String())
+	}
+	return name, nil
+}
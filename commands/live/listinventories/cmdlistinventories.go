@@ -0,0 +1,150 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listinventories
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/cmd/util"
+)
+
+// NewRunner returns a command runner
+func NewRunner(ctx context.Context, factory util.Factory, ioStreams genericclioptions.IOStreams) *Runner {
+	r := &Runner{
+		ctx:             ctx,
+		factory:         factory,
+		ioStreams:       ioStreams,
+		now:             time.Now,
+		listInventories: live.ListInventories,
+		deleteInventory: live.DeleteInventory,
+	}
+	c := &cobra.Command{
+		Use:     "list-inventories",
+		Args:    cobra.NoArgs,
+		PreRunE: r.PreRunE,
+		RunE:    r.RunE,
+		Short:   livedocs.ListInventoriesShort,
+		Long:    livedocs.ListInventoriesShort + "\n" + livedocs.ListInventoriesLong,
+		Example: livedocs.ListInventoriesExamples,
+	}
+	c.Flags().StringVar(&r.ttlString, "ttl", "",
+		"The time an inventory may go without being applied before it is considered abandoned, "+
+			"e.g. '30d' or '12h'. The kpt.dev/inventory-ttl annotation of an inventory takes precedence.")
+	c.Flags().BoolVar(&r.abandoned, "abandoned", false,
+		"If true, only list the abandoned inventories.")
+	c.Flags().BoolVar(&r.prune, "prune", false,
+		"If true, delete the abandoned inventories. The resources in the inventories are left in the cluster.")
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, factory util.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	return NewRunner(ctx, factory, ioStreams).Command
+}
+
+// Runner contains the run function for the list-inventories command
+type Runner struct {
+	ctx       context.Context
+	Command   *cobra.Command
+	factory   util.Factory
+	ioStreams genericclioptions.IOStreams
+
+	ttlString string
+	ttl       time.Duration
+	abandoned bool
+	prune     bool
+
+	// now, listInventories and deleteInventory are fields so they can be
+	// replaced in tests.
+	now             func() time.Time
+	listInventories func(ctx context.Context, factory util.Factory, namespace string) ([]live.InventorySummary, error)
+	deleteInventory func(ctx context.Context, factory util.Factory, s live.InventorySummary) error
+}
+
+func (r *Runner) PreRunE(_ *cobra.Command, _ []string) error {
+	if r.ttlString != "" {
+		ttl, err := live.ParseTTL(r.ttlString)
+		if err != nil {
+			return err
+		}
+		r.ttl = ttl
+	}
+	return nil
+}
+
+func (r *Runner) RunE(_ *cobra.Command, _ []string) error {
+	// List the inventories of all namespaces, unless a namespace is
+	// given explicitly.
+	namespace, explicit, err := r.factory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	if !explicit {
+		namespace = ""
+	}
+	invs, err := r.listInventories(r.ctx, r.factory, namespace)
+	if err != nil {
+		return err
+	}
+
+	now := r.now()
+	w := tabwriter.NewWriter(r.ioStreams.Out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tINVENTORY-ID\tRESOURCES\tAGE\tLAST-APPLIED\tOWNER\tABANDONED")
+	var abandoned []live.InventorySummary
+	for _, inv := range invs {
+		if inv.Invalid != nil {
+			fmt.Fprintf(r.ioStreams.ErrOut, "warning: skipping inventory %s/%s: %v\n", inv.Namespace, inv.Name, inv.Invalid)
+			continue
+		}
+		isAbandoned := inv.Abandoned(now, r.ttl)
+		if isAbandoned {
+			abandoned = append(abandoned, inv)
+		} else if r.abandoned {
+			continue
+		}
+		lastApplied := "<unknown>"
+		if last := inv.LastActivity(); !last.IsZero() {
+			lastApplied = duration.HumanDuration(now.Sub(last))
+		}
+		owner := inv.Owner
+		if owner == "" {
+			owner = "<none>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%t\n", inv.Namespace, inv.Name, inv.ID, inv.Resources,
+			duration.HumanDuration(now.Sub(inv.Created)), lastApplied, owner, isAbandoned)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !r.prune {
+		return nil
+	}
+	for _, inv := range abandoned {
+		if err := r.deleteInventory(r.ctx, r.factory, inv); err != nil {
+			return fmt.Errorf("failed to delete inventory %s/%s: %w", inv.Namespace, inv.Name, err)
+		}
+		fmt.Fprintf(r.ioStreams.Out, "deleted abandoned inventory %s/%s\n", inv.Namespace, inv.Name)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listinventories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/cmd/util"
+)
+
+func TestCmd(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	invs := []live.InventorySummary{
+		{
+			Name:        "active",
+			Namespace:   "ns-a",
+			ID:          "active-id",
+			Resources:   3,
+			Created:     now.Add(-90 * 24 * time.Hour),
+			LastApplied: now.Add(-5 * time.Hour),
+			Owner:       "team-a",
+		},
+		{
+			Name:        "stale",
+			Namespace:   "ns-b",
+			ID:          "stale-id",
+			Created:     now.Add(-60 * 24 * time.Hour),
+			LastUpdated: now.Add(-40 * 24 * time.Hour),
+		},
+		{
+			Name:      "unknown",
+			Namespace: "ns-b",
+			ID:        "unknown-id",
+			Created:   now.Add(-60 * 24 * time.Hour),
+		},
+		{
+			Name:        "invalid",
+			Namespace:   "ns-c",
+			ID:          "invalid-id",
+			Created:     now.Add(-60 * 24 * time.Hour),
+			LastApplied: now.Add(-40 * 24 * time.Hour),
+			Invalid:     fmt.Errorf("invalid kpt.dev/inventory-ttl annotation"),
+		},
+	}
+
+	testCases := map[string]struct {
+		args             []string
+		expectedOutput   string
+		expectedDeleted  []string
+		expectedErrorMsg string
+	}{
+		"invalid ttl": {
+			args:             []string{"--ttl", "soon"},
+			expectedErrorMsg: `invalid TTL "soon"`,
+		},
+		"list all": {
+			expectedOutput: `NAMESPACE   NAME      INVENTORY-ID   RESOURCES   AGE   LAST-APPLIED   OWNER    ABANDONED
+ns-a        active    active-id      3           90d   5h             team-a   false
+ns-b        stale     stale-id       0           60d   40d            <none>   false
+ns-b        unknown   unknown-id     0           60d   <unknown>      <none>   false
+`,
+		},
+		"list abandoned": {
+			args: []string{"--ttl", "30d", "--abandoned"},
+			expectedOutput: `NAMESPACE   NAME    INVENTORY-ID   RESOURCES   AGE   LAST-APPLIED   OWNER    ABANDONED
+ns-b        stale   stale-id       0           60d   40d            <none>   true
+`,
+		},
+		"prune abandoned": {
+			args: []string{"--ttl", "30d", "--prune"},
+			expectedOutput: `NAMESPACE   NAME      INVENTORY-ID   RESOURCES   AGE   LAST-APPLIED   OWNER    ABANDONED
+ns-a        active    active-id      3           90d   5h             team-a   false
+ns-b        stale     stale-id       0           60d   40d            <none>   true
+ns-b        unknown   unknown-id     0           60d   <unknown>      <none>   false
+deleted abandoned inventory ns-b/stale
+`,
+			expectedDeleted: []string{"stale"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory()
+			defer tf.Cleanup()
+			ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+
+			var deleted []string
+			runner := NewRunner(fake.CtxWithDefaultPrinter(), tf, ioStreams)
+			runner.now = func() time.Time { return now }
+			runner.listInventories = func(_ context.Context, _ util.Factory, namespace string) ([]live.InventorySummary, error) {
+				assert.Equal(t, "", namespace)
+				return invs, nil
+			}
+			runner.deleteInventory = func(_ context.Context, _ util.Factory, s live.InventorySummary) error {
+				deleted = append(deleted, s.Name)
+				return nil
+			}
+			runner.Command.SetArgs(tc.args)
+			err := runner.Command.Execute()
+
+			if tc.expectedErrorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, out.String())
+			assert.Equal(t, "warning: skipping inventory ns-c/invalid: invalid kpt.dev/inventory-ttl annotation\n", errOut.String())
+			assert.Equal(t, tc.expectedDeleted, deleted)
+		})
+	}
+}
@@ -22,8 +22,12 @@ import (
 	"github.com/GoogleContainerTools/kpt/commands/live/destroy"
 	initialization "github.com/GoogleContainerTools/kpt/commands/live/init"
 	"github.com/GoogleContainerTools/kpt/commands/live/installrg"
+	"github.com/GoogleContainerTools/kpt/commands/live/listinventories"
 	"github.com/GoogleContainerTools/kpt/commands/live/migrate"
+	"github.com/GoogleContainerTools/kpt/commands/live/plan"
+	"github.com/GoogleContainerTools/kpt/commands/live/rollback"
 	"github.com/GoogleContainerTools/kpt/commands/live/status"
+	"github.com/GoogleContainerTools/kpt/commands/live/wait"
 	"github.com/GoogleContainerTools/kpt/commands/util"
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
 	"github.com/GoogleContainerTools/kpt/pkg/live"
@@ -46,18 +50,25 @@ func GetCommand(ctx context.Context, _, version string) *cobra.Command {
 		ErrOut: os.Stderr,
 	}
 
-	f := util.NewFactory(liveCmd, version)
+	f, contextFactory := util.NewFactoryWithContexts(liveCmd, version)
 	invFactory := live.NewClusterClientFactory()
 	loader := status.NewRGInventoryLoader(ctx, f)
 
 	// Init command which updates a Kptfile for the ResourceGroup inventory object.
 	klog.V(2).Infoln("init command updates Kptfile for ResourceGroup inventory")
 	initCmd := initialization.NewCommand(ctx, f, ioStreams)
-	applyCmd := apply.NewCommand(ctx, f, ioStreams, false)
+	applyRunner := apply.NewRunner(ctx, f, ioStreams, false)
+	applyRunner.ContextFactory = contextFactory
+	applyCmd := applyRunner.Command
 	destroyCmd := destroy.NewCommand(ctx, f, ioStreams)
 	statusCmd := status.NewCommand(ctx, f, invFactory, loader)
 	installRGCmd := installrg.NewCommand(ctx, f, ioStreams)
-	liveCmd.AddCommand(initCmd, applyCmd, destroyCmd, statusCmd, installRGCmd)
+	rollbackCmd := rollback.NewCommand(ctx, f, ioStreams)
+	planCmd := plan.NewCommand(ctx, f, ioStreams)
+	listInventoriesCmd := listinventories.NewCommand(ctx, f, ioStreams)
+	waitCmd := wait.NewCommand(ctx, f, invFactory, loader)
+	liveCmd.AddCommand(initCmd, applyCmd, destroyCmd, statusCmd, installRGCmd, rollbackCmd, planCmd,
+		listInventoriesCmd, waitCmd)
 
 	// Add the migrate command to change from ConfigMap to ResourceGroup inventory
 	// object.
@@ -16,12 +16,15 @@ package plan
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
 	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
 	"github.com/GoogleContainerTools/kpt/pkg/live"
 	kptplanner "github.com/GoogleContainerTools/kpt/pkg/live/planner"
 	"github.com/spf13/cobra"
@@ -30,6 +33,7 @@ import (
 	"k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
 	print "sigs.k8s.io/cli-utils/pkg/print/common"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
@@ -43,6 +47,7 @@ const (
 	ContentPrefix = "\t\t"
 )
 
+// NewRunner returns a command runner
 func NewRunner(ctx context.Context, factory util.Factory, ioStreams genericclioptions.IOStreams) *Runner {
 	r := &Runner{
 		ctx:       ctx,
@@ -53,9 +58,13 @@ func NewRunner(ctx context.Context, factory util.Factory, ioStreams genericcliop
 		},
 	}
 	c := &cobra.Command{
-		Use:     "plan [PKG_PATH | -]",
-		PreRunE: r.PreRunE,
-		RunE:    r.RunE,
+		Use:               "plan [PKG_PATH | -]",
+		PreRunE:           r.PreRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+		RunE:              r.RunE,
+		Short:             livedocs.PlanShort,
+		Long:              livedocs.PlanShort + "\n" + livedocs.PlanLong,
+		Example:           livedocs.PlanExamples,
 	}
 	c.Flags().StringVar(&r.inventoryPolicyString, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
@@ -66,6 +75,8 @@ func NewRunner(ctx context.Context, factory util.Factory, ioStreams genericcliop
 		"The client owner of the fields being applied on the server-side.")
 	c.Flags().StringVar(&r.output, "output", "text",
 		"The output format for the plan. Must be either 'text' or 'krm'. Default is 'text'")
+	c.Flags().StringVar(&r.planFile, "plan-file", "",
+		"Path of the file to write the plan to. The plan can be applied with 'kpt live apply --plan'.")
 	r.Command = c
 
 	return r
@@ -75,6 +86,7 @@ func NewCommand(ctx context.Context, factory util.Factory, ioStreams genericclio
 	return NewRunner(ctx, factory, ioStreams).Command
 }
 
+// Runner contains the run function for the plan command
 type Runner struct {
 	ctx       context.Context
 	Command   *cobra.Command
@@ -84,14 +96,22 @@ type Runner struct {
 	inventoryPolicyString string
 	serverSideOptions     common.ServerSideOptions
 	output                string
+	planFile              string
+
+	// planRunner computes the plan. It is a field so it can be replaced
+	// in tests.
+	planRunner func(r *Runner, invInfo inventory.Info, objs []*unstructured.Unstructured) (*kptplanner.Plan, error)
 }
 
 func (r *Runner) PreRunE(_ *cobra.Command, _ []string) error {
+	if _, err := flagutils.ConvertInventoryPolicy(r.inventoryPolicyString); err != nil {
+		return err
+	}
 	return r.validateOutputFormat()
 }
 
 func (r *Runner) validateOutputFormat() error {
-	if !(r.output == "text" || r.output == "krm") {
+	if !(r.output == TextOutput || r.output == KRMOutput) {
 		return fmt.Errorf("unknown output format %q. Must be either 'text' or 'krm'", r.output)
 	}
 	return nil
@@ -125,6 +145,24 @@ func (r *Runner) RunE(c *cobra.Command, args []string) error {
 		return err
 	}
 
+	// objs may contain kind List
+	objs, err = live.Flatten(objs)
+	if err != nil {
+		return err
+	}
+
+	// The field manager, conflict policy and ignored fields set in the
+	// package are resolved here, so the plan records them.
+	policy, err := live.ReadServerSidePolicy(inv, objs)
+	if err != nil {
+		return err
+	}
+	r.serverSideOptions = policy.Merge(r.serverSideOptions,
+		c.Flags().Changed("field-manager"), c.Flags().Changed("force-conflicts"))
+	if err := live.RemoveIgnoredFields(objs, r.serverSideOptions.ServerSideApply); err != nil {
+		return err
+	}
+
 	// Convert the inventory data input to the format required by
 	// the actuation code.
 	invInfo, err := live.ToInventoryInfo(inv)
@@ -132,25 +170,72 @@ func (r *Runner) RunE(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Create and execute the planner.
-	planner, err := kptplanner.NewClusterPlanner(r.factory)
+	planRunner := r.planRunner
+	if planRunner == nil {
+		planRunner = buildPlan
+	}
+	plan, err := planRunner(r, invInfo, objs)
 	if err != nil {
 		return err
 	}
-	plan, err := planner.BuildPlan(r.ctx, invInfo, objs, kptplanner.Options{
-		ServerSideOptions: r.serverSideOptions,
+
+	planFile, err := kptplanner.NewPlanFile(plan, inv, objs, kptplanner.PlanOptions{
+		ServerSideApply: r.serverSideOptions.ServerSideApply,
+		ForceConflicts:  r.serverSideOptions.ForceConflicts,
+		FieldManager:    r.serverSideOptions.FieldManager,
+		InventoryPolicy: r.inventoryPolicyString,
 	})
 	if err != nil {
 		return err
 	}
 
 	switch r.output {
-	case "text":
-		return printText(plan, objs, r.ioStreams)
-	case "krm":
-		return printKRM(plan, r.ioStreams)
+	case TextOutput:
+		err = printText(plan, objs, r.ioStreams)
+	case KRMOutput:
+		err = printKRM(planFile, r.ioStreams)
+	default:
+		err = fmt.Errorf("unknown output format %s", r.output)
 	}
-	return fmt.Errorf("unknown output format %s", r.output)
+	if err != nil {
+		return err
+	}
+
+	// A plan with errors can't be applied, so it is not written.
+	if errs := planFile.Errors(); len(errs) > 0 {
+		return fmt.Errorf("unable to plan %d resource(s)", len(errs))
+	}
+	if r.planFile != "" {
+		return writePlanFile(planFile, r.planFile)
+	}
+	return nil
+}
+
+func buildPlan(r *Runner, invInfo inventory.Info, objs []*unstructured.Unstructured) (*kptplanner.Plan, error) {
+	inventoryPolicy, err := flagutils.ConvertInventoryPolicy(r.inventoryPolicyString)
+	if err != nil {
+		return nil, err
+	}
+	planner, err := kptplanner.NewClusterPlanner(r.factory)
+	if err != nil {
+		return nil, err
+	}
+	return planner.BuildPlan(r.ctx, invInfo, objs, kptplanner.Options{
+		ServerSideOptions: r.serverSideOptions,
+		InventoryPolicy:   inventoryPolicy,
+	})
+}
+
+func writePlanFile(planFile *kptplanner.PlanFile, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := planFile.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
 
 func printText(plan *kptplanner.Plan, objs []*unstructured.Unstructured, ioStreams genericclioptions.IOStreams) error {
@@ -208,7 +293,7 @@ func printEntry(prefix string, action kptplanner.Action, ioStreams genericcliopt
 }
 
 func findAndPrintDiff(before, after *unstructured.Unstructured, prefix string, ioStreams genericclioptions.IOStreams) {
-	diff, err := diffObjects(before, after)
+	diff, err := kptplanner.DiffObjects(before, after)
 	if err != nil {
 		panic(err)
 	}
@@ -264,63 +349,14 @@ func printWithPrefix(text, prefix string, ioStreams genericclioptions.IOStreams)
 
 // printKRM outputs the plan inside a ResourceList so the output format
 // follows the KRM function wire format.
-func printKRM(
-	plan *kptplanner.Plan,
-	ioStreams genericclioptions.IOStreams,
-) error {
-	planResource, err := yaml.Parse(strings.TrimSpace(`
-apiVersion: kpt.dev/v1alpha1
-kind: Plan
-metadata:
-  name: plan
-  annotations:
-    config.kubernetes.io/local-config: true	
-`))
-	if err != nil {
+func printKRM(planFile *kptplanner.PlanFile, ioStreams genericclioptions.IOStreams) error {
+	var b bytes.Buffer
+	if err := planFile.Write(&b); err != nil {
 		return fmt.Errorf("unable to create yaml document: %w", err)
 	}
-
-	sNode, err := planResource.Pipe(yaml.LookupCreate(yaml.SequenceNode, "spec", "actions"))
+	planResource, err := yaml.Parse(b.String())
 	if err != nil {
-		return fmt.Errorf("unable to update yaml document: %w", err)
-	}
-
-	for i := range plan.Actions {
-		action := plan.Actions[i]
-		a := yaml.NewRNode(&yaml.Node{Kind: yaml.MappingNode})
-		fields := map[string]*yaml.RNode{
-			"action":     yaml.NewScalarRNode(string(action.Type)),
-			"apiVersion": yaml.NewScalarRNode(action.Group),
-			"kind":       yaml.NewScalarRNode(action.Kind),
-			"name":       yaml.NewScalarRNode(action.Name),
-			"namespace":  yaml.NewScalarRNode(action.Namespace),
-		}
-		if action.Original != nil {
-			r, err := unstructuredToRNode(action.Original)
-			if err != nil {
-				return err
-			}
-			fields["original"] = r
-		}
-		if action.Updated != nil {
-			r, err := unstructuredToRNode(action.Updated)
-			if err != nil {
-				return err
-			}
-			fields["updated"] = r
-		}
-		if action.Error != "" {
-			fields["error"] = yaml.NewScalarRNode(action.Error)
-		}
-
-		for key, val := range fields {
-			if err := a.PipeE(yaml.SetField(key, val)); err != nil {
-				return fmt.Errorf("unable to update yaml document: %w", err)
-			}
-		}
-		if err := sNode.PipeE(yaml.Append(a.YNode())); err != nil {
-			return fmt.Errorf("unable to update yaml document: %w", err)
-		}
+		return fmt.Errorf("unable to create yaml document: %w", err)
 	}
 
 	writer := &kio.ByteWriter{
@@ -335,11 +371,3 @@ metadata:
 	}
 	return nil
 }
-
-func unstructuredToRNode(u *unstructured.Unstructured) (*yaml.RNode, error) {
-	b, err := yaml.Marshal(u.Object)
-	if err != nil {
-		return nil, err
-	}
-	return yaml.Parse((string(b)))
-}
@@ -0,0 +1,130 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/testutil"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
+	kptplanner "github.com/GoogleContainerTools/kpt/pkg/live/planner"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+func TestCmd(t *testing.T) {
+	testCases := map[string]struct {
+		args             []string
+		plan             *kptplanner.Plan
+		expectedOutput   string
+		expectedErrorMsg string
+		expectPlanFile   bool
+	}{
+		"invalid inventory policy": {
+			args:             []string{"--inventory-policy", "noSuchPolicy"},
+			expectedErrorMsg: "inventory policy must be one of strict, adopt",
+		},
+		"invalid output format": {
+			args:             []string{"--output", "foo"},
+			expectedErrorMsg: `unknown output format "foo"`,
+		},
+		"no changes": {
+			args:           []string{"--plan-file", "plan.yaml"},
+			plan:           &kptplanner.Plan{},
+			expectedOutput: "no changes found\n",
+			expectPlanFile: true,
+		},
+		"krm output": {
+			args: []string{"--output", "krm"},
+			plan: &kptplanner.Plan{
+				Actions: []kptplanner.Action{
+					{Type: kptplanner.Delete, Kind: "ConfigMap", Name: "cm", Namespace: "testns"},
+				},
+			},
+			expectedOutput: "kind: Plan",
+		},
+		"plan with errors is not written": {
+			args: []string{"--plan-file", "plan.yaml"},
+			plan: &kptplanner.Plan{
+				Actions: []kptplanner.Action{
+					{Type: kptplanner.Error, Kind: "ConfigMap", Name: "cm", Namespace: "testns", Error: "denied"},
+				},
+			},
+			expectedOutput:   "denied",
+			expectedErrorMsg: "unable to plan 1 resource(s)",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("testns")
+			defer tf.Cleanup()
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			w, clean := testutil.SetupWorkspace(t)
+			defer clean()
+			kf := kptfileutil.DefaultKptfile(filepath.Base(w.WorkspaceDirectory))
+			kf.Inventory = &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+			}
+			testutil.AddKptfileToWorkspace(t, w, kf)
+
+			revert := testutil.Chdir(t, w.WorkspaceDirectory)
+			defer revert()
+
+			runner := NewRunner(fake.CtxWithDefaultPrinter(), tf, ioStreams)
+			runner.Command.SetArgs(tc.args)
+			runner.planRunner = func(_ *Runner, inv inventory.Info, _ []*unstructured.Unstructured) (*kptplanner.Plan, error) {
+				if tc.plan == nil {
+					t.FailNow()
+				}
+				assert.Equal(t, "my-inv-id", inv.ID())
+				return tc.plan, nil
+			}
+			err := runner.Command.Execute()
+
+			if tc.expectedErrorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, out.String(), tc.expectedOutput)
+
+			planPath := filepath.Join(w.WorkspaceDirectory, "plan.yaml")
+			if !tc.expectPlanFile {
+				assert.NoFileExists(t, planPath)
+				return
+			}
+			pf, err := kptplanner.ReadPlanFile(planPath)
+			require.NoError(t, err)
+			assert.Equal(t, *kf.Inventory, pf.Spec.Inventory)
+			assert.Equal(t, kptplanner.PlanOptions{
+				ServerSideApply: true,
+				FieldManager:    "kubectl",
+				InventoryPolicy: "strict",
+			}, pf.Spec.Options)
+		})
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
+	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/strings"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	"github.com/GoogleContainerTools/kpt/pkg/status"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/printers"
+)
+
+// snapshotStore lists and records the inventory snapshots. It is
+// implemented by live.SnapshotStore.
+type snapshotStore interface {
+	List(ctx context.Context, inv inventory.Info) ([]live.Snapshot, error)
+	Record(ctx context.Context, inv inventory.Info, objs []*unstructured.Unstructured) error
+}
+
+func NewRunner(
+	ctx context.Context,
+	factory util.Factory,
+	ioStreams genericclioptions.IOStreams,
+) *Runner {
+	r := &Runner{
+		ctx:            ctx,
+		ioStreams:      ioStreams,
+		factory:        factory,
+		rollbackRunner: runRollback,
+	}
+	c := &cobra.Command{
+		Use:               "rollback [PKG_PATH | -]",
+		RunE:              r.runE,
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+		Short:             livedocs.RollbackShort,
+		Long:              livedocs.RollbackShort + "\n" + livedocs.RollbackLong,
+		Example:           livedocs.RollbackExamples,
+	}
+	r.Command = c
+
+	c.Flags().BoolVar(&r.serverSideOptions.ServerSideApply, "server-side", false,
+		"If true, apply merge patch is calculated on API server instead of client.")
+	c.Flags().BoolVar(&r.serverSideOptions.ForceConflicts, "force-conflicts", false,
+		"If true, overwrite applied fields on server if field manager conflict.")
+	c.Flags().StringVar(&r.serverSideOptions.FieldManager, "field-manager", common.DefaultFieldManager,
+		"The client owner of the fields being applied on the server-side.")
+	c.Flags().IntVar(&r.to, "to", 0,
+		"Revision of the snapshot to roll back to. Defaults to the revision before the latest one.")
+	c.Flags().BoolVar(&r.list, "list", false,
+		"List the recorded snapshots instead of rolling back.")
+	c.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
+		fmt.Sprintf("Output format, must be one of %s", strings.JoinStringsWithQuotes(printers.SupportedPrinters())))
+	c.Flags().DurationVar(&r.reconcileTimeout, "reconcile-timeout", time.Duration(0),
+		"Timeout threshold for waiting for all resources to reach the Current status.")
+	c.Flags().StringVar(&r.prunePropagationPolicyString, "prune-propagation-policy",
+		"Background", "Propagation policy for pruning")
+	c.Flags().DurationVar(&r.pruneTimeout, "prune-timeout", time.Duration(0),
+		"Timeout threshold for waiting for all pruned resources to be deleted")
+	c.Flags().BoolVar(&r.dryRun, "dry-run", false,
+		"dry-run the rollback of the resources in the package.")
+	c.Flags().BoolVar(&r.printStatusEvents, "show-status-events", false,
+		"Print status events (always enabled for table output)")
+	c.Flags().StringVar(&r.statusPolicyString, "status-policy", "all",
+		"It determines which status information should be saved in the inventory (if compatible). Available options "+
+			fmt.Sprintf("%q and %q.", "all", "none"))
+	return r
+}
+
+// NewCommand returns a cobra command.
+func NewCommand(ctx context.Context, factory util.Factory,
+	ioStreams genericclioptions.IOStreams) *cobra.Command {
+	return NewRunner(ctx, factory, ioStreams).Command
+}
+
+// Runner contains the run function that contains the cli functionality for the
+// rollback command.
+type Runner struct {
+	ctx       context.Context
+	Command   *cobra.Command
+	ioStreams genericclioptions.IOStreams
+	factory   util.Factory
+
+	serverSideOptions            common.ServerSideOptions
+	to                           int
+	list                         bool
+	output                       string
+	reconcileTimeout             time.Duration
+	prunePropagationPolicyString string
+	pruneTimeout                 time.Duration
+	dryRun                       bool
+	printStatusEvents            bool
+	statusPolicyString           string
+
+	prunePropPolicy metav1.DeletionPropagation
+	statusPolicy    inventory.StatusPolicy
+
+	// store is created from the factory unless set in tests.
+	store snapshotStore
+
+	rollbackRunner func(r *Runner, inv inventory.Info, objs []*unstructured.Unstructured,
+		dryRunStrategy common.DryRunStrategy) error
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, _ []string) error {
+	var err error
+	r.prunePropPolicy, err = flagutils.ConvertPropagationPolicy(r.prunePropagationPolicyString)
+	if err != nil {
+		return err
+	}
+	r.statusPolicy, err = flagutils.ConvertStatusPolicy(r.statusPolicyString)
+	if err != nil {
+		return err
+	}
+	if found := printers.ValidatePrinterType(r.output); !found {
+		return fmt.Errorf("unknown output type %q", r.output)
+	}
+	if r.to < 0 {
+		return fmt.Errorf("--to must be a positive revision, got %d", r.to)
+	}
+	return nil
+}
+
+func (r *Runner) runE(c *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		// default to the current working directory
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		args = append(args, cwd)
+	}
+
+	path := args[0]
+	var err error
+	if args[0] != "-" {
+		path, err = argutil.ResolveSymlink(r.ctx, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, inv, err := live.Load(r.factory, path, c.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	invInfo, err := live.ToInventoryInfo(inv)
+	if err != nil {
+		return err
+	}
+
+	if r.store == nil {
+		r.store, err = live.NewSnapshotStore(r.factory)
+		if err != nil {
+			return err
+		}
+	}
+	snapshots, err := r.store.List(r.ctx, invInfo)
+	if err != nil {
+		return err
+	}
+
+	if r.list {
+		return printSnapshots(c.OutOrStdout(), snapshots)
+	}
+
+	target, err := selectSnapshot(snapshots, r.to)
+	if err != nil {
+		return err
+	}
+
+	// The snapshot is applied like `kpt live apply` applies the package, so
+	// a package applied server-side keeps its field managers.
+	policy, err := live.ReadServerSidePolicy(inv, target.Objects)
+	if err != nil {
+		return err
+	}
+	r.serverSideOptions = policy.Merge(r.serverSideOptions,
+		c.Flags().Changed("field-manager"), c.Flags().Changed("force-conflicts"))
+
+	dryRunStrategy := common.DryRunNone
+	if r.dryRun {
+		if r.serverSideOptions.ServerSideApply {
+			dryRunStrategy = common.DryRunServer
+		} else {
+			dryRunStrategy = common.DryRunClient
+		}
+	}
+	return r.rollbackRunner(r, invInfo, target.Objects, dryRunStrategy)
+}
+
+// selectSnapshot returns the snapshot with the given revision, or the one
+// before the latest snapshot if revision is 0.
+func selectSnapshot(snapshots []live.Snapshot, revision int) (live.Snapshot, error) {
+	if len(snapshots) == 0 {
+		return live.Snapshot{}, fmt.Errorf("no snapshots have been recorded for the inventory")
+	}
+	if revision == 0 {
+		if len(snapshots) < 2 {
+			return live.Snapshot{}, fmt.Errorf("no snapshot before revision %d to roll back to",
+				snapshots[0].Revision)
+		}
+		return snapshots[len(snapshots)-2], nil
+	}
+	available := ""
+	for i, s := range snapshots {
+		if s.Revision == revision {
+			return s, nil
+		}
+		if i > 0 {
+			available += ", "
+		}
+		available += fmt.Sprint(s.Revision)
+	}
+	return live.Snapshot{}, fmt.Errorf("snapshot revision %d not found; available revisions: %s",
+		revision, available)
+}
+
+func printSnapshots(out io.Writer, snapshots []live.Snapshot) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "REVISION\tCREATED\tRESOURCES\tHASH")
+	for _, s := range snapshots {
+		created := ""
+		if !s.Created.IsZero() {
+			created = s.Created.Format(time.RFC3339)
+		}
+		hash := s.Hash
+		if len(hash) > 12 {
+			hash = hash[:12]
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", s.Revision, created, len(s.Objects), hash)
+	}
+	return w.Flush()
+}
+
+func runRollback(r *Runner, inv inventory.Info, objs []*unstructured.Unstructured,
+	dryRunStrategy common.DryRunStrategy) error {
+	invClient, err := inventory.NewClient(r.factory, live.WrapInventoryObj, live.InvToUnstructuredFunc, r.statusPolicy, live.ResourceGroupGVK)
+	if err != nil {
+		return err
+	}
+
+	statusWatcher, err := status.NewStatusWatcher(r.factory)
+	if err != nil {
+		return err
+	}
+
+	applier, err := apply.NewApplierBuilder().
+		WithFactory(r.factory).
+		WithInventoryClient(invClient).
+		WithStatusWatcher(statusWatcher).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	// Applying the snapshot prunes the resources that were added to the
+	// inventory since the snapshot was recorded.
+	ch := applier.Run(r.ctx, inv, objs, apply.ApplierOptions{
+		ServerSideOptions:      r.serverSideOptions,
+		ReconcileTimeout:       r.reconcileTimeout,
+		EmitStatusEvents:       true,
+		DryRunStrategy:         dryRunStrategy,
+		PrunePropagationPolicy: r.prunePropPolicy,
+		PruneTimeout:           r.pruneTimeout,
+		InventoryPolicy:        inventory.PolicyMustMatch,
+	})
+
+	// Print the preview strategy unless the output format is json.
+	if dryRunStrategy.ClientOrServerDryRun() && r.output != printers.JSONPrinter {
+		if dryRunStrategy.ServerDryRun() {
+			fmt.Println("Dry-run strategy: server")
+		} else {
+			fmt.Println("Dry-run strategy: client")
+		}
+	}
+	// The printer will print updates from the channel. It will block
+	// until the channel is closed.
+	printer := printers.GetPrinter(r.output, r.ioStreams)
+	if err := printer.Print(ch, dryRunStrategy, r.printStatusEvents); err != nil {
+		return err
+	}
+	if dryRunStrategy.ClientOrServerDryRun() {
+		return nil
+	}
+
+	// The rollback is recorded as a new snapshot, so it can be undone by
+	// another rollback.
+	return r.store.Record(r.ctx, inv, objs)
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/testutil"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+type fakeStore []live.Snapshot
+
+func (s fakeStore) List(context.Context, inventory.Info) ([]live.Snapshot, error) {
+	return s, nil
+}
+
+func (s fakeStore) Record(context.Context, inventory.Info, []*unstructured.Unstructured) error {
+	return nil
+}
+
+func configMap(name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetName(name)
+	u.SetNamespace("my-ns")
+	return u
+}
+
+func TestCmd(t *testing.T) {
+	snapshots := fakeStore{
+		{Revision: 3, Hash: "0123456789abcdef", Objects: []*unstructured.Unstructured{configMap("a")}},
+		{Revision: 4, Hash: "fedcba9876543210", Objects: []*unstructured.Unstructured{configMap("a"), configMap("b")}},
+		{Revision: 5, Hash: "0000000000000000", Objects: []*unstructured.Unstructured{configMap("c")}},
+	}
+
+	testCases := map[string]struct {
+		args             []string
+		snapshots        fakeStore
+		expectedObjs     []string
+		expectedDryRun   common.DryRunStrategy
+		expectedSSA      *common.ServerSideOptions
+		expectedOut      string
+		expectedErrorMsg string
+	}{
+		"defaults to the previous revision": {
+			snapshots:    snapshots,
+			expectedObjs: []string{"a", "b"},
+		},
+		"rolls back to the given revision": {
+			args:         []string{"--to", "3", "--dry-run"},
+			snapshots:    snapshots,
+			expectedObjs: []string{"a"},
+			// Without --server-side, the rollback is dry-run client-side.
+			expectedDryRun: common.DryRunClient,
+		},
+		"rolls back server-side": {
+			args:           []string{"--server-side", "--field-manager", "my-manager", "--dry-run"},
+			snapshots:      snapshots,
+			expectedObjs:   []string{"a", "b"},
+			expectedDryRun: common.DryRunServer,
+			expectedSSA: &common.ServerSideOptions{
+				ServerSideApply: true,
+				ForceConflicts:  true,
+				FieldManager:    "my-manager",
+			},
+		},
+		"uses the server-side apply policy of the inventory": {
+			args:         []string{"--server-side"},
+			snapshots:    snapshots,
+			expectedObjs: []string{"a", "b"},
+			expectedSSA: &common.ServerSideOptions{
+				ServerSideApply: true,
+				ForceConflicts:  true,
+				FieldManager:    "my-inv-manager",
+			},
+		},
+		"unknown revision": {
+			args:             []string{"--to", "2"},
+			snapshots:        snapshots,
+			expectedErrorMsg: "snapshot revision 2 not found; available revisions: 3, 4, 5",
+		},
+		"negative revision": {
+			args:             []string{"--to", "-1"},
+			snapshots:        snapshots,
+			expectedErrorMsg: "--to must be a positive revision, got -1",
+		},
+		"no previous revision": {
+			snapshots:        snapshots[2:],
+			expectedErrorMsg: "no snapshot before revision 5 to roll back to",
+		},
+		"no snapshots": {
+			expectedErrorMsg: "no snapshots have been recorded for the inventory",
+		},
+		"lists snapshots": {
+			args:      []string{"--list"},
+			snapshots: snapshots,
+			expectedOut: `REVISION  CREATED  RESOURCES  HASH
+3                  1          0123456789ab
+4                  2          fedcba987654
+5                  1          000000000000
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("testns")
+			defer tf.Cleanup()
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+
+			w, clean := testutil.SetupWorkspace(t)
+			defer clean()
+			kf := kptfileutil.DefaultKptfile(filepath.Base(w.WorkspaceDirectory))
+			kf.Inventory = &kptfilev1.Inventory{
+				Namespace:   "my-ns",
+				Name:        "my-name",
+				InventoryID: "my-inv-id",
+				Annotations: map[string]string{
+					live.FieldManagerAnnotation:   "my-inv-manager",
+					live.ConflictPolicyAnnotation: string(live.ConflictPolicyForce),
+				},
+			}
+			testutil.AddKptfileToWorkspace(t, w, kf)
+
+			revert := testutil.Chdir(t, w.WorkspaceDirectory)
+			defer revert()
+
+			runner := NewRunner(fake.CtxWithDefaultPrinter(), tf, ioStreams)
+			runner.store = tc.snapshots
+			out := &bytes.Buffer{}
+			runner.Command.SetOut(out)
+			runner.Command.SetArgs(tc.args)
+			var applied []string
+			runner.rollbackRunner = func(r *Runner, inv inventory.Info, objs []*unstructured.Unstructured,
+				dryRunStrategy common.DryRunStrategy) error {
+				assert.Equal(t, "my-inv-id", inv.ID())
+				assert.Equal(t, tc.expectedDryRun, dryRunStrategy)
+				if tc.expectedSSA != nil {
+					assert.Equal(t, *tc.expectedSSA, r.serverSideOptions)
+				}
+				for _, obj := range objs {
+					applied = append(applied, obj.GetName())
+				}
+				return nil
+			}
+			err := runner.Command.Execute()
+
+			if tc.expectedErrorMsg != "" {
+				if !assert.Error(t, err) {
+					t.FailNow()
+				}
+				assert.Contains(t, err.Error(), tc.expectedErrorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedObjs, applied)
+			assert.Equal(t, tc.expectedOut, out.String())
+		})
+	}
+}
@@ -17,6 +17,8 @@ package status
 import (
 	"context"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
 	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
@@ -29,14 +31,55 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 )
 
+// EventsTableOutput is the output format that prints the status events as
+// the rows of a table.
+const EventsTableOutput = "events-table"
+
+// Runner extends the status runner from cli-utils with the events-table
+// output format and the status history.
+type Runner struct {
+	*status.Runner
+
+	// historyFile is the file the status history is appended to.
+	historyFile string
+	eventsTable bool
+
+	// now returns the current time. It can be replaced in tests.
+	now func() time.Time
+}
+
 func NewRunner(ctx context.Context, factory util.Factory,
-	invFactory inventory.ClientFactory, loader status.Loader) *status.Runner {
-	r := status.GetRunner(ctx, factory, invFactory, loader)
+	invFactory inventory.ClientFactory, loader status.Loader) *Runner {
+	r := &Runner{
+		Runner: status.GetRunner(ctx, factory, invFactory, loader),
+		now:    time.Now,
+	}
 	r.PollerFactoryFunc = pollerFactoryFunc
 	r.Command.Use = "status [PKG_PATH | -]"
 	r.Command.Short = livedocs.StatusShort
 	r.Command.Long = livedocs.StatusShort + "\n" + livedocs.StatusLong
 	r.Command.Example = livedocs.StatusExamples
+	r.Command.Flags().Lookup("output").Usage =
+		"Output format. Must be one of events, events-table, json or table."
+	r.Command.Flags().StringVar(&r.historyFile, "history-file", "",
+		"Append the status history to this file and print the time to ready of the resources.")
+
+	preRunE := r.Command.PreRunE
+	r.Command.PreRunE = func(c *cobra.Command, args []string) error {
+		if output := c.Flags().Lookup("output"); output.Value.String() == EventsTableOutput {
+			// The events are polled and printed by the runner from
+			// cli-utils, so it must be given a format it knows.
+			r.eventsTable = true
+			if err := output.Value.Set("events"); err != nil {
+				return err
+			}
+		}
+		return preRunE(c, args)
+	}
+	runE := r.Command.RunE
+	r.Command.RunE = func(c *cobra.Command, args []string) error {
+		return r.runE(c, args, runE)
+	}
 	return r
 }
 
@@ -45,6 +88,60 @@ func NewCommand(ctx context.Context, factory util.Factory,
 	return NewRunner(ctx, factory, invFactory, loader).Command
 }
 
+// runE runs statusRunE, the run function of the cli-utils runner, with a
+// poller that prints the events table and records the status history.
+func (r *Runner) runE(c *cobra.Command, args []string, statusRunE func(*cobra.Command, []string) error) error {
+	if !r.eventsTable && r.historyFile == "" {
+		return statusRunE(c, args)
+	}
+
+	p := &recordingPoller{now: r.now}
+	out := c.OutOrStdout()
+	var statusOut pendingOutput
+	if r.eventsTable {
+		statusesFlag, _ := c.Flags().GetString("statuses")
+		p.table = &eventsTable{out: out, statuses: map[string]bool{}}
+		for _, s := range strings.Split(statusesFlag, ",") {
+			if s != "" {
+				p.table.statuses[strings.ToLower(s)] = true
+			}
+		}
+		// The events table replaces the output of the events printer. The
+		// output before polling starts is kept in case nothing is polled,
+		// the output of the events printer is dropped.
+		p.onPoll = statusOut.discard
+		c.SetOut(&statusOut)
+		defer c.SetOut(out)
+	}
+	pollerFactory := r.PollerFactoryFunc
+	r.PollerFactoryFunc = func(f util.Factory) (poller.Poller, error) {
+		statusPoller, err := pollerFactory(f)
+		if err != nil {
+			return nil, err
+		}
+		p.Poller = statusPoller
+		return p, nil
+	}
+	defer func() { r.PollerFactoryFunc = pollerFactory }()
+
+	err := statusRunE(c, args)
+	if p.recorder == nil {
+		// Nothing was polled, so print the messages of the runner, e.g.
+		// that the inventory is empty.
+		_, _ = statusOut.WriteTo(out)
+		return err
+	}
+	if err != nil || r.historyFile == "" {
+		return err
+	}
+	entry := p.recorder.Entry()
+	if err := kptstatus.AppendHistory(r.historyFile, entry); err != nil {
+		return err
+	}
+	printTimeToReady(out, entry)
+	return nil
+}
+
 func pollerFactoryFunc(f util.Factory) (poller.Poller, error) {
 	return kptstatus.NewStatusPoller(f)
 }
@@ -17,6 +17,8 @@ package status
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -26,6 +28,7 @@ import (
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
 	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	kptstatus "github.com/GoogleContainerTools/kpt/pkg/status"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
@@ -298,6 +301,118 @@ foo/deployment.apps/default/foo is InProgress: inProgress
 	}
 }
 
+func TestStatusCommand_eventsTableAndHistory(t *testing.T) {
+	events := []pollevent.Event{
+		{
+			Type: pollevent.ResourceUpdateEvent,
+			Resource: &pollevent.ResourceStatus{
+				Identifier: depObject,
+				Status:     status.InProgressStatus,
+				Message:    "inProgress",
+			},
+		},
+		{
+			Type: pollevent.ResourceUpdateEvent,
+			Resource: &pollevent.ResourceStatus{
+				Identifier: stsObject,
+				Status:     status.CurrentStatus,
+				Message:    "current",
+			},
+		},
+	}
+	testCases := map[string]struct {
+		args           []string
+		inventory      []object.ObjMetadata
+		expectHistory  bool
+		expectedOutput string
+	}{
+		"events table": {
+			args:      []string{"--output", "events-table"},
+			inventory: []object.ObjMetadata{depObject, stsObject},
+			expectedOutput: `
+TIME      RESOURCE                      STATUS       MESSAGE
+0s        deployment.apps/default/foo   InProgress   inProgress
+0s        statefulset.apps/default/bar  Current      current
+`,
+		},
+		"events table with statuses": {
+			args:      []string{"--output", "events-table", "--statuses", "current"},
+			inventory: []object.ObjMetadata{depObject, stsObject},
+			expectedOutput: `
+TIME      RESOURCE                      STATUS       MESSAGE
+0s        statefulset.apps/default/bar  Current      current
+`,
+		},
+		"events table with empty inventory": {
+			args:           []string{"--output", "events-table"},
+			expectedOutput: "no resources found in the inventory\n",
+		},
+		"history": {
+			args:          []string{"--history-file", "history.jsonl"},
+			inventory:     []object.ObjMetadata{depObject, stsObject},
+			expectHistory: true,
+			expectedOutput: `
+foo/deployment.apps/default/foo is InProgress: inProgress
+foo/statefulset.apps/default/bar is Current: current
+RESOURCE                      STATUS      TIME-TO-READY
+deployment.apps/default/foo   InProgress  -
+statefulset.apps/default/bar  Current     0s
+not all resources are ready
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("namespace")
+			defer tf.Cleanup()
+
+			w, clean := testutil.SetupWorkspace(t)
+			defer clean()
+			kf := kptfileutil.DefaultKptfile(filepath.Base(w.WorkspaceDirectory))
+			kf.Inventory = &kptfilev1.Inventory{
+				Name:        "foo",
+				Namespace:   "default",
+				InventoryID: "test",
+			}
+			testutil.AddKptfileToWorkspace(t, w, kf)
+
+			revert := testutil.Chdir(t, w.WorkspaceDirectory)
+			defer revert()
+
+			var outBuf bytes.Buffer
+			ctx := fake.CtxWithPrinter(&outBuf, &outBuf)
+			invFactory := inventory.FakeClientFactory(tc.inventory)
+			loader := NewFakeLoader(ctx, tf, tc.inventory)
+			runner := NewRunner(ctx, tf, invFactory, loader)
+			runner.PollerFactoryFunc = func(c cmdutil.Factory) (poller.Poller, error) {
+				return &fakePoller{events}, nil
+			}
+			start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			runner.now = func() time.Time { return start }
+
+			runner.Command.SetArgs(tc.args)
+			runner.Command.SetOut(&outBuf)
+			err := runner.Command.Execute()
+			assert.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(tc.expectedOutput), strings.TrimSpace(outBuf.String()))
+
+			historyPath := filepath.Join(w.WorkspaceDirectory, "history.jsonl")
+			if !tc.expectHistory {
+				assert.NoFileExists(t, historyPath)
+				return
+			}
+			b, err := os.ReadFile(historyPath)
+			assert.NoError(t, err)
+			var entry kptstatus.HistoryEntry
+			assert.NoError(t, json.Unmarshal(b, &entry))
+			assert.Equal(t, start, entry.Start)
+			assert.Nil(t, entry.TimeToReady)
+			assert.Len(t, entry.Resources, 2)
+		})
+	}
+}
+
 type fakePoller struct {
 	events []pollevent.Event
 }
@@ -314,3 +429,19 @@ func (f *fakePoller) Poll(ctx context.Context, _ object.ObjMetadataSet,
 	}()
 	return eventChannel
 }
+
+func TestPendingOutput(t *testing.T) {
+	var o pendingOutput
+	_, _ = o.Write([]byte("inventory is empty\n"))
+	var out strings.Builder
+	_, err := o.WriteTo(&out)
+	assert.NoError(t, err)
+	assert.Equal(t, "inventory is empty\n", out.String())
+
+	_, _ = o.Write([]byte("before polling\n"))
+	o.discard()
+	n, err := o.Write([]byte("while polling\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, len("while polling\n"), n)
+	assert.Equal(t, 0, o.buf.Len())
+}
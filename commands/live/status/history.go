@@ -0,0 +1,165 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	kptstatus "github.com/GoogleContainerTools/kpt/pkg/status"
+	"sigs.k8s.io/cli-utils/pkg/apply/poller"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// recordingPoller wraps a poller to record the status history of the
+// polled resources and, if table is set, print the events as a table.
+type recordingPoller struct {
+	poller.Poller
+	now      func() time.Time
+	table    *eventsTable
+	recorder *kptstatus.HistoryRecorder
+	// onPoll is called when polling starts, if it is set.
+	onPoll func()
+}
+
+func (p *recordingPoller) Poll(ctx context.Context, ids object.ObjMetadataSet,
+	options polling.PollOptions) <-chan event.Event {
+	p.recorder = kptstatus.NewHistoryRecorder(ids, p.now)
+	if p.onPoll != nil {
+		p.onPoll()
+	}
+	if p.table != nil {
+		p.table.printHeader(ids)
+	}
+	in := p.Poller.Poll(ctx, ids, options)
+	out := make(chan event.Event)
+	go func() {
+		defer close(out)
+		for e := range in {
+			p.recorder.Observe(e)
+			if p.table != nil {
+				p.table.printEvent(p.recorder.Elapsed(), e)
+			}
+			out <- e
+		}
+	}()
+	return out
+}
+
+// pendingOutput buffers the output written to it until discard is called.
+// After that, the buffered output is dropped and so is any output written
+// later, so the output of a command that polls for a long time doesn't
+// grow without bound.
+type pendingOutput struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	discarded bool
+}
+
+func (o *pendingOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.discarded {
+		return len(p), nil
+	}
+	return o.buf.Write(p)
+}
+
+func (o *pendingOutput) discard() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.discarded = true
+	o.buf = bytes.Buffer{}
+}
+
+// WriteTo writes the buffered output to w.
+func (o *pendingOutput) WriteTo(w io.Writer) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.WriteTo(w)
+}
+
+// eventsTable prints status events as the rows of a table as they are
+// received.
+type eventsTable struct {
+	out io.Writer
+	// statuses are the lowercase statuses to print. All statuses are
+	// printed if it is empty.
+	statuses map[string]bool
+	idWidth  int
+}
+
+func (t *eventsTable) printHeader(ids object.ObjMetadataSet) {
+	t.idWidth = len("RESOURCE")
+	for _, id := range ids {
+		if l := len(resourceID(id)); l > t.idWidth {
+			t.idWidth = l
+		}
+	}
+	t.printRow("TIME", "RESOURCE", "STATUS", "MESSAGE")
+}
+
+func (t *eventsTable) printEvent(elapsed time.Duration, e event.Event) {
+	switch e.Type {
+	case event.ResourceUpdateEvent:
+		s := e.Resource.Status.String()
+		if len(t.statuses) != 0 && !t.statuses[strings.ToLower(s)] {
+			return
+		}
+		t.printRow(formatElapsed(elapsed), resourceID(e.Resource.Identifier), s, e.Resource.Message)
+	case event.ErrorEvent:
+		t.printRow(formatElapsed(elapsed), "", "Error", e.Error.Error())
+	}
+}
+
+func (t *eventsTable) printRow(elapsed, id, status, message string) {
+	_, _ = fmt.Fprintf(t.out, "%-8s  %-*s  %-11s  %s\n", elapsed, t.idWidth, id, status, message)
+}
+
+// printTimeToReady prints the time to ready of each resource in entry and
+// of all resources.
+func printTimeToReady(out io.Writer, entry kptstatus.HistoryEntry) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "RESOURCE\tSTATUS\tTIME-TO-READY")
+	for _, r := range entry.Resources {
+		ready := "-"
+		if r.TimeToReady != nil {
+			ready = formatElapsed(r.TimeToReady.Duration)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID(), r.Status(), ready)
+	}
+	_ = w.Flush()
+	if entry.TimeToReady == nil {
+		_, _ = fmt.Fprintln(out, "not all resources are ready")
+		return
+	}
+	_, _ = fmt.Fprintf(out, "all resources are ready after %s\n", formatElapsed(entry.TimeToReady.Duration))
+}
+
+func resourceID(id object.ObjMetadata) string {
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(id.GroupKind.String()), id.Namespace, id.Name)
+}
+
+func formatElapsed(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/docs/generated/livedocs"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	kptstatus "github.com/GoogleContainerTools/kpt/pkg/status"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/cmd/status"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/poller"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ForCurrent waits until the resources are reconciled, like the wait
+	// of kpt live apply.
+	ForCurrent = "current"
+	// ForDeleted waits until the resources are deleted, like the wait of
+	// kpt live destroy.
+	ForDeleted = "deleted"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, factory util.Factory,
+	invFactory inventory.ClientFactory, loader status.Loader) *Runner {
+	r := &Runner{
+		ctx:               ctx,
+		factory:           factory,
+		invFactory:        invFactory,
+		loader:            loader,
+		PollerFactoryFunc: pollerFactoryFunc,
+		findInventory:     live.FindInventory,
+	}
+	c := &cobra.Command{
+		Use:     "wait [PKG_PATH | -]",
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+		Short:   livedocs.WaitShort,
+		Long:    livedocs.WaitShort + "\n" + livedocs.WaitLong,
+		Example: livedocs.WaitExamples,
+	}
+	c.Flags().StringVar(&r.inventoryID, "inventory-id", "",
+		"Wait for the resources of the inventory with this id in the cluster, instead of the inventory of a package.")
+	c.Flags().StringVar(&r.objectsFile, "objects", "",
+		"Wait for the resources listed in this file, instead of the resources of an inventory.")
+	c.Flags().StringVar(&r.waitFor, "for", ForCurrent,
+		"The status to wait for. Must be either current or deleted.")
+	c.Flags().DurationVar(&r.timeout, "timeout", 0,
+		"How long to wait for the resources. The default is to wait until all resources are reconciled or failed.")
+	c.Flags().DurationVar(&r.period, "poll-period", 2*time.Second,
+		"Polling period for resource statuses.")
+	c.Flags().BoolVar(&r.showStatusEvents, "show-status-events", false,
+		"Print the status events of the resources while waiting.")
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, factory util.Factory,
+	invFactory inventory.ClientFactory, loader status.Loader) *cobra.Command {
+	return NewRunner(ctx, factory, invFactory, loader).Command
+}
+
+// Runner contains the run function for the wait command.
+type Runner struct {
+	ctx        context.Context
+	Command    *cobra.Command
+	factory    util.Factory
+	invFactory inventory.ClientFactory
+	loader     status.Loader
+
+	inventoryID      string
+	objectsFile      string
+	waitFor          string
+	timeout          time.Duration
+	period           time.Duration
+	showStatusEvents bool
+
+	PollerFactoryFunc func(util.Factory) (poller.Poller, error)
+
+	// findInventory is a field so it can be replaced in tests.
+	findInventory func(ctx context.Context, factory util.Factory, namespace, id string) (*unstructured.Unstructured, error)
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+	if r.waitFor != ForCurrent && r.waitFor != ForDeleted {
+		return fmt.Errorf("--for must be either %s or %s", ForCurrent, ForDeleted)
+	}
+	if r.inventoryID != "" && r.objectsFile != "" {
+		return fmt.Errorf("--inventory-id and --objects can't be used together")
+	}
+	if len(args) > 0 && (r.inventoryID != "" || r.objectsFile != "") {
+		return fmt.Errorf("a package can't be used together with --inventory-id or --objects")
+	}
+	return nil
+}
+
+func (r *Runner) runE(c *cobra.Command, args []string) error {
+	ids, err := r.objects(c, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(c.OutOrStdout(), "no resources to wait for")
+		return nil
+	}
+	statusPoller, err := r.PollerFactoryFunc(r.factory)
+	if err != nil {
+		return err
+	}
+	return r.wait(c.OutOrStdout(), statusPoller, ids)
+}
+
+// objects returns the resources to wait for.
+func (r *Runner) objects(c *cobra.Command, args []string) (object.ObjMetadataSet, error) {
+	switch {
+	case r.objectsFile != "":
+		return readObjects(r.objectsFile)
+	case r.inventoryID != "":
+		// Look for the inventory in all namespaces, unless a namespace is
+		// given explicitly.
+		namespace, explicit, err := r.factory.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return nil, err
+		}
+		if !explicit {
+			namespace = ""
+		}
+		obj, err := r.findInventory(r.ctx, r.factory, namespace, r.inventoryID)
+		if err != nil {
+			return nil, err
+		}
+		return live.WrapInventoryObj(obj).Load()
+	default:
+		inv, err := r.loader.GetInvInfo(c, args)
+		if err != nil {
+			return nil, err
+		}
+		invClient, err := r.invFactory.NewClient(r.factory)
+		if err != nil {
+			return nil, err
+		}
+		return invClient.GetClusterObjs(inv)
+	}
+}
+
+// objectRef references a resource, like the resources of a ResourceGroup.
+type objectRef struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// readObjects reads the resources listed in the file at path. The file is
+// either a list of object references or a ResourceGroup.
+func readObjects(path string) (object.ObjMetadataSet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var refs []objectRef
+	if err := yaml.Unmarshal(b, &refs); err != nil {
+		var rg struct {
+			Kind string `json:"kind"`
+			Spec struct {
+				Resources []objectRef `json:"resources"`
+			} `json:"spec"`
+		}
+		if err := yaml.Unmarshal(b, &rg); err != nil || rg.Kind != live.ResourceGroupGVK.Kind {
+			return nil, fmt.Errorf("invalid objects file %q: must be a list of object references or a ResourceGroup", path)
+		}
+		refs = rg.Spec.Resources
+	}
+	var ids object.ObjMetadataSet
+	for i, ref := range refs {
+		if ref.Kind == "" || ref.Name == "" {
+			return nil, fmt.Errorf("invalid objects file %q: object %d must have a kind and a name", path, i)
+		}
+		ids = ids.Union(object.ObjMetadataSet{{
+			GroupKind: schema.GroupKind{Group: ref.Group, Kind: ref.Kind},
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+		}})
+	}
+	return ids, nil
+}
+
+// wait polls the status of the resources ids until they all have the
+// desired status, failed or the timeout expired. Like the wait task of
+// kpt live apply, a failed resource that reconciles before the wait ends
+// is successful.
+func (r *Runner) wait(out io.Writer, statusPoller poller.Poller, ids object.ObjMetadataSet) error {
+	desired := kstatus.CurrentStatus
+	if r.waitFor == ForDeleted {
+		desired = kstatus.NotFoundStatus
+	}
+	ctx, cancel := context.WithCancel(r.ctx)
+	if r.timeout > 0 {
+		ctx, cancel = context.WithTimeout(r.ctx, r.timeout)
+	}
+	defer cancel()
+
+	results := map[object.ObjMetadata]event.WaitEventStatus{}
+	statuses := map[object.ObjMetadata]kstatus.Status{}
+	pending := len(ids)
+	for e := range statusPoller.Poll(ctx, ids, polling.PollOptions{PollInterval: r.period}) {
+		switch e.Type {
+		case pollevent.ErrorEvent:
+			return e.Error
+		case pollevent.ResourceUpdateEvent:
+		default:
+			continue
+		}
+		rs := e.Resource
+		id := rs.Identifier
+		if !ids.Contains(id) {
+			continue
+		}
+		if r.showStatusEvents && statuses[id] != rs.Status {
+			fmt.Fprintf(out, "%s is %s: %s\n", resourceID(id), rs.Status, rs.Message)
+		}
+		statuses[id] = rs.Status
+
+		result, found := results[id]
+		switch {
+		case rs.Status == desired && result != event.ReconcileSuccessful:
+			if !found {
+				pending--
+			}
+			results[id] = event.ReconcileSuccessful
+		case rs.Status == kstatus.FailedStatus && !found:
+			pending--
+			results[id] = event.ReconcileFailed
+		default:
+			continue
+		}
+		fmt.Fprintf(out, "%s reconcile %s\n", resourceID(id), strings.ToLower(results[id].String()))
+		if pending == 0 {
+			cancel()
+		}
+	}
+	if pending > 0 {
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if _, found := results[id]; !found {
+				results[id] = event.ReconcileTimeout
+				fmt.Fprintf(out, "%s reconcile %s\n", resourceID(id), strings.ToLower(event.ReconcileTimeout.String()))
+			}
+		}
+	}
+
+	var s stats.Stats
+	for _, id := range ids {
+		s.WaitStats.Inc(results[id])
+	}
+	ws := s.WaitStats
+	fmt.Fprintf(out, "reconcile result: %d attempted, %d successful, %d skipped, %d failed, %d timed out\n",
+		ws.Sum(), ws.Successful, ws.Skipped, ws.Failed, ws.Timeout)
+	return printcommon.ResultErrorFromStats(s)
+}
+
+// resourceID returns the id of a resource in the format used by the events
+// printer of kpt live apply.
+func resourceID(id object.ObjMetadata) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(id.GroupKind.String()), id.Name)
+}
+
+func pollerFactoryFunc(f util.Factory) (poller.Poller, error) {
+	return kptstatus.NewStatusPoller(f)
+}
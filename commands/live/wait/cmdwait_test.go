@@ -0,0 +1,294 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/commands/live/status"
+	"github.com/GoogleContainerTools/kpt/internal/testutil"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/apply/poller"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+var (
+	depObject = object.ObjMetadata{
+		Name:      "foo",
+		Namespace: "default",
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+	}
+	svcObject = object.ObjMetadata{
+		Name:      "bar",
+		Namespace: "default",
+		GroupKind: schema.GroupKind{Kind: "Service"},
+	}
+)
+
+const objectsFile = `- group: apps
+  kind: Deployment
+  name: foo
+  namespace: default
+- kind: Service
+  name: bar
+  namespace: default
+`
+
+func update(id object.ObjMetadata, s kstatus.Status) pollevent.Event {
+	return pollevent.Event{
+		Type: pollevent.ResourceUpdateEvent,
+		Resource: &pollevent.ResourceStatus{
+			Identifier: id,
+			Status:     s,
+			Message:    "message",
+		},
+	}
+}
+
+func TestCmd(t *testing.T) {
+	testCases := map[string]struct {
+		args      []string
+		inventory []object.ObjMetadata
+		events    []pollevent.Event
+
+		expectedErrMsg string
+		expectedOutput string
+	}{
+		"resources of the package": {
+			inventory: []object.ObjMetadata{depObject, svcObject},
+			events: []pollevent.Event{
+				update(depObject, kstatus.InProgressStatus),
+				update(svcObject, kstatus.CurrentStatus),
+				update(depObject, kstatus.CurrentStatus),
+			},
+			expectedOutput: `service/bar reconcile successful
+deployment.apps/foo reconcile successful
+reconcile result: 2 attempted, 2 successful, 0 skipped, 0 failed, 0 timed out
+`,
+		},
+		"empty inventory": {
+			expectedOutput: "no resources to wait for\n",
+		},
+		"resources in a file": {
+			args: []string{"--objects", "objects.yaml", "--show-status-events"},
+			events: []pollevent.Event{
+				update(depObject, kstatus.InProgressStatus),
+				update(depObject, kstatus.InProgressStatus),
+				update(svcObject, kstatus.CurrentStatus),
+				update(depObject, kstatus.CurrentStatus),
+			},
+			expectedOutput: `deployment.apps/foo is InProgress: message
+service/bar is Current: message
+service/bar reconcile successful
+deployment.apps/foo is Current: message
+deployment.apps/foo reconcile successful
+reconcile result: 2 attempted, 2 successful, 0 skipped, 0 failed, 0 timed out
+`,
+		},
+		"resources of an inventory in the cluster": {
+			args: []string{"--inventory-id", "test", "--for", "deleted"},
+			events: []pollevent.Event{
+				update(depObject, kstatus.NotFoundStatus),
+				update(svcObject, kstatus.NotFoundStatus),
+			},
+			expectedOutput: `deployment.apps/foo reconcile successful
+service/bar reconcile successful
+reconcile result: 2 attempted, 2 successful, 0 skipped, 0 failed, 0 timed out
+`,
+		},
+		"failed resource": {
+			args: []string{"--objects", "objects.yaml"},
+			events: []pollevent.Event{
+				update(depObject, kstatus.FailedStatus),
+				update(svcObject, kstatus.CurrentStatus),
+			},
+			expectedErrMsg: "1 resources failed to reconcile before timeout",
+			expectedOutput: `deployment.apps/foo reconcile failed
+service/bar reconcile successful
+reconcile result: 2 attempted, 1 successful, 0 skipped, 1 failed, 0 timed out
+`,
+		},
+		"timeout": {
+			args: []string{"--objects", "objects.yaml", "--timeout", "10ms"},
+			events: []pollevent.Event{
+				update(depObject, kstatus.InProgressStatus),
+				update(svcObject, kstatus.CurrentStatus),
+			},
+			expectedErrMsg: "1 resources failed to reconcile before timeout",
+			expectedOutput: `service/bar reconcile successful
+deployment.apps/foo reconcile timeout
+reconcile result: 2 attempted, 1 successful, 0 skipped, 0 failed, 1 timed out
+`,
+		},
+		"invalid wait status": {
+			args:           []string{"--for", "ready"},
+			expectedErrMsg: "--for must be either current or deleted",
+		},
+		"package and objects": {
+			args:           []string{".", "--objects", "objects.yaml"},
+			expectedErrMsg: "a package can't be used together with --inventory-id or --objects",
+		},
+		"inventory id and objects": {
+			args:           []string{"--inventory-id", "test", "--objects", "objects.yaml"},
+			expectedErrMsg: "--inventory-id and --objects can't be used together",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("namespace")
+			defer tf.Cleanup()
+
+			w, clean := testutil.SetupWorkspace(t)
+			defer clean()
+			kf := kptfileutil.DefaultKptfile(filepath.Base(w.WorkspaceDirectory))
+			kf.Inventory = &kptfilev1.Inventory{
+				Name:        "foo",
+				Namespace:   "default",
+				InventoryID: "test",
+			}
+			testutil.AddKptfileToWorkspace(t, w, kf)
+			// the objects file is kept out of the package, since it isn't a
+			// resource.
+			objectsPath := filepath.Join(t.TempDir(), "objects.yaml")
+			require.NoError(t, os.WriteFile(objectsPath, []byte(objectsFile), 0600))
+			var args []string
+			for _, arg := range tc.args {
+				if arg == "objects.yaml" {
+					arg = objectsPath
+				}
+				args = append(args, arg)
+			}
+
+			revert := testutil.Chdir(t, w.WorkspaceDirectory)
+			defer revert()
+
+			var outBuf bytes.Buffer
+			ctx := fake.CtxWithPrinter(&outBuf, &outBuf)
+			invFactory := inventory.FakeClientFactory(tc.inventory)
+			loader := status.NewFakeLoader(ctx, tf, tc.inventory)
+			runner := NewRunner(ctx, tf, invFactory, loader)
+			runner.PollerFactoryFunc = func(cmdutil.Factory) (poller.Poller, error) {
+				return &fakePoller{tc.events}, nil
+			}
+			runner.findInventory = func(_ context.Context, _ cmdutil.Factory, namespace, id string) (*unstructured.Unstructured, error) {
+				assert.Equal(t, "namespace", namespace)
+				assert.Equal(t, "test", id)
+				return resourceGroup(depObject, svcObject), nil
+			}
+
+			runner.Command.SetArgs(args)
+			runner.Command.SetOut(&outBuf)
+			runner.Command.SilenceUsage = true
+			err := runner.Command.Execute()
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tc.expectedOutput != "" {
+				assert.Equal(t, tc.expectedOutput, outBuf.String())
+			}
+		})
+	}
+}
+
+func TestReadObjects(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		p := filepath.Join(dir, "objects.yaml")
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+		return p
+	}
+
+	ids, err := readObjects(write(objectsFile))
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{depObject, svcObject}, ids)
+
+	ids, err = readObjects(write(`apiVersion: kpt.dev/v1alpha1
+kind: ResourceGroup
+metadata:
+  name: inventory
+spec:
+  resources:
+  - group: apps
+    kind: Deployment
+    name: foo
+    namespace: default
+`))
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{depObject}, ids)
+
+	_, err = readObjects(write("kind: ConfigMap\n"))
+	assert.ErrorContains(t, err, "must be a list of object references or a ResourceGroup")
+	_, err = readObjects(write("- kind: Service\n"))
+	assert.ErrorContains(t, err, "object 0 must have a kind and a name")
+}
+
+func resourceGroup(ids ...object.ObjMetadata) *unstructured.Unstructured {
+	var resources []interface{}
+	for _, id := range ids {
+		resources = append(resources, map[string]interface{}{
+			"group":     id.GroupKind.Group,
+			"kind":      id.GroupKind.Kind,
+			"name":      id.Name,
+			"namespace": id.Namespace,
+		})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kpt.dev/v1alpha1",
+		"kind":       "ResourceGroup",
+		"metadata": map[string]interface{}{
+			"name":      "inventory",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"resources": resources,
+		},
+	}}
+}
+
+type fakePoller struct {
+	events []pollevent.Event
+}
+
+func (f *fakePoller) Poll(ctx context.Context, _ object.ObjMetadataSet,
+	_ polling.PollOptions) <-chan pollevent.Event {
+	eventChannel := make(chan pollevent.Event)
+	go func() {
+		defer close(eventChannel)
+		for _, e := range f.events {
+			eventChannel <- e
+		}
+		<-ctx.Done()
+	}()
+	return eventChannel
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cat
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/pkgdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/fetch"
+	"github.com/GoogleContainerTools/kpt/internal/util/parse"
+	"github.com/GoogleContainerTools/kpt/internal/util/render"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx: ctx,
+	}
+	r.RunnerOptions.InitDefaults()
+	c := &cobra.Command{
+		Use:     "cat REPO_URI[.git]/PKG_PATH[@VERSION]",
+		Short:   docs.CatShort,
+		Long:    docs.CatShort + "\n" + docs.CatLong,
+		Example: docs.CatExamples,
+		RunE:    r.runE,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: r.preRunE,
+	}
+	c.Flags().StringVarP(&r.output, "output", "o", cmdutil.Stdout,
+		fmt.Sprintf("output resources are written to stdout in provided format. Allowed values: %s|%s", cmdutil.Stdout, cmdutil.Unwrap))
+	c.Flags().BoolVar(&r.render, "render", false,
+		"render the package with its function pipelines before writing the resources.")
+	c.Flags().Var(&r.RunnerOptions.ImagePullPolicy, "image-pull-policy",
+		"pull image before running the container "+r.RunnerOptions.ImagePullPolicy.HelpAllowedValues())
+	_ = c.RegisterFlagCompletionFunc("image-pull-policy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return r.RunnerOptions.ImagePullPolicy.AllStrings(), cobra.ShellCompDirectiveDefault
+	})
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Git     *kptfilev1.Git
+	Command *cobra.Command
+
+	// RunnerOptions contains the options of the functions run with --render.
+	RunnerOptions fnruntime.RunnerOptions
+
+	// name is the name of the package, used as the root directory of the
+	// package in the in-memory filesystem.
+	name   string
+	output string
+	render bool
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmdcat.preRunE"
+	if r.output != cmdutil.Stdout && r.output != cmdutil.Unwrap {
+		return errors.E(op, fmt.Errorf("invalid input for --output flag %q, must be %q or %q",
+			r.output, cmdutil.Stdout, cmdutil.Unwrap))
+	}
+	if args[0] == "-" {
+		return errors.E(op, fmt.Errorf("a package url is required"))
+	}
+	// The destination is only used to name the package.
+	t, err := parse.GitParseArgs(r.ctx, []string{args[0], pkg.CurDir})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.Git = &t.Git
+	r.name = filepath.Base(t.Destination)
+	return nil
+}
+
+func (r *Runner) runE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdcat.runE"
+	fsys := filesys.MakeFsInMemory()
+	pkgPath := filepath.Join(string(filepath.Separator), r.name)
+	if err := fetch.ReadArchive(r.ctx, r.Git, fsys, pkgPath); err != nil {
+		return errors.E(op, err)
+	}
+
+	var out bytes.Buffer
+	if r.render {
+		renderer := render.Renderer{
+			PkgPath:       pkgPath,
+			Output:        &out,
+			RunnerOptions: r.RunnerOptions,
+			FileSystem:    fsys,
+		}
+		if _, err := renderer.Execute(r.ctx); err != nil {
+			return errors.E(op, err)
+		}
+	} else {
+		err := kio.Pipeline{
+			Inputs: []kio.Reader{kio.LocalPackageReader{
+				PackagePath:        pkgPath,
+				MatchFilesGlob:     pkg.MatchAllKRM,
+				PreserveSeqIndent:  true,
+				PackageFileName:    kptfilev1.KptFileName,
+				IncludeSubpackages: true,
+				WrapBareSeqNode:    true,
+				FileSystem:         filesys.FileSystemOrOnDisk{FileSystem: fsys},
+			}},
+			Outputs: []kio.Writer{kio.ByteWriter{
+				Writer:                &out,
+				KeepReaderAnnotations: true,
+				WrappingKind:          kio.ResourceListKind,
+				WrappingAPIVersion:    kio.ResourceListAPIVersion,
+			}},
+		}.Execute()
+		if err != nil {
+			return errors.E(op, types.UniquePath(pkgPath), err)
+		}
+	}
+	return cmdutil.WriteFnOutput(r.output, out.String(), false, printer.FromContextOrDie(r.ctx).OutStream())
+}
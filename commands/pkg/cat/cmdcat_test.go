@@ -0,0 +1,71 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cat_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/commands/pkg/cat"
+	"github.com/GoogleContainerTools/kpt/internal/testutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testutil.ConfigureTestKptCache(m))
+}
+
+func TestCmd_execute(t *testing.T) {
+	g, w, clean := testutil.SetupRepoAndWorkspace(t, testutil.Content{
+		Data:   testutil.Dataset1,
+		Branch: "master",
+	})
+	defer clean()
+	defer testutil.Chdir(t, w.WorkspaceDirectory)()
+
+	out := &bytes.Buffer{}
+	r := cat.NewRunner(fake.CtxWithPrinter(out, &bytes.Buffer{}), "kpt")
+	r.Command.SetArgs([]string{"file://" + g.RepoDirectory + ".git/java@master"})
+	require.NoError(t, r.Command.Execute())
+	assert.Contains(t, out.String(), "kind: ResourceList\n")
+	assert.Contains(t, out.String(), "config.kubernetes.io/path: 'java-deployment.resource.yaml'")
+	assert.NotContains(t, out.String(), "mysql")
+
+	out.Reset()
+	r = cat.NewRunner(fake.CtxWithPrinter(out, &bytes.Buffer{}), "kpt")
+	r.Command.SetArgs([]string{"file://" + g.RepoDirectory + ".git/java@master", "-o", "unwrap"})
+	require.NoError(t, r.Command.Execute())
+	assert.NotContains(t, out.String(), "ResourceList")
+	assert.NotContains(t, out.String(), "config.kubernetes.io/path")
+	assert.Contains(t, out.String(), "kind: Deployment\n")
+
+	// Nothing is written to the workspace.
+	entries, err := os.ReadDir(w.WorkspaceDirectory)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.Equal(t, ".git", e.Name())
+	}
+}
+
+func TestCmd_invalidOutput(t *testing.T) {
+	r := cat.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	r.Command.SetArgs([]string{"https://github.com/example/repo.git/pkg@v1", "-o", "dir"})
+	err := r.Command.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid input for --output flag "dir"`)
+}
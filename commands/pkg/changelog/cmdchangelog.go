@@ -0,0 +1,137 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/pkgdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/util/changelog"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const (
+	outputMarkdown = "markdown"
+	outputJSON     = "json"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx: ctx,
+	}
+	c := &cobra.Command{
+		Use:               "changelog [PKG_PATH] [flags]",
+		Short:             docs.ChangelogShort,
+		Long:              docs.ChangelogShort + "\n" + docs.ChangelogLong,
+		Example:           docs.ChangelogExamples,
+		RunE:              r.runE,
+		Args:              cobra.MaximumNArgs(1),
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+	}
+	c.Flags().StringVar(&r.from, "from", "",
+		"the upstream version to start from. Defaults to the commit in the upstreamLock of the Kptfile.")
+	c.Flags().StringVar(&r.to, "to", "",
+		"the upstream version to end at. Defaults to the ref in the upstream of the Kptfile.")
+	c.Flags().StringVarP(&r.output, "output", "o", outputMarkdown,
+		"the format of the changelog -- must be one of: markdown, json.")
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+
+	from   string
+	to     string
+	output string
+
+	repo      string
+	directory string
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmdchangelog.preRunE"
+	if r.output != outputMarkdown && r.output != outputJSON {
+		return errors.E(op, errors.InvalidParam,
+			fmt.Errorf("--output must be one of %s, %s", outputMarkdown, outputJSON))
+	}
+	if len(args) == 0 {
+		args = append(args, pkg.CurDir)
+	}
+	absPath, _, err := pathutil.ResolveAbsAndRelPaths(args[0])
+	if err != nil {
+		return err
+	}
+	p, err := pkg.New(filesys.FileSystemOrOnDisk{}, absPath)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	kf, err := p.Kptfile()
+	if err != nil {
+		return errors.E(op, p.UniquePath, err)
+	}
+	if kf.Upstream == nil || kf.Upstream.Git == nil {
+		return errors.E(op, p.UniquePath, fmt.Errorf("package must have a git upstream"))
+	}
+	r.repo = kf.Upstream.Git.Repo
+	r.directory = kf.Upstream.Git.Directory
+	if r.from == "" {
+		if kf.UpstreamLock == nil || kf.UpstreamLock.Git == nil {
+			return errors.E(op, p.UniquePath, errors.MissingParam,
+				fmt.Errorf("package has no upstreamLock, --from must be provided"))
+		}
+		r.from = kf.UpstreamLock.Git.Commit
+	}
+	if r.to == "" {
+		r.to = kf.Upstream.Git.Ref
+	}
+	return nil
+}
+
+func (r *Runner) runE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdchangelog.runE"
+	c, err := changelog.Generate(r.ctx, r.repo, r.directory, r.from, r.to)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	pr := printer.FromContextOrDie(r.ctx)
+	if r.output == outputJSON {
+		b, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			return errors.E(op, err)
+		}
+		fmt.Fprintf(pr.OutStream(), "%s\n", b)
+		return nil
+	}
+	fmt.Fprint(pr.OutStream(), c.Markdown())
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/changelog"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd(t *testing.T) {
+	t.Setenv(gitutil.RepoCacheDirEnv, t.TempDir())
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Jane", "GIT_AUTHOR_EMAIL=jane@example.com",
+			"GIT_COMMITTER_NAME=Jane", "GIT_COMMITTER_EMAIL=jane@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q", "-b", "main")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "app"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "app", "configmap.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`), 0600))
+	git("add", "-A")
+	git("commit", "-q", "-m", "Add app")
+	v1 := git("rev-parse", "HEAD")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "app", "service.yaml"), []byte(`apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`), 0600))
+	git("add", "-A")
+	git("commit", "-q", "-m", "Add service")
+	git("tag", "v2")
+
+	p := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(p, kptfilev1.KptFileName), []byte(fmt.Sprintf(`apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: app
+upstream:
+  type: git
+  git:
+    repo: %[1]s
+    directory: /app
+    ref: v2
+upstreamLock:
+  type: git
+  git:
+    repo: %[1]s
+    directory: /app
+    ref: main
+    commit: %[2]s
+`, repo, v1)), 0600))
+
+	var out bytes.Buffer
+	r := NewRunner(fake.CtxWithPrinter(&out, &out), "kpt")
+	r.Command.SetArgs([]string{p})
+	require.NoError(t, r.Command.Execute())
+	assert.Contains(t, out.String(), "- added Service web\n")
+	assert.Contains(t, out.String(), " Add service (Jane)\n")
+	assert.NotContains(t, out.String(), "Add app")
+
+	out.Reset()
+	r = NewRunner(fake.CtxWithPrinter(&out, &bytes.Buffer{}), "kpt")
+	r.Command.SetArgs([]string{p, "--to", v1, "-o", "json"})
+	require.NoError(t, r.Command.Execute())
+	c := &changelog.Changelog{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), c))
+	assert.True(t, c.IsEmpty())
+
+	r = NewRunner(fake.CtxWithPrinter(&out, &out), "kpt")
+	r.Command.SetArgs([]string{p, "-o", "yaml"})
+	r.Command.SilenceUsage = true
+	assert.ErrorContains(t, r.Command.Execute(), "--output must be one of markdown, json")
+}
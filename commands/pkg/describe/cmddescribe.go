@@ -0,0 +1,201 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/pkgdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/describe"
+	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const (
+	TextOutput = "text"
+	JSONOutput = "json"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx: ctx,
+	}
+	c := &cobra.Command{
+		Use:               "describe [PKG_PATH]",
+		Short:             docs.DescribeShort,
+		Long:              docs.DescribeShort + "\n" + docs.DescribeLong,
+		Example:           docs.DescribeExamples,
+		RunE:              r.runE,
+		Args:              cobra.MaximumNArgs(1),
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+	}
+	c.Flags().StringVarP(&r.output, "output", "o", TextOutput,
+		fmt.Sprintf("output format, must be either %s or %s.", TextOutput, JSONOutput))
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Path    types.UniquePath
+	Command *cobra.Command
+
+	output string
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmddescribe.preRunE"
+	if r.output != TextOutput && r.output != JSONOutput {
+		return errors.E(op, fmt.Errorf("--output must be either %s or %s", TextOutput, JSONOutput))
+	}
+	if len(args) == 0 {
+		args = append(args, pkg.CurDir)
+	}
+	resolvedPath, err := argutil.ResolveSymlink(r.ctx, args[0])
+	if err != nil {
+		return err
+	}
+	absResolvedPath, _, err := pathutil.ResolveAbsAndRelPaths(resolvedPath)
+	if err != nil {
+		return err
+	}
+	r.Path = types.UniquePath(absResolvedPath)
+	return nil
+}
+
+func (r *Runner) runE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmddescribe.runE"
+	d, err := describe.Describe(r.ctx, filesys.FileSystemOrOnDisk{}, string(r.Path))
+	if err != nil {
+		return errors.E(op, r.Path, err)
+	}
+	out := printer.FromContextOrDie(r.ctx).OutStream()
+	if r.output == JSONOutput {
+		e := json.NewEncoder(out)
+		e.SetIndent("", "  ")
+		return e.Encode(d)
+	}
+	printDescription(out, d)
+	return nil
+}
+
+// printDescription prints d in a human-readable form.
+func printDescription(out io.Writer, d *describe.Description) {
+	fmt.Fprintf(out, "Package: %s\n", d.Name)
+	fmt.Fprintf(out, "Path: %s\n", d.Path)
+	if d.Description != "" {
+		fmt.Fprintf(out, "Description: %s\n", d.Description)
+	}
+	if len(d.Keywords) > 0 {
+		fmt.Fprintf(out, "Keywords: %s\n", strings.Join(d.Keywords, ", "))
+	}
+	if d.Site != "" {
+		fmt.Fprintf(out, "Site: %s\n", d.Site)
+	}
+	if d.Upstream != nil {
+		u := d.Upstream
+		fmt.Fprintf(out, "Upstream:\n")
+		fmt.Fprintf(out, "  Repo: %s\n", u.Repo)
+		fmt.Fprintf(out, "  Directory: %s\n", u.Directory)
+		fmt.Fprintf(out, "  Ref: %s\n", u.Ref)
+		if u.UpdateStrategy != "" {
+			fmt.Fprintf(out, "  Update strategy: %s\n", u.UpdateStrategy)
+		}
+		if u.Commit != "" {
+			fmt.Fprintf(out, "  Locked ref: %s\n", u.LockedRef)
+			fmt.Fprintf(out, "  Commit: %s\n", u.Commit)
+		} else {
+			fmt.Fprintf(out, "  Not fetched\n")
+		}
+	}
+	printTimestamps(out, "", d.Timestamps)
+
+	if len(d.Pipeline.Mutators) > 0 || len(d.Pipeline.Validators) > 0 {
+		fmt.Fprintf(out, "Pipeline:\n")
+		printList(out, "Mutators", d.Pipeline.Mutators)
+		printList(out, "Validators", d.Pipeline.Validators)
+	}
+
+	if len(d.Subpackages) > 0 {
+		fmt.Fprintf(out, "Subpackages:\n")
+		for _, s := range d.Subpackages {
+			fmt.Fprintf(out, "  %s: %d resource(s)", s.Path, s.Resources)
+			if s.Upstream != nil {
+				fmt.Fprintf(out, ", upstream %s", s.Upstream.Repo)
+				if s.Upstream.Directory != "" {
+					fmt.Fprintf(out, "/%s", strings.TrimPrefix(s.Upstream.Directory, "/"))
+				}
+				fmt.Fprintf(out, "@%s", s.Upstream.Ref)
+				if s.Upstream.Commit != "" {
+					fmt.Fprintf(out, " (%s)", shortCommit(s.Upstream.Commit))
+				}
+			}
+			fmt.Fprintln(out)
+			printTimestamps(out, "    ", s.Timestamps)
+		}
+	}
+
+	if len(d.Resources) > 0 {
+		fmt.Fprintf(out, "Resources:\n")
+		for _, c := range d.Resources {
+			fmt.Fprintf(out, "  %s %s: %d\n", c.APIVersion, c.Kind, c.Count)
+		}
+	}
+}
+
+func printTimestamps(out io.Writer, indent string, t describe.Timestamps) {
+	if t.LastUpdated != "" {
+		fmt.Fprintf(out, "%sLast updated: %s\n", indent, t.LastUpdated)
+	}
+	if t.LastCommitted != "" {
+		fmt.Fprintf(out, "%sLast committed: %s\n", indent, t.LastCommitted)
+	}
+}
+
+func printList(out io.Writer, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "  %s:\n", title)
+	for _, item := range items {
+		fmt.Fprintf(out, "    %s\n", item)
+	}
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
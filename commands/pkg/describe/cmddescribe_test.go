@@ -0,0 +1,98 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/commands/pkg/describe"
+	describeutil "github.com/GoogleContainerTools/kpt/internal/util/describe"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: foo
+upstream:
+  type: git
+  git:
+    repo: https://github.com/example/blueprints
+    directory: /foo
+    ref: v1
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-labels:v0.1
+`
+
+func TestCmd_execute(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Kptfile"), []byte(kptfile), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cm.yaml"),
+		[]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bar"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bar", "Kptfile"),
+		[]byte("apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: bar\n"), 0600))
+
+	out := &bytes.Buffer{}
+	runner := describe.NewRunner(fake.CtxWithPrinter(out, out), "")
+	runner.Command.SetArgs([]string{dir})
+	require.NoError(t, runner.Command.Execute())
+	assert.Equal(t, `Package: foo
+Path: `+dir+`
+Upstream:
+  Repo: https://github.com/example/blueprints
+  Directory: /foo
+  Ref: v1
+  Not fetched
+Pipeline:
+  Mutators:
+    gcr.io/kpt-fn/set-labels:v0.1
+Subpackages:
+  bar: 0 resource(s)
+Resources:
+  v1 ConfigMap: 1
+`, out.String())
+
+	out.Reset()
+	runner = describe.NewRunner(fake.CtxWithPrinter(out, out), "")
+	runner.Command.SetArgs([]string{dir, "-o", "json"})
+	require.NoError(t, runner.Command.Execute())
+	var d describeutil.Description
+	require.NoError(t, json.Unmarshal(out.Bytes(), &d))
+	assert.Equal(t, "foo", d.Name)
+	assert.Len(t, d.Subpackages, 1)
+}
+
+func TestCmd_invalidArgs(t *testing.T) {
+	dir := t.TempDir()
+
+	runner := describe.NewRunner(fake.CtxWithDefaultPrinter(), "")
+	runner.Command.SetArgs([]string{dir, "-o", "yaml"})
+	err := runner.Command.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--output must be either text or json")
+
+	runner = describe.NewRunner(fake.CtxWithDefaultPrinter(), "")
+	runner.Command.SetArgs([]string{dir})
+	err = runner.Command.Execute()
+	require.Error(t, err)
+}
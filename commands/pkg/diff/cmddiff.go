@@ -35,13 +35,14 @@ func NewRunner(ctx context.Context, parent string) *Runner {
 		ctx: ctx,
 	}
 	c := &cobra.Command{
-		Use:          "diff [PKG_PATH@VERSION] [flags]",
-		Short:        pkgdocs.DiffShort,
-		Long:         pkgdocs.DiffShort + "\n" + pkgdocs.DiffLong,
-		Example:      pkgdocs.DiffExamples,
-		PreRunE:      r.preRunE,
-		RunE:         r.runE,
-		SilenceUsage: true,
+		Use:               "diff [PKG_PATH@VERSION] [flags]",
+		Short:             pkgdocs.DiffShort,
+		Long:              pkgdocs.DiffShort + "\n" + pkgdocs.DiffLong,
+		Example:           pkgdocs.DiffExamples,
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+		RunE:              r.runE,
+		SilenceUsage:      true,
 	}
 	diffTool := "diff"
 	if tool := os.Getenv("KPT_EXTERNAL_DIFF"); tool != "" {
@@ -54,6 +55,8 @@ func NewRunner(ctx context.Context, parent string) *Runner {
 		"diff tool to use to show the changes")
 	c.Flags().StringVar(&r.DiffToolOpts, "diff-tool-opts", diffToolOpts,
 		"diff tool commandline options to use to show the changes")
+	c.Flags().BoolVar(&r.IgnoreGenerated, "ignore-generated", false,
+		"exclude the resources generated by functions, which have the `kpt.dev/generated-by` annotation, from the diff")
 	c.Flags().BoolVar(&r.Debug, "debug", false,
 		"when true, prints additional debug information and do not delete staged pkg dirs")
 	r.C = c
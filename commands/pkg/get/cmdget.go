@@ -16,6 +16,7 @@ package get
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
 
@@ -39,8 +40,8 @@ func NewRunner(ctx context.Context, parent string) *Runner {
 		ctx: ctx,
 	}
 	c := &cobra.Command{
-		Use:        "get REPO_URI[.git]/PKG_PATH[@VERSION] [LOCAL_DEST_DIRECTORY]",
-		Args:       cobra.MinimumNArgs(1),
+		Use:        "get {REPO_URI[.git]/PKG_PATH[@VERSION] [LOCAL_DEST_DIRECTORY] | -f MANIFEST}",
+		Args:       r.validateArgs,
 		Short:      docs.GetShort,
 		Long:       docs.GetShort + "\n" + docs.GetLong,
 		Example:    docs.GetExamples,
@@ -55,6 +56,10 @@ func NewRunner(ctx context.Context, parent string) *Runner {
 			strings.Join(kptfilev1.UpdateStrategiesAsStrings(), ","))
 	c.Flags().BoolVar(&r.isDeploymentInstance, "for-deployment", false,
 		"(Experimental) indicates if this package will be deployed to a cluster.")
+	c.Flags().StringVarP(&r.manifestPath, "file", "f", "",
+		"path of a PackageManifest listing the packages to fetch, instead of a single package given as argument.")
+	c.Flags().IntVar(&r.concurrency, "concurrency", 4,
+		"maximum number of repositories fetched at the same time with --file.")
 	_ = c.RegisterFlagCompletionFunc("strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return kptfilev1.UpdateStrategiesAsStrings(), cobra.ShellCompDirectiveDefault
 	})
@@ -72,10 +77,46 @@ type Runner struct {
 	Command              *cobra.Command
 	strategy             string
 	isDeploymentInstance bool
+	manifestPath         string
+	concurrency          int
+
+	// manifest is the manifest read from manifestPath.
+	manifest *Manifest
+}
+
+func (r *Runner) validateArgs(cmd *cobra.Command, args []string) error {
+	if r.manifestPath != "" {
+		return cobra.NoArgs(cmd, args)
+	}
+	return cobra.MinimumNArgs(1)(cmd, args)
 }
 
 func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
 	const op errors.Op = "cmdget.preRunE"
+	if r.manifestPath != "" {
+		if r.concurrency < 1 {
+			return errors.E(op, fmt.Errorf("--concurrency must be at least 1"))
+		}
+		m, err := ReadManifest(r.manifestPath)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.manifest = m
+		return nil
+	}
+	g, err := r.newGetCommand(args, r.strategy, r.isDeploymentInstance)
+	if err != nil {
+		return err
+	}
+	r.Get = g
+	return nil
+}
+
+// newGetCommand returns the command fetching the package given by args,
+// which are the arguments of kpt pkg get.
+func (r *Runner) newGetCommand(args []string, strategyName string, isDeploymentInstance bool) (get.Command, error) {
+	const op errors.Op = "cmdget.preRunE"
+	var g get.Command
 	if len(args) == 1 {
 		args = append(args, pkg.CurDir)
 	} else {
@@ -83,39 +124,42 @@ func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
 		if err == nil || os.IsExist(err) {
 			resolvedPath, err := argutil.ResolveSymlink(r.ctx, args[1])
 			if err != nil {
-				return errors.E(op, err)
+				return g, errors.E(op, err)
 			}
 			args[1] = resolvedPath
 		}
 	}
 	t, err := parse.GitParseArgs(r.ctx, args)
 	if err != nil {
-		return errors.E(op, err)
+		return g, errors.E(op, err)
 	}
 
-	r.Get.Git = &t.Git
+	g.Git = &t.Git
 	absDestPath, _, err := pathutil.ResolveAbsAndRelPaths(t.Destination)
 	if err != nil {
-		return err
+		return g, err
 	}
 
 	p, err := pkg.New(filesys.FileSystemOrOnDisk{}, absDestPath)
 	if err != nil {
-		return errors.E(op, types.UniquePath(t.Destination), err)
+		return g, errors.E(op, types.UniquePath(t.Destination), err)
 	}
-	r.Get.Destination = string(p.UniquePath)
+	g.Destination = string(p.UniquePath)
 
-	strategy, err := kptfilev1.ToUpdateStrategy(r.strategy)
+	strategy, err := kptfilev1.ToUpdateStrategy(strategyName)
 	if err != nil {
-		return err
+		return g, err
 	}
-	r.Get.UpdateStrategy = strategy
-	r.Get.IsDeploymentInstance = r.isDeploymentInstance
-	return nil
+	g.UpdateStrategy = strategy
+	g.IsDeploymentInstance = isDeploymentInstance
+	return g, nil
 }
 
 func (r *Runner) runE(_ *cobra.Command, _ []string) error {
 	const op errors.Op = "cmdget.runE"
+	if r.manifest != nil {
+		return r.getManifest()
+	}
 	if err := r.Get.Run(r.ctx); err != nil {
 		return errors.E(op, types.UniquePath(r.Get.Destination), err)
 	}
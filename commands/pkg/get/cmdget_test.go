@@ -419,3 +419,102 @@ func TestCmd_flagAndArgParsing_Symlink(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no such file or directory")
 }
+
+func TestCmd_manifest(t *testing.T) {
+	g, w, clean := testutil.SetupRepoAndWorkspace(t, testutil.Content{
+		Data:   testutil.Dataset1,
+		Branch: "master",
+	})
+	defer clean()
+
+	defer testutil.Chdir(t, w.WorkspaceDirectory)()
+
+	manifest := fmt.Sprintf(`apiVersion: kpt.dev/v1alpha1
+kind: PackageManifest
+packages:
+- uri: file://%[1]s.git/java
+  destination: java-app
+- uri: file://%[1]s.git/mysql@master
+  strategy: fast-forward
+- uri: file://%[2]s.git/@master
+  destination: missing
+`, g.RepoDirectory, filepath.Join(w.WorkspaceDirectory, "not-a-repo"))
+	manifestPath := filepath.Join(t.TempDir(), "packages.yaml")
+	if !assert.NoError(t, os.WriteFile(manifestPath, []byte(manifest), 0600)) {
+		t.FailNow()
+	}
+
+	r := get.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	r.Command.SilenceErrors = true
+	r.Command.SilenceUsage = true
+	r.Command.SetArgs([]string{"-f", manifestPath})
+	err := r.Command.Execute()
+	assert.EqualError(t, err, fmt.Sprintf("1 of 3 package(s) in %q failed to fetch", manifestPath))
+
+	// the other packages are fetched even if one of them fails.
+	g.AssertEqual(t, filepath.Join(g.DatasetDirectory, testutil.Dataset1, "java"),
+		filepath.Join(w.WorkspaceDirectory, "java-app"), true)
+	g.AssertEqual(t, filepath.Join(g.DatasetDirectory, testutil.Dataset1, "mysql"),
+		filepath.Join(w.WorkspaceDirectory, "mysql"), true)
+	b, err := os.ReadFile(filepath.Join(w.WorkspaceDirectory, "mysql", kptfilev1.KptFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "updateStrategy: fast-forward")
+}
+
+func TestCmd_manifestFlagParsing(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if !assert.NoError(t, os.WriteFile(p, []byte(content), 0600)) {
+			t.FailNow()
+		}
+		return p
+	}
+	valid := write("valid.yaml", "apiVersion: kpt.dev/v1alpha1\nkind: PackageManifest\npackages:\n- uri: https://github.com/kptdev/kpt.git/package-examples/nginx@v0.9\n")
+
+	testCases := map[string]struct {
+		args          []string
+		expectedError string
+	}{
+		"args can't be used with a manifest": {
+			args:          []string{"-f", valid, "https://github.com/kptdev/kpt.git/package-examples/nginx@v0.9"},
+			expectedError: `unknown command "https://github.com/kptdev/kpt.git/package-examples/nginx@v0.9" for "get"`,
+		},
+		"concurrency must be positive": {
+			args:          []string{"-f", valid, "--concurrency=0"},
+			expectedError: "--concurrency must be at least 1",
+		},
+		"wrong kind": {
+			args:          []string{"-f", write("kind.yaml", "apiVersion: kpt.dev/v1alpha1\nkind: Plan\npackages: []\n")},
+			expectedError: "expected kpt.dev/v1alpha1 PackageManifest, got kpt.dev/v1alpha1 Plan",
+		},
+		"unknown fields": {
+			args:          []string{"-f", write("unknown.yaml", "apiVersion: kpt.dev/v1alpha1\nkind: PackageManifest\npackages:\n- url: foo\n")},
+			expectedError: `unknown field "url"`,
+		},
+		"same destination": {
+			args: []string{"-f", write("dest.yaml", `apiVersion: kpt.dev/v1alpha1
+kind: PackageManifest
+packages:
+- uri: https://github.com/kptdev/kpt.git/package-examples/nginx@v0.9
+  destination: nginx
+- uri: https://github.com/kptdev/kpt.git/package-examples/nginx@v1.0
+  destination: ./nginx/
+`)},
+			expectedError: `packages 0 and 1 have the same destination "./nginx/"`,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			r := get.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+			r.Command.SilenceErrors = true
+			r.Command.SilenceUsage = true
+			r.Command.RunE = NoOpFailRunE{t: t}.runE
+			r.Command.SetArgs(tc.args)
+			err := r.Command.Execute()
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedError)
+			}
+		})
+	}
+}
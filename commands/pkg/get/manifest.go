@@ -0,0 +1,174 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package get
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/GoogleContainerTools/kpt/internal/util/get"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ManifestAPIVersion = "kpt.dev/v1alpha1"
+	ManifestKind       = "PackageManifest"
+)
+
+// Manifest lists the packages fetched by `kpt pkg get -f`.
+type Manifest struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Metadata   ManifestMetadata `json:"metadata,omitempty"`
+	Packages   []ManifestEntry  `json:"packages"`
+}
+
+type ManifestMetadata struct {
+	Name string `json:"name,omitempty"`
+}
+
+type ManifestEntry struct {
+	// URI is the package to fetch, in the format of the first argument of
+	// kpt pkg get, i.e. REPO_URI[.git]/PKG_PATH[@VERSION].
+	URI string `json:"uri"`
+	// Destination is the local directory of the package, like the second
+	// argument of kpt pkg get. Relative paths are relative to the current
+	// directory. If empty, the package is fetched into a directory named
+	// after the package in the current directory.
+	Destination string `json:"destination,omitempty"`
+	// Strategy is the update strategy of the package. If empty, the
+	// --strategy flag is used.
+	Strategy string `json:"strategy,omitempty"`
+	// ForDeployment indicates if the package will be deployed to a
+	// cluster, like the --for-deployment flag.
+	ForDeployment bool `json:"forDeployment,omitempty"`
+}
+
+// ReadManifest reads the manifest at path.
+func ReadManifest(path string) (*Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest: %w", err)
+	}
+	m := &Manifest{}
+	if err := yaml.UnmarshalStrict(b, m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %q: %w", path, err)
+	}
+	if m.APIVersion != ManifestAPIVersion || m.Kind != ManifestKind {
+		return nil, fmt.Errorf("invalid manifest %q: expected %s %s, got %s %s",
+			path, ManifestAPIVersion, ManifestKind, m.APIVersion, m.Kind)
+	}
+	if len(m.Packages) == 0 {
+		return nil, fmt.Errorf("invalid manifest %q: no packages listed", path)
+	}
+	destinations := map[string]int{}
+	for i, e := range m.Packages {
+		if e.URI == "" {
+			return nil, fmt.Errorf("invalid manifest %q: package %d has no uri", path, i)
+		}
+		if e.Destination == "" {
+			continue
+		}
+		dest := filepath.Clean(e.Destination)
+		if j, found := destinations[dest]; found {
+			return nil, fmt.Errorf("invalid manifest %q: packages %d and %d have the same destination %q",
+				path, j, i, e.Destination)
+		}
+		destinations[dest] = i
+	}
+	return m, nil
+}
+
+// getManifest fetches the packages of the manifest. The packages of the
+// same repository are fetched one after the other, since they share the
+// cached clone of the repository, and up to concurrency repositories are
+// fetched at the same time. A summary of the fetched packages is printed
+// at the end.
+func (r *Runner) getManifest() error {
+	pr := printer.FromContextOrDie(r.ctx)
+	entries := r.manifest.Packages
+	errs := make([]error, len(entries))
+	cmds := make([]get.Command, len(entries))
+
+	// the arguments are parsed one after the other, since parsing may
+	// also use the cached clone of the repository.
+	var repos []string
+	byRepo := map[string][]int{}
+	for i, e := range entries {
+		args := []string{e.URI}
+		if e.Destination != "" {
+			args = append(args, e.Destination)
+		}
+		strategy := e.Strategy
+		if strategy == "" {
+			strategy = r.strategy
+		}
+		cmds[i], errs[i] = r.newGetCommand(args, strategy, e.ForDeployment || r.isDeploymentInstance)
+		if errs[i] != nil {
+			continue
+		}
+		repo := cmds[i].Git.Repo
+		if _, found := byRepo[repo]; !found {
+			repos = append(repos, repo)
+		}
+		byRepo[repo] = append(byRepo[repo], i)
+	}
+
+	sem := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+	for _, repo := range repos {
+		wg.Add(1)
+		go func(indexes []int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			for _, i := range indexes {
+				errs[i] = cmds[i].Run(r.ctx)
+			}
+		}(byRepo[repo])
+	}
+	wg.Wait()
+
+	failed := 0
+	pr.Printf("\nSummary of the %d package(s) in %s:\n", len(entries), r.manifestPath)
+	for i, e := range entries {
+		if errs[i] != nil {
+			failed++
+			pr.Printf("  FAILED  %s: %v\n", e.URI, errs[i])
+			continue
+		}
+		pr.Printf("  OK      %s -> %s\n", e.URI, relDestination(cmds[i].Destination))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d package(s) in %q failed to fetch", failed, len(entries), r.manifestPath)
+	}
+	return nil
+}
+
+// relDestination returns the destination dest relative to the current
+// directory if possible.
+func relDestination(dest string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return dest
+	}
+	if rel, err := filepath.Rel(cwd, dest); err == nil {
+		return rel
+	}
+	return dest
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importkustomize
+
+import (
+	"context"
+	"strings"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/pkgdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/kustomizeimport"
+	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx: ctx,
+	}
+	c := &cobra.Command{
+		Use:     "import-kustomize KUSTOMIZE_DIR PKG_PATH",
+		Short:   docs.ImportKustomizeShort,
+		Long:    docs.ImportKustomizeShort + "\n" + docs.ImportKustomizeLong,
+		Example: docs.ImportKustomizeExamples,
+		RunE:    r.runE,
+		Args:    cobra.ExactArgs(2),
+		PreRunE: r.preRunE,
+	}
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+
+	src  string
+	dest types.UniquePath
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+	src, _, err := pathutil.ResolveAbsAndRelPaths(args[0])
+	if err != nil {
+		return err
+	}
+	dest, _, err := pathutil.ResolveAbsAndRelPaths(args[1])
+	if err != nil {
+		return err
+	}
+	r.src = src
+	r.dest = types.UniquePath(dest)
+	return nil
+}
+
+func (r *Runner) runE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdimportkustomize.runE"
+	overlays, err := kustomizeimport.Import(r.ctx, r.src, string(r.dest))
+	if err != nil {
+		return errors.E(op, r.dest, err)
+	}
+	pr := printer.FromContextOrDie(r.ctx)
+	for _, o := range overlays {
+		pr.Printf("imported kustomization %q with %d resource(s)\n", o.Path, o.Resources)
+		if len(o.Functions) > 0 {
+			pr.Printf("  mapped to functions: %s\n", strings.Join(o.Functions, ", "))
+		}
+		if len(o.Unmapped) > 0 {
+			pr.Printf("  applied, but not mapped to functions: %s\n", strings.Join(o.Unmapped, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importkustomize
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "kustomization.yaml"), []byte(`resources:
+- configmap.yaml
+namespace: prod
+namePrefix: prod-
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "configmap.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`), 0600))
+	dest := filepath.Join(t.TempDir(), "config")
+
+	var out bytes.Buffer
+	r := NewRunner(fake.CtxWithPrinter(&out, &out), "kpt")
+	r.Command.SetArgs([]string{src, dest})
+	require.NoError(t, r.Command.Execute())
+
+	assert.Contains(t, out.String(), `imported kustomization "." with 1 resource(s)
+  mapped to functions: gcr.io/kpt-fn/set-namespace:v0.4.1
+  applied, but not mapped to functions: namePrefix
+`)
+	assert.FileExists(t, filepath.Join(dest, kptfilev1.KptFileName))
+	assert.FileExists(t, filepath.Join(dest, "configmap_prod-config.yaml"))
+
+	r = NewRunner(fake.CtxWithPrinter(&out, &out), "kpt")
+	r.Command.SetArgs([]string{src, dest})
+	r.Command.SilenceUsage = true
+	assert.ErrorContains(t, r.Command.Execute(), "already exists and is not empty")
+}
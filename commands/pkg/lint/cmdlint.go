@@ -0,0 +1,133 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/pkgdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/lint"
+	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx: ctx,
+	}
+	c := &cobra.Command{
+		Use:               "lint [PKG_PATH]",
+		Short:             docs.LintShort,
+		Long:              docs.LintShort + "\n" + docs.LintLong,
+		Example:           docs.LintExamples,
+		RunE:              r.runE,
+		Args:              cobra.MaximumNArgs(1),
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+	}
+	c.Flags().StringSliceVar(&r.skip, "skip", nil,
+		fmt.Sprintf("checks to skip. Allowed values: %s", strings.Join(lint.Checks, ", ")))
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Path    types.UniquePath
+	Command *cobra.Command
+
+	skip []string
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmdlint.preRunE"
+	for _, s := range r.skip {
+		found := false
+		for _, c := range lint.Checks {
+			found = found || s == c
+		}
+		if !found {
+			return errors.E(op, fmt.Errorf("unknown check %q in --skip, must be one of %s",
+				s, strings.Join(lint.Checks, ", ")))
+		}
+	}
+	if len(args) == 0 {
+		args = append(args, pkg.CurDir)
+	}
+	resolvedPath, err := argutil.ResolveSymlink(r.ctx, args[0])
+	if err != nil {
+		return err
+	}
+	absResolvedPath, _, err := pathutil.ResolveAbsAndRelPaths(resolvedPath)
+	if err != nil {
+		return err
+	}
+	// The Kptfile is only checked to exist here, so that an invalid Kptfile
+	// is reported as a finding.
+	isPkg, err := pkg.IsPackageDir(filesys.FileSystemOrOnDisk{}, absResolvedPath)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if !isPkg {
+		return errors.E(op, types.UniquePath(absResolvedPath), fmt.Errorf("no Kptfile found"))
+	}
+	r.Path = types.UniquePath(absResolvedPath)
+	return nil
+}
+
+func (r *Runner) runE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdlint.runE"
+	skip := map[string]bool{}
+	for _, s := range r.skip {
+		skip[s] = true
+	}
+	l := &lint.Linter{
+		FileSystem: filesys.FileSystemOrOnDisk{},
+		Skip:       skip,
+	}
+	findings, err := l.Lint(string(r.Path))
+	if err != nil {
+		return errors.E(op, r.Path, err)
+	}
+
+	out := printer.FromContextOrDie(r.ctx).OutStream()
+	for _, f := range findings {
+		fmt.Fprintf(out, "%s: %s: %s\n", f.Path, f.Check, f.Message)
+		if f.Fix != "" {
+			fmt.Fprintf(out, "  fix: %s\n", f.Fix)
+		}
+	}
+	if len(findings) > 0 {
+		return errors.E(op, r.Path, fmt.Errorf("found %d problem(s)", len(findings)))
+	}
+	fmt.Fprintf(out, "No problems found.\n")
+	return nil
+}
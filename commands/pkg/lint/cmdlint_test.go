@@ -0,0 +1,87 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/commands/pkg/lint"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: foo
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-labels:latest
+`
+
+const packageContext = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kptfile.kpt.dev
+  annotations:
+    config.kubernetes.io/local-config: "true"
+data:
+  name: foo
+`
+
+func TestCmd_execute(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Kptfile"), []byte(kptfile), 0600))
+
+	out := &bytes.Buffer{}
+	runner := lint.NewRunner(fake.CtxWithPrinter(out, out), "")
+	runner.Command.SetArgs([]string{dir})
+	err := runner.Command.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "found 2 problem(s)")
+	assert.Equal(t, `Kptfile: image-tag: pipeline.mutators[0].image "gcr.io/kpt-fn/set-labels:latest" uses the latest tag, so the package may render differently over time
+  fix: pin the image to a version tag or a digest
+package-context.yaml: package-context: package-context.yaml is missing
+  fix: run `+"`kpt pkg init`"+` in the package directory to create it
+`, out.String())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package-context.yaml"), []byte(packageContext), 0600))
+	out.Reset()
+	runner = lint.NewRunner(fake.CtxWithPrinter(out, out), "")
+	runner.Command.SetArgs([]string{dir, "--skip", "image-tag"})
+	require.NoError(t, runner.Command.Execute())
+	assert.Equal(t, "No problems found.\n", out.String())
+}
+
+func TestCmd_invalidArgs(t *testing.T) {
+	dir := t.TempDir()
+
+	runner := lint.NewRunner(fake.CtxWithDefaultPrinter(), "")
+	runner.Command.SetArgs([]string{dir})
+	err := runner.Command.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no Kptfile found")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Kptfile"), []byte(kptfile), 0600))
+	runner = lint.NewRunner(fake.CtxWithDefaultPrinter(), "")
+	runner.Command.SetArgs([]string{dir, "--skip", "spelling"})
+	err = runner.Command.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown check "spelling" in --skip`)
+}
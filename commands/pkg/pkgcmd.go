@@ -17,10 +17,17 @@ package pkg
 import (
 	"context"
 
+	"github.com/GoogleContainerTools/kpt/commands/pkg/cat"
+	"github.com/GoogleContainerTools/kpt/commands/pkg/changelog"
+	"github.com/GoogleContainerTools/kpt/commands/pkg/describe"
 	"github.com/GoogleContainerTools/kpt/commands/pkg/diff"
 	"github.com/GoogleContainerTools/kpt/commands/pkg/get"
+	"github.com/GoogleContainerTools/kpt/commands/pkg/importkustomize"
 	initialization "github.com/GoogleContainerTools/kpt/commands/pkg/init"
+	"github.com/GoogleContainerTools/kpt/commands/pkg/lint"
 	"github.com/GoogleContainerTools/kpt/commands/pkg/update"
+	"github.com/GoogleContainerTools/kpt/commands/pkg/vendor"
+	"github.com/GoogleContainerTools/kpt/commands/pkg/verify"
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/pkgdocs"
 	"github.com/GoogleContainerTools/kpt/thirdparty/cmdconfig/commands/cmdtree"
 	"github.com/spf13/cobra"
@@ -47,7 +54,10 @@ func GetCommand(ctx context.Context, name string) *cobra.Command {
 	pkg.AddCommand(
 		get.NewCommand(ctx, name), initialization.NewCommand(ctx, name),
 		update.NewCommand(ctx, name), diff.NewCommand(ctx, name),
-		cmdtree.NewCommand(ctx, name),
+		vendor.NewCommand(ctx, name), verify.NewCommand(ctx, name),
+		lint.NewCommand(ctx, name), cmdtree.NewCommand(ctx, name),
+		cat.NewCommand(ctx, name), describe.NewCommand(ctx, name),
+		importkustomize.NewCommand(ctx, name), changelog.NewCommand(ctx, name),
 	)
 	return pkg
 }
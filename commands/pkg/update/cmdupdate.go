@@ -26,10 +26,12 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/pkg"
 	"github.com/GoogleContainerTools/kpt/internal/types"
 	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/changelog"
 	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/update"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 )
@@ -40,20 +42,35 @@ func NewRunner(ctx context.Context, parent string) *Runner {
 		ctx: ctx,
 	}
 	c := &cobra.Command{
-		Use:        "update [PKG_PATH@VERSION] [flags]",
-		Short:      docs.UpdateShort,
-		Long:       docs.UpdateShort + "\n" + docs.UpdateLong,
-		Example:    docs.UpdateExamples,
-		RunE:       r.runE,
-		Args:       cobra.MaximumNArgs(1),
-		PreRunE:    r.preRunE,
-		SuggestFor: []string{"rebase", "replace"},
+		Use:               "update [PKG_PATH@VERSION] [flags]",
+		Short:             docs.UpdateShort,
+		Long:              docs.UpdateShort + "\n" + docs.UpdateLong,
+		Example:           docs.UpdateExamples,
+		RunE:              r.runE,
+		Args:              cobra.MaximumNArgs(1),
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+		SuggestFor:        []string{"rebase", "replace"},
 	}
 
 	c.Flags().StringVar(&r.strategy, "strategy", string(kptfilev1.ResourceMerge),
 		"the update strategy that will be used when updating the package. This will change "+
 			"the default strategy for the package -- must be one of: "+
 			strings.Join(kptfilev1.UpdateStrategiesAsStrings(), ","))
+	c.Flags().BoolVar(&r.Update.FromVendor, "from-vendor", false,
+		"update the package from the upstreams vendored with 'kpt pkg vendor' instead of fetching them from git.")
+	c.Flags().StringArrayVar(&r.Update.Skip, "skip", []string{},
+		"path of a subpackage, relative to the package, that will not be updated. Can be repeated.")
+	c.Flags().BoolVar(&r.Update.FollowRenames, "follow-renames", false,
+		"if the upstream directory of the package was moved, update the package from the new directory "+
+			"and change the upstream directory in the Kptfile.")
+	c.Flags().StringVar(&r.transcript, "transcript", "",
+		"write the decisions of the update to this file, so that they can be replayed with --from-transcript.")
+	c.Flags().StringVar(&r.fromTranscript, "from-transcript", "",
+		"replay the decisions of the update transcript in this file: update to the same version, "+
+			"with the same strategies, skipping the same subpackages.")
+	c.Flags().StringVar(&r.changelog, "changelog", "",
+		"write a summary of the upstream changes pulled in by the update to this file, in markdown.")
 	_ = c.RegisterFlagCompletionFunc("strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return kptfilev1.UpdateStrategiesAsStrings(), cobra.ShellCompDirectiveDefault
 	})
@@ -69,13 +86,17 @@ func NewCommand(ctx context.Context, parent string) *cobra.Command {
 // Runner contains the run function.
 // TODO, support listing versions
 type Runner struct {
-	ctx      context.Context
-	strategy string
-	Update   update.Command
-	Command  *cobra.Command
+	ctx            context.Context
+	strategy       string
+	transcript     string
+	fromTranscript string
+	changelog      string
+	replayed       *update.Transcript
+	Update         update.Command
+	Command        *cobra.Command
 }
 
-func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+func (r *Runner) preRunE(c *cobra.Command, args []string) error {
 	const op errors.Op = "cmdupdate.preRunE"
 	if len(args) == 0 {
 		args = append(args, pkg.CurDir)
@@ -119,18 +140,86 @@ func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
 	if len(parts) > 1 {
 		r.Update.Ref = parts[1]
 	}
+
+	if r.fromTranscript != "" {
+		if len(parts) > 1 || c.Flags().Changed("strategy") {
+			return errors.E(op, errors.InvalidParam,
+				fmt.Errorf("a version or --strategy can't be used with --from-transcript"))
+		}
+		t, err := update.ReadTranscript(r.fromTranscript)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.Update.Replay(t)
+		r.replayed = t
+	}
+	if r.changelog != "" && r.Update.FromVendor {
+		return errors.E(op, errors.InvalidParam,
+			fmt.Errorf("--changelog can't be used with --from-vendor"))
+	}
 	return nil
 }
 
 func (r *Runner) runE(_ *cobra.Command, _ []string) error {
 	const op errors.Op = "cmdupdate.runE"
+	// The upstream is read before the update, since the update changes it.
+	var repo, directory, fromCommit string
+	if r.changelog != "" {
+		kf, err := r.Update.Pkg.Kptfile()
+		if err != nil {
+			return errors.E(op, r.Update.Pkg.UniquePath, err)
+		}
+		if kf.Upstream != nil && kf.Upstream.Git != nil && kf.UpstreamLock != nil && kf.UpstreamLock.Git != nil {
+			repo, directory, fromCommit = kf.Upstream.Git.Repo, kf.Upstream.Git.Directory, kf.UpstreamLock.Git.Commit
+		}
+	}
 	if err := r.Update.Run(r.ctx); err != nil {
 		return errors.E(op, r.Update.Pkg.UniquePath, err)
 	}
+	if r.changelog != "" {
+		if err := r.writeChangelog(repo, directory, fromCommit); err != nil {
+			return errors.E(op, r.Update.Pkg.UniquePath, err)
+		}
+	}
 
+	t := r.Update.Transcript()
+	if r.replayed != nil {
+		// The package may differ from the package the transcript was
+		// recorded for, so the decisions may not apply the same way.
+		pr := printer.FromContextOrDie(r.ctx)
+		for _, m := range r.replayed.Mismatches(t) {
+			fmt.Fprintf(pr.ErrStream(), "warning: %s\n", m)
+		}
+	}
+	if r.transcript != "" {
+		if err := t.Write(r.transcript); err != nil {
+			return errors.E(op, errors.IO, err)
+		}
+	}
 	return nil
 }
 
+// writeChangelog writes the changelog of the upstream of the package from
+// fromCommit to the commit the package was updated to. Nothing is written
+// if the package was not fetched from git before the update.
+func (r *Runner) writeChangelog(repo, directory, fromCommit string) error {
+	if fromCommit == "" {
+		pr := printer.FromContextOrDie(r.ctx)
+		fmt.Fprintf(pr.ErrStream(), "warning: package has no upstreamLock, no changelog was written\n")
+		return nil
+	}
+	kf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, r.Update.Pkg.UniquePath.String())
+	if err != nil {
+		return err
+	}
+	c, err := changelog.Generate(r.ctx, repo, directory, fromCommit, kf.UpstreamLock.Git.Commit)
+	if err != nil {
+		return err
+	}
+	c.To = kf.UpstreamLock.Git.Ref
+	return os.WriteFile(r.changelog, []byte(c.Markdown()), 0644)
+}
+
 func resolveRelPath(path types.UniquePath) (string, error) {
 	const op errors.Op = "cmdupdate.resolveRelPath"
 	cwd, err := os.Getwd()
@@ -132,6 +132,85 @@ func TestCmd_execute(t *testing.T) {
 	}
 }
 
+// TestCmd_followRenames verifies that update finds the new directory of a
+// package that was moved upstream, and follows it with --follow-renames.
+func TestCmd_followRenames(t *testing.T) {
+	g, w, clean := testutil.SetupRepoAndWorkspace(t, testutil.Content{
+		Data:   testutil.Dataset1,
+		Branch: "master",
+	})
+	defer clean()
+
+	defer testutil.Chdir(t, w.WorkspaceDirectory)()
+
+	getCmd := get.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	getCmd.Command.SetArgs([]string{"file://" + g.RepoDirectory + ".git/java", w.WorkspaceDirectory})
+	if !assert.NoError(t, getCmd.Command.Execute()) {
+		t.FailNow()
+	}
+
+	// move the package to another directory upstream.
+	upstreamRunner, err := gitutil.NewLocalGitRunner(g.RepoDirectory)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.NoError(t, os.MkdirAll(filepath.Join(g.RepoDirectory, "apps"), 0700)) {
+		t.FailNow()
+	}
+	_, err = upstreamRunner.Run(fake.CtxWithDefaultPrinter(), "mv", "java", "apps/java")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	commit, err := g.Commit("move the java package")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	updateCmd := update.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	updateCmd.Command.SetArgs([]string{"java"})
+	err = updateCmd.Command.Execute()
+	if !assert.Error(t, err) {
+		t.FailNow()
+	}
+	assert.Contains(t, err.Error(), `directory "/java" was moved to "/apps/java"`)
+
+	updateCmd = update.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	updateCmd.Command.SetArgs([]string{"java", "--follow-renames"})
+	if !assert.NoError(t, updateCmd.Command.Execute()) {
+		t.FailNow()
+	}
+	g.AssertKptfile(t, filepath.Join(w.WorkspaceDirectory, "java"), kptfilev1.KptFile{
+		ResourceMeta: yaml.ResourceMeta{
+			ObjectMeta: yaml.ObjectMeta{
+				NameMeta: yaml.NameMeta{
+					Name: "java",
+				},
+			},
+			TypeMeta: yaml.TypeMeta{
+				APIVersion: kptfilev1.TypeMeta.APIVersion,
+				Kind:       kptfilev1.TypeMeta.Kind},
+		},
+		Upstream: &kptfilev1.Upstream{
+			Type: kptfilev1.GitOrigin,
+			Git: &kptfilev1.Git{
+				Repo:      "file://" + g.RepoDirectory,
+				Ref:       "master",
+				Directory: "/apps/java",
+			},
+			UpdateStrategy: kptfilev1.ResourceMerge,
+		},
+		UpstreamLock: &kptfilev1.UpstreamLock{
+			Type: kptfilev1.GitOrigin,
+			Git: &kptfilev1.GitLock{
+				Repo:      "file://" + g.RepoDirectory,
+				Ref:       "master",
+				Directory: "/apps/java",
+				Commit:    commit,
+			},
+		},
+	})
+}
+
 func TestCmd_successUnCommitted(t *testing.T) {
 	g, w, clean := testutil.SetupRepoAndWorkspace(t, testutil.Content{
 		Data:   testutil.Dataset1,
@@ -342,6 +421,50 @@ func TestCmd_Execute_flagAndArgParsing(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, kptfilev1.ResourceMerge, r.Update.Strategy)
 	assert.Equal(t, "", r.Update.Ref)
+
+	// verify the transcript sets the ref, strategies and skipped packages
+	transcript := filepath.Join(t.TempDir(), "transcript.yaml")
+	err = os.WriteFile(transcript, []byte(`apiVersion: kpt.dev/v1alpha1
+kind: UpdateTranscript
+metadata:
+  name: pkg
+spec:
+  ref: v2
+  strategy: fast-forward
+  packages:
+  - path: mysql
+    action: merged
+    strategy: force-delete-replace
+  - path: redis
+    action: skipped
+`), 0600)
+	assert.NoError(t, err)
+	r = update.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	r.Command.RunE = NoOpRunE
+	r.Command.SetArgs([]string{dir, "--from-transcript", transcript, "--skip", "nginx"})
+	err = r.Command.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", r.Update.Ref)
+	assert.Equal(t, kptfilev1.FastForward, r.Update.Strategy)
+	assert.Equal(t, []string{"nginx", "redis"}, r.Update.Skip)
+	assert.Equal(t, map[string]kptfilev1.UpdateStrategyType{"mysql": kptfilev1.ForceDeleteReplace},
+		r.Update.Strategies)
+
+	// verify a version can't be given with a transcript
+	r = update.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	r.Command.SilenceErrors = true
+	r.Command.RunE = failRun
+	r.Command.SetArgs([]string{dir + "@v3", "--from-transcript", transcript})
+	err = r.Command.Execute()
+	assert.ErrorContains(t, err, "a version or --strategy can't be used with --from-transcript")
+
+	// verify a changelog can't be written offline
+	r = update.NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	r.Command.SilenceErrors = true
+	r.Command.RunE = failRun
+	r.Command.SetArgs([]string{dir, "--from-vendor", "--changelog", "changelog.md"})
+	err = r.Command.Execute()
+	assert.ErrorContains(t, err, "--changelog can't be used with --from-vendor")
 }
 
 func TestCmd_flagAndArgParsing_Symlink(t *testing.T) {
@@ -0,0 +1,113 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"fmt"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/pkgdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/digest"
+	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx: ctx,
+	}
+	c := &cobra.Command{
+		Use:               "verify [PKG_PATH]",
+		Short:             docs.VerifyShort,
+		Long:              docs.VerifyShort + "\n" + docs.VerifyLong,
+		Example:           docs.VerifyExamples,
+		RunE:              r.runE,
+		Args:              cobra.MaximumNArgs(1),
+		PreRunE:           r.preRunE,
+		ValidArgsFunction: cmdutil.CompletePackagePaths,
+	}
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Path    types.UniquePath
+	Command *cobra.Command
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmdverify.preRunE"
+	if len(args) == 0 {
+		args = append(args, pkg.CurDir)
+	}
+	resolvedPath, err := argutil.ResolveSymlink(r.ctx, args[0])
+	if err != nil {
+		return err
+	}
+	absResolvedPath, _, err := pathutil.ResolveAbsAndRelPaths(resolvedPath)
+	if err != nil {
+		return err
+	}
+	p, err := pkg.New(filesys.FileSystemOrOnDisk{}, absResolvedPath)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.Path = p.UniquePath
+	return nil
+}
+
+func (r *Runner) runE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdverify.runE"
+	results, err := digest.Verify(string(r.Path))
+	if err != nil {
+		return errors.E(op, r.Path, err)
+	}
+	pr := printer.FromContextOrDie(r.ctx)
+	out := pr.OutStream()
+	var mismatches int
+	for _, res := range results {
+		switch {
+		case res.Expected == "":
+			fmt.Fprintf(out, "Package %q: no digest recorded\n", res.Path)
+		case res.Verified():
+			fmt.Fprintf(out, "Package %q: verified\n", res.Path)
+		default:
+			mismatches++
+			fmt.Fprintf(out, "Package %q: digest mismatch: expected %s, got %s\n", res.Path, res.Expected, res.Actual)
+		}
+	}
+	if len(results) == 0 {
+		fmt.Fprintf(out, "No packages with an upstreamLock found.\n")
+	}
+	if mismatches > 0 {
+		return errors.E(op, r.Path, fmt.Errorf("%d package(s) do not match their recorded digest", mismatches))
+	}
+	return nil
+}
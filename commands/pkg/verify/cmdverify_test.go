@@ -0,0 +1,79 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/commands/pkg/get"
+	"github.com/GoogleContainerTools/kpt/commands/pkg/verify"
+	"github.com/GoogleContainerTools/kpt/internal/testutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testutil.ConfigureTestKptCache(m))
+}
+
+func TestCmd_execute(t *testing.T) {
+	g, w, clean := testutil.SetupRepoAndWorkspace(t, testutil.Content{
+		Data:   testutil.Dataset1,
+		Branch: "master",
+	})
+	defer clean()
+	defer testutil.Chdir(t, w.WorkspaceDirectory)()
+
+	getRunner := get.NewRunner(fake.CtxWithDefaultPrinter(), "")
+	getRunner.Command.SetArgs([]string{"file://" + g.RepoDirectory + ".git/", "./"})
+	require.NoError(t, getRunner.Command.Execute())
+	dest := filepath.Join(w.WorkspaceDirectory, g.RepoName)
+
+	out := &bytes.Buffer{}
+	runner := verify.NewRunner(fake.CtxWithPrinter(out, out), "")
+	runner.Command.SetArgs([]string{dest})
+	require.NoError(t, runner.Command.Execute())
+	assert.Equal(t, "Package \".\": verified\n", out.String())
+
+	err := os.WriteFile(filepath.Join(dest, "java", "java-service.yaml"), []byte("kind: Service\n"), 0600)
+	require.NoError(t, err)
+	out.Reset()
+	runner = verify.NewRunner(fake.CtxWithPrinter(out, out), "")
+	runner.Command.SetArgs([]string{dest})
+	err = runner.Command.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 package(s) do not match their recorded digest")
+	assert.Contains(t, out.String(), "Package \".\": digest mismatch: expected sha256:")
+}
+
+func TestCmd_noUpstream(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "Kptfile"), []byte(`apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: foo
+`), 0600)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	runner := verify.NewRunner(fake.CtxWithPrinter(out, out), "")
+	runner.Command.SetArgs([]string{dir})
+	require.NoError(t, runner.Command.Execute())
+	assert.Equal(t, "No packages with an upstreamLock found.\n", out.String())
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/parse"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// defaultIndexFile is the index file read if the index url doesn't
+// include a path.
+const defaultIndexFile = "index.yaml"
+
+// packageIndex is the content of an index file in a git catalog
+// repository, e.g.
+//
+//	packages:
+//	- name: nginx
+//	  version: v0.1.0
+//	  repo: https://github.com/org/catalog.git/nginx
+//	  description: A simple nginx deployment.
+//	  keywords: [nginx, web]
+type packageIndex struct {
+	Packages []indexEntry `yaml:"packages"`
+}
+
+type indexEntry struct {
+	Name        string   `yaml:"name"`
+	Version     string   `yaml:"version,omitempty"`
+	Repo        string   `yaml:"repo,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Keywords    []string `yaml:"keywords,omitempty"`
+}
+
+// indexSource lists the packages in an index file in a git repository.
+// The url has the same format as package urls for kpt pkg get:
+// REPO_URI[.git]/FILE_PATH[@VERSION].
+type indexSource struct {
+	url string
+}
+
+func (s *indexSource) list(ctx context.Context) ([]Package, error) {
+	if !parse.HasGitSuffix(s.url) {
+		return nil, fmt.Errorf("invalid index url %q: must contain .git", s.url)
+	}
+	repo, dir, ref, err := parse.URL(s.url)
+	if err != nil {
+		return nil, err
+	}
+	file := strings.TrimPrefix(filepath.ToSlash(dir), "/")
+	if file == "" {
+		file = defaultIndexFile
+	}
+
+	gur, err := gitutil.NewGitUpstreamRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if ref == "" {
+		ref, err = gur.GetDefaultBranch(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	repoDir, err := gur.GetRepo(ctx, []string{ref})
+	if err != nil {
+		return nil, err
+	}
+	commit, found := gur.ResolveRef(ref)
+	if !found {
+		commit = ref
+	}
+	gitRunner, err := gitutil.NewLocalGitRunner(repoDir)
+	if err != nil {
+		return nil, err
+	}
+	rr, err := gitRunner.Run(ctx, "show", commit+":"+file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index %q: %w", s.url, err)
+	}
+	return parseIndex([]byte(rr.Stdout), repo)
+}
+
+// parseIndex parses the index file content. Entries without a repo
+// default to the catalog repository.
+func parseIndex(content []byte, repo string) ([]Package, error) {
+	var index packageIndex
+	if err := yaml.Unmarshal(content, &index); err != nil {
+		return nil, fmt.Errorf("invalid index file: %w", err)
+	}
+	var pkgs []Package
+	for _, e := range index.Packages {
+		if e.Name == "" {
+			return nil, fmt.Errorf("invalid index file: package name must not be empty")
+		}
+		p := Package{
+			Name:        e.Name,
+			Version:     e.Version,
+			Repository:  e.Repo,
+			Description: e.Description,
+			Keywords:    e.Keywords,
+		}
+		if p.Repository == "" {
+			p.Repository = repo
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs, nil
+}
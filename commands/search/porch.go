@@ -0,0 +1,80 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	porchapi "github.com/GoogleContainerTools/kpt/porch/api/porch/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// porchSource lists the latest published revision of every package in
+// the Porch repositories of a namespace.
+type porchSource struct {
+	client    client.Reader
+	namespace string
+}
+
+func (s *porchSource) list(ctx context.Context) ([]Package, error) {
+	var prs porchapi.PackageRevisionList
+	if err := s.client.List(ctx, &prs, client.InNamespace(s.namespace), client.MatchingLabels{
+		porchapi.LatestPackageRevisionKey: porchapi.LatestPackageRevisionValue,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list package revisions: %w", err)
+	}
+
+	// The description and keywords are only available from the Kptfile in
+	// the package resources, which are listed at once rather than fetched
+	// per package revision.
+	var prrs porchapi.PackageRevisionResourcesList
+	if err := s.client.List(ctx, &prrs, client.InNamespace(s.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list package revision resources: %w", err)
+	}
+	kptfiles := make(map[string]string, len(prrs.Items))
+	for _, prr := range prrs.Items {
+		if content, found := prr.Spec.Resources[kptfilev1.KptFileName]; found {
+			kptfiles[prr.Name] = content
+		}
+	}
+
+	var pkgs []Package
+	for _, pr := range prs.Items {
+		if !porchapi.LifecycleIsPublished(pr.Spec.Lifecycle) {
+			continue
+		}
+		p := Package{
+			Name:       pr.Spec.PackageName,
+			Version:    pr.Spec.Revision,
+			Repository: pr.Spec.RepositoryName,
+		}
+		if content, found := kptfiles[pr.Name]; found {
+			kf, err := pkg.DecodeKptfile(strings.NewReader(content))
+			if err != nil {
+				return nil, fmt.Errorf("package revision %q: %w", pr.Name, err)
+			}
+			if kf.Info != nil {
+				p.Description = kf.Info.Description
+				p.Keywords = kf.Info.Keywords
+			}
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs, nil
+}
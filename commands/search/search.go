@@ -0,0 +1,170 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/GoogleContainerTools/kpt/commands/util"
+	"github.com/GoogleContainerTools/kpt/internal/docs/generated/searchdocs"
+	"github.com/GoogleContainerTools/kpt/internal/util/porch"
+	"github.com/spf13/cobra"
+	k8scmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// Package is a package found by the search.
+type Package struct {
+	Name        string
+	Version     string
+	Repository  string
+	Description string
+	Keywords    []string
+}
+
+// matches returns true if every term occurs, ignoring case, in the name,
+// description or keywords of the package.
+func (p Package) matches(terms []string) bool {
+	fields := append([]string{p.Name, p.Description}, p.Keywords...)
+	for _, term := range terms {
+		term = strings.ToLower(term)
+		found := false
+		for _, f := range fields {
+			if strings.Contains(strings.ToLower(f), term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// source lists the packages available for searching.
+type source interface {
+	list(ctx context.Context) ([]Package, error)
+}
+
+func NewCommand(ctx context.Context, version string) *cobra.Command {
+	r := newRunner(ctx)
+	r.factory = util.NewFactory(r.Command, version)
+	return r.Command
+}
+
+func newRunner(ctx context.Context) *runner {
+	r := &runner{
+		ctx: ctx,
+	}
+	c := &cobra.Command{
+		Use:     "search KEYWORD...",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   searchdocs.SearchShort,
+		Long:    searchdocs.SearchShort + "\n" + searchdocs.SearchLong,
+		Example: searchdocs.SearchExamples,
+		RunE:    r.runE,
+	}
+	r.Command = c
+
+	c.Flags().BoolVar(&r.porch, "porch", false,
+		"Also search the package revisions in the Porch repositories registered in the namespace.")
+	c.Flags().StringArrayVar(&r.indexes, "index", nil,
+		"Search the package index file in a git catalog repository, e.g. https://github.com/org/catalog.git/index.yaml@main. Can be repeated.")
+	return r
+}
+
+type runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+	factory k8scmdutil.Factory
+
+	porch   bool
+	indexes []string
+
+	// sources overrides the sources built from the flags in tests.
+	sources []source
+}
+
+func (r *runner) runE(cmd *cobra.Command, args []string) error {
+	sources := r.sources
+	if sources == nil {
+		var err error
+		sources, err = r.newSources()
+		if err != nil {
+			return err
+		}
+	}
+
+	var found []Package
+	for _, s := range sources {
+		pkgs, err := s.list(r.ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range pkgs {
+			if p.matches(args) {
+				found = append(found, p)
+			}
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].Name != found[j].Name {
+			return found[i].Name < found[j].Name
+		}
+		return found[i].Repository < found[j].Repository
+	})
+	return printPackages(cmd.OutOrStdout(), found)
+}
+
+func (r *runner) newSources() ([]source, error) {
+	var sources []source
+	if r.porch {
+		cfg, err := r.factory.ToRESTConfig()
+		if err != nil {
+			return nil, err
+		}
+		c, err := porch.CreateClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		namespace, _, err := r.factory.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &porchSource{client: c, namespace: namespace})
+	}
+	for _, index := range r.indexes {
+		sources = append(sources, &indexSource{url: index})
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("nothing to search: enable --porch or provide at least one --index")
+	}
+	return sources, nil
+}
+
+func printPackages(out io.Writer, pkgs []Package) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tREPOSITORY\tDESCRIPTION")
+	for _, p := range pkgs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Name, p.Version, p.Repository,
+			strings.Join(strings.Fields(p.Description), " "))
+	}
+	return w.Flush()
+}
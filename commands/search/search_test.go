@@ -0,0 +1,220 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	porchapi "github.com/GoogleContainerTools/kpt/porch/api/porch/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeSource []Package
+
+func (s fakeSource) list(context.Context) ([]Package, error) {
+	return s, nil
+}
+
+func TestCmd(t *testing.T) {
+	sources := []source{
+		fakeSource{
+			{Name: "nginx", Version: "v2", Repository: "blueprints", Description: "An nginx\nweb server.", Keywords: []string{"web"}},
+			{Name: "redis", Version: "v1", Repository: "blueprints", Description: "A key value store."},
+		},
+		fakeSource{
+			{Name: "ingress", Version: "v0.1.0", Repository: "https://github.com/org/catalog", Keywords: []string{"Web", "gateway"}},
+			{Name: "nginx", Version: "v1", Repository: "https://github.com/org/catalog"},
+		},
+	}
+
+	tests := map[string]struct {
+		args     []string
+		expected string
+	}{
+		"matches name": {
+			args: []string{"nginx"},
+			expected: `NAME   VERSION  REPOSITORY                      DESCRIPTION
+nginx  v2       blueprints                      An nginx web server.
+nginx  v1       https://github.com/org/catalog
+`,
+		},
+		"matches keywords ignoring case": {
+			args: []string{"WEB"},
+			expected: `NAME     VERSION  REPOSITORY                      DESCRIPTION
+ingress  v0.1.0   https://github.com/org/catalog
+nginx    v2       blueprints                      An nginx web server.
+`,
+		},
+		"all keywords must match": {
+			args: []string{"web", "gateway"},
+			expected: `NAME     VERSION  REPOSITORY                      DESCRIPTION
+ingress  v0.1.0   https://github.com/org/catalog
+`,
+		},
+		"no matches": {
+			args: []string{"postgres"},
+			expected: `NAME  VERSION  REPOSITORY  DESCRIPTION
+`,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			r := newRunner(context.Background())
+			r.sources = sources
+			out := &bytes.Buffer{}
+			r.Command.SetOut(out)
+			r.Command.SetArgs(tc.args)
+			require.NoError(t, r.Command.Execute())
+			assert.Equal(t, tc.expected, trimLines(out.String()))
+		})
+	}
+}
+
+func TestCmd_nothingToSearch(t *testing.T) {
+	// Porch is only searched with --porch.
+	r := newRunner(context.Background())
+	r.Command.SetArgs([]string{"nginx"})
+	r.Command.SilenceUsage = true
+	r.Command.SetErr(&bytes.Buffer{})
+	assert.EqualError(t, r.Command.Execute(), "nothing to search: enable --porch or provide at least one --index")
+}
+
+// trimLines removes the column padding tabwriter leaves at the end of lines.
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestPorchSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchapi.AddToScheme(scheme))
+
+	latest := map[string]string{porchapi.LatestPackageRevisionKey: porchapi.LatestPackageRevisionValue}
+	revision := func(name, pkg, rev string, lifecycle porchapi.PackageRevisionLifecycle, labels map[string]string) *porchapi.PackageRevision {
+		return &porchapi.PackageRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec: porchapi.PackageRevisionSpec{
+				PackageName:    pkg,
+				Revision:       rev,
+				RepositoryName: "blueprints",
+				Lifecycle:      lifecycle,
+			},
+		}
+	}
+	resources := func(name, kptfile string) *porchapi.PackageRevisionResources {
+		prr := &porchapi.PackageRevisionResources{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       porchapi.PackageRevisionResourcesSpec{Resources: map[string]string{}},
+		}
+		if kptfile != "" {
+			prr.Spec.Resources["Kptfile"] = kptfile
+		}
+		return prr
+	}
+
+	c := crfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		revision("blueprints-nginx-v2", "nginx", "v2", porchapi.PackageRevisionLifecyclePublished, latest),
+		resources("blueprints-nginx-v2", `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: nginx
+info:
+  description: An nginx web server.
+  keywords:
+  - web
+`),
+		revision("blueprints-nginx-v1", "nginx", "v1", porchapi.PackageRevisionLifecyclePublished, nil),
+		revision("blueprints-redis-draft", "redis", "", porchapi.PackageRevisionLifecycleDraft, latest),
+		revision("blueprints-basens-v1", "basens", "v1", porchapi.PackageRevisionLifecyclePublished, latest),
+		resources("blueprints-basens-v1", ""),
+	).Build()
+
+	// The package revision resources are listed in bulk, never fetched one
+	// by one.
+	pkgs, err := (&porchSource{client: listOnlyReader{c}, namespace: "default"}).list(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Package{
+		{Name: "nginx", Version: "v2", Repository: "blueprints", Description: "An nginx web server.", Keywords: []string{"web"}},
+		{Name: "basens", Version: "v1", Repository: "blueprints"},
+	}, pkgs)
+}
+
+// listOnlyReader fails every Get.
+type listOnlyReader struct {
+	client.Reader
+}
+
+func (listOnlyReader) Get(_ context.Context, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+	return fmt.Errorf("unexpected get of %s", key)
+}
+
+func TestIndexSource(t *testing.T) {
+	t.Setenv(gitutil.RepoCacheDirEnv, t.TempDir())
+	repoDir := filepath.Join(t.TempDir(), "catalog.git")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "indexes"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "indexes", "web.yaml"), []byte(`packages:
+- name: nginx
+  version: v0.1.0
+  repo: https://github.com/org/blueprints.git/nginx
+  description: An nginx web server.
+  keywords: [web]
+- name: ingress
+`), 0600))
+	for _, args := range [][]string{
+		{"init", "--initial-branch=main"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-m", "index"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	ctx := fake.CtxWithDefaultPrinter()
+	pkgs, err := (&indexSource{url: repoDir + "/indexes/web.yaml@main"}).list(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Name: "nginx", Version: "v0.1.0", Repository: "https://github.com/org/blueprints.git/nginx",
+			Description: "An nginx web server.", Keywords: []string{"web"}},
+		{Name: "ingress", Repository: strings.TrimSuffix(repoDir, ".git")},
+	}, pkgs)
+
+	_, err = (&indexSource{url: repoDir + "/missing.yaml@main"}).list(ctx)
+	assert.ErrorContains(t, err, "failed to read index")
+}
+
+func TestParseIndex(t *testing.T) {
+	_, err := parseIndex([]byte("packages:\n- version: v1\n"), "repo")
+	assert.EqualError(t, err, "invalid index file: package name must not be empty")
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/stats"
+	"github.com/spf13/cobra"
+)
+
+var kindTitles = map[stats.Kind]string{
+	stats.Command:    "Commands",
+	stats.Fetch:      "Repos",
+	stats.Function:   "Functions",
+	stats.ApplyPhase: "Apply phases",
+}
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{
+		ctx: ctx,
+		now: time.Now,
+	}
+	c := &cobra.Command{
+		Use:   "stats",
+		Args:  cobra.NoArgs,
+		Short: "Summarize the durations of kpt commands recorded locally",
+		Long: `Summarize the durations of kpt commands recorded locally
+
+When the recording of stats is enabled, kpt records how long every command
+took, and how long it spent fetching each repo, running each function and in
+each phase of 'kpt live apply', into a local file. Nothing is sent anywhere.
+stats summarizes the records to find the slowest commands, repos and
+functions: for each, it prints how many times it ran, how many runs failed,
+and the total, average and longest duration of its runs, slowest first.
+
+Recording is off by default. It is enabled by setting the KPT_STATS
+environment variable to true, or with 'stats: true' in the kpt config file.
+The records are stored in ~/.kpt/stats.jsonl, or in the file set with the
+KPT_STATS_FILE environment variable, as JSON lines.
+`,
+		Example: `
+  # summarize the records of the last week
+  $ kpt stats --since 7d
+
+  # list the 3 slowest functions
+  $ kpt stats --kind function --top 3
+
+  # delete the records
+  $ kpt stats --clear
+`,
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+	}
+	c.Flags().StringVar(&r.sinceString, "since", "",
+		"only summarize the records of this period, e.g. '7d' or '12h'. Defaults to all records.")
+	c.Flags().StringVar(&r.kind, "kind", "",
+		"only summarize the records of this kind: command, fetch, function or apply-phase.")
+	c.Flags().IntVar(&r.top, "top", 10,
+		"the number of entries to print for each kind. 0 prints all of them.")
+	c.Flags().BoolVar(&r.clear, "clear", false,
+		"delete the records.")
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function for the stats command.
+type Runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+
+	sinceString string
+	kind        string
+	top         int
+	clear       bool
+
+	since time.Duration
+	// now returns the current time. It is overridden in tests.
+	now func() time.Time
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdstats.preRunE"
+	if r.sinceString != "" {
+		since, err := stats.ParseSince(r.sinceString)
+		if err != nil {
+			return errors.E(op, errors.InvalidParam, fmt.Errorf("--since: %w", err))
+		}
+		r.since = since
+	}
+	if r.kind != "" {
+		if _, found := kindTitles[stats.Kind(r.kind)]; !found {
+			return errors.E(op, errors.InvalidParam,
+				fmt.Errorf("--kind must be one of command, fetch, function or apply-phase"))
+		}
+	}
+	if r.top < 0 {
+		return errors.E(op, errors.InvalidParam, fmt.Errorf("--top must not be negative"))
+	}
+	return nil
+}
+
+func (r *Runner) runE(c *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdstats.runE"
+	path, err := stats.Path()
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if r.clear {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.E(op, errors.IO, err)
+		}
+		return nil
+	}
+
+	var since time.Time
+	if r.since > 0 {
+		since = r.now().Add(-r.since)
+	}
+	records, err := stats.Read(path, since)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if len(records) == 0 {
+		if !stats.IsEnabled() {
+			fmt.Fprintf(c.ErrOrStderr(), "No stats recorded. Set %s=true, or 'stats: true' in the kpt config, "+
+				"to record them.\n", stats.EnvVar)
+		} else {
+			fmt.Fprintln(c.ErrOrStderr(), "No stats recorded.")
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(c.OutOrStdout(), 0, 0, 2, ' ', 0)
+	var kind stats.Kind
+	printed := 0
+	for _, s := range stats.Summarize(records) {
+		if r.kind != "" && s.Kind != stats.Kind(r.kind) {
+			continue
+		}
+		if s.Kind != kind {
+			if kind != "" {
+				fmt.Fprintln(w)
+			}
+			kind = s.Kind
+			printed = 0
+			title, found := kindTitles[kind]
+			if !found {
+				title = string(kind)
+			}
+			fmt.Fprintf(w, "%s\n", title)
+			fmt.Fprintf(w, "NAME\tRUNS\tFAILED\tTOTAL\tAVERAGE\tMAX\n")
+		}
+		if r.top > 0 && printed >= r.top {
+			continue
+		}
+		printed++
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n", s.Name, s.Count, s.Failed,
+			round(s.Total), round(s.Average()), round(s.Max))
+	}
+	return w.Flush()
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Millisecond)
+}
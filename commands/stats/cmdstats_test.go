@@ -0,0 +1,100 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/util/stats"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const records = `{"time":"2026-10-01T10:00:00Z","command":"kpt fn render","kind":"function","name":"gcr.io/kpt-fn/set-labels:v0.1","seconds":1.2}
+{"time":"2026-10-01T10:00:00Z","command":"kpt fn render","kind":"function","name":"gcr.io/kpt-fn/kubeval:v0.3","seconds":4,"failed":true}
+{"time":"2026-10-01T10:00:00Z","command":"kpt fn render","kind":"command","name":"kpt fn render","seconds":5.5,"failed":true}
+{"time":"2026-10-10T10:00:00Z","command":"kpt fn render","kind":"function","name":"gcr.io/kpt-fn/set-labels:v0.1","seconds":0.8}
+{"time":"2026-10-10T10:00:00Z","command":"kpt fn render","kind":"function","name":"gcr.io/kpt-fn/kubeval:v0.3","seconds":2}
+{"time":"2026-10-10T10:00:00Z","command":"kpt fn render","kind":"command","name":"kpt fn render","seconds":3}
+`
+
+func TestCmd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.jsonl")
+	t.Setenv(stats.FileEnvVar, path)
+	t.Setenv(stats.EnvVar, "true")
+	require.NoError(t, os.WriteFile(path, []byte(records), 0600))
+	now := func() time.Time { return time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC) }
+
+	testCases := map[string]struct {
+		args     []string
+		expected string
+		errMsg   string
+	}{
+		"all records": {
+			expected: `Commands
+NAME           RUNS  FAILED  TOTAL  AVERAGE  MAX
+kpt fn render  2     1       8.5s   4.25s    5.5s
+
+Functions
+NAME                           RUNS  FAILED  TOTAL  AVERAGE  MAX
+gcr.io/kpt-fn/kubeval:v0.3     2     1       6s     3s       4s
+gcr.io/kpt-fn/set-labels:v0.1  2     0       2s     1s       1.2s
+`,
+		},
+		"recent records of a kind": {
+			args: []string{"--since", "7d", "--kind", "function", "--top", "1"},
+			expected: `Functions
+NAME                        RUNS  FAILED  TOTAL  AVERAGE  MAX
+gcr.io/kpt-fn/kubeval:v0.3  1     0       2s     2s       2s
+`,
+		},
+		"invalid kind": {
+			args:   []string{"--kind", "foo"},
+			errMsg: "--kind must be one of command, fetch, function or apply-phase",
+		},
+		"invalid since": {
+			args:   []string{"--since", "week"},
+			errMsg: `--since: invalid duration "week"`,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var out bytes.Buffer
+			r := NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+			r.now = now
+			r.Command.SetOut(&out)
+			r.Command.SetArgs(tc.args)
+			r.Command.SilenceUsage = true
+			r.Command.SilenceErrors = true
+			err := r.Command.Execute()
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+
+	r := NewRunner(fake.CtxWithDefaultPrinter(), "kpt")
+	r.Command.SetArgs([]string{"--clear"})
+	require.NoError(t, r.Command.Execute())
+	assert.NoFileExists(t, path)
+}
@@ -30,16 +30,60 @@ import (
 )
 
 func NewFactory(cmd *cobra.Command, version string) cluster.Factory {
+	f, _ := NewFactoryWithContexts(cmd, version)
+	return f
+}
+
+// ContextFactory returns a factory for the kubeconfig context kubeContext.
+type ContextFactory func(kubeContext string) cluster.Factory
+
+// NewFactoryWithContexts returns the factory returned by NewFactory, and a
+// ContextFactory for other contexts of the same kubeconfig. The factories
+// of the ContextFactory use the kubeconfig, namespace, impersonation and
+// timeout flags of cmd, but take the cluster and user from their context.
+func NewFactoryWithContexts(cmd *cobra.Command, version string) (cluster.Factory, ContextFactory) {
 	flags := cmd.PersistentFlags()
 	kubeConfigFlags := genericclioptions.NewConfigFlags(true).
 		WithDeprecatedPasswordFlag()
 	kubeConfigFlags.AddFlags(flags)
-	UpdateQPS(kubeConfigFlags)
+	limits := &rateLimits{}
+	flags.Float32Var(&limits.qps, "qps", 0,
+		"Maximum number of requests per second to the API server. A negative value disables client-side "+
+			"throttling. Defaults to no throttling if the API server has flow control enabled, and to 30 otherwise.")
+	flags.IntVar(&limits.burst, "burst", 0,
+		"Maximum burst of requests to the API server, when client-side throttling is enabled. "+
+			"Defaults to 60, or to twice --qps if it is set.")
+	cmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+
+	contextFactory := func(kubeContext string) cluster.Factory {
+		contextFlags := genericclioptions.NewConfigFlags(true)
+		contextFlags.KubeConfig = kubeConfigFlags.KubeConfig
+		contextFlags.CacheDir = kubeConfigFlags.CacheDir
+		contextFlags.Namespace = kubeConfigFlags.Namespace
+		contextFlags.Impersonate = kubeConfigFlags.Impersonate
+		contextFlags.ImpersonateUID = kubeConfigFlags.ImpersonateUID
+		contextFlags.ImpersonateGroup = kubeConfigFlags.ImpersonateGroup
+		contextFlags.Timeout = kubeConfigFlags.Timeout
+		contextFlags.DisableCompression = kubeConfigFlags.DisableCompression
+		contextFlags.Context = &kubeContext
+		return newFactory(contextFlags, version, limits)
+	}
+	return newFactory(kubeConfigFlags, version, limits), contextFactory
+}
+
+// rateLimits are the client-side throttling limits set with the --qps and
+// --burst flags. Zero values use the defaults of UpdateQPS.
+type rateLimits struct {
+	qps   float32
+	burst int
+}
+
+func newFactory(kubeConfigFlags *genericclioptions.ConfigFlags, version string, limits *rateLimits) cluster.Factory {
+	updateQPS(kubeConfigFlags, limits)
 	userAgentKubeConfigFlags := &cfgflags.UserAgentKubeConfigFlags{
 		Delegate:  kubeConfigFlags,
 		UserAgent: fmt.Sprintf("kpt/%s", version),
 	}
-	cmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
 	return cluster.NewFactory(userAgentKubeConfigFlags)
 }
 
@@ -55,8 +99,31 @@ func NewFactory(cmd *cobra.Command, version string) cluster.Factory {
 // Flow Control is enabled by default on Kubernetes v1.20+.
 // https://kubernetes.io/docs/concepts/cluster-administration/flow-control/
 func UpdateQPS(flags *genericclioptions.ConfigFlags) {
+	updateQPS(flags, &rateLimits{})
+}
+
+// updateQPS is UpdateQPS with the limits set by the user, which take
+// precedence over the defaults.
+func updateQPS(flags *genericclioptions.ConfigFlags, limits *rateLimits) {
 	flags.
 		WithWrapConfigFn(func(c *rest.Config) *rest.Config {
+			if limits.qps != 0 {
+				qps, burst := limits.qps, -1
+				if qps > 0 {
+					burst = limits.burst
+					if burst <= 0 {
+						burst = int(maxIfNotNegative(2*qps, 1))
+					}
+				}
+				klog.V(1).Infof("Client-side throttling QPS set to %.0f (burst: %d)", qps, burst)
+				c.QPS = qps
+				c.Burst = burst
+				flags.
+					WithDiscoveryQPS(qps).
+					WithDiscoveryBurst(burst)
+				return c
+			}
+
 			// Timeout if the query takes too long, defaulting to the lower QPS limits.
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
@@ -74,6 +141,9 @@ func UpdateQPS(flags *genericclioptions.ConfigFlags) {
 			} else {
 				qps = maxIfNotNegative(c.QPS, 30)
 				burst = int(maxIfNotNegative(float32(c.Burst), 60))
+				if limits.burst > 0 {
+					burst = limits.burst
+				}
 				klog.V(1).Infof("Flow control disabled on apiserver: client-side throttling QPS set to %.0f (burst: %d)", qps, burst)
 			}
 
@@ -0,0 +1,56 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+)
+
+func TestUpdateQPS_limits(t *testing.T) {
+	testCases := map[string]struct {
+		limits        rateLimits
+		expectedQPS   float32
+		expectedBurst int
+	}{
+		"qps": {
+			limits:        rateLimits{qps: 50},
+			expectedQPS:   50,
+			expectedBurst: 100,
+		},
+		"qps and burst": {
+			limits:        rateLimits{qps: 50, burst: 200},
+			expectedQPS:   50,
+			expectedBurst: 200,
+		},
+		"throttling disabled": {
+			limits:        rateLimits{qps: -1, burst: 200},
+			expectedQPS:   -1,
+			expectedBurst: -1,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			flags := genericclioptions.NewConfigFlags(true)
+			updateQPS(flags, &tc.limits)
+			c := flags.WrapConfigFn(&rest.Config{Host: "https://127.0.0.1:1"})
+			assert.Equal(t, tc.expectedQPS, c.QPS)
+			assert.Equal(t, tc.expectedBurst, c.Burst)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render contains the ws render command
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/wsdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/render"
+	"github.com/GoogleContainerTools/kpt/internal/workspace"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// NewRunner returns a command runner
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{ctx: ctx}
+	r.RunnerOptions.InitDefaults()
+
+	c := &cobra.Command{
+		Use:     "render [DIR] [flags]",
+		Short:   docs.RenderShort,
+		Long:    docs.RenderShort + "\n" + docs.RenderLong,
+		Example: docs.RenderExamples,
+		RunE:    r.runE,
+		Args:    cobra.MaximumNArgs(1),
+	}
+	c.Flags().StringVar(&r.resultsDirPath, "results-dir", "",
+		"path to a directory to save function results, in a subdirectory for every package")
+	c.Flags().Var(&r.RunnerOptions.ImagePullPolicy, "image-pull-policy",
+		"pull image before running the container "+r.RunnerOptions.ImagePullPolicy.HelpAllowedValues())
+	_ = c.RegisterFlagCompletionFunc("image-pull-policy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return r.RunnerOptions.ImagePullPolicy.AllStrings(), cobra.ShellCompDirectiveDefault
+	})
+	c.Flags().BoolVar(&r.RunnerOptions.AllowExec, "allow-exec", r.RunnerOptions.AllowExec,
+		"allow binary executable to be run during pipeline execution.")
+	c.Flags().BoolVar(&r.RunnerOptions.AllowNetwork, "allow-network", false,
+		"allow functions to access network during pipeline execution.")
+	c.Flags().BoolVar(&r.RunnerOptions.AllowMount, "allow-mount", false,
+		"allow functions to mount the storage declared in their `mounts` field.")
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function
+type Runner struct {
+	ctx            context.Context
+	resultsDirPath string
+	Command        *cobra.Command
+
+	RunnerOptions fnruntime.RunnerOptions
+}
+
+func (r *Runner) runE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmdwsrender.runE"
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	dir, err := argutil.ResolveSymlink(r.ctx, dir)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	ws, err := workspace.Find(filesys.FileSystemOrOnDisk{}, dir)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return ws.ForEachPackage(r.ctx, "render", func(pkgPath string) error {
+		var resultsDir string
+		if r.resultsDirPath != "" {
+			resultsDir = filepath.Join(r.resultsDirPath, filepath.FromSlash(ws.DisplayPath(pkgPath)))
+			if err := os.MkdirAll(resultsDir, 0755); err != nil {
+				return fmt.Errorf("cannot read or create results dir %q: %w", resultsDir, err)
+			}
+		}
+		renderer := render.Renderer{
+			PkgPath:        pkgPath,
+			ResultsDirPath: resultsDir,
+			RunnerOptions:  r.RunnerOptions,
+			FileSystem:     filesys.FileSystemOrOnDisk{},
+		}
+		_, err := renderer.Execute(r.ctx)
+		return err
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd_render(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Kptworkspace": `apiVersion: kpt.dev/v1alpha1
+kind: Kptworkspace
+metadata:
+  name: platform
+packages:
+- frontend
+- backend
+`,
+		"frontend/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: frontend\n",
+		"frontend/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: frontend\n",
+		// exec functions are not allowed, so rendering backend fails.
+		"backend/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: backend
+pipeline:
+  mutators:
+  - exec: ./set-labels
+`,
+	}
+	for p, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(p)), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, p), []byte(content), 0600))
+	}
+	resultsDir := filepath.Join(t.TempDir(), "results")
+
+	var out, errOut bytes.Buffer
+	r := NewRunner(fake.CtxWithPrinter(&out, &errOut), "kpt")
+	r.Command.SetArgs([]string{dir, "--results-dir", resultsDir})
+	err := r.Command.Execute()
+	if assert.Error(t, err) {
+		assert.Equal(t, "render failed for 1 of 2 packages", err.Error())
+	}
+	assert.Contains(t, out.String(), "\nPACKAGE   RESULT\nfrontend  Succeeded\nbackend   Failed: ")
+	assert.FileExists(t, filepath.Join(resultsDir, "frontend", "results.yaml"))
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tree contains the ws tree command
+package tree
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/wsdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/workspace"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/spf13/cobra"
+	"github.com/xlab/treeprint"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{ctx: ctx}
+	c := &cobra.Command{
+		Use:     "tree [DIR]",
+		Short:   docs.TreeShort,
+		Long:    docs.TreeShort + "\n" + docs.TreeLong,
+		Example: docs.TreeExamples,
+		RunE:    r.runE,
+		Args:    cobra.MaximumNArgs(1),
+	}
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx     context.Context
+	Command *cobra.Command
+}
+
+func (r *Runner) runE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmdwstree.runE"
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	dir, err := argutil.ResolveSymlink(r.ctx, dir)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	fsys := filesys.FileSystemOrOnDisk{}
+	ws, err := workspace.Find(fsys, dir)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := printTree(printer.FromContextOrDie(r.ctx).OutStream(), fsys, ws); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// printTree prints the packages of the workspace and their subpackages as
+// a tree.
+func printTree(out io.Writer, fsys filesys.FileSystem, ws *workspace.Workspace) error {
+	tree := treeprint.New()
+	tree.SetValue(fmt.Sprintf("Kptworkspace %q", ws.Kptworkspace.Name))
+	for _, pkgPath := range ws.PackagePaths() {
+		label, err := packageLabel(fsys, pkgPath, ws.DisplayPath(pkgPath))
+		if err != nil {
+			return err
+		}
+		branch := tree.AddBranch(label)
+
+		subPkgs, err := pkg.Subpackages(fsys, pkgPath, pkg.All, true)
+		if err != nil {
+			return err
+		}
+		sort.Strings(subPkgs)
+		branches := map[string]treeprint.Tree{".": branch}
+		for _, sp := range subPkgs {
+			// subpackages are sorted, so the closest ancestor package of a
+			// subpackage already has a branch.
+			parent := filepath.Dir(sp)
+			for branches[parent] == nil {
+				parent = filepath.Dir(parent)
+			}
+			label, err := packageLabel(fsys, filepath.Join(pkgPath, sp), filepath.Base(sp))
+			if err != nil {
+				return err
+			}
+			branches[sp] = branches[parent].AddBranch(label)
+		}
+	}
+	_, err := io.WriteString(out, tree.String())
+	return err
+}
+
+// packageLabel returns the label of the package at pkgPath in the tree,
+// with its upstream if it has one.
+func packageLabel(fsys filesys.FileSystem, pkgPath, name string) (string, error) {
+	kf, err := pkg.ReadKptfile(fsys, pkgPath)
+	if err != nil {
+		return "", err
+	}
+	label := fmt.Sprintf("Package %q", name)
+	if kf.Upstream != nil && kf.Upstream.Git != nil {
+		g := kf.Upstream.Git
+		upstream := g.Repo
+		if d := strings.Trim(g.Directory, "/"); d != "" {
+			upstream += "/" + d
+		}
+		label += fmt.Sprintf(" (upstream %s@%s)", upstream, g.Ref)
+	}
+	return label, nil
+}
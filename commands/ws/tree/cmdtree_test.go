@@ -0,0 +1,72 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd_tree(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Kptworkspace": `apiVersion: kpt.dev/v1alpha1
+kind: Kptworkspace
+metadata:
+  name: platform
+packages:
+- infra
+- apps/frontend
+`,
+		"apps/frontend/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: frontend
+upstream:
+  type: git
+  git:
+    repo: https://github.com/my-org/blueprints
+    directory: /frontend
+    ref: v1.2.0
+`,
+		"infra/Kptfile":                 "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: infra\n",
+		"infra/network/Kptfile":         "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: network\n",
+		"infra/network/subnets/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: subnets\n",
+		"infra/dns/Kptfile":             "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: dns\n",
+		"infra/dns/zone.yaml":           "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: zone\n",
+	}
+	for p, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(p)), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, p), []byte(content), 0600))
+	}
+
+	var out bytes.Buffer
+	r := NewRunner(fake.CtxWithPrinter(&out, &out), "kpt")
+	r.Command.SetArgs([]string{filepath.Join(dir, "infra", "network")})
+	require.NoError(t, r.Command.Execute())
+	assert.Equal(t, `Kptworkspace "platform"
+├── Package "infra"
+│   ├── Package "dns"
+│   └── Package "network"
+│       └── Package "subnets"
+└── Package "apps/frontend" (upstream https://github.com/my-org/blueprints/frontend@v1.2.0)
+`, out.String())
+}
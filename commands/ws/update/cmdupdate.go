@@ -0,0 +1,115 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package update contains the ws update command
+package update
+
+import (
+	"context"
+	"strings"
+
+	docs "github.com/GoogleContainerTools/kpt/internal/docs/generated/wsdocs"
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/util/argutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/update"
+	"github.com/GoogleContainerTools/kpt/internal/workspace"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// NewRunner returns a command runner.
+func NewRunner(ctx context.Context, parent string) *Runner {
+	r := &Runner{ctx: ctx}
+	c := &cobra.Command{
+		Use:     "update [DIR] [flags]",
+		Short:   docs.UpdateShort,
+		Long:    docs.UpdateShort + "\n" + docs.UpdateLong,
+		Example: docs.UpdateExamples,
+		RunE:    r.runE,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: r.preRunE,
+	}
+
+	c.Flags().StringVar(&r.strategy, "strategy", "",
+		"the update strategy that will be used when updating the packages. This will change "+
+			"the default strategy for the packages -- must be one of: "+
+			strings.Join(kptfilev1.UpdateStrategiesAsStrings(), ","))
+	_ = c.RegisterFlagCompletionFunc("strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return kptfilev1.UpdateStrategiesAsStrings(), cobra.ShellCompDirectiveDefault
+	})
+	cmdutil.FixDocs("kpt", parent, c)
+	r.Command = c
+	return r
+}
+
+func NewCommand(ctx context.Context, parent string) *cobra.Command {
+	return NewRunner(ctx, parent).Command
+}
+
+// Runner contains the run function.
+type Runner struct {
+	ctx      context.Context
+	strategy string
+	Update   update.Command
+	Command  *cobra.Command
+}
+
+func (r *Runner) preRunE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = "cmdwsupdate.preRunE"
+	if r.strategy != "" {
+		strategy, err := kptfilev1.ToUpdateStrategy(r.strategy)
+		if err != nil {
+			return errors.E(op, errors.InvalidParam, err)
+		}
+		r.Update.Strategy = strategy
+	}
+	return nil
+}
+
+func (r *Runner) runE(_ *cobra.Command, args []string) error {
+	const op errors.Op = "cmdwsupdate.runE"
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	dir, err := argutil.ResolveSymlink(r.ctx, dir)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	fsys := filesys.FileSystemOrOnDisk{}
+	ws, err := workspace.Find(fsys, dir)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	// The same update command is used for all packages, so upstream repos
+	// that are shared by packages are only fetched once.
+	return ws.ForEachPackage(r.ctx, "update", func(pkgPath string) error {
+		p, err := pkg.New(fsys, pkgPath)
+		if err != nil {
+			return err
+		}
+		kf, err := p.Kptfile()
+		if err != nil {
+			return err
+		}
+		if kf.Upstream == nil || kf.Upstream.Git == nil {
+			return &workspace.SkipError{Reason: "package has no upstream"}
+		}
+		r.Update.Pkg = p
+		return r.Update.Run(r.ctx)
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmd_update(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Kptworkspace": `apiVersion: kpt.dev/v1alpha1
+kind: Kptworkspace
+metadata:
+  name: platform
+packages:
+- frontend
+`,
+		"frontend/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: frontend\n",
+	}
+	for p, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(p)), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, p), []byte(content), 0600))
+	}
+
+	testCases := map[string]struct {
+		args           []string
+		expectedOutput string
+		expectedErr    string
+	}{
+		"packages without upstream are skipped": {
+			args:           []string{dir},
+			expectedOutput: "\nPACKAGE   RESULT\nfrontend  Skipped: package has no upstream\n",
+		},
+		"invalid strategy": {
+			args:        []string{dir, "--strategy", "merge"},
+			expectedErr: `unknown update strategy "merge"`,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var out bytes.Buffer
+			r := NewRunner(fake.CtxWithPrinter(&out, &out), "kpt")
+			r.Command.SetArgs(tc.args)
+			err := r.Command.Execute()
+			if tc.expectedErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
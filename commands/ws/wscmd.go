@@ -0,0 +1,50 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ws
+
+import (
+	"context"
+
+	"github.com/GoogleContainerTools/kpt/commands/ws/render"
+	"github.com/GoogleContainerTools/kpt/commands/ws/tree"
+	"github.com/GoogleContainerTools/kpt/commands/ws/update"
+	"github.com/GoogleContainerTools/kpt/internal/docs/generated/wsdocs"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand(ctx context.Context, name string) *cobra.Command {
+	ws := &cobra.Command{
+		Use:     "ws",
+		Short:   wsdocs.WsShort,
+		Long:    wsdocs.WsLong,
+		Aliases: []string{"workspace"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			h, err := cmd.Flags().GetBool("help")
+			if err != nil {
+				return err
+			}
+			if h {
+				return cmd.Help()
+			}
+			return cmd.Usage()
+		},
+	}
+
+	ws.AddCommand(
+		render.NewCommand(ctx, name), update.NewCommand(ctx, name),
+		tree.NewCommand(ctx, name),
+	)
+	return ws
+}
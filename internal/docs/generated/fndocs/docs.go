@@ -7,6 +7,50 @@ The ` + "`" + `fn` + "`" + ` command group contains subcommands for transforming
 using containerized functions.
 `
 
+var DevShort = `Run a function under development on a package whenever it changes`
+var DevLong = `
+  kpt fn dev [PKG_PATH] --exec EXEC [flags]
+
+Args:
+
+  PKG_PATH:
+    Local package path to run the function on. Defaults to the current
+    directory.
+
+Flags:
+
+  --exec:
+    The command running the function, e.g. ` + "`" + `go run ./cmd/my-fn` + "`" + ` or
+    ` + "`" + `./my-fn.star` + "`" + `. A single ` + "`" + `.go` + "`" + ` file is run with ` + "`" + `go run` + "`" + `. If the command
+    refers to a local file, the directory of the file is watched.
+  
+  --fn-config:
+    Path to the file containing the ` + "`" + `functionConfig` + "`" + ` of the function.
+  
+  --interval:
+    How often the watched files are checked for changes. Defaults to 500ms.
+  
+  --once:
+    Run the function once and exit, instead of watching for changes. The
+    command fails if the function fails.
+  
+  --watch:
+    A file or directory of the source of the function to watch, in addition to
+    the package. Can be repeated. Git metadata is not watched.
+`
+var DevExamples = `
+  # Run the function in main.go on the package in my-pkg whenever main.go or the
+  # package changes
+  $ kpt fn dev my-pkg --exec ./main.go
+
+  # Run a function with its config, and watch the packages of its source
+  $ kpt fn dev my-pkg --exec "go run ./cmd/set-owner" --fn-config owner.yaml \
+    --watch ./cmd --watch ./pkg
+
+  # Print the changes made by a starlark function once
+  $ kpt fn dev my-pkg --exec ./fn.star --once
+`
+
 var DocShort = `Display the documentation for a function`
 var DocLong = `
 ` + "`" + `kpt fn doc` + "`" + ` invokes the function container with ` + "`" + `--help` + "`" + ` flag.
@@ -26,6 +70,8 @@ Environment Variables:
 
   KPT_FN_RUNTIME:
     The runtime to run kpt functions. It must be one of "docker", "podman" and "nerdctl".
+    If it is not set, the first available of them is used. Run
+    ` + "`" + `kpt fn runtime-check` + "`" + ` to see which runtime is used.
 `
 var DocExamples = `
   # display the documentation for image set-namespace:v0.1.1
@@ -63,6 +109,16 @@ Flags:
     By default, container function is executed as ` + "`" + `nobody` + "`" + ` user. You may want to use
     this flag to run higher privilege operations such as mounting the local filesystem.
   
+  --chain:
+    Path to a file with a list of functions to execute in sequence, each on the
+    output of the previous one. The functions are specified like in the
+    Kptfile ` + "`" + `pipeline` + "`" + `, with ` + "`" + `image` + "`" + ` or ` + "`" + `exec` + "`" + `, and ` + "`" + `configPath` + "`" + ` or ` + "`" + `configMap` + "`" + `.
+    ` + "`" + `configPath` + "`" + ` is relative to the directory of the file. The resources are read
+    and written only once for the whole chain, and nothing is written if a
+    function fails. The selector, exclusion and container flags apply to all
+    the functions. Can't be used with ` + "`" + `--image` + "`" + `, ` + "`" + `--exec` + "`" + `, ` + "`" + `--fn-config` + "`" + `,
+    ` + "`" + `--save` + "`" + ` or function arguments.
+  
   --env, e:
     List of local environment variables to be exported to the container function.
     By default, none of local environment variables are made available to the
@@ -94,6 +150,8 @@ Flags:
     If using ifNotPresent, kpt will only pull the image when it can't find it in
     the local cache.
     If using never, kpt will only use images from the local cache.
+    With the global --offline flag, images are never pulled, and missing images
+    are an error.
   
   --include-meta-resources, m:
     (DEPRECATED) include-meta-resources is no longer necessary because meta
@@ -130,6 +188,11 @@ Flags:
     3. OUT_DIR_PATH: output resources are written to provided directory.
        The provided directory must not already exist.
   
+  --override:
+    If used with ` + "`" + `--save` + "`" + `, this flag will save the evaluated function to the
+    ` + "`" + `.upstream.overrides` + "`" + ` section of the Kptfile instead of the pipeline, so that
+    it is run again on the package after every ` + "`" + `kpt pkg update` + "`" + `. Requires ` + "`" + `--image` + "`" + `.
+  
   --type, t;
     Specify the function type. Accept value ` + "`" + `mutator` + "`" + ` (default), ` + "`" + `validator` + "`" + `. 
     If used with ` + "`" + `--save` + "`" + `, this flag will save the evaluated function to the corresponding
@@ -150,6 +213,8 @@ Environment Variables:
 
   KPT_FN_RUNTIME:
     The runtime to run kpt functions. It must be one of "docker", "podman" and "nerdctl".
+    If it is not set, the first available of them is used. Run
+    ` + "`" + `kpt fn runtime-check` + "`" + ` to see which runtime is used.
 `
 var EvalExamples = `
   # execute container my-fn on the resources in DIR directory and
@@ -169,6 +234,10 @@ var EvalExamples = `
   # execute container my-fn and save it to Kptfile ` + "`" + `pipeline.validators` + "`" + ` list.
   $ kpt fn eval DIR -s -t validator -i gcr.io/example.com/my-fn:v1.0.0 -- foo=bar
 
+  # execute container set-namespace and save it to Kptfile ` + "`" + `upstream.overrides` + "`" + ` list
+  # to re-apply it after every update.
+  $ kpt fn eval DIR -s --override -i ghcr.io/kptdev/krm-functions-catalog/set-namespace:latest -- namespace=staging
+
   # execute executable my-fn on the resources in DIR directory and
   # write output back to DIR
   $ kpt fn eval DIR --exec ./my-fn
@@ -201,6 +270,16 @@ var EvalExamples = `
     | kpt fn eval - -i gcr.io/kpt-fn/set-labels:v0.1 -- label_name=color label_value=orange \
     | kpt fn sink wordpress
 
+  # chaining functions with a chain file to set namespace and set labels on
+  # wordpress package, reading and writing the package only once
+  $ cat chain.yaml
+  - image: gcr.io/kpt-fn/set-namespace:v0.1
+    configMap:
+      namespace: mywordpress
+  - image: gcr.io/kpt-fn/set-labels:v0.1
+    configPath: labels.yaml
+  $ kpt fn eval wordpress --chain chain.yaml
+
   # execute container 'set-namespace' on the resources in current directory and write
   # the output resources to another directory
   $ kpt fn eval -i gcr.io/kpt-fn/set-namespace:v0.1 -o path/to/dir -- namespace=mywordpress
@@ -269,18 +348,76 @@ Args:
 
 Flags:
 
+  --add-mutator:
+    A mutator appended to the pipeline of the package for this render only,
+    specified as a comma separated list of ` + "`" + `KEY=VALUE` + "`" + ` pairs. The keys are
+    ` + "`" + `image` + "`" + ` or ` + "`" + `exec` + "`" + `, ` + "`" + `config` + "`" + ` for the path of the function config in the
+    package, and ` + "`" + `name` + "`" + `, e.g. ` + "`" + `image=set-labels:v0.1,config=labels.yaml` + "`" + `.
+    The Kptfile is not modified. Can be repeated.
+  
+  --add-validator:
+    A validator appended to the pipeline of the package for this render only,
+    specified like ` + "`" + `--add-mutator` + "`" + `. Can be repeated.
+  
   --allow-exec:
     Allow executable binaries to run as function. Note that executable binaries
     can perform privileged operations on your system, so ensure that binaries
-    referred in the pipeline are trusted and safe to execute.
+    referred in the pipeline are trusted and safe to execute. Functions with
+    ` + "`" + `exec: starlark` + "`" + ` run in the built-in sandboxed starlark runtime, and
+    functions with ` + "`" + `exec: apply-replacements` + "`" + ` in the built-in replacements
+    engine, and don't require this flag.
   
   --allow-network:
     Allow functions to access network during pipeline execution. Default: ` + "`" + `false` + "`" + `. Note that this is applicable to container based functions only.
+    Functions that set ` + "`" + `network: false` + "`" + ` in the Kptfile never have network
+    access, and functions that set ` + "`" + `network: true` + "`" + ` require this flag.
+  
+  --allow-mount:
+    Allow container based functions to mount the storage declared in their
+    ` + "`" + `mounts` + "`" + ` field in the Kptfile. Functions with ` + "`" + `mounts` + "`" + ` require this flag.
+    The sources of ` + "`" + `bind` + "`" + ` mounts must be within the package, also after
+    following symlinks. Default: ` + "`" + `false` + "`" + `.
+  
+  --annotate-generated:
+    Mark the resources generated by the mutators with the
+    ` + "`" + `kpt.dev/generated-by` + "`" + ` annotation, set to the image or exec of the function
+    that generated them. Resources authored in the package are not annotated.
+    The annotation lets ` + "`" + `kpt pkg tree --hide-generated` + "`" + ` and
+    ` + "`" + `kpt pkg diff --ignore-generated` + "`" + ` show only the authored resources.
+    Default: ` + "`" + `false` + "`" + `.
+  
+  --emit-workflow:
+    Instead of rendering the package, print a workflow definition that runs the
+    functions of the pipeline, one step per function, in the same order as
+    ` + "`" + `render` + "`" + `. Allowed values: tekton|argo
+    1. tekton: a Tekton PipelineRun with a single task.
+    2. argo: an Argo Workflow with one template per function.
+    Every step runs ` + "`" + `kpt fn eval` + "`" + ` in the kpt image against the package in the
+    ` + "`" + `source` + "`" + ` workspace (Tekton) or volume (Argo), which is bound to the
+    PersistentVolumeClaim ` + "`" + `kpt-source` + "`" + `. A docker daemon sidecar runs the function
+    containers. Functions using ` + "`" + `exec` + "`" + `, ` + "`" + `mounts` + "`" + ` or ` + "`" + `env` + "`" + `, and functions with
+    more than one selector or exclusion are not supported.
+  
+  --env, e:
+    An environment variable that functions may receive, specified as
+    ` + "`" + `KEY=VALUE` + "`" + `, or as ` + "`" + `KEY` + "`" + ` to take the value from the environment of kpt.
+    A function only receives the variables listed in its ` + "`" + `env` + "`" + ` field in the
+    Kptfile, and rendering fails if one of them isn't given. The values are
+    passed to the container runtime through its environment, not its command
+    line, and are replaced with ` + "`" + `[REDACTED]` + "`" + ` in the stderr and results of all
+    functions. Can be repeated, and takes precedence over ` + "`" + `--env-file` + "`" + `.
+  
+  --env-file:
+    Path to a file with environment variables that functions may receive, one
+    ` + "`" + `KEY=VALUE` + "`" + ` or ` + "`" + `KEY` + "`" + ` per line. Empty lines and lines starting with ` + "`" + `#` + "`" + ` are
+    ignored. Keep the file outside of the package, so secrets are not
+    committed.
   
   --image-pull-policy:
     If the image should be pulled before rendering the package(s). It can be set
     to one of always, ifNotPresent, never. If unspecified, always will be the
-    default.
+    default. With the global --offline flag, images are never pulled, and
+    missing images are an error.
   
   --output, o:
     If specified, the output resources are written to provided location,
@@ -291,16 +428,102 @@ Flags:
     3. OUT_DIR_PATH: output resources are written to provided directory.
        The provided directory must not already exist.
   
+  --reference-graph:
+    Path to a file to write the reference graph of the rendered resources to.
+    The reference graph is a ` + "`" + `ReferenceGraph` + "`" + ` resource with an entry for every
+    field of a resource that refers to another resource, e.g. from
+    ` + "`" + `spec.template.spec.volumes[0].configMap.name` + "`" + ` of a Deployment to a
+    ConfigMap. Each entry lists the referring resource, the path of the field and
+    the referenced resource, and sets ` + "`" + `missing: true` + "`" + ` if the referenced resource
+    is not in the package. References are found with a built-in catalog of the
+    fields of Kubernetes resources that refer to ConfigMaps, Secrets, Services,
+    ServiceAccounts, PersistentVolumeClaims, Namespaces, (Cluster)Roles and the
+    targets of HorizontalPodAutoscalers. Local config resources are ignored.
+    Transformations that rename resources or change their namespace, and
+    validators that check for dangling references, can read the graph. The file
+    should be outside of the package.
+  
   --results-dir:
     Path to a directory to write structured results. Directory will be created if
     it doesn't exist. Structured results emitted by the functions are aggregated and saved
     to ` + "`" + `results.yaml` + "`" + ` file in the specified directory.
     If not specified, no result files are written to the local filesystem.
+  
+  --resolve-external:
+    Resolve the ` + "`" + `ExternalValue` + "`" + ` resources of the package with the given
+    providers, and set the values in the resources they target. Resolution is
+    opt-in, and an ` + "`" + `ExternalValue` + "`" + ` with a provider that is not listed fails the
+    render. The values are resolved after the pipeline has run, so they are
+    never seen by the functions. It requires ` + "`" + `--output` + "`" + `, so that the resolved
+    values, often secrets, are never written to the package. An ` + "`" + `ExternalValue` + "`" + ` looks like:
+  
+      apiVersion: kpt.dev/v1alpha1
+      kind: ExternalValue
+      metadata:
+        name: db-password
+        annotations:
+          config.kubernetes.io/local-config: "true"
+      spec:
+        provider: vault
+        ref: secret/data/db#password
+        encoding: base64 # optional
+        target:
+          kind: Secret
+          name: db
+          fieldPath: data.password
+  
+    The providers are:
+  
+      * env: The reference is the name of an environment variable.
+      * vault: The reference is the path of a HashiCorp Vault secret and a key,
+        separated by ` + "`" + `#` + "`" + `. The server and token are read from VAULT_ADDR and
+        VAULT_TOKEN, or ~/.vault-token.
+      * gcp-secret-manager: The reference is the name of a Google Cloud Secret
+        Manager secret or secret version, e.g.
+        ` + "`" + `projects/my-project/secrets/db/versions/3` + "`" + `. The application default
+        credentials are used.
+  
+    Rendering in-place writes the resolved values to the files of the package,
+    so use it with --output to keep them out of the package.
+  
+  --skip-mutator:
+    The name, image, exec or composite of a mutator in the pipeline of the
+    package to skip for this render only. Rendering fails if no mutator matches. The Kptfile is
+    not modified. Can be repeated.
+  
+  --skip-validator:
+    The name, image, exec or composite of a validator in the pipeline of the
+    package to skip for this render only, e.g. for an emergency fix while a validator is
+    broken. Rendering fails if no validator matches. Can be repeated.
+  
+  --status-file:
+    Path to a file to write the render status to. The render status is a
+    ` + "`" + `RenderStatus` + "`" + ` resource with an entry for every mutator that was run, in
+    order, with the function image or exec, the package of the pipeline, the time
+    the function was run and the resources it added, modified or removed. For
+    modified resources, the paths of the changed fields are listed, for example
+    ` + "`" + `metadata.labels[app.kubernetes.io/name]` + "`" + ` or ` + "`" + `spec.template.spec.containers[0].image` + "`" + `.
+    The file should be outside of the package, since it would otherwise be read
+    as a resource of the package by the next render.
+  
+  --verify-idempotent:
+    Render the package a second time, starting from the output of the first
+    render, and fail if the second render changes any resources. The changed
+    resources and fields are listed in the error. The second render doesn't
+    modify the local filesystem, and nothing is written if the verification
+    fails. Use it to catch non-deterministic functions before their output is
+    committed.
+  
+  --workflow-kpt-image:
+    The kpt image used by the steps of the workflow printed with
+    --emit-workflow. Defaults to ` + "`" + `gcr.io/kpt-dev/kpt:latest` + "`" + `.
 
 Environment Variables:
 
   KPT_FN_RUNTIME:
     The runtime to run kpt functions. It must be one of "docker", "podman" and "nerdctl".
+    If it is not set, the first available of them is used. Run
+    ` + "`" + `kpt fn runtime-check` + "`" + ` to see which runtime is used.
 `
 var RenderExamples = `
   # Render the package in current directory
@@ -312,6 +535,14 @@ var RenderExamples = `
   # Render my-package-dir
   $ kpt fn render my-package-dir
 
+  # Render the package in current directory and record the changes made by
+  # every mutator in /tmp/render-status.yaml
+  $ kpt fn render --status-file /tmp/render-status.yaml
+
+  # Render the package in current directory and record the references between
+  # its resources in /tmp/reference-graph.yaml
+  $ kpt fn render --reference-graph /tmp/reference-graph.yaml
+
   # Render the package in current directory and write output resources to another DIR
   $ kpt fn render -o path/to/dir
 
@@ -330,6 +561,102 @@ var RenderExamples = `
 
   # Render my-package-dir with network access enabled for functions
   $ kpt fn render --allow-network
+
+  # Render my-package-dir, passing the token of the pricing API to the
+  # functions that list PRICING_API_TOKEN in their ` + "`" + `env` + "`" + ` field
+  $ kpt fn render my-package-dir --env PRICING_API_TOKEN --env-file ~/.kpt/secrets.env
+
+  # Render my-package-dir and fail if rendering the output again changes it
+  $ kpt fn render my-package-dir --verify-idempotent
+
+  # Render my-package-dir without the kubeval validator and with an additional
+  # mutator, without changing the Kptfile
+  $ kpt fn render my-package-dir --skip-validator gcr.io/kpt-fn/kubeval:v0.3 \
+    --add-mutator image=gcr.io/kpt-fn/set-labels:v0.1,config=labels.yaml
+
+  # Render my-package-dir with the secrets from Vault and apply the output,
+  # without writing the secrets to the package
+  $ kpt fn render my-package-dir --resolve-external vault -o unwrap | kubectl apply -f -
+
+  # Print a Tekton PipelineRun that renders the package in-cluster
+  $ kpt fn render my-package-dir --emit-workflow tekton > pipelinerun.yaml
+`
+
+var RuntimeCheckShort = `Check which container runtime functions run with`
+var RuntimeCheckLong = `
+` + "`" + `kpt fn runtime-check` + "`" + ` inspects the container runtimes on the host and reports
+which one container functions run with. It fails if that runtime is not
+available.
+
+  kpt fn runtime-check
+
+For each of docker, podman and nerdctl the command prints whether it is
+installed and available, its version, and whether it runs rootless.
+
+If ` + "`" + `KPT_FN_RUNTIME` + "`" + ` is not set, functions run with the first available runtime
+in the order docker, podman, nerdctl. If none is available, docker is used.
+
+When the runtime runs rootless, kpt adjusts how function containers are run:
+
+- On hosts with cgroup v1, podman runs the container with ` + "`" + `--cgroups=disabled` + "`" + `
+  and nerdctl with ` + "`" + `--cgroup-manager=none` + "`" + `, since a rootless user can't manage
+  cgroups.
+- If the user has no subordinate user ids in ` + "`" + `/etc/subuid` + "`" + `, the user namespace
+  of the container can't map the ` + "`" + `nobody` + "`" + ` user, so functions run as root in
+  the user namespace instead. This has the privileges of the user running kpt.
+  Setting the user of a function explicitly takes precedence.
+
+The adjustments made on the host are printed by the command.
+
+Environment Variables:
+
+  KPT_FN_RUNTIME:
+    The runtime to run kpt functions. It must be one of "docker", "podman" and "nerdctl".
+`
+var RuntimeCheckExamples = `
+  # check the container runtime that functions run with
+  $ kpt fn runtime-check
+
+  # check that podman can run functions
+  $ KPT_FN_RUNTIME=podman kpt fn runtime-check
+`
+
+var ServeShort = `Serve function evaluation over gRPC and HTTP`
+var ServeLong = `
+  kpt fn serve [flags]
+
+Flags:
+
+  --address:
+    The address to serve the gRPC endpoint on. Defaults to ` + "`" + `localhost:9445` + "`" + `.
+  
+  --http-address:
+    The address to serve the HTTP endpoint on. The HTTP endpoint is disabled
+    unless this flag is set.
+  
+  --image-pull-policy:
+    If the image should be pulled before running a function. It can be set to
+    one of always, ifNotPresent, never. If unspecified, ifNotPresent will be
+    used, so that repeated evaluations don't pull the image every time.
+  
+  --timeout:
+    How long a function may run before it is stopped. Defaults to 5 minutes.
+
+Environment Variables:
+
+  KPT_FN_RUNTIME:
+    The runtime to run kpt functions. It must be one of "docker", "podman" and "nerdctl".
+    If it is not set, the first available of them is used. Run
+    ` + "`" + `kpt fn runtime-check` + "`" + ` to see which runtime is used.
+`
+var ServeExamples = `
+  # serve the gRPC endpoint on the default address
+  $ kpt fn serve
+
+  # also serve the HTTP endpoint and evaluate a function with curl
+  $ kpt fn serve --http-address=localhost:8080 &
+  $ curl -X POST localhost:8080/v1/evaluate \
+    -d '{"image": "set-namespace:v0.4.1", "resourceList": "..."}'
 `
 
 var SinkShort = `Write resources to a local directory`
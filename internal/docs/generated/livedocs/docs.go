@@ -21,6 +21,41 @@ Args:
 
 Flags:
 
+  --admission-dry-run:
+    Before applying, send every resource of the package to the cluster as a
+    server-side dry-run, so that it is evaluated by the
+    ValidatingAdmissionPolicies and admission webhooks of the cluster. All the
+    resources that would be denied are reported at once, grouped by the policy
+    or webhook that denied them, instead of the apply failing at the first
+    denied resource. Resources in namespaces or of kinds created by the package
+    are skipped, since they can't be dry-run before the apply. The violations
+    are handled according to --preflight, which can't be off. Default value is
+    false.
+  
+  --adopt:
+    Adopt the resources of the package that already exist in the cluster without
+    being managed by any inventory, e.g. resources that were applied with
+    kubectl, instead of failing to apply them. The adopted resources are listed
+    before the apply, and marked as adopted in the report of --report. Resources
+    managed by other inventories are only adopted with --force-adopt. Can't be
+    used with --inventory-policy or --plan.
+  
+  --adopt-match:
+    Only adopt the unmanaged resources matching this rule. A rule is a
+    comma-separated list of the keys group, kind, namespace and name with glob
+    patterns, e.g. ` + "`" + `kind=Deployment,namespace=prod,name=web-*` + "`" + `. Can be repeated,
+    and a resource is adopted if it matches any rule. The apply fails if an
+    unmanaged resource doesn't match any rule. Can only be used with --adopt.
+  
+  --contexts:
+    Comma-separated kubeconfig contexts of the clusters to apply the package to,
+    e.g. ` + "`" + `ctx1,ctx2` + "`" + `. The package is loaded and applied separately for every
+    cluster, with the same inventory, since every cluster keeps its own
+    inventory object. Each line of output is prefixed with the context it is
+    for, and a summary with the result for every context is printed at the end.
+    A failed apply doesn't stop the applies to the other clusters, but the
+    command fails if any of them failed. Can't be used with --context or --plan.
+  
   --dry-run:
     It true, kpt will validate the resources in the package and print which
     resources will be applied and which resources will be pruned, but no resources
@@ -31,12 +66,29 @@ Flags:
   
   --field-manager:
     Identifier for the **owner** of the fields being applied. Only usable
-    when --server-side flag is specified. Default value is kubectl.
+    when --server-side flag is specified. Default value is kubectl, or the
+    value of the ` + "`" + `kpt.dev/field-manager` + "`" + ` annotation of the inventory (see
+    Server-side apply policies below).
+  
+  --force-adopt:
+    Before applying, kpt checks whether any of the resources that are new to the
+    inventory or will be pruned are claimed by a different inventory, either
+    through the owning-inventory annotation on the live resource or, for pruned
+    resources, because another inventory in the cluster lists it. The other
+    inventories are only listed if resources would be pruned, and are skipped if
+    the user isn't allowed to list them. Such conflicts usually happen when packages are split
+    or merged, and by default they are reported as an error so that resources
+    still in use by another package are not deleted. If this flag is set, the
+    applied resources are adopted into the current package and the conflicting
+    resources that would have been pruned are removed from the current inventory
+    without being deleted. Default value is false.
   
   --force-conflicts:
     Force overwrite of field conflicts during apply due to different field
     managers. Only usable when --server-side flag is specified.
-    Default value is false (error and failure when field managers conflict).
+    Default value is false (error and failure when field managers conflict),
+    or set by the ` + "`" + `kpt.dev/apply-conflict-policy` + "`" + ` annotation of the inventory
+    (see Server-side apply policies below).
   
   --install-resource-group:
     Install the ResourceGroup CRD into the cluster if it isn't already
@@ -54,6 +106,11 @@ Flags:
   
     The default value is ` + "`" + `strict` + "`" + `.
   
+  --max-parallelism:
+    The maximum number of clusters of --contexts that the package is applied to
+    at the same time with --parallel. 0 applies to all of them at once.
+    Default value is 0.
+  
   --output:
     Determines the output format for the status information. Must be one of the following:
   
@@ -65,6 +122,34 @@ Flags:
   
     The default value is ‘events’.
   
+  --parallel:
+    If true, apply the package to the clusters of --contexts in parallel
+    instead of one after the other. Can't be used with --output=table.
+    Default value is false.
+  
+  --plan:
+    Path of a plan created with ` + "`" + `kpt live plan --plan-file` + "`" + `. The resources and
+    the inventory of the plan are applied instead of a package, so PKG_PATH
+    can't be given. The --server-side, --force-conflicts, --field-manager and
+    --inventory-policy options are also taken from the plan. The apply is
+    refused if any of the planned resources has changed in the cluster since
+    the plan was created.
+  
+  --preflight:
+    Validate the resources against the cluster before anything is changed.
+    Resources of unknown kinds, and unknown or invalid fields according to
+    the OpenAPI schema of the cluster, including the schemas of CRDs, are
+    reported. Resources whose CRD is part of the package are not validated.
+    The available options are:
+  
+      * strict: Report the problems and don't apply the package.
+      * warn: Report the problems as warnings and apply the package.
+      * off: Skip the validation.
+  
+    The default value is 'warn', so packages that apply fine today, e.g. with
+    fields the OpenAPI schema of the cluster doesn't know about, are not
+    refused. Use 'strict' to refuse the apply instead.
+  
   --prune-propagation-policy:
     The propagation policy that should be used when pruning resources. The
     default value here is 'Background'. The other options are 'Foreground' and 'Orphan'.
@@ -78,7 +163,30 @@ Flags:
   --reconcile-timeout:
     The threshold for how long to wait for all resources to reconcile before
     giving up. If this flag is not set, kpt live apply will wait until
-    interrupted.
+    interrupted, unless resources set a per-resource reconcile timeout (see
+    below). In that case the wait is bounded by the largest per-resource
+    timeout.
+  
+  --report:
+    Write an ` + "`" + `ApplyReport` + "`" + ` of the apply, as evidence for audits. The report
+    is a KRM resource recording the inventory, the local user, the cluster,
+    the git commit of the package, the digest of the applied resources, the
+    outcome, and what happened to every applied and pruned resource. If the
+    value is an http or https URL, the report is posted to it as YAML,
+    otherwise it is written to the file at the given path. The report is also
+    written if the apply fails. Can't be used with --contexts.
+  
+  --report-signing-key:
+    Path of an ed25519 private key in PKCS #8 PEM format, e.g. generated with
+    ` + "`" + `openssl genpkey -algorithm ed25519` + "`" + `, to sign the report with. The
+    signature of the JSON encoding of the spec of the report is recorded in the
+    ` + "`" + `kpt.dev/apply-report-signature` + "`" + ` annotation, and sent in the
+    ` + "`" + `X-Kpt-Signature` + "`" + ` header to a webhook. Requires --report.
+  
+  --report-timeout:
+    The timeout for posting the report to an http or https URL given with
+    --report, so an unresponsive webhook doesn't block the command. 0 means no
+    timeout. Default is ` + "`" + `30s` + "`" + `.
   
   --server-side:
     Perform the apply operation server-side rather than client-side.
@@ -89,6 +197,105 @@ Flags:
     for all resources. Default is ` + "`" + `false` + "`" + `.
   
     Does not apply for the ` + "`" + `table` + "`" + ` output format.
+  
+  --skip-unchanged:
+    Skip the apply if the package is the one that was last applied successfully
+    with the inventory. After every successful apply, the hash of the applied
+    resources and server-side options is recorded in the
+    ` + "`" + `kpt.dev/applied-package-hash` + "`" + ` annotation of the inventory, and any other
+    update of the inventory removes it. The check costs a single read of the
+    inventory. The resources are then reported as skipped because they are
+    unchanged, without any write to the API server. Changes made to the
+    resources in the cluster by others since the last apply are not detected,
+    so don't use this flag to correct drift. If the package has changed, all its
+    resources are applied. Can't be used with ` + "`" + `--dry-run` + "`" + ` or ` + "`" + `--plan` + "`" + `. Default
+    is ` + "`" + `false` + "`" + `.
+
+Per-resource reconcile policies:
+
+  Individual resources can set their own reconcile timeout, failure policy and
+  readiness condition with annotations:
+  
+    kpt.dev/reconcile-timeout:
+      How long to wait for the resource to reconcile after it has been applied,
+      as a duration such as ` + "`" + `5m` + "`" + `.
+  
+    kpt.dev/reconcile-failure-policy:
+      What to do when the resource fails to reconcile or exceeds its reconcile
+      timeout. Must be one of the following:
+  
+        * continue: Report the failure and continue the apply. The apply still
+          exits with an error once it has completed.
+        * abort: Stop the apply. Resources in later apply phases are not applied
+          and nothing is pruned.
+        * rollback: Restore the resource to the state it had before the apply,
+          or delete it if it did not exist, and continue the apply.
+  
+      The default value is ` + "`" + `continue` + "`" + `.
+  
+    kpt.dev/ready-when:
+      A custom readiness condition for resources, such as custom resources,
+      whose status doesn't follow the kstatus conventions. The resource is
+      Current once the condition is met. The condition is one or more
+      comparisons of the form ` + "`" + `PATH == VALUE` + "`" + ` or ` + "`" + `PATH != VALUE` + "`" + `, or just
+      ` + "`" + `PATH` + "`" + ` to check that a field is set and not false, joined by ` + "`" + `&&` + "`" + `. PATH
+      is a JSONPath expression without the braces, e.g. ` + "`" + `status.phase` + "`" + ` or
+      ` + "`" + `status.conditions[?(@.type=="Ready")].status` + "`" + `. VALUE is a quoted string,
+      a number or a boolean. If the resource reports ` + "`" + `status.observedGeneration` + "`" + `,
+      the condition is only checked once it has observed the latest generation.
+  
+  Package-wide defaults of the reconcile timeout and failure policy can be set
+  by adding the same annotations to the ` + "`" + `inventory.annotations` + "`" + ` section of the
+  Kptfile. Annotations on a resource override the package defaults. If any
+  resource fails to reconcile, whatever its failure policy, kpt live apply reports
+  the failed resources and exits with a non-zero status. Reconcile policies are
+  not enforced for dry-runs.
+
+Server-side apply policies:
+
+  The field manager and conflict policy of the server-side apply of a package
+  can be set with annotations in the ` + "`" + `inventory.annotations` + "`" + ` section of the
+  Kptfile. The --field-manager and --force-conflicts flags override them.
+  
+    kpt.dev/field-manager:
+      Identifier for the owner of the fields being applied.
+  
+    kpt.dev/apply-conflict-policy:
+      What to do when a field is owned by another field manager. Must be one
+      of the following:
+  
+        * fail: Fail the apply of the resource.
+        * force: Take the ownership of the field and overwrite it.
+  
+  Individual resources can leave fields to other field managers, such as the
+  replicas of a Deployment scaled by a HorizontalPodAutoscaler, with the
+  annotation:
+  
+    kpt.dev/apply-ignored-fields:
+      Comma-separated list of dot-separated paths of fields that are removed
+      from the resource before it is applied, e.g. ` + "`" + `spec.replicas` + "`" + `. Fields of
+      apiVersion, kind and metadata can't be ignored. Requires --server-side.
+
+Environment Variables:
+
+  OTEL:
+    Export OpenTelemetry traces of the apply. Each apply, prune and wait phase
+    is recorded as a span, with a child span for every resource carrying its
+    group, kind, namespace, name and status. The value is a comma-separated list
+    of exporters, the same format used by the Porch server:
+  
+      * otel://HOST:PORT: Export to an OTLP collector over gRPC.
+      * stdout://: Print the spans to stderr.
+  
+  KPT_PROMETHEUS_PUSHGATEWAY:
+    URL of a Prometheus Pushgateway to push the metrics of the apply to, under
+    the job ` + "`" + `kpt_live_apply` + "`" + `. The following metrics are recorded:
+  
+      * kpt_live_resource_duration_seconds: Time taken to apply, prune, delete
+        or wait for a resource, by action, group, kind and status.
+      * kpt_live_resource_failures_total: Number of resources that failed, by
+        action, group and kind.
+      * kpt_live_phase_duration_seconds: Time taken by each phase, by action.
 `
 var ApplyExamples = `
   # apply resources in the current directory
@@ -98,8 +305,61 @@ var ApplyExamples = `
   # for all the resources to be reconciled before pruning
   $ kpt live apply --reconcile-timeout=15m my-dir
 
+  # abort the apply if any resource in the package doesn't reconcile within
+  # 5 minutes of being applied, given a Kptfile with:
+  #   inventory:
+  #     annotations:
+  #       kpt.dev/reconcile-timeout: 5m
+  #       kpt.dev/reconcile-failure-policy: abort
+  $ kpt live apply my-dir
+
+  # wait for a PersistentVolumeClaim to be bound, given the annotation on the
+  # PersistentVolumeClaim:
+  #   kpt.dev/ready-when: status.phase == "Bound"
+  $ kpt live apply --reconcile-timeout=5m my-dir
+
+  # apply resources with server-side apply and leave the replicas of a
+  # Deployment to a HorizontalPodAutoscaler, given a Kptfile with:
+  #   inventory:
+  #     annotations:
+  #       kpt.dev/field-manager: platform-team
+  #       kpt.dev/apply-conflict-policy: force
+  # and a Deployment with the annotation:
+  #   kpt.dev/apply-ignored-fields: spec.replicas
+  $ kpt live apply --server-side my-dir
+
+  # apply resources and export the traces to an OpenTelemetry collector
+  $ OTEL=otel://localhost:4317 kpt live apply my-dir
+
   # apply resources and specify how often to poll the cluster for resource status
   $ kpt live apply --reconcile-timeout=15m --poll-period=5s my-dir
+
+  # apply resources in the current directory, and adopt the Deployments of the
+  # package that were applied to the prod namespace with kubectl
+  $ kpt live apply --adopt --adopt-match=kind=Deployment,namespace=prod
+
+  # report all the resources of the package that the admission policies of the
+  # cluster would deny, without applying anything if there are any
+  $ kpt live apply --admission-dry-run my-dir
+
+  # apply a plan that was created with kpt live plan --plan-file=plan.yaml
+  $ kpt live apply --plan=plan.yaml
+
+  # apply resources in the current directory, unless none of them has changed
+  # in the cluster
+  $ kpt live apply --skip-unchanged
+
+  # apply resources in the current directory to the clusters of the staging
+  # and prod contexts in parallel
+  $ kpt live apply --contexts=staging,prod --parallel
+
+  # apply a large package with client-side throttling raised to 100 requests
+  # per second and a burst of 300 requests
+  $ kpt live apply --qps=100 --burst=300
+
+  # apply resources in the current directory and post a signed report of the
+  # apply to a webhook
+  $ kpt live apply --report=https://audit.example.com/kpt --report-signing-key=key.pem
 `
 
 var DestroyShort = `Remove all previously applied resources in a package from the cluster`
@@ -148,6 +408,17 @@ Flags:
     for all resources. Default is ` + "`" + `false` + "`" + `.
   
     Does not apply for the ` + "`" + `table` + "`" + ` output format.
+
+Environment Variables:
+
+  OTEL:
+    Export OpenTelemetry traces of the destroy. Each delete and wait phase is
+    recorded as a span, with a child span for every resource. The format is the
+    same as for ` + "`" + `kpt live apply` + "`" + `.
+  
+  KPT_PROMETHEUS_PUSHGATEWAY:
+    URL of a Prometheus Pushgateway to push the metrics of the destroy to, under
+    the job ` + "`" + `kpt_live_destroy` + "`" + `. The metrics are the same as for ` + "`" + `kpt live apply` + "`" + `.
 `
 var DestroyExamples = `
   # remove all resources in the current package from the cluster.
@@ -179,15 +450,31 @@ Flags:
     The name for the ResourceGroup resource that contains the inventory
     for the package. Defaults to the name of the package.
   
+  --name-template:
+    A Go template for the name of the ResourceGroup resource, e.g.
+    '{{.Repo}}-{{.Path}}'. It can't be used with ` + "`" + `--name` + "`" + `.
+  
   --namespace:
     The namespace for the ResourceGroup resource that contains the inventory
     for the package. If not provided, kpt will check if all the resources
     in the package belong in the same namespace. If they do, that namespace will
     be used. If they do not, the namespace in the user's context will be chosen.
   
+  --namespace-template:
+    A Go template for the namespace of the ResourceGroup resource, e.g.
+    'team-{{.Package}}'. It takes precedence over ` + "`" + `--namespace` + "`" + `.
+  
   --rg-file:
     The name used for the file created for the ResourceGroup CR. Defaults to
     'resourcegroup.yaml'.
+  
+  --skip-collision-check:
+    Do not check the cluster for inventories that the package would share with
+    another package. The check is only run if the name or the inventory id of
+    the inventory is given with ` + "`" + `--name` + "`" + `, ` + "`" + `--name-template` + "`" + ` or ` + "`" + `--inventory-id` + "`" + `,
+    since a generated inventory can't be shared. If the inventories can't be
+    listed within 10 seconds, a warning is printed and the package is
+    initialized anyway. Defaults to false.
 `
 var InitExamples = `
   # initialize a package in the current directory.
@@ -195,6 +482,10 @@ var InitExamples = `
 
   # initialize a package with explicit namespace for the ResourceGroup.
   $ kpt live init --namespace=test my-dir
+
+  # initialize the packages of a repository with inventories named after their
+  # path in the repository, e.g. platform-apps-web for apps/web.
+  $ kpt live init --name-template '{{.Repo}}-{{.Path}}' apps/web
 `
 
 var InstallResourceGroupShort = `Install the ResourceGroup CRD in the cluster.`
@@ -206,6 +497,45 @@ var InstallResourceGroupExamples = `
   $ kpt live install-resource-group
 `
 
+var ListInventoriesShort = `List the inventories in the cluster and find abandoned ones`
+var ListInventoriesLong = `
+  kpt live list-inventories [flags]
+
+Flags:
+
+  --abandoned:
+    If true, only list the abandoned inventories. Default value is false.
+  
+  --prune:
+    If true, delete the abandoned inventories, leaving the resources in them in
+    the cluster. Default value is false.
+  
+  --ttl:
+    The time an inventory may go without being applied before it is considered
+    abandoned, as a number of days, e.g. ` + "`" + `30d` + "`" + `, or a duration, e.g. ` + "`" + `12h` + "`" + `. The
+    ` + "`" + `kpt.dev/inventory-ttl` + "`" + ` annotation of an inventory takes precedence. If no TTL
+    is set, inventories are never abandoned.
+`
+var ListInventoriesExamples = `
+  # list the inventories in all namespaces
+  $ kpt live list-inventories
+
+  # list the inventories that haven't been applied for 90 days
+  $ kpt live list-inventories --ttl 90d --abandoned
+
+  # delete the inventories that haven't been applied for 90 days
+  $ kpt live list-inventories --ttl 90d --prune
+
+  # set a TTL and owner for the inventory of a package in its Kptfile
+  inventory:
+    namespace: default
+    name: inventory-obj
+    inventoryID: 4ed8ea4b-4d76-4d9b-8b5f-1e9ea8c6f9e3
+    annotations:
+      kpt.dev/inventory-ttl: 30d
+      kpt.dev/owner: team-a
+`
+
 var MigrateShort = `Migrate a package and the inventory object to use the ResourceGroup CRD.`
 var MigrateLong = `
   kpt live migrate [PKG_PATH] [flags]
@@ -241,6 +571,162 @@ var MigrateExamples = `
   $ kpt live migrate
 `
 
+var PlanShort = `Preview the changes that applying a package will make to the cluster`
+var PlanLong = `
+  kpt live plan [PKG_PATH | -] [flags]
+
+Args:
+
+  PKG_PATH | -:
+    Path to the local package which should be applied to the cluster. It must
+    contain a Kptfile or a ResourceGroup manifest with inventory metadata.
+    Defaults to the current working directory.
+    Using '-' as the package path will cause kpt to read resources from stdin.
+
+Flags:
+
+  --field-manager:
+    Identifier for the **owner** of the fields being applied. Default value
+    is kubectl, or the value of the ` + "`" + `kpt.dev/field-manager` + "`" + ` annotation of the
+    inventory.
+  
+  --force-conflicts:
+    Force overwrite of field conflicts during apply due to different field
+    managers. Default value is false (error and failure when field managers
+    conflict), or set by the ` + "`" + `kpt.dev/apply-conflict-policy` + "`" + ` annotation of the
+    inventory. Fields listed in the ` + "`" + `kpt.dev/apply-ignored-fields` + "`" + ` annotation
+    of a resource are not planned. See ` + "`" + `kpt live apply` + "`" + ` for the annotations.
+  
+  --inventory-policy:
+    Determines how to handle overlaps between the package being currently applied
+    and existing resources in the cluster. The available options are:
+  
+      * strict: If any of the resources already exist in the cluster, but doesn't
+        belong to the current package, it is considered an error.
+      * adopt: If a resource already exist in the cluster, but belongs to a
+        different package, it is considered an error. Resources that doesn't belong
+        to other packages are adopted into the current package.
+  
+    The default value is ` + "`" + `strict` + "`" + `.
+  
+  --output:
+    Determines the output format for the plan. Must be one of the following:
+  
+      * text: The plan will be printed as text to stdout.
+      * krm: The plan will be printed as a Plan resource inside a ResourceList
+        to stdout. This can be used as input to kpt functions for automatic
+        validation.
+  
+    The default value is ‘text’.
+  
+  --plan-file:
+    Path of the file to write the plan to. The plan can be applied with
+    ` + "`" + `kpt live apply --plan` + "`" + `. The file is only written if all resources could
+    be planned.
+`
+var PlanExamples = `
+  # preview the changes of applying the package in the current directory
+  $ kpt live plan
+
+  # create a plan for the package in the my-dir directory and output it in KRM format
+  $ kpt live plan --output=krm my-dir
+
+  # write the plan to a file for review, and apply it later
+  $ kpt live plan --plan-file=plan.yaml my-dir
+  $ kpt live apply --plan=plan.yaml
+`
+
+var RollbackShort = `Re-apply a previously applied revision of a package to the cluster`
+var RollbackLong = `
+  kpt live rollback [PKG_PATH | -] [flags]
+
+Args:
+
+  PKG_PATH | -:
+    Path to the local package whose resources should be rolled back. It must
+    contain a Kptfile or a ResourceGroup manifest with inventory metadata.
+    Defaults to the current working directory.
+    Using '-' as the package path will cause kpt to read resources from stdin.
+
+Flags:
+
+  --dry-run:
+    If true, kpt will print the resources that will be applied and pruned, but
+    no changes will be made to the cluster. With --server-side, a server-side
+    dry-run is done.
+  
+  --field-manager:
+    Identifier for the **owner** of the fields being applied. Only usable
+    when --server-side flag is specified. Default value is kubectl, or the
+    value of the ` + "`" + `kpt.dev/field-manager` + "`" + ` annotation of the inventory, like for
+    ` + "`" + `kpt live apply` + "`" + `.
+  
+  --force-conflicts:
+    Force overwrite of field conflicts during the rollback due to different
+    field managers. Only usable when --server-side flag is specified. Default
+    value is false, or set by the ` + "`" + `kpt.dev/apply-conflict-policy` + "`" + ` annotation of
+    the inventory, like for ` + "`" + `kpt live apply` + "`" + `.
+  
+  --list:
+    List the recorded snapshots of the package instead of rolling back.
+  
+  --output:
+    Determines the output format for the status information. Must be one of the following:
+  
+      * events: The output will be a list of the status events as they become available.
+      * json: The output will be a list of the status events as they become available,
+        each formatted as a json object.
+      * table: The output will be presented as a table that will be updated inline
+        as the status of resources become available.
+  
+    The default value is ‘events’.
+  
+  --prune-propagation-policy:
+    The propagation policy that should be used when pruning resources. The
+    default value here is 'Background'. The other options are 'Foreground' and 'Orphan'.
+  
+  --prune-timeout:
+    The threshold for how long to wait for all pruned resources to be
+    deleted before giving up. If this flag is not set, kpt live rollback will not
+    wait. In most cases, it would also make sense to set the
+    --prune-propagation-policy to Foreground when this flag is set.
+  
+  --reconcile-timeout:
+    The threshold for how long to wait for all resources to reconcile before
+    giving up. If this flag is not set, kpt live rollback will not wait for
+    resources to reconcile.
+  
+  --server-side:
+    Apply the snapshot server-side rather than client-side. A package applied
+    with ` + "`" + `kpt live apply --server-side` + "`" + ` should be rolled back server-side too,
+    so the fields keep their field managers. Default value is false
+    (client-side).
+  
+  --show-status-events:
+    The output will include the details on the reconciliation status
+    for all resources. Default is ` + "`" + `false` + "`" + `.
+  
+    Does not apply for the ` + "`" + `table` + "`" + ` output format.
+  
+  --to:
+    The revision of the snapshot to roll back to. Defaults to the revision
+    before the latest one.
+`
+var RollbackExamples = `
+  # roll back the package in the current directory to the previous apply
+  $ kpt live rollback
+
+  # list the recorded snapshots of the package in the my-dir directory
+  $ kpt live rollback --list my-dir
+
+  # roll back the package in the my-dir directory to revision 3 and wait up to
+  # 5 minutes for the resources to reconcile
+  $ kpt live rollback --to=3 --reconcile-timeout=5m my-dir
+
+  # roll back a package that is applied server-side
+  $ kpt live rollback --server-side --field-manager=my-pipeline my-dir
+`
+
 var StatusShort = `Display shows the status for the resources in the cluster`
 var StatusLong = `
   kpt live status [PKG_PATH | -] [flags]
@@ -260,6 +746,8 @@ Flags:
     Determines the output format for the status information. Must be one of the following:
   
       * events: The output will be a list of the status events as they become available.
+      * events-table: The output will be a table with a row for each status event as
+        it becomes available, with the time since polling started.
       * json: The output will be a list of the status events as they become available,
         each formatted as a json object.
       * table: The output will be presented as a table that will be updated inline
@@ -267,6 +755,13 @@ Flags:
   
     The default value is ‘events’.
   
+  --history-file:
+    Append the status history of the resources to the given file and print
+    the time it took each resource, and all resources, to become Current.
+    Each run appends one line with a JSON object to the file, so it can be
+    used to track how long the resources of a package take to become ready
+    across releases of the package.
+  
   --poll-period:
     The frequency with which the cluster will be polled to determine the status
     of the applied resources. The default value is 2 seconds.
@@ -280,7 +775,9 @@ Flags:
         status, i.e. all the resources have been deleted from the live state.
       * forever: Keep polling for status until interrupted.
   
-    The default value is ‘known’.
+    The default value is ‘known’. Resources with the ` + "`" + `kpt.dev/ready-when` + "`" + `
+    annotation reach the Current status once their readiness condition is met,
+    see ` + "`" + `kpt live apply` + "`" + `.
   
   --timeout:
     Determines how long the command should run before exiting. This deadline will
@@ -319,6 +816,12 @@ var StatusExamples = `
   # directory. Output in table format:
   $ kpt live status my-app --poll-until=forever --output=table
 
+  # Wait until the resources of the package in the current directory are
+  # Current, print the status events as a table and record the time to ready
+  # in history.jsonl.
+  $ kpt live status --poll-until=current --output=events-table \
+      --history-file=history.jsonl
+
   # Monitor status for the all resources on the cluster
   # with certain inventory names and under certain namespaces.
   $ kpt live status --inv-type remote --inv-names inv1,inv2 --namespaces ns1,ns2
@@ -326,3 +829,64 @@ var StatusExamples = `
   # Monitor resources on the cluster that has Current or InProgress status
   $ kpt live status --inv-type remote --statuses Current,InProgress
 `
+
+var WaitShort = `Wait for applied resources to be reconciled or deleted`
+var WaitLong = `
+  kpt live wait [PKG_PATH | -] [flags]
+
+Args:
+
+  PKG_PATH | -:
+    Path to the local package whose inventory lists the resources to wait for.
+    It must contain a Kptfile with inventory information. Defaults to the
+    current working directory. Using '-' as the package path will cause kpt to
+    read the resources from stdin. Can't be used with ` + "`" + `--inventory-id` + "`" + ` or
+    ` + "`" + `--objects` + "`" + `.
+
+Flags:
+
+  --for:
+    The status to wait for. Must be either ` + "`" + `current` + "`" + `, to wait for the resources
+    to be reconciled, or ` + "`" + `deleted` + "`" + `. Default value is ` + "`" + `current` + "`" + `.
+  
+  --inventory-id:
+    Wait for the resources of the inventory with this id in the cluster. The
+    inventories in all namespaces are searched, unless a namespace is given
+    with the ` + "`" + `--namespace` + "`" + ` flag.
+  
+  --objects:
+    Wait for the resources listed in this file.
+  
+  --poll-period:
+    The frequency with which the cluster will be polled to determine the status
+    of the resources. Default value is 2s.
+  
+  --show-status-events:
+    Print the status events of the resources while waiting. Default value is
+    false.
+  
+  --timeout:
+    How long to wait for the resources to reach the status. The resources that
+    haven't reached it when the timeout expires are timed out. The default is to
+    wait until every resource has reached the status or failed.
+`
+var WaitExamples = `
+  # wait for the resources of the package in the current directory to be
+  # reconciled
+  $ kpt live wait
+
+  # wait up to 5 minutes for the resources of an inventory in the cluster
+  $ kpt live wait --inventory-id 4ed8ea4b-4d76-4d9b-8b5f-1e9ea8c6f9e3 --timeout 5m
+
+  # wait for the resources listed in a file to be deleted
+  $ kpt live wait --objects objects.yaml --for deleted
+
+  # objects.yaml
+  - group: apps
+    kind: Deployment
+    name: wordpress
+    namespace: default
+  - kind: Service
+    name: wordpress
+    namespace: default
+`
@@ -14,5 +14,6 @@ kpt functionality is divided into following command groups:
 | [pkg]   | get, update, and describe packages with resources.                    |
 | [fn]    | generate, transform, validate packages using containerized functions. |
 | [live]  | deploy local configuration packages to a cluster.                     |
+| [ws]    | operate on the packages of a workspace together.                      |
 | [alpha] | commands currently in alpha and might change without notice.          |
 `
@@ -7,22 +7,122 @@ The ` + "`" + `pkg` + "`" + ` command group contains subcommands for fetching, u
 from git repositories.
 `
 
-var CatShort = `Print the resources in a file/directory`
+var CatShort = `Print the resources of a remote package without fetching it.`
 var CatLong = `
-  kpt pkg cat [FILE | DIR]
+  kpt pkg cat REPO_URI[.git]/PKG_PATH[@VERSION] [flags]
 
 Args:
 
-  FILE | DIR:
-    Path to a directory either a directory containing files with KRM resources, or
-    a file with KRM resource(s). Defaults to the current directory.
+  REPO_URI:
+    URI of a git repository containing 1 or more packages as subdirectories.
+    In most cases the .git suffix should be specified to delimit the REPO_URI
+    from the PKG_PATH, but this is not required for widely recognized repo
+    prefixes.
+  
+  PKG_PATH:
+    Path to remote subdirectory containing Kubernetes resource configuration
+    files or directories. Defaults to the root directory.
+    Uses '/' as the path separator (regardless of OS).
+    e.g. staging/cockroachdb
+  
+  VERSION:
+    A git tag, branch, ref or commit for the remote version of the package
+    to read. Defaults to the default branch of the repository.
+
+Flags:
+
+  --output, o:
+    The format of the resources written to stdout. It defaults to 'stdout'.
+  
+      * stdout: A ResourceList, which keeps the path of every resource in the
+        package and can be piped into kpt fn eval or kpt live apply.
+      * unwrap: A stream of YAML documents without the path annotations,
+        which can be piped into kubectl.
+  
+  --render:
+    Render the package with the function pipelines in its Kptfiles before
+    writing the resources, as kpt fn render would. The package is rendered in
+    memory. It is ` + "`" + `false` + "`" + ` by default.
+  
+  --image-pull-policy:
+    If the image should be pulled before rendering the package. Available
+    options are always, ifNotPresent and never. The default is ifNotPresent.
+
+Env Vars:
+
+  KPT_CACHE_DIR:
+    Controls where to cache remote repos when reading packages from them.
+    Defaults to <HOME>/.kpt/repos/
 `
 var CatExamples = `
-  # Print resource from a file.
-  $ kpt pkg cat path/to/deployment.yaml
+  # print the resources of the cockroachdb package
+  $ kpt pkg cat https://github.com/kubernetes/examples.git/staging/cockroachdb@master
 
-  # Print resources from current directory.
-  $ kpt pkg cat
+  # render the package and apply it to the cluster with kubectl
+  $ kpt pkg cat https://github.com/GoogleContainerTools/kpt.git/package-examples/wordpress@v0.9 \
+    --render -o unwrap | kubectl apply -f -
+`
+
+var ChangelogShort = `Summarize the upstream changes of a package between two versions.`
+var ChangelogLong = `
+  kpt pkg changelog [PKG_PATH] [flags]
+
+Args:
+
+  PKG_PATH:
+    Local package path with a git upstream. Defaults to the current working
+    directory.
+
+Flags:
+
+  --from:
+    The upstream version to start from: a git tag, branch, ref or commit. As
+    for ` + "`" + `kpt pkg get` + "`" + `, a tag prefixed with the directory of the package is used
+    if it exists. Defaults to the commit in the upstreamLock of the Kptfile.
+  
+  --to:
+    The upstream version to end at. Defaults to the ref in the upstream of the
+    Kptfile.
+  
+  --output, -o:
+    The format of the changelog, markdown or json. Defaults to markdown.
+
+Env Vars:
+
+  KPT_CACHE_DIR:
+    Controls where to cache remote packages when fetching them.
+    Defaults to <HOME>/.kpt/repos/
+`
+var ChangelogExamples = `
+  # summarize the upstream changes that an update of the package in the
+  # current directory would pull in
+  $ kpt pkg changelog
+
+  # summarize the upstream changes between v1 and v2 as json
+  $ kpt pkg changelog my-package-dir/ --from v1 --to v2 -o json
+`
+
+var DescribeShort = `Summarize the upstream, pipeline, subpackages and resources of a package.`
+var DescribeLong = `
+  kpt pkg describe [PKG_PATH] [flags]
+
+Args:
+
+  PKG_PATH:
+    Local package to describe. Directory must exist and contain a Kptfile.
+    Defaults to the current working directory.
+
+Flags:
+
+  --output, -o:
+    The output format, either ` + "`" + `text` + "`" + ` or ` + "`" + `json` + "`" + `. Default value is ` + "`" + `text` + "`" + `.
+`
+var DescribeExamples = `
+  # describe the package in the current directory
+  $ kpt pkg describe
+
+  # describe the wordpress package as JSON
+  $ kpt pkg describe wordpress -o json
 `
 
 var DiffShort = `Show differences between a local package and upstream.`
@@ -68,6 +168,14 @@ Flags:
   
     # Show changes using the diff command with recursive options.
     kpt pkg diff @master --diff-tool meld --diff-tool-opts "-r"
+  
+  --ignore-generated:
+    Exclude the resources generated by functions, which have the
+    ` + "`" + `kpt.dev/generated-by` + "`" + ` annotation set by ` + "`" + `kpt fn render --annotate-generated` + "`" + `,
+    from the compared packages.
+  
+    # Show changes to the resources authored in the package.
+    kpt pkg diff @master --ignore-generated
 
 Environment Variables:
 
@@ -88,6 +196,14 @@ Environment Variables:
     Defaults to <HOME>/.kpt/repos/
     On macOS and Linux <HOME> is determined by the $HOME env variable, while on
     Windows it is given by the %USERPROFILE% env variable.
+  
+  KPT_GIT_CREDENTIAL_HELPER:
+    The git credential helper used to authenticate to remote repos over HTTPS,
+    instead of the helpers in the git config. It has the syntax of the git
+    ` + "`" + `credential.helper` + "`" + ` setting: the name of a ` + "`" + `git-credential-<name>` + "`" + `
+    executable, an absolute path, or a shell command starting with ` + "`" + `!` + "`" + `. Token
+    providers such as the GitHub CLI (` + "`" + `!gh auth git-credential` + "`" + `), the Google
+    Cloud SDK (` + "`" + `gcloud.sh` + "`" + `) or Git Credential Manager (` + "`" + `manager` + "`" + `) can be used.
 `
 var DiffExamples = `
 
@@ -98,6 +214,8 @@ var DiffExamples = `
 var GetShort = `Fetch a package from a git repo.`
 var GetLong = `
   kpt pkg get REPO_URI[.git]/PKG_PATH[@VERSION] [LOCAL_DEST_DIRECTORY] [flags]
+  
+  kpt pkg get -f MANIFEST [flags]
 
 Args:
 
@@ -140,6 +258,29 @@ Flags:
     (Experimental) indicates if the fetched package is a deployable instance that
     will be deployed to a cluster.
     It is ` + "`" + `false` + "`" + ` by default.
+  
+  --file, -f:
+    Path of a manifest listing the packages to fetch, instead of a single
+    package given as argument. Each package has the ` + "`" + `uri` + "`" + ` of the package, in
+    the format of the first argument, and optionally a ` + "`" + `destination` + "`" + `, a
+    ` + "`" + `strategy` + "`" + ` overriding --strategy and ` + "`" + `forDeployment` + "`" + `. Relative
+    destinations are relative to the current directory:
+  
+      apiVersion: kpt.dev/v1alpha1
+      kind: PackageManifest
+      packages:
+      - uri: https://github.com/kubernetes/examples.git/staging/cockroachdb@master
+        destination: cockroachdb
+      - uri: https://github.com/kubernetes/examples.git/staging/storm@master
+        strategy: fast-forward
+  
+    The packages of different repositories are fetched concurrently. All the
+    packages are fetched even if some of them fail, and a summary of the
+    fetched and failed packages is printed at the end.
+  
+  --concurrency:
+    The maximum number of repositories fetched at the same time with --file.
+    Defaults to 4.
 
 Env Vars:
 
@@ -148,6 +289,14 @@ Env Vars:
     Defaults to <HOME>/.kpt/repos/
     On macOS and Linux <HOME> is determined by the $HOME env variable, while on
     Windows it is given by the %USERPROFILE% env variable.
+  
+  KPT_GIT_CREDENTIAL_HELPER:
+    The git credential helper used to authenticate to remote repos over HTTPS,
+    instead of the helpers in the git config. It has the syntax of the git
+    ` + "`" + `credential.helper` + "`" + ` setting: the name of a ` + "`" + `git-credential-<name>` + "`" + `
+    executable, an absolute path, or a shell command starting with ` + "`" + `!` + "`" + `. Token
+    providers such as the GitHub CLI (` + "`" + `!gh auth git-credential` + "`" + `), the Google
+    Cloud SDK (` + "`" + `gcloud.sh` + "`" + `) or Git Credential Manager (` + "`" + `manager` + "`" + `) can be used.
 `
 var GetExamples = `
 
@@ -171,6 +320,27 @@ var GetExamples = `
   # Create a deployable instance of examples package from github.com/kubernetes/examples
   # This will create a new directory 'examples' for the package.
   $ kpt pkg get https://github.com/kubernetes/examples.git/@6fe2792 --for-deployment
+
+  # Fetch all the packages listed in packages.yaml.
+  $ kpt pkg get -f packages.yaml
+`
+
+var ImportKustomizeShort = `Convert a tree of kustomizations into a kpt package.`
+var ImportKustomizeLong = `
+  kpt pkg import-kustomize KUSTOMIZE_DIR PKG_PATH
+
+Args:
+
+  KUSTOMIZE_DIR:
+    The directory with the kustomizations to import.
+  
+  PKG_PATH:
+    The path of the new package. The directory must not exist or be empty.
+`
+var ImportKustomizeExamples = `
+  # import the base and overlays in the kustomize directory into the
+  # package web, with a subpackage for each overlay
+  $ kpt pkg import-kustomize kustomize/ web
 `
 
 var InitShort = `Initialize an empty package.`
@@ -203,6 +373,42 @@ var InitExamples = `
   $ kpt pkg init
 `
 
+var LintShort = `Check the Kptfiles and resources of a package for common problems.`
+var LintLong = `
+  kpt pkg lint [PKG_PATH] [flags]
+
+Args:
+
+  PKG_PATH:
+    Local package to check. Directory must exist and contain a Kptfile.
+    Defaults to the current working directory.
+
+Flags:
+
+  --skip:
+    Comma-separated list of checks to skip. Can be repeated. The checks are:
+  
+      * kptfile: The Kptfiles of the package and its subpackages can be read,
+        have a supported apiVersion, no unknown fields, a name, and a valid
+        pipeline and upstream.
+      * image-tag: The function images of the pipelines and upstream overrides
+        are pinned to a tag other than ` + "`" + `latest` + "`" + `, or to a digest.
+      * upstream-lock: The upstreamLock of every package with an upstream is
+        set, and has the same type, repo, directory and ref as the upstream.
+      * package-context: Every package has a package-context.yaml with the
+        ConfigMap ` + "`" + `kptfile.kpt.dev` + "`" + ` and its data.name set.
+      * duplicate-resource: No two resources of the package and its
+        subpackages have the same group, kind, namespace and name. Local config
+        resources are ignored.
+`
+var LintExamples = `
+  # Check the package in the current directory.
+  $ kpt pkg lint
+
+  # Check the package in my-package-dir/, except for the image tags.
+  $ kpt pkg lint my-package-dir/ --skip image-tag
+`
+
 var TreeShort = `Display resources, files and packages in a tree structure.`
 var TreeLong = `
   kpt pkg tree [DIR]
@@ -210,6 +416,9 @@ var TreeLong = `
 var TreeExamples = `
   # Show resources in the current directory.
   $ kpt pkg tree
+
+  # Show only the resources authored in the package in the current directory.
+  $ kpt pkg tree --hide-generated
 `
 
 var UpdateShort = `Apply upstream package updates.`
@@ -234,6 +443,28 @@ Args:
 
 Flags:
 
+  --changelog:
+    Write a summary of the upstream changes pulled in by the update to this
+    file, in markdown, e.g. for the message of the commit of the update. See
+    ` + "`" + `kpt pkg changelog` + "`" + `. It can't be used with ` + "`" + `--from-vendor` + "`" + `.
+  
+  --follow-renames:
+    If the upstream directory of the package was moved in the version the
+    package is updated to, update the package from the new directory and change
+    the upstream directory in the Kptfile.
+  
+  --from-transcript:
+    Replay the decisions of the update transcript in this file: update to the
+    same version, with the same strategies, skipping the same subpackages. A
+    version or ` + "`" + `--strategy` + "`" + ` can't be given with it. A warning is printed for
+    every package the update handled differently than the transcript.
+  
+  --from-vendor:
+    Update the package using the upstreams stored in the package by
+    ` + "`" + `kpt pkg vendor` + "`" + ` instead of fetching them from git. The update fails if any
+    of the required upstreams hasn't been vendored. It doesn't need network
+    access, so it also works with the global ` + "`" + `--offline` + "`" + ` flag.
+  
   --strategy:
     Defines which strategy should be used to update the package. This will change
     the update strategy for the current kpt package for the current and future
@@ -246,6 +477,16 @@ Flags:
         since it was fetched.
       * force-delete-replace: Wipe all the local changes to the package and replace
         it with the remote version.
+  
+  --skip:
+    Path of a subpackage, relative to the package, that will not be updated. The
+    subpackage and its nested packages are left as they are. Can be repeated.
+    Local subpackages, which don't have their own upstream, can only be skipped
+    with the resource-merge strategy.
+  
+  --transcript:
+    Write the decisions of the update to this file, so that they can be
+    replayed with ` + "`" + `--from-transcript` + "`" + `.
 
 Env Vars:
 
@@ -254,6 +495,14 @@ Env Vars:
     Defaults to <HOME>/.kpt/repos/
     On macOS and Linux <HOME> is determined by the $HOME env variable, while on
     Windows it is given by the %USERPROFILE% env variable.
+  
+  KPT_GIT_CREDENTIAL_HELPER:
+    The git credential helper used to authenticate to remote repos over HTTPS,
+    instead of the helpers in the git config. It has the syntax of the git
+    ` + "`" + `credential.helper` + "`" + ` setting: the name of a ` + "`" + `git-credential-<name>` + "`" + `
+    executable, an absolute path, or a shell command starting with ` + "`" + `!` + "`" + `. Token
+    providers such as the GitHub CLI (` + "`" + `!gh auth git-credential` + "`" + `), the Google
+    Cloud SDK (` + "`" + `gcloud.sh` + "`" + `) or Git Credential Manager (` + "`" + `manager` + "`" + `) can be used.
 `
 var UpdateExamples = `
   # Update package in the current directory.
@@ -267,4 +516,62 @@ var UpdateExamples = `
   # Update with the fast-forward strategy.
   # git add . && git commit -m "some message"
   $ kpt pkg update my-package-dir/@master --strategy fast-forward
+
+  # Update the package in the current directory and commit the update with a
+  # summary of the upstream changes.
+  # git add . && git commit -m "some message"
+  $ kpt pkg update --changelog /tmp/changelog.md
+  $ git add . && git commit -m "Update package" -m "$(cat /tmp/changelog.md)"
+
+  # Update the package in the current directory from its vendored upstreams.
+  # git add . && git commit -m "some message"
+  $ kpt pkg update --from-vendor
+
+  # Update a package without its mysql subpackage, recording the decisions, and
+  # make the same update on a similar package.
+  # git add . && git commit -m "some message"
+  $ kpt pkg update wordpress-dev/@v2 --skip mysql --transcript update.yaml
+  $ kpt pkg update wordpress-prod/ --from-transcript update.yaml
+
+  # Update a package whose upstream directory was moved, e.g. to apps/wordpress,
+  # and change the directory in its Kptfile.
+  # git add . && git commit -m "some message"
+  $ kpt pkg update wordpress/@v3 --follow-renames
+`
+
+var VendorShort = `Store upstream package sources inside a package for offline updates.`
+var VendorLong = `
+  kpt pkg vendor [PKG_PATH]
+
+Args:
+
+  PKG_PATH:
+    Local package whose upstreams should be vendored. Directory must exist and
+    contain a Kptfile. Defaults to the current working directory.
+`
+var VendorExamples = `
+  # Vendor the upstreams of the package in the current directory.
+  $ kpt pkg vendor
+
+  # Vendor the upstreams of my-package-dir/ and later update it offline.
+  $ kpt pkg vendor my-package-dir/
+  $ kpt pkg update my-package-dir/ --from-vendor
+`
+
+var VerifyShort = `Verify the content of fetched packages against their recorded digests.`
+var VerifyLong = `
+  kpt pkg verify [PKG_PATH]
+
+Args:
+
+  PKG_PATH:
+    Local package to verify. Directory must exist and contain a Kptfile.
+    Defaults to the current working directory.
+`
+var VerifyExamples = `
+  # Verify the package in the current directory.
+  $ kpt pkg verify
+
+  # Verify the package in my-package-dir/.
+  $ kpt pkg verify my-package-dir/
 `
@@ -0,0 +1,296 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Code is a stable, machine-readable code for a kind of error, e.g.
+// KPT1001. Codes are never reused for a different kind of error, so
+// wrappers of kpt can rely on them.
+type Code string
+
+// The error codes are grouped by area: 0xxx for general errors, 1xxx for git, 2xxx for packages, 3xxx for live and 4xxx for
+// functions.
+const (
+	CodeUnknown Code = "KPT0001"
+	CodeOffline Code = "KPT0002"
+
+	CodeGitCommandFailed      Code = "KPT1000"
+	CodeGitUnknownRef         Code = "KPT1001"
+	CodeGitNotFound           Code = "KPT1002"
+	CodeGitHTTPSAuthRequired  Code = "KPT1003"
+	CodeRepositoryUnavailable Code = "KPT1004"
+	CodeRepositoryNotFound    Code = "KPT1005"
+
+	CodeKptfileNotFound        Code = "KPT2001"
+	CodeKptfileV1alpha1        Code = "KPT2002"
+	CodeKptfileV1alpha2        Code = "KPT2003"
+	CodeKptfileUnknownResource Code = "KPT2004"
+	CodeKptfileUnreadable      Code = "KPT2005"
+	CodeKptfileInvalid         Code = "KPT2006"
+	CodePkgNotGitRepo          Code = "KPT2101"
+	CodePkgRepoDirty           Code = "KPT2102"
+
+	CodeNoInventory            Code = "KPT3001"
+	CodeMultipleInventories    Code = "KPT3002"
+	CodeResourceGroupInstall   Code = "KPT3003"
+	CodeNoResourceGroupCRD     Code = "KPT3004"
+	CodeInventoryExists        Code = "KPT3005"
+	CodeInventoryInRGExists    Code = "KPT3006"
+	CodeInventoryInKfExists    Code = "KPT3007"
+	CodeMultipleResourceGroups Code = "KPT3008"
+	CodeInvalidInventory       Code = "KPT3009"
+	CodeUnknownResourceTypes   Code = "KPT3010"
+	CodeReconcileFailed        Code = "KPT3011"
+
+	CodeFunctionImageNotFound Code = "KPT4001"
+	CodeFunctionFailed        Code = "KPT4002"
+)
+
+// CatalogEntry explains an error code.
+type CatalogEntry struct {
+	Code Code
+	// Title is a short description of the error.
+	Title string
+	// Remediation is the guidance on how to fix the error.
+	Remediation string
+}
+
+// catalog is the list of all error codes. Entries must never be removed
+// or have their code changed.
+var catalog = []CatalogEntry{
+	{
+		Code:  CodeUnknown,
+		Title: "Unclassified error",
+		Remediation: `The error has not been classified. The message of the error has the details.
+Run the command again with --stack-trace for more information.`,
+	},
+	{
+		Code:  CodeOffline,
+		Title: "Network access in offline mode",
+		Remediation: `kpt runs in offline mode, set by the --offline flag or the KPT_OFFLINE
+environment variable, but the command needs the network, e.g. to pull a
+function image or to fetch an upstream repository. Pull the images, e.g. with
+'docker pull', and fetch the packages before going offline, or run the command
+without offline mode.`,
+	},
+	{
+		Code:  CodeGitCommandFailed,
+		Title: "Git command failed",
+		Remediation: `A git command run by kpt failed. The details of the error show the output of
+git. Verify that the repository and the reference are correct, and that the
+git command succeeds when run by hand.`,
+	},
+	{
+		Code:  CodeGitUnknownRef,
+		Title: "Unknown git reference",
+		Remediation: `The branch, tag or commit doesn't exist in the upstream repository. Verify the
+reference, e.g. with 'git ls-remote <REPO>', and use an existing one.`,
+	},
+	{
+		Code:        CodeGitNotFound,
+		Title:       "Git executable not found",
+		Remediation: `kpt requires git. Install git and make sure it is available in the PATH.`,
+	},
+	{
+		Code:  CodeGitHTTPSAuthRequired,
+		Title: "Repository requires authentication",
+		Remediation: `The repository requires authentication, which kpt doesn't support for the
+'https' protocol without a credential helper. Use the 'git' protocol instead,
+or configure a credential helper for the repository in the kpt config file.`,
+	},
+	{
+		Code:  CodeRepositoryUnavailable,
+		Title: "Repository unavailable",
+		Remediation: `The repository can't be accessed. Verify the URL of the repository and the
+network connection, and that you have access to the repository.`,
+	},
+	{
+		Code:        CodeRepositoryNotFound,
+		Title:       "Repository not found",
+		Remediation: `The repository doesn't exist. Verify the URL of the repository.`,
+	},
+	{
+		Code:  CodeKptfileNotFound,
+		Title: "No Kptfile found",
+		Remediation: `The directory is not a kpt package. Verify the path of the package, or run
+'kpt pkg init' to make the directory a package.`,
+	},
+	{
+		Code:  CodeKptfileV1alpha1,
+		Title: "Kptfile has the v1alpha1 schema",
+		Remediation: `The Kptfile has an old version of the schema. Update the package to the latest
+format by following https://kpt.dev/installation/migration.`,
+	},
+	{
+		Code:  CodeKptfileV1alpha2,
+		Title: "Kptfile has the v1alpha2 schema",
+		Remediation: `The Kptfile has an old version of the schema. Upgrade the package with
+'kpt fn eval <PKG_PATH> -i gcr.io/kpt-fn/fix:v0.2 --include-meta-resources'.`,
+	},
+	{
+		Code:  CodeKptfileUnknownResource,
+		Title: "Kptfile has an unknown resource type",
+		Remediation: `The apiVersion or kind of the Kptfile is not known. The Kptfile must have
+apiVersion kpt.dev/v1 and kind Kptfile.`,
+	},
+	{
+		Code:  CodeKptfileUnreadable,
+		Title: "Kptfile can't be read",
+		Remediation: `The Kptfile is not valid YAML or has unknown fields. Fix the Kptfile using the
+details of the error, e.g. with 'kpt pkg lint'.`,
+	},
+	{
+		Code:        CodeKptfileInvalid,
+		Title:       "Kptfile is invalid",
+		Remediation: `A field of the Kptfile has an invalid value. Fix the field named in the error.`,
+	},
+	{
+		Code:  CodePkgNotGitRepo,
+		Title: "Package is not in a git repository",
+		Remediation: `Updating a package requires it to be in a git repository. Initialize a
+repository with 'git init' and commit the package with 'git commit'.`,
+	},
+	{
+		Code:  CodePkgRepoDirty,
+		Title: "Package has uncommitted changes",
+		Remediation: `Updating a package requires its changes to be committed, so the update can be
+reviewed and reverted. Commit the changes with 'git commit'.`,
+	},
+	{
+		Code:  CodeNoInventory,
+		Title: "Package uninitialized",
+		Remediation: `The package has no inventory information, which kpt needs to keep track of
+the applied resources. Run 'kpt live init' to add it.`,
+	},
+	{
+		Code:  CodeMultipleInventories,
+		Title: "Package has multiple inventory templates",
+		Remediation: `The package must have one and only one inventory object template. Remove the
+extra templates.`,
+	},
+	{
+		Code:  CodeResourceGroupInstall,
+		Title: "ResourceGroup CRD can't be installed",
+		Remediation: `The ResourceGroup CRD couldn't be installed in the cluster. Verify that you
+have the permission to create CRDs, or ask a cluster admin to run
+'kpt live install-resource-group'.`,
+	},
+	{
+		Code:  CodeNoResourceGroupCRD,
+		Title: "ResourceGroup CRD not found",
+		Remediation: `The ResourceGroup CRD must be installed in the cluster. Install it with the
+--install-resource-group flag or the 'kpt live install-resource-group'
+command.`,
+	},
+	{
+		Code:  CodeInventoryExists,
+		Title: "Inventory information already exists",
+		Remediation: `Changing the inventory information after the package has been applied can
+orphan the applied resources. Use --force if you want to change it anyway.`,
+	},
+	{
+		Code:  CodeInventoryInRGExists,
+		Title: "Inventory information already exists in the ResourceGroup",
+		Remediation: `Changing the inventory information after the package has been applied can
+orphan the applied resources. Use --force if you want to change it anyway.`,
+	},
+	{
+		Code:  CodeInventoryInKfExists,
+		Title: "Inventory information exists in the Kptfile",
+		Remediation: `The inventory information is stored in the Kptfile. Migrate it to a
+standalone ResourceGroup object with 'kpt live migrate'.`,
+	},
+	{
+		Code:  CodeMultipleResourceGroups,
+		Title: "Multiple ResourceGroup objects",
+		Remediation: `The package must have at most one ResourceGroup object. Remove the extra
+ResourceGroup objects.`,
+	},
+	{
+		Code:  CodeInvalidInventory,
+		Title: "Inventory information is invalid",
+		Remediation: `Fix the inventory information in the ResourceGroup file, or provide it with
+the command line flags. Use 'kpt live init' to generate it the first time.`,
+	},
+	{
+		Code:  CodeUnknownResourceTypes,
+		Title: "Unknown resource types",
+		Remediation: `Some resource types are neither known to the cluster nor defined by a CRD in
+the package. Install the CRDs of the types, or add them to the package.`,
+	},
+	{
+		Code:  CodeReconcileFailed,
+		Title: "Resources failed to reconcile",
+		Remediation: `Some resources failed to apply, to be pruned or deleted, or to reconcile
+before the timeout. Check the status of the resources with 'kpt live status',
+and increase the --reconcile-timeout if they need more time.`,
+	},
+	{
+		Code:  CodeFunctionImageNotFound,
+		Title: "Function image not found",
+		Remediation: `The image of the function doesn't exist remotely. Verify the image name and
+tag. When developing a function locally, set --image-pull-policy to
+ifNotPresent or never.`,
+	},
+	{
+		Code:  CodeFunctionFailed,
+		Title: "Function failed",
+		Remediation: `A function of the pipeline failed. The output of the function above the error
+explains why. Fix the package or the function config, and use --results-dir
+to save the results of the functions.`,
+	},
+}
+
+// Catalog returns the entries of all error codes.
+func Catalog() []CatalogEntry {
+	return append([]CatalogEntry{}, catalog...)
+}
+
+// Explain returns the catalog entry of code. The code is case-insensitive.
+func Explain(code string) (CatalogEntry, bool) {
+	for _, e := range catalog {
+		if strings.EqualFold(string(e.Code), code) {
+			return e, true
+		}
+	}
+	return CatalogEntry{}, false
+}
+
+// jsonError is the JSON output of an error.
+type jsonError struct {
+	Code     Code   `json:"code"`
+	Title    string `json:"title,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ExitCode int    `json:"exitCode"`
+}
+
+// WriteJSON writes rr as a JSON object with the code, the title of the
+// code, the message and the exit code.
+func WriteJSON(w io.Writer, rr ResolvedResult) error {
+	je := jsonError{
+		Code:     rr.Code,
+		Message:  strings.TrimSpace(rr.Message),
+		ExitCode: rr.ExitCode,
+	}
+	if e, found := Explain(string(rr.Code)); found {
+		je.Title = e.Title
+	}
+	return json.NewEncoder(w).Encode(je)
+}
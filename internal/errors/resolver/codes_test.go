@@ -0,0 +1,96 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
+	"github.com/GoogleContainerTools/kpt/internal/util/update"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/print/common"
+)
+
+func TestCatalog(t *testing.T) {
+	codePattern := regexp.MustCompile(`^KPT[0-9]{4}$`)
+	seen := map[Code]bool{}
+	for _, e := range Catalog() {
+		assert.Regexp(t, codePattern, string(e.Code))
+		assert.False(t, seen[e.Code], "duplicate code %s", e.Code)
+		seen[e.Code] = true
+		assert.NotEmpty(t, e.Title, e.Code)
+		assert.NotEmpty(t, e.Remediation, e.Code)
+	}
+}
+
+func TestResolveError_Code(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected Code
+	}{
+		"git": {
+			err:      &gitutil.GitExecError{Type: gitutil.RepositoryNotFound},
+			expected: CodeRepositoryNotFound,
+		},
+		"update": {
+			err:      &update.PkgRepoDirtyError{Path: "foo"},
+			expected: CodePkgRepoDirty,
+		},
+		"live": {
+			err:      &common.ResultError{},
+			expected: CodeReconcileFailed,
+		},
+		"offline": {
+			err: errors.E(errors.Op("gitutil.NewGitUpstreamRepo"), errors.Repo("https://github.com/kptdev/kpt"),
+				&offline.Error{Operation: "fetching repository"}),
+			expected: CodeOffline,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			rr, ok := ResolveError(tc.err)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, rr.Code)
+			_, found := Explain(string(rr.Code))
+			assert.True(t, found, "code %s is not in the catalog", rr.Code)
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	e, found := Explain("kpt2001")
+	assert.True(t, found)
+	assert.Equal(t, CodeKptfileNotFound, e.Code)
+
+	_, found = Explain("KPT9999")
+	assert.False(t, found)
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteJSON(&buf, ResolvedResult{
+		Message:  "\nError: No Kptfile found at \"foo\".\n",
+		ExitCode: 1,
+		Code:     CodeKptfileNotFound,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"code":"KPT2001","title":"No Kptfile found","message":"Error: No Kptfile found at \"foo\".","exitCode":1}
+`, buf.String())
+}
@@ -36,5 +36,6 @@ func (*containerImageErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 	}
 	return ResolvedResult{
 		Message: containerImageError.Error(),
+		Code:    CodeFunctionImageNotFound,
 	}, true
 }
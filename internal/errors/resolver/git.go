@@ -40,29 +40,36 @@ func (*gitExecErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 		strings.Join(gitExecErr.Args, " "))
 
 	var msg string
+	var code Code
 	switch gitExecErr.Type {
 	case gitutil.UnknownReference:
+		code = CodeGitUnknownRef
 		msg = fmt.Sprintf("Error: Unknown ref %q. Please verify that the reference exists in upstream repo %q.", gitExecErr.Ref, gitExecErr.Repo)
 		msg = msg + "\n" + BuildOutputDetails(gitExecErr.StdOut, gitExecErr.StdErr)
 
 	case gitutil.GitExecutableNotFound:
+		code = CodeGitNotFound
 		msg = "Error: No git executable found. kpt requires git to be installed and available in the path."
 		msg = msg + "\n" + BuildOutputDetails(gitExecErr.StdOut, gitExecErr.StdErr)
 
 	case gitutil.HTTPSAuthRequired:
+		code = CodeGitHTTPSAuthRequired
 		msg = fmt.Sprintf("Error: Repository %q requires authentication.", gitExecErr.Repo)
 		msg += " kpt does not support this for the 'https' protocol."
 		msg += " Please use the 'git' protocol instead."
 		msg = msg + "\n" + BuildOutputDetails(gitExecErr.StdOut, gitExecErr.StdErr)
 
 	case gitutil.RepositoryUnavailable:
+		code = CodeRepositoryUnavailable
 		msg = fmt.Sprintf("Error: Unable to access repository %q.", gitExecErr.Repo)
 		msg = msg + "\n" + BuildOutputDetails(gitExecErr.StdOut, gitExecErr.StdErr)
 
 	case gitutil.RepositoryNotFound:
+		code = CodeRepositoryNotFound
 		msg = fmt.Sprintf("Error: Repository %q not found.", gitExecErr.Repo)
 		msg = msg + "\n" + BuildOutputDetails(gitExecErr.StdOut, gitExecErr.StdErr)
 	default:
+		code = CodeGitCommandFailed
 		msg = fmt.Sprintf("Error: Failed to execute git command %q", fullCommand)
 		if gitExecErr.Repo != "" {
 			msg += fmt.Sprintf(" against repo %q", gitExecErr.Repo)
@@ -74,6 +81,7 @@ func (*gitExecErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 	}
 	return ResolvedResult{
 		Message: msg,
+		Code:    code,
 	}, true
 }
 
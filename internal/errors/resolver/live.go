@@ -80,13 +80,13 @@ func (*liveErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 	var noInventoryObjError *inventory.NoInventoryObjError
 	if errors.As(err, &noInventoryObjError) {
 		msg := noInventoryObjErrorMsg
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeNoInventory}, true
 	}
 
 	var multipleInventoryObjError *inventory.MultipleInventoryObjError
 	if errors.As(err, &multipleInventoryObjError) {
 		msg := multipleInventoryObjErrorMsg
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeMultipleInventories}, true
 	}
 
 	var resourceGroupCRDInstallError *cmdutil.ResourceGroupCRDInstallError
@@ -96,37 +96,37 @@ func (*liveErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 		cause := resourceGroupCRDInstallError.Err
 		msg += fmt.Sprintf("\nDetails: %v", cause)
 
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeResourceGroupInstall}, true
 	}
 
 	var noResourceGroupCRDError *cmdutil.NoResourceGroupCRDError
 	if errors.As(err, &noResourceGroupCRDError) {
 		msg := noResourceGroupCRDMsg
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeNoResourceGroupCRD}, true
 	}
 
 	var invExistsError *initialization.InvExistsError
 	if errors.As(err, &invExistsError) {
 		msg := invInfoAlreadyExistsMsg
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeInventoryExists}, true
 	}
 
 	var invInfoInRGAlreadyExistsError *initialization.InvInRGExistsError
 	if errors.As(err, &invInfoInRGAlreadyExistsError) {
 		msg := invInfoInRGAlreadyExistsMsg
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeInventoryInRGExists}, true
 	}
 
 	var invInKfExistsError *initialization.InvInKfExistsError
 	if errors.As(err, &invInKfExistsError) {
 		msg := invInfoInKfAlreadyExistsMsg
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeInventoryInKfExists}, true
 	}
 
 	var multipleResourceGroupsError *pkg.MultipleResourceGroupsError
 	if errors.As(err, &multipleResourceGroupsError) {
 		msg := multipleResourceGroupsMsg
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeMultipleResourceGroups}, true
 	}
 
 	var inventoryInfoValidationError *live.InventoryInfoValidationError
@@ -140,7 +140,7 @@ func (*liveErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 			msg += fmt.Sprintf("%s\n", v.Reason)
 		}
 
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeInvalidInventory}, true
 	}
 
 	var unknownTypesError *manifestreader.UnknownTypesError
@@ -151,7 +151,7 @@ func (*liveErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 			msg += fmt.Sprintf("%s\n", gvk)
 		}
 
-		return ResolvedResult{Message: msg}, true
+		return ResolvedResult{Message: msg, Code: CodeUnknownResourceTypes}, true
 	}
 
 	var resultError *common.ResultError
@@ -159,6 +159,7 @@ func (*liveErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 		return ResolvedResult{
 			Message:  "", // Printer summary now replaces ResultError message
 			ExitCode: 3,
+			Code:     CodeReconcileFailed,
 		}, true
 	}
 
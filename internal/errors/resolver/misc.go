@@ -18,11 +18,13 @@ import (
 	goerrors "errors"
 
 	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
 )
 
 //nolint:gochecknoinits
 func init() {
 	AddErrorResolver(&alreadyHandledErrorResolver{})
+	AddErrorResolver(&offlineErrorResolver{})
 }
 
 type alreadyHandledErrorResolver struct{}
@@ -30,7 +32,22 @@ type alreadyHandledErrorResolver struct{}
 func (*alreadyHandledErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 	kioErr := errors.UnwrapKioError(err)
 	if goerrors.Is(kioErr, errors.ErrAlreadyHandled) {
-		return ResolvedResult{}, true
+		return ResolvedResult{Code: CodeFunctionFailed}, true
 	}
 	return ResolvedResult{}, false
 }
+
+// offlineErrorResolver resolves the errors of operations that require
+// network access in offline mode.
+type offlineErrorResolver struct{}
+
+func (*offlineErrorResolver) Resolve(err error) (ResolvedResult, bool) {
+	var offlineErr *offline.Error
+	if !goerrors.As(err, &offlineErr) {
+		return ResolvedResult{}, false
+	}
+	return ResolvedResult{
+		Message: offlineErr.Error(),
+		Code:    CodeOffline,
+	}, true
+}
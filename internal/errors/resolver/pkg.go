@@ -51,6 +51,7 @@ func (*pkgErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 	if errors.As(err, &validateError) {
 		return ResolvedResult{
 			Message: validateError.Error(),
+			Code:    CodeKptfileInvalid,
 		}, true
 	}
 
@@ -63,6 +64,7 @@ func resolveNestedErr(err error, path string) (ResolvedResult, bool) {
 
 		return ResolvedResult{
 			Message: msg,
+			Code:    CodeKptfileNotFound,
 		}, true
 	}
 
@@ -74,6 +76,7 @@ func resolveNestedErr(err error, path string) (ResolvedResult, bool) {
 
 		return ResolvedResult{
 			Message: msg,
+			Code:    CodeKptfileV1alpha1,
 		}, true
 	}
 
@@ -85,6 +88,7 @@ func resolveNestedErr(err error, path string) (ResolvedResult, bool) {
 
 		return ResolvedResult{
 			Message: msg,
+			Code:    CodeKptfileV1alpha2,
 		}, true
 	}
 
@@ -93,6 +97,7 @@ func resolveNestedErr(err error, path string) (ResolvedResult, bool) {
 		msg := fmt.Sprintf("Error: Kptfile at %q has an unknown resource type (%q).", path, unknownKptfileResourceError.GVK.String())
 		return ResolvedResult{
 			Message: msg,
+			Code:    CodeKptfileUnknownResource,
 		}, true
 	}
 
@@ -110,5 +115,6 @@ func resolveNestedErr(err error, path string) (ResolvedResult, bool) {
 
 	return ResolvedResult{
 		Message: msg,
+		Code:    CodeKptfileUnreadable,
 	}, true
 }
@@ -34,6 +34,9 @@ func ResolveError(err error) (ResolvedResult, bool) {
 		if rr.ExitCode == 0 {
 			rr.ExitCode = 1
 		}
+		if rr.Code == "" {
+			rr.Code = CodeUnknown
+		}
 		if found {
 			return rr, true
 		}
@@ -44,6 +47,8 @@ func ResolveError(err error) (ResolvedResult, bool) {
 type ResolvedResult struct {
 	Message  string
 	ExitCode int
+	// Code is the error code of the error. Defaults to CodeUnknown.
+	Code Code
 }
 
 // ErrorResolver is an interface that allows kpt to resolve an error into
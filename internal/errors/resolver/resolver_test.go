@@ -31,6 +31,7 @@ func TestResolveError_DefaultExitCode(t *testing.T) {
 	rr, ok := ResolveError(&TestError{})
 	assert.True(t, ok)
 	assert.Equal(t, 1, rr.ExitCode)
+	assert.Equal(t, CodeUnknown, rr.Code)
 }
 
 type TestErrorResolver struct{}
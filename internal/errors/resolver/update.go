@@ -32,16 +32,19 @@ type updateErrorResolver struct{}
 
 func (*updateErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 	var msg string
+	var code Code
 
 	var pkgNotGitRepoError *update.PkgNotGitRepoError
 	if errors.As(err, &pkgNotGitRepoError) {
 		//nolint:lll
+		code = CodePkgNotGitRepo
 		msg = fmt.Sprintf("Package %q is not within a git repository.", pkgNotGitRepoError.Path)
 		msg += " Please initialize a repository using 'git init' and then commit the changes using 'git commit -m \"<commit message>\"'."
 	}
 
 	var pkgRepoDirtyError *update.PkgRepoDirtyError
 	if errors.As(err, &pkgRepoDirtyError) {
+		code = CodePkgRepoDirty
 		msg = fmt.Sprintf("Package %q contains uncommitted changes.", pkgRepoDirtyError.Path)
 		msg += " Please commit the changes using 'git commit -m \"<commit message>\"'."
 	}
@@ -49,6 +52,7 @@ func (*updateErrorResolver) Resolve(err error) (ResolvedResult, bool) {
 	if msg != "" {
 		return ResolvedResult{
 			Message: msg,
+			Code:    code,
 		}, true
 	}
 	return ResolvedResult{}, false
@@ -0,0 +1,69 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnruntime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/composite"
+)
+
+// CompositeResolveFunc is the type for a function that returns the
+// composite function published as image.
+type CompositeResolveFunc func(ctx context.Context, image string) (*composite.CompositeFunction, error)
+
+// ResolveCompositeForCLI loads the composite function published as image
+// from its registry, or from its registry mirror. Composite functions are
+// cached in the temp directory.
+func ResolveCompositeForCLI(ctx context.Context, image string) (*composite.CompositeFunction, error) {
+	client, err := composite.NewClient(filepath.Join(os.TempDir(), "kpt-fn-composite"))
+	if err != nil {
+		return nil, err
+	}
+	return client.Load(ctx, MirrorImage(image))
+}
+
+// ExpandComposites returns fns with every composite function replaced by
+// its functions, in order. The selectors and exclusions of the composite
+// function entry are given to its functions that have none.
+func ExpandComposites(ctx context.Context, fns []kptfilev1.Function, resolve CompositeResolveFunc) ([]kptfilev1.Function, error) {
+	var output []kptfilev1.Function
+	for _, fn := range fns {
+		if fn.Composite == "" {
+			output = append(output, fn)
+			continue
+		}
+		if resolve == nil {
+			return nil, fmt.Errorf("composite function %q is not supported here", fn.Composite)
+		}
+		c, err := resolve(ctx, fn.Composite)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve composite function %q: %w", fn.Composite, err)
+		}
+		for _, f := range c.Functions {
+			f = *f.DeepCopy()
+			if len(f.Selectors) == 0 && len(f.Exclusions) == 0 {
+				f.Selectors = fn.Selectors
+				f.Exclusions = fn.Exclusions
+			}
+			output = append(output, f)
+		}
+	}
+	return output, nil
+}
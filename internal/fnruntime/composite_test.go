@@ -0,0 +1,87 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnruntime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/composite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandComposites(t *testing.T) {
+	resolve := func(_ context.Context, image string) (*composite.CompositeFunction, error) {
+		if image != "gcr.io/my-org/org-standard:v1" {
+			return nil, fmt.Errorf("not found")
+		}
+		return &composite.CompositeFunction{Functions: []kptfilev1.Function{
+			{Image: "set-labels:v0.1.5", ConfigMap: map[string]string{"owner": "platform-team"}},
+			{Image: "set-annotations:v0.1.4", Selectors: []kptfilev1.Selector{{Kind: "Service"}}},
+		}}, nil
+	}
+	deployments := []kptfilev1.Selector{{Kind: "Deployment"}}
+
+	testCases := map[string]struct {
+		fns     []kptfilev1.Function
+		resolve CompositeResolveFunc
+
+		expected       []kptfilev1.Function
+		expectedErrMsg string
+	}{
+		"no composite": {
+			fns:      []kptfilev1.Function{{Image: "set-namespace:v0.4.1"}},
+			expected: []kptfilev1.Function{{Image: "set-namespace:v0.4.1"}},
+		},
+		"composite is expanded in place": {
+			fns: []kptfilev1.Function{
+				{Image: "set-namespace:v0.4.1"},
+				{Composite: "gcr.io/my-org/org-standard:v1", Selectors: deployments},
+				{Exec: "./fn"},
+			},
+			resolve: resolve,
+			expected: []kptfilev1.Function{
+				{Image: "set-namespace:v0.4.1"},
+				{Image: "set-labels:v0.1.5", ConfigMap: map[string]string{"owner": "platform-team"}, Selectors: deployments},
+				{Image: "set-annotations:v0.1.4", Selectors: []kptfilev1.Selector{{Kind: "Service"}}},
+				{Exec: "./fn"},
+			},
+		},
+		"composite not found": {
+			fns:            []kptfilev1.Function{{Composite: "gcr.io/my-org/unknown:v1"}},
+			resolve:        resolve,
+			expectedErrMsg: `unable to resolve composite function "gcr.io/my-org/unknown:v1": not found`,
+		},
+		"composites not supported": {
+			fns:            []kptfilev1.Function{{Composite: "gcr.io/my-org/org-standard:v1"}},
+			expectedErrMsg: `composite function "gcr.io/my-org/org-standard:v1" is not supported here`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			fns, err := ExpandComposites(context.Background(), tc.fns, tc.resolve)
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, fns)
+		})
+	}
+}
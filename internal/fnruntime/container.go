@@ -24,10 +24,12 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
 	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
 	"golang.org/x/mod/semver"
@@ -61,6 +63,26 @@ const (
 
 type ContainerRuntime string
 
+// RegistryMirrors maps image name prefixes, e.g. "gcr.io/kpt-fn", to the
+// prefixes of the mirrors that container, wasm and composite functions are
+// pulled from instead.
+var RegistryMirrors map[string]string
+
+// MirrorImage returns the name of image in the registry mirror with the
+// longest matching prefix, or image if no mirror matches.
+func MirrorImage(image string) string {
+	var match string
+	for prefix := range RegistryMirrors {
+		if (image == prefix || strings.HasPrefix(image, prefix+"/")) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	if match == "" {
+		return image
+	}
+	return RegistryMirrors[match] + strings.TrimPrefix(image, match)
+}
+
 // ContainerFnPermission contains the permission of container
 // function such as network access.
 type ContainerFnPermission struct {
@@ -90,6 +112,10 @@ type ContainerFn struct {
 	StorageMounts []runtimeutil.StorageMount
 	// Env is a slice of env string that will be exposed to container
 	Env []string
+	// SecretEnv are env vars that will be exposed to the container. Unlike
+	// Env, their values are passed through the environment of the container
+	// runtime CLI, so they don't show up in its command line.
+	SecretEnv map[string]string
 	// FnResult is used to store the information about the result from
 	// the function.
 	FnResult *fnresult.Result
@@ -110,8 +136,8 @@ func (r ContainerRuntime) GetBin() string {
 // It reads the input from the given reader and writes the output
 // to the provided writer.
 func (f *ContainerFn) Run(reader io.Reader, writer io.Writer) error {
-	// If the env var is empty, stringToContainerRuntime defaults it to docker.
-	runtime, err := StringToContainerRuntime(os.Getenv(ContainerRuntimeEnv))
+	// If the env var is empty, the first available runtime is used.
+	runtime, err := ResolveContainerRuntime()
 	if err != nil {
 		return err
 	}
@@ -123,19 +149,23 @@ func (f *ContainerFn) Run(reader io.Reader, writer io.Writer) error {
 		return err
 	}
 
+	opts := rootlessOptionsFor(runtime)
+	if err := f.checkOffline(runtime.GetBin(), opts); err != nil {
+		return err
+	}
 	switch runtime {
 	case Podman:
-		return f.runCLI(reader, writer, podmanBin, filterPodmanCLIOutput)
+		return f.runCLI(reader, writer, podmanBin, opts, filterPodmanCLIOutput)
 	case Nerdctl:
-		return f.runCLI(reader, writer, nerdctlBin, filterNerdctlCLIOutput)
+		return f.runCLI(reader, writer, nerdctlBin, opts, filterNerdctlCLIOutput)
 	default:
-		return f.runCLI(reader, writer, dockerBin, filterDockerCLIOutput)
+		return f.runCLI(reader, writer, dockerBin, opts, filterDockerCLIOutput)
 	}
 }
 
-func (f *ContainerFn) runCLI(reader io.Reader, writer io.Writer, bin string, filterCLIOutputFn func(io.Reader) string) error {
+func (f *ContainerFn) runCLI(reader io.Reader, writer io.Writer, bin string, opts rootlessOptions, filterCLIOutputFn func(io.Reader) string) error {
 	errSink := bytes.Buffer{}
-	cmd, cancel := f.getCmd(bin)
+	cmd, cancel := f.getCmd(bin, opts)
 	defer cancel()
 	cmd.Stdin = reader
 	cmd.Stdout = writer
@@ -160,31 +190,58 @@ func (f *ContainerFn) runCLI(reader io.Reader, writer io.Writer, bin string, fil
 	return nil
 }
 
+// checkOffline fails fast in offline mode if running the function would
+// pull its image, either because the image pull policy is always or
+// because the image is not present locally.
+func (f *ContainerFn) checkOffline(binName string, opts rootlessOptions) error {
+	if !offline.IsEnabled() {
+		return nil
+	}
+	image := MirrorImage(f.Image)
+	if f.ImagePullPolicy == AlwaysPull {
+		return &offline.Error{Operation: fmt.Sprintf("pulling image %q with image pull policy always", image)}
+	}
+	args := append(append([]string{}, opts.globalArgs...), "image", "inspect", image)
+	if err := exec.Command(binName, args...).Run(); err != nil {
+		return &offline.Error{Operation: fmt.Sprintf("pulling image %q, which is not present locally,", image)}
+	}
+	return nil
+}
+
 // getCmd assembles a command for docker, podman or nerdctl. The input binName
-// is expected to be one of "docker", "podman" and "nerdctl".
-func (f *ContainerFn) getCmd(binName string) (*exec.Cmd, context.CancelFunc) {
+// is expected to be one of "docker", "podman" and "nerdctl". The rootless
+// options are applied if the runtime runs rootless.
+func (f *ContainerFn) getCmd(binName string, opts rootlessOptions) (*exec.Cmd, context.CancelFunc) {
 	network := networkNameNone
 	if f.Perm.AllowNetwork {
 		network = networkNameHost
 	}
 	uidgid := "nobody"
+	if opts.user != "" {
+		uidgid = opts.user
+	}
 	if f.UIDGID != "" {
 		uidgid = f.UIDGID
 	}
 
-	args := []string{
+	args := append([]string{}, opts.globalArgs...)
+	args = append(args,
 		"run", "--rm", "-i",
 		"--network", string(network),
 		"--user", uidgid,
 		"--security-opt=no-new-privileges",
-	}
+	)
+	args = append(args, opts.runArgs...)
 
-	switch f.ImagePullPolicy {
-	case NeverPull:
+	switch {
+	case offline.IsEnabled():
+		// the image is known to be present, since checkOffline passed.
 		args = append(args, "--pull", "never")
-	case AlwaysPull:
+	case f.ImagePullPolicy == NeverPull:
+		args = append(args, "--pull", "never")
+	case f.ImagePullPolicy == AlwaysPull:
 		args = append(args, "--pull", "always")
-	case IfNotPresentPull:
+	case f.ImagePullPolicy == IfNotPresentPull:
 		args = append(args, "--pull", "missing")
 	default:
 		args = append(args, "--pull", "missing")
@@ -194,14 +251,29 @@ func (f *ContainerFn) getCmd(binName string) (*exec.Cmd, context.CancelFunc) {
 	}
 	args = append(args,
 		NewContainerEnvFromStringSlice(f.Env).GetDockerFlags()...)
-	args = append(args, f.Image)
+	var secretNames []string
+	for name := range f.SecretEnv {
+		secretNames = append(secretNames, name)
+	}
+	sort.Strings(secretNames)
+	for _, name := range secretNames {
+		args = append(args, "-e", name)
+	}
+	args = append(args, MirrorImage(f.Image))
 	// setup container run timeout
 	timeout := defaultLongTimeout
 	if f.Timeout != 0 {
 		timeout = f.Timeout
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	return exec.CommandContext(ctx, binName, args...), cancel
+	cmd := exec.CommandContext(ctx, binName, args...)
+	if len(secretNames) > 0 {
+		cmd.Env = os.Environ()
+		for _, name := range secretNames {
+			cmd.Env = append(cmd.Env, name+"="+f.SecretEnv[name])
+		}
+	}
+	return cmd, cancel
 }
 
 // NewContainerEnvFromStringSlice returns a new ContainerEnv pointer with parsing
@@ -385,7 +457,7 @@ func StringToContainerRuntime(v string) (ContainerRuntime, error) {
 	case "":
 		return Docker, nil
 	default:
-		return "", fmt.Errorf("unsupported runtime: %q the runtime must be either %s or %s", v, Docker, Podman)
+		return "", fmt.Errorf("unsupported runtime: %q the runtime must be one of %s, %s and %s", v, Docker, Podman, Nerdctl)
 	}
 }
 
@@ -0,0 +1,104 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnruntime
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"sigs.k8s.io/kustomize/api/filters/replacement"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// ReplacementsExec is the `exec` of functions that are evaluated with the
+// built-in replacements engine instead of an executable. It copies values
+// between the fields of resources, like the apply-replacements function
+// image, and reads the replacements from the `replacements` field of the
+// function config, e.g. an ApplyReplacements resource.
+const ReplacementsExec = "apply-replacements"
+
+// IsReplacementsExec returns true if the given `exec` of a function selects
+// the built-in replacements engine.
+func IsReplacementsExec(exec string) bool {
+	return strings.TrimSpace(exec) == ReplacementsExec
+}
+
+// IsBuiltinExec returns true if the given `exec` of a function selects one
+// of the built-in runtimes, which run in-process without an executable and
+// so don't need to be allowed with --allow-exec.
+func IsBuiltinExec(exec string) bool {
+	return IsStarlarkExec(exec) || IsReplacementsExec(exec)
+}
+
+// ReplacementsFn applies the replacements of the function config to the
+// resources of the ResourceList. The replacements have the syntax of the
+// replacements of kustomize.
+type ReplacementsFn struct{}
+
+// Run applies the replacements to the ResourceList read from r and writes
+// the resulting ResourceList to w.
+func (f *ReplacementsFn) Run(r io.Reader, w io.Writer) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	rl, err := yaml.Parse(string(b))
+	if err != nil {
+		return fmt.Errorf("failed to parse ResourceList: %w", err)
+	}
+	config := rl.Field("functionConfig")
+	if config == nil || config.Value.Field("replacements") == nil {
+		return fmt.Errorf("function config of %q must have a `replacements` field", ReplacementsExec)
+	}
+	s, err := config.Value.String()
+	if err != nil {
+		return err
+	}
+	var fltr replacement.Filter
+	if err := yaml.Unmarshal([]byte(s), &fltr); err != nil {
+		return fmt.Errorf("invalid replacements in function config: %w", err)
+	}
+
+	var items []*yaml.RNode
+	if field := rl.Field("items"); field != nil {
+		if items, err = field.Value.Elements(); err != nil {
+			return err
+		}
+	}
+	items, err = fltr.Filter(items)
+	if err != nil {
+		return &ExecError{
+			OriginalErr:    err,
+			ExitCode:       1,
+			Stderr:         err.Error(),
+			TruncateOutput: printer.TruncateOutput,
+		}
+	}
+	list := yaml.NewListRNode()
+	for _, item := range items {
+		list.YNode().Content = append(list.YNode().Content, item.YNode())
+	}
+	if err := rl.PipeE(yaml.SetField("items", list)); err != nil {
+		return err
+	}
+	out, err := rl.String()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(out))
+	return err
+}
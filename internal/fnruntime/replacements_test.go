@@ -0,0 +1,96 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnruntime
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const replacementsInput = `apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: settings
+  data:
+    image: nginx:1.25
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: web
+  spec:
+    template:
+      spec:
+        containers:
+        - name: web
+          image: nginx
+functionConfig:
+  apiVersion: fn.kpt.dev/v1alpha1
+  kind: ApplyReplacements
+  metadata:
+    name: replacements
+  replacements:
+  - source:
+      kind: ConfigMap
+      name: settings
+      fieldPath: data.image
+    targets:
+    - select:
+        kind: Deployment
+      fieldPaths:
+      - spec.template.spec.containers.[name=web].image
+`
+
+func TestIsBuiltinExec(t *testing.T) {
+	assert.True(t, IsBuiltinExec("apply-replacements"))
+	assert.True(t, IsBuiltinExec("starlark fn.star"))
+	assert.False(t, IsBuiltinExec("apply-replacements-fn"))
+	assert.False(t, IsBuiltinExec("/usr/bin/apply-replacements"))
+}
+
+func TestReplacementsFn_Run(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, (&ReplacementsFn{}).Run(strings.NewReader(replacementsInput), &out))
+	assert.Equal(t, strings.Replace(replacementsInput, "image: nginx\n", "image: nginx:1.25\n", 1), out.String())
+}
+
+func TestReplacementsFn_RunErrors(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		errMsg string
+	}{
+		"missing replacements": {
+			input:  "apiVersion: config.kubernetes.io/v1\nkind: ResourceList\nitems: []\nfunctionConfig:\n  kind: ConfigMap\n",
+			errMsg: "function config of \"apply-replacements\" must have a `replacements` field",
+		},
+		"missing source": {
+			input:  strings.Replace(replacementsInput, "name: settings\n      fieldPath", "name: other\n      fieldPath", 1),
+			errMsg: "nothing selected by ConfigMap.[noVer].[noGrp]/other.[noNs]:data.image",
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			err := (&ReplacementsFn{}).Run(strings.NewReader(tc.input), &bytes.Buffer{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
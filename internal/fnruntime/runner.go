@@ -22,6 +22,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/errors"
 	"github.com/GoogleContainerTools/kpt/internal/pkg"
 	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/stats"
 	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/fn"
@@ -43,6 +45,9 @@ import (
 
 const (
 	FuncGenPkgContext = "builtins/gen-pkg-context"
+
+	// redacted replaces the values of secrets in the output of functions.
+	redacted = "[REDACTED]"
 )
 
 type RunnerOptions struct {
@@ -67,6 +72,12 @@ type RunnerOptions struct {
 	// so explicit permission is desired.
 	AllowNetwork bool
 
+	// AllowMount specifies if container based functions are allowed to
+	// mount the storage declared in the `mounts` field of the function.
+	// Mounting storage of the host is a privileged operation, so explicit
+	// permission is required.
+	AllowMount bool
+
 	// allowWasm determines if function wasm are allowed to be run during pipeline
 	// execution. Running wasm function is an alpha feature, so it needs to be
 	// enabled explicitly.
@@ -74,6 +85,16 @@ type RunnerOptions struct {
 
 	// ResolveToImage will resolve a partial image to a fully-qualified one
 	ResolveToImage ImageResolveFunc
+
+	// ResolveComposite returns the composite functions referenced by
+	// pipelines. If nil, pipelines with composite functions can't run.
+	ResolveComposite CompositeResolveFunc
+
+	// Env are the values of the environment variables that functions may
+	// receive. A function only receives the variables listed in its `env`
+	// field. The values are redacted from the output of all functions, since
+	// they are often secrets.
+	Env map[string]string
 }
 
 // ImageResolveFunc is the type for a function that can resolve a partial image to a (more) fully-qualified name
@@ -82,6 +103,7 @@ type ImageResolveFunc func(ctx context.Context, image string) (string, error)
 func (o *RunnerOptions) InitDefaults() {
 	o.ImagePullPolicy = IfNotPresentPull
 	o.ResolveToImage = ResolveToImageForCLI
+	o.ResolveComposite = ResolveCompositeForCLI
 }
 
 // NewRunner returns a FunctionRunner given a specification of a function
@@ -140,26 +162,49 @@ func NewRunner(
 			case f.Image != "":
 				// If allowWasm is true, we will use wasm runtime for image field.
 				if opts.AllowWasm {
+					if (f.Network != nil && *f.Network) || len(f.Mounts) > 0 || len(f.Env) > 0 {
+						return nil, fmt.Errorf("function %q: `network`, `mounts` and `env` are not supported for wasm functions", f.Image)
+					}
 					wFn, err := NewWasmFn(NewOciLoader(filepath.Join(os.TempDir(), "kpt-fn-wasm"), f.Image))
 					if err != nil {
 						return nil, err
 					}
 					fltr.Run = wFn.Run
 				} else {
+					allowNetwork, err := containerNetwork(f, opts)
+					if err != nil {
+						return nil, err
+					}
+					env, err := containerEnv(f, opts)
+					if err != nil {
+						return nil, err
+					}
+					mounts, err := containerMounts(f, pkgPath, opts)
+					if err != nil {
+						return nil, err
+					}
 					cfn := &ContainerFn{
 						Image:           f.Image,
 						ImagePullPolicy: opts.ImagePullPolicy,
 						Perm: ContainerFnPermission{
-							AllowNetwork: opts.AllowNetwork,
-							// mounts are disabled for render operations (currently)
-							// but it may change in the future.
-							// AllowMount: true,
+							AllowNetwork: allowNetwork,
+							AllowMount:   len(mounts) > 0,
 						},
-						Ctx:      ctx,
-						FnResult: fnResult,
+						StorageMounts: mounts,
+						SecretEnv:     env,
+						Ctx:           ctx,
+						FnResult:      fnResult,
 					}
 					fltr.Run = cfn.Run
 				}
+			case IsStarlarkExec(f.Exec):
+				sFn, err := NewStarlarkFn(ctx, fsys, f.Exec, pkgPath, fnResult)
+				if err != nil {
+					return nil, err
+				}
+				fltr.Run = sFn.Run
+			case IsReplacementsExec(f.Exec):
+				fltr.Run = (&ReplacementsFn{}).Run
 			case f.Exec != "":
 				// If AllowWasm is true, we will use wasm runtime for exec field.
 				if opts.AllowWasm {
@@ -197,6 +242,88 @@ func NewRunner(
 	return NewFunctionRunner(ctx, fltr, pkgPath, fnResult, fnResults, opts)
 }
 
+// containerNetwork returns if the container of function f may access the
+// network. The `network` field of the function can only restrict the
+// network access allowed by opts.
+func containerNetwork(f *kptfilev1.Function, opts RunnerOptions) (bool, error) {
+	if f.Network == nil {
+		return opts.AllowNetwork, nil
+	}
+	if *f.Network && !opts.AllowNetwork {
+		return false, fmt.Errorf("function %q requires network access, must run with `--allow-network` option", f.Image)
+	}
+	return *f.Network, nil
+}
+
+// containerMounts returns the storage mounts of the container of function f.
+// The sources of bind mounts are resolved relative to the package at pkgPath,
+// following symlinks, and must stay within the package.
+func containerMounts(f *kptfilev1.Function, pkgPath types.UniquePath, opts RunnerOptions) ([]runtimeutil.StorageMount, error) {
+	if len(f.Mounts) == 0 {
+		return nil, nil
+	}
+	if !opts.AllowMount {
+		return nil, fmt.Errorf("function %q requires storage mounts, must run with `--allow-mount` option", f.Image)
+	}
+	var mounts []runtimeutil.StorageMount
+	for _, m := range f.Mounts {
+		sm := runtimeutil.StorageMount{
+			MountType:     m.Type,
+			DstPath:       m.Dst,
+			ReadWriteMode: m.ReadWrite,
+		}
+		switch m.Type {
+		case kptfilev1.BindMount:
+			src, err := bindMountSource(string(pkgPath), m.Src)
+			if err != nil {
+				return nil, fmt.Errorf("function %q: %w", f.Image, err)
+			}
+			sm.Src = src
+		case kptfilev1.TmpfsMount:
+			// A read-only temporary file system is of no use.
+			sm.ReadWriteMode = true
+		}
+		mounts = append(mounts, sm)
+	}
+	return mounts, nil
+}
+
+// bindMountSource resolves the source src of a bind mount relative to the
+// package at pkgPath. Symlinks are followed, so the source can't point
+// outside of the package through a link.
+func bindMountSource(pkgPath, src string) (string, error) {
+	root, err := filepath.EvalSymlinks(pkgPath)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(src)))
+	if err != nil {
+		return "", fmt.Errorf("mount source %q: %w", src, err)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("mount source %q must be within the package", src)
+	}
+	return resolved, nil
+}
+
+// containerEnv returns the env vars of the container of function f, with
+// the values from opts.
+func containerEnv(f *kptfilev1.Function, opts RunnerOptions) (map[string]string, error) {
+	if len(f.Env) == 0 {
+		return nil, nil
+	}
+	env := map[string]string{}
+	for _, name := range f.Env {
+		value, found := opts.Env[name]
+		if !found {
+			return nil, fmt.Errorf("function %q requires environment variable %q, must be provided with `--env` or `--env-file` option", f.Image, name)
+		}
+		env[name] = value
+	}
+	return env, nil
+}
+
 // NewFunctionRunner returns a FunctionRunner given a specification of a function
 // and it's config.
 func NewFunctionRunner(ctx context.Context,
@@ -252,6 +379,7 @@ func (fr *FunctionRunner) Filter(input []*yaml.RNode) (output []*yaml.RNode, err
 	}
 	t0 := time.Now()
 	output, err = fr.do(input)
+	stats.Add(stats.Function, fr.name, time.Since(t0), err)
 	if err != nil {
 		printOpt := printer.NewOpt()
 		pr.OptPrintf(printOpt, "[FAIL] %q in %v\n", fr.name, time.Since(t0).Truncate(time.Millisecond*100))
@@ -305,6 +433,7 @@ func (fr *FunctionRunner) do(input []*yaml.RNode) (output []*yaml.RNode, err err
 		// function exec error. Revisit this if this turns out to be true.
 		return output, resultErr
 	}
+	fr.redactEnv(fnResult, err)
 	if err != nil {
 		var execErr *ExecError
 		// set exitCode to non-zero by default in case of an error.
@@ -326,6 +455,37 @@ func (fr *FunctionRunner) do(input []*yaml.RNode) (output []*yaml.RNode, err err
 	return output, nil
 }
 
+// redactEnv replaces the values of the env vars in fr.opts in the stderr
+// and result messages of the function, so secrets passed to functions
+// are neither printed nor saved in the results.
+func (fr *FunctionRunner) redactEnv(fnResult *fnresult.Result, err error) {
+	var values []string
+	for _, v := range fr.opts.Env {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+	// Replace longer values first, so a value that contains another one
+	// is redacted completely.
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	var oldnew []string
+	for _, v := range values {
+		oldnew = append(oldnew, v, redacted)
+	}
+	r := strings.NewReplacer(oldnew...)
+	fnResult.Stderr = r.Replace(fnResult.Stderr)
+	for _, item := range fnResult.Results {
+		item.Message = r.Replace(item.Message)
+	}
+	var execErr *ExecError
+	if goerrors.As(err, &execErr) {
+		execErr.Stderr = r.Replace(execErr.Stderr)
+	}
+}
+
 func setPkgPathAnnotationIfNotExist(resources []*yaml.RNode, pkgPath types.UniquePath) error {
 	for _, r := range resources {
 		currPkgPath, err := pkg.GetPkgPathAnnotation(r)
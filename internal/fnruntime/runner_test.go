@@ -19,17 +19,22 @@ package fnruntime
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/fn/framework"
+	"sigs.k8s.io/kustomize/kyaml/fn/runtime/runtimeutil"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
 )
@@ -646,3 +651,204 @@ func TestPrintFnStderr(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerNetwork(t *testing.T) {
+	trueVal, falseVal := true, false
+	testCases := map[string]struct {
+		network      *bool
+		allowNetwork bool
+		expected     bool
+		expectedErr  string
+	}{
+		"not specified": {
+			allowNetwork: true,
+			expected:     true,
+		},
+		"not specified without --allow-network": {
+			expected: false,
+		},
+		"disabled with --allow-network": {
+			network:      &falseVal,
+			allowNetwork: true,
+			expected:     false,
+		},
+		"enabled with --allow-network": {
+			network:      &trueVal,
+			allowNetwork: true,
+			expected:     true,
+		},
+		"enabled without --allow-network": {
+			network:     &trueVal,
+			expectedErr: "must run with `--allow-network` option",
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			f := &kptfilev1.Function{Image: "gcr.io/kpt-fn/fetch", Network: tc.network}
+			allowed, err := containerNetwork(f, RunnerOptions{AllowNetwork: tc.allowNetwork})
+			if tc.expectedErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, allowed)
+		})
+	}
+}
+
+func TestContainerMounts(t *testing.T) {
+	pkgPath := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(pkgPath, "data", "certs"), 0700))
+	assert.NoError(t, os.Mkdir(filepath.Join(pkgPath, "out"), 0700))
+	root, err := filepath.EvalSymlinks(pkgPath)
+	assert.NoError(t, err)
+
+	f := &kptfilev1.Function{
+		Image: "gcr.io/kpt-fn/fetch",
+		Mounts: []kptfilev1.Mount{
+			{Type: kptfilev1.BindMount, Src: "data/certs", Dst: "/certs"},
+			{Type: kptfilev1.BindMount, Src: "out", Dst: "/out", ReadWrite: true},
+			{Type: kptfilev1.TmpfsMount, Dst: "/tmp"},
+		},
+	}
+	mounts, err := containerMounts(f, types.UniquePath(pkgPath), RunnerOptions{AllowMount: true})
+	assert.NoError(t, err)
+	var actual []string
+	for _, m := range mounts {
+		actual = append(actual, m.String())
+	}
+	assert.Equal(t, []string{
+		"type=bind,source=" + filepath.Join(root, "data", "certs") + ",target=/certs,readonly",
+		"type=bind,source=" + filepath.Join(root, "out") + ",target=/out",
+		"type=tmpfs,source=,target=/tmp",
+	}, actual)
+
+	_, err = containerMounts(f, types.UniquePath(pkgPath), RunnerOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must run with `--allow-mount` option")
+	}
+}
+
+func TestContainerMounts_outsidePackage(t *testing.T) {
+	dir := t.TempDir()
+	pkgPath := filepath.Join(dir, "pkg")
+	assert.NoError(t, os.Mkdir(pkgPath, 0700))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "secrets"), 0700))
+	assert.NoError(t, os.Symlink(filepath.Join(dir, "secrets"), filepath.Join(pkgPath, "link")))
+
+	f := &kptfilev1.Function{
+		Image:  "gcr.io/kpt-fn/fetch",
+		Mounts: []kptfilev1.Mount{{Type: kptfilev1.BindMount, Src: "link", Dst: "/secrets"}},
+	}
+	_, err := containerMounts(f, types.UniquePath(pkgPath), RunnerOptions{AllowMount: true})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `mount source "link" must be within the package`)
+	}
+}
+
+func TestContainerEnv(t *testing.T) {
+	f := &kptfilev1.Function{Image: "gcr.io/kpt-fn/fetch", Env: []string{"TOKEN", "EMPTY"}}
+	env, err := containerEnv(f, RunnerOptions{Env: map[string]string{"TOKEN": "secret", "EMPTY": "", "OTHER": "other"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"TOKEN": "secret", "EMPTY": ""}, env)
+
+	_, err = containerEnv(f, RunnerOptions{Env: map[string]string{"TOKEN": "secret"}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `requires environment variable "EMPTY"`)
+	}
+}
+
+func TestFunctionRunner_redactsEnv(t *testing.T) {
+	output := `apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items: []
+results:
+- message: token secret-token-123 is invalid
+  severity: warning
+`
+	testCases := map[string]struct {
+		run            func(fnResult *fnresult.Result) func(io.Reader, io.Writer) error
+		expectedStderr string
+		expectedErr    bool
+	}{
+		"success": {
+			run: func(fnResult *fnresult.Result) func(io.Reader, io.Writer) error {
+				return func(_ io.Reader, w io.Writer) error {
+					fnResult.Stderr = "using secret-token-123 and secret-token"
+					_, err := w.Write([]byte(output))
+					return err
+				}
+			},
+			expectedStderr: "using [REDACTED] and [REDACTED]",
+		},
+		"failure": {
+			run: func(*fnresult.Result) func(io.Reader, io.Writer) error {
+				return func(io.Reader, io.Writer) error {
+					return &ExecError{ExitCode: 1, Stderr: "request with secret-token-123 failed"}
+				}
+			},
+			expectedStderr: "request with [REDACTED] failed",
+			expectedErr:    true,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var out bytes.Buffer
+			ctx := printer.WithContext(context.Background(), printer.New(&out, &out))
+			fnResult := &fnresult.Result{Image: "gcr.io/kpt-fn/fetch"}
+			fnResults := fnresult.NewResultList()
+			fr, err := NewFunctionRunner(ctx, &runtimeutil.FunctionFilter{Run: tc.run(fnResult)}, "", fnResult, fnResults,
+				RunnerOptions{Env: map[string]string{"TOKEN": "secret-token-123", "SHORT": "secret-token"}})
+			assert.NoError(t, err)
+
+			_, err = fr.Filter(nil)
+			assert.Equal(t, tc.expectedErr, err != nil)
+			if assert.Len(t, fnResults.Items, 1) {
+				assert.Equal(t, tc.expectedStderr, fnResults.Items[0].Stderr)
+				for _, r := range fnResults.Items[0].Results {
+					assert.Equal(t, "token [REDACTED] is invalid", r.Message)
+				}
+			}
+			assert.NotContains(t, out.String(), "secret-token")
+		})
+	}
+}
+
+func TestContainerFn_getCmdSecretEnv(t *testing.T) {
+	f := &ContainerFn{
+		Image:     "gcr.io/kpt-fn/fetch",
+		Env:       []string{"DEBUG=true"},
+		SecretEnv: map[string]string{"TOKEN": "secret", "API_KEY": "key"},
+	}
+	cmd, cancel := f.getCmd(dockerBin, rootlessOptions{})
+	defer cancel()
+	args := strings.Join(cmd.Args, " ")
+	assert.Contains(t, args, "-e DEBUG=true -e API_KEY -e TOKEN gcr.io/kpt-fn/fetch")
+	assert.NotContains(t, args, "secret")
+	assert.Contains(t, cmd.Env, "TOKEN=secret")
+	assert.Contains(t, cmd.Env, "API_KEY=key")
+}
+
+func TestContainerFn_offline(t *testing.T) {
+	offline.Enabled = true
+	defer func() { offline.Enabled = false }()
+
+	f := &ContainerFn{
+		Image:           "gcr.io/kpt-fn/set-labels:v0.1",
+		ImagePullPolicy: AlwaysPull,
+	}
+	err := f.checkOffline("true", rootlessOptions{})
+	assert.EqualError(t, err, `kpt is in offline mode, but pulling image "gcr.io/kpt-fn/set-labels:v0.1" with image pull policy always requires network access`)
+
+	f.ImagePullPolicy = IfNotPresentPull
+	// "false" fails like the inspection of a missing image.
+	err = f.checkOffline("false", rootlessOptions{})
+	assert.EqualError(t, err, `kpt is in offline mode, but pulling image "gcr.io/kpt-fn/set-labels:v0.1", which is not present locally, requires network access`)
+	assert.NoError(t, f.checkOffline("true", rootlessOptions{}))
+
+	cmd, cancel := f.getCmd(dockerBin, rootlessOptions{})
+	defer cancel()
+	assert.Contains(t, strings.Join(cmd.Args, " "), "--pull never")
+}
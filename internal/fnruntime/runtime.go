@@ -0,0 +1,224 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnruntime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"os/user"
+	"strings"
+	"sync"
+)
+
+// ContainerRuntimes are the supported container runtimes, in the order
+// they are tried if KPT_FN_RUNTIME is not set.
+var ContainerRuntimes = []ContainerRuntime{Docker, Podman, Nerdctl}
+
+const (
+	// cgroupV2ControllersFile only exists if the unified cgroup v2
+	// hierarchy is mounted.
+	cgroupV2ControllersFile = "/sys/fs/cgroup/cgroup.controllers"
+
+	// subUIDFile lists the subordinate user ids that rootless runtimes
+	// map into the user namespace of a container.
+	subUIDFile = "/etc/subuid"
+)
+
+var (
+	detectRuntimeOnce sync.Once
+	detectedRuntime   ContainerRuntime
+
+	rootlessMu    sync.Mutex
+	rootlessCache = map[ContainerRuntime]bool{}
+)
+
+// ResolveContainerRuntime returns the runtime set with KPT_FN_RUNTIME. If
+// it isn't set, the first available runtime of ContainerRuntimes is
+// detected and returned.
+func ResolveContainerRuntime() (ContainerRuntime, error) {
+	if v := os.Getenv(ContainerRuntimeEnv); v != "" {
+		return StringToContainerRuntime(v)
+	}
+	detectRuntimeOnce.Do(func() {
+		detectedRuntime = DetectContainerRuntime()
+	})
+	return detectedRuntime, nil
+}
+
+// DetectContainerRuntime returns the first runtime of ContainerRuntimes
+// that is installed and available. It returns docker if none are, so that
+// the error reported when running a function explains how to install it.
+func DetectContainerRuntime() ContainerRuntime {
+	for _, r := range ContainerRuntimes {
+		if _, err := exec.LookPath(r.GetBin()); err != nil {
+			continue
+		}
+		if ContainerRuntimeAvailable(r) == nil {
+			return r
+		}
+	}
+	return Docker
+}
+
+// RuntimeInfo describes a container runtime on this host.
+type RuntimeInfo struct {
+	Runtime ContainerRuntime
+	// Err is the reason the runtime can't run functions, or nil if it can.
+	Err error
+	// Version is the version of the runtime client.
+	Version string
+	// Rootless is true if the runtime runs containers without root
+	// privileges.
+	Rootless bool
+}
+
+// InspectContainerRuntime checks if the runtime is available, and returns
+// its version and whether it runs rootless.
+func InspectContainerRuntime(r ContainerRuntime) RuntimeInfo {
+	info := RuntimeInfo{Runtime: r}
+	if _, err := exec.LookPath(r.GetBin()); err != nil {
+		info.Err = err
+		return info
+	}
+	if err := ContainerRuntimeAvailable(r); err != nil {
+		info.Err = err
+		return info
+	}
+	info.Version, _ = runtimeOutput(r.GetBin(), "version", "--format", "{{.Client.Version}}")
+	info.Rootless = isRootless(r)
+	return info
+}
+
+// CgroupV2 returns true if the host uses the unified cgroup v2 hierarchy.
+func CgroupV2() bool {
+	_, err := os.Stat(cgroupV2ControllersFile)
+	return err == nil
+}
+
+// isRootless returns true if the runtime runs containers without root
+// privileges. The result is cached, since it requires running the runtime.
+func isRootless(r ContainerRuntime) bool {
+	rootlessMu.Lock()
+	defer rootlessMu.Unlock()
+	if rootless, found := rootlessCache[r]; found {
+		return rootless
+	}
+	var rootless bool
+	switch r {
+	case Podman:
+		out, err := runtimeOutput(podmanBin, "info", "--format", "{{.Host.Security.Rootless}}")
+		rootless = err == nil && out == "true"
+	default:
+		// docker and nerdctl both list the rootless security option.
+		out, err := runtimeOutput(r.GetBin(), "info", "--format", "{{json .SecurityOptions}}")
+		rootless = err == nil && strings.Contains(out, "name=rootless")
+	}
+	rootlessCache[r] = rootless
+	return rootless
+}
+
+func runtimeOutput(bin string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), versionCommandTimeout)
+	defer cancel()
+	out := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdout = out
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// hasSubordinateIDs returns true if the current user has a range of
+// subordinate user ids in /etc/subuid.
+func hasSubordinateIDs() bool {
+	u, err := user.Current()
+	if err != nil {
+		return false
+	}
+	f, err := os.Open(subUIDFile)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		owner, _, _ := strings.Cut(s.Text(), ":")
+		if owner == u.Username || owner == u.Uid {
+			return true
+		}
+	}
+	return false
+}
+
+// rootlessOptions are the adjustments made to the function container if
+// the runtime runs rootless.
+type rootlessOptions struct {
+	// globalArgs are added before the run command.
+	globalArgs []string
+	// runArgs are added to the run command.
+	runArgs []string
+	// user replaces the default user functions run as.
+	user string
+}
+
+// newRootlessOptions returns the adjustments for a rootless runtime:
+//   - On cgroup v1 the user can't manage cgroups, so podman and nerdctl
+//     must not create them for the container.
+//   - Without subordinate ids the user namespace only maps the user to
+//     root, so the function can't run as nobody. It runs as root in the
+//     user namespace instead, which has the privileges of the user.
+func newRootlessOptions(r ContainerRuntime, rootless, cgroupV2, subIDs bool) rootlessOptions {
+	var opts rootlessOptions
+	if !rootless {
+		return opts
+	}
+	if !cgroupV2 {
+		switch r {
+		case Podman:
+			opts.runArgs = append(opts.runArgs, "--cgroups=disabled")
+		case Nerdctl:
+			opts.globalArgs = append(opts.globalArgs, "--cgroup-manager=none")
+		}
+	}
+	if !subIDs {
+		opts.user = "0:0"
+	}
+	return opts
+}
+
+// rootlessOptionsFor inspects the host and returns the adjustments for
+// the runtime.
+func rootlessOptionsFor(r ContainerRuntime) rootlessOptions {
+	if !isRootless(r) {
+		return rootlessOptions{}
+	}
+	return newRootlessOptions(r, true, CgroupV2(), hasSubordinateIDs())
+}
+
+// RootlessArgs returns the flags that functions are run with because the
+// runtime runs rootless.
+func RootlessArgs(r ContainerRuntime) []string {
+	opts := rootlessOptionsFor(r)
+	args := append([]string{}, opts.globalArgs...)
+	args = append(args, opts.runArgs...)
+	if opts.user != "" {
+		args = append(args, "--user", opts.user)
+	}
+	return args
+}
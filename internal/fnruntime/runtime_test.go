@@ -0,0 +1,92 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnruntime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRootlessOptions(t *testing.T) {
+	testCases := map[string]struct {
+		runtime  ContainerRuntime
+		rootless bool
+		cgroupV2 bool
+		subIDs   bool
+		expected rootlessOptions
+	}{
+		"not rootless": {
+			runtime: Podman,
+		},
+		"rootless podman on cgroup v2": {
+			runtime:  Podman,
+			rootless: true,
+			cgroupV2: true,
+			subIDs:   true,
+		},
+		"rootless podman on cgroup v1": {
+			runtime:  Podman,
+			rootless: true,
+			subIDs:   true,
+			expected: rootlessOptions{runArgs: []string{"--cgroups=disabled"}},
+		},
+		"rootless nerdctl on cgroup v1": {
+			runtime:  Nerdctl,
+			rootless: true,
+			subIDs:   true,
+			expected: rootlessOptions{globalArgs: []string{"--cgroup-manager=none"}},
+		},
+		"rootless docker on cgroup v1": {
+			runtime:  Docker,
+			rootless: true,
+			subIDs:   true,
+		},
+		"rootless without subordinate ids": {
+			runtime:  Podman,
+			rootless: true,
+			cgroupV2: true,
+			expected: rootlessOptions{user: "0:0"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			opts := newRootlessOptions(tc.runtime, tc.rootless, tc.cgroupV2, tc.subIDs)
+			assert.Equal(t, tc.expected, opts)
+		})
+	}
+}
+
+func TestContainerFn_getCmdRootless(t *testing.T) {
+	opts := rootlessOptions{
+		globalArgs: []string{"--cgroup-manager=none"},
+		runArgs:    []string{"--cgroups=disabled"},
+		user:       "0:0",
+	}
+
+	f := &ContainerFn{Image: "gcr.io/kpt-fn/set-labels:v0.1"}
+	cmd, cancel := f.getCmd(podmanBin, opts)
+	defer cancel()
+	args := strings.Join(cmd.Args, " ")
+	assert.True(t, strings.HasPrefix(args, "podman --cgroup-manager=none run --rm -i"))
+	assert.Contains(t, args, "--user 0:0 --security-opt=no-new-privileges --cgroups=disabled")
+
+	f.UIDGID = "1000:1000"
+	cmd, cancel = f.getCmd(podmanBin, opts)
+	defer cancel()
+	assert.Contains(t, strings.Join(cmd.Args, " "), "--user 1000:1000")
+}
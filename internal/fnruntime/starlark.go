@@ -0,0 +1,361 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnruntime
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/google/shlex"
+	"go.starlark.net/resolve"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	// StarlarkExec is the `exec` of functions that are evaluated with the
+	// built-in starlark runtime instead of an executable. It can be followed
+	// by the path of a script in the package, e.g. `exec: starlark fn.star`.
+	// Without a path, the script is read from the `source` field of the
+	// function config.
+	StarlarkExec = "starlark"
+
+	// defaultStarlarkMaxSteps is the number of computation steps a starlark
+	// script may execute before it is stopped.
+	defaultStarlarkMaxSteps = 1 << 30
+)
+
+// starlarkResolveMu serializes the compilation of scripts, since the
+// resolve options of starlark are package variables.
+var starlarkResolveMu sync.Mutex
+
+// compileStarlark compiles the script src. Top-level control flow and while
+// loops are allowed, as in the scripts for the starlark function image.
+// Scripts are still bounded by MaxSteps. The resolve options are only set
+// while the script is compiled, so they don't change the dialect of other
+// users of starlark in the process.
+func compileStarlark(name, src string, predeclared starlark.StringDict) (*starlark.Program, error) {
+	starlarkResolveMu.Lock()
+	defer starlarkResolveMu.Unlock()
+	globalReassign, recursion := resolve.AllowGlobalReassign, resolve.AllowRecursion
+	defer func() {
+		resolve.AllowGlobalReassign, resolve.AllowRecursion = globalReassign, recursion
+	}()
+	resolve.AllowGlobalReassign, resolve.AllowRecursion = true, true
+	_, prog, err := starlark.SourceProgram(name, src, predeclared.Has)
+	return prog, err
+}
+
+// IsStarlarkExec returns true if the given `exec` of a function selects the
+// built-in starlark runtime.
+func IsStarlarkExec(exec string) bool {
+	s, err := shlex.Split(exec)
+	return err == nil && len(s) > 0 && s[0] == StarlarkExec
+}
+
+// StarlarkFn evaluates a starlark script as a KRM function. The script runs
+// in-process and can only access the ResourceList, which it reads and
+// modifies as `ctx.resource_list`. It has no access to the file system,
+// network or environment of the host.
+type StarlarkFn struct {
+	Ctx context.Context
+	// Name identifies the script in error messages.
+	Name string
+	// Program is the source of the script. If empty, the source is read
+	// from the function config.
+	Program string
+	// MaxSteps is the number of computation steps after which the script
+	// is stopped. Defaults to defaultStarlarkMaxSteps.
+	MaxSteps uint64
+	// Timeout is how long the script may run. The default value is 5 minutes.
+	Timeout time.Duration
+	// FnResult is used to store the information about the result from
+	// the function.
+	FnResult *fnresult.Result
+}
+
+// NewStarlarkFn returns a StarlarkFn for the given `exec` of a function. The
+// script path, if any, is resolved relative to the package at pkgPath and
+// must not be outside of it.
+func NewStarlarkFn(ctx context.Context, fsys filesys.FileSystem, exec string,
+	pkgPath types.UniquePath, fnResult *fnresult.Result) (*StarlarkFn, error) {
+	s, err := shlex.Split(exec)
+	if err != nil {
+		return nil, fmt.Errorf("exec command %q must be valid: %w", exec, err)
+	}
+	f := &StarlarkFn{
+		Ctx:      ctx,
+		Name:     StarlarkExec,
+		FnResult: fnResult,
+	}
+	switch len(s) {
+	case 1:
+		return f, nil
+	case 2:
+	default:
+		return nil, fmt.Errorf("exec command %q must specify at most one starlark script", exec)
+	}
+	p := filepath.Clean(s[1])
+	if filepath.IsAbs(p) || p == ".." || strings.HasPrefix(p, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("starlark script %q must be a relative path inside the package", s[1])
+	}
+	// Symlinks are resolved before the script is checked to be inside the
+	// package, so a link can't point outside of it.
+	root, _, err := fsys.CleanedAbs(string(pkgPath))
+	if err != nil {
+		return nil, err
+	}
+	dir, file, err := fsys.CleanedAbs(filepath.Join(string(pkgPath), p))
+	if err != nil || file == "" {
+		return nil, fmt.Errorf("missing starlark script %q", s[1])
+	}
+	if rel, err := filepath.Rel(string(root), string(dir)); err != nil ||
+		rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("starlark script %q must be a relative path inside the package", s[1])
+	}
+	b, err := fsys.ReadFile(dir.Join(file))
+	if err != nil {
+		return nil, fmt.Errorf("missing starlark script %q", s[1])
+	}
+	f.Name = s[1]
+	f.Program = string(b)
+	return f, nil
+}
+
+// Run evaluates the script with the ResourceList read from r and writes the
+// resulting ResourceList to w.
+func (f *StarlarkFn) Run(r io.Reader, w io.Writer) error {
+	timeout := defaultLongTimeout
+	if f.Timeout != 0 {
+		timeout = f.Timeout
+	}
+	parent := f.Ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	rl, err := yaml.Parse(string(b))
+	if err != nil {
+		return fmt.Errorf("failed to parse ResourceList: %w", err)
+	}
+	program := f.Program
+	if program == "" {
+		if program, err = starlarkSourceFromConfig(rl); err != nil {
+			return err
+		}
+	}
+	resourceList, err := starlarkValueFromNode(rl.YNode())
+	if err != nil {
+		return err
+	}
+
+	var stderr []string
+	thread := &starlark.Thread{
+		Name: f.Name,
+		Print: func(_ *starlark.Thread, msg string) {
+			stderr = append(stderr, msg)
+		},
+	}
+	maxSteps := uint64(defaultStarlarkMaxSteps)
+	if f.MaxSteps != 0 {
+		maxSteps = f.MaxSteps
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-done:
+		}
+	}()
+
+	predeclared := starlark.StringDict{
+		"ctx": starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"resource_list": resourceList,
+		}),
+	}
+	prog, err := compileStarlark(f.Name, program, predeclared)
+	if err == nil {
+		_, err = prog.Init(thread, predeclared)
+	}
+	if err != nil {
+		msg := err.Error()
+		var evalErr *starlark.EvalError
+		if goerrors.As(err, &evalErr) {
+			msg = evalErr.Backtrace()
+		}
+		return &ExecError{
+			OriginalErr:    err,
+			ExitCode:       1,
+			Stderr:         strings.Join(append(stderr, msg), "\n"),
+			TruncateOutput: printer.TruncateOutput,
+		}
+	}
+
+	node, err := nodeFromStarlarkValue(resourceList)
+	if err != nil {
+		return fmt.Errorf("invalid ResourceList after running starlark script: %w", err)
+	}
+	out, err := yaml.NewRNode(node).String()
+	if err != nil {
+		return err
+	}
+	if len(stderr) > 0 && f.FnResult != nil {
+		f.FnResult.Stderr = strings.Join(stderr, "\n")
+	}
+	_, err = w.Write([]byte(out))
+	return err
+}
+
+// starlarkSourceFromConfig returns the script in the function config of the
+// ResourceList. It is read from the `source` field, as used by the StarlarkRun
+// config of the starlark function image, or from `data.source` of a ConfigMap.
+func starlarkSourceFromConfig(rl *yaml.RNode) (string, error) {
+	for _, path := range [][]string{
+		{"functionConfig", "source"},
+		{"functionConfig", "data", "source"},
+	} {
+		n, err := rl.Pipe(yaml.Lookup(path...))
+		if err != nil {
+			return "", err
+		}
+		if n != nil && n.YNode().Kind == yaml.ScalarNode && n.YNode().Value != "" {
+			return n.YNode().Value, nil
+		}
+	}
+	return "", fmt.Errorf("starlark script must be specified as a file in `exec` or in the `source` field of the function config")
+}
+
+// starlarkValueFromNode converts a yaml node to a starlark value. Mapping
+// nodes are converted to dicts which keep the order of the fields.
+func starlarkValueFromNode(n *yaml.Node) (starlark.Value, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return starlark.None, nil
+		}
+		return starlarkValueFromNode(n.Content[0])
+	case yaml.AliasNode:
+		return starlarkValueFromNode(n.Alias)
+	case yaml.MappingNode:
+		d := starlark.NewDict(len(n.Content) / 2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := starlarkValueFromNode(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			if err := d.SetKey(starlark.String(n.Content[i].Value), v); err != nil {
+				return nil, err
+			}
+		}
+		return d, nil
+	case yaml.SequenceNode:
+		elems := make([]starlark.Value, 0, len(n.Content))
+		for _, c := range n.Content {
+			v, err := starlarkValueFromNode(c)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, v)
+		}
+		return starlark.NewList(elems), nil
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case yaml.NodeTagNull:
+			return starlark.None, nil
+		case yaml.NodeTagBool:
+			b, err := strconv.ParseBool(n.Value)
+			if err == nil {
+				return starlark.Bool(b), nil
+			}
+		case yaml.NodeTagInt:
+			i, err := strconv.ParseInt(n.Value, 0, 64)
+			if err == nil {
+				return starlark.MakeInt64(i), nil
+			}
+		case yaml.NodeTagFloat:
+			f, err := strconv.ParseFloat(n.Value, 64)
+			if err == nil {
+				return starlark.Float(f), nil
+			}
+		}
+		return starlark.String(n.Value), nil
+	}
+	return nil, fmt.Errorf("unsupported yaml node kind %d", n.Kind)
+}
+
+// nodeFromStarlarkValue converts a starlark value to a yaml node.
+func nodeFromStarlarkValue(v starlark.Value) (*yaml.Node, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: yaml.NodeTagNull, Value: "null"}, nil
+	case starlark.Bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: yaml.NodeTagBool, Value: strconv.FormatBool(bool(v))}, nil
+	case starlark.Int:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: yaml.NodeTagInt, Value: v.String()}, nil
+	case starlark.Float:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: yaml.NodeTagFloat,
+			Value: strconv.FormatFloat(float64(v), 'g', -1, 64)}, nil
+	case starlark.String:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: yaml.NodeTagString, Value: string(v)}, nil
+	case *starlark.Dict:
+		n := &yaml.Node{Kind: yaml.MappingNode, Tag: yaml.NodeTagMap}
+		for _, item := range v.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("dict keys must be strings, got %s", item[0].Type())
+			}
+			val, err := nodeFromStarlarkValue(item[1])
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: yaml.NodeTagString, Value: string(k)}, val)
+		}
+		return n, nil
+	case starlark.Indexable:
+		// lists and tuples
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: yaml.NodeTagSeq}
+		for i := 0; i < v.Len(); i++ {
+			val, err := nodeFromStarlarkValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, val)
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("unsupported starlark value of type %s", v.Type())
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnruntime
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.starlark.net/resolve"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const starlarkInput = `apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: nginx
+  spec:
+    replicas: 3
+    paused: false
+    template:
+      spec:
+        containers:
+        - name: nginx
+          image: nginx:1.14
+          ports:
+          - containerPort: 80
+            ratio: 0.5
+          args:
+          - "01"
+          - "true"
+          - null
+functionConfig:
+  apiVersion: fn.kpt.dev/v1alpha1
+  kind: StarlarkRun
+  metadata:
+    name: set-replicas
+  source: |
+    for r in ctx.resource_list["items"]:
+      r["spec"]["replicas"] = 5
+    print("updated", len(ctx.resource_list["items"]), "resource(s)")
+`
+
+func TestIsStarlarkExec(t *testing.T) {
+	assert.True(t, IsStarlarkExec("starlark"))
+	assert.True(t, IsStarlarkExec("starlark fn.star"))
+	assert.False(t, IsStarlarkExec("starlark-fn"))
+	assert.False(t, IsStarlarkExec("/usr/bin/starlark"))
+	assert.False(t, IsStarlarkExec(""))
+}
+
+func TestStarlarkFn_Run(t *testing.T) {
+	fnResult := &fnresult.Result{}
+	f := &StarlarkFn{Ctx: context.Background(), Name: StarlarkExec, FnResult: fnResult}
+	var out bytes.Buffer
+	require.NoError(t, f.Run(strings.NewReader(starlarkInput), &out))
+	assert.Equal(t, strings.Replace(starlarkInput, "replicas: 3", "replicas: 5", 1), out.String())
+	assert.Equal(t, "updated 1 resource(s)", fnResult.Stderr)
+}
+
+func TestStarlarkFn_RunErrors(t *testing.T) {
+	tests := map[string]struct {
+		program  string
+		maxSteps uint64
+		errMsg   string
+	}{
+		"missing script": {
+			program: "",
+			errMsg:  "starlark script must be specified",
+		},
+		"script error": {
+			program: `print("before")
+fail("invalid resources")`,
+			errMsg: "invalid resources",
+		},
+		"load is not allowed": {
+			program: `load("foo.star", "bar")`,
+			errMsg:  "load not implemented",
+		},
+		"too many steps": {
+			program:  "while True:\n  pass\n",
+			maxSteps: 1000,
+			errMsg:   "too many steps",
+		},
+		"invalid output": {
+			program: `ctx.resource_list["items"] = [{1: "foo"}]`,
+			errMsg:  "dict keys must be strings, got int",
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			input := starlarkInput[:strings.Index(starlarkInput, "functionConfig:")]
+			f := &StarlarkFn{
+				Ctx:      context.Background(),
+				Name:     StarlarkExec,
+				Program:  tc.program,
+				MaxSteps: tc.maxSteps,
+				FnResult: &fnresult.Result{},
+			}
+			err := f.Run(strings.NewReader(input), &bytes.Buffer{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestNewStarlarkFn(t *testing.T) {
+	fsys := filesys.MakeFsInMemory()
+	require.NoError(t, fsys.MkdirAll("/pkg/fns"))
+	require.NoError(t, fsys.WriteFile("/pkg/fns/set.star", []byte(`print("hi")`)))
+
+	f, err := NewStarlarkFn(context.Background(), fsys, "starlark fns/set.star", "/pkg", &fnresult.Result{})
+	require.NoError(t, err)
+	assert.Equal(t, "fns/set.star", f.Name)
+	assert.Equal(t, `print("hi")`, f.Program)
+
+	f, err = NewStarlarkFn(context.Background(), fsys, "starlark", "/pkg", &fnresult.Result{})
+	require.NoError(t, err)
+	assert.Empty(t, f.Program)
+
+	for exec, errMsg := range map[string]string{
+		"starlark ../set.star":        "must be a relative path inside the package",
+		"starlark /pkg/fns/set.star":  "must be a relative path inside the package",
+		"starlark missing.star":       `missing starlark script "missing.star"`,
+		"starlark fns/set.star extra": "must specify at most one starlark script",
+		`starlark "fns/set.star`:      "must be valid",
+	} {
+		_, err := NewStarlarkFn(context.Background(), fsys, exec, "/pkg", &fnresult.Result{})
+		if assert.Error(t, err, exec) {
+			assert.Contains(t, err.Error(), errMsg)
+		}
+	}
+}
+
+func TestNewStarlarkFn_symlinkOutsidePackage(t *testing.T) {
+	dir := t.TempDir()
+	pkgPath := filepath.Join(dir, "pkg")
+	require.NoError(t, os.MkdirAll(pkgPath, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "set.star"), []byte(`print("hi")`), 0600))
+	require.NoError(t, os.Symlink(dir, filepath.Join(pkgPath, "link")))
+
+	_, err := NewStarlarkFn(context.Background(), filesys.MakeFsOnDisk(), "starlark link/set.star", types.UniquePath(pkgPath), &fnresult.Result{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must be a relative path inside the package")
+	}
+}
+
+func TestStarlarkFn_RunKeepsResolveOptions(t *testing.T) {
+	input := starlarkInput[:strings.Index(starlarkInput, "functionConfig:")]
+	f := &StarlarkFn{
+		Ctx:      context.Background(),
+		Name:     StarlarkExec,
+		Program:  "n = 0\nwhile n < 3:\n  n += 1\n",
+		FnResult: &fnresult.Result{},
+	}
+	require.NoError(t, f.Run(strings.NewReader(input), &bytes.Buffer{}))
+	assert.False(t, resolve.AllowGlobalReassign)
+	assert.False(t, resolve.AllowRecursion)
+}
@@ -156,3 +156,21 @@ func TestNewConfigMap(t *testing.T) {
 	assert.Contains(t, mapAsString, `integer: "8081"`)
 	assert.Contains(t, mapAsString, `float: "1.23"`)
 }
+
+func TestMirrorImage(t *testing.T) {
+	defer func(mirrors map[string]string) { RegistryMirrors = mirrors }(RegistryMirrors)
+	RegistryMirrors = map[string]string{
+		"gcr.io":        "mirror.example.com/gcr",
+		"gcr.io/kpt-fn": "mirror.example.com/kpt-fn",
+	}
+	tests := map[string]string{
+		"gcr.io/kpt-fn/set-namespace:v0.4":  "mirror.example.com/kpt-fn/set-namespace:v0.4",
+		"gcr.io/other/fn@sha256:0123":       "mirror.example.com/gcr/other/fn@sha256:0123",
+		"gcr.io/kpt-fn-other/set-labels:v1": "mirror.example.com/gcr/kpt-fn-other/set-labels:v1",
+		"gcr.iox/kpt-fn/set-namespace:v0.4": "gcr.iox/kpt-fn/set-namespace:v0.4",
+		"ghcr.io/kptdev/set-namespace:v0.4": "ghcr.io/kptdev/set-namespace:v0.4",
+	}
+	for image, expected := range tests {
+		assert.Equal(t, expected, MirrorImage(image), image)
+	}
+}
@@ -109,7 +109,7 @@ func (o *OciLoader) getReadCloser() (io.ReadCloser, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to create a storage client: %w", err)
 	}
-	rc, err := storage.LoadWasm(context.TODO(), o.image)
+	rc, err := storage.LoadWasm(context.TODO(), MirrorImage(o.image))
 	if err != nil {
 		return nil, fmt.Errorf("unable to load image from %v: %w", o.image, err)
 	}
@@ -0,0 +1,215 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnserver
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages and service below implement the wire format of the
+// FunctionEvaluator service of the Porch function runner:
+//
+//	service FunctionEvaluator {
+//	  rpc EvaluateFunction (EvaluateFunctionRequest) returns (EvaluateFunctionResponse) {}
+//	}
+//
+//	message EvaluateFunctionRequest {
+//	  bytes resource_list = 1;
+//	  string image = 2;
+//	}
+//
+//	message EvaluateFunctionResponse {
+//	  bytes resource_list = 1;
+//	  bytes log = 2;
+//	}
+//
+// They are encoded by hand so that Porch clients can talk to kpt without
+// kpt depending on the Porch server module.
+
+const evaluateFunctionMethod = "/evaluator.FunctionEvaluator/EvaluateFunction"
+
+// EvaluateFunctionRequest asks for a function to be run on a ResourceList.
+type EvaluateFunctionRequest struct {
+	// ResourceList is the serialized input ResourceList.
+	ResourceList []byte
+	// Image is the container image of the function.
+	Image string
+}
+
+// EvaluateFunctionResponse is the result of running a function.
+type EvaluateFunctionResponse struct {
+	// ResourceList is the serialized output ResourceList.
+	ResourceList []byte
+	// Log is the stderr output of the function.
+	Log []byte
+}
+
+func (r *EvaluateFunctionRequest) marshal() []byte {
+	var b []byte
+	if len(r.ResourceList) > 0 {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.ResourceList)
+	}
+	if r.Image != "" {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendString(b, r.Image)
+	}
+	return b
+}
+
+func (r *EvaluateFunctionRequest) unmarshal(b []byte) error {
+	return unmarshalBytesFields(b, func(num protowire.Number, v []byte) {
+		switch num {
+		case 1:
+			r.ResourceList = append([]byte(nil), v...)
+		case 2:
+			r.Image = string(v)
+		}
+	})
+}
+
+func (r *EvaluateFunctionResponse) marshal() []byte {
+	var b []byte
+	if len(r.ResourceList) > 0 {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.ResourceList)
+	}
+	if len(r.Log) > 0 {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.Log)
+	}
+	return b
+}
+
+func (r *EvaluateFunctionResponse) unmarshal(b []byte) error {
+	return unmarshalBytesFields(b, func(num protowire.Number, v []byte) {
+		switch num {
+		case 1:
+			r.ResourceList = append([]byte(nil), v...)
+		case 2:
+			r.Log = append([]byte(nil), v...)
+		}
+	})
+}
+
+// unmarshalBytesFields calls set for every length-delimited field in b and
+// skips fields of other types, as proto3 requires for unknown fields.
+func unmarshalBytesFields(b []byte, set func(protowire.Number, []byte)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		set(num, v)
+		b = b[n:]
+	}
+	return nil
+}
+
+type message interface {
+	marshal() []byte
+	unmarshal([]byte) error
+}
+
+// Codec encodes the FunctionEvaluator messages in the protobuf wire format.
+// It must be used by both the server and clients of the service.
+type Codec struct{}
+
+// Marshal implements encoding.Codec.
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(message)
+	if !ok {
+		return nil, fmt.Errorf("unsupported message type %T", v)
+	}
+	return m.marshal(), nil
+}
+
+// Unmarshal implements encoding.Codec.
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(message)
+	if !ok {
+		return fmt.Errorf("unsupported message type %T", v)
+	}
+	return m.unmarshal(data)
+}
+
+// Name implements encoding.Codec. The name matches the default codec, so
+// clients using generated protobuf code are served without changes.
+func (Codec) Name() string {
+	return "proto"
+}
+
+// FunctionEvaluator evaluates functions on behalf of gRPC and HTTP clients.
+type FunctionEvaluator interface {
+	EvaluateFunction(ctx context.Context, req *EvaluateFunctionRequest) (*EvaluateFunctionResponse, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "evaluator.FunctionEvaluator",
+	HandlerType: (*FunctionEvaluator)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EvaluateFunction",
+			Handler:    evaluateFunctionHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func evaluateFunctionHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := &EvaluateFunctionRequest{}
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionEvaluator).EvaluateFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: evaluateFunctionMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionEvaluator).EvaluateFunction(ctx, req.(*EvaluateFunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EvaluateFunction calls the FunctionEvaluator service over conn.
+func EvaluateFunction(ctx context.Context, conn grpc.ClientConnInterface,
+	req *EvaluateFunctionRequest) (*EvaluateFunctionResponse, error) {
+	out := &EvaluateFunctionResponse{}
+	if err := conn.Invoke(ctx, evaluateFunctionMethod, req, out, grpc.ForceCodec(Codec{})); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
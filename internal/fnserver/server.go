@@ -0,0 +1,150 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fnserver serves function evaluation over gRPC, using the
+// protocol of the Porch function runner, and over HTTP.
+package fnserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HTTPEvaluatePath is the path of the HTTP endpoint that evaluates functions.
+const HTTPEvaluatePath = "/v1/evaluate"
+
+// maxHTTPRequestBytes is the maximum size of the body of a request to the
+// HTTP endpoint, so a client can't make the server read an unbounded body
+// into memory.
+var maxHTTPRequestBytes int64 = 32 << 20
+
+// ContainerEvaluator evaluates functions with the local container runtime.
+type ContainerEvaluator struct {
+	ImagePullPolicy fnruntime.ImagePullPolicy
+	// Timeout is how long a function may run. Defaults to the timeout of
+	// fnruntime.ContainerFn.
+	Timeout time.Duration
+}
+
+// EvaluateFunction implements FunctionEvaluator.
+func (e *ContainerEvaluator) EvaluateFunction(ctx context.Context,
+	req *EvaluateFunctionRequest) (*EvaluateFunctionResponse, error) {
+	if req.Image == "" {
+		return nil, status.Error(codes.InvalidArgument, "image must be specified")
+	}
+	image, err := fnruntime.ResolveToImageForCLI(ctx, req.Image)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	fn := &fnruntime.ContainerFn{
+		Ctx:             ctx,
+		Image:           image,
+		ImagePullPolicy: e.ImagePullPolicy,
+		Timeout:         e.Timeout,
+		FnResult:        &fnresult.Result{Image: image},
+	}
+	var out bytes.Buffer
+	if err := fn.Run(bytes.NewReader(req.ResourceList), &out); err != nil {
+		var execErr *fnruntime.ExecError
+		if goerrors.As(err, &execErr) {
+			return nil, status.Errorf(codes.Internal, "failed to evaluate function %q with exit code %d: %s",
+				image, execErr.ExitCode, execErr.Stderr)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to evaluate function %q: %v", image, err)
+	}
+	return &EvaluateFunctionResponse{
+		ResourceList: out.Bytes(),
+		Log:          []byte(fn.FnResult.Stderr),
+	}, nil
+}
+
+// NewGRPCServer returns a gRPC server that serves the FunctionEvaluator
+// service with e.
+func NewGRPCServer(e FunctionEvaluator, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.ForceServerCodec(Codec{}))
+	s := grpc.NewServer(opts...)
+	s.RegisterService(&serviceDesc, e)
+	return s
+}
+
+// httpRequest is the body of a request to the HTTP endpoint. It mirrors
+// EvaluateFunctionRequest, with the ResourceList as a string.
+type httpRequest struct {
+	Image        string `json:"image"`
+	ResourceList string `json:"resourceList"`
+}
+
+// httpResponse is the body of a successful response of the HTTP endpoint.
+type httpResponse struct {
+	ResourceList string `json:"resourceList"`
+	Log          string `json:"log,omitempty"`
+}
+
+// NewHTTPHandler returns a handler that evaluates functions with e for
+// POST requests to HTTPEvaluatePath.
+func NewHTTPHandler(e FunctionEvaluator) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(HTTPEvaluatePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxHTTPRequestBytes+1))
+		if err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if int64(len(body)) > maxHTTPRequestBytes {
+			http.Error(w, fmt.Sprintf("request body exceeds the limit of %d bytes", maxHTTPRequestBytes),
+				http.StatusRequestEntityTooLarge)
+			return
+		}
+		var req httpRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, err := e.EvaluateFunction(r.Context(), &EvaluateFunctionRequest{
+			Image:        req.Image,
+			ResourceList: []byte(req.ResourceList),
+		})
+		if err != nil {
+			code := http.StatusInternalServerError
+			if status.Code(err) == codes.InvalidArgument {
+				code = http.StatusBadRequest
+			}
+			http.Error(w, status.Convert(err).Message(), code)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(httpResponse{
+			ResourceList: string(resp.ResourceList),
+			Log:          string(resp.Log),
+		})
+	})
+	return mux
+}
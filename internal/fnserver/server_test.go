@@ -0,0 +1,156 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fnserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// fakeEvaluator echoes the input ResourceList and logs the image.
+type fakeEvaluator struct{}
+
+func (fakeEvaluator) EvaluateFunction(_ context.Context, req *EvaluateFunctionRequest) (*EvaluateFunctionResponse, error) {
+	if req.Image == "" {
+		return nil, status.Error(codes.InvalidArgument, "image must be specified")
+	}
+	if req.Image == "fail" {
+		return nil, status.Error(codes.Internal, "function failed")
+	}
+	return &EvaluateFunctionResponse{
+		ResourceList: req.ResourceList,
+		Log:          []byte("ran " + req.Image),
+	}, nil
+}
+
+func TestCodec(t *testing.T) {
+	req := &EvaluateFunctionRequest{ResourceList: []byte("kind: ResourceList"), Image: "set-labels:v0.1"}
+	b, err := Codec{}.Marshal(req)
+	require.NoError(t, err)
+
+	// Unknown fields are skipped.
+	b = protowire.AppendTag(b, 3, protowire.VarintType)
+	b = protowire.AppendVarint(b, 42)
+	got := &EvaluateFunctionRequest{}
+	require.NoError(t, Codec{}.Unmarshal(b, got))
+	assert.Equal(t, req, got)
+
+	assert.Error(t, Codec{}.Unmarshal([]byte{0x0a, 0x05, 'a'}, got))
+	_, err = Codec{}.Marshal("not a message")
+	assert.EqualError(t, err, "unsupported message type string")
+}
+
+func TestGRPCServer(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := NewGRPCServer(fakeEvaluator{})
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := context.Background()
+	resp, err := EvaluateFunction(ctx, conn, &EvaluateFunctionRequest{
+		ResourceList: []byte("kind: ResourceList"),
+		Image:        "set-labels:v0.1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "kind: ResourceList", string(resp.ResourceList))
+	assert.Equal(t, "ran set-labels:v0.1", string(resp.Log))
+
+	_, err = EvaluateFunction(ctx, conn, &EvaluateFunctionRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHTTPHandler(t *testing.T) {
+	defer func(limit int64) { maxHTTPRequestBytes = limit }(maxHTTPRequestBytes)
+	maxHTTPRequestBytes = 1024
+
+	s := httptest.NewServer(NewHTTPHandler(fakeEvaluator{}))
+	defer s.Close()
+
+	tests := map[string]struct {
+		method       string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		"evaluates function": {
+			method:       http.MethodPost,
+			body:         `{"image": "set-labels:v0.1", "resourceList": "kind: ResourceList"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"resourceList":"kind: ResourceList","log":"ran set-labels:v0.1"}` + "\n",
+		},
+		"missing image": {
+			method:       http.MethodPost,
+			body:         `{"resourceList": "kind: ResourceList"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "image must be specified\n",
+		},
+		"function failure": {
+			method:       http.MethodPost,
+			body:         `{"image": "fail"}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "function failed\n",
+		},
+		"invalid body": {
+			method:       http.MethodPost,
+			body:         `image: foo`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"body too large": {
+			method:       http.MethodPost,
+			body:         `{"image": "set-labels:v0.1", "resourceList": "` + strings.Repeat("a", 1024) + `"}`,
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedBody: "request body exceeds the limit of 1024 bytes\n",
+		},
+		"wrong method": {
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: "method not allowed\n",
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, s.URL+HTTPEvaluatePath, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, string(body))
+			}
+		})
+	}
+}
@@ -28,10 +28,13 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/util/httputil"
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
 )
 
@@ -39,6 +42,24 @@ import (
 // for remote repos.  Defaults to UserHomeDir/.kpt/repos if unspecified.
 const RepoCacheDirEnv = "KPT_CACHE_DIR"
 
+// CredentialHelperEnv is the name of the environment variable that sets the
+// git credential helper used to authenticate to remote repos, replacing the
+// helpers in the git config. The value has the syntax of the git
+// credential.helper setting: the name of a git-credential-<name> executable,
+// an absolute path, or a shell snippet starting with '!'.
+const CredentialHelperEnv = "KPT_GIT_CREDENTIAL_HELPER"
+
+// CredentialHelpers maps URL prefixes of remote repos, e.g.
+// "https://github.com/my-org", to the git credential helpers used for them.
+// They take precedence over the helper set with CredentialHelperEnv.
+var CredentialHelpers map[string]string
+
+// Mirrors maps URL prefixes of remote repos, e.g. "https://github.com", to
+// the URL prefixes of the mirrors that they are fetched from instead, with
+// the git url.<base>.insteadOf setting. The upstream of packages still
+// records the original URL.
+var Mirrors map[string]string
+
 // NewLocalGitRunner returns a new GitLocalRunner for a local package.
 func NewLocalGitRunner(pkg string) (*GitLocalRunner, error) {
 	const op errors.Op = "gitutil.NewLocalGitRunner"
@@ -97,15 +118,38 @@ func (g *GitLocalRunner) RunVerbose(ctx context.Context, command string, args ..
 func (g *GitLocalRunner) run(ctx context.Context, verbose bool, command string, args ...string) (RunResult, error) {
 	const op errors.Op = "gitutil.run"
 
-	fullArgs := append(
-		[]string{"-c", "user.name=Kpt", "-c", "user.email=kpt@kpt.dev", command},
-		args...,
-	)
+	fullArgs := []string{"-c", "user.name=Kpt", "-c", "user.email=kpt@kpt.dev"}
+	if helper := os.Getenv(CredentialHelperEnv); helper != "" {
+		// The empty value clears the helpers from the git config.
+		fullArgs = append(fullArgs, "-c", "credential.helper=", "-c", "credential.helper="+helper)
+	}
+	urls := make([]string, 0, len(CredentialHelpers))
+	for url := range CredentialHelpers {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	for _, url := range urls {
+		key := fmt.Sprintf("credential.%s.helper", url)
+		fullArgs = append(fullArgs, "-c", key+"=", "-c", key+"="+CredentialHelpers[url])
+	}
+	prefixes := make([]string, 0, len(Mirrors))
+	for prefix := range Mirrors {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		fullArgs = append(fullArgs, "-c", fmt.Sprintf("url.%s.insteadOf=%s", Mirrors[prefix], prefix))
+	}
+	fullArgs = append(fullArgs, command)
+	fullArgs = append(fullArgs, args...)
 	cmd := exec.CommandContext(ctx, g.gitPath, fullArgs...)
 	cmd.Dir = g.Dir
 	// Disable git prompting the user for credentials.
 	cmd.Env = append(os.Environ(),
 		"GIT_TERMINAL_PROMPT=0")
+	// The proxy of the kpt config is only passed to git, so it isn't used
+	// for the requests to the Kubernetes API server.
+	cmd.Env = append(cmd.Env, httputil.ProxyEnv()...)
 	pr := printer.FromContextOrDie(ctx)
 	cmdStdout := &bytes.Buffer{}
 	cmdStderr := &bytes.Buffer{}
@@ -162,6 +206,9 @@ func NewGitUpstreamRepo(ctx context.Context, uri string, opts ...NewGitUpstreamR
 	if g.fetchedRefs == nil {
 		g.fetchedRefs = map[string]bool{}
 	}
+	if err := offline.Check(fmt.Sprintf("fetching repository %q", uri)); err != nil {
+		return nil, errors.E(op, errors.Repo(uri), err)
+	}
 	if err := g.updateRefs(ctx); err != nil {
 		return nil, errors.E(op, errors.Repo(uri), err)
 	}
@@ -89,6 +89,62 @@ func TestLocalGitRunner(t *testing.T) {
 	}
 }
 
+func TestLocalGitRunner_credentialHelper(t *testing.T) {
+	dir := t.TempDir()
+	runner, err := NewLocalGitRunner(dir)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	_, err = runner.Run(fake.CtxWithDefaultPrinter(), "init", "--initial-branch=main")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	_, err = runner.Run(fake.CtxWithDefaultPrinter(), "config", "credential.helper", "store")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// The helper from the environment replaces the helpers from the config.
+	t.Setenv(CredentialHelperEnv, "!echo password=token")
+	rr, err := runner.Run(fake.CtxWithDefaultPrinter(), "config", "--get-all", "credential.helper")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, []string{"store", "", "!echo password=token"}, strings.Split(strings.TrimSpace(rr.Stdout), "\n"))
+
+	// Helpers for URL prefixes are set in addition.
+	defer func(helpers map[string]string) { CredentialHelpers = helpers }(CredentialHelpers)
+	CredentialHelpers = map[string]string{"https://github.com/my-org": "!echo password=org-token"}
+	rr, err = runner.Run(fake.CtxWithDefaultPrinter(), "config", "--get-urlmatch", "credential.helper", "https://github.com/my-org/repo")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "!echo password=org-token", strings.TrimSpace(rr.Stdout))
+}
+
+func TestLocalGitRunner_mirrors(t *testing.T) {
+	dir := t.TempDir()
+	runner, err := NewLocalGitRunner(dir)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer func(mirrors map[string]string) { Mirrors = mirrors }(Mirrors)
+	Mirrors = map[string]string{"https://github.com": "https://git.example.com/github"}
+	_, err = runner.Run(fake.CtxWithDefaultPrinter(), "init", "--initial-branch=main")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	_, err = runner.Run(fake.CtxWithDefaultPrinter(), "remote", "add", "origin", "https://github.com/kptdev/kpt")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	rr, err := runner.Run(fake.CtxWithDefaultPrinter(), "remote", "get-url", "origin")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "https://git.example.com/github/kptdev/kpt", strings.TrimSpace(rr.Stdout))
+}
+
 func TestNewGitUpstreamRepo_noRepo(t *testing.T) {
 	dir := t.TempDir()
 
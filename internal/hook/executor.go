@@ -83,7 +83,7 @@ func (e *Executor) fnChain(ctx context.Context, fns []kptfilev1.Function) ([]kio
 		var err error
 		var runner kio.Filter
 		fn := fns[i]
-		if fn.Exec != "" && !e.RunnerOptions.AllowExec {
+		if fn.Exec != "" && !fnruntime.IsBuiltinExec(fn.Exec) && !e.RunnerOptions.AllowExec {
 			return nil, ErrAllowedExecNotSpecified
 		}
 		opts := e.RunnerOptions
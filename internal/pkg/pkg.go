@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/errors"
 	"github.com/GoogleContainerTools/kpt/internal/types"
 	"github.com/GoogleContainerTools/kpt/internal/util/git"
+	"github.com/GoogleContainerTools/kpt/internal/util/ignore"
 	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	rgfilev1alpha1 "github.com/GoogleContainerTools/kpt/pkg/api/resourcegroup/v1alpha1"
@@ -387,6 +388,20 @@ func (p *Pkg) DirectSubpackages() ([]*Pkg, error) {
 	return subPkgs, nil
 }
 
+// RelativePackage returns the package at the slash-separated path relPath
+// relative to p, e.g. a sibling package '../network'. Like for subpackages,
+// the display path of the returned package is relative to the root package.
+func (p *Pkg) RelativePackage(relPath string) (*Pkg, error) {
+	relPkg, err := New(p.fsys, filepath.Join(p.UniquePath.String(), filepath.FromSlash(relPath)))
+	if err != nil {
+		return nil, err
+	}
+	if err := p.adjustDisplayPathForSubpkg(relPkg); err != nil {
+		return nil, err
+	}
+	return relPkg, nil
+}
+
 // adjustDisplayPathForSubpkg adjusts the display path of subPkg relative to the RootPkgUniquePath
 // subPkg also inherits the RootPkgUniquePath value from parent package p
 func (p *Pkg) adjustDisplayPathForSubpkg(subPkg *Pkg) error {
@@ -534,6 +549,12 @@ func (p *Pkg) LocalResources() (resources []*yaml.RNode, err error) {
 		return nil, nil
 	}
 
+	// the paths in the .kptignore file of the package are not read.
+	ignored, err := ignore.Load(p.fsys, p.UniquePath.String())
+	if err != nil {
+		return nil, errors.E(op, p.UniquePath, err)
+	}
+
 	pkgReader := &kio.LocalPackageReader{
 		PackagePath:        string(p.UniquePath),
 		PackageFileName:    kptfilev1.KptFileName,
@@ -543,6 +564,7 @@ func (p *Pkg) LocalResources() (resources []*yaml.RNode, err error) {
 		SetAnnotations: map[string]string{
 			pkgPathAnnotation: string(p.UniquePath),
 		},
+		FileSkipFunc:    ignored.SkipFile,
 		WrapBareSeqNode: true,
 		FileSystem: filesys.FileSystemOrOnDisk{
 			FileSystem: p.fsys,
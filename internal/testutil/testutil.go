@@ -138,6 +138,14 @@ func KptfileAwarePkgEqual(t *testing.T, pkg1, pkg2 string, addMergeCommentsToSou
 			t.FailNow()
 		}
 
+		// The digests are derived from the package content, which is
+		// compared separately.
+		for _, kf := range []*kptfilev1.KptFile{pkg1kf, pkg2kf} {
+			if kf.UpstreamLock != nil {
+				kf.UpstreamLock.Digest = ""
+			}
+		}
+
 		equal, err := kptfileutil.Equal(pkg1kf, pkg2kf)
 		if !assert.NoError(t, err) {
 			t.FailNow()
@@ -272,6 +280,19 @@ func (g *TestGitRepo) AssertKptfile(t *testing.T, cloned string, kpkg kptfilev1.
 	if !assert.NoError(t, err) {
 		return false
 	}
+	// The digest depends on the package content, so it is only compared
+	// if the expected Kptfile specifies one.
+	if kpkg.UpstreamLock != nil && kpkg.UpstreamLock.Digest == "" {
+		actual, err := pkg.DecodeKptfile(bytes.NewReader(b))
+		if !assert.NoError(t, err) {
+			return false
+		}
+		if actual.UpstreamLock != nil {
+			lock := *kpkg.UpstreamLock
+			lock.Digest = actual.UpstreamLock.Digest
+			kpkg.UpstreamLock = &lock
+		}
+	}
 	var res bytes.Buffer
 	d := yaml.NewEncoder(&res)
 	if !assert.NoError(t, d.Encode(kpkg)) {
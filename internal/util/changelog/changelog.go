@@ -0,0 +1,439 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package changelog summarizes the upstream changes of a package between
+// two versions.
+package changelog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Changelog summarizes the changes to a package directory in its upstream
+// repository between two versions.
+type Changelog struct {
+	Repo       string `json:"repo"`
+	Directory  string `json:"directory"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	FromCommit string `json:"fromCommit"`
+	ToCommit   string `json:"toCommit"`
+
+	// Commits are the commits that changed the package directory, newest
+	// first. Merge commits are left out.
+	Commits []Commit `json:"commits,omitempty"`
+
+	// Added, Removed and Modified identify the resources of the package
+	// that changed, e.g. `Deployment default/web`.
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+
+	// Pipeline describes the changes to the functions of the pipelines of
+	// the package and its subpackages.
+	Pipeline []string `json:"pipeline,omitempty"`
+}
+
+// Commit is a commit that changed the package directory.
+type Commit struct {
+	SHA     string `json:"sha"`
+	Author  string `json:"author"`
+	Subject string `json:"subject"`
+}
+
+// IsEmpty returns true if nothing changed in the package directory.
+func (c *Changelog) IsEmpty() bool {
+	return len(c.Commits) == 0 && len(c.Added) == 0 && len(c.Removed) == 0 &&
+		len(c.Modified) == 0 && len(c.Pipeline) == 0
+}
+
+// Markdown renders the changelog as markdown, for the body of a commit
+// message or a pull request.
+func (c *Changelog) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Upstream changes to %s in %s from %s to %s\n", c.Directory, c.Repo, c.From, c.To)
+	if c.IsEmpty() {
+		b.WriteString("\nNo changes.\n")
+		return b.String()
+	}
+	if len(c.Commits) > 0 {
+		b.WriteString("\nCommits:\n")
+		for _, commit := range c.Commits {
+			fmt.Fprintf(&b, "- %s %s (%s)\n", shortSHA(commit.SHA), commit.Subject, commit.Author)
+		}
+	}
+	if len(c.Added)+len(c.Removed)+len(c.Modified) > 0 {
+		b.WriteString("\nResources:\n")
+		for _, r := range c.Added {
+			fmt.Fprintf(&b, "- added %s\n", r)
+		}
+		for _, r := range c.Removed {
+			fmt.Fprintf(&b, "- removed %s\n", r)
+		}
+		for _, r := range c.Modified {
+			fmt.Fprintf(&b, "- modified %s\n", r)
+		}
+	}
+	if len(c.Pipeline) > 0 {
+		b.WriteString("\nPipeline:\n")
+		for _, p := range c.Pipeline {
+			fmt.Fprintf(&b, "- %s\n", p)
+		}
+	}
+	return b.String()
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// Generate returns the changelog of the directory of the repo between the
+// versions from and to. Like for `kpt pkg get`, a version can be a
+// branch, a tag, a tag prefixed with the directory or a commit.
+func Generate(ctx context.Context, repo, directory, from, to string) (*Changelog, error) {
+	const op errors.Op = "changelog.Generate"
+	upstream, err := gitutil.NewGitUpstreamRepo(ctx, repo)
+	if err != nil {
+		return nil, errors.E(op, errors.Repo(repo), err)
+	}
+	directory = path.Clean("/" + directory)
+	from = packageRef(upstream, directory, from)
+	to = packageRef(upstream, directory, to)
+	dir, err := upstream.GetRepo(ctx, []string{from, to})
+	if err != nil {
+		return nil, errors.E(op, errors.Repo(repo), err)
+	}
+	git, err := gitutil.NewLocalGitRunner(dir)
+	if err != nil {
+		return nil, errors.E(op, errors.Repo(repo), err)
+	}
+	// the refs are fetched without their history, which is needed to find
+	// the commits between them.
+	if rr, err := git.Run(ctx, "rev-parse", "--is-shallow-repository"); err == nil && strings.TrimSpace(rr.Stdout) == "true" {
+		if _, err := git.RunVerbose(ctx, "fetch", "--unshallow", "origin"); err != nil {
+			return nil, errors.E(op, errors.Git, errors.Repo(repo), err)
+		}
+	}
+
+	c := &Changelog{
+		Repo:      repo,
+		Directory: directory,
+		From:      from,
+		To:        to,
+	}
+	if c.FromCommit, err = resolveCommit(ctx, upstream, git, from); err != nil {
+		return nil, errors.E(op, errors.Repo(repo), err)
+	}
+	if c.ToCommit, err = resolveCommit(ctx, upstream, git, to); err != nil {
+		return nil, errors.E(op, errors.Repo(repo), err)
+	}
+	// git pathspecs are relative to the root of the repository.
+	pathspec := strings.TrimPrefix(directory, "/")
+	if pathspec == "" {
+		pathspec = "."
+	}
+	if c.Commits, err = commits(ctx, git, c.FromCommit, c.ToCommit, pathspec); err != nil {
+		return nil, errors.E(op, errors.Repo(repo), err)
+	}
+
+	fromPkg, err := readPackage(ctx, git, c.FromCommit, pathspec)
+	if err != nil {
+		return nil, errors.E(op, errors.Repo(repo), err)
+	}
+	toPkg, err := readPackage(ctx, git, c.ToCommit, pathspec)
+	if err != nil {
+		return nil, errors.E(op, errors.Repo(repo), err)
+	}
+	c.Added, c.Removed, c.Modified = diffResources(fromPkg.resources, toPkg.resources)
+	c.Pipeline = diffPipelines(fromPkg.pipelines, toPkg.pipelines)
+	return c, nil
+}
+
+// packageRef returns the tag for the directory if the upstream repository
+// has a tag like <directory>/<ref>, like `kpt pkg get` does. Otherwise ref
+// is returned.
+func packageRef(upstream *gitutil.GitUpstreamRepo, directory, ref string) string {
+	ps := strings.Split(strings.TrimPrefix(directory, "/"), "/")
+	for len(ps) != 0 && ps[0] != "" {
+		p := path.Join(path.Join(ps...), ref)
+		if _, found := upstream.ResolveTag(p); found {
+			return p
+		}
+		ps = ps[:len(ps)-1]
+	}
+	return ref
+}
+
+// resolveCommit returns the commit that ref refers to.
+func resolveCommit(ctx context.Context, upstream *gitutil.GitUpstreamRepo, git *gitutil.GitLocalRunner, ref string) (string, error) {
+	if commit, found := upstream.ResolveRef(ref); found {
+		return commit, nil
+	}
+	rr, err := git.Run(ctx, "rev-parse", "--verify", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown version %q", ref)
+	}
+	return strings.TrimSpace(rr.Stdout), nil
+}
+
+// commits returns the commits that changed pathspec between from and to.
+func commits(ctx context.Context, git *gitutil.GitLocalRunner, from, to, pathspec string) ([]Commit, error) {
+	rr, err := git.Run(ctx, "log", "--no-merges", "--format=%H%x09%an%x09%s", from+".."+to, "--", pathspec)
+	if err != nil {
+		return nil, err
+	}
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(rr.Stdout), "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		commits = append(commits, Commit{SHA: parts[0], Author: parts[1], Subject: parts[2]})
+	}
+	return commits, nil
+}
+
+// pkgContent is the content of a package at a commit.
+type pkgContent struct {
+	// resources are the resources of the package and its subpackages by
+	// their identifier, as normalized yaml.
+	resources map[string]string
+	// pipelines are the pipelines of the package and its subpackages, by
+	// the directory of their Kptfile relative to the package.
+	pipelines map[string]*kptfilev1.Pipeline
+}
+
+// readPackage reads the resources and the pipelines of the directory
+// pathspec at commit.
+func readPackage(ctx context.Context, git *gitutil.GitLocalRunner, commit, pathspec string) (*pkgContent, error) {
+	c := &pkgContent{
+		resources: map[string]string{},
+		pipelines: map[string]*kptfilev1.Pipeline{},
+	}
+	rr, err := git.Run(ctx, "ls-tree", "-r", "--name-only", commit, "--", pathspec)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range strings.Split(strings.TrimSpace(rr.Stdout), "\n") {
+		base := path.Base(file)
+		ext := path.Ext(file)
+		if base != kptfilev1.KptFileName && ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		show, err := git.Run(ctx, "show", commit+":"+file)
+		if err != nil {
+			return nil, err
+		}
+		rel := strings.TrimPrefix(file, strings.TrimSuffix(pathspec, ".")+"/")
+		if pathspec == "." {
+			rel = file
+		}
+		if base == kptfilev1.KptFileName {
+			kf := &kptfilev1.KptFile{}
+			if err := yaml.Unmarshal([]byte(show.Stdout), kf); err != nil {
+				return nil, fmt.Errorf("invalid Kptfile %q at %s: %w", file, shortSHA(commit), err)
+			}
+			c.pipelines[path.Dir(rel)] = kf.Pipeline
+			continue
+		}
+		nodes, err := (&kio.ByteReader{Reader: bytes.NewBufferString(show.Stdout), OmitReaderAnnotations: true}).Read()
+		if err != nil {
+			// files that aren't KRM, e.g. other yaml files, are not
+			// resources of the package.
+			continue
+		}
+		for _, n := range nodes {
+			if n.GetKind() == "" || n.GetKind() == kptfilev1.KptFileKind {
+				continue
+			}
+			_ = n.PipeE(yaml.ClearAnnotation(kioutil.IndexAnnotation))
+			s, err := n.String()
+			if err != nil {
+				return nil, err
+			}
+			c.resources[resourceID(n)] = s
+		}
+	}
+	return c, nil
+}
+
+func resourceID(n *yaml.RNode) string {
+	name := n.GetName()
+	if ns := n.GetNamespace(); ns != "" {
+		name = ns + "/" + name
+	}
+	return n.GetKind() + " " + name
+}
+
+// diffResources returns the sorted identifiers of the resources that were
+// added, removed and modified between from and to.
+func diffResources(from, to map[string]string) (added, removed, modified []string) {
+	for id, s := range to {
+		prev, found := from[id]
+		switch {
+		case !found:
+			added = append(added, id)
+		case prev != s:
+			modified = append(modified, id)
+		}
+	}
+	for id := range from {
+		if _, found := to[id]; !found {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(modified)
+	return added, removed, modified
+}
+
+// diffPipelines describes the changes to the functions of the pipelines
+// between from and to.
+func diffPipelines(from, to map[string]*kptfilev1.Pipeline) []string {
+	dirs := map[string]bool{}
+	for d := range from {
+		dirs[d] = true
+	}
+	for d := range to {
+		dirs[d] = true
+	}
+	var sorted []string
+	for d := range dirs {
+		sorted = append(sorted, d)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, d := range sorted {
+		var fromMutators, toMutators, fromValidators, toValidators []kptfilev1.Function
+		if pl := from[d]; pl != nil {
+			fromMutators, fromValidators = pl.Mutators, pl.Validators
+		}
+		if pl := to[d]; pl != nil {
+			toMutators, toValidators = pl.Mutators, pl.Validators
+		}
+		suffix := ""
+		if d != "." {
+			suffix = fmt.Sprintf(" of %s", d)
+		}
+		changes = append(changes, diffFunctions("mutator", suffix, fromMutators, toMutators)...)
+		changes = append(changes, diffFunctions("validator", suffix, fromValidators, toValidators)...)
+	}
+	return changes
+}
+
+// diffFunctions describes the functions that were added, removed, moved to
+// another version or reconfigured between from and to. Functions are
+// matched by their name, or by their image without the version.
+func diffFunctions(kind, suffix string, from, to []kptfilev1.Function) []string {
+	fromByKey := map[string]kptfilev1.Function{}
+	for _, f := range from {
+		fromByKey[functionKey(f)] = f
+	}
+	toKeys := map[string]bool{}
+	var changes []string
+	for _, f := range to {
+		key := functionKey(f)
+		toKeys[key] = true
+		prev, found := fromByKey[key]
+		if !found {
+			changes = append(changes, fmt.Sprintf("added %s %s%s", kind, functionDisplay(f), suffix))
+			continue
+		}
+		if prevVersion, version := functionVersion(prev), functionVersion(f); prevVersion != version {
+			changes = append(changes, fmt.Sprintf("updated %s %s%s from %s to %s",
+				kind, key, suffix, orNone(prevVersion), orNone(version)))
+		}
+		if prev.ConfigPath != f.ConfigPath || !reflect.DeepEqual(prev.ConfigMap, f.ConfigMap) ||
+			!reflect.DeepEqual(prev.Selectors, f.Selectors) || !reflect.DeepEqual(prev.Exclusions, f.Exclusions) {
+			changes = append(changes, fmt.Sprintf("changed the config of %s %s%s", kind, key, suffix))
+		}
+	}
+	for _, f := range from {
+		if !toKeys[functionKey(f)] {
+			changes = append(changes, fmt.Sprintf("removed %s %s%s", kind, functionDisplay(f), suffix))
+		}
+	}
+	return changes
+}
+
+// functionKey identifies a function of a pipeline across versions.
+func functionKey(f kptfilev1.Function) string {
+	if f.Name != "" {
+		return f.Name
+	}
+	switch {
+	case f.Image != "":
+		return imageName(f.Image)
+	case f.Composite != "":
+		return imageName(f.Composite)
+	default:
+		return f.Exec
+	}
+}
+
+func functionDisplay(f kptfilev1.Function) string {
+	switch {
+	case f.Image != "":
+		return f.Image
+	case f.Composite != "":
+		return f.Composite
+	default:
+		return f.Exec
+	}
+}
+
+// functionVersion returns the tag or digest of the image of f.
+func functionVersion(f kptfilev1.Function) string {
+	image := f.Image
+	if image == "" {
+		image = f.Composite
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(image, imageName(image)), ":")
+}
+
+// imageName returns image without its tag or digest.
+func imageName(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i]
+	}
+	return image
+}
+
+func orNone(version string) string {
+	if version == "" {
+		return "no version"
+	}
+	return version
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changelog
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	kptfileV1 = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: app
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-namespace:v0.3
+    configMap:
+      namespace: app
+  - image: gcr.io/kpt-fn/set-labels:v0.1
+`
+	kptfileV2 = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: app
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-namespace:v0.4
+    configMap:
+      namespace: app
+  validators:
+  - image: gcr.io/kpt-fn/kubeval:v0.3
+`
+	deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: app
+spec:
+  replicas: %s
+`
+	configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: app
+`
+	service = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: app
+`
+)
+
+func TestGenerate(t *testing.T) {
+	t.Setenv(gitutil.RepoCacheDirEnv, t.TempDir())
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Jane", "GIT_AUTHOR_EMAIL=jane@example.com",
+			"GIT_COMMITTER_NAME=Jane", "GIT_COMMITTER_EMAIL=jane@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(file, content string) {
+		p := filepath.Join(repo, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+	}
+	commit := func(msg string) {
+		git("add", "-A")
+		git("commit", "-q", "-m", msg)
+	}
+
+	git("init", "-q", "-b", "main")
+	write("app/Kptfile", kptfileV1)
+	write("app/deployment.yaml", strings.Replace(deployment, "%s", "1", 1))
+	write("app/configmap.yaml", configMap)
+	commit("Add app")
+	git("tag", "app/v1")
+
+	write("app/deployment.yaml", strings.Replace(deployment, "%s", "3", 1))
+	commit("Scale web")
+	write("other/README.md", "not the package")
+	commit("Unrelated change")
+	require.NoError(t, os.Remove(filepath.Join(repo, "app/configmap.yaml")))
+	write("app/service.yaml", service)
+	write("app/Kptfile", kptfileV2)
+	commit("Expose web")
+	git("tag", "app/v2")
+
+	c, err := Generate(fake.CtxWithDefaultPrinter(), repo, "app", "v1", "v2")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/app", c.Directory)
+	assert.Equal(t, "app/v1", c.From)
+	assert.Equal(t, "app/v2", c.To)
+	var subjects []string
+	for _, commit := range c.Commits {
+		subjects = append(subjects, commit.Subject)
+		assert.Equal(t, "Jane", commit.Author)
+	}
+	assert.Equal(t, []string{"Expose web", "Scale web"}, subjects)
+	assert.Equal(t, []string{"Service app/web"}, c.Added)
+	assert.Equal(t, []string{"ConfigMap app/config"}, c.Removed)
+	assert.Equal(t, []string{"Deployment app/web"}, c.Modified)
+	assert.Equal(t, []string{
+		"updated mutator gcr.io/kpt-fn/set-namespace from v0.3 to v0.4",
+		"removed mutator gcr.io/kpt-fn/set-labels:v0.1",
+		"added validator gcr.io/kpt-fn/kubeval:v0.3",
+	}, c.Pipeline)
+
+	md := c.Markdown()
+	assert.Contains(t, md, "Upstream changes to /app in "+repo+" from app/v1 to app/v2\n")
+	assert.Contains(t, md, "\nResources:\n- added Service app/web\n- removed ConfigMap app/config\n- modified Deployment app/web\n")
+
+	c, err = Generate(fake.CtxWithDefaultPrinter(), repo, "app", "v2", "main")
+	require.NoError(t, err)
+	assert.True(t, c.IsEmpty())
+	assert.Contains(t, c.Markdown(), "No changes.")
+
+	_, err = Generate(fake.CtxWithDefaultPrinter(), repo, "app", "v1", "v3")
+	assert.Error(t, err)
+}
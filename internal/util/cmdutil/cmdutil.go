@@ -58,6 +58,14 @@ func PrintErrorStacktrace() bool {
 // StackOnError if true, will print a stack trace on failure.
 var StackOnError bool
 
+const (
+	TextErrorFormat = "text"
+	JSONErrorFormat = "json"
+)
+
+// ErrorFormat is the format errors are printed in: text or json.
+var ErrorFormat = TextErrorFormat
+
 // WriteFnOutput writes the output resources of function commands to provided destination
 func WriteFnOutput(dest, content string, fromStdin bool, w io.Writer) error {
 	r := strings.NewReader(content)
@@ -118,7 +126,11 @@ func CheckDirectoryNotPresent(outDir string) error {
 }
 
 func GetKeywordsFromFlag(cmd *cobra.Command) []string {
-	flagVal := cmd.Flag("keywords").Value.String()
+	flag := cmd.Flag("keywords")
+	if flag == nil {
+		return nil
+	}
+	flagVal := flag.Value.String()
 	flagVal = strings.TrimPrefix(flagVal, "[")
 	flagVal = strings.TrimSuffix(flagVal, "]")
 	splitted := strings.Split(flagVal, ",")
@@ -132,11 +144,20 @@ func GetKeywordsFromFlag(cmd *cobra.Command) []string {
 	return trimmed
 }
 
+// fnTypeFromFlag returns the value of the type flag, or an empty string
+// if the command doesn't have one.
+func fnTypeFromFlag(cmd *cobra.Command) string {
+	if flag := cmd.Flag("type"); flag != nil {
+		return flag.Value.String()
+	}
+	return ""
+}
+
 // SuggestFunctions looks for functions from kpt curated catalog list as well as the Porch
 // orchestrator to suggest functions.
 func SuggestFunctions(cmd *cobra.Command) []string {
 	matchers := []function.Matcher{
-		function.TypeMatcher{FnType: cmd.Flag("type").Value.String()},
+		function.TypeMatcher{FnType: fnTypeFromFlag(cmd)},
 		function.KeywordsMatcher{Keywords: GetKeywordsFromFlag(cmd)},
 	}
 	functions := DiscoverFunctions(cmd)
@@ -148,7 +169,7 @@ func SuggestFunctions(cmd *cobra.Command) []string {
 // can later help users to select functions.
 func SuggestKeywords(cmd *cobra.Command) []string {
 	functions := DiscoverFunctions(cmd)
-	matched := function.MatchFunctions(functions, function.TypeMatcher{FnType: cmd.Flag("type").Value.String()})
+	matched := function.MatchFunctions(functions, function.TypeMatcher{FnType: fnTypeFromFlag(cmd)})
 	return porch.UnifyKeywords(matched)
 }
 
@@ -159,12 +180,21 @@ func DiscoverFunctions(cmd *cobra.Command) []v1alpha1.Function {
 }
 
 // fetchCatalogFunctions returns the list of latest function images from catalog.kpt.dev.
+// The catalog is cached for a day, and the cached catalog is used if it can't
+// be fetched, so that completion stays fast and works offline.
 func fetchCatalogFunctions() []v1alpha1.Function {
+	cached, stale, err := readCachedCatalog()
+	if err == nil && !stale {
+		return parseFunctions(cached)
+	}
 	content, err := httputil.FetchContent(FunctionsCatalogURL)
-	if err != nil {
-		return nil
+	if err == nil {
+		if fns := parseFunctions(content); len(fns) > 0 {
+			writeCachedCatalog(content)
+			return fns
+		}
 	}
-	return parseFunctions(content)
+	return parseFunctions(cached)
 }
 
 // fnName -> v<major>.<minor> -> catalogEntry
@@ -0,0 +1,153 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// catalogCacheFile is the name of the file the functions catalog is
+	// cached in, inside the kpt cache directory.
+	catalogCacheFile = "catalog-v2.json"
+
+	// catalogCacheTTL is how long the cached functions catalog is used
+	// before it is fetched again.
+	catalogCacheTTL = 24 * time.Hour
+
+	// packageSearchDepth is how many directory levels below a candidate
+	// directory are searched for a Kptfile when completing package paths.
+	packageSearchDepth = 3
+)
+
+var errPackageFound = errors.New("package found")
+
+// CompletePackagePaths completes the first argument of a command to the
+// local directories that are, or contain, kpt packages. Directories are
+// completed with a trailing slash, so that subpackages can be completed
+// in turn.
+func CompletePackagePaths(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	dir, prefix := filepath.Split(toComplete)
+	readDir := dir
+	if readDir == "" {
+		readDir = "."
+	}
+	entries, err := os.ReadDir(readDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var paths []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		// Hidden directories, e.g. .git, are only completed if asked for.
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+		p := filepath.Join(readDir, name)
+		if isPackage(p) {
+			paths = append(paths, dir+name+"/\tkpt package")
+		} else if containsPackage(p) {
+			paths = append(paths, dir+name+"/")
+		}
+	}
+	return paths, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
+
+func isPackage(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, kptfilev1.KptFileName))
+	return err == nil
+}
+
+// containsPackage returns true if there is a Kptfile at most
+// packageSearchDepth levels below dir.
+func containsPackage(dir string) bool {
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || p == dir {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if isPackage(p) {
+			return errPackageFound
+		}
+		rel, _ := filepath.Rel(dir, p)
+		if strings.Count(rel, string(filepath.Separator))+1 >= packageSearchDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return err == errPackageFound
+}
+
+// catalogCachePath returns the path of the cached functions catalog: in
+// the directory set with KPT_CACHE_DIR, or UserHomeDir/.kpt.
+func catalogCachePath() (string, error) {
+	if dir := os.Getenv(gitutil.RepoCacheDirEnv); dir != "" {
+		return filepath.Join(dir, catalogCacheFile), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kpt", catalogCacheFile), nil
+}
+
+// readCachedCatalog returns the content of the cached functions catalog,
+// and whether it is older than catalogCacheTTL.
+func readCachedCatalog() (content string, stale bool, err error) {
+	p, err := catalogCachePath()
+	if err != nil {
+		return "", false, err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return "", false, err
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return "", false, err
+	}
+	return string(b), time.Since(info.ModTime()) > catalogCacheTTL, nil
+}
+
+// writeCachedCatalog caches the functions catalog. Failures are ignored,
+// the catalog is fetched again next time.
+func writeCachedCatalog(content string) {
+	p, err := catalogCachePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(p, []byte(content), 0600)
+}
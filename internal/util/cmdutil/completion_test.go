@@ -0,0 +1,113 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/function"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletePackagePaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{
+		"app",
+		"app/sub",
+		"blueprints/nested/db",
+		"docs",
+		"deep/a/b/c/pkg",
+		".hidden",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0700))
+	}
+	for _, p := range []string{"app", "app/sub", "blueprints/nested/db", "deep/a/b/c/pkg", ".hidden"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, p, "Kptfile"), nil, 0600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file.yaml"), nil, 0600))
+
+	testCases := map[string]struct {
+		toComplete string
+		args       []string
+		expected   []string
+	}{
+		"packages and directories with packages": {
+			toComplete: dir + "/",
+			expected: []string{
+				dir + "/app/\tkpt package",
+				dir + "/blueprints/",
+			},
+		},
+		"prefix": {
+			toComplete: dir + "/b",
+			expected:   []string{dir + "/blueprints/"},
+		},
+		"subpackages": {
+			toComplete: dir + "/app/",
+			expected:   []string{dir + "/app/sub/\tkpt package"},
+		},
+		"hidden directories": {
+			toComplete: dir + "/.",
+			expected:   []string{dir + "/.hidden/\tkpt package"},
+		},
+		"only the first argument": {
+			toComplete: dir + "/",
+			args:       []string{"app"},
+		},
+		"missing directory": {
+			toComplete: dir + "/missing/",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			paths, directive := CompletePackagePaths(&cobra.Command{}, tc.args, tc.toComplete)
+			assert.Equal(t, tc.expected, paths)
+			assert.NotZero(t, directive&cobra.ShellCompDirectiveNoFileComp)
+		})
+	}
+}
+
+func TestFetchCatalogFunctionsCached(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(gitutil.RepoCacheDirEnv, dir)
+	p := filepath.Join(dir, catalogCacheFile)
+	require.NoError(t, os.WriteFile(p, []byte(`{
+  "set-labels": {
+    "v0.1": {
+      "LatestPatchVersion": "v0.1.5",
+      "Types": ["mutator"]
+    }
+  }
+}`), 0600))
+
+	content, stale, err := readCachedCatalog()
+	require.NoError(t, err)
+	assert.False(t, stale)
+	assert.Contains(t, content, "set-labels")
+	assert.Equal(t, []string{"set-labels:v0.1.5"}, function.GetNames(fetchCatalogFunctions()))
+
+	old := time.Now().Add(-2 * catalogCacheTTL)
+	require.NoError(t, os.Chtimes(p, old, old))
+	_, stale, err = readCachedCatalog()
+	require.NoError(t, err)
+	assert.True(t, stale)
+}
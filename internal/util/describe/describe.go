@@ -0,0 +1,248 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package describe summarizes the Kptfile, upstream, pipeline, subpackages
+// and resources of a local package.
+package describe
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Description describes a package.
+type Description struct {
+	Name string `json:"name"`
+	// Path is the path of the package.
+	Path        string    `json:"path"`
+	Description string    `json:"description,omitempty"`
+	Keywords    []string  `json:"keywords,omitempty"`
+	Site        string    `json:"site,omitempty"`
+	Upstream    *Upstream `json:"upstream,omitempty"`
+	Pipeline    Pipeline  `json:"pipeline"`
+	// Subpackages are the subpackages of the package at any depth, sorted
+	// by path.
+	Subpackages []Subpackage `json:"subpackages,omitempty"`
+	// Resources are the counts of the resources of the package, without
+	// the resources of its subpackages, by apiVersion and kind.
+	Resources []ResourceCount `json:"resources,omitempty"`
+	Timestamps
+}
+
+// Timestamps are the times of the git commits that changed a package, in
+// RFC 3339 format. They are empty if the package is not in a git
+// repository.
+type Timestamps struct {
+	// LastUpdated is the time of the commit that updated the package to
+	// its current upstream commit.
+	LastUpdated string `json:"lastUpdated,omitempty"`
+	// LastCommitted is the time of the last commit that changed the
+	// package.
+	LastCommitted string `json:"lastCommitted,omitempty"`
+}
+
+// Upstream describes the upstream and the upstream lock of a package.
+type Upstream struct {
+	Repo           string `json:"repo"`
+	Directory      string `json:"directory,omitempty"`
+	Ref            string `json:"ref,omitempty"`
+	UpdateStrategy string `json:"updateStrategy,omitempty"`
+	// LockedRef and Commit are the ref and the commit of the upstream
+	// lock. They are empty if the package hasn't been fetched.
+	LockedRef string `json:"lockedRef,omitempty"`
+	Commit    string `json:"commit,omitempty"`
+}
+
+// Pipeline lists the functions of the pipeline of a package, by their
+// image or exec.
+type Pipeline struct {
+	Mutators   []string `json:"mutators,omitempty"`
+	Validators []string `json:"validators,omitempty"`
+}
+
+// Subpackage describes a subpackage.
+type Subpackage struct {
+	// Path is the slash-separated path of the subpackage relative to the
+	// package.
+	Path     string    `json:"path"`
+	Name     string    `json:"name"`
+	Upstream *Upstream `json:"upstream,omitempty"`
+	// Resources is the number of resources of the subpackage, without the
+	// resources of its subpackages.
+	Resources int `json:"resources"`
+	Timestamps
+}
+
+// ResourceCount is the number of resources of an apiVersion and kind.
+type ResourceCount struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Count      int    `json:"count"`
+}
+
+// Describe describes the package at the absolute path pkgPath.
+func Describe(ctx context.Context, fsys filesys.FileSystem, pkgPath string) (*Description, error) {
+	p, err := pkg.New(fsys, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	kf, err := p.Kptfile()
+	if err != nil {
+		return nil, err
+	}
+	d := &Description{
+		Name:     kf.Name,
+		Path:     pkgPath,
+		Upstream: upstream(kf),
+	}
+	if kf.Info != nil {
+		d.Description = kf.Info.Description
+		d.Keywords = kf.Info.Keywords
+		d.Site = kf.Info.Site
+	}
+	if kf.Pipeline != nil {
+		for _, fn := range kf.Pipeline.Mutators {
+			d.Pipeline.Mutators = append(d.Pipeline.Mutators, function(fn))
+		}
+		for _, fn := range kf.Pipeline.Validators {
+			d.Pipeline.Validators = append(d.Pipeline.Validators, function(fn))
+		}
+	}
+	d.Resources, err = countResources(p)
+	if err != nil {
+		return nil, err
+	}
+	d.Timestamps = timestamps(ctx, pkgPath, d.Upstream)
+
+	paths, err := pkg.Subpackages(fsys, pkgPath, pkg.All, true)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	for _, subPath := range paths {
+		sub, err := pkg.New(fsys, filepath.Join(pkgPath, subPath))
+		if err != nil {
+			return nil, err
+		}
+		subKf, err := sub.Kptfile()
+		if err != nil {
+			return nil, err
+		}
+		counts, err := countResources(sub)
+		if err != nil {
+			return nil, err
+		}
+		total := 0
+		for _, c := range counts {
+			total += c.Count
+		}
+		s := Subpackage{
+			Path:      filepath.ToSlash(subPath),
+			Name:      subKf.Name,
+			Upstream:  upstream(subKf),
+			Resources: total,
+		}
+		s.Timestamps = timestamps(ctx, string(sub.UniquePath), s.Upstream)
+		d.Subpackages = append(d.Subpackages, s)
+	}
+	return d, nil
+}
+
+func upstream(kf *kptfilev1.KptFile) *Upstream {
+	if kf.Upstream == nil || kf.Upstream.Git == nil {
+		return nil
+	}
+	u := &Upstream{
+		Repo:           kf.Upstream.Git.Repo,
+		Directory:      kf.Upstream.Git.Directory,
+		Ref:            kf.Upstream.Git.Ref,
+		UpdateStrategy: string(kf.Upstream.UpdateStrategy),
+	}
+	if kf.UpstreamLock != nil && kf.UpstreamLock.Git != nil {
+		u.LockedRef = kf.UpstreamLock.Git.Ref
+		u.Commit = kf.UpstreamLock.Git.Commit
+	}
+	return u
+}
+
+// function returns the image or exec of fn, followed by its name if it
+// has one.
+func function(fn kptfilev1.Function) string {
+	s := fn.Image
+	if s == "" {
+		s = "exec: " + fn.Exec
+	}
+	if fn.Name != "" {
+		s += fmt.Sprintf(" (%s)", fn.Name)
+	}
+	return s
+}
+
+// countResources counts the resources of p, without the Kptfile, by
+// apiVersion and kind.
+func countResources(p *pkg.Pkg) ([]ResourceCount, error) {
+	resources, err := p.LocalResources()
+	if err != nil {
+		return nil, err
+	}
+	counts := map[[2]string]int{}
+	for _, r := range resources {
+		if r.GetKind() == kptfilev1.KptFileKind {
+			continue
+		}
+		counts[[2]string{r.GetApiVersion(), r.GetKind()}]++
+	}
+	var list []ResourceCount
+	for k, c := range counts {
+		list = append(list, ResourceCount{APIVersion: k[0], Kind: k[1], Count: c})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].APIVersion != list[j].APIVersion {
+			return list[i].APIVersion < list[j].APIVersion
+		}
+		return list[i].Kind < list[j].Kind
+	})
+	return list, nil
+}
+
+// timestamps reads the timestamps of the package at pkgPath from git. The
+// last update is the commit that added the current upstream commit to the
+// Kptfile.
+func timestamps(ctx context.Context, pkgPath string, u *Upstream) Timestamps {
+	var t Timestamps
+	gitRunner, err := gitutil.NewLocalGitRunner(pkgPath)
+	if err != nil {
+		return t
+	}
+	rr, err := gitRunner.Run(ctx, "log", "-1", "--format=%cI", "--", ".")
+	if err != nil {
+		return t
+	}
+	t.LastCommitted = strings.TrimSpace(rr.Stdout)
+	if u != nil && u.Commit != "" {
+		rr, err := gitRunner.Run(ctx, "log", "-1", "--format=%cI", "-S", u.Commit, "--", kptfilev1.KptFileName)
+		if err == nil {
+			t.LastUpdated = strings.TrimSpace(rr.Stdout)
+		}
+	}
+	return t
+}
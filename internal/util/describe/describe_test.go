@@ -0,0 +1,137 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+func TestDescribe(t *testing.T) {
+	files := map[string]string{
+		"Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+info:
+  description: an example package
+  keywords:
+  - example
+upstream:
+  type: git
+  git:
+    repo: https://github.com/example/blueprints
+    directory: /pkg
+    ref: v1
+  updateStrategy: resource-merge
+upstreamLock:
+  type: git
+  git:
+    repo: https://github.com/example/blueprints
+    directory: /pkg
+    ref: v1
+    commit: 0123456789abcdef
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-labels:v0.1
+    name: labels
+  validators:
+  - exec: ./validate
+`,
+		"resources.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`,
+		"db/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: db
+upstream:
+  type: git
+  git:
+    repo: https://github.com/example/db
+    directory: /
+    ref: main
+`,
+		"db/statefulset.yaml": "apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: db\n",
+		"local/Kptfile":       "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: local\n",
+	}
+	fs := filesys.MakeFsInMemory()
+	for p, content := range files {
+		require.NoError(t, fs.MkdirAll(filepath.Dir(filepath.Join("/pkg", p))))
+		require.NoError(t, fs.WriteFile(filepath.Join("/pkg", p), []byte(content)))
+	}
+
+	d, err := Describe(fake.CtxWithDefaultPrinter(), fs, "/pkg")
+	require.NoError(t, err)
+	assert.Equal(t, &Description{
+		Name:        "pkg",
+		Path:        "/pkg",
+		Description: "an example package",
+		Keywords:    []string{"example"},
+		Upstream: &Upstream{
+			Repo:           "https://github.com/example/blueprints",
+			Directory:      "/pkg",
+			Ref:            "v1",
+			UpdateStrategy: "resource-merge",
+			LockedRef:      "v1",
+			Commit:         "0123456789abcdef",
+		},
+		Pipeline: Pipeline{
+			Mutators:   []string{"gcr.io/kpt-fn/set-labels:v0.1 (labels)"},
+			Validators: []string{"exec: ./validate"},
+		},
+		Subpackages: []Subpackage{
+			{
+				Path: "db",
+				Name: "db",
+				Upstream: &Upstream{
+					Repo:      "https://github.com/example/db",
+					Directory: "/",
+					Ref:       "main",
+				},
+				Resources: 1,
+			},
+			{Path: "local", Name: "local"},
+		},
+		Resources: []ResourceCount{
+			{APIVersion: "apps/v1", Kind: "Deployment", Count: 1},
+			{APIVersion: "v1", Kind: "ConfigMap", Count: 2},
+		},
+	}, d)
+}
+
+func TestDescribe_noKptfile(t *testing.T) {
+	fs := filesys.MakeFsInMemory()
+	require.NoError(t, fs.MkdirAll("/pkg"))
+	_, err := Describe(fake.CtxWithDefaultPrinter(), fs, "/pkg")
+	assert.Error(t, err)
+}
@@ -28,11 +28,15 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/pkg"
 	"github.com/GoogleContainerTools/kpt/internal/util/addmergecomment"
 	"github.com/GoogleContainerTools/kpt/internal/util/fetch"
+	"github.com/GoogleContainerTools/kpt/internal/util/ignore"
 	"github.com/GoogleContainerTools/kpt/internal/util/pkgutil"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
 	"sigs.k8s.io/kustomize/kyaml/errors"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/pathutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
 // Type represents type of diff comparison to be performed.
@@ -100,6 +104,10 @@ type Command struct {
 	// DiffToolOpts refers to the commandline options to for the diffing tool.
 	DiffToolOpts string
 
+	// IgnoreGenerated excludes the resources generated by functions, which
+	// have the kpt.dev/generated-by annotation, from the diff.
+	IgnoreGenerated bool
+
 	// When Debug is true, command will run with verbose logging and will not
 	// cleanup the staged packages to assist with debugging.
 	Debug bool
@@ -196,6 +204,10 @@ func (c *Command) Run(ctx context.Context) error {
 		}
 	}
 
+	if err := removeIgnored(c.Path, currPkg, upstreamPkg, upstreamTargetPkg); err != nil {
+		return errors.Errorf("failed to remove ignored paths: %v", err)
+	}
+
 	if c.Debug {
 		fmt.Fprintf(c.Output, "diffing currPkg: %v, upstreamPkg: %v, upstreamTargetPkg: %v \n",
 			currPkg, upstreamPkg, upstreamTargetPkg)
@@ -241,11 +253,12 @@ func (c *Command) DefaultValues() {
 	}
 	if c.PkgDiffer == nil {
 		c.PkgDiffer = &defaultPkgDiffer{
-			DiffType:     c.DiffType,
-			DiffTool:     c.DiffTool,
-			DiffToolOpts: c.DiffToolOpts,
-			Debug:        c.Debug,
-			Output:       c.Output,
+			DiffType:        c.DiffType,
+			DiffTool:        c.DiffTool,
+			DiffToolOpts:    c.DiffToolOpts,
+			IgnoreGenerated: c.IgnoreGenerated,
+			Debug:           c.Debug,
+			Output:          c.Output,
 		}
 	}
 }
@@ -265,6 +278,10 @@ type defaultPkgDiffer struct {
 	// DiffToolOpts refers to the commandline options to for the diffing tool.
 	DiffToolOpts string
 
+	// IgnoreGenerated excludes the resources generated by functions, which
+	// have the kpt.dev/generated-by annotation, from the diff.
+	IgnoreGenerated bool
+
 	// When Debug is true, command will run with verbose logging and will not
 	// cleanup the staged packages to assist with debugging.
 	Debug bool
@@ -317,6 +334,11 @@ func (d *defaultPkgDiffer) Diff(pkgs ...string) error {
 // prepareForDiff removes metadata such as .git and Kptfile from a staged package
 // to exclude them from diffing.
 func (d *defaultPkgDiffer) prepareForDiff(dir string) error {
+	if d.IgnoreGenerated {
+		if err := removeGenerated(dir); err != nil {
+			return err
+		}
+	}
 	excludePaths := []string{".git", kptfilev1.KptFileName}
 	for _, path := range excludePaths {
 		path = filepath.Join(dir, path)
@@ -327,6 +349,68 @@ func (d *defaultPkgDiffer) prepareForDiff(dir string) error {
 	return nil
 }
 
+// removeIgnored removes the paths in the .kptignore files of the local
+// package at pkgPath, and of its subpackages, from the staged packages.
+func removeIgnored(pkgPath string, staged ...string) error {
+	pkgDirs, err := pathutil.DirsWithFile(pkgPath, kptfilev1.KptFileName, true)
+	if err != nil {
+		return err
+	}
+	for _, pkgDir := range pkgDirs {
+		ignored, err := ignore.Load(filesys.FileSystemOrOnDisk{}, pkgDir)
+		if err != nil {
+			return err
+		}
+		if ignored == nil {
+			continue
+		}
+		rel, err := filepath.Rel(pkgPath, pkgDir)
+		if err != nil {
+			return err
+		}
+		for _, dir := range staged {
+			if dir == "" {
+				continue
+			}
+			dir = filepath.Join(dir, rel)
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				continue
+			}
+			if err := ignored.Remove(dir); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeGenerated removes the resources generated by functions from the
+// staged package in dir. Files that only contain generated resources are
+// removed.
+func removeGenerated(dir string) error {
+	rw := &kio.LocalPackageReadWriter{
+		PackagePath:        dir,
+		PreserveSeqIndent:  true,
+		PackageFileName:    kptfilev1.KptFileName,
+		IncludeSubpackages: true,
+		WrapBareSeqNode:    true,
+		MatchFilesGlob:     pkg.MatchAllKRM,
+	}
+	return kio.Pipeline{
+		Inputs: []kio.Reader{rw},
+		Filters: []kio.Filter{kio.FilterFunc(func(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+			var authored []*yaml.RNode
+			for _, n := range nodes {
+				if !kptfilev1.IsGenerated(n) {
+					authored = append(authored, n)
+				}
+			}
+			return authored, nil
+		})},
+		Outputs: []kio.Writer{rw},
+	}.Execute()
+}
+
 // PkgGetter knows how to fetch a package given a git repo, path and ref.
 type PkgGetter interface {
 	GetPkg(ctx context.Context, stagingDir, targetDir, repo, path, ref string) (dir string, err error)
@@ -256,6 +256,44 @@ locally changed: foo
 			`,
 		},
 
+		"ignored files are not diffed": {
+			reposChanges: map[string][]testutil.Content{
+				testutil.Upstream: {
+					{
+						Pkg: pkgbuilder.NewRootPkg().
+							WithResource(pkgbuilder.DeploymentResource).
+							WithFile(".kptignore", "*.md\n").
+							WithFile("README.md", "upstream"),
+						Branch: "main",
+					},
+				},
+			},
+			updatedLocal: testutil.Content{
+				Pkg: pkgbuilder.NewRootPkg().
+					WithKptfile(
+						pkgbuilder.NewKptfile().
+							WithUpstreamRef(testutil.Upstream, "/", "main", "resource-merge").
+							WithUpstreamLockRef(testutil.Upstream, "/", "main", 0),
+					).
+					WithResource(pkgbuilder.DeploymentResource,
+						pkgbuilder.SetFieldPath("5", "spec", "replicas")).
+					WithFile(".kptignore", "*.md\n").
+					WithFile("README.md", "local").
+					WithFile("CHANGELOG.md", "local"),
+			},
+			fetchRef: "main",
+			diffRef:  "main",
+			diffType: TypeLocal,
+			diffTool: "diff",
+			diffOpts: "-r -i -w",
+			expDiff: `
+9c9
+<   replicas: 5
+---
+>   replicas: 3
+			`,
+		},
+
 		//nolint:gocritic
 		// TODO(mortent): Diff functionality must be updated to handle nested packages.
 		"nested remote package updated in upstream": {
@@ -15,11 +15,14 @@
 package diff
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/GoogleContainerTools/kpt/internal/testutil"
 	"github.com/GoogleContainerTools/kpt/internal/testutil/pkgbuilder"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/kustomize/kyaml/sets"
 )
 
@@ -158,3 +161,38 @@ func toStringSet(files ...string) sets.String {
 	}
 	return s
 }
+
+func TestRemoveGenerated(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"authored.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: authored
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: mixed-generated
+  annotations:
+    kpt.dev/generated-by: gcr.io/example/generator:v1
+`,
+		"generated.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: generated
+  annotations:
+    kpt.dev/generated-by: gcr.io/example/generator:v1
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	require.NoError(t, removeGenerated(dir))
+
+	b, err := os.ReadFile(filepath.Join(dir, "authored.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: authored\n", string(b))
+	assert.NoFileExists(t, filepath.Join(dir, "generated.yaml"))
+}
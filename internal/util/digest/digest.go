@@ -0,0 +1,191 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package digest computes, records and verifies the content digests of
+// fetched packages.
+package digest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	"github.com/GoogleContainerTools/kpt/internal/util/vendor"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const prefix = "sha256:"
+
+// Compute returns the digest of the package at pkgPath. It covers the
+// relative paths and content of all regular files in the package, except
+// for the Kptfile of the package, the vendor store and remote subpackages,
+// which carry their own digest.
+func Compute(pkgPath string) (string, error) {
+	remote, err := pkg.Subpackages(filesys.FileSystemOrOnDisk{}, pkgPath, pkg.Remote, false)
+	if err != nil {
+		return "", err
+	}
+	skip := map[string]bool{
+		kptfilev1.KptFileName: true,
+		vendor.DirName:        true,
+	}
+	for _, p := range remote {
+		skip[filepath.ToSlash(p)] = true
+	}
+
+	var files []string
+	err = filepath.WalkDir(pkgPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(pkgPath, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if d.Name() == ".git" || skip[rel] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || skip[rel] {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, f := range files {
+		b, err := os.ReadFile(filepath.Join(pkgPath, filepath.FromSlash(f)))
+		if err != nil {
+			return "", err
+		}
+		fh := sha256.Sum256(b)
+		fmt.Fprintf(h, "%s  %s\n", hex.EncodeToString(fh[:]), f)
+	}
+	return prefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Record computes the digest of the package at rootPath and of all its
+// subpackages that have an upstreamLock, and records it in their Kptfiles.
+func Record(rootPath string) error {
+	const op errors.Op = "digest.Record"
+	paths, err := lockedPackages(rootPath)
+	if err != nil {
+		return errors.E(op, types.UniquePath(rootPath), err)
+	}
+	for _, p := range paths {
+		kf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, p)
+		if err != nil {
+			return errors.E(op, types.UniquePath(p), err)
+		}
+		d, err := Compute(p)
+		if err != nil {
+			return errors.E(op, types.UniquePath(p), err)
+		}
+		kf.UpstreamLock.Digest = d
+		if err := kptfileutil.WriteFile(p, kf); err != nil {
+			return errors.E(op, types.UniquePath(p), err)
+		}
+	}
+	return nil
+}
+
+// Result is the outcome of verifying the digest of a package.
+type Result struct {
+	// Path is the path of the package relative to the verified root.
+	Path string
+	// Expected is the digest recorded in the Kptfile. It is empty if no
+	// digest has been recorded.
+	Expected string
+	// Actual is the digest of the current package content.
+	Actual string
+}
+
+// Verified returns true if the recorded digest matches the package content.
+func (r Result) Verified() bool {
+	return r.Expected != "" && r.Expected == r.Actual
+}
+
+// Verify compares the recorded digests of the package at rootPath and all
+// its subpackages that have an upstreamLock with their current content.
+func Verify(rootPath string) ([]Result, error) {
+	const op errors.Op = "digest.Verify"
+	paths, err := lockedPackages(rootPath)
+	if err != nil {
+		return nil, errors.E(op, types.UniquePath(rootPath), err)
+	}
+	var results []Result
+	for _, p := range paths {
+		kf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, p)
+		if err != nil {
+			return nil, errors.E(op, types.UniquePath(p), err)
+		}
+		d, err := Compute(p)
+		if err != nil {
+			return nil, errors.E(op, types.UniquePath(p), err)
+		}
+		rel, err := filepath.Rel(rootPath, p)
+		if err != nil {
+			return nil, errors.E(op, types.UniquePath(p), err)
+		}
+		results = append(results, Result{
+			Path:     filepath.ToSlash(rel),
+			Expected: kf.UpstreamLock.Digest,
+			Actual:   d,
+		})
+	}
+	return results, nil
+}
+
+// lockedPackages returns the absolute paths of the package at rootPath and
+// its subpackages that have an upstreamLock, in lexical order.
+func lockedPackages(rootPath string) ([]string, error) {
+	subPkgs, err := pkg.Subpackages(filesys.FileSystemOrOnDisk{}, rootPath, pkg.All, true)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []string{rootPath}
+	for _, p := range subPkgs {
+		candidates = append(candidates, filepath.Join(rootPath, p))
+	}
+	sort.Strings(candidates)
+
+	var paths []string
+	for _, p := range candidates {
+		kf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, p)
+		if err != nil {
+			return nil, err
+		}
+		if kf.UpstreamLock != nil {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rootKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: root
+upstream:
+  type: git
+  git:
+    repo: https://github.com/kptdev/kpt
+    directory: /root
+    ref: main
+upstreamLock:
+  type: git
+  git:
+    repo: https://github.com/kptdev/kpt
+    directory: /root
+    ref: main
+    commit: abc123
+`
+
+const remoteKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: remote
+upstream:
+  type: git
+  git:
+    repo: https://github.com/kptdev/kpt
+    directory: /remote
+    ref: main
+upstreamLock:
+  type: git
+  git:
+    repo: https://github.com/kptdev/kpt
+    directory: /remote
+    ref: main
+    commit: abc123
+`
+
+const localKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: local
+`
+
+func setupPackage(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Kptfile":                 rootKptfile,
+		"cm.yaml":                 "kind: ConfigMap\n",
+		"local/Kptfile":           localKptfile,
+		"local/deploy.yaml":       "kind: Deployment\n",
+		"remote/Kptfile":          remoteKptfile,
+		"remote/svc.yaml":         "kind: Service\n",
+		".kpt-vendor/vendor.lock": "snapshots: []\n",
+	}
+	for p, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(p))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestCompute(t *testing.T) {
+	dir := setupPackage(t)
+	d, err := Compute(dir)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(d, "sha256:"))
+
+	change := func(p, content string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, filepath.FromSlash(p)), []byte(content), 0600))
+		got, err := Compute(dir)
+		require.NoError(t, err)
+		return got
+	}
+	// The Kptfile, vendor store and remote subpackages are excluded.
+	assert.Equal(t, d, change("Kptfile", rootKptfile+"info:\n  description: foo\n"))
+	assert.Equal(t, d, change(".kpt-vendor/vendor.lock", "snapshots: [{}]\n"))
+	assert.Equal(t, d, change("remote/svc.yaml", "kind: Service\nspec: {}\n"))
+	// Local subpackages are included.
+	d2 := change("local/deploy.yaml", "kind: Deployment\nspec: {}\n")
+	assert.NotEqual(t, d, d2)
+	assert.NotEqual(t, d2, change("cm.yaml", "kind: ConfigMap\ndata: {}\n"))
+}
+
+func TestRecordAndVerify(t *testing.T) {
+	dir := setupPackage(t)
+
+	results, err := Verify(dir)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, res := range results {
+		assert.Empty(t, res.Expected)
+		assert.False(t, res.Verified())
+	}
+
+	require.NoError(t, Record(dir))
+	b, err := os.ReadFile(filepath.Join(dir, "local", "Kptfile"))
+	require.NoError(t, err)
+	assert.Equal(t, localKptfile, string(b))
+
+	results, err = Verify(dir)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, ".", results[0].Path)
+	assert.Equal(t, "remote", results[1].Path)
+	for _, res := range results {
+		assert.True(t, res.Verified(), res.Path)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "remote", "svc.yaml"), []byte("kind: Service\nspec: {}\n"), 0600))
+	results, err = Verify(dir)
+	require.NoError(t, err)
+	assert.True(t, results[0].Verified())
+	assert.False(t, results[1].Verified())
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external resolves the values of ExternalValue resources from
+// external sources, such as secret managers, and sets them in the resources
+// of a package.
+package external
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	APIVersion = "kpt.dev/v1alpha1"
+	Kind       = "ExternalValue"
+
+	// EncodingBase64 base64 encodes the resolved value, e.g. for the data
+	// of a Secret.
+	EncodingBase64 = "base64"
+)
+
+// ExternalValue references a value in an external source and the field of
+// a resource of the package the value is set in.
+type ExternalValue struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec ExternalValueSpec `yaml:"spec"`
+}
+
+type ExternalValueSpec struct {
+	// Provider is the name of the provider that resolves the value.
+	Provider string `yaml:"provider"`
+	// Ref identifies the value for the provider.
+	Ref string `yaml:"ref"`
+	// Encoding is applied to the resolved value. The only encoding is
+	// base64. If empty, the value is set as is.
+	Encoding string `yaml:"encoding,omitempty"`
+	// Target is the field the value is set in.
+	Target Target `yaml:"target"`
+}
+
+// Target selects a resource of the package and a field of it.
+type Target struct {
+	APIVersion string `yaml:"apiVersion,omitempty"`
+	Kind       string `yaml:"kind"`
+	Name       string `yaml:"name"`
+	Namespace  string `yaml:"namespace,omitempty"`
+	// FieldPath is the dot separated path of the field, e.g.
+	// `data.password`. The field is created if it doesn't exist.
+	FieldPath string `yaml:"fieldPath"`
+}
+
+// Provider resolves references to values in an external source.
+type Provider interface {
+	// Resolve returns the value referenced by ref.
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// Resolver resolves the ExternalValue resources of a package with its
+// providers.
+type Resolver struct {
+	// Providers are the allowed providers by name. An ExternalValue
+	// with another provider is an error.
+	Providers map[string]Provider
+}
+
+// NewResolver returns a Resolver allowing the built-in providers with the
+// given names.
+func NewResolver(names []string) (*Resolver, error) {
+	r := &Resolver{Providers: map[string]Provider{}}
+	for _, name := range names {
+		p, found := builtinProviders[name]
+		if !found {
+			return nil, fmt.Errorf("unknown external value provider %q, must be one of %s",
+				name, strings.Join(ProviderNames(), ", "))
+		}
+		r.Providers[name] = p()
+	}
+	return r, nil
+}
+
+// ProviderNames returns the names of the built-in providers.
+func ProviderNames() []string {
+	var names []string
+	for name := range builtinProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve resolves the values of the ExternalValue resources in nodes and
+// sets them in the targeted resources. It returns the number of resolved
+// values.
+func (r *Resolver) Resolve(ctx context.Context, nodes []*yaml.RNode) (int, error) {
+	count := 0
+	for _, node := range nodes {
+		if node.GetApiVersion() != APIVersion || node.GetKind() != Kind {
+			continue
+		}
+		var ev ExternalValue
+		if err := node.Document().Decode(&ev); err != nil {
+			return count, fmt.Errorf("invalid %s %q: %w", Kind, node.GetName(), err)
+		}
+		if err := r.resolve(ctx, &ev, nodes); err != nil {
+			return count, fmt.Errorf("%s %q: %w", Kind, ev.Metadata.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *Resolver) resolve(ctx context.Context, ev *ExternalValue, nodes []*yaml.RNode) error {
+	spec := ev.Spec
+	if spec.Ref == "" || spec.Target.Kind == "" || spec.Target.Name == "" || spec.Target.FieldPath == "" {
+		return fmt.Errorf("spec.ref, spec.target.kind, spec.target.name and spec.target.fieldPath are required")
+	}
+	if spec.Encoding != "" && spec.Encoding != EncodingBase64 {
+		return fmt.Errorf("unknown encoding %q", spec.Encoding)
+	}
+	p, found := r.Providers[spec.Provider]
+	if !found {
+		return fmt.Errorf("provider %q is not allowed", spec.Provider)
+	}
+	target, err := findTarget(spec.Target, nodes)
+	if err != nil {
+		return err
+	}
+
+	value, err := p.Resolve(ctx, spec.Ref)
+	if err != nil {
+		return fmt.Errorf("unable to resolve %q with provider %q: %w", spec.Ref, spec.Provider, err)
+	}
+	if spec.Encoding == EncodingBase64 {
+		value = base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	field, err := target.Pipe(yaml.LookupCreate(yaml.ScalarNode, strings.Split(spec.Target.FieldPath, ".")...))
+	if err != nil {
+		return fmt.Errorf("unable to set %s: %w", spec.Target.FieldPath, err)
+	}
+	field.YNode().Value = value
+	field.YNode().Tag = yaml.NodeTagString
+	field.YNode().Style = 0
+	return nil
+}
+
+// findTarget returns the single resource in nodes selected by t.
+func findTarget(t Target, nodes []*yaml.RNode) (*yaml.RNode, error) {
+	var matches []*yaml.RNode
+	for _, node := range nodes {
+		if node.GetKind() != t.Kind || node.GetName() != t.Name {
+			continue
+		}
+		if t.APIVersion != "" && node.GetApiVersion() != t.APIVersion {
+			continue
+		}
+		if t.Namespace != "" && node.GetNamespace() != t.Namespace {
+			continue
+		}
+		matches = append(matches, node)
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("target %s %q not found", t.Kind, t.Name)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("target %s %q matches %d resources, set the apiVersion or namespace of the target",
+			t.Kind, t.Name, len(matches))
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const secret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: prod
+data:
+  user: YWRtaW4=
+`
+
+func externalValue(spec string) string {
+	return `apiVersion: kpt.dev/v1alpha1
+kind: ExternalValue
+metadata:
+  name: db-password
+  annotations:
+    config.kubernetes.io/local-config: "true"
+spec:
+` + spec
+}
+
+func TestResolve(t *testing.T) {
+	env := &EnvProvider{LookupEnv: func(key string) (string, bool) {
+		if key == "DB_PASSWORD" {
+			return "s3cr3t", true
+		}
+		return "", false
+	}}
+
+	tests := map[string]struct {
+		resources string
+		providers map[string]Provider
+
+		expectedCount  int
+		expectedSecret string
+		expectedError  string
+	}{
+		"value is set in the target": {
+			resources: secret + "---\n" + externalValue(`  provider: env
+  ref: DB_PASSWORD
+  target:
+    kind: Secret
+    name: db
+    fieldPath: stringData.password
+`),
+			providers:     map[string]Provider{"env": env},
+			expectedCount: 1,
+			expectedSecret: secret + `stringData:
+  password: s3cr3t
+`,
+		},
+		"value is encoded": {
+			resources: secret + "---\n" + externalValue(`  provider: env
+  ref: DB_PASSWORD
+  encoding: base64
+  target:
+    apiVersion: v1
+    kind: Secret
+    name: db
+    namespace: prod
+    fieldPath: data.password
+`),
+			providers:     map[string]Provider{"env": env},
+			expectedCount: 1,
+			expectedSecret: `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: prod
+data:
+  user: YWRtaW4=
+  password: czNjcjN0
+`,
+		},
+		"provider is not allowed": {
+			resources: secret + "---\n" + externalValue(`  provider: vault
+  ref: secret/data/db#password
+  target:
+    kind: Secret
+    name: db
+    fieldPath: data.password
+`),
+			providers:     map[string]Provider{"env": env},
+			expectedError: `ExternalValue "db-password": provider "vault" is not allowed`,
+		},
+		"target doesn't exist": {
+			resources: secret + "---\n" + externalValue(`  provider: env
+  ref: DB_PASSWORD
+  target:
+    kind: Secret
+    name: other
+    fieldPath: data.password
+`),
+			providers:     map[string]Provider{"env": env},
+			expectedError: `ExternalValue "db-password": target Secret "other" not found`,
+		},
+		"value can't be resolved": {
+			resources: secret + "---\n" + externalValue(`  provider: env
+  ref: MISSING
+  target:
+    kind: Secret
+    name: db
+    fieldPath: data.password
+`),
+			providers:     map[string]Provider{"env": env},
+			expectedError: `environment variable "MISSING" is not set`,
+		},
+		"missing fields": {
+			resources: secret + "---\n" + externalValue(`  provider: env
+  target:
+    kind: Secret
+    name: db
+`),
+			providers:     map[string]Provider{"env": env},
+			expectedError: "spec.ref, spec.target.kind, spec.target.name and spec.target.fieldPath are required",
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			nodes, err := kio.FromBytes([]byte(tc.resources))
+			require.NoError(t, err)
+			r := &Resolver{Providers: tc.providers}
+			count, err := r.Resolve(context.Background(), nodes)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedSecret, nodes[0].MustString())
+		})
+	}
+}
+
+func TestNewResolver(t *testing.T) {
+	r, err := NewResolver([]string{"env", "vault"})
+	require.NoError(t, err)
+	assert.Len(t, r.Providers, 2)
+
+	_, err = NewResolver([]string{"aws"})
+	assert.EqualError(t, err, `unknown external value provider "aws", must be one of env, gcp-secret-manager, vault`)
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "kv2"}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/db":
+			_, _ = w.Write([]byte(`{"data": {"password": "kv1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := &VaultProvider{Address: server.URL, Token: "token", Client: server.Client()}
+	ctx := context.Background()
+	value, err := p.Resolve(ctx, "secret/data/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "kv2", value)
+	value, err = p.Resolve(ctx, "kv/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1", value)
+
+	_, err = p.Resolve(ctx, "kv/db#user")
+	assert.EqualError(t, err, `key "user" not found in the secret`)
+	_, err = p.Resolve(ctx, "kv/missing#password")
+	assert.ErrorContains(t, err, "404 Not Found")
+	_, err = p.Resolve(ctx, "kv/db")
+	assert.EqualError(t, err, "reference must be PATH#KEY")
+}
+
+func TestSecretManagerProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/v1/projects/my-project/secrets/db/versions/latest:access" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"payload": {"data": "czNjcjN0"}}`))
+	}))
+	defer server.Close()
+
+	p := &SecretManagerProvider{
+		Endpoint:    server.URL,
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+	}
+	ctx := context.Background()
+	value, err := p.Resolve(ctx, "projects/my-project/secrets/db")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	_, err = p.Resolve(ctx, "projects/my-project/secrets/db/versions/2")
+	assert.ErrorContains(t, err, "404 Not Found")
+	_, err = p.Resolve(ctx, "db")
+	assert.EqualError(t, err, "reference must be projects/PROJECT/secrets/SECRET[/versions/VERSION]")
+}
+
+func TestResolveIgnoresOtherResources(t *testing.T) {
+	nodes := []*yaml.RNode{yaml.MustParse(secret)}
+	count, err := (&Resolver{}).Resolve(context.Background(), nodes)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, secret, nodes[0].MustString())
+}
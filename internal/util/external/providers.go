@@ -0,0 +1,214 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+var builtinProviders = map[string]func() Provider{
+	"env":                func() Provider { return &EnvProvider{} },
+	"vault":              func() Provider { return &VaultProvider{} },
+	"gcp-secret-manager": func() Provider { return &SecretManagerProvider{} },
+}
+
+// EnvProvider resolves references to environment variables. The reference
+// is the name of the variable.
+type EnvProvider struct {
+	// LookupEnv looks up the environment variable. If nil, os.LookupEnv is
+	// used.
+	LookupEnv func(key string) (string, bool)
+}
+
+func (p *EnvProvider) Resolve(_ context.Context, ref string) (string, error) {
+	lookupEnv := p.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	value, found := lookupEnv(ref)
+	if !found {
+		return "", fmt.Errorf("environment variable %q is not set", ref)
+	}
+	return value, nil
+}
+
+// VaultProvider resolves references to the keys of HashiCorp Vault secrets.
+// The reference is the path of the secret and the key, separated by `#`,
+// e.g. `secret/data/db#password`. Both KV version 1 and 2 secrets engines
+// are supported.
+type VaultProvider struct {
+	// Address is the address of the Vault server. If empty, the VAULT_ADDR
+	// environment variable is used.
+	Address string
+	// Token is the Vault token. If empty, the VAULT_TOKEN environment
+	// variable or the ~/.vault-token file is used.
+	Token string
+	// Client is the HTTP client. If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+func (p *VaultProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	path, key, found := strings.Cut(ref, "#")
+	if !found || path == "" || key == "" {
+		return "", fmt.Errorf("reference must be PATH#KEY")
+	}
+	addr := p.Address
+	if addr == "" {
+		addr = os.Getenv("VAULT_ADDR")
+	}
+	if addr == "" {
+		return "", fmt.Errorf("the address of the Vault server is not set in VAULT_ADDR")
+	}
+	token, err := p.token()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(addr, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := getJSON(p.Client, req, &secret); err != nil {
+		return "", err
+	}
+	data := secret.Data
+	// the KV version 2 secrets engine nests the data of the secret.
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	value, found := data[key]
+	if !found {
+		return "", fmt.Errorf("key %q not found in the secret", key)
+	}
+	s, ok := value.(string)
+	if !ok {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		s = string(b)
+	}
+	return s, nil
+}
+
+func (p *VaultProvider) token() (string, error) {
+	if p.Token != "" {
+		return p.Token, nil
+	}
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	b, err := os.ReadFile(filepath.Join(home, ".vault-token"))
+	if err != nil {
+		return "", fmt.Errorf("the Vault token is not set in VAULT_TOKEN or ~/.vault-token")
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// SecretManagerProvider resolves references to the versions of Google
+// Cloud Secret Manager secrets. The reference is the resource name of the
+// secret version, e.g. `projects/my-project/secrets/db/versions/3`. The
+// latest version is used if the reference is the name of the secret. The
+// application default credentials are used.
+type SecretManagerProvider struct {
+	// Endpoint is the endpoint of the Secret Manager API. If empty,
+	// https://secretmanager.googleapis.com is used.
+	Endpoint string
+	// TokenSource provides the access tokens. If nil, the application
+	// default credentials are used.
+	TokenSource oauth2.TokenSource
+}
+
+func (p *SecretManagerProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 4 && parts[0] == "projects" && parts[2] == "secrets":
+		ref += "/versions/latest"
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "secrets" && parts[4] == "versions":
+	default:
+		return "", fmt.Errorf("reference must be projects/PROJECT/secrets/SECRET[/versions/VERSION]")
+	}
+	ts := p.TokenSource
+	if ts == nil {
+		var err error
+		ts, err = google.DefaultTokenSource(ctx, "https://www.googleapis.com/auth/cloud-platform")
+		if err != nil {
+			return "", err
+		}
+	}
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = "https://secretmanager.googleapis.com"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/v1/"+ref+":access", nil)
+	if err != nil {
+		return "", err
+	}
+	var version struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := getJSON(oauth2.NewClient(ctx, ts), req, &version); err != nil {
+		return "", err
+	}
+	b, err := base64.StdEncoding.DecodeString(version.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret payload: %w", err)
+	}
+	return string(b), nil
+}
+
+// getJSON sends req with client and decodes the JSON response into v.
+func getJSON(client *http.Client, req *http.Request, v interface{}) error {
+	if err := offline.Check(fmt.Sprintf("fetching %q", req.URL.Redacted())); err != nil {
+		return err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", req.URL.Redacted(), resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+)
+
+// ReadArchive reads the package in the directory of the git repo at ref
+// and writes its files to dest in fsys, which is typically an in-memory
+// filesystem. Unlike the Cloner, the package is read from the repo cache
+// with git archive, without checking it out to a local directory.
+func ReadArchive(ctx context.Context, g *kptfilev1.Git, fsys filesys.FileSystem, dest string) error {
+	const op errors.Op = "fetch.ReadArchive"
+	gur, err := gitutil.NewGitUpstreamRepo(ctx, g.Repo)
+	if err != nil {
+		return errors.E(op, errors.Git, err)
+	}
+	repoDir, err := gur.GetRepo(ctx, []string{g.Ref})
+	if err != nil {
+		return errors.E(op, errors.Git, err)
+	}
+	commit, found := gur.ResolveRef(g.Ref)
+	if !found {
+		commit = g.Ref
+	}
+	gitRunner, err := gitutil.NewLocalGitRunner(repoDir)
+	if err != nil {
+		return errors.E(op, errors.Git, err)
+	}
+
+	dir := strings.Trim(path.Clean("/"+filepath.ToSlash(g.Directory)), "/")
+	args := []string{"--format=tar", commit}
+	if dir != "" {
+		args = append(args, "--", dir)
+	}
+	rr, err := gitRunner.Run(ctx, "archive", args...)
+	if err != nil {
+		return errors.E(op, errors.Git, fmt.Errorf("failed to read directory %q at %q: %w", g.Directory, g.Ref, err))
+	}
+	if err := untar(strings.NewReader(rr.Stdout), dir, fsys, dest); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// untar writes the files in the tar stream below dir to dest in fsys.
+// Entries other than directories and regular files, such as symlinks,
+// are skipped.
+func untar(r io.Reader, dir string, fsys filesys.FileSystem, dest string) error {
+	if err := fsys.MkdirAll(dest); err != nil {
+		return err
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := strings.Trim(hdr.Name, "/")
+		if dir != "" {
+			if name != dir && !strings.HasPrefix(name, dir+"/") {
+				continue
+			}
+			name = strings.TrimPrefix(strings.TrimPrefix(name, dir), "/")
+		}
+		p := filepath.Join(dest, filepath.FromSlash(name))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := fsys.MkdirAll(p); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			b, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			if err := fsys.MkdirAll(filepath.Dir(p)); err != nil {
+				return err
+			}
+			if err := fsys.WriteFile(p, b); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/otiai10/copy"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
@@ -31,11 +32,24 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/types"
 	"github.com/GoogleContainerTools/kpt/internal/util/git"
 	"github.com/GoogleContainerTools/kpt/internal/util/pkgutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/stats"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
 )
 
+// PathNotExistError is the error type returned if the directory of the
+// package doesn't exist in the fetched commit of the repo.
+type PathNotExistError struct {
+	Repo   string
+	Path   string
+	Commit string
+}
+
+func (e *PathNotExistError) Error() string {
+	return fmt.Sprintf("path %q does not exist in repo %q", e.Path, e.Repo)
+}
+
 // Command takes the upstream information in the Kptfile at the path for the
 // provided package, and fetches the package referenced if it isn't already
 // there.
@@ -162,8 +176,10 @@ func (c *Cloner) cloneAndCopy(ctx context.Context, dest string) error {
 // for versioning multiple kpt packages in a single repo independently. It
 // relies on the private clonerUsingGitExec function to try fetching different
 // refs.
-func (c *Cloner) ClonerUsingGitExec(ctx context.Context) error {
+func (c *Cloner) ClonerUsingGitExec(ctx context.Context) (err error) {
 	const op errors.Op = "fetch.ClonerUsingGitExec"
+	start := time.Now()
+	defer func() { stats.Add(stats.Fetch, c.repoSpec.CloneSpec(), time.Since(start), err) }()
 
 	// Create a local representation of the upstream repo. This will initialize
 	// the cache for the specified repo uri if it isn't already there. It also
@@ -237,8 +253,7 @@ func (c *Cloner) ClonerUsingGitExec(ctx context.Context) error {
 	if os.IsNotExist(err) {
 		return errors.E(op,
 			errors.Internal,
-			err,
-			fmt.Errorf("path %q does not exist in repo %q", c.repoSpec.Path, c.repoSpec.OrgRepo))
+			&PathNotExistError{Repo: c.repoSpec.OrgRepo, Path: c.repoSpec.Path, Commit: commit})
 	}
 
 	// Copy the content of the pkg into the temp directory.
@@ -701,3 +701,41 @@ func TestCommand_Run_failInvalidTag(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestReadArchive(t *testing.T) {
+	g, _, clean := setupWorkspace(t)
+	defer clean()
+
+	fsys := filesys.MakeFsInMemory()
+	err := ReadArchive(fake.CtxWithDefaultPrinter(), &kptfilev1.Git{
+		Repo:      "file://" + g.RepoDirectory,
+		Directory: "/java",
+		Ref:       "master",
+	}, fsys, "/java")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for _, name := range []string{"java-configmap.resource.yaml", "java-deployment.resource.yaml", "java-service.resource.yaml"} {
+		expected, err := os.ReadFile(filepath.Join(g.DatasetDirectory, testutil.Dataset1, "java", name))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		actual, err := fsys.ReadFile(filepath.Join("/java", name))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Equal(t, string(expected), string(actual))
+	}
+	assert.False(t, fsys.Exists("/java/mysql"))
+
+	err = ReadArchive(fake.CtxWithDefaultPrinter(), &kptfilev1.Git{
+		Repo:      "file://" + g.RepoDirectory,
+		Directory: "/missing",
+		Ref:       "master",
+	}, filesys.MakeFsInMemory(), "/missing")
+	if !assert.Error(t, err) {
+		t.FailNow()
+	}
+	assert.Contains(t, err.Error(), "did not match any files")
+}
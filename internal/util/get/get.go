@@ -30,6 +30,7 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/types"
 	"github.com/GoogleContainerTools/kpt/internal/util/addmergecomment"
 	"github.com/GoogleContainerTools/kpt/internal/util/attribution"
+	"github.com/GoogleContainerTools/kpt/internal/util/digest"
 	"github.com/GoogleContainerTools/kpt/internal/util/fetch"
 	"github.com/GoogleContainerTools/kpt/internal/util/pathutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/stack"
@@ -141,6 +142,9 @@ func (c Command) Run(ctx context.Context) error {
 		pr.Printf("\nCustomized package for deployment.\n")
 	}
 
+	if err := digest.Record(c.Destination); err != nil {
+		return errors.E(op, types.UniquePath(c.Destination), err)
+	}
 	return nil
 }
 
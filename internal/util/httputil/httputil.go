@@ -15,12 +15,18 @@
 package httputil
 
 import (
+	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
 )
 
 // FetchContent fetches the content from the input url
 func FetchContent(url string) (string, error) {
+	if err := offline.Check(fmt.Sprintf("fetching %q", url)); err != nil {
+		return "", err
+	}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return "", err
@@ -0,0 +1,68 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig is the proxy for the requests to git repos and OCI registries
+// from the kpt config. Unlike the proxy environment variables, it isn't
+// used for the requests to the Kubernetes API server. If it is nil, the
+// environment variables are used.
+var ProxyConfig *httpproxy.Config
+
+// Proxy returns the proxy URL for req, like http.ProxyFromEnvironment, but
+// from ProxyConfig if it is set.
+func Proxy(req *http.Request) (*url.URL, error) {
+	if ProxyConfig == nil {
+		return http.ProxyFromEnvironment(req)
+	}
+	return ProxyConfig.ProxyFunc()(req.URL)
+}
+
+// Transport returns the transport for the requests to git repos and OCI
+// registries, which uses the proxy returned by Proxy.
+func Transport() http.RoundTripper {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = Proxy
+	return t
+}
+
+// ProxyEnv returns the proxy environment variables of ProxyConfig, for
+// subprocesses like git that read the proxy from their environment. Only
+// the lower case variables are returned, since curl, and so git, ignores
+// HTTP_PROXY.
+func ProxyEnv() []string {
+	if ProxyConfig == nil {
+		return nil
+	}
+	var env []string
+	for name, value := range map[string]string{
+		"http_proxy":  ProxyConfig.HTTPProxy,
+		"https_proxy": ProxyConfig.HTTPSProxy,
+		"no_proxy":    ProxyConfig.NoProxy,
+	} {
+		if value != "" {
+			env = append(env, name+"="+value)
+		}
+	}
+	sort.Strings(env)
+	return env
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ignore implements the .kptignore file, which lists the paths of a
+// package that are left out of updates, diffs and rendering.
+package ignore
+
+import (
+	goerrors "errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	gitignore "github.com/monochromegane/go-gitignore"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// FileName is the name of the file with the ignored paths of a package.
+const FileName = ".kptignore"
+
+// Matcher matches paths relative to a package against the patterns of its
+// .kptignore file. The patterns use the .gitignore syntax. A nil Matcher
+// doesn't match any paths.
+type Matcher struct {
+	matcher gitignore.IgnoreMatcher
+}
+
+// Load reads the .kptignore file of the package at pkgPath. It returns nil
+// if the package doesn't have a .kptignore file.
+func Load(fsys filesys.FileSystem, pkgPath string) (*Matcher, error) {
+	f, err := fsys.Open(filepath.Join(pkgPath, FileName))
+	if err != nil {
+		if goerrors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return &Matcher{matcher: gitignore.NewGitIgnoreFromReader("/", f)}, nil
+}
+
+// Match returns true if the path relative to the package, or one of its
+// parent directories, is ignored.
+func (m *Matcher) Match(relPath string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+	relPath = strings.Trim(filepath.ToSlash(relPath), "/")
+	if relPath == "" || relPath == "." {
+		return false
+	}
+	parts := strings.Split(relPath, "/")
+	for i := 1; i < len(parts); i++ {
+		if m.matcher.Match("/"+path.Join(parts[:i]...), true) {
+			return true
+		}
+	}
+	return m.matcher.Match("/"+relPath, isDir)
+}
+
+// SkipFile returns true if the file at the path relative to the package is
+// ignored. It can be used as the FileSkipFunc of a kio.LocalPackageReader.
+func (m *Matcher) SkipFile(relPath string) bool {
+	return m.Match(relPath, false)
+}
+
+// Remove removes the ignored files and directories from dir, which holds a
+// copy of the package. Subpackages are left as is, since they have their own
+// .kptignore file.
+func (m *Matcher) Remove(dir string) error {
+	if m == nil {
+		return nil
+	}
+	var ignored []string
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && p != dir {
+			if _, err := os.Stat(filepath.Join(p, kptfilev1.KptFileName)); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if !m.Match(rel, info.IsDir()) {
+			return nil
+		}
+		ignored = append(ignored, p)
+		if info.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, p := range ignored {
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
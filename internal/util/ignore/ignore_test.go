@@ -0,0 +1,113 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+func TestMatch(t *testing.T) {
+	fsys := filesys.MakeFsInMemory()
+	require.NoError(t, fsys.WriteFile("/pkg/"+FileName, []byte(`# generated docs
+docs/
+*.md
+!KEEP.md
+/ci.yaml
+`)))
+	m, err := Load(fsys, "/pkg")
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		"ignored directory":           {path: "docs", isDir: true, expected: true},
+		"file in ignored directory":   {path: "docs/api/index.html", expected: true},
+		"file name pattern":           {path: "README.md", expected: true},
+		"file name pattern in subdir": {path: "app/NOTES.md", expected: true},
+		"negated pattern":             {path: "KEEP.md", expected: false},
+		"anchored pattern":            {path: "ci.yaml", expected: true},
+		"anchored pattern in subdir":  {path: "app/ci.yaml", expected: false},
+		"resource":                    {path: "app/deployment.yaml", expected: false},
+		"package root":                {path: ".", isDir: true, expected: false},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, m.Match(tc.path, tc.isDir))
+		})
+	}
+}
+
+func TestLoad_noFile(t *testing.T) {
+	m, err := Load(filesys.MakeFsInMemory(), "/pkg")
+	require.NoError(t, err)
+	assert.Nil(t, m)
+	// a nil matcher doesn't ignore anything.
+	assert.False(t, m.SkipFile("README.md"))
+	assert.NoError(t, m.Remove(t.TempDir()))
+}
+
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		FileName:                "docs/\n*.md\n",
+		"Kptfile":               "apiVersion: kpt.dev/v1\nkind: Kptfile\n",
+		"README.md":             "readme",
+		"deployment.yaml":       "kind: Deployment",
+		"docs/index.html":       "docs",
+		"app/NOTES.md":          "notes",
+		"app/service.yaml":      "kind: Service",
+		"subpkg/Kptfile":        "apiVersion: kpt.dev/v1\nkind: Kptfile\n",
+		"subpkg/README.md":      "subpackage readme",
+		"subpkg/configmap.yaml": "kind: ConfigMap",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+	}
+
+	m, err := Load(filesys.MakeFsOnDisk(), dir)
+	require.NoError(t, err)
+	require.NoError(t, m.Remove(dir))
+
+	var remaining []string
+	require.NoError(t, filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		remaining = append(remaining, filepath.ToSlash(rel))
+		return err
+	}))
+	sort.Strings(remaining)
+	assert.Equal(t, []string{
+		FileName,
+		"Kptfile",
+		"app/service.yaml",
+		"deployment.yaml",
+		"subpkg/Kptfile",
+		"subpkg/README.md",
+		"subpkg/configmap.yaml",
+	}, remaining)
+}
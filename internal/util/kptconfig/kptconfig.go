@@ -0,0 +1,348 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kptconfig reads the user's kpt config file, which provides
+// defaults for the flags and environment variables of kpt commands.
+package kptconfig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/httputil"
+	"github.com/GoogleContainerTools/kpt/internal/util/stats"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/http/httpproxy"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// ConfigEnv is the name of the environment variable that sets the path of
+// the config file. Defaults to UserHomeDir/.kpt/config.yaml.
+const ConfigEnv = "KPT_CONFIG"
+
+// Config is the kpt config file. Flags and environment variables take
+// precedence over the config.
+type Config struct {
+	// ContainerRuntime is the runtime of container functions: docker,
+	// podman or nerdctl. It is the default for KPT_FN_RUNTIME.
+	ContainerRuntime string `yaml:"containerRuntime,omitempty"`
+
+	// UpdateStrategy is the default of the --strategy flag of
+	// `kpt pkg get`, `kpt pkg update` and `kpt ws update`.
+	UpdateStrategy string `yaml:"updateStrategy,omitempty"`
+
+	// ResultsDir is the default of the --results-dir flag of
+	// `kpt fn render`, `kpt fn eval` and `kpt ws render`.
+	ResultsDir string `yaml:"resultsDir,omitempty"`
+
+	// RegistryMirrors maps image name prefixes, e.g. "gcr.io/kpt-fn", to
+	// the prefixes of the mirrors that container, wasm and composite
+	// functions are pulled from instead.
+	RegistryMirrors map[string]string `yaml:"registryMirrors,omitempty"`
+
+	// GitMirrors maps URL prefixes of remote git repos, e.g.
+	// "https://github.com", to the URL prefixes of the mirrors that they
+	// are fetched from instead.
+	GitMirrors map[string]string `yaml:"gitMirrors,omitempty"`
+
+	// Proxy is the proxy for the git repos and OCI registries.
+	Proxy *Proxy `yaml:"proxy,omitempty"`
+
+	// Credentials are the credentials for remote git repos.
+	Credentials []Credential `yaml:"credentials,omitempty"`
+
+	// Stats opts in to the recording of the durations of commands into
+	// the local stats file. It is the default for KPT_STATS.
+	Stats bool `yaml:"stats,omitempty"`
+}
+
+// Credential is a named credential for the remote git repos with URLs
+// that start with URL.
+type Credential struct {
+	Name string `yaml:"name"`
+	// URL is the URL prefix of the repos, e.g. "https://github.com/my-org".
+	URL string `yaml:"url"`
+	// Helper is the git credential helper that provides the credential,
+	// with the syntax of the git credential.helper setting.
+	Helper string `yaml:"helper"`
+}
+
+// Proxy is the HTTP(S) or SOCKS proxy for the requests to git repos and OCI
+// registries. Its fields are the defaults for the http_proxy, https_proxy
+// and no_proxy environment variables. Unlike the environment variables, it
+// isn't used for the requests to the Kubernetes API server.
+type Proxy struct {
+	// HTTP is the proxy URL for http requests, e.g.
+	// "http://proxy.example.com:3128" or "socks5://proxy.example.com:1080".
+	HTTP string `yaml:"http,omitempty"`
+	// HTTPS is the proxy URL for https requests.
+	HTTPS string `yaml:"https,omitempty"`
+	// NoProxy is the comma-separated list of hosts and domains that are
+	// accessed without the proxy.
+	NoProxy string `yaml:"noProxy,omitempty"`
+}
+
+// proxySchemes are the schemes of proxy URLs that both git and Go support.
+var proxySchemes = map[string]bool{"http": true, "https": true, "socks5": true, "socks5h": true}
+
+// Path returns the path of the config file.
+func Path() (string, error) {
+	if p := os.Getenv(ConfigEnv); p != "" {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kpt", "config.yaml"), nil
+}
+
+// Read reads the config file at path. A missing file is an empty config.
+//
+// The config is read leniently, so that a mistake in the config or a
+// setting of a newer version of kpt doesn't break every command: unknown
+// settings and invalid values are left out of the config, and a warning is
+// returned for each of them. Only failing to read the file is an error.
+func Read(path string) (*Config, []string, error) {
+	const op errors.Op = "kptconfig.Read"
+	c := &Config{}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errors.E(op, errors.IO, err)
+	}
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(b)).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return c, nil, nil
+		}
+		return c, []string{fmt.Sprintf("the config is ignored: %v", err)}, nil
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Tag == yaml.NodeTagNull {
+		return c, nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return c, []string{"the config is ignored: it must be a map of settings"}, nil
+	}
+
+	// every setting is decoded on its own, so that an invalid setting
+	// doesn't invalidate the others.
+	known := settings()
+	var warnings []string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if !known[key.Value] {
+			warnings = append(warnings, fmt.Sprintf("unknown setting %q is ignored", key.Value))
+			continue
+		}
+		// a setting that fails to decode may be decoded partially, so it
+		// is only decoded into the config once it is known to be valid.
+		setting := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{key, value}}
+		if err := setting.Decode(&Config{}); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v; it is ignored", key.Value, err))
+			continue
+		}
+		if err := setting.Decode(c); err != nil {
+			return nil, nil, errors.E(op, err)
+		}
+	}
+	return c, append(warnings, c.validate()...), nil
+}
+
+// settings returns the names of the settings of the config.
+func settings() map[string]bool {
+	names := make(map[string]bool)
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		names[name] = true
+	}
+	return names
+}
+
+// validate removes the invalid settings and entries of settings from the
+// config, and returns a warning for each of them.
+func (c *Config) validate() []string {
+	var warnings []string
+	warn := func(format string, a ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, a...)+"; it is ignored")
+	}
+	if _, err := fnruntime.StringToContainerRuntime(c.ContainerRuntime); err != nil {
+		warn("containerRuntime: %v", err)
+		c.ContainerRuntime = ""
+	}
+	if c.UpdateStrategy != "" {
+		if _, err := kptfilev1.ToUpdateStrategy(c.UpdateStrategy); err != nil {
+			warn("updateStrategy: %v", err)
+			c.UpdateStrategy = ""
+		}
+	}
+	for prefix, mirror := range c.RegistryMirrors {
+		if prefix == "" || mirror == "" || strings.HasSuffix(prefix, "/") || strings.HasSuffix(mirror, "/") {
+			warn("registryMirrors: %q: %q must map a non-empty image name prefix to a mirror, "+
+				"without trailing slashes", prefix, mirror)
+			delete(c.RegistryMirrors, prefix)
+		}
+	}
+	for prefix, mirror := range c.GitMirrors {
+		if prefix == "" || mirror == "" {
+			warn("gitMirrors: %q: %q must map a non-empty URL prefix to a mirror", prefix, mirror)
+			delete(c.GitMirrors, prefix)
+		}
+	}
+	if c.Proxy != nil {
+		for field, value := range map[string]*string{"http": &c.Proxy.HTTP, "https": &c.Proxy.HTTPS} {
+			if *value == "" {
+				continue
+			}
+			u, err := url.Parse(*value)
+			if err != nil || !proxySchemes[u.Scheme] || u.Host == "" {
+				warn("proxy.%s: %q must be a http, https, socks5 or socks5h URL", field, *value)
+				*value = ""
+			}
+		}
+	}
+	names := make(map[string]bool)
+	var credentials []Credential
+	for i, cred := range c.Credentials {
+		switch {
+		case cred.Name == "" || cred.URL == "" || cred.Helper == "":
+			warn("credentials[%d]: name, url and helper must be set", i)
+		case names[cred.Name]:
+			warn("credentials[%d]: duplicate name %q", i, cred.Name)
+		default:
+			names[cred.Name] = true
+			credentials = append(credentials, cred)
+		}
+	}
+	c.Credentials = credentials
+	sort.Strings(warnings)
+	return warnings
+}
+
+// Apply reads the config file and applies it to the kpt command root and
+// its subcommands. The warnings about the config are written to warn, and
+// don't fail the command.
+func Apply(root *cobra.Command, warn io.Writer) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	c, warnings, err := Read(path)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(warn, "warning: kpt config %q: %s\n", path, w)
+	}
+	return c.Apply(root)
+}
+
+// Apply applies the config to the kpt command root and its subcommands.
+// It sets the defaults of flags and environment variables, but doesn't
+// override environment variables that are already set.
+func (c *Config) Apply(root *cobra.Command) error {
+	if c.ContainerRuntime != "" {
+		if _, found := os.LookupEnv(fnruntime.ContainerRuntimeEnv); !found {
+			if err := os.Setenv(fnruntime.ContainerRuntimeEnv, c.ContainerRuntime); err != nil {
+				return err
+			}
+		}
+	}
+	if c.Stats {
+		if _, found := os.LookupEnv(stats.EnvVar); !found {
+			if err := os.Setenv(stats.EnvVar, "true"); err != nil {
+				return err
+			}
+		}
+	}
+	if c.Proxy != nil {
+		httputil.ProxyConfig = c.Proxy.config()
+	}
+	if c.UpdateStrategy != "" {
+		for _, path := range [][]string{{"pkg", "get"}, {"pkg", "update"}, {"ws", "update"}} {
+			if err := setFlagDefault(root, path, "strategy", c.UpdateStrategy); err != nil {
+				return err
+			}
+		}
+	}
+	if c.ResultsDir != "" {
+		for _, path := range [][]string{{"fn", "render"}, {"fn", "eval"}, {"ws", "render"}} {
+			if err := setFlagDefault(root, path, "results-dir", c.ResultsDir); err != nil {
+				return err
+			}
+		}
+	}
+	if len(c.RegistryMirrors) > 0 {
+		fnruntime.RegistryMirrors = c.RegistryMirrors
+	}
+	if len(c.GitMirrors) > 0 {
+		gitutil.Mirrors = c.GitMirrors
+	}
+	if len(c.Credentials) > 0 {
+		gitutil.CredentialHelpers = make(map[string]string)
+		for _, cred := range c.Credentials {
+			gitutil.CredentialHelpers[cred.URL] = cred.Helper
+		}
+	}
+	return nil
+}
+
+// config returns the proxy config of p. The proxy environment variables
+// take precedence over the fields of p.
+func (p *Proxy) config() *httpproxy.Config {
+	cfg := httpproxy.FromEnvironment()
+	if cfg.HTTPProxy == "" {
+		cfg.HTTPProxy = p.HTTP
+	}
+	if cfg.HTTPSProxy == "" {
+		cfg.HTTPSProxy = p.HTTPS
+	}
+	if cfg.NoProxy == "" {
+		cfg.NoProxy = p.NoProxy
+	}
+	return cfg
+}
+
+// setFlagDefault sets the default of the flag name of the subcommand of
+// root at path, if it exists.
+func setFlagDefault(root *cobra.Command, path []string, name, value string) error {
+	cmd, _, err := root.Find(path)
+	if err != nil || cmd == root {
+		return nil
+	}
+	f := cmd.Flags().Lookup(name)
+	if f == nil {
+		return nil
+	}
+	if err := f.Value.Set(value); err != nil {
+		return fmt.Errorf("invalid default %q for flag --%s of %q: %w", value, name, cmd.CommandPath(), err)
+	}
+	f.DefValue = value
+	return nil
+}
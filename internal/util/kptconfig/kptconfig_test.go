@@ -0,0 +1,248 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kptconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/httputil"
+	"github.com/GoogleContainerTools/kpt/internal/util/stats"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http/httpproxy"
+)
+
+func TestRead(t *testing.T) {
+	tests := map[string]struct {
+		config   string
+		expected *Config
+		warnings []string
+	}{
+		"empty": {
+			expected: &Config{},
+		},
+		"all settings": {
+			config: `
+containerRuntime: podman
+updateStrategy: fast-forward
+resultsDir: /tmp/results
+registryMirrors:
+  gcr.io/kpt-fn: mirror.example.com/kpt-fn
+credentials:
+- name: my-org
+  url: https://github.com/my-org
+  helper: "!gh auth git-credential"
+gitMirrors:
+  https://github.com: https://git.example.com/github
+proxy:
+  https: socks5://proxy.example.com:1080
+  noProxy: .example.com
+stats: true
+`,
+			expected: &Config{
+				ContainerRuntime: "podman",
+				UpdateStrategy:   "fast-forward",
+				ResultsDir:       "/tmp/results",
+				RegistryMirrors:  map[string]string{"gcr.io/kpt-fn": "mirror.example.com/kpt-fn"},
+				Credentials: []Credential{
+					{Name: "my-org", URL: "https://github.com/my-org", Helper: "!gh auth git-credential"},
+				},
+				GitMirrors: map[string]string{"https://github.com": "https://git.example.com/github"},
+				Proxy:      &Proxy{HTTPS: "socks5://proxy.example.com:1080", NoProxy: ".example.com"},
+				Stats:      true,
+			},
+		},
+		"unknown settings are ignored": {
+			config:   "runtime: podman\nupdateStrategy: fast-forward\n",
+			expected: &Config{UpdateStrategy: "fast-forward"},
+			warnings: []string{`unknown setting "runtime" is ignored`},
+		},
+		"settings that can't be decoded are ignored": {
+			config:   "stats: sometimes\nresultsDir: /tmp/results\n",
+			expected: &Config{ResultsDir: "/tmp/results"},
+			warnings: []string{"stats: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!str `sometimes` into bool; it is ignored"},
+		},
+		"invalid yaml": {
+			config:   "containerRuntime: [podman\n",
+			expected: &Config{},
+			warnings: []string{"the config is ignored: yaml: line 1: did not find expected ',' or ']'"},
+		},
+		"not a map": {
+			config:   "- podman\n",
+			expected: &Config{},
+			warnings: []string{"the config is ignored: it must be a map of settings"},
+		},
+		"invalid container runtime": {
+			config:   "containerRuntime: rkt\nresultsDir: /tmp/results\n",
+			expected: &Config{ResultsDir: "/tmp/results"},
+			warnings: []string{`containerRuntime: unsupported runtime: "rkt" ` +
+				`the runtime must be one of docker, podman and nerdctl; it is ignored`},
+		},
+		"invalid update strategy": {
+			config:   "updateStrategy: merge\n",
+			expected: &Config{},
+			warnings: []string{`updateStrategy: unknown update strategy "merge"; it is ignored`},
+		},
+		"invalid registry mirror": {
+			config: "registryMirrors:\n  gcr.io/kpt-fn/: mirror.example.com\n  docker.io: mirror.example.com/docker\n",
+			expected: &Config{
+				RegistryMirrors: map[string]string{"docker.io": "mirror.example.com/docker"},
+			},
+			warnings: []string{`registryMirrors: "gcr.io/kpt-fn/": "mirror.example.com" must map a non-empty image name prefix ` +
+				`to a mirror, without trailing slashes; it is ignored`},
+		},
+		"invalid proxy": {
+			config:   "proxy:\n  http: proxy.example.com:3128\n  noProxy: .example.com\n",
+			expected: &Config{Proxy: &Proxy{NoProxy: ".example.com"}},
+			warnings: []string{`proxy.http: "proxy.example.com:3128" must be a http, https, socks5 or socks5h URL; it is ignored`},
+		},
+		"duplicate credential": {
+			config: `
+credentials:
+- name: a
+  url: https://github.com/a
+  helper: store
+- name: a
+  url: https://github.com/b
+  helper: store
+`,
+			expected: &Config{
+				Credentials: []Credential{{Name: "a", URL: "https://github.com/a", Helper: "store"}},
+			},
+			warnings: []string{`credentials[1]: duplicate name "a"; it is ignored`},
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0600))
+			c, warnings, err := Read(path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, c)
+			assert.Equal(t, tc.warnings, warnings)
+		})
+	}
+}
+
+func TestRead_missingFile(t *testing.T) {
+	c, warnings, err := Read(filepath.Join(t.TempDir(), "config.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, &Config{}, c)
+}
+
+func TestConfig_Apply(t *testing.T) {
+	defer func(mirrors, helpers, gitMirrors map[string]string, proxy *httpproxy.Config) {
+		fnruntime.RegistryMirrors = mirrors
+		gitutil.CredentialHelpers = helpers
+		gitutil.Mirrors = gitMirrors
+		httputil.ProxyConfig = proxy
+	}(fnruntime.RegistryMirrors, gitutil.CredentialHelpers, gitutil.Mirrors, httputil.ProxyConfig)
+	t.Setenv(fnruntime.ContainerRuntimeEnv, "docker")
+	t.Setenv(stats.EnvVar, "")
+	require.NoError(t, os.Unsetenv(stats.EnvVar))
+	for _, name := range []string{"http_proxy", "HTTP_PROXY", "https_proxy", "HTTPS_PROXY", "no_proxy", "NO_PROXY"} {
+		t.Setenv(name, "")
+		require.NoError(t, os.Unsetenv(name))
+	}
+	t.Setenv("HTTPS_PROXY", "http://env.example.com:3128")
+
+	root := &cobra.Command{Use: "kpt"}
+	pkgCmd := &cobra.Command{Use: "pkg"}
+	updateCmd := &cobra.Command{Use: "update"}
+	strategy := updateCmd.Flags().String("strategy", "resource-merge", "")
+	fnCmd := &cobra.Command{Use: "fn"}
+	renderCmd := &cobra.Command{Use: "render"}
+	resultsDir := renderCmd.Flags().String("results-dir", "", "")
+	pkgCmd.AddCommand(updateCmd)
+	fnCmd.AddCommand(renderCmd)
+	root.AddCommand(pkgCmd, fnCmd)
+
+	c := &Config{
+		ContainerRuntime: "podman",
+		UpdateStrategy:   "fast-forward",
+		ResultsDir:       "/tmp/results",
+		RegistryMirrors:  map[string]string{"gcr.io/kpt-fn": "mirror.example.com/kpt-fn"},
+		Credentials: []Credential{
+			{Name: "my-org", URL: "https://github.com/my-org", Helper: "store"},
+		},
+		GitMirrors: map[string]string{"https://github.com": "https://git.example.com/github"},
+		Proxy: &Proxy{
+			HTTP:    "http://proxy.example.com:3128",
+			HTTPS:   "http://proxy.example.com:3128",
+			NoProxy: ".example.com",
+		},
+		Stats: true,
+	}
+	require.NoError(t, c.Apply(root))
+
+	assert.Equal(t, "fast-forward", *strategy)
+	assert.Equal(t, "fast-forward", updateCmd.Flags().Lookup("strategy").DefValue)
+	assert.Equal(t, "/tmp/results", *resultsDir)
+	// environment variables take precedence over the config.
+	assert.Equal(t, "docker", os.Getenv(fnruntime.ContainerRuntimeEnv))
+	assert.Equal(t, "true", os.Getenv(stats.EnvVar))
+	assert.Equal(t, c.RegistryMirrors, fnruntime.RegistryMirrors)
+	assert.Equal(t, map[string]string{"https://github.com/my-org": "store"}, gitutil.CredentialHelpers)
+	assert.Equal(t, c.GitMirrors, gitutil.Mirrors)
+	// the proxy is only used for git and the registries, not set in the
+	// environment of kpt, which is read by the Kubernetes client.
+	assert.Equal(t, &httpproxy.Config{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "http://env.example.com:3128",
+		NoProxy:    ".example.com",
+	}, httputil.ProxyConfig)
+	assert.Equal(t, []string{
+		"http_proxy=http://proxy.example.com:3128",
+		"https_proxy=http://env.example.com:3128",
+		"no_proxy=.example.com",
+	}, httputil.ProxyEnv())
+	for _, name := range []string{"http_proxy", "https_proxy", "no_proxy"} {
+		_, found := os.LookupEnv(name)
+		assert.False(t, found, name)
+	}
+
+	// flags take precedence over the config.
+	root.SetArgs([]string{"pkg", "update", "--strategy", "resource-merge"})
+	updateCmd.Run = func(*cobra.Command, []string) {}
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "resource-merge", *strategy)
+}
+
+func TestApply_warnings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("newSetting: true\nupdateStrategy: fast-forward\n"), 0600))
+	t.Setenv(ConfigEnv, path)
+
+	root := &cobra.Command{Use: "kpt"}
+	pkgCmd := &cobra.Command{Use: "pkg"}
+	updateCmd := &cobra.Command{Use: "update"}
+	strategy := updateCmd.Flags().String("strategy", "resource-merge", "")
+	pkgCmd.AddCommand(updateCmd)
+	root.AddCommand(pkgCmd)
+
+	// the settings of a newer kpt don't keep the others from being applied.
+	warn := &bytes.Buffer{}
+	require.NoError(t, Apply(root, warn))
+	assert.Equal(t, "fast-forward", *strategy)
+	assert.Equal(t, fmt.Sprintf("warning: kpt config %q: unknown setting \"newSetting\" is ignored\n", path), warn.String())
+}
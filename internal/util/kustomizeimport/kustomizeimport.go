@@ -0,0 +1,338 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kustomizeimport converts a tree of kustomizations into a kpt
+// package, with a subpackage for each overlay.
+package kustomizeimport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
+	"github.com/GoogleContainerTools/kpt/pkg/kptpkg"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kustomizetypes "sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// The images of the functions the kustomize transformers are mapped to.
+const (
+	SetNamespaceImage   = "gcr.io/kpt-fn/set-namespace:v0.4.1"
+	SetLabelsImage      = "gcr.io/kpt-fn/set-labels:v0.1.5"
+	SetAnnotationsImage = "gcr.io/kpt-fn/set-annotations:v0.1.4"
+	SetImageImage       = "gcr.io/kpt-fn/set-image:v0.1.1"
+)
+
+// structuralFields are the fields of a kustomization that don't transform
+// the resources.
+var structuralFields = map[string]bool{
+	"apiVersion": true,
+	"kind":       true,
+	"metadata":   true,
+	"resources":  true,
+	"bases":      true,
+	"components": true,
+}
+
+// Overlay is a kustomization that is not the base of another kustomization,
+// imported as a package.
+type Overlay struct {
+	// Path is the slash-separated path of the kustomization relative to the
+	// source directory, which is also the path of the package relative to
+	// the destination. The kustomization of the source directory is ".".
+	Path string
+	// Resources is the number of resources of the package.
+	Resources int
+	// Functions are the images of the functions the transformers of the
+	// kustomization are mapped to, in pipeline order.
+	Functions []string
+	// Unmapped are the fields of the kustomization that transform the
+	// resources, but have no equivalent function. They are applied to the
+	// resources of the package, but not kept in its pipeline.
+	Unmapped []string
+}
+
+// kustomization is a kustomization found in the source directory.
+type kustomization struct {
+	kustomizetypes.Kustomization
+	// fields are the names of the top-level fields of the kustomization.
+	fields []string
+}
+
+// Import imports the kustomizations in the src directory into a new kpt
+// package at dest. Every overlay is inflated with kustomize, and its
+// resources are written to a subpackage of dest at the same path, or to dest
+// itself if the only overlay is src. The namespace, labels, annotations and
+// images of an overlay are also mapped to functions of the pipeline of its
+// package, so that they are applied again when the package is rendered.
+func Import(ctx context.Context, src, dest string) ([]Overlay, error) {
+	if entries, err := os.ReadDir(dest); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("destination directory %q already exists and is not empty", dest)
+	}
+	kustomizations, err := findKustomizations(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(kustomizations) == 0 {
+		return nil, fmt.Errorf("no kustomization found in %q", src)
+	}
+	paths := overlayPaths(src, kustomizations)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("every kustomization in %q is the base of another kustomization", src)
+	}
+
+	if paths[0] != "." {
+		if err := initPackage(ctx, dest, filepath.Base(dest)); err != nil {
+			return nil, err
+		}
+	}
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	var overlays []Overlay
+	for _, p := range paths {
+		o, err := importOverlay(ctx, k, filepath.Join(src, p), filepath.Join(dest, p),
+			filepath.Join(filepath.Base(dest), p), kustomizations[p])
+		if err != nil {
+			return nil, fmt.Errorf("unable to import kustomization %q: %w", p, err)
+		}
+		o.Path = filepath.ToSlash(p)
+		overlays = append(overlays, o)
+	}
+	return overlays, nil
+}
+
+// findKustomizations returns the kustomizations in src by their relative
+// path.
+func findKustomizations(src string) (map[string]*kustomization, error) {
+	kustomizations := map[string]*kustomization{}
+	err := filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != src && d.Name()[0] == '.' {
+			return filepath.SkipDir
+		}
+		for _, name := range konfig.RecognizedKustomizationFileNames() {
+			b, err := os.ReadFile(filepath.Join(path, name))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			k := &kustomization{}
+			if err := yaml.Unmarshal(b, &k.Kustomization); err != nil {
+				return fmt.Errorf("invalid kustomization %q: %w", filepath.Join(path, name), err)
+			}
+			fields := map[string]interface{}{}
+			if err := yaml.Unmarshal(b, &fields); err != nil {
+				return fmt.Errorf("invalid kustomization %q: %w", filepath.Join(path, name), err)
+			}
+			for f := range fields {
+				k.fields = append(k.fields, f)
+			}
+			sort.Strings(k.fields)
+			rel, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+			kustomizations[rel] = k
+			break
+		}
+		return nil
+	})
+	return kustomizations, err
+}
+
+// overlayPaths returns the sorted paths of the kustomizations that are not
+// referenced by another kustomization as a resource, base or component.
+func overlayPaths(src string, kustomizations map[string]*kustomization) []string {
+	referenced := map[string]bool{}
+	for p, k := range kustomizations {
+		refs := append(append(append([]string{}, k.Resources...), k.Bases...), k.Components...)
+		for _, ref := range refs {
+			rel, err := filepath.Rel(src, filepath.Join(src, p, ref))
+			if err == nil {
+				referenced[rel] = true
+			}
+		}
+	}
+	var paths []string
+	for p := range kustomizations {
+		if !referenced[p] {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// importOverlay inflates the kustomization at dir into the package at
+// pkgPath, which is displayed as displayPath.
+func importOverlay(ctx context.Context, k *krusty.Kustomizer, dir, pkgPath, displayPath string, kust *kustomization) (Overlay, error) {
+	resMap, err := k.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return Overlay{}, err
+	}
+	b, err := resMap.AsYaml()
+	if err != nil {
+		return Overlay{}, err
+	}
+	nodes, err := (&kio.ByteReader{Reader: bytes.NewReader(b), OmitReaderAnnotations: true}).Read()
+	if err != nil {
+		return Overlay{}, err
+	}
+	if err := setPaths(nodes); err != nil {
+		return Overlay{}, err
+	}
+
+	if err := initPackage(ctx, pkgPath, displayPath); err != nil {
+		return Overlay{}, err
+	}
+	if err := (kio.LocalPackageWriter{PackagePath: pkgPath}).Write(nodes); err != nil {
+		return Overlay{}, err
+	}
+
+	fns, unmapped := mapTransformers(kust)
+	kf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, pkgPath)
+	if err != nil {
+		return Overlay{}, err
+	}
+	if len(fns) > 0 {
+		kf.Pipeline = &kptfilev1.Pipeline{Mutators: fns}
+	}
+	if err := kptfileutil.WriteFile(pkgPath, kf); err != nil {
+		return Overlay{}, err
+	}
+
+	o := Overlay{
+		Resources: len(nodes),
+		Unmapped:  unmapped,
+	}
+	for _, fn := range fns {
+		o.Functions = append(o.Functions, fn.Image)
+	}
+	return o, nil
+}
+
+// setPaths sets the path of each resource to kind_name.yaml. Resources with
+// the same kind and name in different namespaces are written to the same
+// file.
+func setPaths(nodes []*kyaml.RNode) error {
+	counts := map[string]int{}
+	for _, n := range nodes {
+		path := fmt.Sprintf("%s_%s.yaml", strings.ToLower(n.GetKind()), n.GetName())
+		if err := n.PipeE(kyaml.SetAnnotation(kioutil.PathAnnotation, path)); err != nil {
+			return err
+		}
+		if err := n.PipeE(kyaml.SetAnnotation(kioutil.IndexAnnotation, strconv.Itoa(counts[path]))); err != nil {
+			return err
+		}
+		counts[path]++
+	}
+	return nil
+}
+
+// initPackage creates the directory of a package and initializes it like
+// `kpt pkg init`.
+func initPackage(ctx context.Context, pkgPath, relPath string) error {
+	if err := os.MkdirAll(pkgPath, 0755); err != nil {
+		return err
+	}
+	return (&kptpkg.DefaultInitializer{}).Initialize(ctx, filesys.FileSystemOrOnDisk{}, kptpkg.InitOptions{
+		PkgPath: pkgPath,
+		RelPath: relPath,
+		Desc:    "Imported from a kustomization.",
+	})
+}
+
+// mapTransformers maps the transformers of kust to functions. It also
+// returns the fields of kust that transform the resources, but aren't
+// mapped.
+func mapTransformers(kust *kustomization) ([]kptfilev1.Function, []string) {
+	var fns []kptfilev1.Function
+	mapped := map[string]bool{}
+	if kust.Namespace != "" {
+		mapped["namespace"] = true
+		fns = append(fns, kptfilev1.Function{
+			Image:     SetNamespaceImage,
+			ConfigMap: map[string]string{"namespace": kust.Namespace},
+		})
+	}
+
+	// set-labels also sets the labels in selectors, so only the labels
+	// that kustomize adds to selectors are mapped.
+	labels := map[string]string{}
+	for k, v := range kust.CommonLabels {
+		labels[k] = v
+	}
+	mapped["commonLabels"] = true
+	mapped["labels"] = true
+	for _, l := range kust.Labels {
+		if !l.IncludeSelectors || len(l.FieldSpecs) > 0 {
+			mapped["labels"] = false
+			continue
+		}
+		for k, v := range l.Pairs {
+			labels[k] = v
+		}
+	}
+	if len(labels) > 0 {
+		fns = append(fns, kptfilev1.Function{Image: SetLabelsImage, ConfigMap: labels})
+	}
+
+	if len(kust.CommonAnnotations) > 0 {
+		mapped["commonAnnotations"] = true
+		fns = append(fns, kptfilev1.Function{Image: SetAnnotationsImage, ConfigMap: kust.CommonAnnotations})
+	}
+
+	mapped["images"] = true
+	for _, image := range kust.Images {
+		config := map[string]string{"name": image.Name}
+		if image.NewName != "" {
+			config["newName"] = image.NewName
+		}
+		if image.NewTag != "" {
+			config["newTag"] = image.NewTag
+		}
+		if image.Digest != "" {
+			config["digest"] = image.Digest
+		}
+		fns = append(fns, kptfilev1.Function{Image: SetImageImage, ConfigMap: config})
+	}
+
+	var unmapped []string
+	for _, f := range kust.fields {
+		if !structuralFields[f] && !mapped[f] {
+			unmapped = append(unmapped, f)
+		}
+	}
+	return fns, unmapped
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kustomizeimport
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.24
+`
+
+const configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+data:
+  key: value
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+	}
+}
+
+func TestImport(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{
+		"base/kustomization.yaml": "resources:\n- deployment.yaml\n- configmap.yaml\n",
+		"base/deployment.yaml":    deployment,
+		"base/configmap.yaml":     configMap,
+		"overlays/dev/kustomization.yaml": `resources:
+- ../../base
+namespace: dev
+commonLabels:
+  env: dev
+images:
+- name: nginx
+  newTag: "1.25"
+`,
+		"overlays/prod/kustomization.yaml": `resources:
+- ../../base
+namespace: prod
+namePrefix: prod-
+commonAnnotations:
+  team: web
+`,
+	})
+	dest := filepath.Join(t.TempDir(), "web")
+
+	overlays, err := Import(fake.CtxWithDefaultPrinter(), src, dest)
+	require.NoError(t, err)
+	assert.Equal(t, []Overlay{
+		{
+			Path:      "overlays/dev",
+			Resources: 2,
+			Functions: []string{SetNamespaceImage, SetLabelsImage, SetImageImage},
+		},
+		{
+			Path:      "overlays/prod",
+			Resources: 2,
+			Functions: []string{SetNamespaceImage, SetAnnotationsImage},
+			Unmapped:  []string{"namePrefix"},
+		},
+	}, overlays)
+
+	kf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, dest)
+	require.NoError(t, err)
+	assert.Equal(t, "web", kf.Name)
+
+	kf, err = pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, filepath.Join(dest, "overlays", "dev"))
+	require.NoError(t, err)
+	assert.Equal(t, "dev", kf.Name)
+	assert.Equal(t, &kptfilev1.Pipeline{Mutators: []kptfilev1.Function{
+		{Image: SetNamespaceImage, ConfigMap: map[string]string{"namespace": "dev"}},
+		{Image: SetLabelsImage, ConfigMap: map[string]string{"env": "dev"}},
+		{Image: SetImageImage, ConfigMap: map[string]string{"name": "nginx", "newTag": "1.25"}},
+	}}, kf.Pipeline)
+
+	b, err := os.ReadFile(filepath.Join(dest, "overlays", "dev", "deployment_web.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "namespace: dev")
+	assert.Contains(t, string(b), "image: nginx:1.25")
+	assert.NotContains(t, string(b), "config.kubernetes.io")
+
+	assert.FileExists(t, filepath.Join(dest, "overlays", "prod", "configmap_prod-web-config.yaml"))
+}
+
+func TestImport_SingleKustomization(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{
+		"kustomization.yaml": "resources:\n- deployment.yaml\nnamespace: default\n",
+		"deployment.yaml":    deployment,
+	})
+	dest := filepath.Join(t.TempDir(), "web")
+
+	overlays, err := Import(fake.CtxWithDefaultPrinter(), src, dest)
+	require.NoError(t, err)
+	assert.Equal(t, []Overlay{{Path: ".", Resources: 1, Functions: []string{SetNamespaceImage}}}, overlays)
+	assert.FileExists(t, filepath.Join(dest, "deployment_web.yaml"))
+	assert.FileExists(t, filepath.Join(dest, kptfilev1.KptFileName))
+}
+
+func TestImport_Errors(t *testing.T) {
+	src := t.TempDir()
+	_, err := Import(fake.CtxWithDefaultPrinter(), src, filepath.Join(t.TempDir(), "web"))
+	assert.EqualError(t, err, `no kustomization found in "`+src+`"`)
+
+	dest := t.TempDir()
+	writeFiles(t, dest, map[string]string{"foo.yaml": configMap})
+	_, err = Import(fake.CtxWithDefaultPrinter(), src, dest)
+	assert.EqualError(t, err, `destination directory "`+dest+`" already exists and is not empty`)
+}
@@ -0,0 +1,377 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint statically checks the Kptfiles and resources of packages
+// for common problems, without running any functions.
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/builtins"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/filters"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// The names of the checks.
+const (
+	// CheckKptfile checks that the Kptfiles of the package and its
+	// subpackages can be read and are valid.
+	CheckKptfile = "kptfile"
+	// CheckImageTag checks that the function images of the pipelines are
+	// pinned to a tag other than latest, or to a digest.
+	CheckImageTag = "image-tag"
+	// CheckUpstreamLock checks that the upstreamLock of the packages with
+	// an upstream matches it.
+	CheckUpstreamLock = "upstream-lock"
+	// CheckPackageContext checks that every package has a valid
+	// package-context.yaml.
+	CheckPackageContext = "package-context"
+	// CheckDuplicateResource checks that no two resources of the package
+	// have the same group, kind, namespace and name.
+	CheckDuplicateResource = "duplicate-resource"
+)
+
+// Checks lists the names of all checks.
+var Checks = []string{
+	CheckKptfile,
+	CheckImageTag,
+	CheckUpstreamLock,
+	CheckPackageContext,
+	CheckDuplicateResource,
+}
+
+// Finding is a problem found by a check.
+type Finding struct {
+	// Check is the name of the check that found the problem.
+	Check string
+	// Path is the slash-separated path of the file with the problem,
+	// relative to the root package.
+	Path string
+	// Message describes the problem.
+	Message string
+	// Fix suggests how to fix the problem. It may be empty.
+	Fix string
+}
+
+// Linter checks a package and its subpackages.
+type Linter struct {
+	// FileSystem is the filesystem the package is read from.
+	FileSystem filesys.FileSystem
+	// Skip are the names of the checks that are not run.
+	Skip map[string]bool
+
+	root     string
+	findings []Finding
+}
+
+// Lint runs the checks on the package at pkgPath and its subpackages, and
+// returns the problems found, ordered by path.
+func (l *Linter) Lint(pkgPath string) ([]Finding, error) {
+	l.root = pkgPath
+	l.findings = nil
+
+	pkgPaths, err := l.packagePaths()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pkgPaths {
+		kf, ok := l.readKptfile(p)
+		if !ok {
+			continue
+		}
+		if !l.Skip[CheckImageTag] {
+			l.checkImageTags(p, kf)
+		}
+		if !l.Skip[CheckUpstreamLock] {
+			l.checkUpstreamLock(p, kf)
+		}
+		if !l.Skip[CheckPackageContext] {
+			l.checkPackageContext(p)
+		}
+	}
+	if !l.Skip[CheckDuplicateResource] {
+		if err := l.checkDuplicateResources(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		return l.findings[i].Path < l.findings[j].Path
+	})
+	return l.findings, nil
+}
+
+// packagePaths returns the paths of the package and all its subpackages.
+// Unlike pkg.Subpackages, it doesn't read their Kptfiles, so packages with
+// invalid Kptfiles are included.
+func (l *Linter) packagePaths() ([]string, error) {
+	var paths []string
+	err := l.FileSystem.Walk(l.root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if isPkg, err := pkg.IsPackageDir(l.FileSystem, p); err != nil || isPkg {
+			paths = append(paths, p)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func (l *Linter) add(check, p, message, fix string) {
+	rel, err := filepath.Rel(l.root, p)
+	if err != nil {
+		rel = p
+	}
+	l.findings = append(l.findings, Finding{
+		Check:   check,
+		Path:    filepath.ToSlash(rel),
+		Message: message,
+		Fix:     fix,
+	})
+}
+
+// readKptfile reads and validates the Kptfile of the package at pkgPath.
+// It returns false if the Kptfile can't be read, in which case the other
+// checks of the package are skipped.
+func (l *Linter) readKptfile(pkgPath string) (*kptfilev1.KptFile, bool) {
+	kptfilePath := filepath.Join(pkgPath, kptfilev1.KptFileName)
+	kf, err := pkg.ReadKptfile(l.FileSystem, pkgPath)
+	if err != nil {
+		if !l.Skip[CheckKptfile] {
+			var fix string
+			var deprecated *pkg.DeprecatedKptfileError
+			if errors.As(err, &deprecated) {
+				fix = "migrate the Kptfile to " + kptfilev1.KptFileAPIVersion
+			}
+			var kerr *pkg.KptfileError
+			if errors.As(err, &kerr) {
+				err = kerr.Err
+			}
+			l.add(CheckKptfile, kptfilePath, err.Error(), fix)
+		}
+		return nil, false
+	}
+	if l.Skip[CheckKptfile] {
+		return kf, true
+	}
+	if kf.Name == "" {
+		l.add(CheckKptfile, kptfilePath, "metadata.name is not set",
+			fmt.Sprintf("set metadata.name to %q", filepath.Base(pkgPath)))
+	}
+	if err := kf.Validate(l.FileSystem, types.UniquePath(pkgPath)); err != nil {
+		l.add(CheckKptfile, kptfilePath, err.Error(), "")
+	}
+	return kf, true
+}
+
+// checkImageTags reports the function images of the Kptfile that are not
+// pinned to a version.
+func (l *Linter) checkImageTags(pkgPath string, kf *kptfilev1.KptFile) {
+	kptfilePath := filepath.Join(pkgPath, kptfilev1.KptFileName)
+	check := func(field string, fns []kptfilev1.Function) {
+		for i, f := range fns {
+			if f.Image == "" {
+				continue
+			}
+			var reason string
+			switch tag, pinned := imageTag(f.Image); {
+			case pinned:
+				continue
+			case tag == "":
+				reason = "has no tag"
+			case tag == "latest":
+				reason = "uses the latest tag"
+			default:
+				continue
+			}
+			l.add(CheckImageTag, kptfilePath,
+				fmt.Sprintf("%s[%d].image %q %s, so the package may render differently over time", field, i, f.Image, reason),
+				"pin the image to a version tag or a digest")
+		}
+	}
+	if kf.Pipeline != nil {
+		check("pipeline.mutators", kf.Pipeline.Mutators)
+		check("pipeline.validators", kf.Pipeline.Validators)
+	}
+	if kf.Upstream != nil {
+		check("upstream.overrides", kf.Upstream.Overrides)
+	}
+}
+
+// imageTag returns the tag of image, and whether it is pinned to a digest.
+func imageTag(image string) (string, bool) {
+	if strings.Contains(image, "@") {
+		return "", true
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:], false
+	}
+	return "", false
+}
+
+// checkUpstreamLock reports the packages whose upstreamLock doesn't match
+// their upstream.
+func (l *Linter) checkUpstreamLock(pkgPath string, kf *kptfilev1.KptFile) {
+	kptfilePath := filepath.Join(pkgPath, kptfilev1.KptFileName)
+	const fetchFix = "run `kpt pkg update` to fetch the upstream and record the upstreamLock"
+	up, lock := kf.Upstream, kf.UpstreamLock
+	switch {
+	case up == nil && lock == nil:
+		return
+	case up == nil:
+		l.add(CheckUpstreamLock, kptfilePath, "upstreamLock is set, but upstream is not",
+			"remove upstreamLock, or set upstream to the package it was fetched from")
+		return
+	case lock == nil:
+		l.add(CheckUpstreamLock, kptfilePath, "upstream is set, but upstreamLock is not", fetchFix)
+		return
+	case up.Type != lock.Type:
+		l.add(CheckUpstreamLock, kptfilePath,
+			fmt.Sprintf("upstream.type %q doesn't match upstreamLock.type %q", up.Type, lock.Type), fetchFix)
+		return
+	case up.Git == nil || lock.Git == nil:
+		if (up.Git == nil) != (lock.Git == nil) {
+			l.add(CheckUpstreamLock, kptfilePath, "only one of upstream.git and upstreamLock.git is set", fetchFix)
+		}
+		return
+	}
+	if normalizeRepo(up.Git.Repo) != normalizeRepo(lock.Git.Repo) {
+		l.add(CheckUpstreamLock, kptfilePath,
+			fmt.Sprintf("upstream.git.repo %q doesn't match upstreamLock.git.repo %q", up.Git.Repo, lock.Git.Repo), fetchFix)
+	}
+	if normalizeDirectory(up.Git.Directory) != normalizeDirectory(lock.Git.Directory) {
+		l.add(CheckUpstreamLock, kptfilePath,
+			fmt.Sprintf("upstream.git.directory %q doesn't match upstreamLock.git.directory %q",
+				up.Git.Directory, lock.Git.Directory), fetchFix)
+	}
+	if up.Git.Ref != lock.Git.Ref {
+		l.add(CheckUpstreamLock, kptfilePath,
+			fmt.Sprintf("upstream.git.ref %q doesn't match upstreamLock.git.ref %q", up.Git.Ref, lock.Git.Ref),
+			fmt.Sprintf("run `kpt pkg update` to update the package to %q", up.Git.Ref))
+	}
+	if lock.Git.Commit == "" {
+		l.add(CheckUpstreamLock, kptfilePath, "upstreamLock.git.commit is not set", fetchFix)
+	}
+}
+
+func normalizeRepo(repo string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+}
+
+func normalizeDirectory(dir string) string {
+	return path.Clean("/" + filepath.ToSlash(dir))
+}
+
+// checkPackageContext reports the packages without a valid
+// package-context.yaml.
+func (l *Linter) checkPackageContext(pkgPath string) {
+	contextPath := filepath.Join(pkgPath, builtins.PkgContextFile)
+	if !l.FileSystem.Exists(contextPath) {
+		l.add(CheckPackageContext, contextPath, builtins.PkgContextFile+" is missing",
+			"run `kpt pkg init` in the package directory to create it")
+		return
+	}
+	b, err := l.FileSystem.ReadFile(contextPath)
+	if err != nil {
+		l.add(CheckPackageContext, contextPath, err.Error(), "")
+		return
+	}
+	node, err := yaml.Parse(string(b))
+	if err != nil {
+		l.add(CheckPackageContext, contextPath, fmt.Sprintf("invalid YAML: %v", err), "")
+		return
+	}
+	if node.GetKind() != "ConfigMap" || node.GetName() != builtins.PkgContextName {
+		l.add(CheckPackageContext, contextPath,
+			fmt.Sprintf("must contain the ConfigMap %q", builtins.PkgContextName), "")
+		return
+	}
+	if node.GetDataMap()["name"] == "" {
+		l.add(CheckPackageContext, contextPath, "data.name is not set", "set data.name to the name of the package")
+	}
+}
+
+// checkDuplicateResources reports the resources with the same group, kind,
+// namespace and name as an earlier resource. Local config resources, such
+// as function configs and package contexts, are ignored.
+func (l *Linter) checkDuplicateResources() error {
+	reader := &kio.LocalPackageReader{
+		PackagePath:        l.root,
+		PackageFileName:    kptfilev1.KptFileName,
+		IncludeSubpackages: true,
+		MatchFilesGlob:     pkg.MatchAllKRM,
+		WrapBareSeqNode:    true,
+		FileSystem:         filesys.FileSystemOrOnDisk{FileSystem: l.FileSystem},
+	}
+	resources, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	type resourceID struct {
+		gk              schema.GroupKind
+		namespace, name string
+	}
+	files := map[resourceID]string{}
+	for _, r := range resources {
+		if _, local := r.GetAnnotations()[filters.LocalConfigAnnotation]; local || r.GetKind() == kptfilev1.KptFileKind {
+			continue
+		}
+		id := resourceID{
+			gk:        schema.FromAPIVersionAndKind(r.GetApiVersion(), r.GetKind()).GroupKind(),
+			namespace: r.GetNamespace(),
+			name:      r.GetName(),
+		}
+		file, _, _ := kioutil.GetFileAnnotations(r)
+		file = filepath.ToSlash(file)
+		if first, found := files[id]; found {
+			name := id.name
+			if id.namespace != "" {
+				name = id.namespace + "/" + id.name
+			}
+			l.add(CheckDuplicateResource, filepath.Join(l.root, file),
+				fmt.Sprintf("%s %s is also defined in %s", strings.ToLower(id.gk.String()), name, first),
+				"remove or rename one of the resources")
+			continue
+		}
+		files[id] = file
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const pkgContext = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kptfile.kpt.dev
+  annotations:
+    config.kubernetes.io/local-config: "true"
+data:
+  name: example
+`
+
+func TestLint(t *testing.T) {
+	tests := map[string]struct {
+		files    map[string]string
+		skip     map[string]bool
+		expected []Finding
+	}{
+		"no problems": {
+			files: map[string]string{
+				"Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+upstream:
+  type: git
+  git:
+    repo: https://github.com/example/blueprints
+    directory: /pkg
+    ref: v1
+upstreamLock:
+  type: git
+  git:
+    repo: https://github.com/example/blueprints.git
+    directory: pkg
+    ref: v1
+    commit: 0123456789abcdef
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-labels:v0.1
+  validators:
+  - image: gcr.io/kpt-fn/kubeval@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+`,
+				"package-context.yaml": pkgContext,
+				"cm.yaml":              "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+			},
+		},
+		"image tags": {
+			files: map[string]string{
+				"Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-labels:latest
+  - image: localhost:5000/set-namespace
+`,
+				"package-context.yaml": pkgContext,
+			},
+			expected: []Finding{
+				{
+					Check:   CheckImageTag,
+					Path:    "Kptfile",
+					Message: `pipeline.mutators[0].image "gcr.io/kpt-fn/set-labels:latest" uses the latest tag, so the package may render differently over time`,
+					Fix:     "pin the image to a version tag or a digest",
+				},
+				{
+					Check:   CheckImageTag,
+					Path:    "Kptfile",
+					Message: `pipeline.mutators[1].image "localhost:5000/set-namespace" has no tag, so the package may render differently over time`,
+					Fix:     "pin the image to a version tag or a digest",
+				},
+			},
+		},
+		"upstream lock": {
+			files: map[string]string{
+				"Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+upstream:
+  type: git
+  git:
+    repo: https://github.com/example/blueprints
+    directory: /pkg
+    ref: v2
+upstreamLock:
+  type: git
+  git:
+    repo: https://github.com/example/other
+    directory: /pkg
+    ref: v1
+`,
+				"package-context.yaml": pkgContext,
+				"sub/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: sub
+upstream:
+  type: git
+  git:
+    repo: https://github.com/example/blueprints
+    directory: /sub
+    ref: v1
+`,
+				"sub/package-context.yaml": pkgContext,
+			},
+			expected: []Finding{
+				{
+					Check:   CheckUpstreamLock,
+					Path:    "Kptfile",
+					Message: `upstream.git.repo "https://github.com/example/blueprints" doesn't match upstreamLock.git.repo "https://github.com/example/other"`,
+					Fix:     "run `kpt pkg update` to fetch the upstream and record the upstreamLock",
+				},
+				{
+					Check:   CheckUpstreamLock,
+					Path:    "Kptfile",
+					Message: `upstream.git.ref "v2" doesn't match upstreamLock.git.ref "v1"`,
+					Fix:     `run ` + "`kpt pkg update`" + ` to update the package to "v2"`,
+				},
+				{
+					Check:   CheckUpstreamLock,
+					Path:    "Kptfile",
+					Message: "upstreamLock.git.commit is not set",
+					Fix:     "run `kpt pkg update` to fetch the upstream and record the upstreamLock",
+				},
+				{
+					Check:   CheckUpstreamLock,
+					Path:    "sub/Kptfile",
+					Message: "upstream is set, but upstreamLock is not",
+					Fix:     "run `kpt pkg update` to fetch the upstream and record the upstreamLock",
+				},
+			},
+		},
+		"package context": {
+			files: map[string]string{
+				"Kptfile":                  "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n",
+				"sub/Kptfile":              "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: sub\n",
+				"sub/package-context.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: kptfile.kpt.dev\n",
+			},
+			expected: []Finding{
+				{
+					Check:   CheckPackageContext,
+					Path:    "package-context.yaml",
+					Message: "package-context.yaml is missing",
+					Fix:     "run `kpt pkg init` in the package directory to create it",
+				},
+				{
+					Check:   CheckPackageContext,
+					Path:    "sub/package-context.yaml",
+					Message: "data.name is not set",
+					Fix:     "set data.name to the name of the package",
+				},
+			},
+		},
+		"broken subpackage Kptfile": {
+			files: map[string]string{
+				"Kptfile":                  "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n",
+				"package-context.yaml":     pkgContext,
+				"sub/Kptfile":              "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: sub\nunknown: true\n",
+				"sub/package-context.yaml": pkgContext,
+				"old/Kptfile":              "apiVersion: kpt.dev/v1alpha1\nkind: Kptfile\nmetadata:\n  name: old\n",
+				"old/package-context.yaml": pkgContext,
+			},
+			expected: []Finding{
+				{
+					Check:   CheckKptfile,
+					Path:    "old/Kptfile",
+					Message: "old resource version \"v1alpha1\" found in Kptfile",
+					Fix:     "migrate the Kptfile to kpt.dev/v1",
+				},
+				{
+					Check:   CheckKptfile,
+					Path:    "sub/Kptfile",
+					Message: "yaml: unmarshal errors:\n  line 5: field unknown not found in type v1.KptFile",
+				},
+			},
+		},
+		"duplicate resources": {
+			files: map[string]string{
+				"Kptfile":                  "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n",
+				"package-context.yaml":     pkgContext,
+				"a.yaml":                   "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n  namespace: ns\n",
+				"sub/Kptfile":              "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: sub\n",
+				"sub/package-context.yaml": pkgContext,
+				"sub/b.yaml":               "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n  namespace: ns\n",
+				"sub/c.yaml":               "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n  namespace: other\n",
+			},
+			expected: []Finding{
+				{
+					Check:   CheckDuplicateResource,
+					Path:    "sub/b.yaml",
+					Message: "deployment.apps ns/app is also defined in a.yaml",
+					Fix:     "remove or rename one of the resources",
+				},
+			},
+		},
+		"skipped checks": {
+			files: map[string]string{
+				"Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-labels:latest
+`,
+			},
+			skip: map[string]bool{CheckImageTag: true, CheckPackageContext: true},
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			fs := filesys.MakeFsInMemory()
+			for name, content := range tc.files {
+				p := filepath.Join("/pkg", name)
+				require.NoError(t, fs.MkdirAll(filepath.Dir(p)))
+				require.NoError(t, fs.WriteFile(p, []byte(content)))
+			}
+
+			l := &Linter{FileSystem: fs, Skip: tc.skip}
+			findings, err := l.Lint("/pkg")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, findings)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/filters"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Driver selects how the files matching Files are merged.
+type Driver struct {
+	// Files is a glob matching slash-separated file paths relative to the
+	// package. A glob without a slash matches the file name in any directory.
+	Files string
+
+	// Type is the merge driver used for the matching files.
+	Type kptfilev1.MergeDriverType
+
+	// Function merges the resources in the matching files if Type is
+	// kptfilev1.FunctionMergeDriver. It gets the original, updated and dest
+	// versions of a resource and returns the merged resource, or nothing if
+	// the resource should be deleted.
+	Function kio.Filter
+}
+
+// Drivers is an ordered list of merge drivers. The first driver matching
+// a file is used for it.
+type Drivers []Driver
+
+// Find returns the driver for the file at the provided path relative to the
+// package, or nil if the default resource-merge driver should be used.
+func (d Drivers) Find(relPath string) (*Driver, error) {
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "/")
+	for i := range d {
+		name := relPath
+		if !strings.Contains(d[i].Files, "/") {
+			name = path.Base(relPath)
+		}
+		match, err := path.Match(d[i].Files, name)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			if d[i].Type == kptfilev1.ResourceMergeDriver {
+				return nil, nil
+			}
+			return &d[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// handle decides how a resource is merged by the driver.
+func (d *Driver) handle(origin, updated, dest *yaml.RNode) (filters.ResourceMergeStrategy, error) {
+	switch d.Type {
+	case kptfilev1.OursMergeDriver:
+		if dest == nil {
+			return filters.Skip, nil
+		}
+		return filters.KeepDest, nil
+	case kptfilev1.TheirsMergeDriver:
+		if updated == nil {
+			return filters.Skip, nil
+		}
+		return filters.KeepUpdated, nil
+	case kptfilev1.FunctionMergeDriver:
+		return d.mergeWithFunction(origin, updated, dest)
+	default:
+		return filters.Skip, fmt.Errorf("unknown merge driver %q", d.Type)
+	}
+}
+
+// mergeWithFunction runs the merge function on the versions of a resource.
+// The merged resource replaces the content of the dest (or updated) version,
+// so it is written back to the file the resource was read from.
+func (d *Driver) mergeWithFunction(origin, updated, dest *yaml.RNode) (filters.ResourceMergeStrategy, error) {
+	// The versions of the resource share the same file annotations, so
+	// they are removed to make the input a valid resource list.
+	var input []*yaml.RNode
+	for _, n := range []*yaml.RNode{origin, updated, dest} {
+		if n == nil {
+			continue
+		}
+		c := n.Copy()
+		for _, a := range fileAnnotations {
+			if err := c.PipeE(yaml.ClearAnnotation(a)); err != nil {
+				return filters.Skip, err
+			}
+		}
+		input = append(input, c)
+	}
+	output, err := d.Function.Filter(input)
+	if err != nil {
+		return filters.Skip, err
+	}
+
+	var target *yaml.RNode
+	strategy := filters.KeepDest
+	switch {
+	case len(output) == 0:
+		return filters.Skip, nil
+	case len(output) > 1:
+		return filters.Skip, fmt.Errorf("merge function for %q must return at most one resource, got %d",
+			d.Files, len(output))
+	case dest != nil:
+		target = dest
+	case updated != nil:
+		target, strategy = updated, filters.KeepUpdated
+	default:
+		// Only the original version exists, so the resource has been
+		// deleted both upstream and locally.
+		return filters.Skip, nil
+	}
+
+	merged := output[0]
+	annotations := merged.GetAnnotations()
+	targetAnnotations := target.GetAnnotations()
+	for _, a := range append([]string{mergeSourceAnnotation}, fileAnnotations...) {
+		if v, found := targetAnnotations[a]; found {
+			annotations[a] = v
+		} else {
+			delete(annotations, a)
+		}
+	}
+	if err := merged.SetAnnotations(annotations); err != nil {
+		return filters.Skip, err
+	}
+	target.SetYNode(merged.YNode())
+	return strategy, nil
+}
+
+// fileAnnotations are the annotations kyaml uses to track the file a resource
+// was read from.
+var fileAnnotations = []string{
+	kioutil.PathAnnotation, kioutil.IndexAnnotation, kioutil.IdAnnotation,
+	kioutil.LegacyPathAnnotation, kioutil.LegacyIndexAnnotation, kioutil.LegacyIdAnnotation, // nolint:staticcheck
+	kioutil.SeqIndentAnnotation,
+}
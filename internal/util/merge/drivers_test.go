@@ -0,0 +1,179 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/util/merge"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func configMap(data string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: values
+data:
+  %s
+`, data)
+}
+
+func deployment(replicas int) string {
+	return fmt.Sprintf(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: %d
+`, replicas)
+}
+
+// unionData is a merge function that takes the updated version of a
+// ConfigMap and adds the data keys only present in the local version.
+var unionData = kio.FilterFunc(func(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	var updated, dest *yaml.RNode
+	for _, n := range nodes {
+		switch n.GetAnnotations()["config.kubernetes.io/merge-source"] {
+		case "updated":
+			updated = n
+		case "dest":
+			dest = n
+		}
+	}
+	if updated == nil {
+		return nil, nil
+	}
+	if dest != nil {
+		for k, v := range dest.GetDataMap() {
+			data := updated.GetDataMap()
+			if _, found := data[k]; !found {
+				data[k] = v
+				updated.SetDataMap(data)
+			}
+		}
+	}
+	return []*yaml.RNode{updated}, nil
+})
+
+func TestMerge3_Drivers(t *testing.T) {
+	testCases := map[string]struct {
+		drivers        merge.Drivers
+		expectedValues string
+	}{
+		"resource-merge by default": {
+			expectedValues: configMap("color: red\n  size: large\n  owner: me"),
+		},
+		"resource-merge driver": {
+			drivers: merge.Drivers{
+				{Files: "values.yaml", Type: kptfilev1.ResourceMergeDriver},
+				{Files: "conf/*", Type: kptfilev1.OursMergeDriver},
+			},
+			expectedValues: configMap("color: red\n  size: large\n  owner: me"),
+		},
+		"ours driver": {
+			drivers: merge.Drivers{
+				{Files: "values.yaml", Type: kptfilev1.OursMergeDriver},
+			},
+			expectedValues: configMap("color: red\n  size: small\n  owner: me"),
+		},
+		"theirs driver": {
+			drivers: merge.Drivers{
+				{Files: "conf/*.yaml", Type: kptfilev1.TheirsMergeDriver},
+			},
+			expectedValues: configMap("color: blue\n  size: large"),
+		},
+		"function driver": {
+			drivers: merge.Drivers{
+				{Files: "values.yaml", Type: kptfilev1.FunctionMergeDriver, Function: unionData},
+			},
+			expectedValues: configMap("color: blue\n  owner: me\n  size: large"),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			dir := t.TempDir()
+			write := func(pkg, values string, replicas int) {
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, pkg, "conf"), 0700))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, pkg, "conf", "values.yaml"), []byte(values), 0600))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, pkg, "deploy.yaml"), []byte(deployment(replicas)), 0600))
+			}
+			write("original", configMap("color: blue\n  size: small"), 1)
+			write("updated", configMap("color: blue\n  size: large"), 2)
+			write("local", configMap("color: red\n  size: small\n  owner: me"), 1)
+
+			err := merge.Merge3{
+				OriginalPath: filepath.Join(dir, "original"),
+				UpdatedPath:  filepath.Join(dir, "updated"),
+				DestPath:     filepath.Join(dir, "local"),
+				MergeOnPath:  true,
+				Drivers:      tc.drivers,
+			}.Merge()
+			require.NoError(t, err)
+
+			values, err := os.ReadFile(filepath.Join(dir, "local", "conf", "values.yaml"))
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(tc.expectedValues), strings.TrimSpace(string(values)))
+
+			// Files not matching a driver are always merged.
+			deploy, err := os.ReadFile(filepath.Join(dir, "local", "deploy.yaml"))
+			require.NoError(t, err)
+			assert.Equal(t, deployment(2), string(deploy))
+		})
+	}
+}
+
+func TestDriversFind(t *testing.T) {
+	drivers := merge.Drivers{
+		{Files: "monitoring/*.rules.yaml", Type: kptfilev1.FunctionMergeDriver},
+		{Files: "values.yaml", Type: kptfilev1.OursMergeDriver},
+		{Files: "*.md", Type: kptfilev1.TheirsMergeDriver},
+		{Files: "*.yaml", Type: kptfilev1.ResourceMergeDriver},
+		{Files: "*", Type: kptfilev1.OursMergeDriver},
+	}
+
+	testCases := map[string]kptfilev1.MergeDriverType{
+		"monitoring/app.rules.yaml":   kptfilev1.FunctionMergeDriver,
+		"/monitoring/app.rules.yaml":  kptfilev1.FunctionMergeDriver,
+		"a/monitoring/app.rules.yaml": "",
+		"values.yaml":                 kptfilev1.OursMergeDriver,
+		"env/prod/values.yaml":        kptfilev1.OursMergeDriver,
+		"docs/README.md":              kptfilev1.TheirsMergeDriver,
+		"deploy.yaml":                 "",
+		"LICENSE":                     kptfilev1.OursMergeDriver,
+	}
+
+	for path, expected := range testCases {
+		t.Run(path, func(t *testing.T) {
+			d, err := drivers.Find(path)
+			require.NoError(t, err)
+			if expected == "" {
+				assert.Nil(t, d)
+				return
+			}
+			require.NotNil(t, d)
+			assert.Equal(t, expected, d.Type)
+		})
+	}
+}
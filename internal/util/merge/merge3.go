@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/GoogleContainerTools/kpt/internal/util/attribution"
+	"github.com/GoogleContainerTools/kpt/internal/util/ignore"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/filters"
@@ -48,6 +49,15 @@ type Merge3 struct {
 	MatchFilesGlob     []string
 	MergeOnPath        bool
 	IncludeSubPackages bool
+
+	// Drivers selects the merge driver for resources based on the file
+	// they are in. Resources in files not matching any driver are merged
+	// with the resource-merge driver.
+	Drivers Drivers
+
+	// Ignore selects the files that are left out of the merge. They are
+	// neither read from any of the packages nor written to destination.
+	Ignore *ignore.Matcher
 }
 
 func (m Merge3) Merge() error {
@@ -72,6 +82,7 @@ func (m Merge3) Merge() error {
 		PackageFileName:    kptfilev1.KptFileName,
 		PreserveSeqIndent:  true,
 		WrapBareSeqNode:    true,
+		FileSkipFunc:       m.Ignore.SkipFile,
 	}
 	inputs = append(inputs, dest)
 
@@ -85,6 +96,7 @@ func (m Merge3) Merge() error {
 			PackageFileName:    kptfilev1.KptFileName,
 			PreserveSeqIndent:  true,
 			WrapBareSeqNode:    true,
+			FileSkipFunc:       m.Ignore.SkipFile,
 		},
 		Exclusions: relPaths,
 	})
@@ -99,12 +111,13 @@ func (m Merge3) Merge() error {
 			PackageFileName:    kptfilev1.KptFileName,
 			PreserveSeqIndent:  true,
 			WrapBareSeqNode:    true,
+			FileSkipFunc:       m.Ignore.SkipFile,
 		},
 		Exclusions: relPaths,
 	})
 
 	rmMatcher := ResourceMergeMatcher{MergeOnPath: m.MergeOnPath}
-	resourceHandler := resourceHandler{}
+	resourceHandler := resourceHandler{drivers: m.Drivers}
 	kyamlMerge := filters.Merge3{
 		Matcher: &rmMatcher,
 		Handler: &resourceHandler,
@@ -297,10 +310,20 @@ func metadataComment(node *yaml.RNode) string {
 // there is no diff between origin and local.
 type resourceHandler struct {
 	keptResources []*yaml.RNode
+	drivers       Drivers
 }
 
 func (r *resourceHandler) Handle(origin, upstream, local *yaml.RNode) (filters.ResourceMergeStrategy, error) {
 	var strategy filters.ResourceMergeStrategy
+	if len(r.drivers) > 0 {
+		driver, err := r.drivers.Find(resourcePath(origin, upstream, local))
+		if err != nil {
+			return strategy, err
+		}
+		if driver != nil {
+			return driver.handle(origin, upstream, local)
+		}
+	}
 	switch {
 	// Keep the resource if added locally.
 	case origin == nil && upstream == nil && local != nil:
@@ -332,6 +355,20 @@ func (r *resourceHandler) Handle(origin, upstream, local *yaml.RNode) (filters.R
 	return strategy, nil
 }
 
+// resourcePath returns the path of the file the resource was read from,
+// preferring the local version of the resource.
+func resourcePath(origin, upstream, local *yaml.RNode) string {
+	for _, n := range []*yaml.RNode{local, upstream, origin} {
+		if n == nil {
+			continue
+		}
+		if p, _, err := kioutil.GetFileAnnotations(n); err == nil && p != "" {
+			return p
+		}
+	}
+	return ""
+}
+
 func (*resourceHandler) equals(r1, r2 *yaml.RNode) (bool, error) {
 	// We need to create new copies of the resources since we need to
 	// mutate them before comparing them.
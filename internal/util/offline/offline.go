@@ -0,0 +1,60 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package offline implements the offline mode of kpt, in which kpt fails
+// fast instead of accessing the network, e.g. to pull a function image or
+// to fetch an upstream repository.
+package offline
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvVar is the environment variable that enables the offline mode when
+// set to true or 1.
+const EnvVar = "KPT_OFFLINE"
+
+// Enabled is set by the --offline flag.
+var Enabled bool
+
+// IsEnabled returns true if kpt runs in offline mode.
+func IsEnabled() bool {
+	if Enabled {
+		return true
+	}
+	e := strings.ToLower(os.Getenv(EnvVar))
+	return e == "true" || e == "1"
+}
+
+// Error is returned when an operation requires network access in offline
+// mode.
+type Error struct {
+	// Operation describes what requires network access, e.g.
+	// `pulling image "gcr.io/kpt-fn/set-labels:v0.1"`.
+	Operation string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("kpt is in offline mode, but %s requires network access", e.Operation)
+}
+
+// Check returns an *Error for operation if kpt runs in offline mode.
+func Check(operation string) error {
+	if IsEnabled() {
+		return &Error{Operation: operation}
+	}
+	return nil
+}
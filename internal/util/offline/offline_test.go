@@ -0,0 +1,53 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := map[string]struct {
+		enabled bool
+		env     string
+		offline bool
+	}{
+		"online":         {},
+		"flag":           {enabled: true, offline: true},
+		"env true":       {env: "TRUE", offline: true},
+		"env 1":          {env: "1", offline: true},
+		"env false":      {env: "false"},
+		"flag env false": {enabled: true, env: "false", offline: true},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			Enabled = tc.enabled
+			defer func() { Enabled = false }()
+			t.Setenv(EnvVar, tc.env)
+
+			assert.Equal(t, tc.offline, IsEnabled())
+			err := Check(`fetching "https://github.com/kptdev/kpt"`)
+			if !tc.offline {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err,
+				`kpt is in offline mode, but fetching "https://github.com/kptdev/kpt" requires network access`)
+		})
+	}
+}
@@ -21,17 +21,20 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/GoogleContainerTools/kpt/internal/errors"
 	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
 	"github.com/GoogleContainerTools/kpt/internal/pkg"
 	"github.com/GoogleContainerTools/kpt/internal/types"
 	"github.com/GoogleContainerTools/kpt/internal/util/attribution"
+	"github.com/GoogleContainerTools/kpt/internal/util/external"
 	"github.com/GoogleContainerTools/kpt/internal/util/printerutil"
 	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/fn"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/GoogleContainerTools/kpt/pkg/refgraph"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
@@ -41,6 +44,10 @@ import (
 
 var errAllowedExecNotSpecified = fmt.Errorf("must run with `--allow-exec` option to allow running function binaries")
 
+// dependencyAnnotation marks the resources of pipeline dependencies in the
+// input of a pipeline. The value is the path of the dependency.
+const dependencyAnnotation = "internal.kpt.dev/render-dependency"
+
 // Renderer hydrates a given pkg by running the functions in the input pipeline
 type Renderer struct {
 	// PkgPath is the absolute path to the root package
@@ -63,6 +70,37 @@ type Renderer struct {
 
 	// FileSystem is the input filesystem to operate on
 	FileSystem filesys.FileSystem
+
+	// StatusFilePath is the path of the file to write the render status
+	// to. The render status records the changes made by every mutator.
+	// If empty, no render status is written.
+	StatusFilePath string
+
+	// AnnotateGenerated marks the resources generated by the functions
+	// with the kpt.dev/generated-by annotation.
+	AnnotateGenerated bool
+
+	// VerifyIdempotent renders the package a second time, starting from
+	// the output of the first render, and fails if the second render
+	// changes any resources. Nothing is written if the verification fails.
+	VerifyIdempotent bool
+
+	// ReferenceGraphPath is the path of the file to write the reference
+	// graph of the rendered resources to. The reference graph records the
+	// references between the resources found with the rules of
+	// refgraph.DefaultCatalog. If empty, no reference graph is written.
+	ReferenceGraphPath string
+
+	// PipelineOverride changes the pipeline of the root package for this
+	// render only. If nil, the pipeline of the Kptfile is used as is.
+	PipelineOverride *PipelineOverride
+
+	// ExternalResolver resolves the ExternalValue resources of the
+	// rendered package and sets the values in the targeted resources. If
+	// nil, the ExternalValue resources are left as is. It requires an
+	// Output, so that the resolved values, often secrets, are never
+	// written to the package on disk.
+	ExternalResolver *external.Resolver
 }
 
 // Execute runs a pipeline.
@@ -71,19 +109,19 @@ func (e *Renderer) Execute(ctx context.Context) (*fnresult.ResultList, error) {
 
 	pr := printer.FromContextOrDie(ctx)
 
-	root, err := newPkgNode(e.FileSystem, e.PkgPath, nil)
+	if e.ExternalResolver != nil && e.Output == nil {
+		return nil, errors.E(op, types.UniquePath(e.PkgPath), errors.InvalidParam,
+			fmt.Errorf("external values can't be resolved when rendering the package in place"))
+	}
+
+	root, err := e.newRootPkgNode(e.FileSystem)
 	if err != nil {
 		return nil, errors.E(op, types.UniquePath(e.PkgPath), err)
 	}
 
-	// initialize hydration context
-	hctx := &hydrationContext{
-		root:          root,
-		pkgs:          map[types.UniquePath]*pkgNode{},
-		fnResults:     fnresult.NewResultList(),
-		runnerOptions: e.RunnerOptions,
-		fileSystem:    e.FileSystem,
-		runtime:       e.Runtime,
+	hctx := e.newHydrationContext(root, e.FileSystem)
+	if e.StatusFilePath != "" {
+		hctx.status = newStatusRecorder()
 	}
 
 	if _, err = hydrate(ctx, root, hctx); err != nil {
@@ -104,6 +142,37 @@ func (e *Renderer) Execute(ctx context.Context) (*fnresult.ResultList, error) {
 		return nil, err
 	}
 
+	// sort the resources so the output doesn't depend on the order in
+	// which the functions return them.
+	sortResources(hctx.root.resources)
+
+	if e.VerifyIdempotent {
+		if err = e.verifyIdempotent(ctx, hctx); err != nil {
+			return hctx.fnResults, errors.E(op, root.pkg.UniquePath, err)
+		}
+	}
+
+	// the reference graph is built before the resources are written, while
+	// they still carry their path annotations.
+	var graph *fnresult.ReferenceGraph
+	if e.ReferenceGraphPath != "" {
+		if graph, err = refgraph.DefaultCatalog().Build(hctx.root.resources); err != nil {
+			return nil, errors.E(op, root.pkg.UniquePath, err)
+		}
+	}
+
+	// the external values are resolved last, so that they are never seen
+	// by the functions.
+	if e.ExternalResolver != nil {
+		cnt, err := e.ExternalResolver.Resolve(ctx, hctx.root.resources)
+		if err != nil {
+			return hctx.fnResults, errors.E(op, root.pkg.UniquePath, err)
+		}
+		if cnt > 0 {
+			pr.Printf("Resolved %d external value(s).\n", cnt)
+		}
+	}
+
 	// add metrics annotation to output resources to track the usage as the resources
 	// are rendered by kpt fn group
 	at := attribution.Attributor{Resources: hctx.root.resources, CmdGroup: "fn"}
@@ -144,9 +213,58 @@ func (e *Renderer) Execute(ctx context.Context) (*fnresult.ResultList, error) {
 		}
 	}
 
+	if hctx.status != nil {
+		if err = hctx.status.write(e.FileSystem, e.StatusFilePath); err != nil {
+			return nil, err
+		}
+	}
+
+	if graph != nil {
+		if err = writeReferenceGraph(e.FileSystem, e.ReferenceGraphPath, graph); err != nil {
+			return nil, err
+		}
+	}
+
 	return hctx.fnResults, e.saveFnResults(ctx, hctx.fnResults)
 }
 
+// newRootPkgNode reads the root package from the filesystem fsys and
+// applies the pipeline override to it.
+func (e *Renderer) newRootPkgNode(fsys filesys.FileSystem) (*pkgNode, error) {
+	root, err := newPkgNode(fsys, e.PkgPath, nil)
+	if err != nil || e.PipelineOverride.IsEmpty() {
+		return root, err
+	}
+	// the Kptfile is cached by the package, so the override is seen by
+	// the hydration without being written to the filesystem.
+	kf, err := root.pkg.Kptfile()
+	if err != nil {
+		return nil, err
+	}
+	if kf.Pipeline, err = e.PipelineOverride.Apply(kf.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := kf.Validate(fsys, root.pkg.UniquePath); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// newHydrationContext returns the context for hydrating the package root
+// from the filesystem fsys.
+func (e *Renderer) newHydrationContext(root *pkgNode, fsys filesys.FileSystem) *hydrationContext {
+	return &hydrationContext{
+		root:          root,
+		pkgs:          map[types.UniquePath]*pkgNode{},
+		fnResults:     fnresult.NewResultList(),
+		runnerOptions: e.RunnerOptions,
+		fileSystem:    fsys,
+		runtime:       e.Runtime,
+
+		annotateGenerated: e.AnnotateGenerated,
+	}
+}
+
 func (e *Renderer) saveFnResults(ctx context.Context, fnResults *fnresult.ResultList) error {
 	e.fnResultsList = fnResults
 	resultsFile, err := fnruntime.SaveResults(e.FileSystem, e.ResultsDirPath, fnResults)
@@ -169,6 +287,10 @@ type hydrationContext struct {
 	// unique paths.
 	pkgs map[types.UniquePath]*pkgNode
 
+	// propagated caches the functions that the packages propagate to
+	// their subpackages, by unique path.
+	propagated map[types.UniquePath]inheritedFunctions
+
 	// inputFiles is a set of filepaths containing input resources to the
 	// functions across all the packages during hydration.
 	// The file paths are relative to the root package.
@@ -191,6 +313,14 @@ type hydrationContext struct {
 
 	// function runtime
 	runtime fn.FunctionRuntime
+
+	// status records the changes made by the mutators, if the render
+	// status is requested.
+	status *statusRecorder
+
+	// annotateGenerated is true if the resources generated by the mutators
+	// are marked with the kpt.dev/generated-by annotation.
+	annotateGenerated bool
 }
 
 // pkgNode represents a package being hydrated. Think of it as a node in the hydration DAG.
@@ -281,6 +411,10 @@ func hydrate(ctx context.Context, pn *pkgNode, hctx *hydrationContext) (output [
 		return nil, errors.E(op, curr.pkg.UniquePath, err)
 	}
 
+	if err := hctx.inheritFunctions(curr.pkg); err != nil {
+		return nil, errors.E(op, curr.pkg.UniquePath, err)
+	}
+
 	var input []*yaml.RNode
 
 	// determine sub packages to be hydrated
@@ -319,10 +453,19 @@ func hydrate(ctx context.Context, pn *pkgNode, hctx *hydrationContext) (output [
 	// include current package's resources in the input resource list
 	input = append(input, currPkgResources...)
 
+	// render the dependencies of the package and include their resources
+	// as read-only input to the pipeline.
+	depResources, err := hydrateDependencies(ctx, curr, hctx)
+	if err != nil {
+		return output, errors.E(op, curr.pkg.UniquePath, err)
+	}
+	input = append(input, depResources...)
+
 	output, err = curr.runPipeline(ctx, hctx, input)
 	if err != nil {
 		return output, errors.E(op, curr.pkg.UniquePath, err)
 	}
+	output = removeDependencyResources(output)
 
 	// pkg is hydrated, mark the pkg as wet and update the resources
 	curr.state = Wet
@@ -331,6 +474,61 @@ func hydrate(ctx context.Context, pn *pkgNode, hctx *hydrationContext) (output [
 	return output, err
 }
 
+// hydrateDependencies hydrates the packages listed in the pipeline
+// dependencies of pn and returns copies of their resources, marked with
+// the dependencyAnnotation.
+func hydrateDependencies(ctx context.Context, pn *pkgNode, hctx *hydrationContext) ([]*yaml.RNode, error) {
+	pl, err := pn.pkg.Pipeline()
+	if err != nil {
+		return nil, err
+	}
+	var output []*yaml.RNode
+	for _, dep := range pl.Dependencies {
+		depPkg, err := pn.pkg.RelativePackage(dep)
+		if err != nil {
+			return nil, err
+		}
+		// Only packages in the hierarchy being rendered are written back
+		// after rendering, so dependencies must not point outside of it.
+		rel, err := depPkg.RelativePathTo(hctx.root.pkg)
+		if err != nil {
+			return nil, err
+		}
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("dependency %q must be a package within the root package %q",
+				dep, hctx.root.pkg.DisplayPath)
+		}
+		depNode, err := newPkgNode(hctx.fileSystem, "", depPkg)
+		if err != nil {
+			return nil, fmt.Errorf("dependency %q: %w", dep, err)
+		}
+		resources, err := hydrate(ctx, depNode, hctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range cloneResources(resources) {
+			if err := r.PipeE(yaml.SetAnnotation(dependencyAnnotation, dep)); err != nil {
+				return nil, err
+			}
+			output = append(output, r)
+		}
+	}
+	return output, nil
+}
+
+// removeDependencyResources removes the resources of dependencies from
+// the pipeline output.
+func removeDependencyResources(resources []*yaml.RNode) []*yaml.RNode {
+	var output []*yaml.RNode
+	for _, r := range resources {
+		if _, found := r.GetAnnotations()[dependencyAnnotation]; found {
+			continue
+		}
+		output = append(output, r)
+	}
+	return output
+}
+
 // runPipeline runs the pipeline defined at current pkgNode on given input resources.
 func (pn *pkgNode) runPipeline(ctx context.Context, hctx *hydrationContext, input []*yaml.RNode) ([]*yaml.RNode, error) {
 	const op errors.Op = "pipeline.run"
@@ -345,6 +543,15 @@ func (pn *pkgNode) runPipeline(ctx context.Context, hctx *hydrationContext, inpu
 		return nil, err
 	}
 
+	// the composite functions are expanded in the pipeline of the cached
+	// Kptfile, so the mutators and validators see their functions.
+	if pl.Mutators, err = fnruntime.ExpandComposites(ctx, pl.Mutators, hctx.runnerOptions.ResolveComposite); err != nil {
+		return nil, errors.E(op, pn.pkg.UniquePath, err)
+	}
+	if pl.Validators, err = fnruntime.ExpandComposites(ctx, pl.Validators, hctx.runnerOptions.ResolveComposite); err != nil {
+		return nil, errors.E(op, pn.pkg.UniquePath, err)
+	}
+
 	if pl.IsEmpty() {
 		if err := kptfilev1.AreKRM(input); err != nil {
 			return nil, fmt.Errorf("input resource list must contain only KRM resources: %s", err.Error())
@@ -413,13 +620,23 @@ func (pn *pkgNode) runMutators(ctx context.Context, hctx *hydrationContext, inpu
 		selectors := pl.Mutators[i].Selectors
 		exclusions := pl.Mutators[i].Exclusions
 
-		if len(selectors) > 0 || len(exclusions) > 0 {
+		// resources are identified to merge the output of functions with
+		// selectors, to record the changes made by the function and to
+		// find the resources it generated.
+		trackIds := len(selectors) > 0 || len(exclusions) > 0 || hctx.status != nil || hctx.annotateGenerated
+		if trackIds {
 			// set kpt-resource-id annotation on each resource before mutation
 			err = fnruntime.SetResourceIds(input)
 			if err != nil {
 				return nil, err
 			}
 		}
+		var before []*yaml.RNode
+		var start time.Time
+		if hctx.status != nil {
+			before = cloneResources(input)
+			start = hctx.status.now()
+		}
 		// select the resources on which function should be applied
 		selectedInput, err := fnruntime.SelectInput(input, selectors, exclusions, &fnruntime.SelectionContext{RootPackagePath: hctx.root.pkg.UniquePath})
 		if err != nil {
@@ -443,18 +660,49 @@ func (pn *pkgNode) runMutators(ctx context.Context, hctx *hydrationContext, inpu
 		if len(selectors) > 0 || len(exclusions) > 0 {
 			// merge the output resources with input resources
 			input = fnruntime.MergeWithInput(output.Nodes, selectedInput, input)
+		} else {
+			input = output.Nodes
+		}
+		if hctx.status != nil {
+			if err = hctx.status.record(hctx, pn, &pl.Mutators[i], start, before, input); err != nil {
+				return nil, err
+			}
+		}
+		if hctx.annotateGenerated {
+			if err = annotateGenerated(input, &pl.Mutators[i]); err != nil {
+				return nil, err
+			}
+		}
+		if trackIds {
 			// delete the kpt-resource-id annotation on each resource
 			err = fnruntime.DeleteResourceIds(input)
 			if err != nil {
 				return nil, err
 			}
-		} else {
-			input = output.Nodes
 		}
 	}
 	return input, nil
 }
 
+// annotateGenerated sets the kpt.dev/generated-by annotation on the
+// resources that were generated by the function, which are the resources
+// without a kpt-resource-id annotation.
+func annotateGenerated(resources []*yaml.RNode, function *kptfilev1.Function) error {
+	name := function.Image
+	if name == "" {
+		name = function.Exec
+	}
+	for _, r := range resources {
+		if _, found := r.GetAnnotations()[fnruntime.ResourceIDAnnotation]; found {
+			continue
+		}
+		if err := r.PipeE(yaml.SetAnnotation(kptfilev1.GeneratedByAnnotation, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // runValidators runs a set of validator functions on input resources.
 // We bail out on first validation failure today, but the logic can be
 // improved to report multiple failures. Reporting multiple failures
@@ -482,7 +730,7 @@ func (pn *pkgNode) runValidators(ctx context.Context, hctx *hydrationContext, in
 		if len(function.Selectors) > 0 || len(function.Exclusions) > 0 {
 			displayResourceCount = true
 		}
-		if function.Exec != "" && !hctx.runnerOptions.AllowExec {
+		if function.Exec != "" && !fnruntime.IsBuiltinExec(function.Exec) && !hctx.runnerOptions.AllowExec {
 			return errAllowedExecNotSpecified
 		}
 		opts := hctx.runnerOptions
@@ -592,7 +840,7 @@ func fnChain(ctx context.Context, hctx *hydrationContext, pkgPath types.UniquePa
 		if len(function.Selectors) > 0 || len(function.Exclusions) > 0 {
 			displayResourceCount = true
 		}
-		if function.Exec != "" && !hctx.runnerOptions.AllowExec {
+		if function.Exec != "" && !fnruntime.IsBuiltinExec(function.Exec) && !hctx.runnerOptions.AllowExec {
 			return nil, errAllowedExecNotSpecified
 		}
 		opts := hctx.runnerOptions
@@ -15,12 +15,23 @@
 package render
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/util/external"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
 func TestPathRelToRoot(t *testing.T) {
@@ -227,3 +238,436 @@ metadata:
 		})
 	}
 }
+
+func TestRenderDependencies(t *testing.T) {
+	kptfile := func(name, pipeline string) string {
+		return fmt.Sprintf("apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: %s\n%s", name, pipeline)
+	}
+	configMap := func(name string) string {
+		return fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s-config\ndata:\n  cidr: 10.0.0.0/16\n", name)
+	}
+
+	tests := map[string]struct {
+		files map[string]string
+		// pkg is the package to render, relative to the temp dir.
+		pkg string
+		// inputs maps function input files to the resources expected in them.
+		inputs   map[string][]string
+		expected []string
+		errMsg   string
+	}{
+		"sibling dependency": {
+			files: map[string]string{
+				"root/Kptfile":              kptfile("root", ""),
+				"root/network/Kptfile":      kptfile("network", ""),
+				"root/network/network.yaml": configMap("network"),
+				"root/app/Kptfile": kptfile("app", `pipeline:
+  dependencies:
+  - ../network
+  mutators:
+  - exec: tee {{.dir}}/app-input.yaml
+`),
+				"root/app/app.yaml": configMap("app"),
+			},
+			pkg: "root",
+			inputs: map[string][]string{
+				"app-input.yaml": {"name: app-config", "name: network-config", "internal.kpt.dev/render-dependency: '../network'"},
+			},
+			expected: []string{"name: app-config", "name: network-config"},
+		},
+		"dependency rendered before dependent": {
+			files: map[string]string{
+				"root/Kptfile": kptfile("root", ""),
+				"root/a/Kptfile": kptfile("a", `pipeline:
+  dependencies:
+  - ../b
+  mutators:
+  - exec: tee {{.dir}}/a-input.yaml
+`),
+				"root/a/a.yaml": configMap("a"),
+				"root/b/Kptfile": kptfile("b", `pipeline:
+  dependencies:
+  - ../c
+  mutators:
+  - exec: tee {{.dir}}/b-input.yaml
+`),
+				"root/b/b.yaml":  configMap("b"),
+				"root/c/Kptfile": kptfile("c", ""),
+				"root/c/c.yaml":  configMap("c"),
+			},
+			pkg: "root",
+			inputs: map[string][]string{
+				// dependencies are not transitive.
+				"a-input.yaml": {"name: a-config", "name: b-config"},
+				"b-input.yaml": {"name: b-config", "name: c-config"},
+			},
+			expected: []string{"name: a-config", "name: b-config", "name: c-config"},
+		},
+		"dependency cycle": {
+			files: map[string]string{
+				"root/Kptfile":   kptfile("root", ""),
+				"root/a/Kptfile": kptfile("a", "pipeline:\n  dependencies:\n  - ../b\n"),
+				"root/b/Kptfile": kptfile("b", "pipeline:\n  dependencies:\n  - ../a\n"),
+			},
+			pkg:    "root",
+			errMsg: "cycle detected in pkg dependencies",
+		},
+		"dependency outside of the root package": {
+			files: map[string]string{
+				"root/Kptfile":         kptfile("root", ""),
+				"root/network/Kptfile": kptfile("network", ""),
+				"root/app/Kptfile":     kptfile("app", "pipeline:\n  dependencies:\n  - ../network\n"),
+			},
+			pkg:    "root/app",
+			errMsg: `dependency "../network" must be a package within the root package "app"`,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				p := filepath.Join(dir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+				content = strings.ReplaceAll(content, "{{.dir}}", dir)
+				require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+			}
+
+			out := &bytes.Buffer{}
+			r := &Renderer{
+				PkgPath:    filepath.Join(dir, tc.pkg),
+				Output:     out,
+				FileSystem: filesys.FileSystemOrOnDisk{},
+			}
+			r.RunnerOptions.InitDefaults()
+			r.RunnerOptions.AllowExec = true
+			_, err := r.Execute(fake.CtxWithDefaultPrinter())
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			for name, expected := range tc.inputs {
+				input, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				for _, e := range expected {
+					assert.Contains(t, string(input), e, name)
+				}
+			}
+			// dependency resources are not duplicated in the output and
+			// don't keep the dependency annotation.
+			for _, e := range tc.expected {
+				assert.Equal(t, 1, strings.Count(out.String(), e), out.String())
+			}
+			assert.NotContains(t, out.String(), dependencyAnnotation)
+		})
+	}
+}
+
+func TestRenderInheritedFunctions(t *testing.T) {
+	kptfile := func(name, pipeline string) string {
+		return fmt.Sprintf("apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: %s\n%s", name, pipeline)
+	}
+	configMap := func(name string) string {
+		return fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s-config\n", name)
+	}
+	// log.sh logs its argument and the packages of its input resources.
+	script := `#!/bin/sh
+in=$(cat)
+echo "$1:" $(echo "$in" | sed -n 's/^    name: \(.*\)-config$/\1/p' | sort) >> {{.dir}}/log
+echo "$in"
+`
+	files := map[string]string{
+		"log.sh": script,
+		"root/Kptfile": kptfile("root", `pipeline:
+  mutators:
+  - name: log
+    exec: {{.dir}}/log.sh root
+    propagate: true
+  - exec: {{.dir}}/log.sh root-only
+`),
+		"root/root.yaml": configMap("root"),
+		// a inherits the function, and propagates it to e.
+		"root/a/Kptfile":   kptfile("a", ""),
+		"root/a/a.yaml":    configMap("a"),
+		"root/a/e/Kptfile": kptfile("e", ""),
+		"root/a/e/e.yaml":  configMap("e"),
+		// b overrides the function, and doesn't propagate its override.
+		"root/b/Kptfile": kptfile("b", `pipeline:
+  mutators:
+  - name: log
+    exec: {{.dir}}/log.sh b
+`),
+		"root/b/b.yaml":    configMap("b"),
+		"root/b/c/Kptfile": kptfile("c", ""),
+		"root/b/c/c.yaml":  configMap("c"),
+		// d opts out of the function.
+		"root/d/Kptfile": kptfile("d", "pipeline:\n  skipInherited:\n  - log\n"),
+		"root/d/d.yaml":  configMap("d"),
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		content = strings.ReplaceAll(content, "{{.dir}}", dir)
+		require.NoError(t, os.WriteFile(p, []byte(content), 0700))
+	}
+
+	r := &Renderer{
+		PkgPath:    filepath.Join(dir, "root"),
+		FileSystem: filesys.FileSystemOrOnDisk{},
+	}
+	r.RunnerOptions.InitDefaults()
+	r.RunnerOptions.AllowExec = true
+	_, err := r.Execute(fake.CtxWithDefaultPrinter())
+	require.NoError(t, err)
+
+	log, err := os.ReadFile(filepath.Join(dir, "log"))
+	require.NoError(t, err)
+	assert.Equal(t, `root: e
+root: a e
+b: b c
+root: a b c d e root
+root-only: a b c d e root
+`, string(log))
+
+	// the Kptfiles of the subpackages are not changed.
+	kf, err := os.ReadFile(filepath.Join(dir, "root", "a", "Kptfile"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(kf), "pipeline")
+}
+
+func TestRenderAnnotateGenerated(t *testing.T) {
+	dir := t.TempDir()
+	// The generator adds a ConfigMap in front of the input resources.
+	generator := `#!/bin/sh
+sed -e 's/^items:$/items:\n- apiVersion: v1\n  kind: ConfigMap\n  metadata:\n    name: generated\n    annotations:\n      config.kubernetes.io\/path: generated.yaml/'
+`
+	files := map[string]string{
+		"generate.sh": generator,
+		"pkg/Kptfile": fmt.Sprintf(`apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+pipeline:
+  mutators:
+  - exec: %s
+`, filepath.Join(dir, "generate.sh")),
+		"pkg/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: authored\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0700))
+	}
+
+	r := &Renderer{
+		PkgPath:           filepath.Join(dir, "pkg"),
+		AnnotateGenerated: true,
+		FileSystem:        filesys.FileSystemOrOnDisk{},
+	}
+	r.RunnerOptions.InitDefaults()
+	r.RunnerOptions.AllowExec = true
+	_, err := r.Execute(fake.CtxWithDefaultPrinter())
+	require.NoError(t, err)
+
+	generated, err := os.ReadFile(filepath.Join(dir, "pkg", "generated.yaml"))
+	require.NoError(t, err)
+	node, err := yaml.Parse(string(generated))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		kptfilev1.GeneratedByAnnotation: filepath.Join(dir, "generate.sh"),
+	}, node.GetAnnotations())
+	authored, err := os.ReadFile(filepath.Join(dir, "pkg", "cm.yaml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(authored), "kpt.dev/generated-by")
+	assert.NotContains(t, string(authored), fnruntime.ResourceIDAnnotation)
+}
+
+func TestRenderVerifyIdempotent(t *testing.T) {
+	testCases := map[string]struct {
+		script           string
+		expectedErrorMsg string
+		expectedCM       string
+	}{
+		"idempotent pipeline": {
+			script:     `sed -e 's/count: "1"/count: "2"/'`,
+			expectedCM: `count: "2"`,
+		},
+		"non-idempotent pipeline": {
+			script: `sed -e 's/count: "\(1*\)"/count: "\11"/'`,
+			expectedErrorMsg: "pipeline is not idempotent, rendering the output again changed:\n" +
+				"  cm.yaml ConfigMap cm: changed data.count",
+			expectedCM: `count: "1"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{
+				"fn.sh": "#!/bin/sh\n" + tc.script + "\n",
+				"pkg/Kptfile": fmt.Sprintf(`apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+pipeline:
+  mutators:
+  - exec: %s
+`, filepath.Join(dir, "fn.sh")),
+				"pkg/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  count: \"1\"\n",
+			}
+			for name, content := range files {
+				p := filepath.Join(dir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+				require.NoError(t, os.WriteFile(p, []byte(content), 0700))
+			}
+
+			r := &Renderer{
+				PkgPath:          filepath.Join(dir, "pkg"),
+				VerifyIdempotent: true,
+				FileSystem:       filesys.FileSystemOrOnDisk{},
+			}
+			r.RunnerOptions.InitDefaults()
+			r.RunnerOptions.AllowExec = true
+			_, err := r.Execute(fake.CtxWithDefaultPrinter())
+			if tc.expectedErrorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			cm, err := os.ReadFile(filepath.Join(dir, "pkg", "cm.yaml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(cm), tc.expectedCM)
+		})
+	}
+}
+
+func TestRenderKptignore(t *testing.T) {
+	dir := t.TempDir()
+	cm := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\ndata:\n  count: \"1\"\n"
+	files := map[string]string{
+		"fn.sh": "#!/bin/sh\nsed -e 's/count: \"1\"/count: \"2\"/'\n",
+		"pkg/Kptfile": fmt.Sprintf(`apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+pipeline:
+  mutators:
+  - exec: %s
+`, filepath.Join(dir, "fn.sh")),
+		"pkg/.kptignore":       "ci/\n",
+		"pkg/cm.yaml":          fmt.Sprintf(cm, "cm"),
+		"pkg/ci/workflow.yaml": fmt.Sprintf(cm, "workflow"),
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0700))
+	}
+
+	r := &Renderer{
+		PkgPath:    filepath.Join(dir, "pkg"),
+		FileSystem: filesys.FileSystemOrOnDisk{},
+	}
+	r.RunnerOptions.InitDefaults()
+	r.RunnerOptions.AllowExec = true
+	_, err := r.Execute(fake.CtxWithDefaultPrinter())
+	require.NoError(t, err)
+
+	rendered, err := os.ReadFile(filepath.Join(dir, "pkg", "cm.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), `count: "2"`)
+	// the ignored file is neither given to the function nor pruned.
+	ignored, err := os.ReadFile(filepath.Join(dir, "pkg", "ci", "workflow.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cm, "workflow"), string(ignored))
+}
+
+func TestRenderExternalValues(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n",
+		"secret.yaml": `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+`,
+		"external.yaml": `apiVersion: kpt.dev/v1alpha1
+kind: ExternalValue
+metadata:
+  name: db-password
+  annotations:
+    config.kubernetes.io/local-config: "true"
+spec:
+  provider: env
+  ref: DB_PASSWORD
+  target:
+    kind: Secret
+    name: db
+    fieldPath: stringData.password
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	var out bytes.Buffer
+	r := &Renderer{
+		PkgPath:    dir,
+		FileSystem: filesys.FileSystemOrOnDisk{},
+		Output:     &out,
+		ExternalResolver: &external.Resolver{Providers: map[string]external.Provider{
+			"env": &external.EnvProvider{LookupEnv: func(string) (string, bool) { return "s3cr3t", true }},
+		}},
+	}
+	r.RunnerOptions.InitDefaults()
+	_, err := r.Execute(fake.CtxWithDefaultPrinter())
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "stringData:\n    password: s3cr3t\n")
+
+	// the value is only in the output, the package is left as is.
+	secret, err := os.ReadFile(filepath.Join(dir, "secret.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, files["secret.yaml"], string(secret))
+}
+
+func TestSortResources(t *testing.T) {
+	resource := func(kind, name, path, index string) *yaml.RNode {
+		r := yaml.MustParse(fmt.Sprintf("apiVersion: v1\nkind: %s\nmetadata:\n  name: %s\n", kind, name))
+		if path != "" {
+			require.NoError(t, r.PipeE(yaml.SetAnnotation(kioutil.PathAnnotation, path)))
+		}
+		if index != "" {
+			require.NoError(t, r.PipeE(yaml.SetAnnotation(kioutil.IndexAnnotation, index)))
+		}
+		return r
+	}
+	resources := []*yaml.RNode{
+		resource("ConfigMap", "b", "sub/cm.yaml", "0"),
+		resource("Secret", "a", "cm.yaml", "10"),
+		resource("ConfigMap", "c", "cm.yaml", ""),
+		resource("ConfigMap", "a", "cm.yaml", "2"),
+		resource("ConfigMap", "b", "cm.yaml", ""),
+		resource("Kptfile", "pkg", "Kptfile", "0"),
+	}
+	sortResources(resources)
+
+	var names []string
+	for _, r := range resources {
+		path, index, _ := kioutil.GetFileAnnotations(r)
+		names = append(names, fmt.Sprintf("%s[%s] %s/%s", path, index, r.GetKind(), r.GetName()))
+	}
+	assert.Equal(t, []string{
+		"Kptfile[0] Kptfile/pkg",
+		"cm.yaml[] ConfigMap/b",
+		"cm.yaml[] ConfigMap/c",
+		"cm.yaml[2] ConfigMap/a",
+		"cm.yaml[10] Secret/a",
+		"sub/cm.yaml[0] ConfigMap/b",
+	}, names)
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// sortResources sorts resources by their file path and index in the file.
+// Resources with the same path and index, e.g. resources generated without
+// an index, are sorted by apiVersion, kind, namespace and name.
+func sortResources(resources []*yaml.RNode) {
+	type sortKey struct {
+		path  string
+		index int
+		id    string
+	}
+	keys := map[*yaml.RNode]sortKey{}
+	for _, r := range resources {
+		path, index, _ := kioutil.GetFileAnnotations(r)
+		k := sortKey{
+			path:  path,
+			index: -1,
+			id:    strings.Join([]string{r.GetApiVersion(), r.GetKind(), r.GetNamespace(), r.GetName()}, "/"),
+		}
+		if i, err := strconv.Atoi(index); err == nil {
+			k.index = i
+		}
+		keys[r] = k
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := keys[resources[i]], keys[resources[j]]
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		if a.index != b.index {
+			return a.index < b.index
+		}
+		return a.id < b.id
+	})
+}
+
+// verifyIdempotent renders the package again from the resources rendered
+// in hctx, and returns an error describing the changes if the second
+// render changes them. The second render runs on an in-memory copy of the
+// package, so the package on disk is not modified.
+func (e *Renderer) verifyIdempotent(ctx context.Context, hctx *hydrationContext) error {
+	pr := printer.FromContextOrDie(ctx)
+	pr.Printf("\nVerifying that the pipeline is idempotent.\n")
+
+	fsys := filesys.MakeFsInMemory()
+	if err := copyDir(e.FileSystem, fsys, e.PkgPath); err != nil {
+		return err
+	}
+	pkgWriter := &kio.LocalPackageReadWriter{
+		PackagePath:        e.PkgPath,
+		PreserveSeqIndent:  true,
+		PackageFileName:    kptfilev1.KptFileName,
+		IncludeSubpackages: true,
+		WrapBareSeqNode:    true,
+		FileSystem:         filesys.FileSystemOrOnDisk{FileSystem: fsys},
+		MatchFilesGlob:     pkg.MatchAllKRM,
+	}
+	if err := pkgWriter.Write(cloneResources(hctx.root.resources)); err != nil {
+		return err
+	}
+	if err := pruneResources(fsys, hctx); err != nil {
+		return err
+	}
+
+	root, err := e.newRootPkgNode(fsys)
+	if err != nil {
+		return err
+	}
+	second := e.newHydrationContext(root, fsys)
+	if _, err := hydrate(ctx, root, second); err != nil {
+		return fmt.Errorf("second render failed: %w", err)
+	}
+	if err := adjustRelPath(second); err != nil {
+		return err
+	}
+
+	changes, err := resourceChanges(hctx.root.resources, second.root.resources)
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		return fmt.Errorf("pipeline is not idempotent, rendering the output again changed:\n  %s",
+			strings.Join(changes, "\n  "))
+	}
+	return nil
+}
+
+// resourceChanges returns a description of each resource that differs
+// between the resources before and after. Resources are matched by their
+// file path, apiVersion, kind, namespace and name.
+func resourceChanges(before, after []*yaml.RNode) ([]string, error) {
+	key := func(r *yaml.RNode) string {
+		path, _, _ := kioutil.GetFileAnnotations(r)
+		return fmt.Sprintf("%s %s %s", filepath.ToSlash(path), r.GetKind(), resourceName(r))
+	}
+	beforeByKey := map[string]*yaml.RNode{}
+	for _, r := range before {
+		beforeByKey[key(r)] = r
+	}
+	var changes []string
+	seen := map[string]bool{}
+	for _, r := range after {
+		k := key(r)
+		b, found := beforeByKey[k]
+		if !found {
+			changes = append(changes, k+": added")
+			continue
+		}
+		seen[k] = true
+		fields, err := resourceChangedFields(b, r)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			changes = append(changes, fmt.Sprintf("%s: changed %s", k, strings.Join(fields, ", ")))
+		}
+	}
+	for _, r := range before {
+		if k := key(r); !seen[k] {
+			changes = append(changes, k+": removed")
+		}
+	}
+	return changes, nil
+}
+
+func resourceName(r *yaml.RNode) string {
+	if ns := r.GetNamespace(); ns != "" {
+		return ns + "/" + r.GetName()
+	}
+	return r.GetName()
+}
+
+// copyDir copies the directory dir and its contents from the filesystem
+// src to dst. Git metadata is not copied.
+func copyDir(src, dst filesys.FileSystem, dir string) error {
+	return src.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return dst.MkdirAll(path)
+		}
+		b, err := src.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return dst.WriteFile(path, b)
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+)
+
+// inheritedFunctions are the pipeline functions that a package propagates
+// to its subpackages.
+type inheritedFunctions struct {
+	mutators   []kptfilev1.Function
+	validators []kptfilev1.Function
+}
+
+func (f inheritedFunctions) isEmpty() bool {
+	return len(f.mutators) == 0 && len(f.validators) == 0
+}
+
+// inheritFunctions adds the functions that p inherits from its parent
+// packages to the pipeline of its cached Kptfile, so the hydration sees
+// them without the Kptfile being written to the filesystem.
+func (hctx *hydrationContext) inheritFunctions(p *pkg.Pkg) error {
+	inherited, err := hctx.inherited(p)
+	if err != nil || inherited.isEmpty() {
+		return err
+	}
+	kf, err := p.Kptfile()
+	if err != nil {
+		return err
+	}
+	if kf.Pipeline == nil {
+		kf.Pipeline = &kptfilev1.Pipeline{}
+	}
+	kf.Pipeline.Mutators = withInherited(inherited.mutators, kf.Pipeline.Mutators, kf.Pipeline.SkipInherited)
+	kf.Pipeline.Validators = withInherited(inherited.validators, kf.Pipeline.Validators, kf.Pipeline.SkipInherited)
+	return nil
+}
+
+// inherited returns the functions that p inherits from its parent package.
+// The root package of the render doesn't inherit any functions, even if it
+// is a subpackage.
+func (hctx *hydrationContext) inherited(p *pkg.Pkg) (inheritedFunctions, error) {
+	if p.UniquePath == hctx.root.pkg.UniquePath {
+		return inheritedFunctions{}, nil
+	}
+	parent, err := hctx.parentPkg(p)
+	if err != nil {
+		return inheritedFunctions{}, err
+	}
+	return hctx.propagatedBy(parent)
+}
+
+// propagatedBy returns the functions that p propagates to its subpackages:
+// the functions it inherits and doesn't skip or override, and its own
+// functions with propagate set.
+func (hctx *hydrationContext) propagatedBy(p *pkg.Pkg) (inheritedFunctions, error) {
+	if f, found := hctx.propagated[p.UniquePath]; found {
+		return f, nil
+	}
+	inherited, err := hctx.inherited(p)
+	if err != nil {
+		return inheritedFunctions{}, err
+	}
+	pl, err := p.Pipeline()
+	if err != nil {
+		return inheritedFunctions{}, err
+	}
+	f := inheritedFunctions{
+		mutators:   propagated(withInherited(inherited.mutators, pl.Mutators, pl.SkipInherited)),
+		validators: propagated(withInherited(inherited.validators, pl.Validators, pl.SkipInherited)),
+	}
+	if hctx.propagated == nil {
+		hctx.propagated = map[types.UniquePath]inheritedFunctions{}
+	}
+	hctx.propagated[p.UniquePath] = f
+	return f, nil
+}
+
+// parentPkg returns the closest package above p in the hierarchy of the
+// root package.
+func (hctx *hydrationContext) parentPkg(p *pkg.Pkg) (*pkg.Pkg, error) {
+	root := hctx.root.pkg
+	dir := string(p.UniquePath)
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("package %q is not within the root package %q", p.DisplayPath, root.DisplayPath)
+		}
+		dir = parent
+		if dir == string(root.UniquePath) {
+			return root, nil
+		}
+		isPkg, err := pkg.IsPackageDir(hctx.fileSystem, dir)
+		if err != nil {
+			return nil, err
+		}
+		if isPkg {
+			return pkg.New(hctx.fileSystem, dir)
+		}
+	}
+}
+
+// withInherited returns the inherited functions followed by the functions
+// fns of the package. The inherited functions that are named in skip, or
+// that have the name of one of fns, are left out.
+func withInherited(inherited, fns []kptfilev1.Function, skip []string) []kptfilev1.Function {
+	if len(inherited) == 0 {
+		return fns
+	}
+	removed := map[string]bool{}
+	for _, name := range skip {
+		removed[name] = true
+	}
+	for _, f := range fns {
+		if f.Name != "" {
+			removed[f.Name] = true
+		}
+	}
+	var output []kptfilev1.Function
+	for _, f := range inherited {
+		if f.Name != "" && removed[f.Name] {
+			continue
+		}
+		output = append(output, *f.DeepCopy())
+	}
+	return append(output, fns...)
+}
+
+// propagated returns the functions of fns with propagate set.
+func propagated(fns []kptfilev1.Function) []kptfilev1.Function {
+	var output []kptfilev1.Function
+	for _, f := range fns {
+		if f.Propagate {
+			output = append(output, f)
+		}
+	}
+	return output
+}
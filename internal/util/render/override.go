@@ -0,0 +1,127 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+)
+
+// PipelineOverride changes the pipeline of the root package for a single
+// render. The Kptfile of the package is not modified.
+type PipelineOverride struct {
+	// AddMutators are appended to the mutators of the pipeline.
+	AddMutators []kptfilev1.Function
+
+	// AddValidators are appended to the validators of the pipeline.
+	AddValidators []kptfilev1.Function
+
+	// SkipMutators are the names, images or executables of the mutators
+	// that are removed from the pipeline.
+	SkipMutators []string
+
+	// SkipValidators are the names, images or executables of the
+	// validators that are removed from the pipeline.
+	SkipValidators []string
+}
+
+// IsEmpty returns true if the override doesn't change the pipeline.
+func (o *PipelineOverride) IsEmpty() bool {
+	return o == nil || (len(o.AddMutators) == 0 && len(o.AddValidators) == 0 &&
+		len(o.SkipMutators) == 0 && len(o.SkipValidators) == 0)
+}
+
+// Apply returns a copy of the pipeline pl with the override applied. Every
+// function to skip must be in the pipeline, so that a misspelled function
+// doesn't go unnoticed.
+func (o *PipelineOverride) Apply(pl *kptfilev1.Pipeline) (*kptfilev1.Pipeline, error) {
+	if pl == nil {
+		pl = &kptfilev1.Pipeline{}
+	}
+	pl = pl.DeepCopy()
+	if o.IsEmpty() {
+		return pl, nil
+	}
+	var err error
+	if pl.Mutators, err = skipFunctions(pl.Mutators, o.SkipMutators, "mutator"); err != nil {
+		return nil, err
+	}
+	if pl.Validators, err = skipFunctions(pl.Validators, o.SkipValidators, "validator"); err != nil {
+		return nil, err
+	}
+	pl.Mutators = append(pl.Mutators, o.AddMutators...)
+	pl.Validators = append(pl.Validators, o.AddValidators...)
+	return pl, nil
+}
+
+// skipFunctions returns the functions fns without the functions matching
+// one of skips.
+func skipFunctions(fns []kptfilev1.Function, skips []string, kind string) ([]kptfilev1.Function, error) {
+	if len(skips) == 0 {
+		return fns, nil
+	}
+	matched := map[string]bool{}
+	var output []kptfilev1.Function
+	for _, fn := range fns {
+		skip := false
+		for _, s := range skips {
+			if s == fn.Name || s == fn.Image || s == fn.Exec || s == fn.Composite {
+				matched[s] = true
+				skip = true
+			}
+		}
+		if !skip {
+			output = append(output, fn)
+		}
+	}
+	for _, s := range skips {
+		if !matched[s] {
+			return nil, fmt.Errorf("%s %q to skip is not in the pipeline", kind, s)
+		}
+	}
+	return output, nil
+}
+
+// ParseFunction parses a function given on the command line as a comma
+// separated list of `KEY=VALUE` pairs, e.g.
+// `image=set-labels:v0.1,config=labels.yaml`. The keys are `image`, `exec`,
+// `config` for the path of the function config in the package, and `name`.
+func ParseFunction(s string) (kptfilev1.Function, error) {
+	var fn kptfilev1.Function
+	for _, pair := range strings.Split(s, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || value == "" {
+			return fn, fmt.Errorf("invalid function %q: %q must be KEY=VALUE", s, pair)
+		}
+		switch strings.TrimSpace(key) {
+		case "image":
+			fn.Image = value
+		case "exec":
+			fn.Exec = value
+		case "config":
+			fn.ConfigPath = value
+		case "name":
+			fn.Name = value
+		default:
+			return fn, fmt.Errorf("invalid function %q: unknown key %q, must be one of image, exec, config, name", s, key)
+		}
+	}
+	if (fn.Image == "") == (fn.Exec == "") {
+		return fn, fmt.Errorf("invalid function %q: exactly one of image or exec must be set", s)
+	}
+	return fn, nil
+}
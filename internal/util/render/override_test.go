@@ -0,0 +1,190 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+func TestParseFunction(t *testing.T) {
+	testCases := map[string]struct {
+		input            string
+		expected         kptfilev1.Function
+		expectedErrorMsg string
+	}{
+		"image and config": {
+			input:    "image=set-labels:v0.1,config=labels.yaml",
+			expected: kptfilev1.Function{Image: "set-labels:v0.1", ConfigPath: "labels.yaml"},
+		},
+		"exec and name": {
+			input:    "exec=./fn.sh,name=local",
+			expected: kptfilev1.Function{Exec: "./fn.sh", Name: "local"},
+		},
+		"missing value": {
+			input:            "image=",
+			expectedErrorMsg: `"image=" must be KEY=VALUE`,
+		},
+		"unknown key": {
+			input:            "image=set-labels,selector=foo",
+			expectedErrorMsg: `unknown key "selector"`,
+		},
+		"image and exec": {
+			input:            "image=set-labels,exec=./fn.sh",
+			expectedErrorMsg: "exactly one of image or exec must be set",
+		},
+		"no image": {
+			input:            "config=labels.yaml",
+			expectedErrorMsg: "exactly one of image or exec must be set",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			fn, err := ParseFunction(tc.input)
+			if tc.expectedErrorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, fn)
+		})
+	}
+}
+
+func TestPipelineOverrideApply(t *testing.T) {
+	pl := &kptfilev1.Pipeline{
+		Mutators: []kptfilev1.Function{
+			{Image: "set-labels:v0.1"},
+			{Image: "apply-setters:v0.2", Name: "setters"},
+		},
+		Validators: []kptfilev1.Function{
+			{Image: "kubeval:v0.3"},
+		},
+	}
+
+	testCases := map[string]struct {
+		override         PipelineOverride
+		expected         *kptfilev1.Pipeline
+		expectedErrorMsg string
+	}{
+		"empty override": {
+			expected: pl,
+		},
+		"skip by name and image": {
+			override: PipelineOverride{
+				SkipMutators:   []string{"setters"},
+				SkipValidators: []string{"kubeval:v0.3"},
+			},
+			expected: &kptfilev1.Pipeline{
+				Mutators: []kptfilev1.Function{{Image: "set-labels:v0.1"}},
+			},
+		},
+		"add functions": {
+			override: PipelineOverride{
+				AddMutators:   []kptfilev1.Function{{Exec: "./fn.sh"}},
+				AddValidators: []kptfilev1.Function{{Image: "gatekeeper:v0.2"}},
+			},
+			expected: &kptfilev1.Pipeline{
+				Mutators: []kptfilev1.Function{
+					{Image: "set-labels:v0.1"},
+					{Image: "apply-setters:v0.2", Name: "setters"},
+					{Exec: "./fn.sh"},
+				},
+				Validators: []kptfilev1.Function{
+					{Image: "kubeval:v0.3"},
+					{Image: "gatekeeper:v0.2"},
+				},
+			},
+		},
+		"skip unknown function": {
+			override: PipelineOverride{
+				SkipValidators: []string{"setters"},
+			},
+			expectedErrorMsg: `validator "setters" to skip is not in the pipeline`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := tc.override.Apply(pl)
+			if tc.expectedErrorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+	// the pipeline of the Kptfile is left as is.
+	assert.Len(t, pl.Mutators, 2)
+	assert.Len(t, pl.Validators, 1)
+}
+
+func TestRenderPipelineOverride(t *testing.T) {
+	dir := t.TempDir()
+	kptfile := fmt.Sprintf(`apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+pipeline:
+  mutators:
+  - exec: %s
+    name: count
+`, filepath.Join(dir, "count.sh"))
+	files := map[string]string{
+		"count.sh":    "#!/bin/sh\nsed -e 's/count: \"1\"/count: \"2\"/'\n",
+		"color.sh":    "#!/bin/sh\nsed -e 's/color: red/color: blue/'\n",
+		"pkg/Kptfile": kptfile,
+		"pkg/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  count: \"1\"\n  color: red\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0700))
+	}
+
+	r := &Renderer{
+		PkgPath:          filepath.Join(dir, "pkg"),
+		VerifyIdempotent: true,
+		FileSystem:       filesys.FileSystemOrOnDisk{},
+		PipelineOverride: &PipelineOverride{
+			AddMutators:  []kptfilev1.Function{{Exec: filepath.Join(dir, "color.sh")}},
+			SkipMutators: []string{"count"},
+		},
+	}
+	r.RunnerOptions.InitDefaults()
+	r.RunnerOptions.AllowExec = true
+	_, err := r.Execute(fake.CtxWithDefaultPrinter())
+	require.NoError(t, err)
+
+	cm, err := os.ReadFile(filepath.Join(dir, "pkg", "cm.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(cm), `count: "1"`)
+	assert.Contains(t, string(cm), "color: blue")
+	actual, err := os.ReadFile(filepath.Join(dir, "pkg", "Kptfile"))
+	require.NoError(t, err)
+	assert.Equal(t, kptfile, string(actual))
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// statusRecorder records the changes made by the mutators of a render.
+type statusRecorder struct {
+	status *fnresult.RenderStatus
+
+	// now returns the current time. It can be replaced in tests.
+	now func() time.Time
+}
+
+func newStatusRecorder() *statusRecorder {
+	return &statusRecorder{
+		status: fnresult.NewRenderStatus(),
+		now:    time.Now,
+	}
+}
+
+// record adds an entry for the mutator function of the package pn, given
+// the resources before and after the mutator was run. The resources must
+// have been identified with fnruntime.SetResourceIds before the mutator
+// was run.
+func (s *statusRecorder) record(hctx *hydrationContext, pn *pkgNode, function *kptfilev1.Function,
+	start time.Time, before, after []*yaml.RNode) error {
+	relPath, err := pn.pkg.RelativePathTo(hctx.root.pkg)
+	if err != nil {
+		return err
+	}
+	m := fnresult.Mutation{
+		Image:     function.Image,
+		ExecPath:  function.Exec,
+		Pkg:       filepath.ToSlash(relPath),
+		Timestamp: start.UTC().Format(time.RFC3339),
+	}
+
+	beforeByID := map[string]*yaml.RNode{}
+	for _, r := range before {
+		beforeByID[r.GetAnnotations()[fnruntime.ResourceIDAnnotation]] = r
+	}
+	seen := map[string]bool{}
+	for _, r := range after {
+		if isDependencyResource(r) {
+			continue
+		}
+		id := r.GetAnnotations()[fnruntime.ResourceIDAnnotation]
+		b, found := beforeByID[id]
+		if !found || seen[id] {
+			m.Resources = append(m.Resources, s.resourceMutation(hctx, r, fnresult.ResourceAdded, nil))
+			continue
+		}
+		seen[id] = true
+		fields, err := resourceChangedFields(b, r)
+		if err != nil {
+			return err
+		}
+		if len(fields) > 0 {
+			m.Resources = append(m.Resources, s.resourceMutation(hctx, r, fnresult.ResourceModified, fields))
+		}
+	}
+	for _, r := range before {
+		if isDependencyResource(r) || seen[r.GetAnnotations()[fnruntime.ResourceIDAnnotation]] {
+			continue
+		}
+		m.Resources = append(m.Resources, s.resourceMutation(hctx, r, fnresult.ResourceRemoved, nil))
+	}
+	s.status.Items = append(s.status.Items, m)
+	return nil
+}
+
+func (s *statusRecorder) resourceMutation(hctx *hydrationContext, r *yaml.RNode,
+	action fnresult.MutationAction, fields []string) fnresult.ResourceMutation {
+	return fnresult.ResourceMutation{
+		APIVersion: r.GetApiVersion(),
+		Kind:       r.GetKind(),
+		Name:       r.GetName(),
+		Namespace:  r.GetNamespace(),
+		File:       resourceFile(hctx, r),
+		Action:     action,
+		Fields:     fields,
+	}
+}
+
+// write writes the render status to the file at path.
+func (s *statusRecorder) write(fsys filesys.FileSystem, path string) error {
+	b, err := yaml.Marshal(s.status)
+	if err != nil {
+		return err
+	}
+	if err := fsys.WriteFile(path, b); err != nil {
+		return fmt.Errorf("failed to write render status: %w", err)
+	}
+	return nil
+}
+
+// writeReferenceGraph writes the reference graph to the file at path.
+func writeReferenceGraph(fsys filesys.FileSystem, path string, graph *fnresult.ReferenceGraph) error {
+	b, err := yaml.Marshal(graph)
+	if err != nil {
+		return err
+	}
+	if err := fsys.WriteFile(path, b); err != nil {
+		return fmt.Errorf("failed to write reference graph: %w", err)
+	}
+	return nil
+}
+
+func isDependencyResource(r *yaml.RNode) bool {
+	_, found := r.GetAnnotations()[dependencyAnnotation]
+	return found
+}
+
+// resourceFile returns the slash-separated path of the file of the resource
+// relative to the root package, or an empty string if it can't be
+// determined.
+func resourceFile(hctx *hydrationContext, r *yaml.RNode) string {
+	pkgPath, err := pkg.GetPkgPathAnnotation(r)
+	if err != nil || pkgPath == "" {
+		return ""
+	}
+	path, _, err := kioutil.GetFileAnnotations(r)
+	if err != nil || path == "" {
+		return ""
+	}
+	relPath, err := pathRelToRoot(string(hctx.root.pkg.UniquePath), pkgPath, path)
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// resourceChangedFields returns the paths of the fields that differ between
+// the resources a and b. The annotations kpt and the function runtime use
+// to track resources are ignored.
+func resourceChangedFields(a, b *yaml.RNode) ([]string, error) {
+	a, err := withoutInternalAnnotations(a)
+	if err != nil {
+		return nil, err
+	}
+	b, err = withoutInternalAnnotations(b)
+	if err != nil {
+		return nil, err
+	}
+	return changedFields("", a.YNode(), b.YNode(), nil), nil
+}
+
+// withoutInternalAnnotations returns a copy of r without the annotations
+// used to track resources.
+func withoutInternalAnnotations(r *yaml.RNode) (*yaml.RNode, error) {
+	r = r.Copy()
+	for k := range r.GetAnnotations() {
+		if strings.HasPrefix(k, "internal.config.kubernetes.io/") ||
+			k == fnruntime.ResourceIDAnnotation ||
+			k == kioutil.LegacyPathAnnotation || // nolint:staticcheck
+			k == kioutil.LegacyIndexAnnotation || // nolint:staticcheck
+			k == kioutil.LegacyIdAnnotation { // nolint:staticcheck
+			if err := r.PipeE(yaml.ClearAnnotation(k)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r, nil
+}
+
+// changedFields appends the paths of the fields that differ between the
+// nodes a and b to fields. Mappings are compared key by key, and sequences
+// of the same length element by element.
+func changedFields(path string, a, b *yaml.Node, fields []string) []string {
+	switch {
+	case a.Kind == yaml.DocumentNode && b.Kind == yaml.DocumentNode &&
+		len(a.Content) == 1 && len(b.Content) == 1:
+		return changedFields(path, a.Content[0], b.Content[0], fields)
+	case a.Kind == yaml.MappingNode && b.Kind == yaml.MappingNode:
+		aValues, bValues := mappingValues(a), mappingValues(b)
+		var keys []string
+		seen := map[string]bool{}
+		for _, n := range []*yaml.Node{a, b} {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if k := n.Content[i].Value; !seen[k] {
+					seen[k] = true
+					keys = append(keys, k)
+				}
+			}
+		}
+		for _, k := range keys {
+			av, bv := aValues[k], bValues[k]
+			if av == nil || bv == nil {
+				fields = append(fields, fieldPath(path, k))
+				continue
+			}
+			fields = changedFields(fieldPath(path, k), av, bv, fields)
+		}
+		return fields
+	case a.Kind == yaml.SequenceNode && b.Kind == yaml.SequenceNode && len(a.Content) == len(b.Content):
+		for i := range a.Content {
+			fields = changedFields(fmt.Sprintf("%s[%d]", path, i), a.Content[i], b.Content[i], fields)
+		}
+		return fields
+	case a.Kind == yaml.ScalarNode && b.Kind == yaml.ScalarNode &&
+		a.Value == b.Value && a.ShortTag() == b.ShortTag():
+		return fields
+	}
+	if path == "" {
+		path = "."
+	}
+	return append(fields, path)
+}
+
+// mappingValues returns the values of the mapping node n by key.
+func mappingValues(n *yaml.Node) map[string]*yaml.Node {
+	values := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if _, found := values[n.Content[i].Value]; !found {
+			values[n.Content[i].Value] = n.Content[i+1]
+		}
+	}
+	return values
+}
+
+var simpleFieldName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// fieldPath returns the path of the field key of the mapping at path.
+// Keys with characters other than letters, digits, '-' and '_', such as
+// label and annotation keys, are written in brackets.
+func fieldPath(path, key string) string {
+	if !simpleFieldName.MatchString(key) {
+		return fmt.Sprintf("%s[%s]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
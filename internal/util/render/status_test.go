@@ -0,0 +1,243 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func TestResourceChangedFields(t *testing.T) {
+	tests := map[string]struct {
+		before   string
+		after    string
+		expected []string
+	}{
+		"no changes": {
+			before: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: '1'\n",
+			after:  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: \"1\"\n",
+		},
+		"changed, added and removed fields": {
+			before:   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: '1'\n  b: '2'\n",
+			after:    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n  namespace: ns\ndata:\n  a: '3'\n  c: '4'\n",
+			expected: []string{"metadata.namespace", "data.a", "data.b", "data.c"},
+		},
+		"label keys and sequences": {
+			before: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+      volumes:
+      - name: a
+`,
+			after: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app.kubernetes.io/name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v2
+      volumes:
+      - name: a
+      - name: b
+`,
+			expected: []string{
+				"metadata.labels",
+				"spec.template.spec.containers[0].image",
+				"spec.template.spec.volumes",
+			},
+		},
+		"internal annotations are ignored": {
+			before: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    internal.config.kubernetes.io/path: cm.yaml
+    internal.config.k8s.io/kpt-resource-id: "0"
+`,
+			after: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    config.kubernetes.io/path: cm.yaml
+    example.com/owner: team-a
+`,
+			expected: []string{"metadata.annotations[example.com/owner]"},
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			fields, err := resourceChangedFields(yaml.MustParse(tc.before), yaml.MustParse(tc.after))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, fields)
+		})
+	}
+}
+
+func TestRenderStatus(t *testing.T) {
+	dir := t.TempDir()
+	// the mutators change the prefix length of the CIDRs, but not the Kptfiles.
+	files := map[string]string{
+		"root/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: root
+pipeline:
+  mutators:
+  - exec: sed -e s/16$/24/
+  - exec: tee
+`,
+		"root/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  cidr: 10.0.0.0/16\n",
+		"root/sub/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: sub
+pipeline:
+  mutators:
+  - exec: sed -e s/16$/20/
+`,
+		"root/sub/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: sub-cm\ndata:\n  cidr: 10.0.0.0/16\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+	}
+
+	statusPath := filepath.Join(dir, "render-status.yaml")
+	r := &Renderer{
+		PkgPath:        filepath.Join(dir, "root"),
+		StatusFilePath: statusPath,
+		FileSystem:     filesys.FileSystemOrOnDisk{},
+	}
+	r.RunnerOptions.InitDefaults()
+	r.RunnerOptions.AllowExec = true
+	_, err := r.Execute(fake.CtxWithDefaultPrinter())
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(statusPath)
+	require.NoError(t, err)
+	status := &fnresult.RenderStatus{}
+	require.NoError(t, yaml.Unmarshal(b, status))
+	assert.Equal(t, "RenderStatus", status.Kind)
+	require.Len(t, status.Items, 3)
+	for i := range status.Items {
+		assert.NotEmpty(t, status.Items[i].Timestamp)
+		status.Items[i].Timestamp = ""
+	}
+	assert.Equal(t, []fnresult.Mutation{
+		{
+			ExecPath: "sed -e s/16$/20/",
+			Pkg:      "sub",
+			Resources: []fnresult.ResourceMutation{
+				{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       "sub-cm",
+					File:       "sub/cm.yaml",
+					Action:     fnresult.ResourceModified,
+					Fields:     []string{"data.cidr"},
+				},
+			},
+		},
+		{
+			ExecPath: "sed -e s/16$/24/",
+			Pkg:      ".",
+			Resources: []fnresult.ResourceMutation{
+				{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       "cm",
+					File:       "cm.yaml",
+					Action:     fnresult.ResourceModified,
+					Fields:     []string{"data.cidr"},
+				},
+			},
+		},
+		{
+			ExecPath: "tee",
+			Pkg:      ".",
+		},
+	}, status.Items)
+}
+
+func TestRenderReferenceGraph(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"root/Kptfile":     "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n",
+		"root/cm.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+		"root/sub/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: sub\n",
+		"root/sub/pod.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  volumes:
+  - name: config
+    configMap:
+      name: cm
+`,
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+	}
+
+	graphPath := filepath.Join(dir, "reference-graph.yaml")
+	r := &Renderer{
+		PkgPath:            filepath.Join(dir, "root"),
+		ReferenceGraphPath: graphPath,
+		FileSystem:         filesys.FileSystemOrOnDisk{},
+	}
+	r.RunnerOptions.InitDefaults()
+	_, err := r.Execute(fake.CtxWithDefaultPrinter())
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(graphPath)
+	require.NoError(t, err)
+	graph := &fnresult.ReferenceGraph{}
+	require.NoError(t, yaml.Unmarshal(b, graph))
+	assert.Equal(t, "ReferenceGraph", graph.Kind)
+	assert.Equal(t, []fnresult.Reference{
+		{
+			From:  fnresult.ResourceRef{Kind: "Pod", Name: "pod", File: "sub/pod.yaml"},
+			Field: "spec.volumes[0].configMap.name",
+			To:    fnresult.ResourceRef{Kind: "ConfigMap", Name: "cm", File: "cm.yaml"},
+		},
+	}, graph.Items)
+}
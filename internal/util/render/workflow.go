@@ -0,0 +1,459 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/pkg"
+	"github.com/GoogleContainerTools/kpt/internal/types"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// WorkflowEngine is a workflow engine that a pipeline can be converted for.
+type WorkflowEngine string
+
+const (
+	Tekton WorkflowEngine = "tekton"
+	Argo   WorkflowEngine = "argo"
+
+	// DefaultWorkflowKptImage is the image used to run the functions in
+	// emitted workflows.
+	DefaultWorkflowKptImage = "gcr.io/kpt-dev/kpt:latest"
+
+	workflowWorkspace = "source"
+	workflowMountPath = "/workspace/source"
+	dindImage         = "docker:dind"
+	dockerHost        = "tcp://localhost:2375"
+)
+
+// WorkflowEngines are the supported workflow engines.
+var WorkflowEngines = []WorkflowEngine{Tekton, Argo}
+
+// WorkflowEnginesAsStrings returns the supported workflow engines as strings.
+func WorkflowEnginesAsStrings() []string {
+	var engines []string
+	for _, e := range WorkflowEngines {
+		engines = append(engines, string(e))
+	}
+	return engines
+}
+
+// WorkflowEmitter converts the pipelines of a package hierarchy into a
+// workflow definition with one step per function. The steps run the
+// functions in the order they would be run by render.
+type WorkflowEmitter struct {
+	// PkgPath is the absolute path to the root package
+	PkgPath string
+
+	// Engine is the workflow engine to emit the workflow for.
+	Engine WorkflowEngine
+
+	// KptImage is the image with the kpt CLI used to run each function.
+	KptImage string
+
+	// FileSystem is the input filesystem to operate on
+	FileSystem filesys.FileSystem
+}
+
+// workflowStep is a single function invocation in the emitted workflow.
+type workflowStep struct {
+	Name string
+	Args []string
+}
+
+// Emit writes the workflow for the package to w.
+func (e *WorkflowEmitter) Emit(w io.Writer) error {
+	const op errors.Op = "fn.render.workflow"
+	root, err := pkg.New(e.FileSystem, e.PkgPath)
+	if err != nil {
+		return errors.E(op, types.UniquePath(e.PkgPath), err)
+	}
+	steps, err := workflowSteps(root, root, map[string]int{})
+	if err != nil {
+		return errors.E(op, root.UniquePath, err)
+	}
+	if len(steps) == 0 {
+		return errors.E(op, root.UniquePath, fmt.Errorf("package doesn't declare any functions"))
+	}
+
+	kptImage := e.KptImage
+	if kptImage == "" {
+		kptImage = DefaultWorkflowKptImage
+	}
+	name := dnsLabel(filepath.Base(root.UniquePath.String()), 40) + "-render-"
+
+	var wf interface{}
+	switch e.Engine {
+	case Tekton:
+		wf = newTektonPipelineRun(name, kptImage, steps)
+	case Argo:
+		wf = newArgoWorkflow(name, kptImage, steps)
+	default:
+		return errors.E(op, errors.InvalidParam, fmt.Errorf("unsupported workflow engine %q, must be one of %s",
+			e.Engine, strings.Join(WorkflowEnginesAsStrings(), ", ")))
+	}
+	b, err := yaml.Marshal(wf)
+	if err != nil {
+		return errors.E(op, root.UniquePath, err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// workflowSteps returns the steps for the pipeline of p and all its
+// subpackages. Like render, subpackages are handled before their parent.
+func workflowSteps(root, p *pkg.Pkg, names map[string]int) ([]workflowStep, error) {
+	var steps []workflowStep
+	subpkgs, err := p.DirectSubpackages()
+	if err != nil {
+		return nil, err
+	}
+	for _, subpkg := range subpkgs {
+		subSteps, err := workflowSteps(root, subpkg, names)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, subSteps...)
+	}
+
+	pl, err := p.Pipeline()
+	if err != nil {
+		return nil, err
+	}
+	if pl.IsEmpty() {
+		return steps, nil
+	}
+	relPath, err := p.RelativePathTo(root)
+	if err != nil {
+		return nil, err
+	}
+	pkgDir := path.Join(workflowMountPath, filepath.ToSlash(relPath))
+	fns := append(append([]kptfilev1.Function{}, pl.Mutators...), pl.Validators...)
+	for i := range fns {
+		args, err := evalArgs(&fns[i], pkgDir)
+		if err != nil {
+			return nil, errors.E(p.UniquePath, err)
+		}
+		steps = append(steps, workflowStep{
+			Name: stepName(relPath, &fns[i], names),
+			Args: args,
+		})
+	}
+	return steps, nil
+}
+
+// evalArgs returns the arguments to 'kpt fn eval' that run fn on the
+// package at pkgDir.
+func evalArgs(fn *kptfilev1.Function, pkgDir string) ([]string, error) {
+	if fn.Exec != "" {
+		return nil, fmt.Errorf("function %q uses exec, which is not supported in workflows", fn.Exec)
+	}
+	if fn.Composite != "" {
+		return nil, fmt.Errorf("composite function %q is not supported in workflows", fn.Composite)
+	}
+	if len(fn.Selectors) > 1 || len(fn.Exclusions) > 1 {
+		return nil, fmt.Errorf("function %q has more than one selector or exclusion, which is not supported in workflows", fn.Image)
+	}
+	if len(fn.Mounts) > 0 {
+		return nil, fmt.Errorf("function %q uses mounts, which are not supported in workflows", fn.Image)
+	}
+	if len(fn.Env) > 0 {
+		return nil, fmt.Errorf("function %q uses env, which is not supported in workflows", fn.Image)
+	}
+	args := []string{"fn", "eval", pkgDir, "--image", fn.Image}
+	if fn.Network != nil && *fn.Network {
+		args = append(args, "--network")
+	}
+	if fn.ConfigPath != "" {
+		args = append(args, "--fn-config", path.Join(pkgDir, filepath.ToSlash(fn.ConfigPath)))
+	}
+	if len(fn.Selectors) == 1 {
+		args = append(args, selectorArgs("match", fn.Selectors[0])...)
+	}
+	if len(fn.Exclusions) == 1 {
+		args = append(args, selectorArgs("exclude", fn.Exclusions[0])...)
+	}
+	if len(fn.ConfigMap) > 0 {
+		args = append(args, "--")
+		for _, k := range sortedKeys(fn.ConfigMap) {
+			args = append(args, fmt.Sprintf("%s=%s", k, fn.ConfigMap[k]))
+		}
+	}
+	return args, nil
+}
+
+func selectorArgs(prefix string, s kptfilev1.Selector) []string {
+	var args []string
+	add := func(flag, value string) {
+		if value != "" {
+			args = append(args, fmt.Sprintf("--%s-%s", prefix, flag), value)
+		}
+	}
+	add("api-version", s.APIVersion)
+	add("kind", s.Kind)
+	add("name", s.Name)
+	add("namespace", s.Namespace)
+	for _, k := range sortedKeys(s.Labels) {
+		add("labels", fmt.Sprintf("%s=%s", k, s.Labels[k]))
+	}
+	for _, k := range sortedKeys(s.Annotations) {
+		add("annotations", fmt.Sprintf("%s=%s", k, s.Annotations[k]))
+	}
+	return args
+}
+
+// stepName returns a unique name for the step running fn in the package
+// at relPath that is a valid DNS label.
+func stepName(relPath string, fn *kptfilev1.Function, names map[string]int) string {
+	fnName := fn.Name
+	if fnName == "" {
+		fnName = path.Base(fn.Image)
+		if i := strings.IndexAny(fnName, ":@"); i >= 0 {
+			fnName = fnName[:i]
+		}
+	}
+	name := fnName
+	if relPath != "." {
+		name = relPath + "-" + fnName
+	}
+	name = dnsLabel(name, 58)
+	names[name]++
+	if n := names[name]; n > 1 {
+		name = fmt.Sprintf("%s-%d", name, n)
+	}
+	return name
+}
+
+var nonDNSChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// dnsLabel converts s into a DNS label of at most maxLen characters.
+func dnsLabel(s string, maxLen int) string {
+	s = nonDNSChars.ReplaceAllString(strings.ToLower(s), "-")
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	s = strings.Trim(s, "-")
+	if s == "" {
+		return "pkg"
+	}
+	return s
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// container is the subset of the container spec used by both Tekton steps
+// and Argo templates.
+type container struct {
+	Name               string           `yaml:"name,omitempty"`
+	Image              string           `yaml:"image"`
+	Args               []string         `yaml:"args,omitempty"`
+	WorkingDir         string           `yaml:"workingDir,omitempty"`
+	Env                []envVar         `yaml:"env,omitempty"`
+	VolumeMounts       []volumeMount    `yaml:"volumeMounts,omitempty"`
+	SecurityContext    *securityContext `yaml:"securityContext,omitempty"`
+	MirrorVolumeMounts bool             `yaml:"mirrorVolumeMounts,omitempty"`
+}
+
+type envVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type volumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+}
+
+type securityContext struct {
+	Privileged bool `yaml:"privileged"`
+}
+
+type objectMeta struct {
+	GenerateName string `yaml:"generateName"`
+}
+
+// workspaceVolume binds the source workspace (Tekton) or volume (Argo) to
+// a PersistentVolumeClaim that is expected to hold the package.
+type workspaceVolume struct {
+	Name                  string                `yaml:"name"`
+	PersistentVolumeClaim persistentVolumeClaim `yaml:"persistentVolumeClaim"`
+}
+
+type persistentVolumeClaim struct {
+	ClaimName string `yaml:"claimName"`
+}
+
+func sourceVolume() workspaceVolume {
+	return workspaceVolume{
+		Name:                  workflowWorkspace,
+		PersistentVolumeClaim: persistentVolumeClaim{ClaimName: "kpt-" + workflowWorkspace},
+	}
+}
+
+// The functions run with the docker CLI in the kpt image, so every step
+// gets a docker daemon as a sidecar.
+func dindSidecar() container {
+	return container{
+		Name:            "dind",
+		Image:           dindImage,
+		Env:             []envVar{{Name: "DOCKER_TLS_CERTDIR", Value: ""}},
+		SecurityContext: &securityContext{Privileged: true},
+	}
+}
+
+func stepContainer(name, kptImage string, s workflowStep) container {
+	return container{
+		Name:       name,
+		Image:      kptImage,
+		Args:       s.Args,
+		WorkingDir: workflowMountPath,
+		Env:        []envVar{{Name: "DOCKER_HOST", Value: dockerHost}},
+	}
+}
+
+type tektonPipelineRun struct {
+	APIVersion string        `yaml:"apiVersion"`
+	Kind       string        `yaml:"kind"`
+	Metadata   objectMeta    `yaml:"metadata"`
+	Spec       tektonRunSpec `yaml:"spec"`
+}
+
+type tektonRunSpec struct {
+	Workspaces   []workspaceVolume  `yaml:"workspaces"`
+	PipelineSpec tektonPipelineSpec `yaml:"pipelineSpec"`
+}
+
+type tektonPipelineSpec struct {
+	Workspaces []tektonWorkspace `yaml:"workspaces"`
+	Tasks      []tektonTask      `yaml:"tasks"`
+}
+
+type tektonWorkspace struct {
+	Name      string `yaml:"name"`
+	Workspace string `yaml:"workspace,omitempty"`
+	MountPath string `yaml:"mountPath,omitempty"`
+}
+
+type tektonTask struct {
+	Name       string            `yaml:"name"`
+	Workspaces []tektonWorkspace `yaml:"workspaces"`
+	TaskSpec   tektonTaskSpec    `yaml:"taskSpec"`
+}
+
+type tektonTaskSpec struct {
+	Workspaces []tektonWorkspace `yaml:"workspaces"`
+	Sidecars   []container       `yaml:"sidecars"`
+	Steps      []container       `yaml:"steps"`
+}
+
+// newTektonPipelineRun returns a Tekton PipelineRun with a single task that
+// runs the steps in order. The package is expected in the source workspace.
+func newTektonPipelineRun(name, kptImage string, steps []workflowStep) *tektonPipelineRun {
+	var tektonSteps []container
+	for _, s := range steps {
+		tektonSteps = append(tektonSteps, stepContainer(s.Name, kptImage, s))
+	}
+	return &tektonPipelineRun{
+		APIVersion: "tekton.dev/v1",
+		Kind:       "PipelineRun",
+		Metadata:   objectMeta{GenerateName: name},
+		Spec: tektonRunSpec{
+			Workspaces: []workspaceVolume{sourceVolume()},
+			PipelineSpec: tektonPipelineSpec{
+				Workspaces: []tektonWorkspace{{Name: workflowWorkspace}},
+				Tasks: []tektonTask{{
+					Name:       "render",
+					Workspaces: []tektonWorkspace{{Name: workflowWorkspace, Workspace: workflowWorkspace}},
+					TaskSpec: tektonTaskSpec{
+						Workspaces: []tektonWorkspace{{Name: workflowWorkspace, MountPath: workflowMountPath}},
+						Sidecars:   []container{dindSidecar()},
+						Steps:      tektonSteps,
+					},
+				}},
+			},
+		},
+	}
+}
+
+type argoWorkflow struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Kind       string           `yaml:"kind"`
+	Metadata   objectMeta       `yaml:"metadata"`
+	Spec       argoWorkflowSpec `yaml:"spec"`
+}
+
+type argoWorkflowSpec struct {
+	Entrypoint string            `yaml:"entrypoint"`
+	Volumes    []workspaceVolume `yaml:"volumes"`
+	Templates  []argoTemplate    `yaml:"templates"`
+}
+
+type argoTemplate struct {
+	Name      string               `yaml:"name"`
+	Steps     [][]argoWorkflowStep `yaml:"steps,omitempty"`
+	Container *container           `yaml:"container,omitempty"`
+	Sidecars  []container          `yaml:"sidecars,omitempty"`
+}
+
+type argoWorkflowStep struct {
+	Name     string `yaml:"name"`
+	Template string `yaml:"template"`
+}
+
+// newArgoWorkflow returns an Argo Workflow that runs the steps in sequence.
+// The package is expected in the source volume.
+func newArgoWorkflow(name, kptImage string, steps []workflowStep) *argoWorkflow {
+	entrypoint := argoTemplate{Name: "render"}
+	templates := []argoTemplate{}
+	for _, s := range steps {
+		entrypoint.Steps = append(entrypoint.Steps, []argoWorkflowStep{{Name: s.Name, Template: s.Name}})
+		c := stepContainer("", kptImage, s)
+		c.VolumeMounts = []volumeMount{{Name: workflowWorkspace, MountPath: workflowMountPath}}
+		sidecar := dindSidecar()
+		sidecar.MirrorVolumeMounts = true
+		templates = append(templates, argoTemplate{
+			Name:      s.Name,
+			Container: &c,
+			Sidecars:  []container{sidecar},
+		})
+	}
+	return &argoWorkflow{
+		APIVersion: "argoproj.io/v1alpha1",
+		Kind:       "Workflow",
+		Metadata:   objectMeta{GenerateName: name},
+		Spec: argoWorkflowSpec{
+			Entrypoint: entrypoint.Name,
+			Volumes:    []workspaceVolume{sourceVolume()},
+			Templates:  append([]argoTemplate{entrypoint}, templates...),
+		},
+	}
+}
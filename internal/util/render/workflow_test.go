@@ -0,0 +1,302 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const (
+	rootKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: my-pkg
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-labels:v0.1
+    configMap:
+      tier: backend
+      app: db
+  validators:
+  - image: gcr.io/kpt-fn/kubeval:v0.3
+    selectors:
+    - kind: Deployment
+`
+	subKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: sub
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-namespace:v0.4
+    configPath: ns.yaml
+  - image: gcr.io/kpt-fn/set-namespace:v0.4
+    configPath: ns.yaml
+`
+	execKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: my-pkg
+pipeline:
+  mutators:
+  - exec: ./fn
+`
+	mountKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: my-pkg
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/fetch:v0.1
+    mounts:
+    - type: tmpfs
+      dst: /tmp
+`
+	envKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: my-pkg
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/fetch:v0.1
+    env:
+    - PRICING_API_TOKEN
+`
+	compositeKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: my-pkg
+pipeline:
+  mutators:
+  - composite: gcr.io/my-org/org-standard:v1
+`
+)
+
+func TestWorkflowEmitter(t *testing.T) {
+	testCases := map[string]struct {
+		files       map[string]string
+		engine      WorkflowEngine
+		expected    string
+		expectedErr string
+	}{
+		"tekton": {
+			files: map[string]string{
+				"Kptfile":     rootKptfile,
+				"sub/Kptfile": subKptfile,
+			},
+			engine: Tekton,
+			expected: `apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  generateName: my-pkg-render-
+spec:
+  workspaces:
+  - name: source
+    persistentVolumeClaim:
+      claimName: kpt-source
+  pipelineSpec:
+    workspaces:
+    - name: source
+    tasks:
+    - name: render
+      workspaces:
+      - name: source
+        workspace: source
+      taskSpec:
+        workspaces:
+        - name: source
+          mountPath: /workspace/source
+        sidecars:
+        - name: dind
+          image: docker:dind
+          env:
+          - name: DOCKER_TLS_CERTDIR
+            value: ""
+          securityContext:
+            privileged: true
+        steps:
+        - name: sub-set-namespace
+          image: kpt:test
+          args:
+          - fn
+          - eval
+          - /workspace/source/sub
+          - --image
+          - gcr.io/kpt-fn/set-namespace:v0.4
+          - --fn-config
+          - /workspace/source/sub/ns.yaml
+          workingDir: /workspace/source
+          env:
+          - name: DOCKER_HOST
+            value: tcp://localhost:2375
+        - name: sub-set-namespace-2
+          image: kpt:test
+          args:
+          - fn
+          - eval
+          - /workspace/source/sub
+          - --image
+          - gcr.io/kpt-fn/set-namespace:v0.4
+          - --fn-config
+          - /workspace/source/sub/ns.yaml
+          workingDir: /workspace/source
+          env:
+          - name: DOCKER_HOST
+            value: tcp://localhost:2375
+        - name: set-labels
+          image: kpt:test
+          args:
+          - fn
+          - eval
+          - /workspace/source
+          - --image
+          - gcr.io/kpt-fn/set-labels:v0.1
+          - --
+          - app=db
+          - tier=backend
+          workingDir: /workspace/source
+          env:
+          - name: DOCKER_HOST
+            value: tcp://localhost:2375
+        - name: kubeval
+          image: kpt:test
+          args:
+          - fn
+          - eval
+          - /workspace/source
+          - --image
+          - gcr.io/kpt-fn/kubeval:v0.3
+          - --match-kind
+          - Deployment
+          workingDir: /workspace/source
+          env:
+          - name: DOCKER_HOST
+            value: tcp://localhost:2375
+`,
+		},
+		"argo": {
+			files: map[string]string{
+				"Kptfile": strings.Replace(rootKptfile, "  validators:\n  - image: gcr.io/kpt-fn/kubeval:v0.3\n    selectors:\n    - kind: Deployment\n", "", 1),
+			},
+			engine: Argo,
+			expected: `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: my-pkg-render-
+spec:
+  entrypoint: render
+  volumes:
+  - name: source
+    persistentVolumeClaim:
+      claimName: kpt-source
+  templates:
+  - name: render
+    steps:
+    - - name: set-labels
+        template: set-labels
+  - name: set-labels
+    container:
+      image: kpt:test
+      args:
+      - fn
+      - eval
+      - /workspace/source
+      - --image
+      - gcr.io/kpt-fn/set-labels:v0.1
+      - --
+      - app=db
+      - tier=backend
+      workingDir: /workspace/source
+      env:
+      - name: DOCKER_HOST
+        value: tcp://localhost:2375
+      volumeMounts:
+      - name: source
+        mountPath: /workspace/source
+    sidecars:
+    - name: dind
+      image: docker:dind
+      env:
+      - name: DOCKER_TLS_CERTDIR
+        value: ""
+      securityContext:
+        privileged: true
+      mirrorVolumeMounts: true
+`,
+		},
+		"exec functions are not supported": {
+			files:       map[string]string{"Kptfile": execKptfile},
+			engine:      Tekton,
+			expectedErr: `function "./fn" uses exec, which is not supported in workflows`,
+		},
+		"mounts are not supported": {
+			files:       map[string]string{"Kptfile": mountKptfile},
+			engine:      Tekton,
+			expectedErr: `function "gcr.io/kpt-fn/fetch:v0.1" uses mounts, which are not supported in workflows`,
+		},
+		"env is not supported": {
+			files:       map[string]string{"Kptfile": envKptfile},
+			engine:      Tekton,
+			expectedErr: `function "gcr.io/kpt-fn/fetch:v0.1" uses env, which is not supported in workflows`,
+		},
+		"composite is not supported": {
+			files:       map[string]string{"Kptfile": compositeKptfile},
+			engine:      Tekton,
+			expectedErr: `composite function "gcr.io/my-org/org-standard:v1" is not supported in workflows`,
+		},
+		"package without functions": {
+			files:       map[string]string{"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: my-pkg\n"},
+			engine:      Argo,
+			expectedErr: "package doesn't declare any functions",
+		},
+		"unknown engine": {
+			files:       map[string]string{"Kptfile": rootKptfile},
+			engine:      "jenkins",
+			expectedErr: `unsupported workflow engine "jenkins"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			fs := filesys.MakeFsInMemory()
+			pkgPath := "/my-pkg"
+			for p, content := range tc.files {
+				require.NoError(t, fs.MkdirAll(filepath.Join(pkgPath, filepath.Dir(p))))
+				require.NoError(t, fs.WriteFile(filepath.Join(pkgPath, p), []byte(content)))
+			}
+			var out bytes.Buffer
+			err := (&WorkflowEmitter{
+				PkgPath:    pkgPath,
+				Engine:     tc.engine,
+				KptImage:   "kpt:test",
+				FileSystem: fs,
+			}).Emit(&out)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stats records how long kpt commands and their steps take into a
+// local file, so that users can find their slowest functions and repos.
+// Nothing is recorded unless the user opts in, and nothing leaves the
+// machine.
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvVar is the environment variable that enables the recording of
+	// stats when set to true or 1.
+	EnvVar = "KPT_STATS"
+
+	// FileEnvVar is the environment variable that sets the path of the
+	// stats file. Defaults to UserHomeDir/.kpt/stats.jsonl.
+	FileEnvVar = "KPT_STATS_FILE"
+)
+
+// Kind is the kind of step a record is for.
+type Kind string
+
+const (
+	// Command is the run of a whole kpt command.
+	Command Kind = "command"
+	// Fetch is the fetch of a git repo, by repo.
+	Fetch Kind = "fetch"
+	// Function is the run of a function, by image or executable.
+	Function Kind = "function"
+	// ApplyPhase is an apply, prune, delete or wait phase of
+	// `kpt live apply`, by action.
+	ApplyPhase Kind = "apply-phase"
+)
+
+// Kinds are the kinds of records, in the order they are summarized.
+var Kinds = []Kind{Command, Fetch, Function, ApplyPhase}
+
+// Record is the duration of a step of a kpt command.
+type Record struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	Kind    Kind      `json:"kind"`
+	Name    string    `json:"name"`
+	Seconds float64   `json:"seconds"`
+	Failed  bool      `json:"failed,omitempty"`
+}
+
+// IsEnabled returns true if the user opted in to the recording of stats.
+func IsEnabled() bool {
+	e := strings.ToLower(os.Getenv(EnvVar))
+	return e == "true" || e == "1"
+}
+
+// Path returns the path of the stats file.
+func Path() (string, error) {
+	if p := os.Getenv(FileEnvVar); p != "" {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kpt", "stats.jsonl"), nil
+}
+
+var (
+	mu      sync.Mutex
+	pending []Record
+)
+
+// Add records that the step name of kind took d, if stats are enabled.
+// The records are written to the stats file by Flush when the command
+// completes.
+func Add(kind Kind, name string, d time.Duration, err error) {
+	if !IsEnabled() {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	pending = append(pending, Record{
+		Kind:    kind,
+		Name:    name,
+		Seconds: d.Seconds(),
+		Failed:  err != nil,
+	})
+}
+
+// Flush records that command took d, and appends it and the records added
+// while it ran to the stats file. It does nothing if stats are not
+// enabled.
+func Flush(command string, d time.Duration, err error) error {
+	if !IsEnabled() {
+		return nil
+	}
+	Add(Command, command, d, err)
+	mu.Lock()
+	records := pending
+	pending = nil
+	mu.Unlock()
+
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	now := time.Now().UTC()
+	enc := json.NewEncoder(f)
+	for _, r := range records {
+		r.Time = now
+		r.Command = command
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read returns the records in the stats file at path that were recorded at
+// or after since. A missing file has no records.
+func Read(path string, since time.Time) ([]Record, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var records []Record
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		if len(strings.TrimSpace(s.Text())) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(s.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("invalid stats file %q: line %d: %w", path, line, err)
+		}
+		if !r.Time.Before(since) {
+			records = append(records, r)
+		}
+	}
+	return records, s.Err()
+}
+
+// Summary aggregates the records of a step.
+type Summary struct {
+	Kind   Kind
+	Name   string
+	Count  int
+	Failed int
+	Total  time.Duration
+	Max    time.Duration
+}
+
+// Average returns the average duration of the step.
+func (s Summary) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// Summarize aggregates records by kind and name. The summaries of each
+// kind are sorted by total duration, longest first, and the kinds are in
+// the order of Kinds.
+func Summarize(records []Record) []Summary {
+	type key struct {
+		kind Kind
+		name string
+	}
+	byKey := map[key]*Summary{}
+	for _, r := range records {
+		k := key{r.Kind, r.Name}
+		s, found := byKey[k]
+		if !found {
+			s = &Summary{Kind: r.Kind, Name: r.Name}
+			byKey[k] = s
+		}
+		d := time.Duration(r.Seconds * float64(time.Second))
+		s.Count++
+		s.Total += d
+		if d > s.Max {
+			s.Max = d
+		}
+		if r.Failed {
+			s.Failed++
+		}
+	}
+	order := map[Kind]int{}
+	for i, k := range Kinds {
+		order[k] = i
+	}
+	var summaries []Summary
+	for _, s := range byKey {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Name < b.Name
+	})
+	return summaries
+}
+
+// ParseSince parses a duration that is either a Go duration or a number of
+// days with the "d" suffix, e.g. "7d".
+func ParseSince(s string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(s, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q: must be a non-negative number of days or duration", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be a non-negative number of days or duration", s)
+	}
+	return d, nil
+}
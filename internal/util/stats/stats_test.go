@@ -0,0 +1,92 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kpt", "stats.jsonl")
+	t.Setenv(FileEnvVar, path)
+
+	// nothing is recorded unless enabled.
+	t.Setenv(EnvVar, "")
+	Add(Function, "gcr.io/kpt-fn/set-labels:v0.1", time.Second, nil)
+	require.NoError(t, Flush("kpt fn render", 2*time.Second, nil))
+	records, err := Read(path, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	t.Setenv(EnvVar, "true")
+	Add(Function, "gcr.io/kpt-fn/set-labels:v0.1", time.Second, nil)
+	Add(Function, "gcr.io/kpt-fn/kubeval:v0.3", 3*time.Second, fmt.Errorf("failed"))
+	require.NoError(t, Flush("kpt fn render", 5*time.Second, fmt.Errorf("failed")))
+	Add(Fetch, "https://github.com/example/repo", 2*time.Second, nil)
+	require.NoError(t, Flush("kpt pkg get", 3*time.Second, nil))
+
+	records, err = Read(path, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	for i, r := range records {
+		assert.False(t, r.Time.IsZero())
+		records[i].Time = time.Time{}
+	}
+	assert.Equal(t, []Record{
+		{Command: "kpt fn render", Kind: Function, Name: "gcr.io/kpt-fn/set-labels:v0.1", Seconds: 1},
+		{Command: "kpt fn render", Kind: Function, Name: "gcr.io/kpt-fn/kubeval:v0.3", Seconds: 3, Failed: true},
+		{Command: "kpt fn render", Kind: Command, Name: "kpt fn render", Seconds: 5, Failed: true},
+		{Command: "kpt pkg get", Kind: Fetch, Name: "https://github.com/example/repo", Seconds: 2},
+		{Command: "kpt pkg get", Kind: Command, Name: "kpt pkg get", Seconds: 3},
+	}, records)
+
+	records, err = Read(path, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestSummarize(t *testing.T) {
+	records := []Record{
+		{Kind: Function, Name: "a", Seconds: 1},
+		{Kind: Command, Name: "kpt fn render", Seconds: 10},
+		{Kind: Function, Name: "b", Seconds: 2, Failed: true},
+		{Kind: Function, Name: "a", Seconds: 2},
+		{Kind: Fetch, Name: "repo", Seconds: 4},
+	}
+	assert.Equal(t, []Summary{
+		{Kind: Command, Name: "kpt fn render", Count: 1, Total: 10 * time.Second, Max: 10 * time.Second},
+		{Kind: Fetch, Name: "repo", Count: 1, Total: 4 * time.Second, Max: 4 * time.Second},
+		{Kind: Function, Name: "a", Count: 2, Total: 3 * time.Second, Max: 2 * time.Second},
+		{Kind: Function, Name: "b", Count: 1, Failed: 1, Total: 2 * time.Second, Max: 2 * time.Second},
+	}, Summarize(records))
+	assert.Equal(t, 1500*time.Millisecond, Summarize(records)[2].Average())
+}
+
+func TestParseSince(t *testing.T) {
+	d, err := ParseSince("7d")
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, d)
+	d, err = ParseSince("90m")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+	_, err = ParseSince("xd")
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// telemetry configures the export of traces and metrics from kpt commands.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	// OTELEnv configures where traces are exported to. Like for the Porch
+	// server, it is a comma-separated list of exporters:
+	// "otel://HOST:PORT" exports using OTLP over gRPC and "stdout://"
+	// prints the spans to stderr.
+	OTELEnv = "OTEL"
+
+	// PushgatewayEnv is the URL of a Prometheus Pushgateway that metrics
+	// are pushed to when a command completes.
+	PushgatewayEnv = "KPT_PROMETHEUS_PUSHGATEWAY"
+
+	serviceName = "kpt"
+)
+
+// SetupTracing installs a global tracer provider that exports spans as
+// configured by the OTELEnv environment variable. The returned function
+// flushes and shuts down the exporters. Tracing is a no-op if the
+// variable is not set.
+func SetupTracing(ctx context.Context) (func(context.Context) error, error) {
+	config := os.Getenv(OTELEnv)
+	if config == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []sdktrace.TracerProviderOption
+	for _, s := range strings.Split(config, ",") {
+		u, err := url.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w", OTELEnv, s, err)
+		}
+		var exporter sdktrace.SpanExporter
+		switch u.Scheme {
+		case "otel":
+			exporter, err = otlptracegrpc.New(ctx,
+				otlptracegrpc.WithInsecure(),
+				otlptracegrpc.WithEndpoint(u.Host),
+			)
+		case "stdout":
+			exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+		default:
+			return nil, fmt.Errorf("invalid %s value %q: unsupported scheme %q", OTELEnv, s, u.Scheme)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	opts = append(opts, sdktrace.WithResource(resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+	)))
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// PushMetrics pushes the metrics gathered by g to the Pushgateway
+// configured by the PushgatewayEnv environment variable, grouped under
+// the given job. It does nothing if the variable is not set.
+func PushMetrics(ctx context.Context, job string, g prometheus.Gatherer) error {
+	gateway := os.Getenv(PushgatewayEnv)
+	if gateway == "" {
+		return nil
+	}
+	if err := push.New(gateway, job).Gatherer(g).PushContext(ctx); err != nil {
+		return fmt.Errorf("failed to push metrics to %s: %w", gateway, err)
+	}
+	return nil
+}
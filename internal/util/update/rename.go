@@ -0,0 +1,98 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+)
+
+// DirectoryMovedError is the error type returned if the upstream directory
+// of the package was moved in the version the package is updated to, and
+// the update doesn't follow the move.
+type DirectoryMovedError struct {
+	Repo      string
+	Directory string
+	MovedTo   string
+}
+
+func (e *DirectoryMovedError) Error() string {
+	return fmt.Sprintf("directory %q was moved to %q in repo %q; use --follow-renames "+
+		"to update the package from the new directory", e.Directory, e.MovedTo, e.Repo)
+}
+
+// findMovedDirectory returns the directory that dir was moved to between
+// the commits from and to of the upstream repo, based on the renames that
+// git detects between them. The files of a directory are usually moved
+// together, so the directory that most of its renamed files end up in is
+// returned. If the files of dir were not renamed, the empty string is
+// returned.
+func findMovedDirectory(ctx context.Context, upstream *gitutil.GitUpstreamRepo, dir, from, to string) (string, error) {
+	dir = strings.TrimPrefix(path.Clean("/"+dir), "/")
+	if dir == "" {
+		return "", nil
+	}
+	repoDir, err := upstream.GetRepo(ctx, []string{from, to})
+	if err != nil {
+		return "", err
+	}
+	git, err := gitutil.NewLocalGitRunner(repoDir)
+	if err != nil {
+		return "", err
+	}
+	// the diff isn't limited to dir, since git only detects the renames
+	// between the paths it compares.
+	rr, err := git.Run(ctx, "diff", "--find-renames", "--name-status", "-z", from, to)
+	if err != nil {
+		return "", err
+	}
+
+	// With -z, every entry is the status followed by the paths, all
+	// separated by NUL. Renames have a status like R100 and two paths.
+	candidates := map[string]int{}
+	fields := strings.Split(rr.Stdout, "\x00")
+	for i := 0; i < len(fields); i++ {
+		status := fields[i]
+		if !strings.HasPrefix(status, "R") {
+			// other entries have a single path.
+			i++
+			continue
+		}
+		if i+2 >= len(fields) {
+			break
+		}
+		oldPath, newPath := fields[i+1], fields[i+2]
+		i += 2
+		rel := strings.TrimPrefix(oldPath, dir+"/")
+		if rel == oldPath || !strings.HasSuffix(newPath, "/"+rel) {
+			// the file was moved within the directory, or somewhere
+			// else than a directory that replaces dir.
+			continue
+		}
+		candidates[strings.TrimSuffix(newPath, "/"+rel)]++
+	}
+
+	var movedTo string
+	for candidate, count := range candidates {
+		if count > candidates[movedTo] || (count == candidates[movedTo] && candidate < movedTo) {
+			movedTo = candidate
+		}
+	}
+	return movedTo, nil
+}
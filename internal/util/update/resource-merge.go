@@ -25,11 +25,13 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/pkg"
 	"github.com/GoogleContainerTools/kpt/internal/types"
 	pkgdiff "github.com/GoogleContainerTools/kpt/internal/util/diff"
+	"github.com/GoogleContainerTools/kpt/internal/util/ignore"
 	"github.com/GoogleContainerTools/kpt/internal/util/merge"
 	"github.com/GoogleContainerTools/kpt/internal/util/pkgutil"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
 	"sigs.k8s.io/kustomize/kyaml/copyutil"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/sets"
 )
@@ -65,7 +67,12 @@ func (u ResourceMergeUpdater) Update(options Options) error {
 
 	// Update each package and subpackage. Parent package is updated before
 	// subpackages to make sure auto-setters can work correctly.
+	var skipped []string
 	for _, subPkgPath := range append([]string{"."}, subPkgPaths...) {
+		if subPkgPath != "." && isSkipped(subPkgPath, skipped, options.Skipped) {
+			skipped = append(skipped, subPkgPath)
+			continue
+		}
 		isRootPkg := false
 		if subPkgPath == "." && options.IsRoot {
 			isRootPkg = true
@@ -74,7 +81,7 @@ func (u ResourceMergeUpdater) Update(options Options) error {
 		updatedSubPkgPath := filepath.Join(options.UpdatedPath, subPkgPath)
 		originalSubPkgPath := filepath.Join(options.OriginPath, subPkgPath)
 
-		err := u.updatePackage(subPkgPath, localSubPkgPath, updatedSubPkgPath, originalSubPkgPath, isRootPkg)
+		err := u.updatePackage(subPkgPath, localSubPkgPath, updatedSubPkgPath, originalSubPkgPath, isRootPkg, options.MergeDrivers)
 		if err != nil {
 			return errors.E(op, types.UniquePath(localSubPkgPath), err)
 		}
@@ -82,10 +89,21 @@ func (u ResourceMergeUpdater) Update(options Options) error {
 	return nil
 }
 
+// isSkipped returns true if the subpackage at subPkgPath is skipped, or is
+// nested in one of the skipped subpackages.
+func isSkipped(subPkgPath string, skipped []string, skip func(string) bool) bool {
+	for _, s := range skipped {
+		if strings.HasPrefix(subPkgPath, s+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return skip != nil && skip(subPkgPath)
+}
+
 // updatePackage updates the package in the location specified by localPath
 // using the provided paths to the updated version of the package and the
 // original version of the package.
-func (u ResourceMergeUpdater) updatePackage(subPkgPath, localPath, updatedPath, originalPath string, isRootPkg bool) error {
+func (u ResourceMergeUpdater) updatePackage(subPkgPath, localPath, updatedPath, originalPath string, isRootPkg bool, drivers merge.Drivers) error {
 	const op errors.Op = "update.updatePackage"
 	localExists, err := pkgutil.Exists(localPath)
 	if err != nil {
@@ -135,7 +153,7 @@ func (u ResourceMergeUpdater) updatePackage(subPkgPath, localPath, updatedPath,
 			}
 		}
 	default:
-		if err := u.mergePackage(localPath, updatedPath, originalPath, subPkgPath, isRootPkg); err != nil {
+		if err := u.mergePackage(localPath, updatedPath, originalPath, subPkgPath, isRootPkg, drivers); err != nil {
 			return errors.E(op, types.UniquePath(localPath), err)
 		}
 	}
@@ -143,54 +161,104 @@ func (u ResourceMergeUpdater) updatePackage(subPkgPath, localPath, updatedPath,
 }
 
 // mergePackage merge a package. It does a 3-way merge by using the provided
-// paths to the local, updated and original versions of the package.
-func (u ResourceMergeUpdater) mergePackage(localPath, updatedPath, originalPath, _ string, isRootPkg bool) error {
+// paths to the local, updated and original versions of the package. The
+// merge drivers select how individual files are merged.
+func (u ResourceMergeUpdater) mergePackage(localPath, updatedPath, originalPath, _ string, isRootPkg bool, drivers merge.Drivers) error {
 	const op errors.Op = "update.mergePackage"
 	if err := kptfileutil.UpdateKptfile(localPath, updatedPath, originalPath, !isRootPkg); err != nil {
 		return errors.E(op, types.UniquePath(localPath), err)
 	}
 
+	// the paths in the .kptignore file of the local package are left
+	// untouched.
+	ignored, err := ignore.Load(filesys.MakeFsOnDisk(), localPath)
+	if err != nil {
+		return errors.E(op, types.UniquePath(localPath), err)
+	}
+
 	// merge the Resources: original + updated + dest => dest
-	err := merge.Merge3{
+	err = merge.Merge3{
 		OriginalPath: originalPath,
 		UpdatedPath:  updatedPath,
 		DestPath:     localPath,
 		// TODO: Write a test to ensure this is set
 		MergeOnPath:        true,
 		IncludeSubPackages: false,
+		Drivers:            drivers,
+		Ignore:             ignored,
 	}.Merge()
 	if err != nil {
 		return errors.E(op, types.UniquePath(localPath), err)
 	}
 
-	if err := ReplaceNonKRMFiles(updatedPath, originalPath, localPath); err != nil {
+	if err := replaceNonKRMFiles(updatedPath, originalPath, localPath, drivers, ignored); err != nil {
 		return errors.E(op, types.UniquePath(localPath), err)
 	}
 	return nil
 }
 
-// replaceNonKRMFiles replaces the non KRM files in localDir with the corresponding files in updatedDir,
+// ReplaceNonKRMFiles replaces the non KRM files in localDir with the corresponding files in updatedDir,
 // it also deletes non KRM files and sub dirs which are present in localDir and not in updatedDir
 func ReplaceNonKRMFiles(updatedDir, originalDir, localDir string) error {
+	return replaceNonKRMFiles(updatedDir, originalDir, localDir, nil, nil)
+}
+
+// replaceNonKRMFiles is like ReplaceNonKRMFiles, but the local version of files
+// using the ours merge driver is always kept, and files using the theirs merge
+// driver are replaced even if modified locally. Ignored files and directories
+// are left untouched.
+func replaceNonKRMFiles(updatedDir, originalDir, localDir string, drivers merge.Drivers, ignored *ignore.Matcher) error {
 	const op errors.Op = "update.ReplaceNonKRMFiles"
-	updatedSubDirs, updatedFiles, err := getSubDirsAndNonKrmFiles(updatedDir)
+	updatedSubDirs, updatedFiles, err := getSubDirsAndNonKrmFiles(updatedDir, ignored)
 	if err != nil {
 		return errors.E(op, types.UniquePath(localDir), err)
 	}
 
-	originalSubDirs, originalFiles, err := getSubDirsAndNonKrmFiles(originalDir)
+	originalSubDirs, originalFiles, err := getSubDirsAndNonKrmFiles(originalDir, ignored)
 	if err != nil {
 		return errors.E(op, types.UniquePath(localDir), err)
 	}
 
-	localSubDirs, localFiles, err := getSubDirsAndNonKrmFiles(localDir)
+	localSubDirs, localFiles, err := getSubDirsAndNonKrmFiles(localDir, ignored)
 	if err != nil {
 		return errors.E(op, types.UniquePath(localDir), err)
 	}
 
+	// identify the files using the ours and theirs merge drivers
+	oursFiles := sets.String{}
+	theirsFiles := sets.String{}
+	for _, file := range append(localFiles.List(), updatedFiles.List()...) {
+		driver, err := drivers.Find(file)
+		if err != nil {
+			return errors.E(op, types.UniquePath(localDir), err)
+		}
+		switch {
+		case driver == nil:
+		case driver.Type == kptfilev1.OursMergeDriver:
+			oursFiles.Insert(file)
+		case driver.Type == kptfilev1.TheirsMergeDriver:
+			theirsFiles.Insert(file)
+		}
+	}
+
 	// identify all non KRM files modified locally, to leave them untouched
 	locallyModifiedFiles := sets.String{}
 	for _, file := range localFiles.List() {
+		if oursFiles.Has(file) {
+			// the local version is always kept
+			locallyModifiedFiles.Insert(file)
+			continue
+		}
+		if theirsFiles.Has(file) {
+			// the updated version replaces the local one, so the file is
+			// only removed if it is deleted from updated upstream
+			if !updatedFiles.Has(file) && originalFiles.Has(file) {
+				if err = os.Remove(filepath.Join(localDir, file)); err != nil {
+					return errors.E(op, types.UniquePath(localDir), err)
+				}
+			}
+			continue
+		}
 		if !originalFiles.Has(file) {
 			// new local file has been added
 			locallyModifiedFiles.Insert(file)
@@ -223,7 +291,7 @@ func ReplaceNonKRMFiles(updatedDir, originalDir, localDir string) error {
 
 	// replace all non KRM files in local with the ones in updated
 	for _, file := range updatedFiles.List() {
-		if locallyModifiedFiles.Has(file) {
+		if locallyModifiedFiles.Has(file) || oursFiles.Has(file) {
 			// skip syncing locally modified files
 			continue
 		}
@@ -245,8 +313,8 @@ func ReplaceNonKRMFiles(updatedDir, originalDir, localDir string) error {
 }
 
 // getSubDirsAndNonKrmFiles returns the list of all non git sub dirs and, non git+non KRM files
-// in the root directory
-func getSubDirsAndNonKrmFiles(root string) (sets.String, sets.String, error) {
+// in the root directory, without the ignored ones
+func getSubDirsAndNonKrmFiles(root string, ignored *ignore.Matcher) (sets.String, sets.String, error) {
 	const op errors.Op = "update.getSubDirsAndNonKrmFiles"
 	files := sets.String{}
 	dirs := sets.String{}
@@ -255,6 +323,12 @@ func getSubDirsAndNonKrmFiles(root string) (sets.String, sets.String, error) {
 			return errors.E(op, errors.IO, err)
 		}
 
+		if ignored.Match(strings.TrimPrefix(path, root), info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if info.IsDir() {
 			path = strings.TrimPrefix(path, root)
 			if len(path) > 0 {
@@ -21,7 +21,9 @@ import (
 
 	"github.com/GoogleContainerTools/kpt/internal/testutil"
 	"github.com/GoogleContainerTools/kpt/internal/testutil/pkgbuilder"
+	"github.com/GoogleContainerTools/kpt/internal/util/merge"
 	. "github.com/GoogleContainerTools/kpt/internal/util/update"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -300,3 +302,97 @@ func TestUpdate_ResourceMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdate_ResourceMerge_mergeDrivers(t *testing.T) {
+	values := func(color string) string {
+		return `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: values
+data:
+  color: ` + color + `
+`
+	}
+	newPkg := func(color, notes, readme, replicas string) *pkgbuilder.RootPkg {
+		return pkgbuilder.NewRootPkg().
+			WithKptfile(
+				pkgbuilder.NewKptfile().
+					WithUpstream(kptRepo, "/", "master", "resource-merge").
+					WithUpstreamLock(kptRepo, "/", "master", "abc123"),
+			).
+			WithResource(pkgbuilder.DeploymentResource, pkgbuilder.SetFieldPath(replicas, "spec", "replicas")).
+			WithRawResource("values.yaml", values(color)).
+			WithFile("notes.txt", notes).
+			WithFile("README.md", readme)
+	}
+
+	origin := newPkg("blue", "original", "original", "1").ExpandPkg(t, testutil.EmptyReposInfo)
+	updated := newPkg("green", "updated", "updated", "2").ExpandPkg(t, testutil.EmptyReposInfo)
+	local := newPkg("red", "local", "local", "1").ExpandPkg(t, testutil.EmptyReposInfo)
+	expected := newPkg("red", "updated", "local", "2").ExpandPkg(t, testutil.EmptyReposInfo)
+
+	err := (&ResourceMergeUpdater{}).Update(Options{
+		RelPackagePath: "/",
+		OriginPath:     origin,
+		LocalPath:      local,
+		UpdatedPath:    updated,
+		IsRoot:         true,
+		MergeDrivers: merge.Drivers{
+			{Files: "values.yaml", Type: kptfilev1.OursMergeDriver},
+			{Files: "*.txt", Type: kptfilev1.TheirsMergeDriver},
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	testutil.KptfileAwarePkgEqual(t, local, expected, false)
+}
+
+func TestUpdate_ResourceMerge_kptignore(t *testing.T) {
+	ci := func(version string) string {
+		return `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ci
+data:
+  version: ` + version + `
+`
+	}
+	newPkg := func(version, readme, replicas string) *pkgbuilder.RootPkg {
+		return pkgbuilder.NewRootPkg().
+			WithKptfile(
+				pkgbuilder.NewKptfile().
+					WithUpstream(kptRepo, "/", "master", "resource-merge").
+					WithUpstreamLock(kptRepo, "/", "master", "abc123"),
+			).
+			WithResource(pkgbuilder.DeploymentResource, pkgbuilder.SetFieldPath(replicas, "spec", "replicas")).
+			WithRawResource("ci.yaml", ci(version)).
+			WithFile("README.md", readme)
+	}
+
+	origin := newPkg("v1", "original", "1").ExpandPkg(t, testutil.EmptyReposInfo)
+	updated := newPkg("v2", "updated", "2").
+		WithFile("CONTRIBUTING.md", "updated").
+		ExpandPkg(t, testutil.EmptyReposInfo)
+	local := newPkg("v1", "original", "1").
+		WithFile(".kptignore", "# not part of the configuration\n*.md\nci.yaml\n").
+		ExpandPkg(t, testutil.EmptyReposInfo)
+	// only the deployment is updated, the ignored files are left as is.
+	expected := newPkg("v1", "original", "2").
+		WithFile(".kptignore", "# not part of the configuration\n*.md\nci.yaml\n").
+		ExpandPkg(t, testutil.EmptyReposInfo)
+
+	err := (&ResourceMergeUpdater{}).Update(Options{
+		RelPackagePath: "/",
+		OriginPath:     origin,
+		LocalPath:      local,
+		UpdatedPath:    updated,
+		IsRoot:         true,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	testutil.KptfileAwarePkgEqual(t, local, expected, false)
+}
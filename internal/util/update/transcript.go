@@ -0,0 +1,170 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	TranscriptAPIVersion = "kpt.dev/v1alpha1"
+	TranscriptKind       = "UpdateTranscript"
+)
+
+// Action is what an update did to a package.
+type Action string
+
+const (
+	// Merged means the package was merged with its upstream.
+	Merged Action = "merged"
+	// Added means the package was added from upstream.
+	Added Action = "added"
+	// Deleted means the package was deleted since it was removed in
+	// upstream.
+	Deleted Action = "deleted"
+	// KeptLocal means the package was removed in upstream, but kept since
+	// it has local changes.
+	KeptLocal Action = "kept-local"
+	// Ignored means the package was deleted locally, so it wasn't added
+	// again from upstream.
+	Ignored Action = "ignored"
+	// Skipped means the package was not updated since it was skipped.
+	Skipped Action = "skipped"
+)
+
+// Transcript records the decisions of an update, so that the same update
+// can be replayed on other packages.
+type Transcript struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Metadata   TranscriptMetadata `json:"metadata"`
+	Spec       TranscriptSpec     `json:"spec"`
+}
+
+type TranscriptMetadata struct {
+	Name string `json:"name"`
+}
+
+type TranscriptSpec struct {
+	// Ref is the upstream version the package was updated to.
+	Ref string `json:"ref,omitempty"`
+	// Strategy is the update strategy given for the update.
+	Strategy kptfilev1.UpdateStrategyType `json:"strategy,omitempty"`
+	// Packages are the decisions for the package and its subpackages.
+	Packages []PackageDecision `json:"packages,omitempty"`
+}
+
+type PackageDecision struct {
+	// Path is the path of the package relative to the updated package.
+	// The updated package itself is ".".
+	Path   string `json:"path"`
+	Action Action `json:"action"`
+	// Strategy is the update strategy a merged package was merged with.
+	Strategy kptfilev1.UpdateStrategyType `json:"strategy,omitempty"`
+}
+
+// ReadTranscript reads the transcript at path.
+func ReadTranscript(path string) (*Transcript, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read transcript: %w", err)
+	}
+	t := &Transcript{}
+	if err := yaml.UnmarshalStrict(b, t); err != nil {
+		return nil, fmt.Errorf("invalid transcript %q: %w", path, err)
+	}
+	if t.APIVersion != TranscriptAPIVersion || t.Kind != TranscriptKind {
+		return nil, fmt.Errorf("invalid transcript %q: expected %s %s, got %s %s",
+			path, TranscriptAPIVersion, TranscriptKind, t.APIVersion, t.Kind)
+	}
+	for _, p := range t.Spec.Packages {
+		if p.Action == Skipped && p.Path == "." {
+			return nil, fmt.Errorf("invalid transcript %q: the updated package can't be skipped", path)
+		}
+		if p.Strategy != "" {
+			if _, found := strategies[p.Strategy]; !found {
+				return nil, fmt.Errorf("invalid transcript %q: unrecognized update strategy %q for package %q",
+					path, p.Strategy, p.Path)
+			}
+		}
+	}
+	return t, nil
+}
+
+// Write writes the transcript to path.
+func (t *Transcript) Write(path string) error {
+	b, err := yaml.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// Mismatches returns the differences between the decisions of the
+// transcript and the decisions of replayed, the transcript of the update
+// that replayed it.
+func (t *Transcript) Mismatches(replayed *Transcript) []string {
+	actions := map[string]Action{}
+	for _, p := range replayed.Spec.Packages {
+		actions[p.Path] = p.Action
+	}
+	var mismatches []string
+	for _, p := range t.Spec.Packages {
+		action, found := actions[p.Path]
+		delete(actions, p.Path)
+		switch {
+		case !found:
+			mismatches = append(mismatches, fmt.Sprintf("package %q was %s in the transcript, but not found now", p.Path, p.Action))
+		case action != p.Action:
+			mismatches = append(mismatches, fmt.Sprintf("package %q was %s in the transcript, but %s now", p.Path, p.Action, action))
+		}
+	}
+	for _, p := range replayed.Spec.Packages {
+		if action, found := actions[p.Path]; found {
+			mismatches = append(mismatches, fmt.Sprintf("package %q was %s, but is not in the transcript", p.Path, action))
+		}
+	}
+	return mismatches
+}
+
+// decisions records the decisions of an update by package path.
+type decisions map[string]PackageDecision
+
+// record records the action for the package at pkgPath. A remote
+// subpackage is merged both as a subpackage of its parent and with its
+// own upstream, so an earlier action other than a merge is kept.
+func (d decisions) record(pkgPath string, action Action, strategy kptfilev1.UpdateStrategyType) {
+	pkgPath = path.Clean(pkgPath)
+	if prev, found := d[pkgPath]; found && prev.Action != Merged {
+		return
+	}
+	d[pkgPath] = PackageDecision{Path: pkgPath, Action: action, Strategy: strategy}
+}
+
+// list returns the decisions sorted by path.
+func (d decisions) list() []PackageDecision {
+	var list []PackageDecision
+	for _, p := range d {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	return list
+}
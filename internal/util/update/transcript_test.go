@@ -0,0 +1,135 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTranscript(t *testing.T) {
+	testCases := map[string]struct {
+		content        string
+		expectedErrMsg string
+	}{
+		"valid": {
+			content: `apiVersion: kpt.dev/v1alpha1
+kind: UpdateTranscript
+metadata:
+  name: wordpress
+spec:
+  packages:
+  - action: merged
+    path: .
+    strategy: resource-merge
+  - action: skipped
+    path: mysql
+  ref: v2
+  strategy: resource-merge
+`,
+		},
+		"wrong kind": {
+			content: `apiVersion: kpt.dev/v1alpha1
+kind: PackageManifest
+`,
+			expectedErrMsg: "expected kpt.dev/v1alpha1 UpdateTranscript, got kpt.dev/v1alpha1 PackageManifest",
+		},
+		"unknown field": {
+			content: `apiVersion: kpt.dev/v1alpha1
+kind: UpdateTranscript
+spec:
+  version: v2
+`,
+			expectedErrMsg: `unknown field "version"`,
+		},
+		"skipped root package": {
+			content: `apiVersion: kpt.dev/v1alpha1
+kind: UpdateTranscript
+spec:
+  packages:
+  - path: .
+    action: skipped
+`,
+			expectedErrMsg: "the updated package can't be skipped",
+		},
+		"unknown strategy": {
+			content: `apiVersion: kpt.dev/v1alpha1
+kind: UpdateTranscript
+spec:
+  packages:
+  - path: mysql
+    action: merged
+    strategy: rebase
+`,
+			expectedErrMsg: `unrecognized update strategy "rebase" for package "mysql"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "transcript.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0600))
+			transcript, err := ReadTranscript(path)
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "v2", transcript.Spec.Ref)
+			assert.Len(t, transcript.Spec.Packages, 2)
+
+			// the transcript is written back the same way.
+			require.NoError(t, transcript.Write(path))
+			b, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.content, string(b))
+		})
+	}
+}
+
+func TestTranscript_Mismatches(t *testing.T) {
+	recorded := &Transcript{Spec: TranscriptSpec{Packages: []PackageDecision{
+		{Path: ".", Action: Merged, Strategy: kptfilev1.ResourceMerge},
+		{Path: "bar", Action: Deleted},
+		{Path: "mysql", Action: Skipped},
+	}}}
+	replayed := &Transcript{Spec: TranscriptSpec{Packages: []PackageDecision{
+		{Path: ".", Action: Merged, Strategy: kptfilev1.ResourceMerge},
+		{Path: "bar", Action: KeptLocal},
+		{Path: "redis", Action: Added},
+	}}}
+	assert.Equal(t, []string{
+		`package "bar" was deleted in the transcript, but kept-local now`,
+		`package "mysql" was skipped in the transcript, but not found now`,
+		`package "redis" was added, but is not in the transcript`,
+	}, recorded.Mismatches(replayed))
+}
+
+func TestDecisions_record(t *testing.T) {
+	d := decisions{}
+	d.record("bar", Merged, "")
+	d.record("bar", Merged, kptfilev1.FastForward)
+	d.record("abc/", Added, "")
+	d.record("abc", Merged, kptfilev1.ResourceMerge)
+	assert.Equal(t, []PackageDecision{
+		{Path: "abc", Action: Added},
+		{Path: "bar", Action: Merged, Strategy: kptfilev1.FastForward},
+	}, d.list())
+}
@@ -24,19 +24,26 @@ import (
 	"strings"
 
 	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/fnruntime"
 	"github.com/GoogleContainerTools/kpt/internal/gitutil"
+	"github.com/GoogleContainerTools/kpt/internal/hook"
 	"github.com/GoogleContainerTools/kpt/internal/pkg"
 	"github.com/GoogleContainerTools/kpt/internal/types"
 	"github.com/GoogleContainerTools/kpt/internal/util/addmergecomment"
+	"github.com/GoogleContainerTools/kpt/internal/util/digest"
 	"github.com/GoogleContainerTools/kpt/internal/util/fetch"
 	"github.com/GoogleContainerTools/kpt/internal/util/git"
+	"github.com/GoogleContainerTools/kpt/internal/util/merge"
 	"github.com/GoogleContainerTools/kpt/internal/util/pkgutil"
 	"github.com/GoogleContainerTools/kpt/internal/util/stack"
+	"github.com/GoogleContainerTools/kpt/internal/util/vendor"
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
 	"sigs.k8s.io/kustomize/kyaml/copyutil"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/sets"
 )
 
 // PkgNotGitRepoError is the error type returned if the package being updated is not inside
@@ -79,6 +86,15 @@ type Options struct {
 	// updated and origin were fetched based on the information in the
 	// Kptfile from this package.
 	IsRoot bool
+
+	// MergeDrivers select how files are merged by the resource-merge
+	// strategy. They are declared in the Kptfile of the local package.
+	MergeDrivers merge.Drivers
+
+	// Skipped returns true if the local subpackage at subPkgPath, relative
+	// to the package, must be left as it is. It is only used by the
+	// resource-merge strategy, and may be nil.
+	Skipped func(subPkgPath string) bool
 }
 
 // Updater updates a local package
@@ -103,6 +119,32 @@ type Command struct {
 	// Strategy is the update strategy to use
 	Strategy kptfilev1.UpdateStrategyType
 
+	// FromVendor makes the update use the upstreams vendored into the
+	// package with 'kpt pkg vendor' instead of fetching them from git.
+	FromVendor bool
+
+	// Skip are the paths of the subpackages, relative to the package, that
+	// are not updated. The nested packages of a skipped subpackage are not
+	// updated either.
+	Skip []string
+
+	// Strategies are the update strategies of packages by their path
+	// relative to the package. They take precedence over the strategies
+	// in the Kptfiles of the packages.
+	Strategies map[string]kptfilev1.UpdateStrategyType
+
+	// FollowRenames makes the update follow the upstream directory of a
+	// package if it was moved in the version the package is updated to,
+	// and change the upstream directory in the Kptfile to the new one.
+	FollowRenames bool
+
+	// vendorStore is the vendor store of the package, if updating from it.
+	vendorStore *vendor.Store
+
+	// transcript and decisions record the decisions of the update.
+	transcript *Transcript
+	decisions  decisions
+
 	// cachedUpstreamRepos is an upstream repo already fetched for a given repoSpec CloneRef
 	cachedUpstreamRepos map[string]*gitutil.GitUpstreamRepo
 }
@@ -132,6 +174,22 @@ func (u *Command) Run(ctx context.Context) error {
 	if u.Strategy != "" {
 		rootKf.Upstream.UpdateStrategy = u.Strategy
 	}
+	for _, p := range u.Skip {
+		if p = path.Clean(filepath.ToSlash(p)); p == "." || path.IsAbs(p) || strings.HasPrefix(p, "../") {
+			return errors.E(op, u.Pkg.UniquePath, errors.InvalidParam,
+				fmt.Errorf("skipped package %q must be a subpackage of the package", p))
+		}
+	}
+	u.transcript = &Transcript{
+		APIVersion: TranscriptAPIVersion,
+		Kind:       TranscriptKind,
+		Metadata:   TranscriptMetadata{Name: filepath.Base(u.Pkg.UniquePath.String())},
+		Spec: TranscriptSpec{
+			Ref:      rootKf.Upstream.Git.Ref,
+			Strategy: rootKf.Upstream.UpdateStrategy,
+		},
+	}
+	u.decisions = decisions{}
 	err = kptfileutil.WriteFile(u.Pkg.UniquePath.String(), rootKf)
 	if err != nil {
 		return errors.E(op, u.Pkg.UniquePath, err)
@@ -139,7 +197,13 @@ func (u *Command) Run(ctx context.Context) error {
 	if u.cachedUpstreamRepos == nil {
 		u.cachedUpstreamRepos = make(map[string]*gitutil.GitUpstreamRepo)
 	}
-	packageCount := 0
+	if u.FromVendor {
+		u.vendorStore, err = vendor.Open(u.Pkg.UniquePath.String())
+		if err != nil {
+			return errors.E(op, u.Pkg.UniquePath, err)
+		}
+	}
+	var updatedPkgs []*pkg.Pkg
 
 	// Use stack to keep track of paths with a Kptfile that might contain
 	// information about remote subpackages.
@@ -148,9 +212,13 @@ func (u *Command) Run(ctx context.Context) error {
 
 	for s.Len() > 0 {
 		p := s.Pop()
-		packageCount++
+		pkgPath, err := u.pkgPath(p)
+		if err != nil {
+			return errors.E(op, p.UniquePath, err)
+		}
+		updatedPkgs = append(updatedPkgs, p)
 
-		if err := u.updateRootPackage(ctx, p); err != nil {
+		if err := u.updateRootPackage(ctx, p, pkgPath); err != nil {
 			return errors.E(op, p.UniquePath, err)
 		}
 
@@ -165,6 +233,16 @@ func (u *Command) Run(ctx context.Context) error {
 			}
 
 			if subKf.Upstream != nil && subKf.Upstream.Git != nil {
+				subPkgPath, err := u.pkgPath(subPkg)
+				if err != nil {
+					return errors.E(op, subPkg.UniquePath, err)
+				}
+				if u.skipped(subPkgPath) {
+					// a skipped package and its nested packages are left
+					// as they are.
+					u.decisions.record(subPkgPath, Skipped, "")
+					continue
+				}
 				// update subpackage kf ref/strategy if current pkg is a subpkg of root pkg or is root pkg
 				// and if original root pkg ref matches the subpkg ref
 				if shouldUpdateSubPkgRef(subKf, rootKf, originalRootKfRef) {
@@ -178,15 +256,76 @@ func (u *Command) Run(ctx context.Context) error {
 			}
 		}
 	}
-	pr.Printf("\nUpdated %d package(s).\n", packageCount)
+	pr.Printf("\nUpdated %d package(s).\n", len(updatedPkgs))
+
+	// Re-apply the local overrides now that the upstream changes have been
+	// merged. Like in render, nested packages are handled before the
+	// packages that contain them.
+	for i := len(updatedPkgs) - 1; i >= 0; i-- {
+		if err := reapplyOverrides(ctx, updatedPkgs[i].UniquePath.String()); err != nil {
+			return errors.E(op, updatedPkgs[i].UniquePath, err)
+		}
+	}
 
 	// finally, make sure that the merge comments are added to all resources in the updated package
 	if err := addmergecomment.Process(string(u.Pkg.UniquePath)); err != nil {
 		return errors.E(op, u.Pkg.UniquePath, err)
 	}
+
+	// record the digests of the updated packages so they can be verified
+	if err := digest.Record(string(u.Pkg.UniquePath)); err != nil {
+		return errors.E(op, u.Pkg.UniquePath, err)
+	}
 	return nil
 }
 
+// Replay makes the command replay the decisions of the transcript t: the
+// package is updated to the same version with the same strategies, and the
+// same subpackages are skipped.
+func (u *Command) Replay(t *Transcript) {
+	u.Ref = t.Spec.Ref
+	u.Strategy = t.Spec.Strategy
+	u.Strategies = map[string]kptfilev1.UpdateStrategyType{}
+	for _, p := range t.Spec.Packages {
+		switch {
+		case p.Action == Skipped:
+			u.Skip = append(u.Skip, p.Path)
+		case p.Strategy != "":
+			u.Strategies[p.Path] = p.Strategy
+		}
+	}
+}
+
+// Transcript returns the transcript of the decisions of the update. It
+// returns nil if the update hasn't run.
+func (u *Command) Transcript() *Transcript {
+	if u.transcript == nil {
+		return nil
+	}
+	t := *u.transcript
+	t.Spec.Packages = u.decisions.list()
+	return &t
+}
+
+// pkgPath returns the path of p relative to the updated package.
+func (u *Command) pkgPath(p *pkg.Pkg) (string, error) {
+	rel, err := filepath.Rel(u.Pkg.UniquePath.String(), p.UniquePath.String())
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// skipped returns true if the package at pkgPath is skipped.
+func (u Command) skipped(pkgPath string) bool {
+	for _, p := range u.Skip {
+		if path.Clean(filepath.ToSlash(p)) == pkgPath {
+			return true
+		}
+	}
+	return false
+}
+
 // GetCachedUpstreamRepos returns repos cached during update
 func (u Command) GetCachedUpstreamRepos() map[string]*gitutil.GitUpstreamRepo {
 	return u.cachedUpstreamRepos
@@ -246,8 +385,9 @@ func (nrc *nilRepoClone) AbsPath() string {
 
 // updateRootPackage updates a local package. It will use the information
 // about upstream in the Kptfile to fetch upstream and origin, and then
-// recursively traverse the hierarchy to add/update/delete packages.
-func (u Command) updateRootPackage(ctx context.Context, p *pkg.Pkg) error {
+// recursively traverse the hierarchy to add/update/delete packages. The
+// path of p relative to the updated package is pkgPath.
+func (u Command) updateRootPackage(ctx context.Context, p *pkg.Pkg, pkgPath string) error {
 	const op errors.Op = "update.updateRootPackage"
 	kf, err := p.Kptfile()
 	if err != nil {
@@ -260,9 +400,28 @@ func (u Command) updateRootPackage(ctx context.Context, p *pkg.Pkg) error {
 	g := kf.Upstream.Git
 	updated := &git.RepoSpec{OrgRepo: g.Repo, Path: g.Directory, Ref: g.Ref}
 	pr.Printf("Fetching upstream from %s@%s\n", kf.Upstream.Git.Repo, kf.Upstream.Git.Ref)
-	cloner := fetch.NewCloner(updated, fetch.WithCachedRepo(u.cachedUpstreamRepos))
-	if err := cloner.ClonerUsingGitExec(ctx); err != nil {
-		return errors.E(op, p.UniquePath, err)
+	moved := false
+	if err := u.fetchUpstream(ctx, updated); err != nil {
+		movedTo, found := u.movedDirectory(ctx, kf, err)
+		if !found {
+			return errors.E(op, p.UniquePath, err)
+		}
+		if !u.FollowRenames {
+			return errors.E(op, p.UniquePath, &DirectoryMovedError{
+				Repo:      g.Repo,
+				Directory: g.Directory,
+				MovedTo:   movedTo,
+			})
+		}
+		pr.Printf("Upstream directory %q was moved to %q, following it\n", g.Directory, movedTo)
+		if updated.Dir != "" {
+			_ = os.RemoveAll(updated.Dir)
+		}
+		updated = &git.RepoSpec{OrgRepo: g.Repo, Path: movedTo, Ref: g.Ref}
+		if err := u.fetchUpstream(ctx, updated); err != nil {
+			return errors.E(op, p.UniquePath, err)
+		}
+		moved = true
 	}
 	defer os.RemoveAll(updated.AbsPath())
 
@@ -271,7 +430,7 @@ func (u Command) updateRootPackage(ctx context.Context, p *pkg.Pkg) error {
 		gLock := kf.UpstreamLock.Git
 		originRepoSpec := &git.RepoSpec{OrgRepo: gLock.Repo, Path: gLock.Directory, Ref: gLock.Commit}
 		pr.Printf("Fetching origin from %s@%s\n", kf.Upstream.Git.Repo, kf.Upstream.Git.Ref)
-		if err := fetch.NewCloner(originRepoSpec, fetch.WithCachedRepo(u.cachedUpstreamRepos)).ClonerUsingGitExec(ctx); err != nil {
+		if err := u.fetchUpstream(ctx, originRepoSpec); err != nil {
 			return errors.E(op, p.UniquePath, err)
 		}
 		origin = originRepoSpec
@@ -297,7 +456,13 @@ func (u Command) updateRootPackage(ctx context.Context, p *pkg.Pkg) error {
 			isRoot = true
 		}
 
-		if err := u.updatePackage(ctx, relPath, localPath, updatedPath, originPath, isRoot); err != nil {
+		subPkgPath := path.Join(pkgPath, filepath.ToSlash(relPath))
+		if !isRoot && u.skipped(subPkgPath) {
+			pr.Printf("Skipping package %q.\n", subPkgPath)
+			u.decisions.record(subPkgPath, Skipped, "")
+			continue
+		}
+		if err := u.updatePackage(ctx, subPkgPath, relPath, localPath, updatedPath, originPath, isRoot); err != nil {
 			return errors.E(op, p.UniquePath, err)
 		}
 
@@ -314,17 +479,70 @@ func (u Command) updateRootPackage(ctx context.Context, p *pkg.Pkg) error {
 	if err := kptfileutil.UpdateUpstreamLockFromGit(p.UniquePath.String(), updated); err != nil {
 		return errors.E(op, p.UniquePath, err)
 	}
+	if moved {
+		if err := setUpstreamDirectory(p.UniquePath.String(), updated.Path); err != nil {
+			return errors.E(op, p.UniquePath, err)
+		}
+	}
 	return nil
 }
 
+// movedDirectory returns the directory that the upstream directory of the
+// package with the Kptfile kf was moved to, if err is the error of fetching
+// the upstream because its directory no longer exists. The move is looked
+// up between the commit in the upstream lock and the fetched commit.
+func (u Command) movedDirectory(ctx context.Context, kf *kptfilev1.KptFile, err error) (string, bool) {
+	var notExist *fetch.PathNotExistError
+	if u.vendorStore != nil || kf.UpstreamLock == nil || kf.UpstreamLock.Git == nil ||
+		!errors.As(err, &notExist) {
+		return "", false
+	}
+	g := kf.Upstream.Git
+	spec := &git.RepoSpec{OrgRepo: g.Repo}
+	upstream, found := u.cachedUpstreamRepos[spec.CloneSpec()]
+	if !found || kf.UpstreamLock.Git.Repo != g.Repo {
+		return "", false
+	}
+	movedTo, err := findMovedDirectory(ctx, upstream, g.Directory, kf.UpstreamLock.Git.Commit, notExist.Commit)
+	if err != nil || movedTo == "" {
+		return "", false
+	}
+	// keep the format of the directory in the Kptfile.
+	if strings.HasPrefix(g.Directory, "/") {
+		movedTo = "/" + movedTo
+	}
+	return movedTo, true
+}
+
+// setUpstreamDirectory changes the upstream directory in the Kptfile of the
+// package at path to dir.
+func setUpstreamDirectory(path, dir string) error {
+	kf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, path)
+	if err != nil {
+		return err
+	}
+	kf.Upstream.Git.Directory = dir
+	return kptfileutil.WriteFile(path, kf)
+}
+
+// fetchUpstream makes the package referenced by spec available on local
+// disk. If the update runs from the vendor store, the package is taken from
+// it, otherwise it is cloned from git.
+func (u Command) fetchUpstream(ctx context.Context, spec *git.RepoSpec) error {
+	if u.vendorStore != nil {
+		return u.vendorStore.Checkout(spec)
+	}
+	return fetch.NewCloner(spec, fetch.WithCachedRepo(u.cachedUpstreamRepos)).ClonerUsingGitExec(ctx)
+}
+
 // updatePackage takes care of updating a single package. The absolute paths to
 // the local, updated and origin packages are provided, as well as the path to the
-// package relative to the root.
+// package relative to the root and to the updated package.
 // The last parameter tells if this package is the root, i.e. the package
 // from which we got the information about upstream and origin.
 //
 //nolint:gocyclo
-func (u Command) updatePackage(ctx context.Context, subPkgPath, localPath, updatedPath, originPath string, isRootPkg bool) error {
+func (u Command) updatePackage(ctx context.Context, pkgPath, subPkgPath, localPath, updatedPath, originPath string, isRootPkg bool) error {
 	const op errors.Op = "update.updatePackage"
 	pr := printer.FromContextOrDie(ctx)
 
@@ -369,6 +587,7 @@ func (u Command) updatePackage(ctx context.Context, subPkgPath, localPath, updat
 		if err := pkgutil.CopyPackage(updatedPath, localPath, !isRootPkg, pkg.None); err != nil {
 			return errors.E(op, types.UniquePath(localPath), err)
 		}
+		u.decisions.record(pkgPath, Added, "")
 
 	// Package added locally, so no action needed.
 	case !originExists && localExists && !updatedExists:
@@ -383,11 +602,12 @@ func (u Command) updatePackage(ctx context.Context, subPkgPath, localPath, updat
 	// we don't re-add the updated package from upstream.
 	case originExists && !localExists && updatedExists:
 		pr.Printf("Ignoring package %q in upstream since it is deleted from local.\n", packageName(localPath))
+		u.decisions.record(pkgPath, Ignored, "")
 
 	// Package deleted from upstream
 	case originExists && localExists && !updatedExists:
 		// Check the diff. If there are local changes, we keep the subpackage.
-		diff, err := copyutil.Diff(originPath, localPath)
+		diff, err := localChanges(originPath, localPath)
 		if err != nil {
 			return errors.E(op, types.UniquePath(localPath), err)
 		}
@@ -396,18 +616,56 @@ func (u Command) updatePackage(ctx context.Context, subPkgPath, localPath, updat
 			if err := os.RemoveAll(localPath); err != nil {
 				return errors.E(op, types.UniquePath(localPath), err)
 			}
+			u.decisions.record(pkgPath, Deleted, "")
 		} else {
 			pr.Printf("Package %q deleted from upstream, but keeping local since it has changes.\n", packageName(localPath))
+			u.decisions.record(pkgPath, KeptLocal, "")
 		}
 	default:
-		if err := u.mergePackage(ctx, localPath, updatedPath, originPath, subPkgPath, isRootPkg); err != nil {
+		if err := u.mergePackage(ctx, pkgPath, localPath, updatedPath, originPath, subPkgPath, isRootPkg); err != nil {
 			return errors.E(op, types.UniquePath(localPath), err)
 		}
 	}
 	return nil
 }
 
-func (u Command) mergePackage(ctx context.Context, localPath, updatedPath, originPath, relPath string, isRootPkg bool) error {
+// localChanges returns the paths of the files that differ between the origin
+// and local package. Kptfiles that only differ in the recorded digest are not
+// considered changed, since the digest is not part of the fetched content.
+func localChanges(originPath, localPath string) (sets.String, error) {
+	diff, err := copyutil.Diff(originPath, localPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range diff.List() {
+		if filepath.Base(p) != kptfilev1.KptFileName {
+			continue
+		}
+		originKf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, filepath.Join(originPath, filepath.Dir(p)))
+		if err != nil {
+			continue
+		}
+		localKf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, filepath.Join(localPath, filepath.Dir(p)))
+		if err != nil {
+			continue
+		}
+		for _, kf := range []*kptfilev1.KptFile{originKf, localKf} {
+			if kf.UpstreamLock != nil {
+				kf.UpstreamLock.Digest = ""
+			}
+		}
+		equal, err := kptfileutil.Equal(originKf, localKf)
+		if err != nil {
+			return nil, err
+		}
+		if equal {
+			delete(diff, p)
+		}
+	}
+	return diff, nil
+}
+
+func (u Command) mergePackage(ctx context.Context, pkgPath, localPath, updatedPath, originPath, relPath string, isRootPkg bool) error {
 	const op errors.Op = "update.mergePackage"
 	pr := printer.FromContextOrDie(ctx)
 	// at this point, the localPath, updatedPath and originPath exists and are about to be merged
@@ -440,6 +698,7 @@ func (u Command) mergePackage(ctx context.Context, localPath, updatedPath, origi
 	case updatedUnfetched && !originUnfetched:
 		// updated is unfetched, so can't have changes except for Kptfile.
 		// we can just merge that one.
+		u.decisions.record(pkgPath, Merged, "")
 		return kptfileutil.UpdateKptfile(localPath, updatedPath, originPath, true)
 	case !updatedUnfetched && originUnfetched:
 		// This means that the package was unfetched when local forked from upstream,
@@ -458,25 +717,102 @@ func (u Command) mergePackage(ctx context.Context, localPath, updatedPath, origi
 	if err != nil {
 		return errors.E(op, types.UniquePath(localPath), err)
 	}
-	updater, found := strategies[pkgKf.Upstream.UpdateStrategy]
+	strategy := pkgKf.Upstream.UpdateStrategy
+	if s, found := u.Strategies[pkgPath]; found {
+		strategy = s
+	}
+	updater, found := strategies[strategy]
 	if !found {
 		return errors.E(op, types.UniquePath(localPath),
-			fmt.Errorf("unrecognized update strategy %s", u.Strategy))
+			fmt.Errorf("unrecognized update strategy %s", strategy))
 	}
-	pr.Printf("Updating package %q with strategy %q.\n", packageName(localPath), pkgKf.Upstream.UpdateStrategy)
+	drivers, err := mergeDrivers(ctx, pkgKf, localPath)
+	if err != nil {
+		return errors.E(op, types.UniquePath(localPath), err)
+	}
+	pr.Printf("Updating package %q with strategy %q.\n", packageName(localPath), strategy)
 	if err := updater().Update(Options{
 		RelPackagePath: relPath,
 		LocalPath:      localPath,
 		UpdatedPath:    updatedPath,
 		OriginPath:     originPath,
 		IsRoot:         isRootPkg,
+		MergeDrivers:   drivers,
+		Skipped: func(subPkgPath string) bool {
+			p := path.Join(pkgPath, filepath.ToSlash(subPkgPath))
+			if !u.skipped(p) {
+				return false
+			}
+			pr.Printf("Skipping package %q.\n", p)
+			u.decisions.record(p, Skipped, "")
+			return true
+		},
 	}); err != nil {
 		return errors.E(op, types.UniquePath(localPath), err)
 	}
+	u.decisions.record(pkgPath, Merged, strategy)
+	return nil
+}
 
+// reapplyOverrides runs the overrides declared in the upstream section of
+// the Kptfile of the package at pkgPath on the package.
+func reapplyOverrides(ctx context.Context, pkgPath string) error {
+	const op errors.Op = "update.reapplyOverrides"
+	kf, err := pkg.ReadKptfile(filesys.FileSystemOrOnDisk{}, pkgPath)
+	if err != nil {
+		return errors.E(op, types.UniquePath(pkgPath), err)
+	}
+	if kf.Upstream == nil || len(kf.Upstream.Overrides) == 0 {
+		return nil
+	}
+	pr := printer.FromContextOrDie(ctx)
+	pr.Printf("Re-applying %d override(s) to package %q.\n", len(kf.Upstream.Overrides), packageName(pkgPath))
+	executor := hook.Executor{
+		PkgPath:    pkgPath,
+		FileSystem: filesys.FileSystemOrOnDisk{},
+	}
+	executor.RunnerOptions.InitDefaults()
+	if err := executor.Execute(ctx, kf.Upstream.Overrides); err != nil {
+		return errors.E(op, types.UniquePath(pkgPath), err)
+	}
 	return nil
 }
 
+// mergeDrivers returns the merge drivers declared in the Kptfile of the
+// package at pkgPath.
+func mergeDrivers(ctx context.Context, kf *kptfilev1.KptFile, pkgPath string) (merge.Drivers, error) {
+	const op errors.Op = "update.mergeDrivers"
+	if kf.Upstream == nil || len(kf.Upstream.MergeDrivers) == 0 {
+		return nil, nil
+	}
+	fsys := filesys.FileSystemOrOnDisk{}
+	if err := kf.Validate(fsys, types.UniquePath(pkgPath)); err != nil {
+		return nil, errors.E(op, types.UniquePath(pkgPath), err)
+	}
+
+	var drivers merge.Drivers
+	for i := range kf.Upstream.MergeDrivers {
+		d := kf.Upstream.MergeDrivers[i]
+		driver := merge.Driver{
+			Files: d.Files,
+			Type:  d.Driver,
+		}
+		if d.Driver == kptfilev1.FunctionMergeDriver {
+			var opts fnruntime.RunnerOptions
+			opts.InitDefaults()
+			fn := *d.Function
+			runner, err := fnruntime.NewRunner(ctx, fsys, &fn, types.UniquePath(pkgPath),
+				fnresult.NewResultList(), opts, nil)
+			if err != nil {
+				return nil, errors.E(op, types.UniquePath(pkgPath), err)
+			}
+			driver.Function = runner
+		}
+		drivers = append(drivers, driver)
+	}
+	return drivers, nil
+}
+
 func packageName(path string) string {
 	return filepath.Base(path)
 }
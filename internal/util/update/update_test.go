@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleContainerTools/kpt/internal/testutil"
 	"github.com/GoogleContainerTools/kpt/internal/testutil/pkgbuilder"
 	. "github.com/GoogleContainerTools/kpt/internal/util/update"
+	"github.com/GoogleContainerTools/kpt/internal/util/vendor"
 	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
 	"github.com/GoogleContainerTools/kpt/pkg/kptfile/kptfileutil"
 	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
@@ -110,6 +111,98 @@ func TestCommand_Run_noRefChanges(t *testing.T) {
 	}
 }
 
+// TestCommand_Run_offline updates a package from its vendored upstreams.
+// - Get a package using a branch ref
+// - Modify upstream with new content
+// - Vendor the upstreams of the local package
+// - Remove the upstream repo
+// - Update the local package from the vendored upstreams
+func TestCommand_Run_fromVendor(t *testing.T) {
+	g := &testutil.TestSetupManager{
+		T: t,
+		ReposChanges: map[string][]testutil.Content{
+			testutil.Upstream: {
+				{
+					Data:   testutil.Dataset1,
+					Branch: masterBranch,
+				},
+				{
+					Data: testutil.Dataset2,
+				},
+			},
+		},
+	}
+	defer g.Clean()
+	if !g.Init() {
+		return
+	}
+	upstreamRepo := g.Repos[testutil.Upstream]
+	commit, err := upstreamRepo.GetCommit()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	p := pkgtest.CreatePkgOrFail(t, g.LocalWorkspace.FullPackagePath())
+	if !assert.NoError(t, vendor.Command{Pkg: p}.Run(fake.CtxWithDefaultPrinter())) {
+		return
+	}
+	if !assert.NoError(t, os.RemoveAll(upstreamRepo.RepoDirectory)) {
+		return
+	}
+
+	cmd := &Command{
+		Pkg:        p,
+		Strategy:   kptfilev1.ResourceMerge,
+		FromVendor: true,
+	}
+	if !assert.NoError(t, cmd.Run(fake.CtxWithDefaultPrinter())) {
+		return
+	}
+	assert.Empty(t, cmd.GetCachedUpstreamRepos())
+
+	if !assert.NoError(t, os.RemoveAll(filepath.Join(p.UniquePath.String(), vendor.DirName))) {
+		return
+	}
+	if !g.AssertLocalDataEquals(testutil.Dataset2, true) {
+		return
+	}
+	g.AssertKptfile(upstreamRepo.RepoName, commit, masterBranch, kptfilev1.ResourceMerge)
+}
+
+func TestCommand_Run_fromVendorNotVendored(t *testing.T) {
+	g := &testutil.TestSetupManager{
+		T: t,
+		ReposChanges: map[string][]testutil.Content{
+			testutil.Upstream: {
+				{
+					Data:   testutil.Dataset1,
+					Branch: masterBranch,
+				},
+			},
+		},
+	}
+	defer g.Clean()
+	if !g.Init() {
+		return
+	}
+
+	p := pkgtest.CreatePkgOrFail(t, g.LocalWorkspace.FullPackagePath())
+	if !assert.NoError(t, vendor.Command{Pkg: p}.Run(fake.CtxWithDefaultPrinter())) {
+		return
+	}
+
+	cmd := &Command{
+		Pkg:        p,
+		Ref:        "v1.0",
+		FromVendor: true,
+	}
+	err := cmd.Run(fake.CtxWithDefaultPrinter())
+	var notFoundErr *vendor.EntryNotFoundError
+	if assert.ErrorAs(t, err, &notFoundErr) {
+		assert.Equal(t, "v1.0", notFoundErr.Ref)
+	}
+}
+
 func TestCommand_Run_subDir(t *testing.T) {
 	for i := range kptfilev1.UpdateStrategies {
 		strategy := kptfilev1.UpdateStrategies[i]
@@ -3876,3 +3969,140 @@ func TestReplaceNonKRMFiles(t *testing.T) {
 		})
 	}
 }
+
+// TestUpdateReappliesOverrides verifies that the overrides recorded in the
+// upstream section of the Kptfile are run on the package after an update.
+func TestUpdateReappliesOverrides(t *testing.T) {
+	reposChanges := map[string][]testutil.Content{
+		testutil.Upstream: {
+			{
+				Pkg: pkgbuilder.NewRootPkg().
+					WithResource(pkgbuilder.DeploymentResource),
+				Branch: masterBranch,
+			},
+		},
+	}
+	repos, w, clean := testutil.SetupReposAndWorkspace(t, reposChanges)
+	defer clean()
+
+	w.PackageDir = testPackageName
+	kf := kptfileutil.DefaultKptfile(testPackageName)
+	kf.Upstream = &kptfilev1.Upstream{
+		Type: kptfilev1.GitOrigin,
+		Git: &kptfilev1.Git{
+			Repo:      repos[testutil.Upstream].RepoDirectory,
+			Directory: "/",
+			Ref:       masterBranch,
+		},
+		UpdateStrategy: kptfilev1.ResourceMerge,
+		Overrides: []kptfilev1.Function{
+			{Image: "builtins/gen-pkg-context"},
+		},
+	}
+	testutil.AddKptfileToWorkspace(t, w, kf)
+
+	err := (&Command{
+		Pkg: pkgtest.CreatePkgOrFail(t, w.FullPackagePath()),
+	}).Run(fake.CtxWithDefaultPrinter())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.FileExists(t, filepath.Join(w.FullPackagePath(), "deployment.yaml"))
+	assert.FileExists(t, filepath.Join(w.FullPackagePath(), "package-context.yaml"))
+
+	updated, err := pkg.ReadKptfile(filesys.MakeFsOnDisk(), w.FullPackagePath())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, kf.Upstream.Overrides, updated.Upstream.Overrides)
+}
+
+// TestCommand_Run_skipAndReplay skips a subpackage added in both upstream and
+// local, and replays the transcript of the update.
+func TestCommand_Run_skipAndReplay(t *testing.T) {
+	newSetup := func(t *testing.T) *testutil.TestSetupManager {
+		g := &testutil.TestSetupManager{
+			T: t,
+			ReposChanges: map[string][]testutil.Content{
+				testutil.Upstream: {
+					{
+						Pkg: pkgbuilder.NewRootPkg().
+							WithKptfile().
+							WithSubPackages(pkgbuilder.NewSubPkg("bar").WithKptfile()),
+						Branch: masterBranch,
+					},
+					{
+						Pkg: pkgbuilder.NewRootPkg().
+							WithKptfile().
+							WithSubPackages(
+								pkgbuilder.NewSubPkg("bar").WithKptfile(),
+								pkgbuilder.NewSubPkg("abc").WithKptfile().
+									WithResource(pkgbuilder.DeploymentResource),
+							),
+					},
+				},
+			},
+			LocalChanges: []testutil.Content{
+				{
+					Pkg: pkgbuilder.NewRootPkg().
+						WithKptfile().
+						WithSubPackages(
+							pkgbuilder.NewSubPkg("bar").WithKptfile(),
+							pkgbuilder.NewSubPkg("abc").WithKptfile(),
+						),
+				},
+			},
+		}
+		if !g.Init() {
+			t.FailNow()
+		}
+		return g
+	}
+
+	g := newSetup(t)
+	defer g.Clean()
+	cmd := &Command{
+		Pkg:      pkgtest.CreatePkgOrFail(t, g.LocalWorkspace.FullPackagePath()),
+		Strategy: kptfilev1.ResourceMerge,
+		Skip:     []string{"abc"},
+	}
+	if !assert.NoError(t, cmd.Run(fake.CtxWithDefaultPrinter())) {
+		t.FailNow()
+	}
+	// the skipped package keeps its local content.
+	assert.NoFileExists(t, filepath.Join(g.LocalWorkspace.FullPackagePath(), "abc", "deployment.yaml"))
+	transcript := cmd.Transcript()
+	assert.Equal(t, TranscriptSpec{
+		Ref:      masterBranch,
+		Strategy: kptfilev1.ResourceMerge,
+		Packages: []PackageDecision{
+			{Path: ".", Action: Merged, Strategy: kptfilev1.ResourceMerge},
+			{Path: "abc", Action: Skipped},
+		},
+	}, transcript.Spec)
+	transcriptPath := filepath.Join(t.TempDir(), "transcript.yaml")
+	if !assert.NoError(t, transcript.Write(transcriptPath)) {
+		t.FailNow()
+	}
+
+	// without the skip, the update fails on the conflict.
+	sibling := newSetup(t)
+	defer sibling.Clean()
+	err := (&Command{
+		Pkg: pkgtest.CreatePkgOrFail(t, sibling.LocalWorkspace.FullPackagePath()),
+	}).Run(fake.CtxWithDefaultPrinter())
+	assert.ErrorContains(t, err, `subpackage "abc" added in both upstream and local`)
+
+	// replaying the transcript makes the same decisions.
+	replayed, err := ReadTranscript(transcriptPath)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	replayCmd := &Command{Pkg: pkgtest.CreatePkgOrFail(t, sibling.LocalWorkspace.FullPackagePath())}
+	replayCmd.Replay(replayed)
+	if !assert.NoError(t, replayCmd.Run(fake.CtxWithDefaultPrinter())) {
+		t.FailNow()
+	}
+	assert.Empty(t, replayed.Mismatches(replayCmd.Transcript()))
+}
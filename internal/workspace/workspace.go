@@ -0,0 +1,174 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package workspace reads kpt workspaces, which are the packages of a repo
+// that are declared in a Kptworkspace file, and runs operations on all of
+// their packages.
+package workspace
+
+import (
+	"bytes"
+	"context"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	wsv1alpha1 "github.com/GoogleContainerTools/kpt/pkg/api/kptworkspace/v1alpha1"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Workspace is a Kptworkspace and the directory it is in.
+type Workspace struct {
+	// Dir is the absolute path of the directory of the Kptworkspace.
+	Dir string
+
+	Kptworkspace *wsv1alpha1.Kptworkspace
+}
+
+// Find returns the workspace of the directory dir, which is declared by
+// the Kptworkspace in dir or in its closest parent directory.
+func Find(fsys filesys.FileSystem, dir string) (*Workspace, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for d := dir; ; d = filepath.Dir(d) {
+		if fsys.Exists(filepath.Join(d, wsv1alpha1.KptworkspaceFileName)) {
+			return Read(fsys, d)
+		}
+		if filepath.Dir(d) == d {
+			return nil, fmt.Errorf("no %s found in %q or any of its parent directories",
+				wsv1alpha1.KptworkspaceFileName, dir)
+		}
+	}
+}
+
+// Read reads the workspace declared by the Kptworkspace in the directory
+// dir. The packages of the workspace must exist.
+func Read(fsys filesys.FileSystem, dir string) (*Workspace, error) {
+	p := filepath.Join(dir, wsv1alpha1.KptworkspaceFileName)
+	b, err := fsys.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	kw, err := Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", wsv1alpha1.KptworkspaceFileName, p, err)
+	}
+	w := &Workspace{Dir: dir, Kptworkspace: kw}
+	for _, pkgPath := range w.PackagePaths() {
+		if !fsys.Exists(filepath.Join(pkgPath, kptfilev1.KptFileName)) {
+			return nil, fmt.Errorf("invalid %s %q: package %q has no %s",
+				wsv1alpha1.KptworkspaceFileName, p, w.DisplayPath(pkgPath), kptfilev1.KptFileName)
+		}
+	}
+	return w, nil
+}
+
+// Decode decodes and validates a Kptworkspace.
+func Decode(in io.Reader) (*wsv1alpha1.Kptworkspace, error) {
+	kw := &wsv1alpha1.Kptworkspace{}
+	d := yaml.NewDecoder(in)
+	d.KnownFields(true)
+	if err := d.Decode(kw); err != nil {
+		return nil, err
+	}
+	if err := kw.Validate(); err != nil {
+		return nil, err
+	}
+	return kw, nil
+}
+
+// PackagePaths returns the absolute paths of the packages of the
+// workspace, in the order they are declared.
+func (w *Workspace) PackagePaths() []string {
+	var paths []string
+	for _, p := range w.Kptworkspace.Packages {
+		paths = append(paths, filepath.Join(w.Dir, filepath.FromSlash(p)))
+	}
+	return paths
+}
+
+// DisplayPath returns the slash-delimited path of the package at pkgPath
+// relative to the workspace.
+func (w *Workspace) DisplayPath(pkgPath string) string {
+	rel, err := filepath.Rel(w.Dir, pkgPath)
+	if err != nil {
+		return pkgPath
+	}
+	return filepath.ToSlash(rel)
+}
+
+// SkipError is returned by the functions passed to ForEachPackage when
+// the operation doesn't apply to a package.
+type SkipError struct {
+	Reason string
+}
+
+func (e *SkipError) Error() string {
+	return e.Reason
+}
+
+// ForEachPackage calls fn with the absolute path of every package of the
+// workspace, in order. It continues with the next package if fn fails for
+// a package, and prints the error, unless it was already printed. At the
+// end, the result of every package is printed as a table, and an error is
+// returned if fn failed for any package. The operation is used in the
+// error, e.g. "render".
+func (w *Workspace) ForEachPackage(ctx context.Context, operation string, fn func(pkgPath string) error) error {
+	pr := printer.FromContextOrDie(ctx)
+	pkgPaths := w.PackagePaths()
+	errs := make([]error, len(pkgPaths))
+	for i, p := range pkgPaths {
+		errs[i] = fn(p)
+		var skipErr *SkipError
+		if errs[i] != nil && !goerrors.As(errs[i], &skipErr) && !goerrors.Is(errs[i], errors.ErrAlreadyHandled) {
+			pr.Printf("Error: %s\n", errs[i])
+		}
+	}
+
+	var failed int
+	tw := tabwriter.NewWriter(pr.OutStream(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nPACKAGE\tRESULT")
+	for i, p := range pkgPaths {
+		result := "Succeeded"
+		var skipErr *SkipError
+		switch {
+		case errs[i] == nil:
+		case goerrors.As(errs[i], &skipErr):
+			result = "Skipped: " + skipErr.Reason
+		case goerrors.Is(errs[i], errors.ErrAlreadyHandled):
+			failed++
+			result = "Failed"
+		default:
+			failed++
+			result = "Failed: " + strings.SplitN(errs[i].Error(), "\n", 2)[0]
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", w.DisplayPath(p), result)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s failed for %d of %d packages", operation, failed, len(pkgPaths))
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/pkg/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+const kptworkspace = `apiVersion: kpt.dev/v1alpha1
+kind: Kptworkspace
+metadata:
+  name: platform
+packages:
+- apps/frontend
+- infra
+- apps/backend
+`
+
+func setupWorkspace(t *testing.T) filesys.FileSystem {
+	fsys := filesys.MakeFsInMemory()
+	files := map[string]string{
+		"/repo/Kptworkspace":              kptworkspace,
+		"/repo/apps/frontend/Kptfile":     "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: frontend\n",
+		"/repo/apps/backend/Kptfile":      "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: backend\n",
+		"/repo/infra/Kptfile":             "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: infra\n",
+		"/repo/infra/network/Kptfile":     "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: network\n",
+		"/repo/infra/network/subnet.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: subnet\n",
+	}
+	for p, content := range files {
+		require.NoError(t, fsys.MkdirAll(filepath.Dir(p)))
+		require.NoError(t, fsys.WriteFile(p, []byte(content)))
+	}
+	return fsys
+}
+
+func TestFind(t *testing.T) {
+	fsys := setupWorkspace(t)
+
+	for _, dir := range []string{"/repo", "/repo/infra/network"} {
+		ws, err := Find(fsys, dir)
+		require.NoError(t, err)
+		assert.Equal(t, "/repo", ws.Dir)
+		assert.Equal(t, "platform", ws.Kptworkspace.Name)
+		assert.Equal(t, []string{"/repo/apps/frontend", "/repo/infra", "/repo/apps/backend"}, ws.PackagePaths())
+		assert.Equal(t, "apps/frontend", ws.DisplayPath("/repo/apps/frontend"))
+	}
+
+	require.NoError(t, fsys.MkdirAll("/other"))
+	_, err := Find(fsys, "/other")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `no Kptworkspace found in "/other" or any of its parent directories`)
+	}
+}
+
+func TestRead_invalid(t *testing.T) {
+	testCases := map[string]struct {
+		kptworkspace string
+		expectedErr  string
+	}{
+		"wrong kind": {
+			kptworkspace: "apiVersion: kpt.dev/v1\nkind: Kptfile\npackages:\n- infra\n",
+			expectedErr:  `must have apiVersion "kpt.dev/v1alpha1" and kind "Kptworkspace"`,
+		},
+		"unknown field": {
+			kptworkspace: "apiVersion: kpt.dev/v1alpha1\nkind: Kptworkspace\npackage:\n- infra\n",
+			expectedErr:  "field package not found",
+		},
+		"no packages": {
+			kptworkspace: "apiVersion: kpt.dev/v1alpha1\nkind: Kptworkspace\n",
+			expectedErr:  "packages must not be empty",
+		},
+		"absolute path": {
+			kptworkspace: "apiVersion: kpt.dev/v1alpha1\nkind: Kptworkspace\npackages:\n- /infra\n",
+			expectedErr:  `packages[0] "/infra" must be a slash-delimited relative path`,
+		},
+		"outside of the workspace": {
+			kptworkspace: "apiVersion: kpt.dev/v1alpha1\nkind: Kptworkspace\npackages:\n- infra/../../other\n",
+			expectedErr:  `packages[0] "infra/../../other" must not be outside of the workspace`,
+		},
+		"nested packages": {
+			kptworkspace: "apiVersion: kpt.dev/v1alpha1\nkind: Kptworkspace\npackages:\n- infra/network\n- infra\n",
+			expectedErr:  `packages[0] "infra/network" and packages[1] "infra" must not contain each other`,
+		},
+		"not a package": {
+			kptworkspace: "apiVersion: kpt.dev/v1alpha1\nkind: Kptworkspace\npackages:\n- apps\n",
+			expectedErr:  `package "apps" has no Kptfile`,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			fsys := setupWorkspace(t)
+			require.NoError(t, fsys.WriteFile("/repo/Kptworkspace", []byte(tc.kptworkspace)))
+			_, err := Read(fsys, "/repo")
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestForEachPackage(t *testing.T) {
+	ws, err := Find(setupWorkspace(t), "/repo")
+	require.NoError(t, err)
+
+	var out, errOut bytes.Buffer
+	ctx := printer.WithContext(context.Background(), printer.New(&out, &errOut))
+	var visited []string
+	err = ws.ForEachPackage(ctx, "update", func(pkgPath string) error {
+		visited = append(visited, pkgPath)
+		switch ws.DisplayPath(pkgPath) {
+		case "infra":
+			return fmt.Errorf("merge conflict\nin network")
+		case "apps/backend":
+			return &SkipError{Reason: "package has no upstream"}
+		}
+		return nil
+	})
+	if assert.Error(t, err) {
+		assert.Equal(t, "update failed for 1 of 3 packages", err.Error())
+	}
+	assert.Equal(t, ws.PackagePaths(), visited)
+	assert.Equal(t, `
+PACKAGE        RESULT
+apps/frontend  Succeeded
+infra          Failed: merge conflict
+apps/backend   Skipped: package has no upstream
+`, out.String())
+	assert.Equal(t, "Error: merge conflict\nin network\n", errOut.String())
+
+	// errors that are already printed are not printed again.
+	out.Reset()
+	errOut.Reset()
+	err = ws.ForEachPackage(ctx, "render", func(string) error {
+		return errors.E(errors.Op("render"), errors.ErrAlreadyHandled)
+	})
+	if assert.Error(t, err) {
+		assert.Equal(t, "render failed for 3 of 3 packages", err.Error())
+	}
+	assert.Equal(t, 3, strings.Count(out.String(), "Failed\n"))
+	assert.Empty(t, errOut.String())
+}
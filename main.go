@@ -15,12 +15,15 @@
 //go:generate $GOBIN/mdtogo site/reference/cli/live internal/docs/generated/livedocs --license=none --recursive=true --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/pkg internal/docs/generated/pkgdocs --license=none --recursive=true --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/fn internal/docs/generated/fndocs --license=none --recursive=true --strategy=cmdDocs
+//go:generate $GOBIN/mdtogo site/reference/cli/ws internal/docs/generated/wsdocs --license=none --recursive=true --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/alpha internal/docs/generated/alphadocs --license=none --recursive=false --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/alpha/repo internal/docs/generated/repodocs --license=none --recursive=true --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/alpha/rpkg internal/docs/generated/rpkgdocs --license=none --recursive=true --strategy=cmdDocs
+//go:generate $GOBIN/mdtogo site/reference/cli/search internal/docs/generated/searchdocs --license=none --recursive=false --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/alpha/sync internal/docs/generated/syncdocs --license=none --recursive=true --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/alpha/wasm internal/docs/generated/wasmdocs --license=none --recursive=true --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/alpha/license internal/docs/generated/licensedocs --license=none --recursive=true --strategy=cmdDocs
+//go:generate $GOBIN/mdtogo site/reference/cli/alpha/composite internal/docs/generated/compositedocs --license=none --recursive=true --strategy=cmdDocs
 //go:generate $GOBIN/mdtogo site/reference/cli/README.md internal/docs/generated/overview --license=none --strategy=cmdDocs
 package main
 
@@ -28,10 +31,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/GoogleContainerTools/kpt/internal/errors"
 	"github.com/GoogleContainerTools/kpt/internal/errors/resolver"
 	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/kptconfig"
+	"github.com/GoogleContainerTools/kpt/internal/util/stats"
 	"github.com/GoogleContainerTools/kpt/run"
 	"github.com/spf13/cobra"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -59,21 +66,47 @@ func runMain() int {
 
 	cmd := run.GetMain(ctx)
 
+	// Apply the defaults from the user's kpt config file. A broken config
+	// must not break every command, e.g. `kpt version`, so problems with
+	// it are only reported as warnings.
+	if err = kptconfig.Apply(cmd, cmd.ErrOrStderr()); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: failed to apply the kpt config: %v\n", err)
+	}
+
+	start := time.Now()
 	err = cli.RunNoErrOutput(cmd)
+	recordStats(cmd, start, err)
 	if err != nil {
 		return handleErr(cmd, err)
 	}
 	return 0
 }
 
+// recordStats records how long the command took, if the user opted in to
+// stats. `kpt stats` itself is not recorded.
+func recordStats(root *cobra.Command, start time.Time, err error) {
+	c, _, findErr := root.Find(os.Args[1:])
+	if findErr != nil || c == root || (c.Name() == "stats" && c.Parent() == root) {
+		return
+	}
+	if statsErr := stats.Flush(c.CommandPath(), time.Since(start), err); statsErr != nil {
+		fmt.Fprintf(root.ErrOrStderr(), "failed to record stats: %v\n", statsErr)
+	}
+}
+
 // handleErr takes care of printing an error message for a given error.
 func handleErr(cmd *cobra.Command, err error) int {
+	if cmdutil.ErrorFormat == cmdutil.JSONErrorFormat {
+		return handleJSONErr(cmd, err)
+	}
+
 	// First attempt to see if we can resolve the error into a specific
 	// error message.
 	if re, resolved := resolver.ResolveError(err); resolved {
 		if re.Message != "" {
 			fmt.Fprintf(cmd.ErrOrStderr(), "%s \n", re.Message)
 		}
+		printErrorCode(cmd, re.Code)
 		return re.ExitCode
 	}
 
@@ -83,9 +116,10 @@ func handleErr(cmd *cobra.Command, err error) int {
 		unwrapped, ok := errors.UnwrapErrors(kptErr)
 		if ok && !cmdutil.PrintErrorStacktrace() {
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s \n", unwrapped.Error())
-			return 1
+		} else {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s \n", kptErr.Error())
 		}
-		fmt.Fprintf(cmd.ErrOrStderr(), "%s \n", kptErr.Error())
+		printErrorCode(cmd, resolver.CodeUnknown)
 		return 1
 	}
 
@@ -93,6 +127,50 @@ func handleErr(cmd *cobra.Command, err error) int {
 	// printing of several error types used in kubectl
 	// TODO: See if we can handle this in kpt and get a uniform experience
 	// across all of kpt.
+	// The handler exits by default, so it is replaced to print the error
+	// code after the message and return the exit code instead.
+	exitCode := 1
+	k8scmdutil.BehaviorOnFatal(func(msg string, code int) {
+		if msg != "" {
+			if !strings.HasSuffix(msg, "\n") {
+				msg += "\n"
+			}
+			fmt.Fprint(cmd.ErrOrStderr(), msg)
+		}
+		printErrorCode(cmd, resolver.CodeUnknown)
+		exitCode = code
+	})
+	defer k8scmdutil.DefaultBehaviorOnFatal()
 	k8scmdutil.CheckErr(err)
-	return 1
+	return exitCode
+}
+
+// printErrorCode prints the error code of an error and how to learn more
+// about it.
+func printErrorCode(cmd *cobra.Command, code resolver.Code) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "Error code: %s. Run \"kpt explain-error %s\" for how to fix it.\n",
+		code, code)
+}
+
+// handleJSONErr prints the error as a JSON object with its error code, so
+// wrappers of kpt can handle the kinds of errors differently.
+func handleJSONErr(cmd *cobra.Command, err error) int {
+	re, resolved := resolver.ResolveError(err)
+	if !resolved {
+		re = resolver.ResolvedResult{
+			Message:  err.Error(),
+			ExitCode: 1,
+			Code:     resolver.CodeUnknown,
+		}
+		var kptErr *errors.Error
+		if errors.As(err, &kptErr) && !cmdutil.PrintErrorStacktrace() {
+			if unwrapped, ok := errors.UnwrapErrors(kptErr); ok {
+				re.Message = unwrapped.Error()
+			}
+		}
+	}
+	if err := resolver.WriteJSON(cmd.ErrOrStderr(), re); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%v\n", err)
+	}
+	return re.ExitCode
 }
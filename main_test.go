@@ -0,0 +1,51 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleErr_unresolved(t *testing.T) {
+	const code = `Error code: KPT0001. Run "kpt explain-error KPT0001" for how to fix it.`
+	testCases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"kpt error": {
+			err:      errors.E(errors.Op("test.op"), fmt.Errorf("something failed")),
+			expected: "Error: something failed \n" + code + "\n",
+		},
+		"other error": {
+			err:      fmt.Errorf("something failed"),
+			expected: "error: something failed\n" + code + "\n",
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var stderr bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetErr(&stderr)
+			assert.Equal(t, 1, handleErr(cmd, tc.err))
+			assert.Equal(t, tc.expected, stderr.String())
+		})
+	}
+}
@@ -88,3 +88,149 @@ func NewResultList() *ResultList {
 		Items: []Result{},
 	}
 }
+
+// RenderStatusGVK is the GroupVersionKind of RenderStatus objects
+func RenderStatusGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "kpt.dev",
+		Version: "v1",
+		Kind:    "RenderStatus",
+	}
+}
+
+// RenderStatus records the changes made by the mutators of the pipelines
+// run by a render.
+type RenderStatus struct {
+	yaml.ResourceMeta `yaml:",inline"`
+	// Items contain an entry for every mutator, in the order they were run
+	Items []Mutation `yaml:"items,omitempty"`
+}
+
+// Mutation records the changes made by an individual mutator
+type Mutation struct {
+	// Image is the full name of the image of the function
+	// Image and Exec are mutually exclusive
+	Image string `yaml:"image,omitempty"`
+	// ExecPath is the path to the executable of the function
+	ExecPath string `yaml:"exec,omitempty"`
+	// Pkg is the slash-separated path of the package the pipeline belongs
+	// to, relative to the root package.
+	Pkg string `yaml:"pkg"`
+	// Timestamp is the time the function was run, in RFC 3339 format
+	Timestamp string `yaml:"timestamp"`
+	// Resources are the resources added, changed or removed by the function
+	Resources []ResourceMutation `yaml:"resources,omitempty"`
+}
+
+// MutationAction describes how a resource was changed by a function
+type MutationAction string
+
+const (
+	ResourceAdded    MutationAction = "Added"
+	ResourceModified MutationAction = "Modified"
+	ResourceRemoved  MutationAction = "Removed"
+)
+
+// ResourceMutation records the changes made to a resource by a function
+type ResourceMutation struct {
+	APIVersion string `yaml:"apiVersion,omitempty"`
+	Kind       string `yaml:"kind,omitempty"`
+	Name       string `yaml:"name,omitempty"`
+	Namespace  string `yaml:"namespace,omitempty"`
+	// File is the slash-separated path of the file of the resource,
+	// relative to the root package.
+	File string `yaml:"file,omitempty"`
+	// Action is how the resource was changed
+	Action MutationAction `yaml:"action"`
+	// Fields are the paths of the fields that were changed. They are only
+	// set for modified resources.
+	Fields []string `yaml:"fields,omitempty"`
+}
+
+// NewRenderStatus returns an instance of RenderStatus with metadata
+// field populated.
+func NewRenderStatus() *RenderStatus {
+	return &RenderStatus{
+		ResourceMeta: yaml.ResourceMeta{
+			TypeMeta: yaml.TypeMeta{
+				APIVersion: ResultListAPIVersion,
+				Kind:       RenderStatusGVK().Kind,
+			},
+			ObjectMeta: yaml.ObjectMeta{
+				NameMeta: yaml.NameMeta{
+					Name: "render-status",
+				},
+				Annotations: map[string]string{
+					"config.kubernetes.io/local-config": "true",
+				},
+			},
+		},
+		Items: []Mutation{},
+	}
+}
+
+// ReferenceGraphGVK is the GroupVersionKind of ReferenceGraph objects
+func ReferenceGraphGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "kpt.dev",
+		Version: "v1",
+		Kind:    "ReferenceGraph",
+	}
+}
+
+// ReferenceGraph records the references between the resources of a
+// rendered package, e.g. from a Deployment to the ConfigMap it mounts.
+// Transformations that change resource identifiers can use it to update
+// the fields that refer to the changed resources.
+type ReferenceGraph struct {
+	yaml.ResourceMeta `yaml:",inline"`
+	// Items contain an entry for every reference, ordered by the
+	// resources they are from
+	Items []Reference `yaml:"items,omitempty"`
+}
+
+// Reference is a reference from a field of a resource to another resource
+type Reference struct {
+	// From is the resource containing the reference
+	From ResourceRef `yaml:"from"`
+	// Field is the path of the field containing the name of the referenced
+	// resource, e.g. spec.template.spec.volumes[0].configMap.name
+	Field string `yaml:"field"`
+	// To is the referenced resource
+	To ResourceRef `yaml:"to"`
+	// Missing is true if the referenced resource is not in the package
+	Missing bool `yaml:"missing,omitempty"`
+}
+
+// ResourceRef identifies a resource in a ReferenceGraph
+type ResourceRef struct {
+	Group     string `yaml:"group,omitempty"`
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+	// File is the slash-separated path of the file of the resource,
+	// relative to the root package. It is not set for missing resources.
+	File string `yaml:"file,omitempty"`
+}
+
+// NewReferenceGraph returns an instance of ReferenceGraph with metadata
+// field populated.
+func NewReferenceGraph() *ReferenceGraph {
+	return &ReferenceGraph{
+		ResourceMeta: yaml.ResourceMeta{
+			TypeMeta: yaml.TypeMeta{
+				APIVersion: ResultListAPIVersion,
+				Kind:       ReferenceGraphGVK().Kind,
+			},
+			ObjectMeta: yaml.ObjectMeta{
+				NameMeta: yaml.NameMeta{
+					Name: "reference-graph",
+				},
+				Annotations: map[string]string{
+					"config.kubernetes.io/local-config": "true",
+				},
+			},
+		},
+		Items: []Reference{},
+	}
+}
@@ -42,8 +42,19 @@ const (
 
 	// Deprecated: prefer KptFileGVK
 	KptFileAPIVersion = KptFileGroup + "/" + KptFileVersion
+
+	// GeneratedByAnnotation marks the resources generated by the functions
+	// of a pipeline, as opposed to the resources authored in the package.
+	// The value is the image or exec of the function.
+	GeneratedByAnnotation = "kpt.dev/generated-by"
 )
 
+// IsGenerated returns true if the resource was generated by a function.
+func IsGenerated(r *yaml.RNode) bool {
+	_, found := r.GetAnnotations()[GeneratedByAnnotation]
+	return found
+}
+
 // KptFileGVK is the GroupVersionKind of Kptfile objects
 func KptFileGVK() schema.GroupVersionKind {
 	return schema.GroupVersionKind{
@@ -147,6 +158,59 @@ type Upstream struct {
 
 	// UpdateStrategy declares how a package will be updated from upstream.
 	UpdateStrategy UpdateStrategyType `yaml:"updateStrategy,omitempty" json:"updateStrategy,omitempty"`
+
+	// MergeDrivers declare how the files in the package are merged when
+	// the package is updated with the resource-merge strategy. The first
+	// driver matching a file is used. Files not matching any driver use
+	// the resource-merge driver.
+	MergeDrivers []MergeDriver `yaml:"mergeDrivers,omitempty" json:"mergeDrivers,omitempty"`
+
+	// Overrides are functions that are run on the package after every update.
+	// They re-apply local customizations, such as the values set with
+	// apply-setters or set-namespace, to the updated package, including to
+	// resources that were added in upstream.
+	Overrides []Function `yaml:"overrides,omitempty" json:"overrides,omitempty"`
+}
+
+// MergeDriverType defines how the files selected by a merge driver are merged.
+type MergeDriverType string
+
+const (
+	// ResourceMergeDriver performs a structural 3-way merge of the resources.
+	ResourceMergeDriver MergeDriverType = "resource-merge"
+	// OursMergeDriver keeps the local version of the files.
+	OursMergeDriver MergeDriverType = "ours"
+	// TheirsMergeDriver replaces the files with the upstream version.
+	TheirsMergeDriver MergeDriverType = "theirs"
+	// FunctionMergeDriver merges the resources with a KRM function.
+	FunctionMergeDriver MergeDriverType = "function"
+)
+
+// MergeDriverTypes is a slice with all the supported merge drivers.
+var MergeDriverTypes = []MergeDriverType{
+	ResourceMergeDriver,
+	OursMergeDriver,
+	TheirsMergeDriver,
+	FunctionMergeDriver,
+}
+
+// MergeDriver selects the merge driver for a set of files.
+type MergeDriver struct {
+	// Files is a glob matching the slash-separated paths of files relative to
+	// the package directory. A glob without a slash is matched against the
+	// file name in any directory of the package.
+	// e.g. 'monitoring/*.rules.yaml', 'values.yaml'
+	Files string `yaml:"files,omitempty" json:"files,omitempty"`
+
+	// Driver is the merge driver used for the files.
+	Driver MergeDriverType `yaml:"driver,omitempty" json:"driver,omitempty"`
+
+	// Function is the KRM function used by the function driver. It is
+	// invoked once per resource with the original, upstream and local
+	// versions of the resource, annotated with `config.kubernetes.io/merge-source`
+	// set to `original`, `updated` and `dest` respectively, and must return the
+	// merged resource, or no resource if it should be deleted.
+	Function *Function `yaml:"function,omitempty" json:"function,omitempty"`
 }
 
 // Git is the user-specified locator for a package on Git.
@@ -170,6 +234,12 @@ type UpstreamLock struct {
 
 	// Git is the resolved locator for a package on Git.
 	Git *GitLock `yaml:"git,omitempty" json:"git,omitempty"`
+
+	// Digest is the sha256 digest of the package content as written by the
+	// last `kpt pkg get` or `kpt pkg update`, excluding the Kptfile of the
+	// package and any remote subpackages. It is checked by `kpt pkg verify`.
+	// e.g. 'sha256:5b41362bc82b7f3d56edc5a306db22105707d01ff4819e26faef9724a2d406c9'
+	Digest string `yaml:"digest,omitempty" json:"digest,omitempty"`
 }
 
 // GitLock is the resolved locator for a package on Git.
@@ -253,6 +323,19 @@ type Pipeline struct {
 	// When omitted, defaults to './*'.
 	// Sources []string `yaml:"sources,omitempty"`
 
+	// Dependencies lists packages whose rendered resources are passed to the
+	// functions in this pipeline as additional, read-only input. Each entry is
+	// a slash-separated relative path to another package in the package
+	// hierarchy being rendered, e.g. '../network'. Dependencies are rendered
+	// before this package, and their resources are removed from the output
+	// of this pipeline.
+	Dependencies []string `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+
+	// SkipInherited lists the names of the functions propagated from the
+	// parent packages that are not applied to this package, nor propagated
+	// further to its subpackages.
+	SkipInherited []string `yaml:"skipInherited,omitempty" json:"skipInherited,omitempty"`
+
 	// Following fields define the sequence of functions in the pipeline.
 	// Input of the first function is the resolved sources.
 	// Input of the second function is the output of the first function, and so on.
@@ -305,8 +388,26 @@ type Function struct {
 	//
 	// 	 exec: set-namespace
 	// 	 exec: /usr/local/bin/my-custom-fn
+	//
+	// `starlark` is reserved for the built-in starlark runtime, which runs the
+	// script at the given path in the package, or in the `source` field of the
+	// function config, without an executable, e.g:
+	//
+	// 	 exec: starlark set-replicas.star
 	Exec string `yaml:"exec,omitempty" json:"exec,omitempty"`
 
+	// `Composite` specifies a composite function, an ordered list of
+	// functions published as an OCI artifact with `kpt alpha composite push`.
+	// The composite function is expanded into its functions when the
+	// pipeline runs, e.g:
+	//
+	//	composite: us-docker.pkg.dev/my-org/fns/org-standard:v1
+	//
+	// The `selectors` and `exclude` of the entry apply to the functions of
+	// the composite function that don't have their own. It can't be combined
+	// with the other fields of the function.
+	Composite string `yaml:"composite,omitempty" json:"composite,omitempty"`
+
 	// `ConfigPath` specifies a slash-delimited relative path to a file in the current directory
 	// containing a KRM resource used as the function config. This resource is
 	// excluded when resolving 'sources', and as a result cannot be operated on
@@ -327,8 +428,65 @@ type Function struct {
 	// `Exclude` are used to specify resources on which the function should NOT be executed.
 	// If not specified, all resources selected by `Selectors` are selected.
 	Exclusions []Selector `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+
+	// `Network` determines if the function container may access the network.
+	// If false, the function runs without network access, even if network
+	// access is allowed with `--allow-network`. If true, the function must
+	// be run with `--allow-network`. If not specified, the function has
+	// network access only if `--allow-network` is given.
+	// It is only supported for functions with an `image`.
+	Network *bool `yaml:"network,omitempty" json:"network,omitempty"`
+
+	// `Mounts` are the directories and files of the package, or temporary
+	// file systems, that are mounted into the function container.
+	// It is only supported for functions with an `image`.
+	Mounts []Mount `yaml:"mounts,omitempty" json:"mounts,omitempty"`
+
+	// `Env` are the names of the environment variables that are passed to
+	// the function container, e.g. tokens of APIs the function queries.
+	// The values are not part of the package, they are provided by the
+	// package consumer with the `--env` and `--env-file` flags of
+	// `kpt fn render`.
+	// It is only supported for functions with an `image`.
+	Env []string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// `Propagate` applies the function to the subpackages of the package
+	// as well, when the package is rendered, as if the function was declared
+	// in their pipelines before their own functions. A subpackage overrides
+	// the function with a function of the same `name` in the same list, or
+	// opts out of it with `pipeline.skipInherited`.
+	// A propagated function can't have a `configPath`. The paths of its
+	// mounts and starlark script are relative to the subpackage it runs in.
+	Propagate bool `yaml:"propagate,omitempty" json:"propagate,omitempty"`
 }
 
+// Mount specifies storage that is mounted into a function container.
+// +kubebuilder:object:generate=true
+type Mount struct {
+	// Type is the type of the mount, either `bind` or `tmpfs`.
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Src is the slash-delimited path of the mounted directory or file,
+	// relative to the package. It must not be outside of the package.
+	// It is only used for `bind` mounts.
+	Src string `yaml:"src,omitempty" json:"src,omitempty"`
+
+	// Dst is the absolute path in the container where the storage is mounted.
+	Dst string `yaml:"dst,omitempty" json:"dst,omitempty"`
+
+	// ReadWrite determines if the function may write to a `bind` mount.
+	// Bind mounts are read-only by default, `tmpfs` mounts are always
+	// writable.
+	ReadWrite bool `yaml:"rw,omitempty" json:"rw,omitempty"`
+}
+
+const (
+	// BindMount mounts a directory or file of the package.
+	BindMount = "bind"
+	// TmpfsMount mounts an empty temporary file system.
+	TmpfsMount = "tmpfs"
+)
+
 // Selector specifies the selection criteria
 // please update IsEmpty method if more properties are added
 // +kubebuilder:object:generate=true
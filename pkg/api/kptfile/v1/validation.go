@@ -16,6 +16,7 @@ package v1
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -34,14 +35,107 @@ const (
 	kustomizationAPIGroup = "kustomize.config.k8s.io"
 )
 
+// envNameRegexp matches the names of environment variables.
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 func (kf *KptFile) Validate(fsys filesys.FileSystem, pkgPath types.UniquePath) error {
 	if err := kf.Pipeline.validate(fsys, pkgPath); err != nil {
 		return fmt.Errorf("invalid pipeline: %w", err)
 	}
+	if err := kf.Upstream.validate(fsys, pkgPath); err != nil {
+		return fmt.Errorf("invalid upstream: %w", err)
+	}
 	// TODO: validate other fields
 	return nil
 }
 
+// validate will validate the merge drivers and overrides of the upstream.
+func (u *Upstream) validate(fsys filesys.FileSystem, pkgPath types.UniquePath) error {
+	if u == nil {
+		return nil
+	}
+	for i := range u.MergeDrivers {
+		if err := u.MergeDrivers[i].validate(i); err != nil {
+			return err
+		}
+	}
+	for i := range u.Overrides {
+		f := u.Overrides[i]
+		field := fmt.Sprintf("upstream.overrides[%d]", i)
+		// Overrides run during pkg update, which doesn't allow running
+		// function binaries.
+		if f.Exec != "" {
+			return &ValidateError{
+				Field:  field + ".exec",
+				Reason: "overrides must be specified with `image`",
+			}
+		}
+		if f.Composite != "" {
+			return &ValidateError{
+				Field:  field + ".composite",
+				Reason: "overrides must be specified with `image`",
+			}
+		}
+		if err := f.validate(fsys, field, pkgPath); err != nil {
+			return fmt.Errorf("function %q: %w", f.Image, err)
+		}
+	}
+	return nil
+}
+
+func (d *MergeDriver) validate(idx int) error {
+	field := fmt.Sprintf("upstream.mergeDrivers[%d]", idx)
+	if d.Files == "" {
+		return &ValidateError{
+			Field:  field + ".files",
+			Reason: "must specify the files the merge driver applies to",
+		}
+	}
+	if _, err := path.Match(d.Files, ""); err != nil {
+		return &ValidateError{
+			Field:  field + ".files",
+			Value:  d.Files,
+			Reason: err.Error(),
+		}
+	}
+	switch d.Driver {
+	case ResourceMergeDriver, OursMergeDriver, TheirsMergeDriver:
+		if d.Function != nil {
+			return &ValidateError{
+				Field:  field + ".function",
+				Reason: fmt.Sprintf("must only be specified for the %q driver", FunctionMergeDriver),
+			}
+		}
+	case FunctionMergeDriver:
+		if d.Function == nil || d.Function.Image == "" {
+			return &ValidateError{
+				Field:  field + ".function.image",
+				Reason: "must specify the image of the merge function",
+			}
+		}
+		if d.Function.Exec != "" {
+			return &ValidateError{
+				Field:  field + ".function.exec",
+				Reason: "merge functions must be specified with `image`",
+			}
+		}
+		if err := ValidateFunctionImageURL(d.Function.Image); err != nil {
+			return &ValidateError{
+				Field:  field + ".function.image",
+				Value:  d.Function.Image,
+				Reason: err.Error(),
+			}
+		}
+	default:
+		return &ValidateError{
+			Field:  field + ".driver",
+			Value:  string(d.Driver),
+			Reason: fmt.Sprintf("must be one of %v", MergeDriverTypes),
+		}
+	}
+	return nil
+}
+
 // validate will validate all fields in the Pipeline
 // 'mutators' and 'validators' share same schema and
 // they are valid if all functions in them are ALL valid.
@@ -49,16 +143,33 @@ func (p *Pipeline) validate(fsys filesys.FileSystem, pkgPath types.UniquePath) e
 	if p == nil {
 		return nil
 	}
+	for i, dep := range p.Dependencies {
+		if err := validateDependencyPath(dep); err != nil {
+			return &ValidateError{
+				Field:  fmt.Sprintf("pipeline.dependencies[%d]", i),
+				Value:  dep,
+				Reason: err.Error(),
+			}
+		}
+	}
+	for i, name := range p.SkipInherited {
+		if strings.TrimSpace(name) == "" {
+			return &ValidateError{
+				Field:  fmt.Sprintf("pipeline.skipInherited[%d]", i),
+				Reason: "must be the name of a function",
+			}
+		}
+	}
 	for i := range p.Mutators {
 		f := p.Mutators[i]
-		err := f.validate(fsys, "mutators", i, pkgPath)
+		err := f.validate(fsys, fmt.Sprintf("pipeline.mutators[%d]", i), pkgPath)
 		if err != nil {
 			return fmt.Errorf("function %q: %w", f.Image, err)
 		}
 	}
 	for i := range p.Validators {
 		f := p.Validators[i]
-		err := f.validate(fsys, "validators", i, pkgPath)
+		err := f.validate(fsys, fmt.Sprintf("pipeline.validators[%d]", i), pkgPath)
 		if err != nil {
 			return fmt.Errorf("function %q: %w", f.Image, err)
 		}
@@ -66,16 +177,37 @@ func (p *Pipeline) validate(fsys filesys.FileSystem, pkgPath types.UniquePath) e
 	return nil
 }
 
-func (f *Function) validate(fsys filesys.FileSystem, fnType string, idx int, pkgPath types.UniquePath) error {
+// validateDependencyPath validates that p is a relative path to a package
+// other than the current one.
+func validateDependencyPath(p string) error {
+	if strings.TrimSpace(p) == "" {
+		return fmt.Errorf("path must not be empty")
+	}
+	if path.IsAbs(p) || filepath.IsAbs(p) {
+		return fmt.Errorf("path must be relative")
+	}
+	if strings.Contains(p, "\\") {
+		return fmt.Errorf("path must be slash-separated")
+	}
+	if path.Clean(p) == "." {
+		return fmt.Errorf("package must not depend on itself")
+	}
+	return nil
+}
+
+func (f *Function) validate(fsys filesys.FileSystem, field string, pkgPath types.UniquePath) error {
+	if f.Composite != "" {
+		return f.validateComposite(field)
+	}
 	if f.Image == "" && f.Exec == "" {
 		return &ValidateError{
-			Field:  fmt.Sprintf("pipeline.%s[%d]", fnType, idx),
+			Field:  field,
 			Reason: "must specify a functon (`image` or `exec`) to execute",
 		}
 	}
 	if f.Image != "" && f.Exec != "" {
 		return &ValidateError{
-			Field:  fmt.Sprintf("pipeline.%s[%d]", fnType, idx),
+			Field:  field,
 			Reason: "must not specify both `image` and `exec` at the same time",
 		}
 	}
@@ -83,7 +215,7 @@ func (f *Function) validate(fsys filesys.FileSystem, fnType string, idx int, pkg
 		err := ValidateFunctionImageURL(f.Image)
 		if err != nil {
 			return &ValidateError{
-				Field:  fmt.Sprintf("pipeline.%s[%d].image", fnType, idx),
+				Field:  field + ".image",
 				Value:  f.Image,
 				Reason: err.Error(),
 			}
@@ -91,24 +223,58 @@ func (f *Function) validate(fsys filesys.FileSystem, fnType string, idx int, pkg
 	}
 	// TODO(droot): validate the exec
 
+	if (f.Network != nil || len(f.Mounts) > 0 || len(f.Env) > 0) && f.Image == "" {
+		return &ValidateError{
+			Field:  field,
+			Reason: "`network`, `mounts` and `env` are only supported for functions with an `image`",
+		}
+	}
+	for i, m := range f.Mounts {
+		if err := m.validate(); err != nil {
+			return &ValidateError{
+				Field:  fmt.Sprintf("%s.mounts[%d]", field, i),
+				Reason: err.Error(),
+			}
+		}
+	}
+
+	for i, name := range f.Env {
+		// Values must not be part of the package, since they are often
+		// secrets.
+		if !envNameRegexp.MatchString(name) {
+			return &ValidateError{
+				Field:  fmt.Sprintf("%s.env[%d]", field, i),
+				Value:  name,
+				Reason: "must be the name of an environment variable, without a value",
+			}
+		}
+	}
+
 	if len(f.ConfigMap) != 0 && f.ConfigPath != "" {
 		return &ValidateError{
-			Field:  fmt.Sprintf("pipeline.%s[%d]", fnType, idx),
+			Field:  field,
 			Reason: "functionConfig must not specify both `configMap` and `configPath` at the same time",
 		}
 	}
 
+	if f.Propagate && f.ConfigPath != "" {
+		return &ValidateError{
+			Field:  field,
+			Reason: "`propagate` is not supported with `configPath`, use `configMap` instead",
+		}
+	}
+
 	if f.ConfigPath != "" {
 		if err := validateFnConfigPathSyntax(f.ConfigPath); err != nil {
 			return &ValidateError{
-				Field:  fmt.Sprintf("pipeline.%s[%d].configPath", fnType, idx),
+				Field:  field + ".configPath",
 				Value:  f.ConfigPath,
 				Reason: err.Error(),
 			}
 		}
 		if _, err := GetValidatedFnConfigFromPath(fsys, pkgPath, f.ConfigPath); err != nil {
 			return &ValidateError{
-				Field:  fmt.Sprintf("pipeline.%s[%d].configPath", fnType, idx),
+				Field:  field + ".configPath",
 				Value:  f.ConfigPath,
 				Reason: err.Error(),
 			}
@@ -117,6 +283,47 @@ func (f *Function) validate(fsys filesys.FileSystem, fnType string, idx int, pkg
 	return nil
 }
 
+// validateComposite validates a function that refers to a composite
+// function.
+func (f *Function) validateComposite(field string) error {
+	if f.Image != "" || f.Exec != "" || f.ConfigPath != "" || len(f.ConfigMap) > 0 ||
+		f.Network != nil || len(f.Mounts) > 0 || len(f.Env) > 0 {
+		return &ValidateError{
+			Field:  field,
+			Reason: "`composite` can only be combined with `name`, `selectors` and `exclude`",
+		}
+	}
+	if err := ValidateFunctionImageURL(f.Composite); err != nil {
+		return &ValidateError{
+			Field:  field + ".composite",
+			Value:  f.Composite,
+			Reason: err.Error(),
+		}
+	}
+	return nil
+}
+
+func (m *Mount) validate() error {
+	switch m.Type {
+	case BindMount:
+		// Mounting files outside the package would give functions access
+		// to any file on the package consumer's machine.
+		if err := validateFnConfigPathSyntax(m.Src); err != nil {
+			return fmt.Errorf("src %w", err)
+		}
+	case TmpfsMount:
+		if m.Src != "" {
+			return fmt.Errorf("src must not be specified for %s mounts", TmpfsMount)
+		}
+	default:
+		return fmt.Errorf("type must be %q or %q", BindMount, TmpfsMount)
+	}
+	if !path.IsAbs(m.Dst) {
+		return fmt.Errorf("dst must be an absolute path")
+	}
+	return nil
+}
+
 // ValidateFunctionImageURL validates the function name.
 // According to Docker implementation
 // https://github.com/docker/distribution/blob/master/reference/reference.go. A valid
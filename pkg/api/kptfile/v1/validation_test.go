@@ -25,6 +25,7 @@ import (
 )
 
 func TestKptfileValidate(t *testing.T) {
+	trueVal, falseVal := true, false
 	type input struct {
 		name    string
 		kptfile KptFile
@@ -63,6 +64,74 @@ func TestKptfileValidate(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "pipeline: valid dependencies",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Dependencies: []string{"../network", "./db"},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "pipeline: absolute dependency path",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Dependencies: []string{"/tmp/network"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: dependency on itself",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Dependencies: []string{"./"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: propagated function",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Name:      "set-labels",
+							Image:     "gcr.io/kpt-fn/set-labels:v0.1",
+							ConfigMap: map[string]string{"team": "infra"},
+							Propagate: true,
+						},
+					},
+					SkipInherited: []string{"set-namespace"},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "pipeline: propagated function with configPath",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Image:      "gcr.io/kpt-fn/set-labels:v0.1",
+							ConfigPath: "labels.yaml",
+							Propagate:  true,
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: empty skipInherited name",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					SkipInherited: []string{""},
+				},
+			},
+			valid: false,
+		},
 		{
 			name: "pipeline: invalid image name",
 			kptfile: KptFile{
@@ -149,6 +218,265 @@ func TestKptfileValidate(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "upstream: valid merge drivers",
+			kptfile: KptFile{
+				Upstream: &Upstream{
+					MergeDrivers: []MergeDriver{
+						{
+							Files:  "values.yaml",
+							Driver: OursMergeDriver,
+						},
+						{
+							Files:  "monitoring/*.rules.yaml",
+							Driver: FunctionMergeDriver,
+							Function: &Function{
+								Image: "gcr.io/kpt-fn/merge-rules:v0.1",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "upstream: unknown merge driver",
+			kptfile: KptFile{
+				Upstream: &Upstream{
+					MergeDrivers: []MergeDriver{
+						{
+							Files:  "values.yaml",
+							Driver: "union",
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "upstream: invalid merge driver glob",
+			kptfile: KptFile{
+				Upstream: &Upstream{
+					MergeDrivers: []MergeDriver{
+						{
+							Files:  "[values.yaml",
+							Driver: TheirsMergeDriver,
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: valid network and mounts",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Image:   "gcr.io/kpt-fn/set-labels",
+							Network: &trueVal,
+							Mounts: []Mount{
+								{Type: BindMount, Src: "data", Dst: "/data"},
+								{Type: TmpfsMount, Dst: "/tmp"},
+							},
+						},
+					},
+					Validators: []Function{
+						{
+							Image:   "gcr.io/kpt-fn/kubeval",
+							Network: &falseVal,
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "pipeline: network for exec function",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Exec:    "./fn",
+							Network: &falseVal,
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: bind mount outside of the package",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Image:  "gcr.io/kpt-fn/set-labels",
+							Mounts: []Mount{{Type: BindMount, Src: "../secrets", Dst: "/data"}},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: mount with relative dst",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Image:  "gcr.io/kpt-fn/set-labels",
+							Mounts: []Mount{{Type: TmpfsMount, Dst: "tmp"}},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: volume mount",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Image:  "gcr.io/kpt-fn/set-labels",
+							Mounts: []Mount{{Type: "volume", Src: "data", Dst: "/data"}},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: valid env",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Image: "gcr.io/kpt-fn/set-labels",
+							Env:   []string{"PRICING_API_TOKEN", "_DEBUG"},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "pipeline: env with value",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Image: "gcr.io/kpt-fn/set-labels",
+							Env:   []string{"PRICING_API_TOKEN=secret"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: env for exec function",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Exec: "./fn",
+							Env:  []string{"PRICING_API_TOKEN"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: composite",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Composite: "us-docker.pkg.dev/my-org/fns/org-standard:v1",
+							Selectors: []Selector{{Kind: "Deployment"}},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "pipeline: composite with image",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Mutators: []Function{
+						{
+							Composite: "us-docker.pkg.dev/my-org/fns/org-standard:v1",
+							Image:     "gcr.io/kpt-fn/set-labels",
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "pipeline: composite with config",
+			kptfile: KptFile{
+				Pipeline: &Pipeline{
+					Validators: []Function{
+						{
+							Composite: "us-docker.pkg.dev/my-org/fns/org-standard:v1",
+							ConfigMap: map[string]string{"foo": "bar"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "upstream: function merge driver without image",
+			kptfile: KptFile{
+				Upstream: &Upstream{
+					MergeDrivers: []MergeDriver{
+						{
+							Files:  "*.yaml",
+							Driver: FunctionMergeDriver,
+							Function: &Function{
+								Exec: "./merge.sh",
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "upstream: valid overrides",
+			kptfile: KptFile{
+				Upstream: &Upstream{
+					Overrides: []Function{
+						{
+							Image: "gcr.io/kpt-fn/set-namespace:v0.4",
+							ConfigMap: map[string]string{
+								"namespace": "staging",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "upstream: override without image",
+			kptfile: KptFile{
+				Upstream: &Upstream{
+					Overrides: []Function{
+						{
+							Exec: "./set-namespace.sh",
+						},
+					},
+				},
+			},
+			valid: false,
+		},
 	}
 
 	for _, c := range cases {
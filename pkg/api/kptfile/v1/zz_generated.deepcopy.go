@@ -44,6 +44,21 @@ func (in *Function) DeepCopyInto(out *Function) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]Mount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Function.
@@ -56,9 +71,34 @@ func (in *Function) DeepCopy() *Function {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mount) DeepCopyInto(out *Mount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mount.
+func (in *Mount) DeepCopy() *Mount {
+	if in == nil {
+		return nil
+	}
+	out := new(Mount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Pipeline) DeepCopyInto(out *Pipeline) {
 	*out = *in
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipInherited != nil {
+		in, out := &in.SkipInherited, &out.SkipInherited
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Mutators != nil {
 		in, out := &in.Mutators, &out.Mutators
 		*out = make([]Function, len(*in))
@@ -0,0 +1,85 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package defines Kptworkspace schema.
+// Version: v1alpha1
+// swagger:meta
+package v1alpha1
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	KptworkspaceFileName = "Kptworkspace"
+)
+
+// KptworkspaceGVK is the GroupVersionKind of Kptworkspace objects
+func KptworkspaceGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "kpt.dev",
+		Version: "v1alpha1",
+		Kind:    "Kptworkspace",
+	}
+}
+
+// Kptworkspace declares the packages of a repo that are operated on
+// together by the `kpt ws` commands.
+// swagger:model kptworkspace
+type Kptworkspace struct {
+	yaml.ResourceMeta `yaml:",inline" json:",inline"`
+
+	// Packages are the slash-delimited paths of the packages of the
+	// workspace, relative to the directory of the Kptworkspace. They must
+	// not be outside of that directory, and must not contain each other,
+	// since operations on a package include its subpackages.
+	Packages []string `yaml:"packages,omitempty" json:"packages,omitempty"`
+}
+
+// Validate returns an error if the Kptworkspace is invalid.
+func (w *Kptworkspace) Validate() error {
+	gvk := KptworkspaceGVK()
+	if w.APIVersion != gvk.GroupVersion().String() || w.Kind != gvk.Kind {
+		return fmt.Errorf("must have apiVersion %q and kind %q", gvk.GroupVersion().String(), gvk.Kind)
+	}
+	if len(w.Packages) == 0 {
+		return fmt.Errorf("packages must not be empty")
+	}
+	for i, p := range w.Packages {
+		if p == "" || path.IsAbs(p) || strings.Contains(p, "\\") {
+			return fmt.Errorf("packages[%d] %q must be a slash-delimited relative path", i, p)
+		}
+		if c := path.Clean(p); c == ".." || strings.HasPrefix(c, "../") {
+			return fmt.Errorf("packages[%d] %q must not be outside of the workspace", i, p)
+		}
+		for j, other := range w.Packages[:i] {
+			if containsPath(p, other) || containsPath(other, p) {
+				return fmt.Errorf("packages[%d] %q and packages[%d] %q must not contain each other", j, other, i, p)
+			}
+		}
+	}
+	return nil
+}
+
+// containsPath returns true if the slash-delimited path p is dir, or is
+// inside of it.
+func containsPath(dir, p string) bool {
+	dir, p = path.Clean(dir), path.Clean(p)
+	return dir == "." || p == dir || strings.HasPrefix(p, dir+"/")
+}
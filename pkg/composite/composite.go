@@ -0,0 +1,180 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package composite publishes and loads composite functions, ordered lists
+// of functions that are referenced as a single entry of a Kptfile pipeline.
+package composite
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleContainerTools/kpt/internal/util/httputil"
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/oci"
+	"github.com/google/go-containerregistry/pkg/gcrane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	APIVersion = "fn.kpt.dev/v1alpha1"
+	Kind       = "CompositeFunction"
+
+	// LayerMediaType is the media type of the layer that holds the
+	// CompositeFunction in the published image.
+	LayerMediaType types.MediaType = "application/vnd.kpt.composite-function.v1+yaml"
+)
+
+// CompositeFunction is an ordered list of functions that is published and
+// versioned as a single artifact.
+type CompositeFunction struct {
+	yaml.ResourceMeta `yaml:",inline" json:",inline"`
+
+	// Functions are the functions of the composite function, in the order
+	// they run.
+	Functions []kptfilev1.Function `yaml:"functions,omitempty" json:"functions,omitempty"`
+}
+
+// Parse parses and validates a CompositeFunction. The functions of a
+// composite function must be specified with `image`, and their config with
+// `configMap`, since the composite function can't refer to the files of the
+// packages it runs on.
+func Parse(b []byte) (*CompositeFunction, error) {
+	c := &CompositeFunction{}
+	if err := yaml.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("invalid composite function: %w", err)
+	}
+	if c.APIVersion != APIVersion || c.Kind != Kind {
+		return nil, fmt.Errorf("invalid composite function: must be of kind %s/%s, found %s/%s",
+			APIVersion, Kind, c.APIVersion, c.Kind)
+	}
+	if len(c.Functions) == 0 {
+		return nil, fmt.Errorf("invalid composite function %q: must have at least one function", c.Name)
+	}
+	for i, f := range c.Functions {
+		field := fmt.Sprintf("functions[%d]", i)
+		if f.Image == "" || f.Exec != "" || f.Composite != "" || f.ConfigPath != "" {
+			return nil, fmt.Errorf("invalid composite function %q: %s must be specified with `image` and an optional `configMap`",
+				c.Name, field)
+		}
+		if err := kptfilev1.ValidateFunctionImageURL(f.Image); err != nil {
+			return nil, fmt.Errorf("invalid composite function %q: %s: %w", c.Name, field, err)
+		}
+	}
+	return c, nil
+}
+
+// Client publishes and loads composite functions to and from OCI
+// registries. Loaded composite functions are cached.
+type Client struct {
+	*oci.Storage
+}
+
+func NewClient(cacheDir string) (*Client, error) {
+	store, err := oci.NewStorage(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Storage: store}, nil
+}
+
+// Push validates the composite function in the file and pushes it to the
+// registry as imageName. It returns the digest of the pushed image.
+func (c *Client) Push(ctx context.Context, file, imageName string) (string, error) {
+	tag, err := name.NewTag(imageName)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse tag %q: %w", imageName, err)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	if _, err := Parse(b); err != nil {
+		return "", err
+	}
+
+	img, err := mutate.AppendLayers(empty.Image, static.NewLayer(b, LayerMediaType))
+	if err != nil {
+		return "", fmt.Errorf("failed to append image layers: %w", err)
+	}
+	hash, err := img.Digest()
+	if err != nil {
+		return "", fmt.Errorf("failed to get digest of the image: %w", err)
+	}
+	if err := remote.Write(tag, img,
+		remote.WithAuthFromKeychain(gcrane.Keychain),
+		remote.WithContext(ctx),
+		remote.WithTransport(httputil.Transport()),
+	); err != nil {
+		return "", fmt.Errorf("failed to push image %s: %w", tag, err)
+	}
+	return hash.String(), nil
+}
+
+// Load returns the composite function published as imageName.
+func (c *Client) Load(ctx context.Context, imageName string) (*CompositeFunction, error) {
+	ref, err := name.ParseReference(imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher := func() (io.ReadCloser, error) {
+		if err := offline.Check(fmt.Sprintf("pulling composite function %q, which is not cached,", imageName)); err != nil {
+			return nil, err
+		}
+		img, err := remote.Image(ref,
+			remote.WithContext(ctx),
+			remote.WithAuthFromKeychain(gcrane.Keychain),
+			remote.WithTransport(httputil.Transport()),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get remote image: %w", err)
+		}
+		layers, err := img.Layers()
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range layers {
+			mt, err := l.MediaType()
+			if err != nil {
+				return nil, err
+			}
+			if mt == LayerMediaType {
+				return l.Uncompressed()
+			}
+		}
+		return nil, fmt.Errorf("image %q is not a composite function", imageName)
+	}
+
+	f, err := oci.WithCacheFile(filepath.Join(c.GetCacheDir(), "composite", ref.String()), fetcher)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orgStandard = `apiVersion: fn.kpt.dev/v1alpha1
+kind: CompositeFunction
+metadata:
+  name: org-standard
+functions:
+- image: gcr.io/kpt-fn/set-labels:v0.1.5
+  configMap:
+    owner: platform-team
+- image: gcr.io/kpt-fn/set-annotations:v0.1.4
+  configMap:
+    cost-center: "1234"
+`
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		input          string
+		expectedErrMsg string
+	}{
+		"valid": {
+			input: orgStandard,
+		},
+		"wrong kind": {
+			input:          "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n",
+			expectedErrMsg: "invalid composite function: must be of kind fn.kpt.dev/v1alpha1/CompositeFunction, found v1/ConfigMap",
+		},
+		"no functions": {
+			input:          "apiVersion: fn.kpt.dev/v1alpha1\nkind: CompositeFunction\nmetadata:\n  name: empty\n",
+			expectedErrMsg: `invalid composite function "empty": must have at least one function`,
+		},
+		"exec function": {
+			input: strings.Replace(orgStandard, "- image: gcr.io/kpt-fn/set-labels:v0.1.5", "- exec: set-labels", 1),
+			expectedErrMsg: `invalid composite function "org-standard": functions[0] must be specified with ` +
+				"`image` and an optional `configMap`",
+		},
+		"nested composite": {
+			input: orgStandard + "- composite: gcr.io/my-org/other:v1\n",
+			expectedErrMsg: `invalid composite function "org-standard": functions[2] must be specified with ` +
+				"`image` and an optional `configMap`",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			c, err := Parse([]byte(tc.input))
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "org-standard", c.Name)
+			assert.Equal(t, []kptfilev1.Function{
+				{Image: "gcr.io/kpt-fn/set-labels:v0.1.5", ConfigMap: map[string]string{"owner": "platform-team"}},
+				{Image: "gcr.io/kpt-fn/set-annotations:v0.1.4", ConfigMap: map[string]string{"cost-center": "1234"}},
+			}, c.Functions)
+		})
+	}
+}
+
+func TestPushLoad(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	image := strings.TrimPrefix(s.URL, "http://") + "/my-org/org-standard:v1"
+
+	file := filepath.Join(t.TempDir(), "org-standard.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(orgStandard), 0600))
+
+	client, err := NewClient(t.TempDir())
+	require.NoError(t, err)
+	digest, err := client.Push(context.Background(), file, image)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(digest, "sha256:"), digest)
+
+	c, err := client.Load(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, "org-standard", c.Name)
+	assert.Len(t, c.Functions, 2)
+
+	// the composite function is cached once loaded.
+	s.Close()
+	c, err = client.Load(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, "org-standard", c.Name)
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// AdmissionDryRunner sends obj to the cluster as a server-side dry-run
+// apply, so that it goes through the admission control of the cluster
+// without being persisted.
+type AdmissionDryRunner func(ctx context.Context, obj *unstructured.Unstructured) error
+
+// NewAdmissionDryRunner returns an AdmissionDryRunner that uses the
+// dynamic client and RESTMapper provided by the factory.
+func NewAdmissionDryRunner(factory util.Factory, fieldManager string) (AdmissionDryRunner, error) {
+	dc, err := factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, obj *unstructured.Unstructured) error {
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return err
+		}
+		var ri dynamic.ResourceInterface = dc.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			ri = dc.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+		}
+		data, err := json.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		force := true
+		_, err = ri.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+			DryRun:       []string{metav1.DryRunAll},
+			Force:        &force,
+			FieldManager: fieldManager,
+		})
+		return err
+	}, nil
+}
+
+// AdmissionViolation describes a resource that the admission control of
+// the cluster would deny.
+type AdmissionViolation struct {
+	ID object.ObjMetadata
+	// Policy names the ValidatingAdmissionPolicy or webhook that denied
+	// the resource. It is empty if the denial can't be attributed.
+	Policy string
+	// Message is the message of the denial.
+	Message string
+}
+
+func (v AdmissionViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.ID, v.Message)
+}
+
+var (
+	admissionPolicyRegexp  = regexp.MustCompile(`ValidatingAdmissionPolicy '([^']+)'`)
+	admissionWebhookRegexp = regexp.MustCompile(`admission webhook "([^"]+)"`)
+)
+
+// deniedBy returns the ValidatingAdmissionPolicy or webhook named in the
+// message of a denial.
+func deniedBy(msg string) string {
+	if m := admissionPolicyRegexp.FindStringSubmatch(msg); m != nil {
+		return fmt.Sprintf("ValidatingAdmissionPolicy %q", m[1])
+	}
+	if m := admissionWebhookRegexp.FindStringSubmatch(msg); m != nil {
+		return fmt.Sprintf("webhook %q", m[1])
+	}
+	return ""
+}
+
+// CheckAdmission runs a server-side dry-run of every object in objs, and
+// returns the objects that are denied by the ValidatingAdmissionPolicies
+// and admission webhooks of the cluster. All objects are checked, so the
+// violations of the whole package are found at once. Objects that can't be
+// dry-run before the apply are skipped: those in a namespace or of a kind
+// that is created by the package, and those of kinds unknown to the
+// cluster.
+func CheckAdmission(ctx context.Context, dryRun AdmissionDryRunner, objs []*unstructured.Unstructured) ([]AdmissionViolation, error) {
+	packageCRDs := map[schema.GroupKind]bool{}
+	packageNamespaces := map[string]bool{}
+	for _, obj := range objs {
+		if gk, found := object.GetCRDGroupKind(obj); found {
+			packageCRDs[gk] = true
+		}
+		if object.IsKindNamespace(obj) {
+			packageNamespaces[obj.GetName()] = true
+		}
+	}
+
+	var violations []AdmissionViolation
+	for _, obj := range objs {
+		if packageCRDs[obj.GroupVersionKind().GroupKind()] || packageNamespaces[obj.GetNamespace()] {
+			continue
+		}
+		err := dryRun(ctx, obj)
+		switch {
+		case err == nil, meta.IsNoMatchError(err), apierrors.IsNotFound(err):
+			continue
+		case apierrors.IsInvalid(err), apierrors.IsForbidden(err), apierrors.IsBadRequest(err):
+			msg := err.Error()
+			violations = append(violations, AdmissionViolation{
+				ID:      object.UnstructuredToObjMetadata(obj),
+				Policy:  deniedBy(msg),
+				Message: msg,
+			})
+		default:
+			return nil, err
+		}
+	}
+	return violations, nil
+}
+
+// AdmissionError is returned when resources would be denied by the
+// admission control of the cluster in strict preflight mode.
+type AdmissionError struct {
+	Violations []AdmissionViolation
+}
+
+func (e *AdmissionError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d resource(s) would be denied by the admission control of the cluster:\n", len(e.Violations))
+	for _, group := range GroupAdmissionViolations(e.Violations) {
+		fmt.Fprintf(&b, "  %s:\n", group.Policy)
+		for _, v := range group.Violations {
+			fmt.Fprintf(&b, "    %s\n", v)
+		}
+	}
+	b.WriteString("No resources were applied. Fix the resources, or re-run with --preflight=warn " +
+		"to apply them anyway.")
+	return b.String()
+}
+
+// AdmissionViolationGroup are the violations of a single policy.
+type AdmissionViolationGroup struct {
+	Policy     string
+	Violations []AdmissionViolation
+}
+
+// GroupAdmissionViolations groups violations by the policy that denied
+// them, sorted by policy. Violations that can't be attributed to a policy
+// are grouped last, as "other".
+func GroupAdmissionViolations(violations []AdmissionViolation) []AdmissionViolationGroup {
+	byPolicy := map[string][]AdmissionViolation{}
+	for _, v := range violations {
+		byPolicy[v.Policy] = append(byPolicy[v.Policy], v)
+	}
+	var policies []string
+	for p := range byPolicy {
+		if p != "" {
+			policies = append(policies, p)
+		}
+	}
+	sort.Strings(policies)
+	var groups []AdmissionViolationGroup
+	for _, p := range policies {
+		groups = append(groups, AdmissionViolationGroup{Policy: p, Violations: byPolicy[p]})
+	}
+	if other, found := byPolicy[""]; found {
+		groups = append(groups, AdmissionViolationGroup{Policy: "other", Violations: other})
+	}
+	return groups
+}
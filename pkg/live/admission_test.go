@@ -0,0 +1,150 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	policyDenial = "configmaps \"b\" is forbidden: ValidatingAdmissionPolicy 'require-team' " +
+		"with binding 'require-team-binding' denied request: missing label team"
+	webhookDenial = "admission webhook \"validate.example.com\" denied the request: data is required"
+)
+
+func statusError(code int32, reason metav1.StatusReason, msg string) error {
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    code,
+		Reason:  reason,
+		Message: msg,
+	}}
+}
+
+func TestCheckAdmission(t *testing.T) {
+	obj := func(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+	crd := obj("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "widgets.example.com")
+	crd.Object["spec"] = map[string]interface{}{
+		"group": "example.com",
+		"names": map[string]interface{}{"kind": "Widget"},
+	}
+	// The dry-run denies the objects by name.
+	denials := map[string]error{
+		"b":     statusError(http.StatusUnprocessableEntity, metav1.StatusReasonInvalid, policyDenial),
+		"c":     statusError(http.StatusForbidden, metav1.StatusReasonForbidden, webhookDenial),
+		"d":     statusError(http.StatusForbidden, metav1.StatusReasonForbidden, "user cannot patch configmaps"),
+		"new":   statusError(http.StatusNotFound, metav1.StatusReasonNotFound, "namespace not found"),
+		"fails": fmt.Errorf("connection refused"),
+	}
+	var dryRun []string
+	dryRunner := func(_ context.Context, obj *unstructured.Unstructured) error {
+		dryRun = append(dryRun, obj.GetName())
+		return denials[obj.GetName()]
+	}
+	configMapID := func(name string) object.ObjMetadata {
+		return object.ObjMetadata{Namespace: "ns", Name: name, GroupKind: schema.GroupKind{Kind: "ConfigMap"}}
+	}
+
+	testCases := map[string]struct {
+		objs []*unstructured.Unstructured
+
+		expectedDryRun     []string
+		expectedViolations []AdmissionViolation
+		expectedErrMsg     string
+	}{
+		"all resources are checked": {
+			objs: []*unstructured.Unstructured{
+				obj("v1", "ConfigMap", "ns", "a"),
+				obj("v1", "ConfigMap", "ns", "b"),
+				obj("v1", "ConfigMap", "ns", "c"),
+				obj("v1", "ConfigMap", "ns", "d"),
+				obj("v1", "ConfigMap", "ns", "new"),
+			},
+			expectedDryRun: []string{"a", "b", "c", "d", "new"},
+			expectedViolations: []AdmissionViolation{
+				{ID: configMapID("b"), Policy: `ValidatingAdmissionPolicy "require-team"`, Message: policyDenial},
+				{ID: configMapID("c"), Policy: `webhook "validate.example.com"`, Message: webhookDenial},
+				{ID: configMapID("d"), Message: "user cannot patch configmaps"},
+			},
+		},
+		"resources created by the package are skipped": {
+			objs: []*unstructured.Unstructured{
+				obj("v1", "Namespace", "", "other"),
+				obj("v1", "ConfigMap", "other", "b"),
+				crd,
+				obj("example.com/v1", "Widget", "ns", "c"),
+			},
+			expectedDryRun: []string{"other", "widgets.example.com"},
+		},
+		"dry-run fails": {
+			objs:           []*unstructured.Unstructured{obj("v1", "ConfigMap", "ns", "fails")},
+			expectedDryRun: []string{"fails"},
+			expectedErrMsg: "connection refused",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			dryRun = nil
+			violations, err := CheckAdmission(context.Background(), dryRunner, tc.objs)
+			assert.Equal(t, tc.expectedDryRun, dryRun)
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedViolations, violations)
+		})
+	}
+}
+
+func TestAdmissionError(t *testing.T) {
+	id := func(name string) object.ObjMetadata {
+		return object.ObjMetadata{Namespace: "ns", Name: name, GroupKind: schema.GroupKind{Kind: "ConfigMap"}}
+	}
+	err := &AdmissionError{Violations: []AdmissionViolation{
+		{ID: id("a"), Message: "user cannot patch configmaps"},
+		{ID: id("b"), Policy: `webhook "validate.example.com"`, Message: webhookDenial},
+		{ID: id("c"), Policy: `ValidatingAdmissionPolicy "require-team"`, Message: policyDenial},
+		{ID: id("d"), Policy: `webhook "validate.example.com"`, Message: webhookDenial},
+	}}
+	assert.Equal(t, `4 resource(s) would be denied by the admission control of the cluster:
+  ValidatingAdmissionPolicy "require-team":
+    ns_c__ConfigMap: `+policyDenial+`
+  webhook "validate.example.com":
+    ns_b__ConfigMap: `+webhookDenial+`
+    ns_d__ConfigMap: `+webhookDenial+`
+  other:
+    ns_a__ConfigMap: user cannot patch configmaps
+No resources were applied. Fix the resources, or re-run with --preflight=warn to apply them anyway.`, err.Error())
+}
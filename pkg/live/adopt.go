@@ -0,0 +1,138 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// AdoptRule matches the unmanaged objects adopted by
+// `kpt live apply --adopt`. The fields are path.Match patterns, and an
+// empty field matches any value.
+type AdoptRule struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// ParseAdoptRule parses a rule of the form
+// "kind=Deployment,namespace=prod,name=web-*". The keys are group, kind,
+// namespace and name.
+func ParseAdoptRule(s string) (AdoptRule, error) {
+	var rule AdoptRule
+	for _, kv := range strings.Split(s, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(kv), "=")
+		if !found {
+			return AdoptRule{}, fmt.Errorf("invalid adopt rule %q: %q must be of the form key=value", s, kv)
+		}
+		if _, err := path.Match(value, ""); err != nil {
+			return AdoptRule{}, fmt.Errorf("invalid adopt rule %q: invalid pattern %q", s, value)
+		}
+		switch key {
+		case "group":
+			rule.Group = value
+		case "kind":
+			rule.Kind = value
+		case "namespace":
+			rule.Namespace = value
+		case "name":
+			rule.Name = value
+		default:
+			return AdoptRule{}, fmt.Errorf("invalid adopt rule %q: unknown key %q, must be one of group, kind, namespace or name", s, key)
+		}
+	}
+	return rule, nil
+}
+
+// Matches returns true if the rule matches the object id.
+func (r AdoptRule) Matches(id object.ObjMetadata) bool {
+	return matchPattern(r.Group, id.GroupKind.Group) &&
+		matchPattern(r.Kind, id.GroupKind.Kind) &&
+		matchPattern(r.Namespace, id.Namespace) &&
+		matchPattern(r.Name, id.Name)
+}
+
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	matched, _ := path.Match(pattern, value)
+	return matched
+}
+
+// FindUnmanaged returns the objects of objs that exist in the cluster, but
+// aren't managed by any inventory, since their live state has no
+// owning-inventory annotation.
+func FindUnmanaged(ctx context.Context, getLive LiveObjectGetter, inv inventory.Info,
+	objs []*unstructured.Unstructured) (object.ObjMetadataSet, error) {
+	var unmanaged object.ObjMetadataSet
+	for _, id := range object.UnstructuredSetToObjMetadataSet(objs) {
+		live, err := getLive(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if live != nil && inventory.IDMatch(inv, live) == inventory.Empty {
+			unmanaged = append(unmanaged, id)
+		}
+	}
+	return unmanaged, nil
+}
+
+// SelectAdopted splits the unmanaged objects into the objects matched by
+// any of rules, which are adopted, and the rest. All objects are adopted
+// if there are no rules.
+func SelectAdopted(unmanaged object.ObjMetadataSet, rules []AdoptRule) (adopted, rest object.ObjMetadataSet) {
+	for _, id := range unmanaged {
+		matched := len(rules) == 0
+		for _, rule := range rules {
+			if rule.Matches(id) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			adopted = append(adopted, id)
+		} else {
+			rest = append(rest, id)
+		}
+	}
+	return adopted, rest
+}
+
+// UnmanagedObjectsError is returned when objects that would be applied
+// already exist in the cluster without being managed by an inventory, and
+// aren't matched by the adopt rules.
+type UnmanagedObjectsError struct {
+	IDs object.ObjMetadataSet
+}
+
+func (e *UnmanagedObjectsError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d object(s) exist in the cluster without being managed by an inventory and don't match an --adopt-match rule:\n",
+		len(e.IDs))
+	for _, id := range e.IDs {
+		fmt.Fprintf(&b, "  %s\n", id)
+	}
+	b.WriteString("Add an --adopt-match rule matching the objects to adopt them.")
+	return b.String()
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestParseAdoptRule(t *testing.T) {
+	tests := map[string]struct {
+		rule           string
+		expected       AdoptRule
+		expectedErrMsg string
+	}{
+		"all keys": {
+			rule:     "group=apps, kind=Deployment,namespace=prod,name=web-*",
+			expected: AdoptRule{Group: "apps", Kind: "Deployment", Namespace: "prod", Name: "web-*"},
+		},
+		"missing value": {
+			rule:           "kind",
+			expectedErrMsg: `invalid adopt rule "kind": "kind" must be of the form key=value`,
+		},
+		"unknown key": {
+			rule:           "label=foo",
+			expectedErrMsg: `unknown key "label"`,
+		},
+		"invalid pattern": {
+			rule:           "name=[",
+			expectedErrMsg: `invalid pattern "["`,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			rule, err := ParseAdoptRule(tc.rule)
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rule)
+		})
+	}
+}
+
+func TestFindUnmanaged(t *testing.T) {
+	inv := WrapInventoryInfoObj(inventoryObj)
+	owners := map[object.ObjMetadata]string{
+		testPod:        "",
+		testDeployment: testInventoryLabel,
+		testService:    "other-id",
+	}
+	getLive := func(_ context.Context, id object.ObjMetadata) (*unstructured.Unstructured, error) {
+		owner, found := owners[id]
+		if !found {
+			return nil, nil
+		}
+		return liveObj(id, owner), nil
+	}
+	var objs []*unstructured.Unstructured
+	for _, id := range []object.ObjMetadata{testPod, testDeployment, testService, {Name: "missing", GroupKind: testPod.GroupKind}} {
+		objs = append(objs, liveObj(id, ""))
+	}
+
+	unmanaged, err := FindUnmanaged(context.Background(), getLive, inv, objs)
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{testPod}, unmanaged)
+}
+
+func TestSelectAdopted(t *testing.T) {
+	unmanaged := object.ObjMetadataSet{testPod, testDeployment}
+
+	adopted, rest := SelectAdopted(unmanaged, nil)
+	assert.Equal(t, unmanaged, adopted)
+	assert.Empty(t, rest)
+
+	adopted, rest = SelectAdopted(unmanaged, []AdoptRule{{Kind: "Deploy*"}, {Group: "apps", Name: "other"}})
+	assert.Equal(t, object.ObjMetadataSet{testDeployment}, adopted)
+	assert.Equal(t, object.ObjMetadataSet{testPod}, rest)
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"fmt"
+	"strings"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// FieldManagerAnnotation sets the field manager of the server-side
+	// apply of a package. It is set on the inventory in the Kptfile.
+	FieldManagerAnnotation = "kpt.dev/field-manager"
+	// ConflictPolicyAnnotation sets what the server-side apply of a
+	// package does when a field is owned by another field manager. It is
+	// set on the inventory in the Kptfile.
+	ConflictPolicyAnnotation = "kpt.dev/apply-conflict-policy"
+	// IgnoredFieldsAnnotation lists the fields of an object that are not
+	// applied, so they can be owned by other field managers, e.g. the
+	// replicas of a Deployment that is scaled by a HorizontalPodAutoscaler.
+	// The value is a comma-separated list of dot-separated field paths,
+	// e.g. "spec.replicas".
+	IgnoredFieldsAnnotation = "kpt.dev/apply-ignored-fields"
+)
+
+// ConflictPolicy determines what the server-side apply does when a field is
+// owned by another field manager.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyFail fails the apply of the object.
+	ConflictPolicyFail ConflictPolicy = "fail"
+	// ConflictPolicyForce takes the ownership of the field and overwrites it.
+	ConflictPolicyForce ConflictPolicy = "force"
+)
+
+// ServerSidePolicy is the field manager and conflict policy of the
+// server-side apply of a package.
+type ServerSidePolicy struct {
+	// FieldManager is the field manager, or empty if not set.
+	FieldManager string
+	// ConflictPolicy is the conflict policy, or empty if not set.
+	ConflictPolicy ConflictPolicy
+}
+
+// ReadServerSidePolicy reads the server-side apply policy from the
+// annotations of the inventory, i.e. the inventory section of the Kptfile.
+// A single apply uses the same field manager and conflict policy for all
+// objects, so they can't be set on the objects.
+func ReadServerSidePolicy(inv kptfilev1.Inventory, objs []*unstructured.Unstructured) (ServerSidePolicy, error) {
+	for _, obj := range objs {
+		for _, a := range []string{FieldManagerAnnotation, ConflictPolicyAnnotation} {
+			if _, found := obj.GetAnnotations()[a]; found {
+				return ServerSidePolicy{}, fmt.Errorf("%s: the %s annotation can only be set on the inventory in the Kptfile",
+					object.UnstructuredToObjMetadata(obj), a)
+			}
+		}
+	}
+	p := ServerSidePolicy{
+		FieldManager:   inv.Annotations[FieldManagerAnnotation],
+		ConflictPolicy: ConflictPolicy(inv.Annotations[ConflictPolicyAnnotation]),
+	}
+	if v, found := inv.Annotations[FieldManagerAnnotation]; found && strings.TrimSpace(v) == "" {
+		return p, fmt.Errorf("inventory: invalid %s annotation: must not be empty", FieldManagerAnnotation)
+	}
+	switch p.ConflictPolicy {
+	case "", ConflictPolicyFail, ConflictPolicyForce:
+	default:
+		return p, fmt.Errorf("inventory: invalid %s annotation %q: must be one of %s, %s",
+			ConflictPolicyAnnotation, p.ConflictPolicy, ConflictPolicyFail, ConflictPolicyForce)
+	}
+	return p, nil
+}
+
+// Merge returns opts with the field manager and conflict policy set from
+// the policy. Options set explicitly with flags take precedence over the
+// policy, so fieldManagerSet and forceConflictsSet report whether the
+// --field-manager and --force-conflicts flags were set.
+func (p ServerSidePolicy) Merge(opts common.ServerSideOptions, fieldManagerSet, forceConflictsSet bool) common.ServerSideOptions {
+	if p.FieldManager != "" && !fieldManagerSet {
+		opts.FieldManager = p.FieldManager
+	}
+	if p.ConflictPolicy != "" && !forceConflictsSet {
+		opts.ForceConflicts = p.ConflictPolicy == ConflictPolicyForce
+	}
+	return opts
+}
+
+// RemoveIgnoredFields removes the fields listed in the IgnoredFieldsAnnotation
+// of objs from them. Ignored fields are only supported for server-side
+// apply, since a client-side apply deletes the fields that were applied
+// before from the cluster once they are removed.
+func RemoveIgnoredFields(objs []*unstructured.Unstructured, serverSide bool) error {
+	for _, obj := range objs {
+		v, found := obj.GetAnnotations()[IgnoredFieldsAnnotation]
+		if !found {
+			continue
+		}
+		id := object.UnstructuredToObjMetadata(obj)
+		if !serverSide {
+			return fmt.Errorf("%s: the %s annotation requires --server-side", id, IgnoredFieldsAnnotation)
+		}
+		for _, f := range strings.Split(v, ",") {
+			path := strings.Split(strings.TrimSpace(f), ".")
+			if err := validateIgnoredField(path); err != nil {
+				return fmt.Errorf("%s: invalid %s annotation %q: %w", id, IgnoredFieldsAnnotation, v, err)
+			}
+			unstructured.RemoveNestedField(obj.Object, path...)
+		}
+	}
+	return nil
+}
+
+func validateIgnoredField(path []string) error {
+	for _, p := range path {
+		if p == "" {
+			return fmt.Errorf("fields must be dot-separated paths, e.g. spec.replicas")
+		}
+	}
+	switch path[0] {
+	case "apiVersion", "kind", "metadata":
+		return fmt.Errorf("field %q can't be ignored", strings.Join(path, "."))
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"testing"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
+func TestReadServerSidePolicy(t *testing.T) {
+	tests := map[string]struct {
+		inv      kptfilev1.Inventory
+		objs     []*unstructured.Unstructured
+		expected ServerSidePolicy
+		errMsg   string
+	}{
+		"no annotations": {
+			objs: []*unstructured.Unstructured{liveObj(testDeployment, "")},
+		},
+		"inventory annotations": {
+			inv: kptfilev1.Inventory{
+				Annotations: map[string]string{
+					FieldManagerAnnotation:   "platform-team",
+					ConflictPolicyAnnotation: "force",
+				},
+			},
+			expected: ServerSidePolicy{FieldManager: "platform-team", ConflictPolicy: ConflictPolicyForce},
+		},
+		"empty field manager": {
+			inv: kptfilev1.Inventory{
+				Annotations: map[string]string{FieldManagerAnnotation: " "},
+			},
+			errMsg: "inventory: invalid kpt.dev/field-manager annotation: must not be empty",
+		},
+		"invalid conflict policy": {
+			inv: kptfilev1.Inventory{
+				Annotations: map[string]string{ConflictPolicyAnnotation: "ignore"},
+			},
+			errMsg: `invalid kpt.dev/apply-conflict-policy annotation "ignore": must be one of fail, force`,
+		},
+		"object annotation": {
+			objs: []*unstructured.Unstructured{func() *unstructured.Unstructured {
+				u := liveObj(testDeployment, "")
+				u.SetAnnotations(map[string]string{ConflictPolicyAnnotation: "force"})
+				return u
+			}()},
+			errMsg: "the kpt.dev/apply-conflict-policy annotation can only be set on the inventory in the Kptfile",
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			policy, err := ReadServerSidePolicy(tc.inv, tc.objs)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, policy)
+		})
+	}
+}
+
+func TestServerSidePolicy_Merge(t *testing.T) {
+	opts := common.ServerSideOptions{ServerSideApply: true, FieldManager: common.DefaultFieldManager}
+	policy := ServerSidePolicy{FieldManager: "platform-team", ConflictPolicy: ConflictPolicyForce}
+
+	assert.Equal(t, common.ServerSideOptions{
+		ServerSideApply: true,
+		ForceConflicts:  true,
+		FieldManager:    "platform-team",
+	}, policy.Merge(opts, false, false))
+
+	// Flags take precedence over the policy.
+	assert.Equal(t, opts, policy.Merge(opts, true, true))
+	assert.Equal(t, opts, ServerSidePolicy{}.Merge(opts, false, false))
+}
+
+func TestRemoveIgnoredFields(t *testing.T) {
+	deployment := func(ignored string) *unstructured.Unstructured {
+		u := liveObj(testDeployment, "")
+		u.SetAnnotations(map[string]string{IgnoredFieldsAnnotation: ignored})
+		u.Object["spec"] = map[string]interface{}{
+			"replicas": int64(3),
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"app": "foo"},
+				},
+			},
+		}
+		return u
+	}
+
+	tests := map[string]struct {
+		obj          *unstructured.Unstructured
+		clientSide   bool
+		expectedSpec map[string]interface{}
+		errMsg       string
+	}{
+		"removes fields": {
+			obj: deployment("spec.replicas, spec.template.metadata.labels, spec.missing"),
+			expectedSpec: map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{},
+				},
+			},
+		},
+		"requires server-side apply": {
+			obj:        deployment("spec.replicas"),
+			clientSide: true,
+			errMsg:     "the kpt.dev/apply-ignored-fields annotation requires --server-side",
+		},
+		"metadata can't be ignored": {
+			obj:    deployment("metadata.labels"),
+			errMsg: `field "metadata.labels" can't be ignored`,
+		},
+		"empty path element": {
+			obj:    deployment("spec..replicas"),
+			errMsg: "fields must be dot-separated paths",
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			err := RemoveIgnoredFields([]*unstructured.Unstructured{tc.obj}, !tc.clientSide)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSpec, tc.obj.Object["spec"])
+		})
+	}
+}
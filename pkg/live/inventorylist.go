@@ -0,0 +1,240 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
+const (
+	// LastAppliedTimeAnnotation records when the inventory was last
+	// updated by an apply or destroy. The value is an RFC 3339 timestamp.
+	LastAppliedTimeAnnotation = "kpt.dev/last-applied-time"
+	// InventoryTTLAnnotation sets how long an inventory may go without
+	// being applied before it is considered abandoned. The value is a Go
+	// duration, e.g. "720h", or a number of days, e.g. "30d".
+	InventoryTTLAnnotation = "kpt.dev/inventory-ttl"
+	// OwnerAnnotation names the team or person responsible for the
+	// package of an inventory.
+	OwnerAnnotation = "kpt.dev/owner"
+)
+
+// ParseTTL parses an inventory TTL, which is either a Go duration or a
+// number of days with the "d" suffix.
+func ParseTTL(s string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(s, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid TTL %q: must be a non-negative number of days or duration", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid TTL %q: must be a non-negative number of days or duration", s)
+	}
+	return d, nil
+}
+
+// InventorySummary describes a ResourceGroup inventory in the cluster.
+type InventorySummary struct {
+	Name      string
+	Namespace string
+	// ID is the inventory id from the inventory label.
+	ID string
+	// Resources is the number of resources in the inventory.
+	Resources int
+	// Created is the creation time of the inventory.
+	Created time.Time
+	// LastApplied is the time the inventory was last applied. It is zero
+	// if the inventory was last applied by a kpt version that didn't
+	// record it.
+	LastApplied time.Time
+	// LastUpdated is the latest time a field manager updated the inventory,
+	// from its managedFields. It is zero if the server didn't record it.
+	LastUpdated time.Time
+	// Owner is the value of the owner annotation.
+	Owner string
+	// TTL is the value of the TTL annotation. Zero means no TTL is set.
+	TTL time.Duration
+	// Invalid is the error in the inventory, e.g. a malformed annotation.
+	// Invalid inventories are never abandoned.
+	Invalid error
+}
+
+// NewInventorySummary summarizes the ResourceGroup obj.
+// If the inventory is invalid, the error is returned along with the parts
+// of the summary that could be read.
+func NewInventorySummary(obj *unstructured.Unstructured) (InventorySummary, error) {
+	annotations := obj.GetAnnotations()
+	s := InventorySummary{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		ID:        obj.GetLabels()[common.InventoryLabel],
+		Created:   obj.GetCreationTimestamp().UTC(),
+		Owner:     annotations[OwnerAnnotation],
+	}
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Time != nil && mf.Time.UTC().After(s.LastUpdated) {
+			s.LastUpdated = mf.Time.UTC()
+		}
+	}
+	resources, _, err := unstructured.NestedSlice(obj.Object, "spec", "resources")
+	if err != nil {
+		return s, err
+	}
+	s.Resources = len(resources)
+	if v, found := annotations[LastAppliedTimeAnnotation]; found {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return s, fmt.Errorf("invalid %s annotation %q: %w", LastAppliedTimeAnnotation, v, err)
+		}
+		s.LastApplied = t
+	}
+	if v, found := annotations[InventoryTTLAnnotation]; found {
+		ttl, err := ParseTTL(v)
+		if err != nil {
+			return s, fmt.Errorf("invalid %s annotation: %w", InventoryTTLAnnotation, err)
+		}
+		s.TTL = ttl
+	}
+	return s, nil
+}
+
+// LastActivity returns the time the inventory was last applied, or the
+// time it was last updated if that is not known. The creation time is never
+// used, since an old inventory may still be applied regularly by a kpt
+// version that doesn't record the last apply. It is zero if the last
+// activity is unknown.
+func (s InventorySummary) LastActivity() time.Time {
+	if s.LastApplied.IsZero() {
+		return s.LastUpdated
+	}
+	return s.LastApplied
+}
+
+// Abandoned returns true if the inventory hasn't been applied for longer
+// than its TTL. The TTL annotation of the inventory takes precedence over
+// defaultTTL. An inventory without a TTL, an invalid inventory, and an
+// inventory with an unknown last activity are never abandoned.
+func (s InventorySummary) Abandoned(now time.Time, defaultTTL time.Duration) bool {
+	if s.Invalid != nil || s.LastActivity().IsZero() {
+		return false
+	}
+	ttl := s.TTL
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+	return ttl > 0 && now.Sub(s.LastActivity()) > ttl
+}
+
+// ListInventories returns the ResourceGroup inventories in namespace, or
+// in all namespaces if namespace is empty, sorted by namespace and name.
+// Invalid inventories are returned with the Invalid error set, so a single
+// malformed inventory doesn't fail the whole list.
+func ListInventories(ctx context.Context, factory util.Factory, namespace string) ([]InventorySummary, error) {
+	ri, err := resourceGroupClient(factory, namespace)
+	if err != nil {
+		return nil, err
+	}
+	list, err := ri.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var summaries []InventorySummary
+	for i := range list.Items {
+		s, err := NewInventorySummary(&list.Items[i])
+		if err != nil {
+			s.Invalid = err
+		}
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries, nil
+}
+
+// FindInventory returns the ResourceGroup inventory with the inventory id
+// in namespace, or in all namespaces if namespace is empty. It is an error
+// if there is no such inventory, or more than one.
+func FindInventory(ctx context.Context, factory util.Factory, namespace, id string) (*unstructured.Unstructured, error) {
+	ri, err := resourceGroupClient(factory, namespace)
+	if err != nil {
+		return nil, err
+	}
+	list, err := ri.List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", common.InventoryLabel, id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(list.Items) {
+	case 0:
+		return nil, fmt.Errorf("no inventory found with inventory id %q", id)
+	case 1:
+		return &list.Items[0], nil
+	default:
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.GetNamespace()+"/"+item.GetName())
+		}
+		return nil, fmt.Errorf("found %d inventories with inventory id %q: %s",
+			len(list.Items), id, strings.Join(names, ", "))
+	}
+}
+
+// DeleteInventory deletes the ResourceGroup of the inventory s. The
+// resources in the inventory are orphaned: they are left in the cluster.
+func DeleteInventory(ctx context.Context, factory util.Factory, s InventorySummary) error {
+	ri, err := resourceGroupClient(factory, s.Namespace)
+	if err != nil {
+		return err
+	}
+	return ri.Delete(ctx, s.Name, metav1.DeleteOptions{})
+}
+
+func resourceGroupClient(factory util.Factory, namespace string) (dynamic.ResourceInterface, error) {
+	dc, err := factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := mapper.RESTMapping(ResourceGroupGVK.GroupKind(), ResourceGroupGVK.Version)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return dc.Resource(mapping.Resource), nil
+	}
+	return dc.Resource(mapping.Resource).Namespace(namespace), nil
+}
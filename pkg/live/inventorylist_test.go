@@ -0,0 +1,148 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
+func TestParseTTL(t *testing.T) {
+	testCases := map[string]struct {
+		ttl      string
+		expected time.Duration
+		isError  bool
+	}{
+		"days":              {ttl: "30d", expected: 30 * 24 * time.Hour},
+		"duration":          {ttl: "12h30m", expected: 12*time.Hour + 30*time.Minute},
+		"zero":              {ttl: "0d"},
+		"negative days":     {ttl: "-1d", isError: true},
+		"negative duration": {ttl: "-1h", isError: true},
+		"fractional days":   {ttl: "1.5d", isError: true},
+		"invalid":           {ttl: "soon", isError: true},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ttl, err := ParseTTL(tc.ttl)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ttl)
+		})
+	}
+}
+
+func TestNewInventorySummary(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "kpt.dev/v1alpha1",
+			"kind":       "ResourceGroup",
+			"metadata": map[string]interface{}{
+				"name":              "inventory-obj",
+				"namespace":         "test-ns",
+				"creationTimestamp": "2026-01-01T00:00:00Z",
+				"managedFields": []interface{}{
+					map[string]interface{}{"manager": "kpt", "operation": "Update", "time": "2026-01-20T00:00:00Z"},
+					map[string]interface{}{"manager": "kubectl", "operation": "Update", "time": "2026-02-02T00:00:00Z"},
+				},
+				"labels": map[string]interface{}{
+					common.InventoryLabel: "inv-id",
+				},
+				"annotations": map[string]interface{}{
+					LastAppliedTimeAnnotation: "2026-02-01T12:00:00Z",
+					InventoryTTLAnnotation:    "7d",
+					OwnerAnnotation:           "team-a",
+				},
+			},
+			"spec": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{"kind": "ConfigMap", "name": "a", "namespace": "test-ns"},
+					map[string]interface{}{"kind": "ConfigMap", "name": "b", "namespace": "test-ns"},
+				},
+			},
+		},
+	}
+	s, err := NewInventorySummary(obj)
+	require.NoError(t, err)
+	assert.Equal(t, InventorySummary{
+		Name:        "inventory-obj",
+		Namespace:   "test-ns",
+		ID:          "inv-id",
+		Resources:   2,
+		Created:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		LastApplied: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC),
+		LastUpdated: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC),
+		Owner:       "team-a",
+		TTL:         7 * 24 * time.Hour,
+	}, s)
+
+	obj.SetAnnotations(map[string]string{InventoryTTLAnnotation: "forever"})
+	s, err = NewInventorySummary(obj)
+	assert.ErrorContains(t, err, "invalid kpt.dev/inventory-ttl annotation")
+	assert.Equal(t, "inventory-obj", s.Name)
+}
+
+func TestInventorySummary_Abandoned(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	created := now.Add(-60 * 24 * time.Hour)
+	testCases := map[string]struct {
+		summary    InventorySummary
+		defaultTTL time.Duration
+		expected   bool
+	}{
+		"no ttl": {
+			summary: InventorySummary{Created: created},
+		},
+		"applied within default ttl": {
+			summary:    InventorySummary{Created: created, LastApplied: now.Add(-time.Hour)},
+			defaultTTL: 24 * time.Hour,
+		},
+		"not applied within default ttl": {
+			summary:    InventorySummary{Created: created, LastApplied: now.Add(-48 * time.Hour)},
+			defaultTTL: 24 * time.Hour,
+			expected:   true,
+		},
+		"annotation ttl takes precedence": {
+			summary:    InventorySummary{Created: created, LastApplied: now.Add(-48 * time.Hour), TTL: 72 * time.Hour},
+			defaultTTL: 24 * time.Hour,
+		},
+		"last update is used if last apply is unknown": {
+			summary:    InventorySummary{Created: created, LastUpdated: now.Add(-48 * time.Hour)},
+			defaultTTL: 24 * time.Hour,
+			expected:   true,
+		},
+		"creation time is not used if last activity is unknown": {
+			summary:    InventorySummary{Created: created},
+			defaultTTL: 30 * 24 * time.Hour,
+		},
+		"invalid inventory": {
+			summary:    InventorySummary{LastApplied: now.Add(-48 * time.Hour), Invalid: fmt.Errorf("invalid")},
+			defaultTTL: 24 * time.Hour,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.summary.Abandoned(now, tc.defaultTTL))
+		})
+	}
+}
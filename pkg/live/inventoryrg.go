@@ -220,6 +220,15 @@ func (icm *InventoryResourceGroup) GetObject() (*unstructured.Unstructured, erro
 
 	// Create the inventory object by copying the template.
 	invCopy := icm.inv.DeepCopy()
+	// Record the time of the apply, so abandoned inventories can be found.
+	annotations := invCopy.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[LastAppliedTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	// The hash is recorded again once the apply has succeeded.
+	delete(annotations, AppliedHashAnnotation)
+	invCopy.SetAnnotations(annotations)
 	// Adds or clears the inventory ObjMetadata to the ResourceGroup "spec.resources" section
 	if len(objs) == 0 {
 		klog.V(4).Infoln("clearing inventory resources")
@@ -16,6 +16,7 @@ package live
 
 import (
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -164,6 +165,9 @@ func TestLoadStore(t *testing.T) {
 				t.Fatalf("unexpected error %v received", err)
 				return
 			}
+			if _, err := time.Parse(time.RFC3339, invStored.GetAnnotations()[LastAppliedTimeAnnotation]); err != nil {
+				t.Fatalf("expected last applied time annotation: %v", err)
+			}
 			wrapped = WrapInventoryObj(invStored)
 			objs, err := wrapped.Load()
 			if !tc.isError && err != nil {
@@ -198,6 +202,18 @@ var cmInvObj = &unstructured.Unstructured{
 	},
 }
 
+func TestGetObject_removesAppliedHash(t *testing.T) {
+	inv := inventoryObj.DeepCopy()
+	inv.SetAnnotations(map[string]string{AppliedHashAnnotation: "abc"})
+	invStored, err := WrapInventoryObj(inv).GetObject()
+	if err != nil {
+		t.Fatalf("unexpected error %v received", err)
+	}
+	if _, found := invStored.GetAnnotations()[AppliedHashAnnotation]; found {
+		t.Fatalf("expected the applied hash annotation to be removed")
+	}
+}
+
 func TestIsResourceGroupInventory(t *testing.T) {
 	tests := map[string]struct {
 		invObj   *unstructured.Unstructured
@@ -0,0 +1,246 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// LiveObjectGetter looks up the live state of the object identified by id.
+// It returns nil and no error if the object does not exist in the cluster,
+// including if its type is not known to the cluster yet, e.g. because the
+// CRD is applied together with the object.
+type LiveObjectGetter func(ctx context.Context, id object.ObjMetadata) (*unstructured.Unstructured, error)
+
+// NewLiveObjectGetter returns a LiveObjectGetter that uses the dynamic
+// client and RESTMapper provided by the factory.
+func NewLiveObjectGetter(factory util.Factory) (LiveObjectGetter, error) {
+	dc, err := factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, id object.ObjMetadata) (*unstructured.Unstructured, error) {
+		mapping, err := mapper.RESTMapping(id.GroupKind)
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var ri dynamic.ResourceInterface = dc.Resource(mapping.Resource)
+		if id.Namespace != "" {
+			ri = dc.Resource(mapping.Resource).Namespace(id.Namespace)
+		}
+		obj, err := ri.Get(ctx, id.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return obj, err
+	}, nil
+}
+
+// OwnershipConflict describes an object whose ownership is claimed both by
+// the inventory being applied and by some other inventory in the cluster.
+type OwnershipConflict struct {
+	// ID identifies the contested object.
+	ID object.ObjMetadata
+	// Owner is the inventory id found in the owning-inventory annotation
+	// of the live object. It is empty if the annotation matches the
+	// current inventory or is not set.
+	Owner string
+	// Inventories are the names of other inventory objects in the cluster
+	// that list the object.
+	Inventories []string
+	// Prune is true if the object is no longer part of the package and
+	// would be pruned by the current apply.
+	Prune bool
+}
+
+func (c OwnershipConflict) String() string {
+	var claims []string
+	if c.Owner != "" {
+		claims = append(claims, fmt.Sprintf("owned by inventory %q", c.Owner))
+	}
+	if len(c.Inventories) > 0 {
+		claims = append(claims, fmt.Sprintf("listed in inventory %s", strings.Join(quoteAll(c.Inventories), ", ")))
+	}
+	action := "apply"
+	if c.Prune {
+		action = "prune"
+	}
+	return fmt.Sprintf("%s (%s): %s", c.ID, action, strings.Join(claims, "; "))
+}
+
+// OwnershipConflictError is returned when objects that would be applied or
+// pruned are claimed by other inventories.
+type OwnershipConflictError struct {
+	InventoryID string
+	Conflicts   []OwnershipConflict
+}
+
+func (e *OwnershipConflictError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d object(s) managed by inventory %q are claimed by other inventories:\n",
+		len(e.Conflicts), e.InventoryID)
+	for _, c := range e.Conflicts {
+		fmt.Fprintf(&b, "  %s\n", c)
+	}
+	b.WriteString("Pruning or applying these objects could break the packages that own them. " +
+		"If the ownership change is intended (for example when splitting or merging packages), " +
+		"re-run with --force-adopt to adopt the applied objects and release the pruned objects " +
+		"from this inventory without deleting them.")
+	return b.String()
+}
+
+// CheckOwnership compares the objects that will be applied and pruned for
+// the inventory inv with the owning-inventory annotation of their live
+// state and with the contents of the other inventories in the cluster.
+// Objects that are claimed by another inventory are returned as conflicts.
+//
+// To keep applies cheap, only the objects that are new to the inventory or
+// would be pruned are looked up; the objects that are already in the
+// inventory are owned by it. The other inventories are only listed if an
+// object that exists in the cluster would be pruned. If the user isn't
+// allowed to list them, e.g. with namespace-scoped permissions, only the
+// owning-inventory annotations are checked.
+func CheckOwnership(ctx context.Context, invClient inventory.Client, getLive LiveObjectGetter,
+	inv inventory.Info, objs []*unstructured.Unstructured) ([]OwnershipConflict, error) {
+	localIDs := object.UnstructuredSetToObjMetadataSet(objs)
+	clusterIDs, err := invClient.GetClusterObjs(inv)
+	if err != nil {
+		return nil, err
+	}
+	newIDs := localIDs.Diff(clusterIDs)
+	pruneIDs := clusterIDs.Diff(localIDs)
+
+	var conflicts []OwnershipConflict
+	check := func(id object.ObjMetadata, prune bool) (*OwnershipConflict, error) {
+		live, err := getLive(ctx, id)
+		if err != nil || live == nil {
+			return nil, err
+		}
+		c := &OwnershipConflict{ID: id, Prune: prune}
+		if inventory.IDMatch(inv, live) == inventory.NoMatch {
+			c.Owner = live.GetAnnotations()[inventory.OwningInventoryKey]
+		}
+		return c, nil
+	}
+	for _, id := range newIDs {
+		c, err := check(id, false)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil && c.Owner != "" {
+			conflicts = append(conflicts, *c)
+		}
+	}
+
+	// An object listed in another inventory is only a problem if this
+	// apply is going to delete it.
+	var pruned []*OwnershipConflict
+	for _, id := range pruneIDs {
+		c, err := check(id, true)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			pruned = append(pruned, c)
+		}
+	}
+	if len(pruned) > 0 {
+		listedIn, err := listedInOtherInventories(ctx, invClient, inv)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range pruned {
+			c.Inventories = listedIn[c.ID]
+			sort.Strings(c.Inventories)
+			if c.Owner != "" || len(c.Inventories) > 0 {
+				conflicts = append(conflicts, *c)
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// listedInOtherInventories returns the names of the inventories other than
+// inv that list each object. If the user isn't allowed to list the
+// inventories, no objects are returned.
+func listedInOtherInventories(ctx context.Context, invClient inventory.Client,
+	inv inventory.Info) (map[object.ObjMetadata][]string, error) {
+	invObjs, err := invClient.ListClusterInventoryObjs(ctx)
+	if apierrors.IsForbidden(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	listedIn := make(map[object.ObjMetadata][]string)
+	for name, ids := range invObjs {
+		if name == inv.Name() {
+			continue
+		}
+		for _, id := range ids {
+			listedIn[id] = append(listedIn[id], name)
+		}
+	}
+	return listedIn, nil
+}
+
+// ReleaseOwnership removes the pruned objects in conflicts from the cluster
+// inventory for inv, so they are left in place rather than deleted by the
+// applier.
+func ReleaseOwnership(invClient inventory.Client, inv inventory.Info, conflicts []OwnershipConflict,
+	dryRun common.DryRunStrategy) error {
+	var release object.ObjMetadataSet
+	for _, c := range conflicts {
+		if c.Prune {
+			release = append(release, c.ID)
+		}
+	}
+	if len(release) == 0 || dryRun.ClientOrServerDryRun() {
+		return nil
+	}
+	clusterIDs, err := invClient.GetClusterObjs(inv)
+	if err != nil {
+		return err
+	}
+	return invClient.Replace(inv, clusterIDs.Diff(release), nil, dryRun)
+}
+
+func quoteAll(strs []string) []string {
+	quoted := make([]string, len(strs))
+	for i, s := range strs {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return quoted
+}
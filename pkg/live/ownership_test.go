@@ -0,0 +1,205 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// fakeListInventoryClient extends the fake inventory client with a
+// configurable set of other inventories in the cluster.
+type fakeListInventoryClient struct {
+	*inventory.FakeClient
+	inventories map[string]object.ObjMetadataSet
+	listErr     error
+}
+
+func (c *fakeListInventoryClient) ListClusterInventoryObjs(context.Context) (map[string]object.ObjMetadataSet, error) {
+	return c.inventories, c.listErr
+}
+
+func liveObj(id object.ObjMetadata, owner string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(id.GroupKind.WithVersion("v1"))
+	u.SetName(id.Name)
+	u.SetNamespace(id.Namespace)
+	if owner != "" {
+		u.SetAnnotations(map[string]string{inventory.OwningInventoryKey: owner})
+	}
+	return u
+}
+
+func TestCheckOwnership(t *testing.T) {
+	inv := WrapInventoryInfoObj(inventoryObj)
+
+	tests := map[string]struct {
+		local       []object.ObjMetadata
+		cluster     object.ObjMetadataSet
+		inventories map[string]object.ObjMetadataSet
+		listErr     error
+		owners      map[object.ObjMetadata]string
+		expected    []OwnershipConflict
+	}{
+		"no conflicts": {
+			local:   []object.ObjMetadata{testPod},
+			cluster: object.ObjMetadataSet{testPod, testDeployment},
+			owners: map[object.ObjMetadata]string{
+				testPod:        testInventoryLabel,
+				testDeployment: testInventoryLabel,
+			},
+		},
+		"objects missing in the cluster are ignored": {
+			local:   []object.ObjMetadata{testPod},
+			cluster: object.ObjMetadataSet{testDeployment},
+			inventories: map[string]object.ObjMetadataSet{
+				"other": {testDeployment},
+			},
+		},
+		"objects already in the inventory are not looked up": {
+			local:   []object.ObjMetadata{testPod},
+			cluster: object.ObjMetadataSet{testPod},
+			owners:  map[object.ObjMetadata]string{testPod: "other-id"},
+		},
+		"inventories are not listed without pruned objects": {
+			local:   []object.ObjMetadata{testPod, testDeployment},
+			cluster: object.ObjMetadataSet{testPod},
+			listErr: fmt.Errorf("inventories must not be listed"),
+		},
+		"inventories that can't be listed are ignored": {
+			local:   []object.ObjMetadata{testPod},
+			cluster: object.ObjMetadataSet{testPod, testDeployment},
+			listErr: apierrors.NewForbidden(schema.GroupResource{Group: "kpt.dev", Resource: "resourcegroups"}, "", nil),
+			owners: map[object.ObjMetadata]string{
+				testDeployment: testInventoryLabel,
+			},
+		},
+		"applied object owned by another inventory": {
+			local:  []object.ObjMetadata{testPod},
+			owners: map[object.ObjMetadata]string{testPod: "other-id"},
+			expected: []OwnershipConflict{
+				{ID: testPod, Owner: "other-id"},
+			},
+		},
+		"pruned object owned by another inventory": {
+			local:   []object.ObjMetadata{testPod},
+			cluster: object.ObjMetadataSet{testPod, testDeployment},
+			owners: map[object.ObjMetadata]string{
+				testPod:        testInventoryLabel,
+				testDeployment: "other-id",
+			},
+			expected: []OwnershipConflict{
+				{ID: testDeployment, Owner: "other-id", Prune: true},
+			},
+		},
+		"pruned object listed in other inventories": {
+			local:   []object.ObjMetadata{testPod},
+			cluster: object.ObjMetadataSet{testPod, testDeployment},
+			inventories: map[string]object.ObjMetadataSet{
+				inventoryObjName: {testPod, testDeployment},
+				"other-b":        {testDeployment},
+				"other-a":        {testDeployment, testPod},
+			},
+			owners: map[object.ObjMetadata]string{
+				testPod:        testInventoryLabel,
+				testDeployment: testInventoryLabel,
+			},
+			expected: []OwnershipConflict{
+				{ID: testDeployment, Inventories: []string{"other-a", "other-b"}, Prune: true},
+			},
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			invClient := &fakeListInventoryClient{
+				FakeClient:  inventory.NewFakeClient(tc.cluster),
+				inventories: tc.inventories,
+				listErr:     tc.listErr,
+			}
+			var objs []*unstructured.Unstructured
+			for _, id := range tc.local {
+				objs = append(objs, liveObj(id, ""))
+			}
+			getLive := func(_ context.Context, id object.ObjMetadata) (*unstructured.Unstructured, error) {
+				owner, found := tc.owners[id]
+				if !found {
+					return nil, nil
+				}
+				return liveObj(id, owner), nil
+			}
+
+			conflicts, err := CheckOwnership(context.Background(), invClient, getLive, inv, objs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, conflicts)
+		})
+	}
+}
+
+func TestNewLiveObjectGetter_unknownType(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+	defer tf.Cleanup()
+	getLive, err := NewLiveObjectGetter(tf)
+	require.NoError(t, err)
+
+	// a package with a CRD and its custom resources is applied before the
+	// cluster knows about the type of the custom resources.
+	crd := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"},
+		Name:      "foos.example.com",
+	}
+	cr := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "example.com", Kind: "Foo"},
+		Namespace: "test-ns",
+		Name:      "foo",
+	}
+	obj, err := getLive(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Nil(t, obj)
+
+	inv := WrapInventoryInfoObj(inventoryObj)
+	conflicts, err := CheckOwnership(context.Background(), inventory.NewFakeClient(nil), getLive, inv,
+		[]*unstructured.Unstructured{liveObj(crd, ""), liveObj(cr, "")})
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestReleaseOwnership(t *testing.T) {
+	inv := WrapInventoryInfoObj(inventoryObj)
+	conflicts := []OwnershipConflict{
+		{ID: testPod, Owner: "other-id"},
+		{ID: testDeployment, Owner: "other-id", Prune: true},
+	}
+
+	invClient := inventory.NewFakeClient(object.ObjMetadataSet{testPod, testDeployment, testService})
+	err := ReleaseOwnership(invClient, inv, conflicts, common.DryRunClient)
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{testPod, testDeployment, testService}, invClient.Objs)
+
+	err = ReleaseOwnership(invClient, inv, conflicts, common.DryRunNone)
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{testPod, testService}, invClient.Objs)
+}
@@ -91,6 +91,10 @@ const (
 
 type Plan struct {
 	Actions []Action
+	// InventoryResourceVersion is the version of the inventory object in
+	// the cluster when the plan was created. It is empty if the inventory
+	// object didn't exist.
+	InventoryResourceVersion string
 }
 
 type Action struct {
@@ -106,18 +110,50 @@ type Action struct {
 
 type Options struct {
 	ServerSideOptions common.ServerSideOptions
+	InventoryPolicy   inventory.Policy
 }
 
 func (r *ClusterPlanner) BuildPlan(ctx context.Context, inv inventory.Info, objects []*unstructured.Unstructured, o Options) (*Plan, error) {
+	// The inventory is read before the dry-run, so a change to it during
+	// planning makes the plan stale.
+	invRV, err := inventoryResourceVersion(ctx, r.resourceFetcher, inventoryObjMetadata(inv.Name(), inv.Namespace()))
+	if err != nil {
+		return nil, err
+	}
 	actions, err := r.dryRunForPlan(ctx, inv, objects, o)
 	if err != nil {
 		return nil, err
 	}
 	return &Plan{
-		Actions: actions,
+		Actions:                  actions,
+		InventoryResourceVersion: invRV,
 	}, nil
 }
 
+// inventoryObjMetadata returns the id of the ResourceGroup inventory object
+// with the given name and namespace.
+func inventoryObjMetadata(name, namespace string) object.ObjMetadata {
+	return object.ObjMetadata{
+		GroupKind: live.ResourceGroupGVK.GroupKind(),
+		Name:      name,
+		Namespace: namespace,
+	}
+}
+
+// inventoryResourceVersion returns the resourceVersion of the inventory
+// object id in the cluster, or the empty string if it doesn't exist. It
+// doesn't exist either if the ResourceGroup CRD isn't installed yet.
+func inventoryResourceVersion(ctx context.Context, fetcher ResourceFetcher, id object.ObjMetadata) (string, error) {
+	u, found, err := fetcher.FetchResource(ctx, id)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return "", err
+	}
+	if !found {
+		return "", nil
+	}
+	return u.GetResourceVersion(), nil
+}
+
 func (r *ClusterPlanner) dryRunForPlan(
 	ctx context.Context,
 	inv inventory.Info,
@@ -127,6 +163,7 @@ func (r *ClusterPlanner) dryRunForPlan(
 	eventCh := r.applier.Run(ctx, inv, objects, apply.ApplierOptions{
 		DryRunStrategy:    common.DryRunServer,
 		ServerSideOptions: o.ServerSideOptions,
+		InventoryPolicy:   o.InventoryPolicy,
 	})
 
 	var actions []Action
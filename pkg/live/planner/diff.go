@@ -12,15 +12,17 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package plan
+package planner
 
 import (
 	"reflect"
+	"sort"
 	"strconv"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// Diff is a difference in a single field between two versions of a resource.
 type Diff struct {
 	Type  string
 	Left  interface{}
@@ -28,11 +30,15 @@ type Diff struct {
 	Path  string
 }
 
-func diffObjects(b, a *unstructured.Unstructured) ([]Diff, error) {
+// DiffObjects returns the differences between b and a, ordered by path.
+func DiffObjects(b, a *unstructured.Unstructured) ([]Diff, error) {
 	diffs, err := diffMaps("", b.Object, a.Object)
 	if err != nil {
 		return nil, err
 	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
 	return diffs, nil
 }
 
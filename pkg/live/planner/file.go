@@ -0,0 +1,314 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package planner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	PlanAPIVersion = "kpt.dev/v1alpha1"
+	PlanKind       = "Plan"
+)
+
+// ignoredDiffPaths are the fields that are updated by the server on every
+// apply, and therefore don't show up as changes in a plan.
+var ignoredDiffPaths = []string{
+	".metadata.generation",
+	".metadata.managedFields",
+	".metadata.resourceVersion",
+}
+
+// PlanFile is the KRM resource written by `kpt live plan`. It contains
+// everything needed to apply the plan later with `kpt live apply --plan`,
+// together with the planned actions for review.
+type PlanFile struct {
+	APIVersion string       `json:"apiVersion"`
+	Kind       string       `json:"kind"`
+	Metadata   PlanMetadata `json:"metadata"`
+	Spec       PlanSpec     `json:"spec"`
+}
+
+type PlanMetadata struct {
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type PlanSpec struct {
+	// Inventory is the inventory of the package the plan was created for.
+	Inventory kptfilev1.Inventory `json:"inventory"`
+	// Options are the options the plan was created with, which are also
+	// used when applying it.
+	Options PlanOptions `json:"options"`
+	// InventoryResourceVersion is the version of the inventory object in
+	// the cluster when the plan was created. It is empty if the inventory
+	// object didn't exist. The objects pruned by the apply are read from
+	// the inventory, so the plan is stale if it has changed.
+	InventoryResourceVersion string `json:"inventoryResourceVersion,omitempty"`
+	// Actions are the planned changes to the resources in the cluster.
+	Actions []PlanAction `json:"actions,omitempty"`
+	// Resources are the resources that are applied.
+	Resources []*unstructured.Unstructured `json:"resources,omitempty"`
+}
+
+type PlanOptions struct {
+	ServerSideApply bool   `json:"serverSideApply,omitempty"`
+	ForceConflicts  bool   `json:"forceConflicts,omitempty"`
+	FieldManager    string `json:"fieldManager,omitempty"`
+	InventoryPolicy string `json:"inventoryPolicy,omitempty"`
+}
+
+type PlanAction struct {
+	Action    ActionType `json:"action"`
+	Group     string     `json:"group,omitempty"`
+	Kind      string     `json:"kind"`
+	Name      string     `json:"name"`
+	Namespace string     `json:"namespace,omitempty"`
+	// ResourceVersion is the version of the resource in the cluster when
+	// the plan was created. It is empty if the resource didn't exist.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// Changes are the changed fields of updated resources.
+	Changes []PlanChange `json:"changes,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+type PlanChange struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// NewPlanFile returns the PlanFile for applying objs to the inventory inv
+// with the given plan.
+func NewPlanFile(plan *Plan, inv kptfilev1.Inventory, objs []*unstructured.Unstructured,
+	opts PlanOptions) (*PlanFile, error) {
+	pf := &PlanFile{
+		APIVersion: PlanAPIVersion,
+		Kind:       PlanKind,
+		Metadata: PlanMetadata{
+			Name: inv.Name,
+			Annotations: map[string]string{
+				"config.kubernetes.io/local-config": "true",
+			},
+		},
+		Spec: PlanSpec{
+			Inventory:                inv,
+			InventoryResourceVersion: plan.InventoryResourceVersion,
+			Options:                  opts,
+			Resources:                objs,
+		},
+	}
+	for _, a := range plan.Actions {
+		pa := PlanAction{
+			Action:    a.Type,
+			Group:     a.Group,
+			Kind:      a.Kind,
+			Name:      a.Name,
+			Namespace: a.Namespace,
+			Error:     a.Error,
+		}
+		if a.Original != nil {
+			pa.ResourceVersion = a.Original.GetResourceVersion()
+		}
+		if a.Type == Update {
+			changes, err := updateChanges(a)
+			if err != nil {
+				return nil, err
+			}
+			pa.Changes = changes
+			// Updates that only touch fields managed by the server don't
+			// change the resource.
+			if len(pa.Changes) == 0 {
+				pa.Action = Unchanged
+			}
+		}
+		pf.Spec.Actions = append(pf.Spec.Actions, pa)
+	}
+	return pf, nil
+}
+
+// Unchanged returns true if applying the plan doesn't change any resource
+// in the cluster. Resources that are skipped by the apply are not changed.
+func (p *Plan) Unchanged() (bool, error) {
+	for _, a := range p.Actions {
+		switch a.Type {
+		case Unchanged, Skip:
+		case Update:
+			changes, err := updateChanges(a)
+			if err != nil {
+				return false, err
+			}
+			if len(changes) > 0 {
+				return false, nil
+			}
+		default:
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// updateChanges returns the changes made by the update action a, without
+// the fields updated by the server on every apply.
+func updateChanges(a Action) ([]PlanChange, error) {
+	diffs, err := DiffObjects(a.Original, a.Updated)
+	if err != nil {
+		return nil, err
+	}
+	var changes []PlanChange
+	for _, d := range diffs {
+		if ignoredDiffPath(d.Path) {
+			continue
+		}
+		changes = append(changes, PlanChange{Path: d.Path, Before: d.Left, After: d.Right})
+	}
+	return changes, nil
+}
+
+func ignoredDiffPath(p string) bool {
+	for _, ignored := range ignoredDiffPaths {
+		if p == ignored || strings.HasPrefix(p, ignored+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes the plan as YAML to w.
+func (p *PlanFile) Write(w io.Writer) error {
+	b, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// ReadPlanFile reads the plan at path.
+func ReadPlanFile(path string) (*PlanFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read plan: %w", err)
+	}
+	p := &PlanFile{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("invalid plan %q: %w", path, err)
+	}
+	if p.APIVersion != PlanAPIVersion || p.Kind != PlanKind {
+		return nil, fmt.Errorf("invalid plan %q: expected %s %s, got %s %s",
+			path, PlanAPIVersion, PlanKind, p.APIVersion, p.Kind)
+	}
+	return p, nil
+}
+
+// Errors returns the actions that failed during planning.
+func (p *PlanFile) Errors() []PlanAction {
+	var errs []PlanAction
+	for _, a := range p.Spec.Actions {
+		if a.Action == Error {
+			errs = append(errs, a)
+		}
+	}
+	return errs
+}
+
+// ChangedFields returns the paths of the fields changed by the updates of
+// the plan, by resource.
+func (p *PlanFile) ChangedFields() map[object.ObjMetadata][]string {
+	changes := make(map[object.ObjMetadata][]string)
+	for _, a := range p.Spec.Actions {
+		if len(a.Changes) == 0 {
+			continue
+		}
+		id := object.ObjMetadata{
+			GroupKind: schema.GroupKind{Group: a.Group, Kind: a.Kind},
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		}
+		for _, c := range a.Changes {
+			changes[id] = append(changes[id], c.Path)
+		}
+	}
+	return changes
+}
+
+// StalePlanError is returned if resources in the cluster have changed
+// since the plan was created.
+type StalePlanError struct {
+	Changed []object.ObjMetadata
+}
+
+func (e *StalePlanError) Error() string {
+	var ids []string
+	for _, id := range e.Changed {
+		ids = append(ids, id.String())
+	}
+	return fmt.Sprintf("plan is stale, %d resource(s) changed in the cluster since the plan was created: %s",
+		len(ids), strings.Join(ids, ", "))
+}
+
+// CheckStale verifies that the resources and the inventory object in the
+// cluster are still at the versions the plan was created for. It returns a
+// *StalePlanError if any of them has changed, been created or been deleted
+// since. Skipped resources are not checked, since they are not changed by
+// the apply.
+func (p *PlanFile) CheckStale(ctx context.Context, fetcher ResourceFetcher) error {
+	var changed []object.ObjMetadata
+	invID := inventoryObjMetadata(p.Spec.Inventory.Name, p.Spec.Inventory.Namespace)
+	invRV, err := inventoryResourceVersion(ctx, fetcher, invID)
+	if err != nil {
+		return err
+	}
+	if invRV != p.Spec.InventoryResourceVersion {
+		changed = append(changed, invID)
+	}
+	for _, a := range p.Spec.Actions {
+		if a.Action == Skip {
+			continue
+		}
+		id := object.ObjMetadata{
+			GroupKind: schema.GroupKind{Group: a.Group, Kind: a.Kind},
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		}
+		u, found, err := fetcher.FetchResource(ctx, id)
+		// If the type doesn't exist in the cluster, then the resource itself doesn't exist.
+		if err != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+		var rv string
+		if found {
+			rv = u.GetResourceVersion()
+		}
+		if rv != a.ResourceVersion {
+			changed = append(changed, id)
+		}
+	}
+	if len(changed) > 0 {
+		return &StalePlanError{Changed: changed}
+	}
+	return nil
+}
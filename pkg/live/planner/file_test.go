@@ -0,0 +1,289 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package planner
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var (
+	liveDeploymentYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: foo
+  namespace: default
+  resourceVersion: "42"
+  generation: 1
+spec:
+  replicas: 1
+`
+	updatedDeploymentYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: foo
+  namespace: default
+  resourceVersion: "42"
+  generation: 2
+spec:
+  replicas: 3
+  paused: true
+`
+	configMapYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: bar
+  namespace: default
+  resourceVersion: "7"
+`
+)
+
+var testInventory = kptfilev1.Inventory{
+	Name:        "inventory",
+	Namespace:   "default",
+	InventoryID: "inventory-id",
+}
+
+func TestNewPlanFile(t *testing.T) {
+	live := testutil.Unstructured(t, liveDeploymentYAML)
+	plan := &Plan{
+		Actions: []Action{
+			{
+				Type:      Update,
+				Group:     "apps",
+				Kind:      "Deployment",
+				Name:      "foo",
+				Namespace: "default",
+				Original:  live,
+				Updated:   testutil.Unstructured(t, updatedDeploymentYAML),
+			},
+			{
+				Type:     Update,
+				Kind:     "ConfigMap",
+				Name:     "bar",
+				Original: testutil.Unstructured(t, configMapYAML),
+				Updated:  testutil.Unstructured(t, configMapYAML),
+			},
+			{
+				Type:  Error,
+				Kind:  "ConfigMap",
+				Name:  "baz",
+				Error: "admission webhook denied the request",
+			},
+		},
+	}
+	objs := []*unstructured.Unstructured{testutil.Unstructured(t, deploymentYAML)}
+	pf, err := NewPlanFile(plan, testInventory, objs, PlanOptions{ServerSideApply: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []PlanAction{
+		{
+			Action:          Update,
+			Group:           "apps",
+			Kind:            "Deployment",
+			Name:            "foo",
+			Namespace:       "default",
+			ResourceVersion: "42",
+			Changes: []PlanChange{
+				{Path: ".spec.paused", After: true},
+				{Path: ".spec.replicas", Before: int64(1), After: int64(3)},
+			},
+		},
+		{
+			Action:          Unchanged,
+			Kind:            "ConfigMap",
+			Name:            "bar",
+			ResourceVersion: "7",
+		},
+		{
+			Action: Error,
+			Kind:   "ConfigMap",
+			Name:   "baz",
+			Error:  "admission webhook denied the request",
+		},
+	}, pf.Spec.Actions)
+	assert.Len(t, pf.Errors(), 1)
+
+	// The plan is read back as written.
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	var b bytes.Buffer
+	require.NoError(t, pf.Write(&b))
+	require.NoError(t, os.WriteFile(path, b.Bytes(), 0600))
+	got, err := ReadPlanFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testInventory, got.Spec.Inventory)
+	assert.Equal(t, PlanOptions{ServerSideApply: true}, got.Spec.Options)
+	require.Len(t, got.Spec.Resources, 1)
+	assert.Equal(t, objs[0].Object, got.Spec.Resources[0].Object)
+}
+
+func TestPlan_Unchanged(t *testing.T) {
+	serverSideUpdate := testutil.Unstructured(t, configMapYAML)
+	serverSideUpdate.SetResourceVersion("8")
+	testCases := map[string]struct {
+		actions   []Action
+		unchanged bool
+	}{
+		"no actions": {
+			unchanged: true,
+		},
+		"unchanged, skipped and server-side updates": {
+			actions: []Action{
+				{Type: Unchanged, Kind: "ConfigMap", Name: "foo"},
+				{Type: Skip, Kind: "ConfigMap", Name: "bar"},
+				{
+					Type:     Update,
+					Kind:     "ConfigMap",
+					Name:     "bar",
+					Original: testutil.Unstructured(t, configMapYAML),
+					Updated:  serverSideUpdate,
+				},
+			},
+			unchanged: true,
+		},
+		"update": {
+			actions: []Action{
+				{
+					Type:     Update,
+					Group:    "apps",
+					Kind:     "Deployment",
+					Name:     "foo",
+					Original: testutil.Unstructured(t, liveDeploymentYAML),
+					Updated:  testutil.Unstructured(t, updatedDeploymentYAML),
+				},
+			},
+		},
+		"create": {
+			actions: []Action{{Type: Create, Kind: "ConfigMap", Name: "foo"}},
+		},
+		"delete": {
+			actions: []Action{{Type: Delete, Kind: "ConfigMap", Name: "foo"}},
+		},
+		"error": {
+			actions: []Action{{Type: Error, Kind: "ConfigMap", Name: "foo", Error: "denied"}},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			unchanged, err := (&Plan{Actions: tc.actions}).Unchanged()
+			require.NoError(t, err)
+			assert.Equal(t, tc.unchanged, unchanged)
+		})
+	}
+}
+
+func TestReadPlanFile_invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]struct {
+		content string
+		errMsg  string
+	}{
+		"wrong kind": {
+			content: "apiVersion: kpt.dev/v1\nkind: Kptfile\n",
+			errMsg:  "expected kpt.dev/v1alpha1 Plan, got kpt.dev/v1 Kptfile",
+		},
+		"unknown field": {
+			content: "apiVersion: kpt.dev/v1alpha1\nkind: Plan\nfoo: bar\n",
+			errMsg:  `unknown field "foo"`,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			path := filepath.Join(dir, tn+".yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0600))
+			_, err := ReadPlanFile(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestPlanFile_CheckStale(t *testing.T) {
+	pf := &PlanFile{
+		Spec: PlanSpec{
+			Actions: []PlanAction{
+				{Action: Update, Group: "apps", Kind: "Deployment", Name: "foo", Namespace: "default", ResourceVersion: "42"},
+				{Action: Create, Kind: "ConfigMap", Name: "bar", Namespace: "default"},
+				{Action: Skip, Kind: "ConfigMap", Name: "skipped", Namespace: "default", ResourceVersion: "1"},
+			},
+		},
+	}
+
+	fetcher := &FakeResourceFetcher{
+		resources: []*unstructured.Unstructured{testutil.Unstructured(t, liveDeploymentYAML)},
+	}
+	assert.NoError(t, pf.CheckStale(context.Background(), fetcher))
+
+	changed := testutil.Unstructured(t, liveDeploymentYAML)
+	changed.SetResourceVersion("43")
+	fetcher.resources = []*unstructured.Unstructured{changed, testutil.Unstructured(t, configMapYAML)}
+	err := pf.CheckStale(context.Background(), fetcher)
+	var staleErr *StalePlanError
+	require.ErrorAs(t, err, &staleErr)
+	assert.Len(t, staleErr.Changed, 2)
+	assert.Contains(t, err.Error(), "plan is stale, 2 resource(s) changed in the cluster")
+}
+
+func TestPlanFile_CheckStale_inventory(t *testing.T) {
+	inventoryObj := func(rv string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(live.ResourceGroupGVK)
+		u.SetName("inv")
+		u.SetNamespace("default")
+		u.SetResourceVersion(rv)
+		return u
+	}
+	pf := &PlanFile{
+		Spec: PlanSpec{
+			Inventory:                kptfilev1.Inventory{Name: "inv", Namespace: "default"},
+			InventoryResourceVersion: "7",
+			Actions: []PlanAction{
+				{Action: Update, Group: "apps", Kind: "Deployment", Name: "foo", Namespace: "default", ResourceVersion: "42"},
+			},
+		},
+	}
+
+	fetcher := &FakeResourceFetcher{
+		resources: []*unstructured.Unstructured{testutil.Unstructured(t, liveDeploymentYAML), inventoryObj("7")},
+	}
+	assert.NoError(t, pf.CheckStale(context.Background(), fetcher))
+
+	// another apply changed the inventory, and so the objects that are
+	// pruned, but none of the resources of the plan.
+	fetcher.resources[1] = inventoryObj("8")
+	err := pf.CheckStale(context.Background(), fetcher)
+	var staleErr *StalePlanError
+	require.ErrorAs(t, err, &staleErr)
+	assert.Equal(t, []object.ObjMetadata{{
+		GroupKind: live.ResourceGroupGVK.GroupKind(),
+		Name:      "inv",
+		Namespace: "default",
+	}}, staleErr.Changed)
+}
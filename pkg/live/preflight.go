@@ -0,0 +1,141 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kubectl/pkg/validation"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// PreflightMode controls the validation of the resources of a package
+// against the schema of the cluster before they are applied.
+type PreflightMode string
+
+const (
+	// PreflightStrict refuses the apply if any resource fails validation.
+	PreflightStrict PreflightMode = "strict"
+	// PreflightWarn reports the resources that fail validation and applies
+	// the package anyway.
+	PreflightWarn PreflightMode = "warn"
+	// PreflightOff skips the validation.
+	PreflightOff PreflightMode = "off"
+)
+
+// PreflightModesAsStrings returns the allowed values of PreflightMode.
+func PreflightModesAsStrings() []string {
+	return []string{string(PreflightStrict), string(PreflightWarn), string(PreflightOff)}
+}
+
+// ParsePreflightMode returns the PreflightMode for s.
+func ParsePreflightMode(s string) (PreflightMode, error) {
+	for _, m := range PreflightModesAsStrings() {
+		if s == m {
+			return PreflightMode(s), nil
+		}
+	}
+	return "", fmt.Errorf("unknown preflight mode %q, must be one of %s",
+		s, strings.Join(PreflightModesAsStrings(), ", "))
+}
+
+// PreflightProblem describes a resource that failed the preflight
+// validation.
+type PreflightProblem struct {
+	// ID identifies the resource.
+	ID object.ObjMetadata
+	// Message explains why the resource is invalid.
+	Message string
+}
+
+func (p PreflightProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.ID, p.Message)
+}
+
+// PreflightError is returned when resources fail the preflight validation
+// in strict mode.
+type PreflightError struct {
+	Problems []PreflightProblem
+}
+
+func (e *PreflightError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d problem(s) found validating the resources against the cluster:\n", len(e.Problems))
+	for _, p := range e.Problems {
+		fmt.Fprintf(&b, "  %s\n", p)
+	}
+	b.WriteString("No resources were applied. Fix the resources, or re-run with --preflight=warn " +
+		"to apply them anyway.")
+	return b.String()
+}
+
+// Preflight validates objs against the API resources known to mapper and
+// the OpenAPI schema of the cluster. It returns the unknown kinds and
+// the unknown or invalid fields it finds. Resources whose
+// CustomResourceDefinition is part of objs are not validated, since the
+// definition in the cluster is updated or created by the apply.
+func Preflight(schemaValidator validation.Schema, mapper meta.RESTMapper,
+	objs []*unstructured.Unstructured) ([]PreflightProblem, error) {
+	packageCRDs := map[schema.GroupKind]bool{}
+	for _, obj := range objs {
+		if gk, found := object.GetCRDGroupKind(obj); found {
+			packageCRDs[gk] = true
+		}
+	}
+
+	var problems []PreflightProblem
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		gvk := obj.GroupVersionKind()
+		if packageCRDs[gvk.GroupKind()] {
+			continue
+		}
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if !meta.IsNoMatchError(err) {
+				return nil, err
+			}
+			problems = append(problems, PreflightProblem{
+				ID:      id,
+				Message: fmt.Sprintf("unknown kind %s in %s", gvk.Kind, gvk.GroupVersion()),
+			})
+			continue
+		}
+
+		data, err := json.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		if err := schemaValidator.ValidateBytes(data); err != nil {
+			for _, e := range flatten(err) {
+				problems = append(problems, PreflightProblem{ID: id, Message: e.Error()})
+			}
+		}
+	}
+	return problems, nil
+}
+
+// flatten returns the errors of the possibly nested aggregate err.
+func flatten(err error) []error {
+	if agg, ok := err.(utilerrors.Aggregate); ok {
+		return utilerrors.Flatten(agg).Errors()
+	}
+	return []error{err}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// fakeSchema rejects the objects with a field named "invalid" at the top
+// level.
+type fakeSchema struct{}
+
+func (fakeSchema) ValidateBytes(data []byte) error {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if _, found := obj["invalid"]; found {
+		return utilerrors.NewAggregate([]error{
+			fmt.Errorf("unknown field \"invalid\" in %s", obj["kind"]),
+		})
+	}
+	return nil
+}
+
+func TestPreflight(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}, meta.RESTScopeRoot)
+
+	configMap := func(name string, fields map[string]interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "ns"},
+		}}
+		for k, v := range fields {
+			u.Object[k] = v
+		}
+		return u
+	}
+	custom := func(kind string, fields map[string]interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       kind,
+			"metadata":   map[string]interface{}{"name": "obj", "namespace": "ns"},
+		}}
+		for k, v := range fields {
+			u.Object[k] = v
+		}
+		return u
+	}
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "widgets.example.com"},
+		"spec": map[string]interface{}{
+			"group": "example.com",
+			"names": map[string]interface{}{"kind": "Widget"},
+		},
+	}}
+
+	testCases := map[string]struct {
+		objs     []*unstructured.Unstructured
+		expected []PreflightProblem
+	}{
+		"valid resources": {
+			objs: []*unstructured.Unstructured{configMap("a", nil)},
+		},
+		"invalid field": {
+			objs: []*unstructured.Unstructured{
+				configMap("a", nil),
+				configMap("b", map[string]interface{}{"invalid": true}),
+			},
+			expected: []PreflightProblem{{
+				ID:      object.ObjMetadata{Namespace: "ns", Name: "b", GroupKind: schema.GroupKind{Kind: "ConfigMap"}},
+				Message: "unknown field \"invalid\" in ConfigMap",
+			}},
+		},
+		"unknown kind": {
+			objs: []*unstructured.Unstructured{custom("Gadget", nil)},
+			expected: []PreflightProblem{{
+				ID:      object.ObjMetadata{Namespace: "ns", Name: "obj", GroupKind: schema.GroupKind{Group: "example.com", Kind: "Gadget"}},
+				Message: "unknown kind Gadget in example.com/v1",
+			}},
+		},
+		"kind defined by a CRD in the package": {
+			objs: []*unstructured.Unstructured{
+				crd,
+				custom("Widget", map[string]interface{}{"invalid": true}),
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			problems, err := Preflight(fakeSchema{}, mapper, tc.objs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, problems)
+		})
+	}
+}
+
+func TestPreflightError(t *testing.T) {
+	err := &PreflightError{Problems: []PreflightProblem{{
+		ID:      object.ObjMetadata{Namespace: "ns", Name: "b", GroupKind: schema.GroupKind{Kind: "ConfigMap"}},
+		Message: "unknown field \"invalid\" in ConfigMap",
+	}}}
+	assert.Equal(t, `1 problem(s) found validating the resources against the cluster:
+  ns_b__ConfigMap: unknown field "invalid" in ConfigMap
+No resources were applied. Fix the resources, or re-run with --preflight=warn to apply them anyway.`, err.Error())
+}
@@ -0,0 +1,432 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/GoogleContainerTools/kpt/pkg/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// ReconcileTimeoutAnnotation sets how long kpt waits for an applied
+	// object to reach the Current status before its failure policy is
+	// enforced. The value is a Go duration, e.g. "5m".
+	ReconcileTimeoutAnnotation = "kpt.dev/reconcile-timeout"
+	// ReconcileFailurePolicyAnnotation sets what kpt does when an applied
+	// object fails to reconcile or exceeds its reconcile timeout.
+	ReconcileFailurePolicyAnnotation = "kpt.dev/reconcile-failure-policy"
+)
+
+// FailurePolicy determines how a reconcile failure of a single object
+// affects the rest of the apply.
+type FailurePolicy string
+
+const (
+	// FailurePolicyContinue reports the failure and continues the apply,
+	// which still fails once it has completed.
+	FailurePolicyContinue FailurePolicy = "continue"
+	// FailurePolicyAbort stops the apply; remaining objects are not applied.
+	FailurePolicyAbort FailurePolicy = "abort"
+	// FailurePolicyRollback restores the object to the state it had before
+	// the apply, deleting it if it did not exist, and continues the apply.
+	FailurePolicyRollback FailurePolicy = "rollback"
+)
+
+// FailurePolicies lists the supported failure policies.
+var FailurePolicies = []FailurePolicy{
+	FailurePolicyContinue,
+	FailurePolicyAbort,
+	FailurePolicyRollback,
+}
+
+// ReconcilePolicy is the reconcile timeout and failure policy of an object.
+type ReconcilePolicy struct {
+	// Timeout is the time the object has to reconcile after it has been
+	// applied. Zero means no per-object timeout.
+	Timeout time.Duration
+	// OnFailure is the policy enforced when the object fails to reconcile.
+	OnFailure FailurePolicy
+}
+
+// ReadReconcilePolicy reads the reconcile policy from the given
+// annotations. Values that are not set are taken from defaults.
+func ReadReconcilePolicy(annotations map[string]string, defaults ReconcilePolicy) (ReconcilePolicy, error) {
+	p := defaults
+	if p.OnFailure == "" {
+		p.OnFailure = FailurePolicyContinue
+	}
+	if v, found := annotations[ReconcileTimeoutAnnotation]; found {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return p, fmt.Errorf("invalid %s annotation %q: must be a non-negative duration", ReconcileTimeoutAnnotation, v)
+		}
+		p.Timeout = d
+	}
+	if v, found := annotations[ReconcileFailurePolicyAnnotation]; found {
+		policy := FailurePolicy(v)
+		valid := false
+		for _, fp := range FailurePolicies {
+			valid = valid || policy == fp
+		}
+		if !valid {
+			return p, fmt.Errorf("invalid %s annotation %q: must be one of %s",
+				ReconcileFailurePolicyAnnotation, v, joinFailurePolicies())
+		}
+		p.OnFailure = policy
+	}
+	return p, nil
+}
+
+func joinFailurePolicies() string {
+	var s []string
+	for _, fp := range FailurePolicies {
+		s = append(s, string(fp))
+	}
+	return strings.Join(s, ", ")
+}
+
+// ReconcilePolicies maps applied objects to their reconcile policy.
+type ReconcilePolicies map[object.ObjMetadata]ReconcilePolicy
+
+// NewReconcilePolicies reads the reconcile policies of objs. The
+// annotations of the inventory, i.e. the inventory section of the Kptfile,
+// provide package-wide defaults that the object annotations override.
+func NewReconcilePolicies(inv kptfilev1.Inventory, objs []*unstructured.Unstructured) (ReconcilePolicies, error) {
+	defaults, err := ReadReconcilePolicy(inv.Annotations, ReconcilePolicy{})
+	if err != nil {
+		return nil, fmt.Errorf("inventory: %w", err)
+	}
+	policies := make(ReconcilePolicies)
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		p, err := ReadReconcilePolicy(obj.GetAnnotations(), defaults)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		// The readiness condition is evaluated by the status readers, but
+		// it is parsed here so an invalid condition fails before the apply.
+		if v, found := obj.GetAnnotations()[status.ReadyWhenAnnotation]; found {
+			if _, err := status.ParseReadyCondition(v); err != nil {
+				return nil, fmt.Errorf("%s: invalid %s annotation: %w", id, status.ReadyWhenAnnotation, err)
+			}
+		}
+		policies[id] = p
+	}
+	return policies, nil
+}
+
+// MaxTimeout returns the largest per-object reconcile timeout, or zero if
+// no object has a timeout.
+func (p ReconcilePolicies) MaxTimeout() time.Duration {
+	var max time.Duration
+	for _, policy := range p {
+		if policy.Timeout > max {
+			max = policy.Timeout
+		}
+	}
+	return max
+}
+
+// Enabled returns true if any object has a reconcile timeout or a failure
+// policy other than continue.
+func (p ReconcilePolicies) Enabled() bool {
+	for _, policy := range p {
+		if policy.Timeout > 0 || policy.OnFailure != FailurePolicyContinue {
+			return true
+		}
+	}
+	return false
+}
+
+// LiveObjectWriter restores the live state of the object identified by id
+// to obj. If obj is nil the object is deleted.
+type LiveObjectWriter func(ctx context.Context, id object.ObjMetadata, obj *unstructured.Unstructured) error
+
+// NewLiveObjectWriter returns a LiveObjectWriter that uses the dynamic
+// client and RESTMapper provided by the factory.
+func NewLiveObjectWriter(factory util.Factory) (LiveObjectWriter, error) {
+	dc, err := factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, id object.ObjMetadata, obj *unstructured.Unstructured) error {
+		mapping, err := mapper.RESTMapping(id.GroupKind)
+		if err != nil {
+			return err
+		}
+		var ri dynamic.ResourceInterface = dc.Resource(mapping.Resource)
+		if id.Namespace != "" {
+			ri = dc.Resource(mapping.Resource).Namespace(id.Namespace)
+		}
+		if obj == nil {
+			err := ri.Delete(ctx, id.Name, metav1.DeleteOptions{})
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		current, err := ri.Get(ctx, id.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			obj.SetResourceVersion("")
+			_, err = ri.Create(ctx, obj, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		obj.SetResourceVersion(current.GetResourceVersion())
+		_, err = ri.Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	}, nil
+}
+
+// ReconcileFailure describes an object that failed to reconcile.
+type ReconcileFailure struct {
+	ID object.ObjMetadata
+	// Reason describes why the object is considered failed.
+	Reason string
+	// Policy is the failure policy that was enforced.
+	Policy FailurePolicy
+	// RollbackErr is set if restoring the object failed.
+	RollbackErr error
+}
+
+func (f ReconcileFailure) String() string {
+	s := fmt.Sprintf("%s: %s (%s)", f.ID, f.Reason, f.Policy)
+	if f.Policy == FailurePolicyRollback {
+		if f.RollbackErr != nil {
+			s += fmt.Sprintf(": rollback failed: %v", f.RollbackErr)
+		} else {
+			s += ": rolled back"
+		}
+	}
+	return s
+}
+
+// ReconcilePolicyError is returned when one or more objects failed to
+// reconcile under a reconcile policy.
+type ReconcilePolicyError struct {
+	Failures []ReconcileFailure
+	// Aborted is true if the apply was stopped by an abort policy.
+	Aborted bool
+}
+
+func (e *ReconcilePolicyError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d object(s) failed to reconcile", len(e.Failures))
+	if e.Aborted {
+		b.WriteString("; apply aborted")
+	}
+	b.WriteString(":")
+	for _, f := range e.Failures {
+		fmt.Fprintf(&b, "\n  %s", f)
+	}
+	return b.String()
+}
+
+// ReconcilePolicyEnforcer enforces the reconcile policies of applied
+// objects by observing the events of an apply.
+type ReconcilePolicyEnforcer struct {
+	Policies ReconcilePolicies
+	// GetLive and Restore are used to record and restore the state of
+	// objects with the rollback policy.
+	GetLive LiveObjectGetter
+	Restore LiveObjectWriter
+	// Cancel stops the apply. It is called when an object with the abort
+	// policy fails.
+	Cancel context.CancelFunc
+
+	mu        sync.Mutex
+	snapshots map[object.ObjMetadata]*unstructured.Unstructured
+	timers    map[object.ObjMetadata]*time.Timer
+	failed    map[object.ObjMetadata]bool
+	failures  []ReconcileFailure
+	aborted   bool
+}
+
+// Snapshot records the live state of all objects with the rollback policy.
+// It must be called before the apply starts.
+func (e *ReconcilePolicyEnforcer) Snapshot(ctx context.Context) error {
+	e.snapshots = make(map[object.ObjMetadata]*unstructured.Unstructured)
+	for id, p := range e.Policies {
+		if p.OnFailure != FailurePolicyRollback {
+			continue
+		}
+		obj, err := e.GetLive(ctx, id)
+		if err != nil {
+			return err
+		}
+		if obj != nil {
+			obj = stripServerFields(obj)
+		}
+		e.snapshots[id] = obj
+	}
+	return nil
+}
+
+// stripServerFields returns a copy of obj without the fields set by the
+// server, so it can be written back to the cluster.
+func stripServerFields(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	for _, f := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", f)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+	return obj
+}
+
+// Run forwards the events from in to the returned channel and enforces
+// the reconcile policies along the way. The returned channel is closed
+// after in has been closed.
+func (e *ReconcilePolicyEnforcer) Run(ctx context.Context, in <-chan event.Event) <-chan event.Event {
+	out := make(chan event.Event)
+	expired := make(chan object.ObjMetadata)
+	done := make(chan struct{})
+	e.timers = make(map[object.ObjMetadata]*time.Timer)
+	e.failed = make(map[object.ObjMetadata]bool)
+
+	go func() {
+		defer close(out)
+		defer close(done)
+		defer e.stopTimers()
+		for {
+			select {
+			case id := <-expired:
+				timeout := e.Policies[id].Timeout
+				e.fail(ctx, id, fmt.Sprintf("not reconciled within %s", timeout))
+			case ev, ok := <-in:
+				if !ok {
+					return
+				}
+				e.observe(ctx, ev, expired, done)
+				out <- ev
+			}
+		}
+	}()
+	return out
+}
+
+func (e *ReconcilePolicyEnforcer) observe(ctx context.Context, ev event.Event,
+	expired chan<- object.ObjMetadata, done <-chan struct{}) {
+	switch ev.Type {
+	case event.ApplyType:
+		id := ev.ApplyEvent.Identifier
+		timeout := e.Policies[id].Timeout
+		if ev.ApplyEvent.Status != event.ApplySuccessful || timeout == 0 {
+			return
+		}
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if _, found := e.timers[id]; found {
+			return
+		}
+		e.timers[id] = time.AfterFunc(timeout, func() {
+			select {
+			case expired <- id:
+			case <-done:
+			}
+		})
+	case event.WaitType:
+		id := ev.WaitEvent.Identifier
+		switch ev.WaitEvent.Status {
+		case event.ReconcileSuccessful:
+			e.stopTimer(id)
+		case event.ReconcileFailed:
+			e.fail(ctx, id, "reconcile failed")
+		case event.ReconcileTimeout:
+			e.fail(ctx, id, "reconcile timed out")
+		}
+	}
+}
+
+func (e *ReconcilePolicyEnforcer) stopTimer(id object.ObjMetadata) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if t, found := e.timers[id]; found {
+		t.Stop()
+	}
+}
+
+func (e *ReconcilePolicyEnforcer) stopTimers() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, t := range e.timers {
+		t.Stop()
+	}
+}
+
+// fail records the reconcile failure of id and enforces its policy. Only
+// the first failure of an object is enforced.
+func (e *ReconcilePolicyEnforcer) fail(ctx context.Context, id object.ObjMetadata, reason string) {
+	if e.failed[id] {
+		return
+	}
+	e.failed[id] = true
+	e.stopTimer(id)
+
+	p, found := e.Policies[id]
+	if !found {
+		// Objects without a policy, e.g. pruned objects, are reported by
+		// the printer as usual.
+		return
+	}
+	f := ReconcileFailure{ID: id, Reason: reason, Policy: p.OnFailure}
+	switch p.OnFailure {
+	case FailurePolicyAbort:
+		e.aborted = true
+		if e.Cancel != nil {
+			e.Cancel()
+		}
+	case FailurePolicyRollback:
+		f.RollbackErr = e.Restore(ctx, id, e.snapshots[id])
+	}
+	e.failures = append(e.failures, f)
+}
+
+// Err returns a ReconcilePolicyError if any object failed to reconcile,
+// whatever its failure policy, so that the apply exits with an error even
+// if it continued past the failure. It must be called after the channel
+// returned by Run has been closed.
+func (e *ReconcilePolicyEnforcer) Err() error {
+	if len(e.failures) == 0 {
+		return nil
+	}
+	failures := append([]ReconcileFailure{}, e.failures...)
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].ID.String() < failures[j].ID.String()
+	})
+	return &ReconcilePolicyError{
+		Failures: failures,
+		Aborted:  e.aborted,
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestNewReconcilePolicies(t *testing.T) {
+	pod := liveObj(testPod, "")
+	pod.SetAnnotations(map[string]string{
+		ReconcileTimeoutAnnotation:       "30s",
+		ReconcileFailurePolicyAnnotation: "abort",
+	})
+	deployment := liveObj(testDeployment, "")
+
+	tests := map[string]struct {
+		inv      kptfilev1.Inventory
+		objs     []*unstructured.Unstructured
+		expected ReconcilePolicies
+		errMsg   string
+	}{
+		"defaults": {
+			objs: []*unstructured.Unstructured{deployment},
+			expected: ReconcilePolicies{
+				testDeployment: {OnFailure: FailurePolicyContinue},
+			},
+		},
+		"object annotations override inventory annotations": {
+			inv: kptfilev1.Inventory{
+				Annotations: map[string]string{
+					ReconcileTimeoutAnnotation:       "5m",
+					ReconcileFailurePolicyAnnotation: "rollback",
+				},
+			},
+			objs: []*unstructured.Unstructured{pod, deployment},
+			expected: ReconcilePolicies{
+				testPod:        {Timeout: 30 * time.Second, OnFailure: FailurePolicyAbort},
+				testDeployment: {Timeout: 5 * time.Minute, OnFailure: FailurePolicyRollback},
+			},
+		},
+		"invalid timeout": {
+			inv: kptfilev1.Inventory{
+				Annotations: map[string]string{ReconcileTimeoutAnnotation: "soon"},
+			},
+			objs:   []*unstructured.Unstructured{deployment},
+			errMsg: `inventory: invalid kpt.dev/reconcile-timeout annotation "soon"`,
+		},
+		"invalid failure policy": {
+			objs: []*unstructured.Unstructured{func() *unstructured.Unstructured {
+				u := deployment.DeepCopy()
+				u.SetAnnotations(map[string]string{ReconcileFailurePolicyAnnotation: "retry"})
+				return u
+			}()},
+			errMsg: `must be one of continue, abort, rollback`,
+		},
+		"invalid ready-when condition": {
+			objs: []*unstructured.Unstructured{func() *unstructured.Unstructured {
+				u := deployment.DeepCopy()
+				u.SetAnnotations(map[string]string{"kpt.dev/ready-when": "status.phase =="})
+				return u
+			}()},
+			errMsg: `invalid kpt.dev/ready-when annotation: invalid value in "status.phase =="`,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			policies, err := NewReconcilePolicies(tc.inv, tc.objs)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, policies)
+		})
+	}
+}
+
+func applied(id object.ObjMetadata) event.Event {
+	return event.Event{
+		Type:       event.ApplyType,
+		ApplyEvent: event.ApplyEvent{Identifier: id, Status: event.ApplySuccessful},
+	}
+}
+
+func waited(id object.ObjMetadata, status event.WaitEventStatus) event.Event {
+	return event.Event{
+		Type:      event.WaitType,
+		WaitEvent: event.WaitEvent{Identifier: id, Status: status},
+	}
+}
+
+func TestReconcilePolicyEnforcer(t *testing.T) {
+	tests := map[string]struct {
+		policies ReconcilePolicies
+		live     map[object.ObjMetadata]*unstructured.Unstructured
+		events   []event.Event
+		// wait keeps the event channel open to let per-object timeouts expire.
+		wait time.Duration
+
+		expectedFailures []ReconcileFailure
+		expectedAborted  bool
+		expectedRestored map[object.ObjMetadata]*unstructured.Unstructured
+	}{
+		"reconciled within timeout": {
+			policies: ReconcilePolicies{
+				testPod: {Timeout: 50 * time.Millisecond, OnFailure: FailurePolicyAbort},
+			},
+			events: []event.Event{
+				applied(testPod),
+				waited(testPod, event.ReconcileSuccessful),
+			},
+			wait: 100 * time.Millisecond,
+		},
+		"timeout with continue policy": {
+			policies: ReconcilePolicies{
+				testPod: {Timeout: 10 * time.Millisecond, OnFailure: FailurePolicyContinue},
+			},
+			events: []event.Event{
+				applied(testPod),
+			},
+			wait: 100 * time.Millisecond,
+			expectedFailures: []ReconcileFailure{
+				{ID: testPod, Reason: "not reconciled within 10ms", Policy: FailurePolicyContinue},
+			},
+		},
+		"failure with continue policy": {
+			policies: ReconcilePolicies{
+				testPod:        {OnFailure: FailurePolicyContinue},
+				testDeployment: {OnFailure: FailurePolicyContinue},
+			},
+			// The apply continues after the failure, but still fails in
+			// the end.
+			events: []event.Event{
+				applied(testPod),
+				waited(testPod, event.ReconcileFailed),
+				applied(testDeployment),
+				waited(testDeployment, event.ReconcileSuccessful),
+			},
+			expectedFailures: []ReconcileFailure{
+				{ID: testPod, Reason: "reconcile failed", Policy: FailurePolicyContinue},
+			},
+		},
+		"failure with abort policy": {
+			policies: ReconcilePolicies{
+				testPod:        {OnFailure: FailurePolicyAbort},
+				testDeployment: {OnFailure: FailurePolicyContinue},
+			},
+			events: []event.Event{
+				applied(testPod),
+				applied(testDeployment),
+				waited(testDeployment, event.ReconcileSuccessful),
+				waited(testPod, event.ReconcileFailed),
+			},
+			expectedFailures: []ReconcileFailure{
+				{ID: testPod, Reason: "reconcile failed", Policy: FailurePolicyAbort},
+			},
+			expectedAborted: true,
+		},
+		"timeout with rollback policy": {
+			policies: ReconcilePolicies{
+				testPod:        {OnFailure: FailurePolicyRollback},
+				testDeployment: {OnFailure: FailurePolicyRollback},
+			},
+			live: map[object.ObjMetadata]*unstructured.Unstructured{
+				testDeployment: func() *unstructured.Unstructured {
+					u := liveObj(testDeployment, "")
+					u.SetResourceVersion("42")
+					u.Object["status"] = map[string]interface{}{"replicas": int64(1)}
+					return u
+				}(),
+			},
+			events: []event.Event{
+				applied(testPod),
+				applied(testDeployment),
+				waited(testPod, event.ReconcileTimeout),
+				waited(testDeployment, event.ReconcileTimeout),
+				// Only the first failure of an object is enforced.
+				waited(testDeployment, event.ReconcileFailed),
+			},
+			expectedFailures: []ReconcileFailure{
+				{ID: testDeployment, Reason: "reconcile timed out", Policy: FailurePolicyRollback},
+				{ID: testPod, Reason: "reconcile timed out", Policy: FailurePolicyRollback},
+			},
+			expectedRestored: map[object.ObjMetadata]*unstructured.Unstructured{
+				testPod:        nil,
+				testDeployment: liveObj(testDeployment, ""),
+			},
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			ctx := context.Background()
+			canceled := false
+			restored := make(map[object.ObjMetadata]*unstructured.Unstructured)
+			e := &ReconcilePolicyEnforcer{
+				Policies: tc.policies,
+				GetLive: func(_ context.Context, id object.ObjMetadata) (*unstructured.Unstructured, error) {
+					return tc.live[id], nil
+				},
+				Restore: func(_ context.Context, id object.ObjMetadata, obj *unstructured.Unstructured) error {
+					restored[id] = obj
+					return nil
+				},
+				Cancel: func() { canceled = true },
+			}
+			require.NoError(t, e.Snapshot(ctx))
+
+			in := make(chan event.Event)
+			out := e.Run(ctx, in)
+			go func() {
+				for _, ev := range tc.events {
+					in <- ev
+				}
+				time.Sleep(tc.wait)
+				close(in)
+			}()
+			var received []event.Event
+			for ev := range out {
+				received = append(received, ev)
+			}
+			assert.Equal(t, tc.events, received)
+
+			err := e.Err()
+			if len(tc.expectedFailures) == 0 {
+				assert.NoError(t, err)
+			} else {
+				require.IsType(t, &ReconcilePolicyError{}, err)
+				policyErr := err.(*ReconcilePolicyError)
+				assert.Equal(t, tc.expectedFailures, policyErr.Failures)
+				assert.Equal(t, tc.expectedAborted, policyErr.Aborted)
+			}
+			assert.Equal(t, tc.expectedAborted, canceled)
+			if tc.expectedRestored != nil {
+				assert.Equal(t, tc.expectedRestored, restored)
+			} else {
+				assert.Empty(t, restored)
+			}
+		})
+	}
+}
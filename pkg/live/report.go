@@ -0,0 +1,460 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ApplyReportAPIVersion = "kpt.dev/v1alpha1"
+	ApplyReportKind       = "ApplyReport"
+
+	// ApplyReportSignatureAnnotation holds the base64 encoded ed25519
+	// signature of the JSON encoding of the spec of a signed apply report.
+	ApplyReportSignatureAnnotation = "kpt.dev/apply-report-signature"
+
+	// ApplyReportSignatureHeader holds the signature of the report in the
+	// requests sent to a webhook.
+	ApplyReportSignatureHeader = "X-Kpt-Signature"
+)
+
+// ApplyOutcome is the result of an apply recorded in an apply report.
+type ApplyOutcome string
+
+const (
+	ApplyOutcomeSucceeded ApplyOutcome = "Succeeded"
+	ApplyOutcomeFailed    ApplyOutcome = "Failed"
+)
+
+// The operations recorded for the resources of an apply report.
+const (
+	OperationCreated    = "created"
+	OperationConfigured = "configured"
+	OperationUnchanged  = "unchanged"
+	OperationPruned     = "pruned"
+	OperationDeleted    = "deleted"
+	OperationSkipped    = "skipped"
+	OperationFailed     = "failed"
+)
+
+// ApplyReport is the KRM resource written by `kpt live apply --report`. It
+// records who applied what and when, and the outcome of the apply, as
+// evidence for audits.
+type ApplyReport struct {
+	APIVersion string              `json:"apiVersion"`
+	Kind       string              `json:"kind"`
+	Metadata   ApplyReportMetadata `json:"metadata"`
+	Spec       ApplyReportSpec     `json:"spec"`
+}
+
+type ApplyReportMetadata struct {
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ApplyReportSpec struct {
+	// Inventory identifies the inventory of the applied package.
+	Inventory ApplyReportInventory `json:"inventory"`
+	// User is the local user who ran the apply.
+	User string `json:"user,omitempty"`
+	// Cluster is the address of the API server of the cluster.
+	Cluster string `json:"cluster,omitempty"`
+	// Commit is the git commit of the package, if it is in a git
+	// repository.
+	Commit string `json:"commit,omitempty"`
+	// UncommittedChanges is true if the package had changes that were not
+	// committed to git.
+	UncommittedChanges bool `json:"uncommittedChanges,omitempty"`
+	// ResourcesDigest is the digest of the applied resources. It is the
+	// same as the hash of the inventory snapshot recorded for rollbacks.
+	ResourcesDigest string `json:"resourcesDigest"`
+	// DryRun is true if the apply was a dry-run.
+	DryRun         bool         `json:"dryRun,omitempty"`
+	StartTime      metav1.Time  `json:"startTime"`
+	CompletionTime metav1.Time  `json:"completionTime"`
+	Outcome        ApplyOutcome `json:"outcome"`
+	// Error is the error the apply failed with.
+	Error   string             `json:"error,omitempty"`
+	Summary ApplyReportSummary `json:"summary"`
+	// Resources are the applied and pruned resources, ordered by action
+	// and identifier.
+	Resources []ApplyReportResource `json:"resources,omitempty"`
+}
+
+type ApplyReportInventory struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	ID        string `json:"id"`
+}
+
+// ApplyReportSummary counts the resources of an apply report by operation.
+type ApplyReportSummary struct {
+	Created    int `json:"created"`
+	Configured int `json:"configured"`
+	Unchanged  int `json:"unchanged"`
+	Pruned     int `json:"pruned"`
+	Deleted    int `json:"deleted"`
+	Skipped    int `json:"skipped"`
+	Failed     int `json:"failed"`
+}
+
+type ApplyReportResource struct {
+	// Action is either apply, prune or delete.
+	Action    string `json:"action"`
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Operation is what the action did to the resource.
+	Operation string `json:"operation"`
+	// Changes are the paths of the fields changed by the apply. They are
+	// only known if a plan was applied.
+	Changes []string `json:"changes,omitempty"`
+	// Adopted is true if the resource existed in the cluster without being
+	// managed by an inventory, and was adopted by the apply.
+	Adopted bool `json:"adopted,omitempty"`
+	// Reconcile is the final reconcile status of the resource.
+	Reconcile string `json:"reconcile,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ApplyReporter records the events of an apply for an apply report.
+type ApplyReporter struct {
+	// Existing are the resources that existed in the cluster before the
+	// apply. Applied resources that are not in Existing are created.
+	Existing map[object.ObjMetadata]bool
+	// Changes are the changed fields of the resources, if known.
+	Changes map[object.ObjMetadata][]string
+	// Adopted are the unmanaged resources adopted by the apply.
+	Adopted object.ObjMetadataSet
+
+	// now returns the current time. It is overridden in tests.
+	now func() time.Time
+
+	start     time.Time
+	resources map[resourceKey]*ApplyReportResource
+	errs      []string
+}
+
+type resourceKey struct {
+	action string
+	id     object.ObjMetadata
+}
+
+// Start records the start time of the apply.
+func (r *ApplyReporter) Start() {
+	if r.now == nil {
+		r.now = time.Now
+	}
+	r.start = r.now()
+	r.resources = make(map[resourceKey]*ApplyReportResource)
+}
+
+// RecordExisting records which of objs exist in the cluster, so the applied
+// resources can be reported as created or configured.
+func (r *ApplyReporter) RecordExisting(ctx context.Context, getLive LiveObjectGetter, objs []*unstructured.Unstructured) error {
+	r.Existing = make(map[object.ObjMetadata]bool)
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		live, err := getLive(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", id, err)
+		}
+		r.Existing[id] = live != nil
+	}
+	return nil
+}
+
+// Run observes the events from in and forwards them unchanged to the
+// returned channel.
+func (r *ApplyReporter) Run(in <-chan event.Event) <-chan event.Event {
+	out := make(chan event.Event)
+	go func() {
+		defer close(out)
+		for ev := range in {
+			r.observe(ev)
+			out <- ev
+		}
+	}()
+	return out
+}
+
+func (r *ApplyReporter) observe(ev event.Event) {
+	switch ev.Type {
+	case event.ApplyType:
+		e := ev.ApplyEvent
+		switch e.Status {
+		case event.ApplySuccessful:
+			op := OperationConfigured
+			if !r.Existing[e.Identifier] {
+				op = OperationCreated
+			}
+			r.record("apply", e.Identifier, op, nil)
+		case event.ApplySkipped:
+			if goerrors.Is(e.Error, ErrUnchanged) {
+				r.record("apply", e.Identifier, OperationUnchanged, nil)
+			} else {
+				r.record("apply", e.Identifier, OperationSkipped, e.Error)
+			}
+		case event.ApplyFailed:
+			r.record("apply", e.Identifier, OperationFailed, e.Error)
+		}
+	case event.PruneType:
+		e := ev.PruneEvent
+		switch e.Status {
+		case event.PruneSuccessful:
+			r.record("prune", e.Identifier, OperationPruned, nil)
+		case event.PruneSkipped:
+			r.record("prune", e.Identifier, OperationSkipped, e.Error)
+		case event.PruneFailed:
+			r.record("prune", e.Identifier, OperationFailed, e.Error)
+		}
+	case event.DeleteType:
+		e := ev.DeleteEvent
+		switch e.Status {
+		case event.DeleteSuccessful:
+			r.record("delete", e.Identifier, OperationDeleted, nil)
+		case event.DeleteSkipped:
+			r.record("delete", e.Identifier, OperationSkipped, e.Error)
+		case event.DeleteFailed:
+			r.record("delete", e.Identifier, OperationFailed, e.Error)
+		}
+	case event.WaitType:
+		e := ev.WaitEvent
+		if e.Status == event.ReconcilePending {
+			return
+		}
+		// the wait events don't tell which action the resource belongs to,
+		// so the status is recorded for the resource of any action.
+		for k, res := range r.resources {
+			if k.id == e.Identifier {
+				res.Reconcile = strings.ToLower(e.Status.String())
+			}
+		}
+	case event.ErrorType:
+		r.errs = append(r.errs, ev.ErrorEvent.Err.Error())
+	}
+}
+
+func (r *ApplyReporter) record(action string, id object.ObjMetadata, op string, err error) {
+	res := &ApplyReportResource{
+		Action:    action,
+		Group:     id.GroupKind.Group,
+		Kind:      id.GroupKind.Kind,
+		Name:      id.Name,
+		Namespace: id.Namespace,
+		Operation: op,
+	}
+	if op == OperationConfigured {
+		res.Changes = r.Changes[id]
+	}
+	if action == "apply" && op != OperationFailed && op != OperationSkipped {
+		res.Adopted = r.Adopted.Contains(id)
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	r.resources[resourceKey{action: action, id: id}] = res
+}
+
+// Report returns the apply report for the apply of objs with the
+// inventory inv, which ended with applyErr.
+func (r *ApplyReporter) Report(inv inventory.Info, objs []*unstructured.Unstructured, dryRun bool, applyErr error) (*ApplyReport, error) {
+	_, hash, err := encodeObjects(objs)
+	if err != nil {
+		return nil, err
+	}
+	report := &ApplyReport{
+		APIVersion: ApplyReportAPIVersion,
+		Kind:       ApplyReportKind,
+		Metadata: ApplyReportMetadata{
+			Name: inv.Name(),
+			Annotations: map[string]string{
+				"config.kubernetes.io/local-config": "true",
+			},
+		},
+		Spec: ApplyReportSpec{
+			Inventory: ApplyReportInventory{
+				Name:      inv.Name(),
+				Namespace: inv.Namespace(),
+				ID:        inv.ID(),
+			},
+			ResourcesDigest: "sha256:" + hash,
+			DryRun:          dryRun,
+			StartTime:       metav1.NewTime(r.start),
+			CompletionTime:  metav1.NewTime(r.now()),
+			Outcome:         ApplyOutcomeSucceeded,
+		},
+	}
+
+	errs := r.errs
+	if applyErr != nil {
+		errs = append([]string{applyErr.Error()}, errs...)
+	}
+	for _, res := range r.resources {
+		report.Spec.Resources = append(report.Spec.Resources, *res)
+		s := &report.Spec.Summary
+		switch res.Operation {
+		case OperationCreated:
+			s.Created++
+		case OperationConfigured:
+			s.Configured++
+		case OperationUnchanged:
+			s.Unchanged++
+		case OperationPruned:
+			s.Pruned++
+		case OperationDeleted:
+			s.Deleted++
+		case OperationSkipped:
+			s.Skipped++
+		case OperationFailed:
+			s.Failed++
+		}
+	}
+	if len(errs) > 0 || report.Spec.Summary.Failed > 0 {
+		report.Spec.Outcome = ApplyOutcomeFailed
+		report.Spec.Error = strings.Join(errs, "; ")
+	}
+	sort.Slice(report.Spec.Resources, func(i, j int) bool {
+		a, b := report.Spec.Resources[i], report.Spec.Resources[j]
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		return fmt.Sprintf("%s/%s/%s/%s", a.Group, a.Kind, a.Namespace, a.Name) <
+			fmt.Sprintf("%s/%s/%s/%s", b.Group, b.Kind, b.Namespace, b.Name)
+	})
+	return report, nil
+}
+
+// ReadSigningKey reads an ed25519 private key in PKCS #8 PEM format, as
+// generated with `openssl genpkey -algorithm ed25519`, from path.
+func ReadSigningKey(path string) (ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %q is not in PEM format", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key %q: %w", path, err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %q must be an ed25519 key, got %T", path, key)
+	}
+	return edKey, nil
+}
+
+// Sign signs the spec of the report with key and records the signature in
+// the ApplyReportSignatureAnnotation.
+func (r *ApplyReport) Sign(key ed25519.PrivateKey) error {
+	b, err := json.Marshal(r.Spec)
+	if err != nil {
+		return err
+	}
+	if r.Metadata.Annotations == nil {
+		r.Metadata.Annotations = map[string]string{}
+	}
+	r.Metadata.Annotations[ApplyReportSignatureAnnotation] = base64.StdEncoding.EncodeToString(ed25519.Sign(key, b))
+	return nil
+}
+
+// Verify checks that the report has been signed with the private key of pub.
+func (r *ApplyReport) Verify(pub ed25519.PublicKey) error {
+	sig, found := r.Metadata.Annotations[ApplyReportSignatureAnnotation]
+	if !found {
+		return fmt.Errorf("apply report is not signed")
+	}
+	b, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("invalid apply report signature: %w", err)
+	}
+	spec, err := json.Marshal(r.Spec)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, spec, b) {
+		return fmt.Errorf("apply report signature doesn't match")
+	}
+	return nil
+}
+
+// Write writes the report as YAML to w.
+func (r *ApplyReport) Write(w io.Writer) error {
+	b, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// Send writes the report to dest. If dest is an http or https URL, the
+// report is posted to it, otherwise it is written to the file at dest.
+func (r *ApplyReport) Send(ctx context.Context, client *http.Client, dest string) error {
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(dest, "http://") && !strings.HasPrefix(dest, "https://") {
+		if err := os.WriteFile(dest, buf.Bytes(), 0600); err != nil {
+			return fmt.Errorf("unable to write apply report: %w", err)
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dest, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/yaml")
+	if sig, found := r.Metadata.Annotations[ApplyReportSignatureAnnotation]; found {
+		req.Header.Set(ApplyReportSignatureHeader, sig)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send apply report: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unable to send apply report: %s returned %s", dest, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+)
+
+func TestApplyReporter(t *testing.T) {
+	inv, err := ToInventoryInfo(kptfilev1.Inventory{
+		Namespace:   testNamespace,
+		Name:        "inventory",
+		InventoryID: "inventory-id",
+	})
+	require.NoError(t, err)
+	pod := liveObj(testPod, "")
+	deployment := liveObj(testDeployment, "")
+	objs := []*unstructured.Unstructured{pod, deployment}
+	_, hash, err := encodeObjects(objs)
+	require.NoError(t, err)
+
+	pruned := object.ObjMetadata{
+		Namespace: testNamespace,
+		Name:      "old",
+		GroupKind: testService.GroupKind,
+	}
+	clock := time.Unix(0, 0)
+	reporter := &ApplyReporter{
+		Changes: map[object.ObjMetadata][]string{
+			testDeployment: {".spec.replicas"},
+		},
+		Adopted: object.ObjMetadataSet{testDeployment},
+		now: func() time.Time {
+			clock = clock.Add(time.Second)
+			return clock
+		},
+	}
+	reporter.Start()
+	getLive := func(_ context.Context, id object.ObjMetadata) (*unstructured.Unstructured, error) {
+		if id == testDeployment {
+			return deployment, nil
+		}
+		return nil, nil
+	}
+	require.NoError(t, reporter.RecordExisting(context.Background(), getLive, objs))
+
+	tests := map[string]struct {
+		events   []event.Event
+		applyErr error
+
+		expectedOutcome   ApplyOutcome
+		expectedError     string
+		expectedSummary   ApplyReportSummary
+		expectedResources []ApplyReportResource
+	}{
+		"successful apply": {
+			events: []event.Event{
+				applied(testPod),
+				applied(testDeployment),
+				{
+					Type:       event.PruneType,
+					PruneEvent: event.PruneEvent{Identifier: pruned, Status: event.PruneSuccessful},
+				},
+				waited(testPod, event.ReconcilePending),
+				waited(testPod, event.ReconcileSuccessful),
+			},
+			expectedOutcome: ApplyOutcomeSucceeded,
+			expectedSummary: ApplyReportSummary{Created: 1, Configured: 1, Pruned: 1},
+			expectedResources: []ApplyReportResource{
+				{Action: "apply", Kind: "Pod", Name: "test-pod", Namespace: testNamespace,
+					Operation: OperationCreated, Reconcile: "successful"},
+				{Action: "apply", Group: "apps", Kind: "Deployment", Name: "test-deployment", Namespace: testNamespace,
+					Operation: OperationConfigured, Changes: []string{".spec.replicas"}, Adopted: true},
+				{Action: "prune", Group: "apps", Kind: "Service", Name: "old", Namespace: testNamespace,
+					Operation: OperationPruned},
+			},
+		},
+		"unchanged resources": {
+			events: []event.Event{
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						Identifier: testPod,
+						Status:     event.ApplySkipped,
+						Error:      ErrUnchanged,
+					},
+				},
+			},
+			expectedOutcome: ApplyOutcomeSucceeded,
+			expectedSummary: ApplyReportSummary{Unchanged: 1},
+			expectedResources: []ApplyReportResource{
+				{Action: "apply", Kind: "Pod", Name: "test-pod", Namespace: testNamespace,
+					Operation: OperationUnchanged},
+			},
+		},
+		"failed apply": {
+			events: []event.Event{
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						Identifier: testPod,
+						Status:     event.ApplyFailed,
+						Error:      fmt.Errorf("denied"),
+					},
+				},
+			},
+			applyErr:        fmt.Errorf("1 resource failed"),
+			expectedOutcome: ApplyOutcomeFailed,
+			expectedError:   "1 resource failed",
+			expectedSummary: ApplyReportSummary{Failed: 1},
+			expectedResources: []ApplyReportResource{
+				{Action: "apply", Kind: "Pod", Name: "test-pod", Namespace: testNamespace,
+					Operation: OperationFailed, Error: "denied"},
+			},
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			reporter.Start()
+			in := make(chan event.Event, len(tc.events))
+			for _, e := range tc.events {
+				in <- e
+			}
+			close(in)
+			var forwarded []event.Event
+			for e := range reporter.Run(in) {
+				forwarded = append(forwarded, e)
+			}
+			assert.Equal(t, tc.events, forwarded)
+
+			report, err := reporter.Report(inv, objs, false, tc.applyErr)
+			require.NoError(t, err)
+			assert.Equal(t, ApplyReportKind, report.Kind)
+			assert.Equal(t, ApplyReportInventory{Name: "inventory", Namespace: testNamespace, ID: "inventory-id"},
+				report.Spec.Inventory)
+			assert.Equal(t, "sha256:"+hash, report.Spec.ResourcesDigest)
+			assert.True(t, report.Spec.CompletionTime.After(report.Spec.StartTime.Time))
+			assert.Equal(t, tc.expectedOutcome, report.Spec.Outcome)
+			assert.Equal(t, tc.expectedError, report.Spec.Error)
+			assert.Equal(t, tc.expectedSummary, report.Spec.Summary)
+			assert.Equal(t, tc.expectedResources, report.Spec.Resources)
+		})
+	}
+}
+
+func TestApplyReportSign(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyPath,
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}), 0600))
+
+	readKey, err := ReadSigningKey(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, key, readKey)
+
+	report := &ApplyReport{
+		APIVersion: ApplyReportAPIVersion,
+		Kind:       ApplyReportKind,
+		Spec:       ApplyReportSpec{Outcome: ApplyOutcomeSucceeded},
+	}
+	assert.EqualError(t, report.Verify(pub), "apply report is not signed")
+	require.NoError(t, report.Sign(readKey))
+	assert.NoError(t, report.Verify(pub))
+
+	report.Spec.Outcome = ApplyOutcomeFailed
+	assert.EqualError(t, report.Verify(pub), "apply report signature doesn't match")
+
+	_, err = ReadSigningKey(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+}
+
+func TestApplyReportSend(t *testing.T) {
+	report := &ApplyReport{
+		APIVersion: ApplyReportAPIVersion,
+		Kind:       ApplyReportKind,
+		Metadata: ApplyReportMetadata{
+			Name:        "inventory",
+			Annotations: map[string]string{ApplyReportSignatureAnnotation: "c2lnbmF0dXJl"},
+		},
+		Spec: ApplyReportSpec{Outcome: ApplyOutcomeSucceeded},
+	}
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "report.yaml")
+		require.NoError(t, report.Send(context.Background(), http.DefaultClient, path))
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		written := &ApplyReport{}
+		require.NoError(t, yaml.UnmarshalStrict(b, written))
+		assert.Equal(t, report.Metadata, written.Metadata)
+		assert.Equal(t, report.Spec.Outcome, written.Spec.Outcome)
+	})
+
+	t.Run("webhook", func(t *testing.T) {
+		var body []byte
+		var header http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		require.NoError(t, report.Send(context.Background(), server.Client(), server.URL))
+		assert.Equal(t, "application/yaml", header.Get("Content-Type"))
+		assert.Equal(t, "c2lnbmF0dXJl", header.Get(ApplyReportSignatureHeader))
+		assert.Contains(t, string(body), "kind: ApplyReport")
+	})
+
+	t.Run("webhook error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		err := report.Send(context.Background(), server.Client(), server.URL)
+		assert.ErrorContains(t, err, "403 Forbidden")
+	})
+}
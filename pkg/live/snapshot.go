@@ -0,0 +1,295 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SnapshotSecretType is the type of the Secrets that hold the inventory
+	// snapshots. Secrets are used since the applied resources may contain
+	// sensitive data.
+	SnapshotSecretType corev1.SecretType = "kpt.dev/inventory-snapshot"
+
+	// SnapshotRevisionAnnotation holds the revision number of a snapshot.
+	SnapshotRevisionAnnotation = "kpt.dev/snapshot-revision"
+
+	// SnapshotHashAnnotation holds the hash of the resources of a snapshot.
+	SnapshotHashAnnotation = "kpt.dev/snapshot-hash"
+
+	// DefaultSnapshotHistory is the number of snapshots kept per inventory.
+	DefaultSnapshotHistory = 10
+
+	// MaxSnapshotBytes is the size limit of the compressed resources of a
+	// snapshot. It matches the size limit of a Secret, minus some room for
+	// its metadata.
+	MaxSnapshotBytes = 1<<20 - 16<<10
+
+	snapshotDataKey = "resources.yaml.gz"
+)
+
+// Snapshot is the set of resources applied with an inventory at some point.
+type Snapshot struct {
+	// Revision increases by one with every recorded snapshot of the
+	// inventory, starting at 1.
+	Revision int
+	// Hash identifies the applied resources.
+	Hash string
+	// Created is the time the snapshot was recorded.
+	Created time.Time
+	// Objects are the applied resources.
+	Objects []*unstructured.Unstructured
+}
+
+// SnapshotStore records inventory snapshots as Secrets in the namespace of
+// the inventory object.
+type SnapshotStore struct {
+	Client kubernetes.Interface
+	// History is the number of snapshots kept per inventory. Older
+	// snapshots are deleted when a new one is recorded.
+	History int
+}
+
+// NewSnapshotStore returns a SnapshotStore that uses the clientset provided
+// by the factory.
+func NewSnapshotStore(factory util.Factory) (*SnapshotStore, error) {
+	cs, err := factory.KubernetesClientSet()
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotStore{Client: cs, History: DefaultSnapshotHistory}, nil
+}
+
+// List returns the snapshots of the inventory ordered by revision, oldest
+// first.
+func (s *SnapshotStore) List(ctx context.Context, inv inventory.Info) ([]Snapshot, error) {
+	secrets, err := s.list(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []Snapshot
+	for i := range secrets {
+		snapshot, err := decodeSnapshot(&secrets[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot %s/%s: %w", secrets[i].Namespace, secrets[i].Name, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// CheckSnapshotSize returns an error if the objects don't fit into a
+// snapshot. It allows failing before the objects are applied rather than
+// after, when the snapshot is recorded.
+func CheckSnapshotSize(objs []*unstructured.Unstructured) error {
+	data, _, err := encodeObjects(objs)
+	if err != nil {
+		return err
+	}
+	return checkSnapshotSize(data)
+}
+
+// Record stores the objects as the latest snapshot of the inventory and
+// deletes snapshots exceeding the history limit. Nothing is recorded if
+// the objects are the same as in the latest snapshot.
+func (s *SnapshotStore) Record(ctx context.Context, inv inventory.Info, objs []*unstructured.Unstructured) error {
+	secrets, err := s.list(ctx, inv)
+	if err != nil {
+		return err
+	}
+	data, hash, err := encodeObjects(objs)
+	if err != nil {
+		return err
+	}
+	if err := checkSnapshotSize(data); err != nil {
+		return err
+	}
+
+	revision := 1
+	if len(secrets) > 0 {
+		latest := secrets[len(secrets)-1]
+		if latest.Annotations[SnapshotHashAnnotation] == hash {
+			return nil
+		}
+		revision = snapshotRevision(&latest) + 1
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-snapshot-%d", inv.Name(), revision),
+			Namespace: inv.Namespace(),
+			Annotations: map[string]string{
+				inventory.OwningInventoryKey: inv.ID(),
+				SnapshotRevisionAnnotation:   strconv.Itoa(revision),
+				SnapshotHashAnnotation:       hash,
+			},
+		},
+		Type: SnapshotSecretType,
+		Data: map[string][]byte{snapshotDataKey: data},
+	}
+	if _, err := s.Client.CoreV1().Secrets(inv.Namespace()).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to record snapshot: %w", err)
+	}
+
+	secrets = append(secrets, *secret)
+	history := s.History
+	if history <= 0 {
+		history = DefaultSnapshotHistory
+	}
+	for len(secrets) > history {
+		if err := s.delete(ctx, &secrets[0]); err != nil {
+			return err
+		}
+		secrets = secrets[1:]
+	}
+	return nil
+}
+
+// Delete deletes all snapshots of the inventory.
+func (s *SnapshotStore) Delete(ctx context.Context, inv inventory.Info) error {
+	secrets, err := s.list(ctx, inv)
+	if err != nil {
+		return err
+	}
+	for i := range secrets {
+		if err := s.delete(ctx, &secrets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list returns the snapshot Secrets of the inventory ordered by revision.
+func (s *SnapshotStore) list(ctx context.Context, inv inventory.Info) ([]corev1.Secret, error) {
+	list, err := s.Client.CoreV1().Secrets(inv.Namespace()).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(SnapshotSecretType)).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	var secrets []corev1.Secret
+	for _, secret := range list.Items {
+		// The fake clientset ignores field selectors.
+		if secret.Type != SnapshotSecretType || secret.Annotations[inventory.OwningInventoryKey] != inv.ID() {
+			continue
+		}
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return snapshotRevision(&secrets[i]) < snapshotRevision(&secrets[j])
+	})
+	return secrets, nil
+}
+
+func (s *SnapshotStore) delete(ctx context.Context, secret *corev1.Secret) error {
+	err := s.Client.CoreV1().Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete snapshot %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+func checkSnapshotSize(data []byte) error {
+	if len(data) > MaxSnapshotBytes {
+		return fmt.Errorf("the snapshot of the resources is %d bytes compressed, which exceeds "+
+			"the limit of %d bytes of a snapshot Secret; split the package into smaller packages",
+			len(data), MaxSnapshotBytes)
+	}
+	return nil
+}
+
+func snapshotRevision(secret *corev1.Secret) int {
+	revision, _ := strconv.Atoi(secret.Annotations[SnapshotRevisionAnnotation])
+	return revision
+}
+
+// encodeObjects returns the objects as gzipped YAML documents, ordered by
+// their identifiers, and the hash of the YAML.
+func encodeObjects(objs []*unstructured.Unstructured) ([]byte, string, error) {
+	sorted := make([]*unstructured.Unstructured, len(objs))
+	copy(sorted, objs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return object.UnstructuredToObjMetadata(sorted[i]).String() <
+			object.UnstructuredToObjMetadata(sorted[j]).String()
+	})
+
+	var buf bytes.Buffer
+	for _, obj := range sorted {
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, "", err
+		}
+		buf.WriteString("---\n")
+		buf.Write(b)
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256(buf.Bytes()))
+
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return nil, "", err
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return compressed.Bytes(), hash, nil
+}
+
+func decodeSnapshot(secret *corev1.Secret) (Snapshot, error) {
+	snapshot := Snapshot{
+		Revision: snapshotRevision(secret),
+		Hash:     secret.Annotations[SnapshotHashAnnotation],
+		Created:  secret.CreationTimestamp.Time,
+	}
+	r, err := gzip.NewReader(bytes.NewReader(secret.Data[snapshotDataKey]))
+	if err != nil {
+		return snapshot, err
+	}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return snapshot, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		snapshot.Objects = append(snapshot.Objects, obj)
+	}
+	return snapshot, nil
+}
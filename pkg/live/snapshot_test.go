@@ -0,0 +1,114 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSnapshotStore(t *testing.T) {
+	ctx := context.Background()
+	inv, err := ToInventoryInfo(kptfilev1.Inventory{
+		Namespace:   testNamespace,
+		Name:        "inventory",
+		InventoryID: "inventory-id",
+	})
+	require.NoError(t, err)
+	other, err := ToInventoryInfo(kptfilev1.Inventory{
+		Namespace:   testNamespace,
+		Name:        "other",
+		InventoryID: "other-id",
+	})
+	require.NoError(t, err)
+
+	store := &SnapshotStore{
+		Client: k8sfake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: testNamespace},
+		}),
+		History: 2,
+	}
+
+	pod := liveObj(testPod, "")
+	deployment := liveObj(testDeployment, "")
+	require.NoError(t, store.Record(ctx, inv, []*unstructured.Unstructured{pod}))
+	// Recording the same objects again doesn't create a new revision.
+	require.NoError(t, store.Record(ctx, inv, []*unstructured.Unstructured{pod}))
+	require.NoError(t, store.Record(ctx, inv, []*unstructured.Unstructured{pod, deployment}))
+	require.NoError(t, store.Record(ctx, other, []*unstructured.Unstructured{deployment}))
+
+	snapshots, err := store.List(ctx, inv)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 1, snapshots[0].Revision)
+	assert.Equal(t, []*unstructured.Unstructured{pod}, snapshots[0].Objects)
+	assert.Equal(t, 2, snapshots[1].Revision)
+	assert.Equal(t, []*unstructured.Unstructured{deployment, pod}, snapshots[1].Objects)
+	assert.NotEqual(t, snapshots[0].Hash, snapshots[1].Hash)
+
+	// Snapshots beyond the history limit are deleted.
+	require.NoError(t, store.Record(ctx, inv, []*unstructured.Unstructured{deployment}))
+	snapshots, err = store.List(ctx, inv)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 2, snapshots[0].Revision)
+	assert.Equal(t, 3, snapshots[1].Revision)
+
+	require.NoError(t, store.Delete(ctx, inv))
+	snapshots, err = store.List(ctx, inv)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+	snapshots, err = store.List(ctx, other)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+}
+
+func TestCheckSnapshotSize(t *testing.T) {
+	assert.NoError(t, CheckSnapshotSize([]*unstructured.Unstructured{liveObj(testPod, "")}))
+
+	// Random data doesn't compress, so it exceeds the limit after gzip.
+	data := make([]byte, MaxSnapshotBytes)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	cm := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "large", "namespace": testNamespace},
+		"data":       map[string]interface{}{"large": base64.StdEncoding.EncodeToString(data)},
+	}}
+	err = CheckSnapshotSize([]*unstructured.Unstructured{cm})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "exceeds the limit")
+	}
+
+	store := &SnapshotStore{Client: k8sfake.NewSimpleClientset()}
+	inv, err := ToInventoryInfo(kptfilev1.Inventory{
+		Namespace:   testNamespace,
+		Name:        "inventory",
+		InventoryID: "inventory-id",
+	})
+	require.NoError(t, err)
+	assert.Error(t, store.Record(context.Background(), inv, []*unstructured.Unstructured{cm}))
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/internal/util/stats"
+	"github.com/GoogleContainerTools/kpt/internal/util/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// ApplyMetrics are the Prometheus metrics recorded for the resources
+// processed by the applier and destroyer.
+type ApplyMetrics struct {
+	// ResourceDuration is the time it took to apply, prune, delete or
+	// wait for a resource.
+	ResourceDuration *prometheus.HistogramVec
+	// ResourceFailures counts the resources that failed to be applied,
+	// pruned, deleted or reconciled.
+	ResourceFailures *prometheus.CounterVec
+	// PhaseDuration is the time it took to run an action group.
+	PhaseDuration *prometheus.HistogramVec
+}
+
+// NewApplyMetrics creates the metrics and registers them with reg.
+func NewApplyMetrics(reg prometheus.Registerer) (*ApplyMetrics, error) {
+	m := &ApplyMetrics{
+		ResourceDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "kpt_live_resource_duration_seconds",
+			Help:    "Time taken to apply, prune, delete or wait for a resource.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 14),
+		}, []string{"action", "group", "kind", "status"}),
+		ResourceFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kpt_live_resource_failures_total",
+			Help: "Number of resources that failed to be applied, pruned, deleted or reconciled.",
+		}, []string{"action", "group", "kind"}),
+		PhaseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "kpt_live_phase_duration_seconds",
+			Help:    "Time taken to run an apply, prune, delete or wait phase.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 14),
+		}, []string{"action"}),
+	}
+	for _, c := range []prometheus.Collector{m.ResourceDuration, m.ResourceFailures, m.PhaseDuration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// RunTelemetry records spans and metrics for the events of a kpt live
+// command, e.g. "apply" or "destroy", and forwards the events to the
+// returned channel. They are only exported if configured in the
+// environment. The returned function ends the span of the command and
+// exports the traces and metrics once the events have been consumed.
+// Failures to export are written to errOut.
+func RunTelemetry(ctx context.Context, command string, inv inventory.Info,
+	in <-chan event.Event, errOut io.Writer) (<-chan event.Event, func(), error) {
+	shutdownTracing, err := telemetry.SetupTracing(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	registry := prometheus.NewRegistry()
+	metrics, err := NewApplyMetrics(registry)
+	if err != nil {
+		return nil, nil, err
+	}
+	spanCtx, span := otel.Tracer("kpt").Start(ctx, "kpt live "+command, trace.WithAttributes(
+		attribute.String("kpt.live.inventory.name", inv.Name()),
+		attribute.String("kpt.live.inventory.namespace", inv.Namespace()),
+		attribute.String("kpt.live.inventory.id", inv.ID()),
+	))
+	out := (&EventTelemetry{
+		Tracer:  otel.Tracer("kpt"),
+		Metrics: metrics,
+	}).Run(spanCtx, in)
+
+	finish := func() {
+		if err := telemetry.PushMetrics(ctx, "kpt_live_"+command, registry); err != nil {
+			fmt.Fprintf(errOut, "%v\n", err)
+		}
+		span.End()
+		if err := shutdownTracing(ctx); err != nil {
+			fmt.Fprintf(errOut, "failed to export traces: %v\n", err)
+		}
+	}
+	return out, finish, nil
+}
+
+// EventTelemetry turns the event stream of the applier or destroyer into
+// OpenTelemetry spans and Prometheus metrics. Every action group becomes a
+// span with a child span for each resource it processed.
+type EventTelemetry struct {
+	Tracer  trace.Tracer
+	Metrics *ApplyMetrics
+
+	// now returns the current time. It is overridden in tests.
+	now func() time.Time
+
+	groups map[string]*groupTelemetry
+	// waitStart records when the applier started waiting for a resource.
+	waitStart map[object.ObjMetadata]time.Time
+}
+
+type groupTelemetry struct {
+	action event.ResourceAction
+	ctx    context.Context
+	span   trace.Span
+	start  time.Time
+	// last is the time of the last resource event in the group. Apply,
+	// prune and delete tasks process one resource after the other, so it
+	// marks the start of the next resource.
+	last time.Time
+}
+
+// Run observes the events from in and forwards them unchanged to the
+// returned channel. Spans are created as children of the span in ctx.
+func (t *EventTelemetry) Run(ctx context.Context, in <-chan event.Event) <-chan event.Event {
+	if t.now == nil {
+		t.now = time.Now
+	}
+	t.groups = make(map[string]*groupTelemetry)
+	t.waitStart = make(map[object.ObjMetadata]time.Time)
+
+	out := make(chan event.Event)
+	go func() {
+		defer close(out)
+		defer t.endGroups()
+		for ev := range in {
+			t.observe(ctx, ev)
+			out <- ev
+		}
+	}()
+	return out
+}
+
+func (t *EventTelemetry) observe(ctx context.Context, ev event.Event) {
+	switch ev.Type {
+	case event.ActionGroupType:
+		e := ev.ActionGroupEvent
+		if e.Status == event.Started {
+			t.startGroup(ctx, e.GroupName, e.Action)
+		} else {
+			t.endGroup(e.GroupName)
+		}
+	case event.ApplyType:
+		e := ev.ApplyEvent
+		if e.Status != event.ApplyPending {
+			t.resourceDone(e.GroupName, e.Identifier, e.Status.String(), e.Status == event.ApplyFailed, e.Error, time.Time{})
+		}
+	case event.PruneType:
+		e := ev.PruneEvent
+		if e.Status != event.PrunePending {
+			t.resourceDone(e.GroupName, e.Identifier, e.Status.String(), e.Status == event.PruneFailed, e.Error, time.Time{})
+		}
+	case event.DeleteType:
+		e := ev.DeleteEvent
+		if e.Status != event.DeletePending {
+			t.resourceDone(e.GroupName, e.Identifier, e.Status.String(), e.Status == event.DeleteFailed, e.Error, time.Time{})
+		}
+	case event.WaitType:
+		e := ev.WaitEvent
+		if e.Status == event.ReconcilePending {
+			t.waitStart[e.Identifier] = t.now()
+			return
+		}
+		failed := e.Status == event.ReconcileFailed || e.Status == event.ReconcileTimeout
+		t.resourceDone(e.GroupName, e.Identifier, e.Status.String(), failed, nil, t.waitStart[e.Identifier])
+		delete(t.waitStart, e.Identifier)
+	}
+}
+
+func (t *EventTelemetry) startGroup(ctx context.Context, name string, action event.ResourceAction) {
+	now := t.now()
+	spanCtx, span := t.Tracer.Start(ctx, "kpt.live."+actionLabel(action),
+		trace.WithTimestamp(now),
+		trace.WithAttributes(
+			attribute.String("kpt.live.action_group", name),
+			attribute.String("kpt.live.action", actionLabel(action)),
+		),
+	)
+	t.groups[name] = &groupTelemetry{
+		action: action,
+		ctx:    spanCtx,
+		span:   span,
+		start:  now,
+		last:   now,
+	}
+}
+
+func (t *EventTelemetry) endGroup(name string) {
+	g, found := t.groups[name]
+	if !found {
+		return
+	}
+	now := t.now()
+	g.span.End(trace.WithTimestamp(now))
+	if t.Metrics != nil {
+		t.Metrics.PhaseDuration.WithLabelValues(actionLabel(g.action)).Observe(now.Sub(g.start).Seconds())
+	}
+	stats.Add(stats.ApplyPhase, actionLabel(g.action), now.Sub(g.start), nil)
+	delete(t.groups, name)
+}
+
+// endGroups ends the spans of the action groups that never finished, e.g.
+// because the command was canceled.
+func (t *EventTelemetry) endGroups() {
+	for name := range t.groups {
+		t.endGroup(name)
+	}
+}
+
+// resourceDone records a span and metrics for a resource that reached a
+// final status. If start is zero, the resource is assumed to have been
+// processed since the previous resource event of its action group.
+func (t *EventTelemetry) resourceDone(groupName string, id object.ObjMetadata, status string,
+	failed bool, err error, start time.Time) {
+	g, found := t.groups[groupName]
+	if !found {
+		return
+	}
+	now := t.now()
+	if start.IsZero() {
+		start = g.last
+	}
+	g.last = now
+	status = strings.ToLower(status)
+	action := actionLabel(g.action)
+
+	_, span := t.Tracer.Start(g.ctx, "kpt.live."+action+".resource",
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.String("k8s.resource.group", id.GroupKind.Group),
+			attribute.String("k8s.resource.kind", id.GroupKind.Kind),
+			attribute.String("k8s.namespace.name", id.Namespace),
+			attribute.String("k8s.resource.name", id.Name),
+			attribute.String("kpt.live.status", status),
+		),
+	)
+	if err != nil {
+		span.RecordError(err, trace.WithTimestamp(now))
+	}
+	if failed {
+		msg := status
+		if err != nil {
+			msg = err.Error()
+		}
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End(trace.WithTimestamp(now))
+
+	if t.Metrics == nil {
+		return
+	}
+	t.Metrics.ResourceDuration.WithLabelValues(action, id.GroupKind.Group, id.GroupKind.Kind, status).
+		Observe(now.Sub(start).Seconds())
+	if failed {
+		t.Metrics.ResourceFailures.WithLabelValues(action, id.GroupKind.Group, id.GroupKind.Kind).Inc()
+	}
+}
+
+func actionLabel(action event.ResourceAction) string {
+	return strings.ToLower(action.String())
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kptfilev1 "github.com/GoogleContainerTools/kpt/pkg/api/kptfile/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+)
+
+func groupEvent(name string, action event.ResourceAction, status event.ActionGroupEventStatus) event.Event {
+	return event.Event{
+		Type:             event.ActionGroupType,
+		ActionGroupEvent: event.ActionGroupEvent{GroupName: name, Action: action, Status: status},
+	}
+}
+
+func TestEventTelemetry(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reg := prometheus.NewRegistry()
+	metrics, err := NewApplyMetrics(reg)
+	require.NoError(t, err)
+
+	// Every call to now advances the clock by a second.
+	clock := time.Unix(0, 0)
+	telemetry := &EventTelemetry{
+		Tracer:  tp.Tracer("test"),
+		Metrics: metrics,
+		now: func() time.Time {
+			clock = clock.Add(time.Second)
+			return clock
+		},
+	}
+
+	applyErr := fmt.Errorf("admission webhook denied the request")
+	events := []event.Event{
+		groupEvent("apply-0", event.ApplyAction, event.Started),
+		applied(testDeployment),
+		{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				Identifier: testPod,
+				Status:     event.ApplyFailed,
+				Error:      applyErr,
+			},
+		},
+		groupEvent("apply-0", event.ApplyAction, event.Finished),
+		groupEvent("wait-0", event.WaitAction, event.Started),
+		waited(testDeployment, event.ReconcilePending),
+		waited(testDeployment, event.ReconcileTimeout),
+		groupEvent("wait-0", event.WaitAction, event.Finished),
+	}
+	for i := range events {
+		switch events[i].Type {
+		case event.ApplyType:
+			events[i].ApplyEvent.GroupName = "apply-0"
+		case event.WaitType:
+			events[i].WaitEvent.GroupName = "wait-0"
+		}
+	}
+
+	in := make(chan event.Event)
+	out := telemetry.Run(context.Background(), in)
+	go func() {
+		for _, ev := range events {
+			in <- ev
+		}
+		close(in)
+	}()
+	var received []event.Event
+	for ev := range out {
+		received = append(received, ev)
+	}
+	assert.Equal(t, events, received)
+
+	type span struct {
+		parent   string
+		duration time.Duration
+		status   codes.Code
+		attrs    map[string]string
+	}
+	spans := make(map[string]span)
+	byID := make(map[string]string)
+	for _, s := range recorder.Ended() {
+		byID[s.SpanContext().SpanID().String()] = s.Name()
+	}
+	for _, s := range recorder.Ended() {
+		attrs := make(map[string]string)
+		for _, kv := range s.Attributes() {
+			attrs[string(kv.Key)] = kv.Value.AsString()
+		}
+		key := s.Name()
+		if name := attrs["k8s.resource.name"]; name != "" {
+			key += "/" + name
+		}
+		spans[key] = span{
+			parent:   byID[s.Parent().SpanID().String()],
+			duration: s.EndTime().Sub(s.StartTime()),
+			status:   s.Status().Code,
+			attrs:    attrs,
+		}
+	}
+	assert.Len(t, spans, 5)
+	assert.Equal(t, 3*time.Second, spans["kpt.live.apply"].duration)
+	assert.Equal(t, "apply-0", spans["kpt.live.apply"].attrs["kpt.live.action_group"])
+
+	deployment := spans["kpt.live.apply.resource/"+testDeployment.Name]
+	assert.Equal(t, "kpt.live.apply", deployment.parent)
+	assert.Equal(t, time.Second, deployment.duration)
+	assert.Equal(t, codes.Unset, deployment.status)
+	assert.Equal(t, map[string]string{
+		"k8s.resource.group": "apps",
+		"k8s.resource.kind":  "Deployment",
+		"k8s.namespace.name": testDeployment.Namespace,
+		"k8s.resource.name":  testDeployment.Name,
+		"kpt.live.status":    "successful",
+	}, deployment.attrs)
+
+	pod := spans["kpt.live.apply.resource/"+testPod.Name]
+	assert.Equal(t, codes.Error, pod.status)
+	assert.Equal(t, "failed", pod.attrs["kpt.live.status"])
+
+	reconcile := spans["kpt.live.wait.resource/"+testDeployment.Name]
+	assert.Equal(t, "kpt.live.wait", reconcile.parent)
+	assert.Equal(t, time.Second, reconcile.duration)
+	assert.Equal(t, codes.Error, reconcile.status)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.ResourceDuration))
+	assert.NoError(t, testutil.CollectAndCompare(metrics.ResourceFailures, strings.NewReader(`
+# HELP kpt_live_resource_failures_total Number of resources that failed to be applied, pruned, deleted or reconciled.
+# TYPE kpt_live_resource_failures_total counter
+kpt_live_resource_failures_total{action="apply",group="",kind="Pod"} 1
+kpt_live_resource_failures_total{action="wait",group="apps",kind="Deployment"} 1
+`)))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.PhaseDuration))
+}
+
+func TestRunTelemetry(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	inv, err := ToInventoryInfo(kptfilev1.Inventory{
+		Namespace:   testNamespace,
+		Name:        "inventory",
+		InventoryID: "inventory-id",
+	})
+	require.NoError(t, err)
+
+	in := make(chan event.Event, 3)
+	in <- groupEvent("delete-0", event.DeleteAction, event.Started)
+	in <- event.Event{
+		Type: event.DeleteType,
+		DeleteEvent: event.DeleteEvent{
+			GroupName:  "delete-0",
+			Identifier: testDeployment,
+			Status:     event.DeleteSuccessful,
+		},
+	}
+	in <- groupEvent("delete-0", event.DeleteAction, event.Finished)
+	close(in)
+
+	out, finish, err := RunTelemetry(context.Background(), "destroy", inv, in, &bytes.Buffer{})
+	require.NoError(t, err)
+	var count int
+	for range out {
+		count++
+	}
+	finish()
+	assert.Equal(t, 3, count)
+
+	var names []string
+	for _, s := range recorder.Ended() {
+		names = append(names, s.Name())
+	}
+	assert.Equal(t, []string{"kpt.live.delete.resource", "kpt.live.delete", "kpt live destroy"}, names)
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// AppliedHashAnnotation records the hash of the package that was last
+// applied successfully with the inventory. It is removed by every other
+// update of the inventory.
+const AppliedHashAnnotation = "kpt.dev/applied-package-hash"
+
+// ErrUnchanged is the reason reported for resources that are not applied
+// because they are unchanged in the cluster.
+var ErrUnchanged = errors.New("resource is unchanged in the cluster")
+
+// PackageHash returns the hash of the resources objs of a package applied
+// with the server-side options opts. The order of objs doesn't matter.
+func PackageHash(objs []*unstructured.Unstructured, opts common.ServerSideOptions) (string, error) {
+	sorted := make([]*unstructured.Unstructured, len(objs))
+	copy(sorted, objs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return object.UnstructuredToObjMetadata(sorted[i]).String() < object.UnstructuredToObjMetadata(sorted[j]).String()
+	})
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+	if err := enc.Encode(opts); err != nil {
+		return "", err
+	}
+	for _, obj := range sorted {
+		if err := enc.Encode(obj.Object); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// AppliedHash returns the hash of the package last applied successfully
+// with the inventory inv. It is empty if the inventory doesn't exist or
+// has been updated since.
+func AppliedHash(ctx context.Context, factory util.Factory, inv inventory.Info) (string, error) {
+	ri, err := resourceGroupClient(factory, inv.Namespace())
+	if err != nil {
+		return "", err
+	}
+	obj, err := ri.Get(ctx, inv.Name(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return obj.GetAnnotations()[AppliedHashAnnotation], nil
+}
+
+// RecordAppliedHash records hash as the hash of the package last applied
+// successfully with the inventory inv.
+func RecordAppliedHash(ctx context.Context, factory util.Factory, inv inventory.Info, hash string) error {
+	ri, err := resourceGroupClient(factory, inv.Namespace())
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{AppliedHashAnnotation: hash},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := ri.Patch(ctx, inv.Name(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to record the hash of the applied package: %w", err)
+	}
+	return nil
+}
+
+// UnchangedApplyEvents returns the events of an apply that skipped all
+// objs because they are unchanged in the cluster, so they can be reported
+// by the printers like the events of an apply.
+func UnchangedApplyEvents(objs []*unstructured.Unstructured) <-chan event.Event {
+	const groupName = "apply-0"
+	ids := object.UnstructuredSetToObjMetadataSet(objs)
+	events := []event.Event{
+		{
+			Type: event.InitType,
+			InitEvent: event.InitEvent{
+				ActionGroups: event.ActionGroupList{
+					{Name: groupName, Action: event.ApplyAction, Identifiers: ids},
+				},
+			},
+		},
+		{
+			Type: event.ActionGroupType,
+			ActionGroupEvent: event.ActionGroupEvent{
+				GroupName: groupName,
+				Action:    event.ApplyAction,
+				Status:    event.Started,
+			},
+		},
+	}
+	for i, obj := range objs {
+		events = append(events, event.Event{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				GroupName:  groupName,
+				Identifier: ids[i],
+				Status:     event.ApplySkipped,
+				Resource:   obj,
+				Error:      ErrUnchanged,
+			},
+		})
+	}
+	events = append(events, event.Event{
+		Type: event.ActionGroupType,
+		ActionGroupEvent: event.ActionGroupEvent{
+			GroupName: groupName,
+			Action:    event.ApplyAction,
+			Status:    event.Finished,
+		},
+	})
+
+	ch := make(chan event.Event, len(events))
+	for _, e := range events {
+		ch <- e
+	}
+	close(ch)
+	return ch
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package live
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/printers"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestUnchangedApplyEvents(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: foo
+  namespace: default
+`),
+		testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: bar
+  namespace: default
+`),
+	}
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := printers.GetPrinter(printers.EventsPrinter, ioStreams).
+		Print(UnchangedApplyEvents(objs), common.DryRunNone, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, `apply phase started
+deployment.apps/foo apply skipped: resource is unchanged in the cluster
+configmap/bar apply skipped: resource is unchanged in the cluster
+apply phase finished
+apply result: 2 attempted, 0 successful, 2 skipped, 0 failed
+`, out.String())
+}
+
+func TestPackageHash(t *testing.T) {
+	deployment := testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: foo
+  namespace: default
+spec:
+  replicas: 1
+`)
+	configMap := testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: bar
+  namespace: default
+`)
+	hash := func(objs []*unstructured.Unstructured, opts common.ServerSideOptions) string {
+		h, err := PackageHash(objs, opts)
+		require.NoError(t, err)
+		return h
+	}
+
+	base := hash([]*unstructured.Unstructured{deployment, configMap}, common.ServerSideOptions{})
+	assert.Equal(t, base, hash([]*unstructured.Unstructured{configMap, deployment}, common.ServerSideOptions{}),
+		"the order of the resources doesn't matter")
+	assert.NotEqual(t, base, hash([]*unstructured.Unstructured{deployment}, common.ServerSideOptions{}))
+	assert.NotEqual(t, base, hash([]*unstructured.Unstructured{deployment, configMap},
+		common.ServerSideOptions{ServerSideApply: true}))
+
+	changed := deployment.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(changed.Object, int64(2), "spec", "replicas"))
+	assert.NotEqual(t, base, hash([]*unstructured.Unstructured{changed, configMap}, common.ServerSideOptions{}))
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/GoogleContainerTools/kpt/internal/util/httputil"
 	"github.com/google/go-containerregistry/pkg/gcrane"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -53,7 +54,7 @@ func NewStorage(cacheDir string) (*Storage, error) {
 	return &Storage{
 		imageCache: c,
 		cacheDir:   cacheDir,
-		transport:  http.DefaultTransport,
+		transport:  httputil.Transport(),
 	}, nil
 }
 
@@ -0,0 +1,371 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package refgraph finds the references between the resources of a
+// package, e.g. from a Deployment to the ConfigMaps and Secrets it uses.
+package refgraph
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/kio/filters"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Rule describes a field of a kind of resource that contains the name of
+// another resource.
+type Rule struct {
+	// Sources are the kinds of resources the rule applies to. The rule
+	// applies to all resources if it is empty.
+	Sources []schema.GroupKind
+	// Path is the dot-separated path of the field with the name of the
+	// referenced resource. A field name followed by "[]" matches every
+	// element of the list, e.g. spec.volumes[].configMap.name.
+	Path string
+	// Targets are the kinds of the referenced resources. If KindField is
+	// empty, it must have a single element.
+	Targets []schema.GroupKind
+	// KindField is the field next to the name field with the kind of the
+	// referenced resource, e.g. kind in the roleRef of a RoleBinding. Only
+	// references to the kinds in Targets are matched.
+	KindField string
+	// NamespaceField is the field next to the name field with the namespace
+	// of the referenced resource. If it is empty or not set, the referenced
+	// resource is in the namespace of the resource, unless it is
+	// cluster-scoped.
+	NamespaceField string
+}
+
+// Catalog is a list of rules.
+type Catalog []Rule
+
+var (
+	namespace             = schema.GroupKind{Kind: "Namespace"}
+	configMap             = schema.GroupKind{Kind: "ConfigMap"}
+	secret                = schema.GroupKind{Kind: "Secret"}
+	service               = schema.GroupKind{Kind: "Service"}
+	serviceAccount        = schema.GroupKind{Kind: "ServiceAccount"}
+	persistentVolumeClaim = schema.GroupKind{Kind: "PersistentVolumeClaim"}
+	pod                   = schema.GroupKind{Kind: "Pod"}
+	replicationController = schema.GroupKind{Kind: "ReplicationController"}
+	deployment            = schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	replicaSet            = schema.GroupKind{Group: "apps", Kind: "ReplicaSet"}
+	statefulSet           = schema.GroupKind{Group: "apps", Kind: "StatefulSet"}
+	daemonSet             = schema.GroupKind{Group: "apps", Kind: "DaemonSet"}
+	job                   = schema.GroupKind{Group: "batch", Kind: "Job"}
+	cronJob               = schema.GroupKind{Group: "batch", Kind: "CronJob"}
+	ingress               = schema.GroupKind{Group: "networking.k8s.io", Kind: "Ingress"}
+	ingressClass          = schema.GroupKind{Group: "networking.k8s.io", Kind: "IngressClass"}
+	role                  = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "Role"}
+	clusterRole           = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}
+	roleBinding           = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}
+	clusterRoleBinding    = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}
+	hpa                   = schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"}
+	priorityClass         = schema.GroupKind{Group: "scheduling.k8s.io", Kind: "PriorityClass"}
+	storageClass          = schema.GroupKind{Group: "storage.k8s.io", Kind: "StorageClass"}
+)
+
+// clusterScoped are the cluster-scoped kinds that can be referenced by the
+// rules of the default catalog.
+var clusterScoped = map[schema.GroupKind]bool{
+	namespace:     true,
+	clusterRole:   true,
+	ingressClass:  true,
+	priorityClass: true,
+	storageClass:  true,
+}
+
+// podSpecPaths are the paths of the pod specs of the workload kinds.
+var podSpecPaths = []struct {
+	kind schema.GroupKind
+	path string
+}{
+	{pod, "spec"},
+	{replicationController, "spec.template.spec"},
+	{deployment, "spec.template.spec"},
+	{replicaSet, "spec.template.spec"},
+	{statefulSet, "spec.template.spec"},
+	{daemonSet, "spec.template.spec"},
+	{job, "spec.template.spec"},
+	{cronJob, "spec.jobTemplate.spec.template.spec"},
+}
+
+// podSpecRules are the rules of pod specs, with paths relative to the pod
+// spec.
+var podSpecRules = []Rule{
+	{Path: "containers[].envFrom[].configMapRef.name", Targets: []schema.GroupKind{configMap}},
+	{Path: "containers[].envFrom[].secretRef.name", Targets: []schema.GroupKind{secret}},
+	{Path: "containers[].env[].valueFrom.configMapKeyRef.name", Targets: []schema.GroupKind{configMap}},
+	{Path: "containers[].env[].valueFrom.secretKeyRef.name", Targets: []schema.GroupKind{secret}},
+	{Path: "initContainers[].envFrom[].configMapRef.name", Targets: []schema.GroupKind{configMap}},
+	{Path: "initContainers[].envFrom[].secretRef.name", Targets: []schema.GroupKind{secret}},
+	{Path: "initContainers[].env[].valueFrom.configMapKeyRef.name", Targets: []schema.GroupKind{configMap}},
+	{Path: "initContainers[].env[].valueFrom.secretKeyRef.name", Targets: []schema.GroupKind{secret}},
+	{Path: "volumes[].configMap.name", Targets: []schema.GroupKind{configMap}},
+	{Path: "volumes[].secret.secretName", Targets: []schema.GroupKind{secret}},
+	{Path: "volumes[].projected.sources[].configMap.name", Targets: []schema.GroupKind{configMap}},
+	{Path: "volumes[].projected.sources[].secret.name", Targets: []schema.GroupKind{secret}},
+	{Path: "volumes[].persistentVolumeClaim.claimName", Targets: []schema.GroupKind{persistentVolumeClaim}},
+	{Path: "imagePullSecrets[].name", Targets: []schema.GroupKind{secret}},
+	{Path: "serviceAccountName", Targets: []schema.GroupKind{serviceAccount}},
+	{Path: "priorityClassName", Targets: []schema.GroupKind{priorityClass}},
+}
+
+// DefaultCatalog returns the rules for the references between the built-in
+// kinds of Kubernetes.
+func DefaultCatalog() Catalog {
+	c := Catalog{
+		{Path: "metadata.namespace", Targets: []schema.GroupKind{namespace}},
+	}
+	for _, s := range podSpecPaths {
+		for _, r := range podSpecRules {
+			r.Sources = []schema.GroupKind{s.kind}
+			r.Path = s.path + "." + r.Path
+			c = append(c, r)
+		}
+	}
+	bindings := []schema.GroupKind{roleBinding, clusterRoleBinding}
+	return append(c,
+		Rule{Sources: []schema.GroupKind{statefulSet}, Path: "spec.serviceName", Targets: []schema.GroupKind{service}},
+		Rule{Sources: []schema.GroupKind{ingress}, Path: "spec.defaultBackend.service.name", Targets: []schema.GroupKind{service}},
+		Rule{Sources: []schema.GroupKind{ingress}, Path: "spec.rules[].http.paths[].backend.service.name", Targets: []schema.GroupKind{service}},
+		Rule{Sources: []schema.GroupKind{ingress}, Path: "spec.tls[].secretName", Targets: []schema.GroupKind{secret}},
+		Rule{Sources: []schema.GroupKind{ingress}, Path: "spec.ingressClassName", Targets: []schema.GroupKind{ingressClass}},
+		Rule{Sources: bindings, Path: "roleRef.name", Targets: []schema.GroupKind{role, clusterRole}, KindField: "kind"},
+		Rule{Sources: bindings, Path: "subjects[].name", Targets: []schema.GroupKind{serviceAccount},
+			KindField: "kind", NamespaceField: "namespace"},
+		Rule{Sources: []schema.GroupKind{hpa}, Path: "spec.scaleTargetRef.name",
+			Targets: []schema.GroupKind{deployment, replicaSet, statefulSet, replicationController}, KindField: "kind"},
+		Rule{Sources: []schema.GroupKind{serviceAccount}, Path: "secrets[].name", Targets: []schema.GroupKind{secret}},
+		Rule{Sources: []schema.GroupKind{serviceAccount}, Path: "imagePullSecrets[].name", Targets: []schema.GroupKind{secret}},
+		Rule{Sources: []schema.GroupKind{persistentVolumeClaim}, Path: "spec.storageClassName", Targets: []schema.GroupKind{storageClass}},
+	)
+}
+
+// Validate returns an error if any of the rules is invalid.
+func (c Catalog) Validate() error {
+	for i, r := range c {
+		if len(r.Targets) == 0 {
+			return fmt.Errorf("rule %d: targets must not be empty", i)
+		}
+		if r.KindField == "" && len(r.Targets) > 1 {
+			return fmt.Errorf("rule %d: kindField is required for more than one target", i)
+		}
+		for _, s := range parsePath(r.Path) {
+			if s.name == "" {
+				return fmt.Errorf("rule %d: invalid path %q", i, r.Path)
+			}
+		}
+	}
+	return nil
+}
+
+// Build returns the references between resources found with the rules of
+// the catalog. Local config resources, such as function configs, are
+// ignored. The file paths of the resources are taken from their path
+// annotations.
+func (c Catalog) Build(resources []*yaml.RNode) (*fnresult.ReferenceGraph, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	var objs []*yaml.RNode
+	// files are the files of the resources, by their references without
+	// a file.
+	files := map[fnresult.ResourceRef]string{}
+	for _, r := range resources {
+		if _, local := r.GetAnnotations()[filters.LocalConfigAnnotation]; local {
+			continue
+		}
+		objs = append(objs, r)
+		ref := resourceRef(r)
+		file := ref.File
+		ref.File = ""
+		files[ref] = file
+	}
+
+	graph := fnresult.NewReferenceGraph()
+	for _, obj := range objs {
+		from := resourceRef(obj)
+		gk := schema.FromAPIVersionAndKind(obj.GetApiVersion(), obj.GetKind()).GroupKind()
+		for _, rule := range c {
+			if !rule.appliesTo(gk) {
+				continue
+			}
+			for _, m := range find(obj.YNode(), parsePath(rule.Path), "") {
+				to, ok := rule.target(m, from.Namespace)
+				if !ok {
+					continue
+				}
+				file, found := files[to]
+				to.File = file
+				graph.Items = append(graph.Items, fnresult.Reference{
+					From:    from,
+					Field:   m.path,
+					To:      to,
+					Missing: !found,
+				})
+			}
+		}
+	}
+	return graph, nil
+}
+
+func (r Rule) appliesTo(gk schema.GroupKind) bool {
+	if len(r.Sources) == 0 {
+		return true
+	}
+	for _, s := range r.Sources {
+		if s == gk {
+			return true
+		}
+	}
+	return false
+}
+
+// target returns the resource referenced by the field m. It returns false
+// if the field doesn't reference a resource of the kinds of the rule.
+func (r Rule) target(m match, namespace string) (fnresult.ResourceRef, bool) {
+	if m.node.Kind != yaml.ScalarNode || m.node.Value == "" {
+		return fnresult.ResourceRef{}, false
+	}
+	gk := r.Targets[0]
+	if r.KindField != "" {
+		kind := scalarField(m.parent, r.KindField)
+		found := false
+		for _, t := range r.Targets {
+			if t.Kind == kind {
+				gk, found = t, true
+				break
+			}
+		}
+		if !found {
+			return fnresult.ResourceRef{}, false
+		}
+	}
+	if ns := scalarField(m.parent, r.NamespaceField); r.NamespaceField != "" && ns != "" {
+		namespace = ns
+	}
+	if clusterScoped[gk] {
+		namespace = ""
+	}
+	return fnresult.ResourceRef{
+		Group:     gk.Group,
+		Kind:      gk.Kind,
+		Name:      m.node.Value,
+		Namespace: namespace,
+	}, true
+}
+
+func resourceRef(r *yaml.RNode) fnresult.ResourceRef {
+	gk := schema.FromAPIVersionAndKind(r.GetApiVersion(), r.GetKind()).GroupKind()
+	path, _, _ := kioutil.GetFileAnnotations(r)
+	ns := r.GetNamespace()
+	if clusterScoped[gk] {
+		ns = ""
+	}
+	return fnresult.ResourceRef{
+		Group:     gk.Group,
+		Kind:      gk.Kind,
+		Name:      r.GetName(),
+		Namespace: ns,
+		File:      filepath.ToSlash(path),
+	}
+}
+
+type segment struct {
+	name string
+	list bool
+}
+
+func parsePath(path string) []segment {
+	var segments []segment
+	for _, p := range strings.Split(path, ".") {
+		s := segment{name: p}
+		if strings.HasSuffix(p, "[]") {
+			s = segment{name: strings.TrimSuffix(p, "[]"), list: true}
+		}
+		segments = append(segments, s)
+	}
+	return segments
+}
+
+// match is a field found by find.
+type match struct {
+	node   *yaml.Node
+	parent *yaml.Node
+	path   string
+}
+
+// find returns the fields matching the path segments in the node n, which
+// is at path.
+func find(n *yaml.Node, segments []segment, path string) []match {
+	if n.Kind == yaml.DocumentNode && len(n.Content) == 1 {
+		n = n.Content[0]
+	}
+	if len(segments) == 0 || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	s := segments[0]
+	value := mappingValue(n, s.name)
+	if value == nil {
+		return nil
+	}
+	fieldPath := s.name
+	if path != "" {
+		fieldPath = path + "." + s.name
+	}
+	if !s.list {
+		if len(segments) == 1 {
+			return []match{{node: value, parent: n, path: fieldPath}}
+		}
+		return find(value, segments[1:], fieldPath)
+	}
+	if value.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var matches []match
+	for i, e := range value.Content {
+		elemPath := fmt.Sprintf("%s[%d]", fieldPath, i)
+		if len(segments) == 1 {
+			matches = append(matches, match{node: e, parent: value, path: elemPath})
+			continue
+		}
+		matches = append(matches, find(e, segments[1:], elemPath)...)
+	}
+	return matches
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalarField(n *yaml.Node, key string) string {
+	if key == "" || n == nil || n.Kind != yaml.MappingNode {
+		return ""
+	}
+	if v := mappingValue(n, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
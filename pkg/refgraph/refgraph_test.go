@@ -0,0 +1,157 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refgraph
+
+import (
+	"testing"
+
+	fnresult "github.com/GoogleContainerTools/kpt/pkg/api/fnresult/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func TestDefaultCatalog(t *testing.T) {
+	resources := []string{`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: ns
+  annotations:
+    config.kubernetes.io/path: deployment.yaml
+spec:
+  template:
+    spec:
+      serviceAccountName: app
+      containers:
+      - name: app
+        envFrom:
+        - configMapRef:
+            name: app-config
+        env:
+        - name: PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: app-secret
+              key: password
+      volumes:
+      - name: config
+        configMap:
+          name: app-config
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: ns
+  annotations:
+    config.kubernetes.io/path: cm.yaml
+`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns
+  annotations:
+    config.kubernetes.io/path: ns.yaml
+`, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: app
+  annotations:
+    config.kubernetes.io/path: crb.yaml
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: view
+subjects:
+- kind: ServiceAccount
+  name: app
+  namespace: ns
+- kind: User
+  name: jane
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: fn-config
+  namespace: ns
+  annotations:
+    config.kubernetes.io/local-config: "true"
+`}
+	var nodes []*yaml.RNode
+	for _, r := range resources {
+		nodes = append(nodes, yaml.MustParse(r))
+	}
+
+	graph, err := DefaultCatalog().Build(nodes)
+	require.NoError(t, err)
+	assert.Equal(t, "ReferenceGraph", graph.Kind)
+
+	deployment := fnresult.ResourceRef{Group: "apps", Kind: "Deployment", Name: "app", Namespace: "ns", File: "deployment.yaml"}
+	cm := fnresult.ResourceRef{Kind: "ConfigMap", Name: "app-config", Namespace: "ns", File: "cm.yaml"}
+	ns := fnresult.ResourceRef{Kind: "Namespace", Name: "ns", File: "ns.yaml"}
+	crb := fnresult.ResourceRef{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding", Name: "app", File: "crb.yaml"}
+	sa := fnresult.ResourceRef{Kind: "ServiceAccount", Name: "app", Namespace: "ns"}
+	assert.Equal(t, []fnresult.Reference{
+		{From: deployment, Field: "metadata.namespace", To: ns},
+		{From: deployment, Field: "spec.template.spec.containers[0].envFrom[0].configMapRef.name", To: cm},
+		{From: deployment, Field: "spec.template.spec.containers[0].env[0].valueFrom.secretKeyRef.name",
+			To: fnresult.ResourceRef{Kind: "Secret", Name: "app-secret", Namespace: "ns"}, Missing: true},
+		{From: deployment, Field: "spec.template.spec.volumes[0].configMap.name", To: cm},
+		{From: deployment, Field: "spec.template.spec.serviceAccountName", To: sa, Missing: true},
+		{From: cm, Field: "metadata.namespace", To: ns},
+		{From: crb, Field: "roleRef.name",
+			To: fnresult.ResourceRef{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"}, Missing: true},
+		{From: crb, Field: "subjects[0].name", To: sa, Missing: true},
+	}, graph.Items)
+}
+
+func TestCatalog_Validate(t *testing.T) {
+	tests := map[string]struct {
+		catalog Catalog
+		errMsg  string
+	}{
+		"default catalog": {
+			catalog: DefaultCatalog(),
+		},
+		"no targets": {
+			catalog: Catalog{{Path: "spec.name"}},
+			errMsg:  "rule 0: targets must not be empty",
+		},
+		"several targets without kind field": {
+			catalog: Catalog{{Path: "spec.name", Targets: []schema.GroupKind{{Kind: "A"}, {Kind: "B"}}}},
+			errMsg:  "rule 0: kindField is required for more than one target",
+		},
+		"invalid path": {
+			catalog: Catalog{{Path: "spec..name", Targets: []schema.GroupKind{{Kind: "A"}}}},
+			errMsg:  `rule 0: invalid path "spec..name"`,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			err := tc.catalog.Validate()
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
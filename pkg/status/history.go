@@ -0,0 +1,182 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// HistoryEntry is the record of a single status poll that is appended to
+// the status history file.
+type HistoryEntry struct {
+	// Start is the time polling started.
+	Start time.Time `json:"start"`
+	// TimeToReady is the time it took for all resources to become Current.
+	// It is nil if not all resources became Current.
+	TimeToReady *metav1.Duration `json:"timeToReady,omitempty"`
+	// Resources is the status history of the polled resources.
+	Resources []ResourceHistory `json:"resources"`
+}
+
+// ResourceHistory is the status history of a single resource.
+type ResourceHistory struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Statuses are the status changes of the resource in the order they
+	// were observed.
+	Statuses []StatusChange `json:"statuses,omitempty"`
+	// TimeToReady is the time it took for the resource to become Current.
+	// It is nil if the resource didn't become Current.
+	TimeToReady *metav1.Duration `json:"timeToReady,omitempty"`
+}
+
+// StatusChange is a status of a resource and when it was observed,
+// relative to the start of polling.
+type StatusChange struct {
+	Status  status.Status   `json:"status"`
+	Elapsed metav1.Duration `json:"elapsed"`
+}
+
+// ID returns the identifier of the resource in the format used by the
+// status printers, e.g. "deployment.apps/default/foo".
+func (r ResourceHistory) ID() string {
+	gk := r.Kind
+	if r.Group != "" {
+		gk += "." + r.Group
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(gk), r.Namespace, r.Name)
+}
+
+// Status returns the last observed status of the resource, or Unknown if
+// no status was observed.
+func (r ResourceHistory) Status() status.Status {
+	if len(r.Statuses) == 0 {
+		return status.UnknownStatus
+	}
+	return r.Statuses[len(r.Statuses)-1].Status
+}
+
+// HistoryRecorder records the status changes of resources observed from
+// the events of a status poller. It is safe for concurrent use.
+type HistoryRecorder struct {
+	mu        sync.Mutex
+	start     time.Time
+	ids       object.ObjMetadataSet
+	resources map[object.ObjMetadata]*ResourceHistory
+	now       func() time.Time
+}
+
+// NewHistoryRecorder returns a recorder for the resources ids that uses
+// now as its clock. Recording starts at the time returned by now.
+func NewHistoryRecorder(ids object.ObjMetadataSet, now func() time.Time) *HistoryRecorder {
+	h := &HistoryRecorder{
+		start:     now(),
+		ids:       ids,
+		resources: map[object.ObjMetadata]*ResourceHistory{},
+		now:       now,
+	}
+	for _, id := range ids {
+		h.resources[id] = &ResourceHistory{
+			Group:     id.GroupKind.Group,
+			Kind:      id.GroupKind.Kind,
+			Namespace: id.Namespace,
+			Name:      id.Name,
+		}
+	}
+	return h
+}
+
+// Observe records the status in e if it is a resource update event for
+// one of the recorded resources and the status has changed.
+func (h *HistoryRecorder) Observe(e event.Event) {
+	if e.Type != event.ResourceUpdateEvent || e.Resource == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	r, found := h.resources[e.Resource.Identifier]
+	if !found || (len(r.Statuses) > 0 && r.Status() == e.Resource.Status) {
+		return
+	}
+	r.Statuses = append(r.Statuses, StatusChange{
+		Status:  e.Resource.Status,
+		Elapsed: metav1.Duration{Duration: h.Elapsed()},
+	})
+}
+
+// Elapsed returns the time since recording started.
+func (h *HistoryRecorder) Elapsed() time.Duration {
+	return h.now().Sub(h.start)
+}
+
+// Entry returns the history entry for the statuses recorded so far.
+// A resource is ready when its last observed status is Current, and its
+// time to ready is when it last became Current.
+func (h *HistoryRecorder) Entry() HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entry := HistoryEntry{Start: h.start.UTC()}
+	allReady := true
+	var timeToReady time.Duration
+	for _, id := range h.ids {
+		r := *h.resources[id]
+		r.Statuses = append([]StatusChange(nil), r.Statuses...)
+		if r.Status() == status.CurrentStatus {
+			ready := r.Statuses[len(r.Statuses)-1].Elapsed
+			r.TimeToReady = &ready
+			if ready.Duration > timeToReady {
+				timeToReady = ready.Duration
+			}
+		} else {
+			allReady = false
+		}
+		entry.Resources = append(entry.Resources, r)
+	}
+	if allReady {
+		entry.TimeToReady = &metav1.Duration{Duration: timeToReady}
+	}
+	return entry
+}
+
+// AppendHistory appends entry to the status history file at path, which
+// holds one JSON encoded HistoryEntry per line. The file is created if it
+// doesn't exist.
+func AppendHistory(path string, entry HistoryEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open status history file: %w", err)
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write status history file: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestHistoryRecorder(t *testing.T) {
+	dep := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "foo",
+	}
+	cm := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "bar",
+	}
+	update := func(id object.ObjMetadata, s status.Status) event.Event {
+		return event.Event{
+			Type:     event.ResourceUpdateEvent,
+			Resource: &event.ResourceStatus{Identifier: id, Status: s},
+		}
+	}
+	seconds := func(n int) metav1.Duration {
+		return metav1.Duration{Duration: time.Duration(n) * time.Second}
+	}
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := start
+	h := NewHistoryRecorder(object.ObjMetadataSet{dep, cm}, func() time.Time { return now })
+
+	h.Observe(update(cm, status.CurrentStatus))
+	now = start.Add(2 * time.Second)
+	h.Observe(update(dep, status.InProgressStatus))
+	now = start.Add(4 * time.Second)
+	// unchanged statuses and other events are not recorded.
+	h.Observe(update(dep, status.InProgressStatus))
+	h.Observe(event.Event{Type: event.SyncEvent})
+
+	entry := h.Entry()
+	assert.Nil(t, entry.TimeToReady)
+	assert.Equal(t, status.InProgressStatus, entry.Resources[0].Status())
+	assert.Nil(t, entry.Resources[0].TimeToReady)
+
+	now = start.Add(7 * time.Second)
+	h.Observe(update(dep, status.CurrentStatus))
+	ready1, ready2 := seconds(7), seconds(0)
+	assert.Equal(t, HistoryEntry{
+		Start:       start,
+		TimeToReady: &ready1,
+		Resources: []ResourceHistory{
+			{
+				Group:     "apps",
+				Kind:      "Deployment",
+				Namespace: "default",
+				Name:      "foo",
+				Statuses: []StatusChange{
+					{Status: status.InProgressStatus, Elapsed: seconds(2)},
+					{Status: status.CurrentStatus, Elapsed: seconds(7)},
+				},
+				TimeToReady: &ready1,
+			},
+			{
+				Kind:      "ConfigMap",
+				Namespace: "default",
+				Name:      "bar",
+				Statuses: []StatusChange{
+					{Status: status.CurrentStatus, Elapsed: seconds(0)},
+				},
+				TimeToReady: &ready2,
+			},
+		},
+	}, h.Entry())
+	assert.Equal(t, "deployment.apps/default/foo", entry.Resources[0].ID())
+	assert.Equal(t, "configmap/default/bar", entry.Resources[1].ID())
+}
+
+func TestAppendHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	ready := metav1.Duration{Duration: 3 * time.Second}
+	entries := []HistoryEntry{
+		{Start: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{Start: time.Date(2026, 1, 3, 3, 4, 5, 0, time.UTC), TimeToReady: &ready},
+	}
+	for _, e := range entries {
+		require.NoError(t, AppendHistory(path, e))
+	}
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+	for i, l := range lines {
+		var e HistoryEntry
+		require.NoError(t, json.Unmarshal([]byte(l), &e))
+		assert.Equal(t, entries[i], e)
+	}
+	assert.Contains(t, lines[1], `"timeToReady":"3s"`)
+}
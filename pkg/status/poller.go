@@ -17,6 +17,7 @@ package status
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader"
@@ -33,12 +34,7 @@ func NewStatusPoller(f util.Factory) (*polling.StatusPoller, error) {
 
 	return polling.NewStatusPollerFromFactory(f, polling.Options{
 		CustomStatusReaders: []engine.StatusReader{
-			&ConfigConnectorStatusReader{
-				Mapper: mapper,
-			},
-			&RolloutStatusReader{
-				Mapper: mapper,
-			},
+			newStatusReader(mapper),
 		},
 	})
 }
@@ -58,13 +54,21 @@ func NewStatusWatcher(f util.Factory) (watcher.StatusWatcher, error) {
 		DynamicClient: dynamicClient,
 		Mapper:        mapper,
 		ResyncPeriod:  1 * time.Hour,
-		StatusReader: statusreaders.NewStatusReader(
-			mapper,
-			NewConfigConnectorStatusReader(mapper),
-			NewRolloutStatusReader(mapper)),
+		StatusReader:  newStatusReader(mapper),
 		ClusterReader: &clusterreader.DynamicClusterReader{
 			DynamicClient: dynamicClient,
 			Mapper:        mapper,
 		},
 	}, nil
 }
+
+// newStatusReader returns the status reader for all resources. Objects with
+// the ReadyWhenAnnotation are ready when their condition is met, and the
+// status of other objects is computed by the Config Connector, Rollout or
+// default status readers.
+func newStatusReader(mapper meta.RESTMapper) engine.StatusReader {
+	return NewReadyWhenStatusReader(mapper, statusreaders.NewStatusReader(
+		mapper,
+		NewConfigConnectorStatusReader(mapper),
+		NewRolloutStatusReader(mapper)))
+}
@@ -0,0 +1,302 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// ReadyWhenAnnotation sets a custom readiness condition for an object. The
+// object is Current once the condition is met, instead of when its kstatus
+// conditions say so, which makes it possible to wait for resources that
+// don't follow the kstatus conventions. The condition is one or more
+// comparisons of the form `PATH == VALUE` or `PATH != VALUE`, or just
+// `PATH`, joined by `&&`. PATH is a JSONPath expression without the braces,
+// e.g. `status.phase` or `status.conditions[?(@.type=="Ready")].status`,
+// and VALUE is a quoted string or a number or boolean.
+const ReadyWhenAnnotation = "kpt.dev/ready-when"
+
+// ReadyCondition is a parsed ReadyWhenAnnotation.
+type ReadyCondition struct {
+	clauses []readyClause
+}
+
+type readyClause struct {
+	text  string
+	path  *jsonpath.JSONPath
+	op    string
+	value string
+}
+
+// ParseReadyCondition parses the value of a ReadyWhenAnnotation.
+func ParseReadyCondition(expr string) (*ReadyCondition, error) {
+	c := &ReadyCondition{}
+	for _, text := range splitTopLevel(expr, "&&") {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, fmt.Errorf("empty condition in %q", expr)
+		}
+		clause := readyClause{text: text}
+		path := text
+		if i, op := findOperator(text); i >= 0 {
+			path = text[:i]
+			clause.op = op
+			value, err := parseValue(strings.TrimSpace(text[i+len(op):]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in %q: %w", text, err)
+			}
+			clause.value = value
+		}
+		path = strings.TrimPrefix(strings.TrimSpace(path), ".")
+		if path == "" {
+			return nil, fmt.Errorf("missing field path in %q", text)
+		}
+		jp := jsonpath.New(ReadyWhenAnnotation).AllowMissingKeys(true)
+		if err := jp.Parse("{." + path + "}"); err != nil {
+			return nil, fmt.Errorf("invalid field path in %q: %w", text, err)
+		}
+		clause.path = jp
+		c.clauses = append(c.clauses, clause)
+	}
+	return c, nil
+}
+
+// Evaluate returns whether the condition is met by u. If it isn't, the
+// message names the first comparison that isn't met.
+func (c *ReadyCondition) Evaluate(u *unstructured.Unstructured) (bool, string, error) {
+	for _, clause := range c.clauses {
+		results, err := clause.path.FindResults(u.Object)
+		if err != nil {
+			return false, "", err
+		}
+		var values []string
+		truthy := false
+		for _, r := range results {
+			for _, v := range r {
+				if !v.IsValid() || !v.CanInterface() || v.Interface() == nil {
+					continue
+				}
+				s := formatValue(v.Interface())
+				values = append(values, s)
+				truthy = truthy || (s != "" && s != "false" && s != "0")
+			}
+		}
+		met := false
+		switch clause.op {
+		case "":
+			met = truthy
+		case "==":
+			for _, v := range values {
+				met = met || v == clause.value
+			}
+		case "!=":
+			met = len(values) > 0
+			for _, v := range values {
+				met = met && v != clause.value
+			}
+		}
+		if !met {
+			return false, fmt.Sprintf("Waiting for %s", clause.text), nil
+		}
+	}
+	return true, "", nil
+}
+
+// ReadyWhenStatusReader computes the status of objects with the
+// ReadyWhenAnnotation from their readiness condition. The status of other
+// objects is read with Delegate.
+type ReadyWhenStatusReader struct {
+	Mapper   meta.RESTMapper
+	Delegate engine.StatusReader
+}
+
+// NewReadyWhenStatusReader returns a ReadyWhenStatusReader that reads the
+// status of objects without the annotation with delegate.
+func NewReadyWhenStatusReader(mapper meta.RESTMapper, delegate engine.StatusReader) engine.StatusReader {
+	return &ReadyWhenStatusReader{
+		Mapper:   mapper,
+		Delegate: delegate,
+	}
+}
+
+var _ engine.StatusReader = &ReadyWhenStatusReader{}
+
+// Supports returns true for all resources, since any object can have the
+// annotation.
+func (r *ReadyWhenStatusReader) Supports(schema.GroupKind) bool {
+	return true
+}
+
+func (r *ReadyWhenStatusReader) ReadStatus(ctx context.Context, reader engine.ClusterReader, id object.ObjMetadata) (*event.ResourceStatus, error) {
+	gvk, err := toGVK(id.GroupKind, r.Mapper)
+	if err != nil {
+		return newUnknownResourceStatus(id, nil, err), nil
+	}
+
+	key := types.NamespacedName{
+		Name:      id.Name,
+		Namespace: id.Namespace,
+	}
+
+	var u unstructured.Unstructured
+	u.SetGroupVersionKind(gvk)
+	err = reader.Get(ctx, key, &u)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		if apierrors.IsNotFound(err) {
+			return newResourceStatus(id, status.NotFoundStatus, &u, "Resource not found"), nil
+		}
+		return newUnknownResourceStatus(id, nil, err), nil
+	}
+
+	// The object is passed on, so the delegate doesn't get it again.
+	return r.ReadStatusForObject(ctx, reader, &u)
+}
+
+func (r *ReadyWhenStatusReader) ReadStatusForObject(ctx context.Context, reader engine.ClusterReader, u *unstructured.Unstructured) (*event.ResourceStatus, error) {
+	expr, found := u.GetAnnotations()[ReadyWhenAnnotation]
+	if !found {
+		return r.Delegate.ReadStatusForObject(ctx, reader, u)
+	}
+	id := object.UnstructuredToObjMetadata(u)
+
+	if u.GetDeletionTimestamp() != nil {
+		return newResourceStatus(id, status.TerminatingStatus, u, "Resource scheduled for deletion"), nil
+	}
+
+	condition, err := ParseReadyCondition(expr)
+	if err != nil {
+		return newResourceStatus(id, status.FailedStatus, u,
+			fmt.Sprintf("Invalid %s annotation: %v", ReadyWhenAnnotation, err)), nil
+	}
+
+	// The condition is only checked once the controller has observed the
+	// latest generation, if it reports it, so it isn't met by a stale status.
+	generation := u.GetGeneration()
+	observed, found, err := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	if err == nil && found && observed < generation {
+		return newResourceStatus(id, status.InProgressStatus, u,
+			fmt.Sprintf("Waiting for the controller to observe generation %d", generation)), nil
+	}
+
+	ready, msg, err := condition.Evaluate(u)
+	if err != nil {
+		return newUnknownResourceStatus(id, u, err), nil
+	}
+	if !ready {
+		return newResourceStatus(id, status.InProgressStatus, u, msg), nil
+	}
+	return newResourceStatus(id, status.CurrentStatus, u, "Resource is ready"), nil
+}
+
+// splitTopLevel splits s around sep, ignoring sep in quotes and brackets.
+func splitTopLevel(s, sep string) []string {
+	var parts []string
+	start := 0
+	forEachTopLevel(s, func(i int) bool {
+		if strings.HasPrefix(s[i:], sep) {
+			parts = append(parts, s[start:i])
+			start = i + len(sep)
+		}
+		return true
+	})
+	return append(parts, s[start:])
+}
+
+// findOperator returns the index and the comparison operator in s, or -1
+// if s has no comparison outside quotes and brackets.
+func findOperator(s string) (int, string) {
+	index, op := -1, ""
+	forEachTopLevel(s, func(i int) bool {
+		for _, o := range []string{"==", "!="} {
+			if strings.HasPrefix(s[i:], o) {
+				index, op = i, o
+				return false
+			}
+		}
+		return true
+	})
+	return index, op
+}
+
+// forEachTopLevel calls fn with the index of every byte of s outside quotes
+// and brackets, until fn returns false.
+func forEachTopLevel(s string, fn func(i int) bool) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+			continue
+		case c == '[' || c == '(':
+			depth++
+			continue
+		case c == ']' || c == ')':
+			depth--
+			continue
+		}
+		if depth == 0 && !fn(i) {
+			return
+		}
+	}
+}
+
+func parseValue(s string) (string, error) {
+	switch {
+	case s == "":
+		return "", fmt.Errorf("missing value")
+	case strings.HasPrefix(s, `"`):
+		return strconv.Unquote(s)
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("unterminated string %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	}
+	return s, nil
+}
+
+func formatValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
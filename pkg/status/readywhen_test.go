@@ -0,0 +1,193 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/statusreaders"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/testutil"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	fakemapper "sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestParseReadyCondition(t *testing.T) {
+	tests := map[string]struct {
+		expr   string
+		errMsg string
+	}{
+		"comparison":       {expr: `status.phase == "Bound"`},
+		"leading dot":      {expr: `.status.phase != 'Pending'`},
+		"field":            {expr: `status.ready`},
+		"conjunction":      {expr: `status.ready && status.replicas == 3`},
+		"filter":           {expr: `status.conditions[?(@.type=="Ready")].status == "True"`},
+		"empty condition":  {expr: `status.ready &&`, errMsg: "empty condition"},
+		"missing path":     {expr: `== "Bound"`, errMsg: "missing field path"},
+		"missing value":    {expr: `status.phase ==`, errMsg: "missing value"},
+		"invalid string":   {expr: `status.phase == "Bound`, errMsg: "invalid value"},
+		"invalid jsonpath": {expr: `status.conditions[?(@.type==`, errMsg: "invalid field path"},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			_, err := ParseReadyCondition(tc.expr)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReadyWhenReadStatus(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Database"}
+	testCases := map[string]struct {
+		resource        string
+		expectedStatus  status.Status
+		expectedMessage string
+	}{
+		"condition is met": {
+			resource: `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  namespace: default
+  annotations:
+    kpt.dev/ready-when: status.phase == "Bound" && status.replicas == 3
+status:
+  phase: Bound
+  replicas: 3
+`,
+			expectedStatus:  status.CurrentStatus,
+			expectedMessage: "Resource is ready",
+		},
+		"condition is not met": {
+			resource: `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  namespace: default
+  annotations:
+    kpt.dev/ready-when: status.phase == "Bound" && status.replicas == 3
+status:
+  phase: Bound
+  replicas: 1
+`,
+			expectedStatus:  status.InProgressStatus,
+			expectedMessage: "Waiting for status.replicas == 3",
+		},
+		"field is missing": {
+			resource: `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  namespace: default
+  annotations:
+    kpt.dev/ready-when: status.phase != "Pending"
+`,
+			expectedStatus:  status.InProgressStatus,
+			expectedMessage: `Waiting for status.phase != "Pending"`,
+		},
+		"condition with filter": {
+			resource: `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  namespace: default
+  annotations:
+    kpt.dev/ready-when: status.conditions[?(@.type=="Available")].status == "True"
+status:
+  conditions:
+  - type: Ready
+    status: "False"
+  - type: Available
+    status: "True"
+`,
+			expectedStatus:  status.CurrentStatus,
+			expectedMessage: "Resource is ready",
+		},
+		"latest generation is not observed": {
+			resource: `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  namespace: default
+  generation: 2
+  annotations:
+    kpt.dev/ready-when: status.phase == "Bound"
+status:
+  observedGeneration: 1
+  phase: Bound
+`,
+			expectedStatus:  status.InProgressStatus,
+			expectedMessage: "Waiting for the controller to observe generation 2",
+		},
+		"invalid condition": {
+			resource: `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  namespace: default
+  annotations:
+    kpt.dev/ready-when: status.phase ==
+`,
+			expectedStatus:  status.FailedStatus,
+			expectedMessage: "Invalid kpt.dev/ready-when annotation: invalid value",
+		},
+		"no annotation": {
+			resource: `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  namespace: default
+status:
+  phase: Bound
+`,
+			expectedStatus:  status.CurrentStatus,
+			expectedMessage: "Resource is current",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			obj := testutil.YamlToUnstructured(t, tc.resource)
+			fakeClusterReader := &fakeClusterReader{
+				getResource: obj,
+			}
+			fakeMapper := fakemapper.NewFakeRESTMapper(gvk)
+			statusReader := NewReadyWhenStatusReader(fakeMapper, statusreaders.NewDefaultStatusReader(fakeMapper))
+
+			res, err := statusReader.ReadStatus(context.Background(), fakeClusterReader, object.UnstructuredToObjMetadata(obj))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.Status)
+			assert.Contains(t, res.Message, tc.expectedMessage)
+			assert.Equal(t, 1, fakeClusterReader.gets, "the object must only be read once")
+		})
+	}
+}
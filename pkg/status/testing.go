@@ -28,12 +28,15 @@ type fakeClusterReader struct {
 
 	getResource *unstructured.Unstructured
 	getErr      error
+	// gets is the number of calls to Get.
+	gets int
 
 	listResources *unstructured.UnstructuredList
 	listErr       error
 }
 
 func (f *fakeClusterReader) Get(_ context.Context, _ client.ObjectKey, u *unstructured.Unstructured) error {
+	f.gets++
 	if f.getResource != nil {
 		u.Object = f.getResource.Object
 	}
@@ -30,6 +30,8 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/v1/match"
 
+	"github.com/GoogleContainerTools/kpt/internal/util/httputil"
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
 	"github.com/GoogleContainerTools/kpt/pkg/oci"
 	"github.com/google/go-containerregistry/pkg/gcrane"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -63,6 +65,7 @@ func (r *Client) PushWasm(ctx context.Context, wasmFile string, imageName string
 	options := []remote.Option{
 		remote.WithAuthFromKeychain(gcrane.Keychain),
 		remote.WithContext(ctx),
+		remote.WithTransport(httputil.Transport()),
 	}
 
 	rmt, err := remote.Get(tag, options...)
@@ -178,9 +181,13 @@ func (r *Client) LoadWasm(ctx context.Context, imageName string) (io.ReadCloser,
 	}
 
 	fetcher := func() (io.ReadCloser, error) {
+		if err := offline.Check(fmt.Sprintf("pulling image %q, which is not cached,", imageName)); err != nil {
+			return nil, err
+		}
 		options := []remote.Option{
 			remote.WithContext(ctx),
 			remote.WithAuthFromKeychain(gcrane.Keychain),
+			remote.WithTransport(httputil.Transport()),
 			remote.WithPlatform(v1.Platform{
 				Architecture: "wasm",
 				OS:           "js",
@@ -114,6 +114,32 @@ type RemoteSyncStatus struct {
 
 	// Internal only. SyncCreated describes if the external sync has been created.
 	SyncCreated bool `json:"syncCreated"`
+
+	// SyncCommit is the commit (or OCI digest) last synced by the external sync
+	// in the target cluster.
+	SyncCommit string `json:"syncCommit,omitempty"`
+
+	// Health summarizes the state of the external sync and the resources it manages
+	// in the target cluster. One of Healthy, Progressing, Degraded or Unknown.
+	Health string `json:"health,omitempty"`
+
+	// DriftedResources lists the resources managed by the external sync whose state
+	// in the target cluster no longer matches the synced source, for example because
+	// they were modified or deleted out-of-band.
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
+}
+
+// DriftedResource identifies a resource in the target cluster that has drifted
+// from the synced source.
+type DriftedResource struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Status is the status of the resource reported by the external sync,
+	// for example NotFound or Failed.
+	Status string `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
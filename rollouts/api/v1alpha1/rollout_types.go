@@ -44,6 +44,10 @@ type RolloutSpec struct {
 
 	// Strategy specifies the rollout strategy to use for this rollout.
 	Strategy RolloutStrategy `json:"strategy"`
+
+	// Schedule restricts when changes are rolled out to the clusters.
+	// Changes are rolled out at any time if it is not specified.
+	Schedule *RolloutSchedule `json:"schedule,omitempty"`
 }
 
 type ClusterTargetSelector struct {
@@ -275,6 +279,57 @@ type RolloutStrategy struct {
 	Progressive   *StrategyProgressive   `json:"progressive,omitempty"`
 }
 
+// RolloutSchedule defines when changes are rolled out. Outside of the
+// windows and during the freezes, no RemoteSync is created, updated or
+// deleted, so new package revisions wait for the next allowed time.
+type RolloutSchedule struct {
+	// TimeZone is the IANA time zone the windows and freezes are in, e.g.
+	// `America/New_York`. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows are the recurring windows changes are rolled out in. Changes
+	// are rolled out at any time outside of the freezes if there are none.
+	Windows []RolloutWindow `json:"windows,omitempty"`
+
+	// Freezes are the periods no changes are rolled out in, even within a
+	// window.
+	Freezes []RolloutFreeze `json:"freezes,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type Weekday string
+
+// RolloutWindow is a window changes are rolled out in, on some days of the
+// week.
+type RolloutWindow struct {
+	// Days are the days of the week the window starts on. Defaults to every
+	// day.
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the time of day the window starts at, in 24-hour HH:MM
+	// format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the time of day the window ends at, in 24-hour HH:MM format. A
+	// window that ends before it starts ends on the next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+}
+
+// RolloutFreeze is a period no changes are rolled out in, e.g. during a
+// holiday.
+type RolloutFreeze struct {
+	// Name describes the freeze.
+	Name string `json:"name,omitempty"`
+
+	// Start is the time the freeze starts at, in YYYY-MM-DDTHH:MM format.
+	Start string `json:"start"`
+
+	// End is the time the freeze ends at, in YYYY-MM-DDTHH:MM format.
+	End string `json:"end"`
+}
+
 // RolloutStatus defines the observed state of Rollout
 type RolloutStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -286,6 +341,24 @@ type RolloutStatus struct {
 	WaveStatuses []WaveStatus `json:"waveStatuses,omitempty"`
 
 	ClusterStatuses []ClusterStatus `json:"clusterStatuses,omitempty"`
+
+	// Schedule is the state of the schedule of the rollout, if it has one.
+	Schedule *ScheduleStatus `json:"schedule,omitempty"`
+}
+
+// ScheduleStatus is the state of the schedule of a rollout.
+type ScheduleStatus struct {
+	// Blocked is true if the schedule doesn't allow rolling out changes now.
+	Blocked bool `json:"blocked,omitempty"`
+
+	// Reason is why changes are blocked: OutsideWindow or Freeze.
+	Reason string `json:"reason,omitempty"`
+
+	// Freeze is the name of the freeze changes are blocked by.
+	Freeze string `json:"freeze,omitempty"`
+
+	// BlockedUntil is the time changes are blocked until.
+	BlockedUntil *metav1.Time `json:"blockedUntil,omitempty"`
 }
 
 type WaveStatus struct {
@@ -304,6 +377,16 @@ type PackageStatus struct {
 	PackageID  string `json:"packageId"`
 	SyncStatus string `json:"syncStatus"`
 	Status     string `json:"status"`
+
+	// SyncCommit is the commit last synced to the cluster.
+	SyncCommit string `json:"syncCommit,omitempty"`
+
+	// Health summarizes the health of the package in the cluster.
+	Health string `json:"health,omitempty"`
+
+	// DriftedResources is the number of resources in the cluster that drifted
+	// from the synced commit.
+	DriftedResources int `json:"driftedResources,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -23,7 +23,9 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -82,10 +85,21 @@ var (
 const (
 	conditionReconciling = "Reconciling"
 	conditionStalled     = "Stalled"
-
-	reasonCreateSync = "CreateSync"
-	reasonUpdateSync = "UpdateSync"
-	reasonError      = "Error"
+	conditionHealthy     = "Healthy"
+	conditionDrifted     = "Drifted"
+
+	reasonCreateSync    = "CreateSync"
+	reasonUpdateSync    = "UpdateSync"
+	reasonError         = "Error"
+	reasonSynced        = "Synced"
+	reasonDriftDetected = "DriftDetected"
+	reasonDriftResolved = "DriftResolved"
+	reasonNoDrift       = "NoDrift"
+
+	// defaultPollInterval is how often the external sync is polled for its
+	// status when no watch events arrive, so drift of the managed resources
+	// is reported even if the RootSync/RepoSync itself doesn't change.
+	defaultPollInterval = time.Minute
 )
 
 // RemoteSyncReconciler reconciles a RemoteSync object
@@ -93,6 +107,14 @@ type RemoteSyncReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
+	// Recorder records events about changes in the health and drift of the
+	// external sync. If nil, one is created from the manager.
+	Recorder record.EventRecorder
+
+	// PollInterval is how often the external sync in the target cluster is
+	// polled. Defaults to defaultPollInterval.
+	PollInterval time.Duration
+
 	store *clusterstore.ClusterStore
 
 	// channel is where watchers put events to trigger new reconcilations based
@@ -107,6 +129,7 @@ type RemoteSyncReconciler struct {
 //+kubebuilder:rbac:groups=gitops.kpt.dev,resources=remotesyncs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gitops.kpt.dev,resources=remotesyncs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=gitops.kpt.dev,resources=remotesyncs/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -147,7 +170,7 @@ func (r *RemoteSyncReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				// Delete the external sync resource
 				err := r.deleteExternalResources(ctx, &remotesync)
 				if err != nil && !apierrors.IsNotFound(err) {
-					statusError := r.updateStatus(ctx, &remotesync, "", err)
+					statusError := r.updateStatus(ctx, &remotesync, nil, err)
 
 					if statusError != nil {
 						logger.Error(statusError, "Failed to update status")
@@ -173,72 +196,161 @@ func (r *RemoteSyncReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	syncStatus, syncError := r.syncExternalSync(ctx, &remotesync)
+	state, syncError := r.syncExternalSync(ctx, &remotesync)
 
-	if err := r.updateStatus(ctx, &remotesync, syncStatus, syncError); err != nil {
+	if err := r.updateStatus(ctx, &remotesync, state, syncError); err != nil {
 		logger.Error(err, "Failed to update status")
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, syncError
+	if syncError != nil {
+		return ctrl.Result{}, syncError
+	}
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, nil
 }
 
-func (r *RemoteSyncReconciler) syncExternalSync(ctx context.Context, rs *gitopsv1alpha1.RemoteSync) (string, error) {
+func (r *RemoteSyncReconciler) syncExternalSync(ctx context.Context, rs *gitopsv1alpha1.RemoteSync) (*syncState, error) {
 	clusterRef := &rs.Spec.ClusterRef
 
 	dynCl, err := r.getDynamicClientForCluster(ctx, clusterRef)
 	if err != nil {
-		return "", fmt.Errorf("failed to create client: %w", err)
+		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
 	if err := r.patchExternalSync(ctx, dynCl, rs); err != nil {
-		return "", fmt.Errorf("failed to create/update sync: %w", err)
+		return nil, fmt.Errorf("failed to create/update sync: %w", err)
 	}
 
 	r.setupWatches(ctx, getExternalSyncName(rs), rs.Namespace, rs.Spec.ClusterRef)
 
-	syncStatus, err := checkSyncStatus(ctx, dynCl, rs)
+	state, err := checkSyncStatus(ctx, dynCl, rs)
 	if err != nil {
-		return "", fmt.Errorf("failed to check status: %w", err)
+		return nil, fmt.Errorf("failed to check status: %w", err)
 	}
 
-	return syncStatus, nil
+	return state, nil
 }
 
-func (r *RemoteSyncReconciler) updateStatus(ctx context.Context, rs *gitopsv1alpha1.RemoteSync, syncStatus string, syncError error) error {
+func (r *RemoteSyncReconciler) updateStatus(ctx context.Context, rs *gitopsv1alpha1.RemoteSync, state *syncState, syncError error) error {
 	logger := klog.FromContext(ctx)
 
 	rsPrior := rs.DeepCopy()
+	setSyncStatus(rs, state, syncError)
+
+	if reflect.DeepEqual(rs.Status, rsPrior.Status) {
+		return nil
+	}
+
+	r.recordStatusEvents(rs, &rsPrior.Status)
+
+	logger.Info("Updating status")
+	return r.Client.Status().Update(ctx, rs)
+}
+
+// setSyncStatus updates the status of the RemoteSync from the observed state of
+// the external sync, or from the error encountered while syncing it.
+func setSyncStatus(rs *gitopsv1alpha1.RemoteSync, state *syncState, syncError error) {
 	conditions := &rs.Status.Conditions
 
 	if syncError == nil {
-		rs.Status.SyncStatus = syncStatus
+		rs.Status.SyncStatus = state.status
 		rs.Status.SyncCreated = true
+		rs.Status.Health = state.health()
+		if state.commit != "" {
+			rs.Status.SyncCommit = state.commit
+		}
 
 		meta.RemoveStatusCondition(conditions, conditionReconciling)
 		meta.RemoveStatusCondition(conditions, conditionStalled)
+
+		switch rs.Status.Health {
+		case healthHealthy:
+			meta.SetStatusCondition(conditions, metav1.Condition{Type: conditionHealthy, Status: metav1.ConditionTrue, Reason: reasonSynced,
+				Message: fmt.Sprintf("Synced commit %q", state.commit)})
+		case healthProgressing:
+			meta.SetStatusCondition(conditions, metav1.Condition{Type: conditionHealthy, Status: metav1.ConditionUnknown, Reason: state.status})
+		default:
+			meta.SetStatusCondition(conditions, metav1.Condition{Type: conditionHealthy, Status: metav1.ConditionFalse, Reason: state.status})
+		}
+
+		// Drift is only meaningful once the external sync has synced, so it
+		// is dropped while a new commit is being synced.
+		rs.Status.DriftedResources = state.drifted
+		switch {
+		case len(state.drifted) > 0:
+			meta.SetStatusCondition(conditions, metav1.Condition{Type: conditionDrifted, Status: metav1.ConditionTrue, Reason: reasonDriftDetected,
+				Message: fmt.Sprintf("%d resource(s) in the target cluster drifted from the synced commit", len(state.drifted))})
+		case state.status == "Synced":
+			meta.SetStatusCondition(conditions, metav1.Condition{Type: conditionDrifted, Status: metav1.ConditionFalse, Reason: reasonNoDrift})
+		default:
+			meta.RemoveStatusCondition(conditions, conditionDrifted)
+		}
 	} else {
 		reconcileReason := reasonUpdateSync
 
 		rs.Status.SyncStatus = "Unknown"
+		rs.Status.Health = healthUnknown
 
 		if !rs.Status.SyncCreated {
 			rs.Status.SyncStatus = ""
+			rs.Status.Health = ""
 			reconcileReason = reasonCreateSync
 		}
 
 		meta.SetStatusCondition(conditions, metav1.Condition{Type: conditionReconciling, Status: metav1.ConditionTrue, Reason: reconcileReason})
 		meta.SetStatusCondition(conditions, metav1.Condition{Type: conditionStalled, Status: metav1.ConditionTrue, Reason: reasonError, Message: syncError.Error()})
+		meta.SetStatusCondition(conditions, metav1.Condition{Type: conditionHealthy, Status: metav1.ConditionUnknown, Reason: reasonError})
 	}
 
 	rs.Status.ObservedGeneration = rs.Generation
+}
 
-	if reflect.DeepEqual(rs.Status, rsPrior.Status) {
-		return nil
+// recordStatusEvents emits events for the transitions between the prior and
+// the current status of the RemoteSync.
+func (r *RemoteSyncReconciler) recordStatusEvents(rs *gitopsv1alpha1.RemoteSync, prior *gitopsv1alpha1.RemoteSyncStatus) {
+	if r.Recorder == nil {
+		return
 	}
 
-	logger.Info("Updating status")
-	return r.Client.Status().Update(ctx, rs)
+	if stalled := meta.FindStatusCondition(rs.Status.Conditions, conditionStalled); stalled != nil {
+		if priorStalled := meta.FindStatusCondition(prior.Conditions, conditionStalled); priorStalled == nil || priorStalled.Message != stalled.Message {
+			r.Recorder.Event(rs, corev1.EventTypeWarning, reasonError, stalled.Message)
+		}
+	}
+
+	if rs.Status.SyncStatus == "Synced" && (prior.SyncStatus != "Synced" || prior.SyncCommit != rs.Status.SyncCommit) {
+		r.Recorder.Eventf(rs, corev1.EventTypeNormal, reasonSynced, "Synced commit %q to cluster %q", rs.Status.SyncCommit, rs.Spec.ClusterRef.Name)
+	}
+
+	if len(rs.Status.DriftedResources) > 0 && !reflect.DeepEqual(rs.Status.DriftedResources, prior.DriftedResources) {
+		r.Recorder.Eventf(rs, corev1.EventTypeWarning, reasonDriftDetected, "%d resource(s) in cluster %q drifted from the synced commit: %s",
+			len(rs.Status.DriftedResources), rs.Spec.ClusterRef.Name, formatDriftedResources(rs.Status.DriftedResources))
+	} else if len(rs.Status.DriftedResources) == 0 && len(prior.DriftedResources) > 0 && rs.Status.SyncStatus == "Synced" {
+		r.Recorder.Eventf(rs, corev1.EventTypeNormal, reasonDriftResolved, "Resources in cluster %q match the synced commit", rs.Spec.ClusterRef.Name)
+	}
+}
+
+func formatDriftedResources(drifted []gitopsv1alpha1.DriftedResource) string {
+	var parts []string
+	for _, d := range drifted {
+		id := d.Kind
+		if d.Group != "" {
+			id = d.Kind + "." + d.Group
+		}
+		name := d.Name
+		if d.Namespace != "" {
+			name = d.Namespace + "/" + d.Name
+		}
+		parts = append(parts, fmt.Sprintf("%s %s (%s)", id, name, d.Status))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (r *RemoteSyncReconciler) pollInterval() time.Duration {
+	if r.PollInterval > 0 {
+		return r.PollInterval
+	}
+	return defaultPollInterval
 }
 
 // patchExternalSync patches the external sync in the remote clusters targeted by
@@ -441,6 +553,9 @@ func (r *RemoteSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.channel = make(chan event.GenericEvent, 10)
 	r.watchers = make(map[gitopsv1alpha1.ClusterRef]*watcher)
 	r.Client = mgr.GetClient()
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("remotesync-controller")
+	}
 	gkeclusterapis.AddToScheme(mgr.GetScheme())
 
 	if err := gitopsv1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
@@ -79,6 +79,9 @@ type RolloutReconciler struct {
 
 	mutex                 sync.Mutex
 	packageDiscoveryCache map[types.NamespacedName]*packagediscovery.PackageDiscovery
+
+	// now returns the current time. Defaults to time.Now.
+	now func() time.Time
 }
 
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
@@ -177,6 +180,7 @@ func (r *RolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
+	var requeueAfter time.Duration
 	if rollout.Spec.Clusters.SourceType == gitopsv1alpha1.GCPFleet &&
 		(rollout.Status.Overall == "Completed" || rollout.Status.Overall == "Stalled") {
 		// TODO (droot): The rollouts in completed/stalled state will not be reconciled
@@ -185,12 +189,28 @@ func (r *RolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		// This can be safely removed once we start monitoring fleet changes.
 		// Note: we watch containercluster types, so this problem doesn't exist for the
 		// KCC clusters.
-		return ctrl.Result{
-			RequeueAfter: 30 * time.Second,
-		}, nil
+		requeueAfter = 30 * time.Second
+	}
+
+	// the changes blocked by the schedule are rolled out once it allows them.
+	if schedule := rollout.Status.Schedule; schedule != nil && schedule.Blocked && schedule.BlockedUntil != nil {
+		untilAllowed := schedule.BlockedUntil.Sub(r.currentTime())
+		if untilAllowed < time.Second {
+			untilAllowed = time.Second
+		}
+		if requeueAfter == 0 || untilAllowed < requeueAfter {
+			requeueAfter = untilAllowed
+		}
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *RolloutReconciler) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
 }
 
 func (r *RolloutReconciler) getStrategy(ctx context.Context, rollout *gitopsv1alpha1.Rollout) (*gitopsv1alpha1.ProgressiveRolloutStrategy, error) {
@@ -310,6 +330,12 @@ func (r *RolloutReconciler) getPackageDiscoveryClient(rolloutNamespacedName type
 func (r *RolloutReconciler) reconcileRollout(ctx context.Context, rollout *gitopsv1alpha1.Rollout, strategy *gitopsv1alpha1.ProgressiveRolloutStrategy, targetClusters []clusterstore.Cluster,
 	discoveredPackages []packagediscovery.DiscoveredPackage) ([]gitopsv1alpha1.ClusterStatus, []gitopsv1alpha1.WaveStatus, error) {
 
+	schedule, err := evaluateSchedule(rollout.Spec.Schedule, r.currentTime())
+	if err != nil {
+		return nil, nil, err
+	}
+	rollout.Status.Schedule = schedule
+
 	packageClusterMatcherClient := packageclustermatcher.NewPackageClusterMatcher(targetClusters, discoveredPackages)
 	clusterPackages, err := packageClusterMatcherClient.GetClusterPackages(rollout.Spec.PackageToTargetMatcher)
 	if err != nil {
@@ -342,7 +368,7 @@ func (r *RolloutReconciler) reconcileRollout(ctx context.Context, rollout *gitop
 		waveTargets := thisWaveTargets.Targets
 		wave := thisWaveTargets.Wave
 
-		thisWaveInProgress, clusterStatuses, err := r.rolloutTargets(ctx, rollout, wave, waveTargets, pauseFutureWaves)
+		thisWaveInProgress, clusterStatuses, err := r.rolloutTargets(ctx, rollout, wave, waveTargets, pauseFutureWaves, schedule)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -528,7 +554,8 @@ func (r *RolloutReconciler) getWaveTargets(ctx context.Context, rollout *gitopsv
 	return allWaveTargets, nil
 }
 
-func (r *RolloutReconciler) rolloutTargets(ctx context.Context, rollout *gitopsv1alpha1.Rollout, wave *gitopsv1alpha1.Wave, targets *Targets, pauseWave bool) (bool, []gitopsv1alpha1.ClusterStatus, error) {
+func (r *RolloutReconciler) rolloutTargets(ctx context.Context, rollout *gitopsv1alpha1.Rollout, wave *gitopsv1alpha1.Wave, targets *Targets, pauseWave bool,
+	schedule *gitopsv1alpha1.ScheduleStatus) (bool, []gitopsv1alpha1.ClusterStatus, error) {
 	clusterStatuses := []gitopsv1alpha1.ClusterStatus{}
 	logger := klog.FromContext(ctx)
 
@@ -541,6 +568,11 @@ func (r *RolloutReconciler) rolloutTargets(ctx context.Context, rollout *gitopsv
 		waiting = "Waiting (Upcoming Wave)"
 	}
 
+	if schedule != nil && schedule.Blocked {
+		maxConcurrent = 0
+		waiting = scheduleWaitingStatus(schedule)
+	}
+
 	for _, target := range targets.Unchanged {
 		if !isRSSynced(target) {
 			concurrentUpdates++
@@ -583,12 +615,8 @@ func (r *RolloutReconciler) rolloutTargets(ctx context.Context, rollout *gitopsv
 			}
 			concurrentUpdates++
 			clusterStatuses = append(clusterStatuses, gitopsv1alpha1.ClusterStatus{
-				Name: target.Spec.ClusterRef.Name,
-				PackageStatus: gitopsv1alpha1.PackageStatus{
-					PackageID:  target.Name,
-					SyncStatus: target.Status.SyncStatus,
-					Status:     "Progressing",
-				},
+				Name:          target.Spec.ClusterRef.Name,
+				PackageStatus: newPackageStatus(target, "Progressing"),
 			})
 		} else {
 			clusterStatuses = append(clusterStatuses, gitopsv1alpha1.ClusterStatus{
@@ -631,12 +659,8 @@ func (r *RolloutReconciler) rolloutTargets(ctx context.Context, rollout *gitopsv
 		}
 
 		clusterStatuses = append(clusterStatuses, gitopsv1alpha1.ClusterStatus{
-			Name: target.Spec.ClusterRef.Name,
-			PackageStatus: gitopsv1alpha1.PackageStatus{
-				PackageID:  target.Name,
-				SyncStatus: target.Status.SyncStatus,
-				Status:     status,
-			},
+			Name:          target.Spec.ClusterRef.Name,
+			PackageStatus: newPackageStatus(target, status),
 		})
 	}
 
@@ -647,6 +671,19 @@ func (r *RolloutReconciler) rolloutTargets(ctx context.Context, rollout *gitopsv
 	return thisWaveInProgress, clusterStatuses, nil
 }
 
+// newPackageStatus reports the sync status, health and drift observed by the
+// RemoteSync for a package in its target cluster.
+func newPackageStatus(rs *gitopsv1alpha1.RemoteSync, status string) gitopsv1alpha1.PackageStatus {
+	return gitopsv1alpha1.PackageStatus{
+		PackageID:        rs.Name,
+		SyncStatus:       rs.Status.SyncStatus,
+		Status:           status,
+		SyncCommit:       rs.Status.SyncCommit,
+		Health:           rs.Status.Health,
+		DriftedResources: len(rs.Status.DriftedResources),
+	}
+}
+
 type WaveTarget struct {
 	Wave    *gitopsv1alpha1.Wave
 	Targets *Targets
@@ -489,6 +489,73 @@ func TestReconcileRollout(t *testing.T) {
 		}, waveStatus)
 	})
 
+	t.Run("ScheduleFreeze", func(t *testing.T) {
+		// This tests that no RemoteSync is created during a freeze of the
+		// schedule.
+		rollout := &gitopsv1alpha1.Rollout{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Rollout",
+				APIVersion: "gitops.kpt.dev/v1alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "sample",
+			},
+
+			Spec: gitopsv1alpha1.RolloutSpec{
+				PackageToTargetMatcher: gitopsv1alpha1.PackageToClusterMatcher{
+					Type: "AllClusters",
+				},
+				Strategy: gitopsv1alpha1.RolloutStrategy{
+					Type: "AllAtOnce",
+				},
+				Targets: gitopsv1alpha1.ClusterTargetSelector{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
+				},
+				Schedule: &gitopsv1alpha1.RolloutSchedule{
+					Freezes: []gitopsv1alpha1.RolloutFreeze{
+						{Name: "holidays", Start: "2026-12-20T00:00", End: "2027-01-04T00:00"},
+					},
+				},
+			},
+		}
+
+		targetClusters := []clusterstore.Cluster{{
+			Ref:    gitopsv1alpha1.ClusterRef{Name: "foo/0"},
+			Labels: map[string]string{"foo": "bar"},
+		}}
+
+		fc := newFakeRemoteSyncClient()
+		reconciler := &RolloutReconciler{
+			Client: fc,
+			now: func() time.Time {
+				return time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC)
+			},
+		}
+
+		strategy, err := reconciler.getStrategy(context.Background(), rollout)
+		require.NoError(t, err)
+
+		_, waveStatus, err := reconciler.reconcileRollout(
+			context.Background(),
+			rollout,
+			strategy,
+			targetClusters,
+			[]packagediscovery.DiscoveredPackage{discoveredPackage},
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, 0, len(fc.remotesyncs))
+		require.Equal(t, "Waiting (Freeze)", waveStatus[0].ClusterStatuses[0].PackageStatus.Status)
+		require.Equal(t, &gitopsv1alpha1.ScheduleStatus{
+			Blocked:      true,
+			Reason:       "Freeze",
+			Freeze:       "holidays",
+			BlockedUntil: &metav1.Time{Time: time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)},
+		}, rollout.Status.Schedule)
+	})
+
 	t.Run("UpdateAndDeleteRemoteSyncs", func(t *testing.T) {
 		// This tests that if there are existing RemoteSyncs that need to be updated
 		// or deleted, reconcileRollout updates/deletes them as needed.
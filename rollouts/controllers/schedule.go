@@ -0,0 +1,140 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	// the controller image has no time zone database.
+	_ "time/tzdata"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gitopsv1alpha1 "github.com/GoogleContainerTools/kpt/rollouts/api/v1alpha1"
+)
+
+const (
+	windowTimeLayout = "15:04"
+	freezeTimeLayout = "2006-01-02T15:04"
+
+	reasonOutsideWindow = "OutsideWindow"
+	reasonFreeze        = "Freeze"
+)
+
+var weekdays = map[gitopsv1alpha1.Weekday]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// evaluateSchedule returns the state of the schedule at now. It returns nil
+// if there is no schedule.
+func evaluateSchedule(schedule *gitopsv1alpha1.RolloutSchedule, now time.Time) (*gitopsv1alpha1.ScheduleStatus, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+	loc := time.UTC
+	if schedule.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid schedule time zone %q: %w", schedule.TimeZone, err)
+		}
+	}
+	now = now.In(loc)
+
+	for _, freeze := range schedule.Freezes {
+		start, err := time.ParseInLocation(freezeTimeLayout, freeze.Start, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start %q of freeze %q: must be in YYYY-MM-DDTHH:MM format", freeze.Start, freeze.Name)
+		}
+		end, err := time.ParseInLocation(freezeTimeLayout, freeze.End, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end %q of freeze %q: must be in YYYY-MM-DDTHH:MM format", freeze.End, freeze.Name)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("freeze %q must end after it starts", freeze.Name)
+		}
+		if !now.Before(start) && now.Before(end) {
+			return &gitopsv1alpha1.ScheduleStatus{
+				Blocked:      true,
+				Reason:       reasonFreeze,
+				Freeze:       freeze.Name,
+				BlockedUntil: &metav1.Time{Time: end},
+			}, nil
+		}
+	}
+
+	if len(schedule.Windows) == 0 {
+		return &gitopsv1alpha1.ScheduleStatus{}, nil
+	}
+	var next time.Time
+	for _, window := range schedule.Windows {
+		start, err := time.Parse(windowTimeLayout, window.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window start %q: must be in HH:MM format", window.Start)
+		}
+		end, err := time.Parse(windowTimeLayout, window.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window end %q: must be in HH:MM format", window.End)
+		}
+		days := map[time.Weekday]bool{}
+		for _, day := range window.Days {
+			weekday, found := weekdays[day]
+			if !found {
+				return nil, fmt.Errorf("invalid window day %q: must be one of Mon, Tue, Wed, Thu, Fri, Sat or Sun", day)
+			}
+			days[weekday] = true
+		}
+
+		// the window may have started yesterday if it ends on the next day,
+		// and starts again within a week.
+		for i := -1; i <= 7; i++ {
+			day := time.Date(now.Year(), now.Month(), now.Day()+i, 0, 0, 0, 0, loc)
+			if len(days) > 0 && !days[day.Weekday()] {
+				continue
+			}
+			windowStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+			windowEnd := time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+			if !windowEnd.After(windowStart) {
+				windowEnd = time.Date(day.Year(), day.Month(), day.Day()+1, end.Hour(), end.Minute(), 0, 0, loc)
+			}
+			if !now.Before(windowStart) && now.Before(windowEnd) {
+				return &gitopsv1alpha1.ScheduleStatus{}, nil
+			}
+			if windowStart.After(now) && (next.IsZero() || windowStart.Before(next)) {
+				next = windowStart
+			}
+		}
+	}
+	return &gitopsv1alpha1.ScheduleStatus{
+		Blocked:      true,
+		Reason:       reasonOutsideWindow,
+		BlockedUntil: &metav1.Time{Time: next},
+	}, nil
+}
+
+// scheduleWaitingStatus returns the status of the clusters waiting for the
+// schedule to allow rolling out changes.
+func scheduleWaitingStatus(schedule *gitopsv1alpha1.ScheduleStatus) string {
+	if schedule.Reason == reasonFreeze {
+		return "Waiting (Freeze)"
+	}
+	return "Waiting (Outside Rollout Window)"
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	gitopsv1alpha1 "github.com/GoogleContainerTools/kpt/rollouts/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvaluateSchedule(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	blocked := func(reason, freeze string, until time.Time) *gitopsv1alpha1.ScheduleStatus {
+		return &gitopsv1alpha1.ScheduleStatus{
+			Blocked:      true,
+			Reason:       reason,
+			Freeze:       freeze,
+			BlockedUntil: &metav1.Time{Time: until},
+		}
+	}
+	weekdays := gitopsv1alpha1.RolloutWindow{
+		Days:  []gitopsv1alpha1.Weekday{"Mon", "Tue", "Wed", "Thu", "Fri"},
+		Start: "09:00",
+		End:   "17:00",
+	}
+	overnight := gitopsv1alpha1.RolloutWindow{Start: "22:00", End: "02:00"}
+	holidays := gitopsv1alpha1.RolloutFreeze{Name: "holidays", Start: "2026-12-20T00:00", End: "2027-01-04T00:00"}
+
+	// 2026-10-19 is a Monday.
+	testCases := map[string]struct {
+		schedule *gitopsv1alpha1.RolloutSchedule
+		now      time.Time
+
+		expected       *gitopsv1alpha1.ScheduleStatus
+		expectedErrMsg string
+	}{
+		"no schedule": {
+			now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		},
+		"no windows": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{},
+			now:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			expected: &gitopsv1alpha1.ScheduleStatus{},
+		},
+		"in window": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{Windows: []gitopsv1alpha1.RolloutWindow{weekdays}},
+			now:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			expected: &gitopsv1alpha1.ScheduleStatus{},
+		},
+		"after window": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{Windows: []gitopsv1alpha1.RolloutWindow{weekdays}},
+			now:      time.Date(2026, 10, 19, 17, 0, 0, 0, time.UTC),
+			expected: blocked("OutsideWindow", "", time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)),
+		},
+		"weekend": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{Windows: []gitopsv1alpha1.RolloutWindow{weekdays}},
+			now:      time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC),
+			expected: blocked("OutsideWindow", "", time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC)),
+		},
+		"overnight window started yesterday": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{Windows: []gitopsv1alpha1.RolloutWindow{overnight}},
+			now:      time.Date(2026, 10, 19, 1, 0, 0, 0, time.UTC),
+			expected: &gitopsv1alpha1.ScheduleStatus{},
+		},
+		"time zone": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{
+				TimeZone: "America/New_York",
+				Windows:  []gitopsv1alpha1.RolloutWindow{weekdays},
+			},
+			// 08:00 in New York.
+			now:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			expected: blocked("OutsideWindow", "", time.Date(2026, 10, 19, 9, 0, 0, 0, newYork)),
+		},
+		"freeze in window": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{
+				Windows: []gitopsv1alpha1.RolloutWindow{weekdays},
+				Freezes: []gitopsv1alpha1.RolloutFreeze{holidays},
+			},
+			now:      time.Date(2026, 12, 22, 12, 0, 0, 0, time.UTC),
+			expected: blocked("Freeze", "holidays", time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)),
+		},
+		"invalid time zone": {
+			schedule:       &gitopsv1alpha1.RolloutSchedule{TimeZone: "Mars/Olympus"},
+			expectedErrMsg: `invalid schedule time zone "Mars/Olympus": unknown time zone Mars/Olympus`,
+		},
+		"invalid window": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{Windows: []gitopsv1alpha1.RolloutWindow{
+				{Start: "9am", End: "17:00"},
+			}},
+			expectedErrMsg: `invalid window start "9am": must be in HH:MM format`,
+		},
+		"freeze ends before it starts": {
+			schedule: &gitopsv1alpha1.RolloutSchedule{Freezes: []gitopsv1alpha1.RolloutFreeze{
+				{Name: "holidays", Start: "2027-01-04T00:00", End: "2026-12-20T00:00"},
+			}},
+			expectedErrMsg: `freeze "holidays" must end after it starts`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			status, err := evaluateSchedule(tc.schedule, tc.now)
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expected == nil || tc.expected.BlockedUntil == nil {
+				require.Equal(t, tc.expected, status)
+				return
+			}
+			require.True(t, tc.expected.BlockedUntil.Equal(status.BlockedUntil), "blocked until %v", status.BlockedUntil)
+			status.BlockedUntil = tc.expected.BlockedUntil
+			require.Equal(t, tc.expected, status)
+		})
+	}
+}
@@ -17,26 +17,149 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	gitopsv1alpha1 "github.com/GoogleContainerTools/kpt/rollouts/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 )
 
-// checkSyncStatus fetches the external sync using the provided client and computes the sync status. The rules
+const (
+	healthHealthy     = "Healthy"
+	healthProgressing = "Progressing"
+	healthDegraded    = "Degraded"
+	healthUnknown     = "Unknown"
+)
+
+// resourceGroupGVR is the ResourceGroup used by Config Sync to track the inventory of
+// an external sync. It has the same name and namespace as the RootSync/RepoSync.
+var resourceGroupGVR = schema.GroupVersionResource{
+	Group:    "kpt.dev",
+	Version:  "v1alpha1",
+	Resource: "resourcegroups",
+}
+
+// syncState is the observed state of an external sync in a target cluster.
+type syncState struct {
+	// status is the sync status computed from the conditions of the external sync.
+	status string
+	// commit is the commit last synced by the external sync.
+	commit string
+	// drifted are the managed resources that no longer match the synced commit.
+	drifted []gitopsv1alpha1.DriftedResource
+}
+
+// health summarizes the sync status and drift into a single value.
+func (s *syncState) health() string {
+	switch s.status {
+	case "Synced":
+		if len(s.drifted) > 0 {
+			return healthDegraded
+		}
+		return healthHealthy
+	case "Pending", "Reconciling":
+		return healthProgressing
+	case "Stalled", "Error":
+		return healthDegraded
+	default:
+		return healthUnknown
+	}
+}
+
+// checkSyncStatus fetches the external sync using the provided client and computes its state. The rules
 // for computing status here mirrors the one used in the status command in the nomos cli.
-func checkSyncStatus(ctx context.Context, client dynamic.Interface, remotesync *gitopsv1alpha1.RemoteSync) (string, error) {
+// Drift is only checked once the external sync reports that it is synced.
+func checkSyncStatus(ctx context.Context, client dynamic.Interface, remotesync *gitopsv1alpha1.RemoteSync) (*syncState, error) {
 	gvr, gvk, err := getGvrAndGvk(remotesync.Spec.Type)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	rs, err := client.Resource(gvr).Namespace(getExternalSyncNamespace(remotesync)).Get(ctx, getExternalSyncName(remotesync), metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s: %w", gvk.Kind, err)
+		return nil, fmt.Errorf("failed to get %s: %w", gvk.Kind, err)
 	}
 
+	status, err := computeSyncStatus(rs, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, _, err := unstructured.NestedString(rs.Object, "status", "sync", "commit")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read synced commit from %s: %w", gvk.Kind, err)
+	}
+
+	state := &syncState{
+		status: status,
+		commit: commit,
+	}
+	if status != "Synced" {
+		return state, nil
+	}
+
+	state.drifted, err = checkDrift(ctx, client, rs, commit)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// checkDrift reads the ResourceGroup of the external sync and returns the managed
+// resources that have been deleted or broken out-of-band, or that were not updated
+// to the synced commit.
+func checkDrift(ctx context.Context, client dynamic.Interface, rs *unstructured.Unstructured, commit string) ([]gitopsv1alpha1.DriftedResource, error) {
+	rg, err := client.Resource(resourceGroupGVR).Namespace(rs.GetNamespace()).Get(ctx, rs.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Older versions of Config Sync don't maintain a ResourceGroup.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ResourceGroup: %w", err)
+	}
+
+	resourceStatuses, _, err := unstructured.NestedSlice(rg.Object, "status", "resourceStatuses")
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract resource statuses from ResourceGroup: %w", err)
+	}
+
+	var drifted []gitopsv1alpha1.DriftedResource
+	for i := range resourceStatuses {
+		res, ok := resourceStatuses[i].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("failed to extract resource status %d from slice", i)
+		}
+		status, _, _ := unstructured.NestedString(res, "status")
+		sourceHash, _, _ := unstructured.NestedString(res, "sourceHash")
+
+		switch {
+		case status == "NotFound", status == "Failed":
+		case sourceHash != "" && commit != "" && !strings.HasPrefix(commit, sourceHash):
+			status = "OutOfSync"
+		default:
+			continue
+		}
+
+		group, _, _ := unstructured.NestedString(res, "group")
+		kind, _, _ := unstructured.NestedString(res, "kind")
+		namespace, _, _ := unstructured.NestedString(res, "namespace")
+		name, _, _ := unstructured.NestedString(res, "name")
+		drifted = append(drifted, gitopsv1alpha1.DriftedResource{
+			Group:     group,
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      name,
+			Status:    status,
+		})
+	}
+	return drifted, nil
+}
+
+// computeSyncStatus computes the sync status from the generation and conditions of the external sync.
+func computeSyncStatus(rs *unstructured.Unstructured, gvk schema.GroupVersionKind) (string, error) {
 	// TODO: Change this to use the RootSync/RepoSync type instead of Unstructured.
 	generation, _, err := unstructured.NestedInt64(rs.Object, "metadata", "generation")
 	if err != nil {
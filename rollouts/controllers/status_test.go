@@ -0,0 +1,208 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gitopsv1alpha1 "github.com/GoogleContainerTools/kpt/rollouts/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const syncedRootSync = `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: my-rollout
+  namespace: config-management-system
+  generation: 2
+status:
+  observedGeneration: 2
+  conditions:
+  - type: Syncing
+    status: "False"
+  sync:
+    commit: 0123456789abcdef
+`
+
+const pendingRootSync = `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: my-rollout
+  namespace: config-management-system
+  generation: 3
+status:
+  observedGeneration: 2
+  sync:
+    commit: 0123456789abcdef
+`
+
+func toUnstructured(t *testing.T, s string) *unstructured.Unstructured {
+	data, err := yaml.YAMLToJSON([]byte(s))
+	require.NoError(t, err)
+	u := &unstructured.Unstructured{}
+	require.NoError(t, u.UnmarshalJSON(data))
+	return u
+}
+
+func resourceGroup(t *testing.T, resourceStatuses string) *unstructured.Unstructured {
+	return toUnstructured(t, fmt.Sprintf(`apiVersion: kpt.dev/v1alpha1
+kind: ResourceGroup
+metadata:
+  name: my-rollout
+  namespace: config-management-system
+status:
+  resourceStatuses:
+%s`, resourceStatuses))
+}
+
+func TestCheckSyncStatus(t *testing.T) {
+	remotesync := &gitopsv1alpha1.RemoteSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "gke-1-my-rollout"},
+		Spec: gitopsv1alpha1.RemoteSyncSpec{
+			ClusterRef: gitopsv1alpha1.ClusterRef{Name: "gke-1"},
+		},
+	}
+
+	testCases := map[string]struct {
+		objects  []runtime.Object
+		expected *syncState
+		health   string
+	}{
+		"synced without resource group": {
+			objects: []runtime.Object{toUnstructured(t, syncedRootSync)},
+			expected: &syncState{
+				status: "Synced",
+				commit: "0123456789abcdef",
+			},
+			health: healthHealthy,
+		},
+		"synced without drift": {
+			objects: []runtime.Object{
+				toUnstructured(t, syncedRootSync),
+				resourceGroup(t, `  - kind: ConfigMap
+    namespace: default
+    name: cm
+    status: Current
+    sourceHash: 0123456
+`),
+			},
+			expected: &syncState{
+				status: "Synced",
+				commit: "0123456789abcdef",
+			},
+			health: healthHealthy,
+		},
+		"synced with drift": {
+			objects: []runtime.Object{
+				toUnstructured(t, syncedRootSync),
+				resourceGroup(t, `  - kind: ConfigMap
+    namespace: default
+    name: cm
+    status: NotFound
+    sourceHash: 0123456
+  - group: apps
+    kind: Deployment
+    namespace: default
+    name: app
+    status: Current
+    sourceHash: fedcba9
+  - kind: Namespace
+    name: default
+    status: Current
+    sourceHash: 0123456
+`),
+			},
+			expected: &syncState{
+				status: "Synced",
+				commit: "0123456789abcdef",
+				drifted: []gitopsv1alpha1.DriftedResource{
+					{Kind: "ConfigMap", Namespace: "default", Name: "cm", Status: "NotFound"},
+					{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "app", Status: "OutOfSync"},
+				},
+			},
+			health: healthDegraded,
+		},
+		"drift is not checked while pending": {
+			objects: []runtime.Object{
+				toUnstructured(t, pendingRootSync),
+				resourceGroup(t, `  - kind: ConfigMap
+    namespace: default
+    name: cm
+    status: NotFound
+`),
+			},
+			expected: &syncState{
+				status: "Pending",
+				commit: "0123456789abcdef",
+			},
+			health: healthProgressing,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+				rootSyncGVR:      "RootSyncList",
+				resourceGroupGVR: "ResourceGroupList",
+			}, tc.objects...)
+
+			state, err := checkSyncStatus(context.Background(), client, remotesync)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, state)
+			require.Equal(t, tc.health, state.health())
+		})
+	}
+}
+
+func TestSetSyncStatus(t *testing.T) {
+	rs := &gitopsv1alpha1.RemoteSync{}
+
+	setSyncStatus(rs, &syncState{
+		status:  "Synced",
+		commit:  "abc",
+		drifted: []gitopsv1alpha1.DriftedResource{{Kind: "ConfigMap", Name: "cm", Status: "NotFound"}},
+	}, nil)
+	require.Equal(t, healthDegraded, rs.Status.Health)
+	require.Equal(t, "abc", rs.Status.SyncCommit)
+	require.True(t, meta.IsStatusConditionTrue(rs.Status.Conditions, conditionDrifted))
+	require.True(t, meta.IsStatusConditionFalse(rs.Status.Conditions, conditionHealthy))
+
+	setSyncStatus(rs, &syncState{status: "Pending"}, nil)
+	require.Equal(t, healthProgressing, rs.Status.Health)
+	require.Equal(t, "abc", rs.Status.SyncCommit)
+	require.Empty(t, rs.Status.DriftedResources)
+	require.Nil(t, meta.FindStatusCondition(rs.Status.Conditions, conditionDrifted))
+
+	setSyncStatus(rs, &syncState{status: "Synced", commit: "def"}, nil)
+	require.Equal(t, healthHealthy, rs.Status.Health)
+	require.Equal(t, "def", rs.Status.SyncCommit)
+	require.True(t, meta.IsStatusConditionFalse(rs.Status.Conditions, conditionDrifted))
+	require.True(t, meta.IsStatusConditionTrue(rs.Status.Conditions, conditionHealthy))
+
+	setSyncStatus(rs, nil, fmt.Errorf("cluster unreachable"))
+	require.Equal(t, "Unknown", rs.Status.SyncStatus)
+	require.Equal(t, healthUnknown, rs.Status.Health)
+	require.True(t, meta.IsStatusConditionTrue(rs.Status.Conditions, conditionStalled))
+}
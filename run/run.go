@@ -27,6 +27,7 @@ import (
 	kptcommands "github.com/GoogleContainerTools/kpt/commands"
 	"github.com/GoogleContainerTools/kpt/internal/docs/generated/overview"
 	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
 	"github.com/GoogleContainerTools/kpt/pkg/printer"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/kustomize/kyaml/commandutil"
@@ -96,6 +97,10 @@ func GetMain(ctx context.Context) *cobra.Command {
 	// enable stack traces
 	cmd.PersistentFlags().BoolVar(&cmdutil.StackOnError, "stack-trace", false,
 		"Print a stack-trace on failure")
+	cmd.PersistentFlags().Var((*errorFormatValue)(&cmdutil.ErrorFormat), "error-format",
+		"The format errors are printed in. Must be either text or json.")
+	cmd.PersistentFlags().BoolVar(&offline.Enabled, "offline", false,
+		"Fail instead of accessing the network, e.g. to pull function images or fetch upstream repositories.")
 
 	if _, err := exec.LookPath("git"); err != nil {
 		fmt.Fprintf(os.Stderr, "kpt requires that `git` is installed and on the PATH")
@@ -204,3 +209,24 @@ func hideFlags(cmd *cobra.Command) {
 		hideFlags(child)
 	}
 }
+
+// errorFormatValue is the value of the --error-format flag. Unlike a plain
+// string flag, it rejects unsupported formats.
+type errorFormatValue string
+
+func (v *errorFormatValue) String() string {
+	return string(*v)
+}
+
+func (v *errorFormatValue) Set(s string) error {
+	switch s {
+	case cmdutil.TextErrorFormat, cmdutil.JSONErrorFormat:
+		*v = errorFormatValue(s)
+		return nil
+	}
+	return fmt.Errorf("must be one of %s, %s", cmdutil.TextErrorFormat, cmdutil.JSONErrorFormat)
+}
+
+func (v *errorFormatValue) Type() string {
+	return "string"
+}
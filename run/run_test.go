@@ -0,0 +1,76 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/kpt/internal/errors"
+	"github.com/GoogleContainerTools/kpt/internal/testutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/cmdutil"
+	"github.com/GoogleContainerTools/kpt/internal/util/offline"
+	"github.com/GoogleContainerTools/kpt/pkg/printer/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOffline_pkgUpdate verifies that the global --offline flag isn't
+// shadowed by a flag of kpt pkg update, so the update fails fast instead
+// of fetching the upstream.
+func TestOffline_pkgUpdate(t *testing.T) {
+	defer func() { offline.Enabled = false }()
+
+	dir := t.TempDir()
+	defer testutil.Chdir(t, dir)()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "my-pkg"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "my-pkg", "Kptfile"), []byte(`
+apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: my-pkg
+upstream:
+  type: git
+  git:
+    repo: https://github.com/kptdev/kpt
+    directory: /package-examples/wordpress
+    ref: main
+`), 0600))
+
+	cmd := GetMain(fake.CtxWithDefaultPrinter())
+	cmd.SetArgs([]string{"--offline", "pkg", "update", "my-pkg"})
+	err := cmd.Execute()
+	var offlineErr *offline.Error
+	if assert.True(t, errors.As(err, &offlineErr), "unexpected error: %v", err) {
+		assert.Contains(t, offlineErr.Operation, "https://github.com/kptdev/kpt")
+	}
+}
+
+func TestErrorFormat(t *testing.T) {
+	defer func() { cmdutil.ErrorFormat = cmdutil.TextErrorFormat }()
+
+	cmd := GetMain(fake.CtxWithDefaultPrinter())
+	cmd.SetArgs([]string{"--error-format", "yaml", "version"})
+	err := cmd.Execute()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `invalid argument "yaml" for "--error-format" flag: must be one of text, json`)
+	}
+
+	cmd = GetMain(fake.CtxWithDefaultPrinter())
+	cmd.SetArgs([]string{"--error-format", "json", "version"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, cmdutil.JSONErrorFormat, cmdutil.ErrorFormat)
+}
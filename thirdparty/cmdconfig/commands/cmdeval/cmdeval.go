@@ -67,10 +67,15 @@ func GetEvalFnRunner(ctx context.Context, parent string) *EvalFnRunner {
 	r.Command.Flags().BoolVarP(
 		&r.SaveFn, "save", "s", false,
 		"save the function and its arguments to Kptfile")
+	r.Command.Flags().BoolVar(
+		&r.SaveOverride, "override", false,
+		"with --save, save the function to `upstream.overrides` in the Kptfile, to re-apply it after `kpt pkg update`")
 	r.Command.Flags().StringVar(
 		&r.Exec, "exec", "", "run an executable as a function")
 	r.Command.Flags().StringVar(
 		&r.FnConfigPath, "fn-config", "", "path to the function config file")
+	r.Command.Flags().StringVar(
+		&r.ChainPath, "chain", "", "path to a file with a list of functions to run in sequence")
 	r.Command.Flags().BoolVarP(
 		&r.IncludeMetaResources, "include-meta-resources", "m", false, "include package meta resources in function input")
 	r.Command.Flags().StringVar(
@@ -142,10 +147,12 @@ type EvalFnRunner struct {
 	FromStdin            bool
 	Image                string
 	SaveFn               bool
+	SaveOverride         bool
 	Keywords             []string
 	FnType               string
 	Exec                 string
 	FnConfigPath         string
+	ChainPath            string
 	ResultsDir           string
 	Network              bool
 	Mounts               []string
@@ -263,15 +270,26 @@ func (r *EvalFnRunner) SaveFnToKptfile() {
 		return
 	}
 
-	if kf.Pipeline == nil {
-		kf.Pipeline = &kptfile.Pipeline{}
-	}
 	var usrMsg string
-	switch r.FnType {
-	case "mutator":
-		kf.Pipeline.Mutators, usrMsg = r.updateFnList(kf.Pipeline.Mutators)
-	case "validator":
-		kf.Pipeline.Validators, usrMsg = r.updateFnList(kf.Pipeline.Validators)
+	if r.SaveOverride {
+		// Overrides are re-applied by `kpt pkg update`, so they are only
+		// useful for packages with an upstream.
+		if kf.Upstream == nil {
+			pr.Printf("function not added: package has no upstream\n")
+			return
+		}
+		r.FnType = "override"
+		kf.Upstream.Overrides, usrMsg = r.updateFnList(kf.Upstream.Overrides)
+	} else {
+		if kf.Pipeline == nil {
+			kf.Pipeline = &kptfile.Pipeline{}
+		}
+		switch r.FnType {
+		case "mutator":
+			kf.Pipeline.Mutators, usrMsg = r.updateFnList(kf.Pipeline.Mutators)
+		case "validator":
+			kf.Pipeline.Validators, usrMsg = r.updateFnList(kf.Pipeline.Validators)
+		}
 	}
 
 	mutatedKfAsYNode, err := r.preserveCommentsAndFieldOrder(kf)
@@ -411,6 +429,82 @@ func (r *EvalFnRunner) getFunctionSpec() (*runtimeutil.FunctionSpec, []string, e
 	return fn, execArgs, nil
 }
 
+// getChain reads the functions of the --chain file. The function config
+// paths in the file are relative to the directory of the file.
+func (r *EvalFnRunner) getChain(ctx context.Context) ([]runfn.ChainedFunction, error) {
+	b, err := os.ReadFile(r.ChainPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read function chain file: %w", err)
+	}
+	var fns []kptfile.Function
+	if err := yaml.Unmarshal(b, &fns); err != nil {
+		return nil, fmt.Errorf("invalid function chain file %q: %w", r.ChainPath, err)
+	}
+	if len(fns) == 0 {
+		return nil, fmt.Errorf("function chain file %q has no functions", r.ChainPath)
+	}
+
+	var chain []runfn.ChainedFunction
+	for i, f := range fns {
+		cf, err := r.getChainedFunction(ctx, f)
+		if err != nil {
+			return nil, fmt.Errorf("function %d of chain file %q: %w", i, r.ChainPath, err)
+		}
+		chain = append(chain, cf)
+	}
+	return chain, nil
+}
+
+func (r *EvalFnRunner) getChainedFunction(ctx context.Context, f kptfile.Function) (runfn.ChainedFunction, error) {
+	cf := runfn.ChainedFunction{Function: &runtimeutil.FunctionSpec{}}
+	switch {
+	case f.Image == "" && f.Exec == "":
+		return cf, fmt.Errorf("must specify `image` or `exec`")
+	case f.Image != "" && f.Exec != "":
+		return cf, fmt.Errorf("must not specify both `image` and `exec`")
+	case len(f.Selectors) != 0 || len(f.Exclusions) != 0 || f.Network != nil || len(f.Mounts) != 0:
+		return cf, fmt.Errorf("`selectors`, `exclude`, `network` and `mounts` are not supported, use the command line flags instead")
+	case len(f.ConfigMap) != 0 && f.ConfigPath != "":
+		return cf, fmt.Errorf("must not specify both `configMap` and `configPath`")
+	}
+
+	if f.Image != "" {
+		img, err := r.RunnerOptions.ResolveToImage(ctx, f.Image)
+		if err != nil {
+			return cf, err
+		}
+		if err := kptfile.ValidateFunctionImageURL(img); err != nil {
+			return cf, err
+		}
+		cf.Function.Container.Image = img
+	} else {
+		s, err := shlex.Split(f.Exec)
+		if err != nil {
+			return cf, fmt.Errorf("exec command %q must be valid: %w", f.Exec, err)
+		}
+		if len(s) > 0 {
+			cf.Function.Exec.Path = s[0]
+			cf.ExecArgs = s[1:]
+		}
+		cf.OriginalExec = f.Exec
+	}
+
+	switch {
+	case f.ConfigPath != "":
+		cf.FnConfigPath = filepath.Join(filepath.Dir(r.ChainPath), filepath.FromSlash(f.ConfigPath))
+		if err := checkFnConfigPathExistence(cf.FnConfigPath); err != nil {
+			return cf, err
+		}
+	case len(f.ConfigMap) != 0:
+		fnConfig, err := fnruntime.NewConfigMap(f.ConfigMap)
+		if err != nil {
+			return cf, err
+		}
+		cf.FnConfig = fnConfig
+	}
+	return cf, nil
+}
+
 func toStorageMounts(mounts []string) []runtimeutil.StorageMount {
 	var sms []runtimeutil.StorageMount
 	for _, mount := range mounts {
@@ -433,8 +527,24 @@ func (r *EvalFnRunner) validateOptionalFlags() error {
 	if r.IncludeMetaResources {
 		return fmt.Errorf("--include-meta-resources is no longer necessary because meta resources are now included by default")
 	}
+	if r.ChainPath != "" {
+		if r.Image != "" || r.Exec != "" || r.FnConfigPath != "" {
+			return fmt.Errorf("--chain can't be used with --image, --exec or --fn-config")
+		}
+		if r.SaveFn {
+			return fmt.Errorf("--save can't be used with --chain")
+		}
+	}
+	if r.SaveOverride {
+		if !r.SaveFn {
+			return fmt.Errorf("--override must be used with --save")
+		}
+		if r.Image == "" {
+			return fmt.Errorf("--image must be specified if saving functions as override (--override=true)")
+		}
+	}
 	// SaveFn stores function to Kptfile. If not enabled, only make in-place changes.
-	if r.SaveFn {
+	if r.SaveFn && !r.SaveOverride {
 		if r.FnType == "" {
 			return fmt.Errorf("--type must be specified if saving functions to Kptfile (--save=true)")
 		}
@@ -464,8 +574,8 @@ func (r *EvalFnRunner) preRunE(c *cobra.Command, args []string) error {
 			return err
 		}
 	}
-	if r.Image == "" && r.Exec == "" {
-		return errors.Errorf("must specify --image or --exec")
+	if r.Image == "" && r.Exec == "" && r.ChainPath == "" {
+		return errors.Errorf("must specify --image, --exec or --chain")
 	}
 	var dataItems []string
 	if c.ArgsLenAtDash() >= 0 {
@@ -482,12 +592,22 @@ func (r *EvalFnRunner) preRunE(c *cobra.Command, args []string) error {
 	if len(dataItems) > 0 && r.FnConfigPath != "" {
 		return fmt.Errorf("function arguments can only be specified without function config file")
 	}
+	if len(dataItems) > 0 && r.ChainPath != "" {
+		return fmt.Errorf("function arguments can't be used with --chain")
+	}
 	fnConfig, err := r.getCLIFunctionConfig(c.Context(), dataItems)
 	if err != nil {
 		return err
 	}
 	r.dataItems = dataItems
-	fnSpec, execArgs, err := r.getFunctionSpec()
+	var fnSpec *runtimeutil.FunctionSpec
+	var execArgs []string
+	var chain []runfn.ChainedFunction
+	if r.ChainPath != "" {
+		chain, err = r.getChain(c.Context())
+	} else {
+		fnSpec, execArgs, err = r.getFunctionSpec()
+	}
 	if err != nil {
 		return err
 	}
@@ -560,6 +680,7 @@ func (r *EvalFnRunner) preRunE(c *cobra.Command, args []string) error {
 		Selector:              r.Selector,
 		Exclusion:             r.Exclusion,
 		RunnerOptions:         r.RunnerOptions,
+		Chain:                 chain,
 	}
 
 	return nil
@@ -33,6 +33,22 @@ func TestRunFnCommand_preRunE(t *testing.T) {
 	}()
 	defer testutil.Chdir(t, filepath.Dir(tempDir))()
 	dir := filepath.Base(tempDir)
+	chainPath := filepath.Join(dir, "chain.yaml")
+	if !assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "chain.yaml"), []byte(`
+- image: set-namespace:v0.4
+  configPath: fn-config.yaml
+- exec: execPath arg1
+`), 0600)) {
+		t.FailNow()
+	}
+	if !assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "fn-config.yaml"), []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: fn-config
+`), 0600)) {
+		t.FailNow()
+	}
 
 	tests := []struct {
 		name             string
@@ -41,6 +57,7 @@ func TestRunFnCommand_preRunE(t *testing.T) {
 		expectedFn       *runtimeutil.FunctionSpec
 		expectedStruct   *runfn.RunFns
 		expectedExecArgs []string
+		expectedChain    []runfn.ChainedFunction
 		err              string
 		path             string
 		input            io.Reader
@@ -306,6 +323,16 @@ kind: ConfigMap
 apiVersion: v1
 `,
 		},
+		{
+			name: "--override without --save",
+			args: []string{"eval", dir, "--image", "foo:bar", "--override"},
+			err:  "--override must be used with --save",
+		},
+		{
+			name: "--override with --exec",
+			args: []string{"eval", dir, "--exec", "./fn", "--save", "--override"},
+			err:  "--image must be specified if saving functions as override",
+		},
 		{
 			name: "--fn-config flag",
 			args: []string{"eval", dir, "--fn-config", "a/b/c", "--image", "foo:bar"},
@@ -334,6 +361,50 @@ kind: ConfigMap
 apiVersion: v1
 `,
 		},
+		{
+			name: "chain",
+			args: []string{"eval", dir, "--chain", chainPath},
+			path: dir,
+			expectedChain: []runfn.ChainedFunction{
+				{
+					Function: &runtimeutil.FunctionSpec{
+						Container: runtimeutil.ContainerSpec{
+							Image: "gcr.io/kpt-fn/set-namespace:v0.4",
+						},
+					},
+					FnConfigPath: filepath.Join(dir, "fn-config.yaml"),
+				},
+				{
+					Function: &runtimeutil.FunctionSpec{
+						Exec: runtimeutil.ExecSpec{
+							Path: "execPath",
+						},
+					},
+					ExecArgs:     []string{"arg1"},
+					OriginalExec: "execPath arg1",
+				},
+			},
+		},
+		{
+			name: "chain with image",
+			args: []string{"eval", dir, "--chain", chainPath, "--image", "foo:bar"},
+			err:  "--chain can't be used with --image, --exec or --fn-config",
+		},
+		{
+			name: "chain with save",
+			args: []string{"eval", dir, "--chain", chainPath, "--save", "--type", "mutator"},
+			err:  "--save can't be used with --chain",
+		},
+		{
+			name: "chain with function arguments",
+			args: []string{"eval", dir, "--chain", chainPath, "--", "a=b"},
+			err:  "function arguments can't be used with --chain",
+		},
+		{
+			name: "missing chain file",
+			args: []string{"eval", dir, "--chain", "a/b/c"},
+			err:  "cannot read function chain file",
+		},
 	}
 
 	for i := range tests {
@@ -428,10 +499,15 @@ apiVersion: v1
 				}
 			}
 
+			if !assert.Equal(t, tt.expectedChain, r.runFns.Chain) {
+				t.FailNow()
+			}
+
 			if tt.expectedStruct != nil {
 				r.runFns.Function = nil
 				r.runFns.FnConfig = nil
 				r.runFns.RunnerOptions.ResolveToImage = nil
+				r.runFns.RunnerOptions.ResolveComposite = nil
 				tt.expectedStruct.FnConfigPath = tt.fnConfigPath
 				if !assert.Equal(t, *tt.expectedStruct, r.runFns) {
 					t.FailNow()
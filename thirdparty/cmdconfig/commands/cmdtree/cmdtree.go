@@ -15,6 +15,7 @@ import (
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/filters"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
 func GetTreeRunner(ctx context.Context, name string) *TreeRunner {
@@ -29,6 +30,8 @@ func GetTreeRunner(ctx context.Context, name string) *TreeRunner {
 		RunE:    r.runE,
 		Args:    cobra.MaximumNArgs(1),
 	}
+	c.Flags().BoolVar(&r.hideGenerated, "hide-generated", false,
+		"hide the resources generated by functions, which have the `kpt.dev/generated-by` annotation.")
 
 	r.Command = c
 	return r
@@ -42,6 +45,8 @@ func NewCommand(ctx context.Context, name string) *cobra.Command {
 type TreeRunner struct {
 	Command *cobra.Command
 	Ctx     context.Context
+
+	hideGenerated bool
 }
 
 func (r *TreeRunner) runE(c *cobra.Command, args []string) error {
@@ -64,6 +69,17 @@ func (r *TreeRunner) runE(c *cobra.Command, args []string) error {
 	fltrs := []kio.Filter{&filters.IsLocalConfig{
 		IncludeLocalConfig: true,
 	}}
+	if r.hideGenerated {
+		fltrs = append(fltrs, kio.FilterFunc(func(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+			var authored []*yaml.RNode
+			for _, n := range nodes {
+				if !kptfilev1.IsGenerated(n) {
+					authored = append(authored, n)
+				}
+			}
+			return authored, nil
+		}))
+	}
 
 	return runner.HandleError(r.Ctx, kio.Pipeline{
 		Inputs:  []kio.Reader{input},
@@ -480,3 +480,32 @@ spec:
 	}
 	assert.Contains(t, stderr.String(), "please note that the symlinks within the package are ignored")
 }
+
+func TestTreeCommand_hideGenerated(t *testing.T) {
+	d := t.TempDir()
+	err := os.WriteFile(filepath.Join(d, "f1.yaml"), []byte(`kind: Deployment
+metadata:
+  name: foo
+---
+kind: ConfigMap
+metadata:
+  name: foo-config
+  annotations:
+    kpt.dev/generated-by: gcr.io/example/generator:v1
+`), 0600)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b := &bytes.Buffer{}
+	r := GetTreeRunner(fake.CtxWithPrinter(b, nil), "")
+	r.Command.SetArgs([]string{d, "--hide-generated"})
+	r.Command.SetOut(b)
+	if !assert.NoError(t, r.Command.Execute()) {
+		return
+	}
+
+	assert.Equal(t, fmt.Sprintf(`%s
+└── [f1.yaml]  Deployment foo
+`, filepath.Base(d)), b.String())
+}
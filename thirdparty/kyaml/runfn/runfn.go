@@ -96,6 +96,29 @@ type RunFns struct {
 	Selector kptfile.Selector
 
 	Exclusion kptfile.Selector
+
+	// Chain contains the functions to run after Function, in order. Each
+	// function gets the output of the previous one as input, and the
+	// resources are read and written only once for the whole chain.
+	Chain []ChainedFunction
+}
+
+// ChainedFunction is a function run by RunFns as part of its Chain.
+type ChainedFunction struct {
+	// Function is the function to run.
+	Function *runtimeutil.FunctionSpec
+
+	// FnConfig is the function config, if it is not read from FnConfigPath.
+	FnConfig *yaml.RNode
+
+	// FnConfigPath is the path to the file containing the function config.
+	FnConfigPath string
+
+	// ExecArgs are the arguments for exec commands
+	ExecArgs []string
+
+	// OriginalExec is the original exec commands
+	OriginalExec string
 }
 
 // Execute runs the command
@@ -149,22 +172,48 @@ func (r RunFns) getNodesAndFilters() (
 }
 
 func (r RunFns) getFilters() ([]kio.Filter, error) {
-	spec := r.Function
-	if spec == nil {
-		return nil, nil
+	var fltrs []kio.Filter
+	for _, step := range r.steps() {
+		spec := step.Function
+		// merge envs from imperative and declarative
+		spec.Container.Env = step.mergeContainerEnv(spec.Container.Env)
+
+		c, err := step.filterProvider()(*spec, step.FnConfig, user.Current)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			fltrs = append(fltrs, c)
+		}
 	}
-	// merge envs from imperative and declarative
-	spec.Container.Env = r.mergeContainerEnv(spec.Container.Env)
+	return fltrs, nil
+}
 
-	c, err := r.functionFilterProvider(*spec, r.FnConfig, user.Current)
-	if err != nil {
-		return nil, err
+// steps returns a RunFns for Function and for each function of the Chain,
+// in the order they must be run.
+func (r RunFns) steps() []RunFns {
+	var steps []RunFns
+	if r.Function != nil {
+		steps = append(steps, r)
 	}
+	for _, f := range r.Chain {
+		step := r
+		step.Function = f.Function
+		step.FnConfig = f.FnConfig
+		step.FnConfigPath = f.FnConfigPath
+		step.ExecArgs = f.ExecArgs
+		step.OriginalExec = f.OriginalExec
+		steps = append(steps, step)
+	}
+	return steps
+}
 
-	if c == nil {
-		return nil, nil
+// filterProvider returns the provider of the filter that runs r.Function.
+func (r RunFns) filterProvider() func(runtimeutil.FunctionSpec, *yaml.RNode, currentUserFunc) (kio.Filter, error) {
+	if r.functionFilterProvider != nil {
+		return r.functionFilterProvider
 	}
-	return []kio.Filter{c}, nil
+	return r.defaultFnFilterProvider
 }
 
 // runFunctions runs the fltrs against the input and writes to either r.Output or output
@@ -289,24 +338,33 @@ func (r *RunFns) init() error {
 
 	r.fnResults = fnresult.NewResultList()
 
-	// functionFilterProvider set the filter provider
-	if r.functionFilterProvider == nil {
-		r.functionFilterProvider = r.defaultFnFilterProvider
+	// fn config paths should be absolute
+	var err error
+	if r.FnConfigPath, err = absFnConfigPath(r.FnConfigPath); err != nil {
+		return err
 	}
-
-	// fn config path should be absolute
-	if r.FnConfigPath != "" && !filepath.IsAbs(r.FnConfigPath) {
-		// if the FnConfigPath is relative, we should use the
-		// current directory to construct full path.
-		path, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get working directory: %w", err)
+	for i := range r.Chain {
+		if r.Chain[i].FnConfigPath, err = absFnConfigPath(r.Chain[i].FnConfigPath); err != nil {
+			return err
 		}
-		r.FnConfigPath = filepath.Join(path, r.FnConfigPath)
 	}
 	return nil
 }
 
+// absFnConfigPath returns the absolute version of the fn config path p.
+func absFnConfigPath(p string) (string, error) {
+	if p == "" || filepath.IsAbs(p) {
+		return p, nil
+	}
+	// if the FnConfigPath is relative, we should use the
+	// current directory to construct full path.
+	path, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return filepath.Join(path, p), nil
+}
+
 type currentUserFunc func() (*user.User, error)
 
 // getUIDGID will return "nobody" if asCurrentUser is false. Otherwise
@@ -317,6 +317,45 @@ func TestCmd_Execute_setFnConfigPath(t *testing.T) {
 	assert.Contains(t, string(b), "kind: ReplicaSet")
 }
 
+// TestCmd_Execute_chain tests the execution of a chain of filters, each
+// getting the output of the previous one
+func TestCmd_Execute_chain(t *testing.T) {
+	dir := setupTest(t)
+	defer os.RemoveAll(dir)
+
+	fn := &runtimeutil.FunctionSpec{
+		Container: runtimeutil.ContainerSpec{
+			Image: "gcr.io/example.com/image:version",
+		},
+	}
+	instance := RunFns{
+		Ctx:                    fake.CtxWithDefaultPrinter(),
+		Path:                   dir,
+		functionFilterProvider: getFilterProvider(t),
+		fnResults:              fnresult.NewResultList(),
+		Chain: []ChainedFunction{
+			{Function: fn, FnConfig: yaml.MustParse(ValueReplacerYAMLData)},
+			{Function: fn, FnConfig: yaml.MustParse(`apiVersion: v1
+kind: ValueReplacer
+stringMatch: StatefulSet
+replace: ReplicaSet
+`)},
+		},
+	}
+	// initialize the defaults
+	assert.NoError(t, instance.init())
+
+	if !assert.NoError(t, instance.Execute()) {
+		return
+	}
+	b, err := os.ReadFile(
+		filepath.Join(dir, "java", "java-deployment.resource.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Contains(t, string(b), "kind: ReplicaSet")
+}
+
 // TestCmd_Execute_setOutput tests the execution of a filter using an io.Writer as output
 func TestCmd_Execute_setOutput(t *testing.T) {
 	dir := setupTest(t)